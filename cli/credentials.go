@@ -0,0 +1,77 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/util/keychain"
+)
+
+// Kinds of credential that can be stored in the keychain.
+const (
+	CredentialPassphrase = "passphrase"
+	CredentialPrivateKey = "privatekey"
+)
+
+// CredentialKey returns the keychain key for a kind of credential for an address.
+func CredentialKey(address common.Address, kind string) string {
+	return fmt.Sprintf("%s/%s", address.Hex(), kind)
+}
+
+// LoadCredentials ensures that credentials are available to sign for the given address.
+// If none have been supplied they are obtained from the keychain, or by prompting for the
+// passphrase of a local account if running in a terminal.
+func LoadCredentials(chainID *big.Int, address common.Address) error {
+	if credentialsSupplied() {
+		return nil
+	}
+
+	// Errors from the keychain are ignored, as it may not be available.
+	if privateKey, err := keychain.Get(CredentialKey(address, CredentialPrivateKey)); err == nil {
+		viper.Set("privatekey", privateKey)
+		return nil
+	}
+	if passphrase, err := keychain.Get(CredentialKey(address, CredentialPassphrase)); err == nil {
+		viper.Set("passphrase", passphrase)
+		return nil
+	}
+
+	if !IsTerminal() {
+		return nil
+	}
+	if _, err := ObtainWallet(chainID, address); err != nil {
+		// Not a local account, so no passphrase to prompt for.
+		return nil
+	}
+	passphrase, err := PromptSecret(fmt.Sprintf("Passphrase for %s: ", address.Hex()))
+	if err != nil {
+		return err
+	}
+	viper.Set("passphrase", passphrase)
+	return nil
+}
+
+// credentialsSupplied returns true if any credentials for signing have been supplied.
+func credentialsSupplied() bool {
+	for _, key := range []string{"passphrase", "privatekey", "mnemonic", "external-signer"} {
+		if viper.GetString(key) != "" {
+			return true
+		}
+	}
+	return viper.GetBool("ledger") || viper.GetBool("trezor")
+}
@@ -0,0 +1,53 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+// ExternalSigner returns the external signer requested for signing, or an empty
+// string if no external signer is requested.
+func ExternalSigner() string {
+	return viper.GetString("external-signer")
+}
+
+// ObtainExternalSignerAccount connects to an external signer and obtains the account
+// for the given address.  The signer is of the form clef://endpoint, where endpoint is
+// the path to the signer's IPC socket or the URL of its HTTP or websocket interface.
+func ObtainExternalSignerAccount(signer string, address common.Address) (accounts.Wallet, *accounts.Account, error) {
+	if !strings.HasPrefix(signer, "clef://") {
+		return nil, nil, fmt.Errorf("unsupported external signer %s; must start with clef://", signer)
+	}
+	endpoint := strings.TrimPrefix(signer, "clef://")
+	if endpoint == "" {
+		return nil, nil, fmt.Errorf("external signer %s does not have an endpoint", signer)
+	}
+
+	wallet, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to external signer at %s: %v", endpoint, err)
+	}
+	account := accounts.Account{Address: address}
+	if !wallet.Contains(account) {
+		return nil, nil, fmt.Errorf("external signer at %s does not manage %s", endpoint, address.Hex())
+	}
+	return wallet, &account, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/spf13/viper"
+)
+
+// HardwareWalletType returns the type of hardware wallet requested for signing, or
+// an empty string if no hardware wallet is requested.
+func HardwareWalletType() (string, error) {
+	ledger := viper.GetBool("ledger")
+	trezor := viper.GetBool("trezor")
+	switch {
+	case ledger && trezor:
+		return "", errors.New("cannot use both ledger and trezor")
+	case ledger:
+		return "ledger", nil
+	case trezor:
+		return "trezor", nil
+	default:
+		return "", nil
+	}
+}
+
+// ObtainHardwareWalletAccount opens the first connected hardware wallet of the given
+// type and derives the account at the given path.
+func ObtainHardwareWalletAccount(walletType string, path string) (accounts.Wallet, *accounts.Account, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid derivation path %s: %v", path, err)
+	}
+
+	var hubs []*usbwallet.Hub
+	switch walletType {
+	case "ledger":
+		hub, err := usbwallet.NewLedgerHub()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to access ledger devices: %v", err)
+		}
+		hubs = append(hubs, hub)
+	case "trezor":
+		hub, err := usbwallet.NewTrezorHubWithHID()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to access trezor devices: %v", err)
+		}
+		hubs = append(hubs, hub)
+		hub, err = usbwallet.NewTrezorHubWithWebUSB()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to access trezor devices: %v", err)
+		}
+		hubs = append(hubs, hub)
+	default:
+		return nil, nil, fmt.Errorf("unsupported hardware wallet %s", walletType)
+	}
+
+	var wallet accounts.Wallet
+	for _, hub := range hubs {
+		if wallets := hub.Wallets(); len(wallets) > 0 {
+			wallet = wallets[0]
+			break
+		}
+	}
+	if wallet == nil {
+		return nil, nil, fmt.Errorf("no %s device found", walletType)
+	}
+
+	if err := openHardwareWallet(wallet); err != nil {
+		return nil, nil, err
+	}
+
+	account, err := wallet.Derive(derivationPath, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive account at %s: %v", path, err)
+	}
+	if !viper.GetBool("quiet") {
+		fmt.Fprintf(os.Stderr, "Please confirm the transaction on your %s\n", walletType)
+	}
+	return wallet, &account, nil
+}
+
+// openHardwareWallet opens a hardware wallet, prompting for a PIN or passphrase if required.
+func openHardwareWallet(wallet accounts.Wallet) error {
+	err := wallet.Open("")
+	if err == usbwallet.ErrTrezorPINNeeded {
+		pin, err := promptHardwareWallet("Enter PIN using the layout shown on the device (7 8 9 / 4 5 6 / 1 2 3): ")
+		if err != nil {
+			return err
+		}
+		err = wallet.Open(pin)
+		if err == nil {
+			return nil
+		}
+		if err != usbwallet.ErrTrezorPassphraseNeeded {
+			return fmt.Errorf("failed to open device: %v", err)
+		}
+		passphrase, err := promptHardwareWallet("Enter passphrase for the device: ")
+		if err != nil {
+			return err
+		}
+		err = wallet.Open(passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to open device: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open device: %v", err)
+	}
+	return nil
+}
+
+// promptHardwareWallet prompts for and reads a line of input.
+func promptHardwareWallet(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %v", err)
+	}
+	return strings.TrimSpace(input), nil
+}
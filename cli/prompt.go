@@ -0,0 +1,55 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IsTerminal returns true if standard input is a terminal.
+func IsTerminal() bool {
+	return isTerminal(int(os.Stdin.Fd()))
+}
+
+// PromptSecret prompts for a secret, hiding the input if standard input is a terminal.
+func PromptSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if !IsTerminal() {
+		// Not a terminal, so read the secret as a line of input.
+		input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && input == "" {
+			return "", fmt.Errorf("failed to read input: %v", err)
+		}
+		return strings.TrimRight(input, "\r\n"), nil
+	}
+	secret, err := readHidden(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %v", err)
+	}
+	return strings.TrimRight(secret, "\r\n"), nil
+}
+
+// Prompt prompts for a line of input.
+func Prompt(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("failed to read input: %v", err)
+	}
+	return strings.TrimSpace(input), nil
+}
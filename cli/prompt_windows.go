@@ -0,0 +1,41 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func isTerminal(fd int) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
+
+// readHidden reads a line from the console with echo disabled.
+func readHidden(fd int) (string, error) {
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return "", err
+	}
+	hidden := mode&^windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT | windows.ENABLE_LINE_INPUT
+	if err := windows.SetConsoleMode(windows.Handle(fd), hidden); err != nil {
+		return "", err
+	}
+	defer windows.SetConsoleMode(windows.Handle(fd), mode) // nolint:errcheck
+
+	return bufio.NewReader(os.NewFile(uintptr(fd), "stdin")).ReadString('\n')
+}
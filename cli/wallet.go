@@ -82,21 +82,33 @@ func ObtainWallet(chainID *big.Int, address common.Address) (accounts.Wallet, er
 	return wallet, fmt.Errorf("failed to obtain wallet for %s", address.Hex())
 }
 
-func obtainGethWallet(chainID *big.Int, address common.Address) (accounts.Wallet, error) {
+// Chain IDs of retired test networks, whose configurations are no longer provided by go-ethereum.
+var (
+	ropstenChainID = big.NewInt(3)
+	rinkebyChainID = big.NewInt(4)
+	goerliChainID  = big.NewInt(5)
+)
+
+// GethKeystoreDir returns the directory of the geth keystore for a given chain
+func GethKeystoreDir(chainID *big.Int) string {
 	keydir := DefaultDataDir()
 	switch {
 	case chainID.Cmp(params.MainnetChainConfig.ChainID) == 0:
 		// Nothing to add for mainnet
-	case chainID.Cmp(params.RopstenChainConfig.ChainID) == 0:
+	case chainID.Cmp(ropstenChainID) == 0:
 		keydir = filepath.Join(keydir, "testnet")
-	case chainID.Cmp(params.RinkebyChainConfig.ChainID) == 0:
+	case chainID.Cmp(rinkebyChainID) == 0:
 		keydir = filepath.Join(keydir, "rinkeby")
-	case chainID.Cmp(params.GoerliChainConfig.ChainID) == 0:
+	case chainID.Cmp(goerliChainID) == 0:
 		keydir = filepath.Join(keydir, "goerli")
 	case chainID.Cmp(params.SepoliaChainConfig.ChainID) == 0:
 		keydir = filepath.Join(keydir, "sepolia")
 	}
-	keydir = filepath.Join(keydir, "keystore")
+	return filepath.Join(keydir, "keystore")
+}
+
+func obtainGethWallet(chainID *big.Int, address common.Address) (accounts.Wallet, error) {
+	keydir := GethKeystoreDir(chainID)
 	backends := []accounts.Backend{keystore.NewKeyStore(keydir, keystore.StandardScryptN, keystore.StandardScryptP)}
 	accountManager := accounts.NewManager(nil, backends...)
 	defer accountManager.Close()
@@ -106,20 +118,7 @@ func obtainGethWallet(chainID *big.Int, address common.Address) (accounts.Wallet
 }
 
 func obtainGethWallets(chainID *big.Int) ([]accounts.Wallet, error) {
-	keydir := DefaultDataDir()
-	switch {
-	case chainID.Cmp(params.MainnetChainConfig.ChainID) == 0:
-		// Nothing to add for mainnet
-	case chainID.Cmp(params.RopstenChainConfig.ChainID) == 0:
-		keydir = filepath.Join(keydir, "testnet")
-	case chainID.Cmp(params.RinkebyChainConfig.ChainID) == 0:
-		keydir = filepath.Join(keydir, "rinkeby")
-	case chainID.Cmp(params.GoerliChainConfig.ChainID) == 0:
-		keydir = filepath.Join(keydir, "goerli")
-	case chainID.Cmp(params.SepoliaChainConfig.ChainID) == 0:
-		keydir = filepath.Join(keydir, "sepolia")
-	}
-	keydir = filepath.Join(keydir, "keystore")
+	keydir := GethKeystoreDir(chainID)
 	backends := []accounts.Backend{keystore.NewKeyStore(keydir, keystore.StandardScryptN, keystore.StandardScryptP)}
 	accountManager := accounts.NewManager(nil, backends...)
 	defer accountManager.Close()
@@ -144,7 +143,7 @@ func obtainParityWallet(chainID *big.Int, address common.Address) (accounts.Wall
 
 	if chainID.Cmp(params.MainnetChainConfig.ChainID) == 0 {
 		keydir = filepath.Join(keydir, "ethereum")
-	} else if chainID.Cmp(params.RopstenChainConfig.ChainID) == 0 {
+	} else if chainID.Cmp(ropstenChainID) == 0 {
 		keydir = filepath.Join(keydir, "test")
 	}
 
@@ -174,7 +173,7 @@ func obtainParityWallets(chainID *big.Int) ([]accounts.Wallet, error) {
 
 	if chainID.Cmp(params.MainnetChainConfig.ChainID) == 0 {
 		keydir = filepath.Join(keydir, "ethereum")
-	} else if chainID.Cmp(params.RopstenChainConfig.ChainID) == 0 {
+	} else if chainID.Cmp(ropstenChainID) == 0 {
 		keydir = filepath.Join(keydir, "test")
 	}
 
@@ -0,0 +1,287 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/bundler"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var aaBundlerURL string
+var aaEntryPointStr string
+var aaSenderStr string
+var aaOwnerStr string
+var aaToStr string
+var aaAmountStr string
+var aaDataStr string
+var aaCallDataStr string
+var aaFactoryStr string
+var aaFactoryDataStr string
+var aaNonceKeyStr string
+
+// aaAccountABI is the ABI of the parts of the accounts and EntryPoint used to build user operations.
+const aaAccountABI = `[{"inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"name":"execute","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"sender","type":"address"},{"name":"key","type":"uint192"}],"name":"getNonce","outputs":[{"name":"nonce","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// aaDummySignature is a signature of the correct form for an ECDSA-owned account, used when
+// estimating gas prior to signing.
+var aaDummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+// aaCmd represents the aa command
+var aaCmd = &cobra.Command{
+	Use:   "aa",
+	Short: "Build and submit ERC-4337 user operations",
+	Long: `Build, sign and submit ERC-4337 user operations for smart accounts through a bundler, and track their status.
+
+The bundler is supplied with --bundler, or with bundler-url in the configuration file.  Calls are made through the execute(address,uint256,bytes) function of the account, as used by SimpleAccount and compatible accounts; other accounts can supply their own call data with --calldata.`,
+}
+
+func init() {
+	RootCmd.AddCommand(aaCmd)
+}
+
+// aaFlags adds the flags to connect to a bundler.
+func aaFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&aaBundlerURL, "bundler", "", "URL of the bundler (default bundler-url from the configuration file)")
+	cmd.Flags().StringVar(&aaEntryPointStr, "entrypoint", util.EntryPointV07.Hex(), "Address of the EntryPoint contract")
+}
+
+// aaUserOperationFlags adds the flags that define a user operation.
+func aaUserOperationFlags(cmd *cobra.Command) {
+	aaFlags(cmd)
+	cmd.Flags().StringVar(&aaSenderStr, "sender", "", "Address of the smart account sending the user operation")
+	cmd.Flags().StringVar(&aaOwnerStr, "owner", "", "Address of the owner of the smart account that signs the user operation")
+	cmd.Flags().StringVar(&aaToStr, "to", "", "Address to which the account sends the call")
+	cmd.Flags().StringVar(&aaAmountStr, "amount", "0", "Amount of Ether the account sends with the call")
+	cmd.Flags().StringVar(&aaDataStr, "data", "", "Data the account sends with the call, as hex")
+	cmd.Flags().StringVar(&aaCallDataStr, "calldata", "", "Call data for the account, as hex, in place of --to, --amount and --data")
+	cmd.Flags().StringVar(&aaFactoryStr, "factory", "", "Address of the factory that deploys the account, if it is not yet deployed")
+	cmd.Flags().StringVar(&aaFactoryDataStr, "factory-data", "", "Data for the factory, as hex")
+	cmd.Flags().StringVar(&aaNonceKeyStr, "nonce-key", "0", "Key of the account nonce to use")
+	cmd.Flags().String("max-fee-per-gas", "", "Maximum fee per gas for the user operation (default twice the current base fee plus the priority fee)")
+	cmd.Flags().String("max-priority-fee-per-gas", "", "Maximum priority fee per gas for the user operation (default suggested from recent fee history)")
+	cmd.Flags().String("passphrase", "", "passphrase for the owner")
+	cmd.Flags().String("privatekey", "", "private key for the owner")
+	cmd.Flags().String("mnemonic", "", "BIP-39 mnemonic for the owner")
+	cmd.Flags().String("hd-path", util.DefaultHDPath, "derivation path for the key when using a mnemonic")
+}
+
+// aaEntryPoint returns the EntryPoint supplied with --entrypoint.
+func aaEntryPoint() common.Address {
+	entryPoint, err := c.Resolve(aaEntryPointStr)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve EntryPoint address %s", aaEntryPointStr))
+	return entryPoint
+}
+
+// aaBundlerClient returns a client for the bundler, checking that it supports the EntryPoint.
+func aaBundlerClient(ctx context.Context, entryPoint common.Address) *bundler.Client {
+	url := aaBundlerURL
+	if url == "" {
+		url = viper.GetString("bundler-url")
+	}
+	cli.Assert(url != "", quiet, "--bundler is required")
+	client, err := bundler.New(ctx, url, entryPoint)
+	cli.ErrCheck(err, quiet, "Failed to connect to bundler")
+
+	entryPoints, err := client.SupportedEntryPoints(ctx)
+	cli.ErrCheck(err, quiet, "Failed to obtain EntryPoints supported by bundler")
+	supported := false
+	for _, supportedEntryPoint := range entryPoints {
+		if supportedEntryPoint == entryPoint {
+			supported = true
+			break
+		}
+	}
+	cli.Assert(supported, quiet, fmt.Sprintf("Bundler does not support EntryPoint %s", entryPoint.Hex()))
+	return client
+}
+
+// buildUserOperation builds a user operation from the flags, estimates its gas with the
+// bundler and signs it with the key of the owner.
+func buildUserOperation(ctx context.Context, client *bundler.Client, entryPoint common.Address) *util.UserOperation {
+	accountABI, err := abi.JSON(strings.NewReader(aaAccountABI))
+	cli.ErrCheck(err, quiet, "Failed to generate account ABI")
+
+	cli.Assert(aaSenderStr != "", quiet, "--sender is required")
+	sender, err := c.Resolve(aaSenderStr)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve sender address %s", aaSenderStr))
+	cli.Assert(aaOwnerStr != "", quiet, "--owner is required")
+	owner, err := c.Resolve(aaOwnerStr)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve owner address %s", aaOwnerStr))
+
+	op := &util.UserOperation{
+		Sender: sender,
+	}
+
+	// Call data.
+	if aaCallDataStr != "" {
+		cli.Assert(aaToStr == "" && aaDataStr == "", quiet, "--calldata cannot be used with --to or --data")
+		cli.Assert(strings.HasPrefix(aaCallDataStr, "0x"), quiet, "--calldata must be hex")
+		op.CallData = common.FromHex(aaCallDataStr)
+	} else {
+		cli.Assert(aaToStr != "", quiet, "--to or --calldata is required")
+		to, err := c.Resolve(aaToStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve address %s", aaToStr))
+		value, err := string2eth.StringToWei(aaAmountStr)
+		cli.ErrCheck(err, quiet, "Invalid amount")
+		data := []byte{}
+		if aaDataStr != "" {
+			cli.Assert(strings.HasPrefix(aaDataStr, "0x"), quiet, "--data must be hex")
+			data = common.FromHex(aaDataStr)
+		}
+		op.CallData, err = accountABI.Pack("execute", to, value, data)
+		cli.ErrCheck(err, quiet, "Failed to create call data")
+	}
+
+	// Factory, if the account is not yet deployed.
+	code, err := c.Client().CodeAt(ctx, sender, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain code of account")
+	if aaFactoryStr != "" {
+		cli.Assert(len(code) == 0, quiet, "Account is already deployed; --factory is not required")
+		factory, err := c.Resolve(aaFactoryStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve factory address %s", aaFactoryStr))
+		op.Factory = &factory
+		op.FactoryData = common.FromHex(aaFactoryDataStr)
+	} else {
+		cli.Assert(len(code) > 0, quiet, "Account is not deployed; --factory and --factory-data are required")
+	}
+
+	// Nonce, from the EntryPoint.
+	key, success := new(big.Int).SetString(aaNonceKeyStr, 10)
+	cli.Assert(success, quiet, "Invalid nonce key")
+	callData, err := accountABI.Pack("getNonce", sender, key)
+	cli.ErrCheck(err, quiet, "Failed to create nonce call")
+	result, err := c.Client().CallContract(ctx, ethereum.CallMsg{To: &entryPoint, Data: callData}, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain nonce from EntryPoint")
+	nonce, err := accountABI.Unpack("getNonce", result)
+	cli.ErrCheck(err, quiet, "Failed to decode nonce from EntryPoint")
+	op.Nonce = nonce[0].(*big.Int)
+
+	// Fees.
+	op.MaxFeePerGas, op.MaxPriorityFeePerGas, err = calculateFees()
+	cli.ErrCheck(err, quiet, "Failed to calculate fees")
+
+	// Gas, estimated with a dummy signature.
+	op.CallGasLimit = big.NewInt(0)
+	op.VerificationGasLimit = big.NewInt(0)
+	op.PreVerificationGas = big.NewInt(0)
+	op.Signature = aaDummySignature
+	estimate, err := client.EstimateUserOperationGas(ctx, op)
+	cli.ErrCheck(err, quiet, "Failed to estimate gas for user operation")
+	op.CallGasLimit = estimate.CallGasLimit
+	op.VerificationGasLimit = estimate.VerificationGasLimit
+	op.PreVerificationGas = estimate.PreVerificationGas
+	outputIf(debug, fmt.Sprintf("Estimated gas is %v call, %v verification, %v pre-verification", op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas))
+
+	// Signature, over the personal message of the hash.
+	privateKey, err := localSigningKey(owner)
+	cli.ErrCheck(err, quiet, "Failed to obtain key for owner")
+	hash := op.Hash(entryPoint, c.ChainID())
+	op.Signature, err = crypto.Sign(accounts.TextHash(hash.Bytes()), privateKey)
+	cli.ErrCheck(err, quiet, "Failed to sign user operation")
+	op.Signature[crypto.RecoveryIDOffset] += 27
+
+	return op
+}
+
+// userOperationCost returns the maximum cost of a user operation.
+func userOperationCost(op *util.UserOperation) *big.Int {
+	gas := new(big.Int).Add(op.CallGasLimit, op.VerificationGasLimit)
+	gas = gas.Add(gas, op.PreVerificationGas)
+	return gas.Mul(gas, op.MaxFeePerGas)
+}
+
+// aaUserOperationJSON is the JSON output for a user operation.
+type aaUserOperationJSON struct {
+	Hash          string                 `json:"user_op_hash"`
+	EntryPoint    string                 `json:"entry_point"`
+	UserOperation map[string]interface{} `json:"user_operation"`
+}
+
+// aaReceiptJSON is the JSON output for the status of a user operation.
+type aaReceiptJSON struct {
+	Hash            string `json:"user_op_hash"`
+	Status          string `json:"status"`
+	TransactionHash string `json:"transaction_hash,omitempty"`
+	BlockNumber     uint64 `json:"block_number,omitempty"`
+	ActualGasUsed   string `json:"actual_gas_used,omitempty"`
+	ActualGasCost   string `json:"actual_gas_cost,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+const (
+	userOperationUnknown   = "unknown"
+	userOperationPending   = "pending"
+	userOperationSucceeded = "succeeded"
+	userOperationFailed    = "failed"
+)
+
+// userOperationStatus returns the status of a user operation given its receipt, if any.
+func userOperationStatus(receipt *bundler.Receipt, known bool) string {
+	switch {
+	case receipt == nil && known:
+		return userOperationPending
+	case receipt == nil:
+		return userOperationUnknown
+	case receipt.Success:
+		return userOperationSucceeded
+	default:
+		return userOperationFailed
+	}
+}
+
+func newAAReceiptJSON(hash common.Hash, receipt *bundler.Receipt, known bool) *aaReceiptJSON {
+	res := &aaReceiptJSON{
+		Hash:   hash.Hex(),
+		Status: userOperationStatus(receipt, known),
+	}
+	if receipt != nil {
+		res.TransactionHash = receipt.TransactionHash.Hex()
+		res.BlockNumber = receipt.BlockNumber
+		res.ActualGasUsed = receipt.ActualGasUsed.String()
+		res.ActualGasCost = receipt.ActualGasCost.String()
+		res.Reason = receipt.Reason
+	}
+	return res
+}
+
+// aaReceiptString returns a text description of the status of a user operation.
+func aaReceiptString(hash common.Hash, receipt *bundler.Receipt, known bool) string {
+	builder := new(strings.Builder)
+	builder.WriteString(fmt.Sprintf("User operation:\t%s\n", hash.Hex()))
+	builder.WriteString(fmt.Sprintf("Status:\t\t%s\n", userOperationStatus(receipt, known)))
+	if receipt != nil {
+		builder.WriteString(fmt.Sprintf("Transaction:\t%s\n", receipt.TransactionHash.Hex()))
+		builder.WriteString(fmt.Sprintf("Block:\t\t%d\n", receipt.BlockNumber))
+		builder.WriteString(fmt.Sprintf("Gas used:\t%v\n", receipt.ActualGasUsed))
+		builder.WriteString(fmt.Sprintf("Cost:\t\t%s\n", formatWei(receipt.ActualGasCost)))
+		if !receipt.Success && receipt.Reason != "" && receipt.Reason != "0x" {
+			builder.WriteString(fmt.Sprintf("Reason:\t\t%s\n", receipt.Reason))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// aaBuildCmd represents the aa build command
+var aaBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build and sign a user operation without submitting it",
+	Long: `Build a user operation for a smart account, estimate its gas with the bundler and sign it, without submitting it.  For example:
+
+    ethereal aa build --bundler=https://bundler.example.com/ --sender=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --owner=0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF --to=0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf --amount=0.01ether --passphrase=secret
+
+The signed user operation is output as JSON, in the form used by the bundler API.
+
+In quiet mode this will return 0 if the user operation is built, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot build user operations when offline")
+		ctx, cancel := localContext()
+		defer cancel()
+
+		entryPoint := aaEntryPoint()
+		client := aaBundlerClient(ctx, entryPoint)
+		op := buildUserOperation(ctx, client, entryPoint)
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		hash := op.Hash(entryPoint, c.ChainID())
+		outputIf(verbose, fmt.Sprintf("Maximum cost is %s", formatWei(userOperationCost(op))))
+		outputJSON(&aaUserOperationJSON{
+			Hash:          hash.Hex(),
+			EntryPoint:    entryPoint.Hex(),
+			UserOperation: op.RPC(entryPoint),
+		})
+	},
+}
+
+func init() {
+	aaCmd.AddCommand(aaBuildCmd)
+	aaUserOperationFlags(aaBuildCmd)
+}
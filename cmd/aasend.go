@@ -0,0 +1,93 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/bundler"
+)
+
+// aaSendCmd represents the aa send command
+var aaSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send a user operation through a bundler",
+	Long: `Build a user operation for a smart account, estimate its gas with the bundler, sign it and submit it to the bundler.  For example:
+
+    ethereal aa send --bundler=https://bundler.example.com/ --sender=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --owner=0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF --to=0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf --amount=0.01ether --passphrase=secret
+
+The hash of the user operation is output, which can be used with "ethereal aa status" to track it.
+
+This will return an exit status of 0 if the user operation is successfully submitted (and included and successful if --wait is supplied), 1 if the user operation is not successfully submitted or fails, and 2 if the user operation is successfully submitted but not included within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot send user operations when offline")
+		if dryRun {
+			// The user operation is built, which simulates it with the bundler, rather than sent.
+			aaBuildCmd.Run(cmd, args)
+		}
+		ctx, cancel := localContext()
+		defer cancel()
+
+		entryPoint := aaEntryPoint()
+		client := aaBundlerClient(ctx, entryPoint)
+		op := buildUserOperation(ctx, client, entryPoint)
+		outputIf(verbose, fmt.Sprintf("Maximum cost is %s", formatWei(userOperationCost(op))))
+
+		hash, err := client.SendUserOperation(ctx, op)
+		cli.ErrCheck(err, quiet, "Failed to send user operation")
+
+		if !viper.GetBool("wait") {
+			if !quiet {
+				fmt.Printf("%s\n", hash.Hex())
+			}
+			os.Exit(exitSuccess)
+		}
+
+		outputIf(verbose, fmt.Sprintf("Waiting for user operation %s to be included", hash.Hex()))
+		receipt, err := client.WaitForUserOperation(context.Background(), hash, viper.GetDuration("limit"))
+		if err == bundler.ErrNotIncluded {
+			outputIf(!quiet, fmt.Sprintf("%s submitted but not included within %v", hash.Hex(), viper.GetDuration("limit")))
+			os.Exit(exitNotMined)
+		}
+		cli.ErrCheck(err, quiet, "Failed to wait for user operation")
+
+		if quiet {
+			if receipt.Success {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+		if jsonOutput() {
+			writeJSON(newAAReceiptJSON(hash, receipt, true))
+		} else {
+			fmt.Print(aaReceiptString(hash, receipt, true))
+		}
+		if receipt.Success {
+			os.Exit(exitSuccess)
+		}
+		os.Exit(exitFailure)
+	},
+}
+
+func init() {
+	aaCmd.AddCommand(aaSendCmd)
+	aaUserOperationFlags(aaSendCmd)
+	aaSendCmd.Flags().Bool("wait", false, "wait for the user operation to be included before returning")
+	aaSendCmd.Flags().Duration("limit", 0, "maximum time to wait for the user operation to be included before failing (default forever)")
+}
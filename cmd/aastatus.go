@@ -0,0 +1,83 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var aaStatusHashStr string
+
+// aaStatusCmd represents the aa status command
+var aaStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Obtain the status of a user operation",
+	Long: `Obtain the status of a user operation from the bundler.  For example:
+
+    ethereal aa status --bundler=https://bundler.example.com/ --hash=0x1a4b4b5e3c9f4a3e2d1c0b9a8f7e6d5c4b3a29180f7e6d5c4b3a29180f7e6d5c
+
+The status is one of:
+
+  - unknown: the bundler does not know of the user operation
+  - pending: the user operation is known to the bundler but has not been included
+  - succeeded: the user operation has been included and its call succeeded
+  - failed: the user operation has been included but its call failed
+
+In quiet mode this will return 0 if the user operation has succeeded, 2 if it is pending, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain user operation status when offline")
+		cli.Assert(aaStatusHashStr != "", quiet, "--hash is required")
+		ctx, cancel := localContext()
+		defer cancel()
+
+		client := aaBundlerClient(ctx, aaEntryPoint())
+		hash := common.HexToHash(aaStatusHashStr)
+		receipt, err := client.UserOperationReceipt(ctx, hash)
+		cli.ErrCheck(err, quiet, "Failed to obtain user operation receipt")
+		known := receipt != nil
+		if receipt == nil {
+			known, err = client.UserOperationKnown(ctx, hash)
+			cli.ErrCheck(err, quiet, "Failed to obtain user operation")
+		}
+
+		if quiet {
+			switch userOperationStatus(receipt, known) {
+			case userOperationSucceeded:
+				os.Exit(exitSuccess)
+			case userOperationPending:
+				os.Exit(exitNotMined)
+			default:
+				os.Exit(exitFailure)
+			}
+		}
+
+		if jsonOutput() {
+			outputJSON(newAAReceiptJSON(hash, receipt, known))
+		}
+
+		fmt.Print(aaReceiptString(hash, receipt, known))
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	aaCmd.AddCommand(aaStatusCmd)
+	aaFlags(aaStatusCmd)
+	aaStatusCmd.Flags().StringVar(&aaStatusHashStr, "hash", "", "Hash of the user operation")
+}
@@ -14,7 +14,10 @@
 package cmd
 
 import (
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
 )
 
 // accountCmd represents the account command
@@ -22,9 +25,24 @@ var accountCmd = &cobra.Command{
 	Use:     "account",
 	Aliases: []string{"acc"},
 	Short:   "Manage accounts",
-	Long:    `Obtain information about Ethereum accounts.`,
+	Long:    `Create, import, export and obtain information about Ethereum accounts.`,
 }
 
 func init() {
 	RootCmd.AddCommand(accountCmd)
 }
+
+// accountKeystore returns the local keystore for the current chain, using
+// the supplied scrypt parameters for any keys that it encrypts.
+func accountKeystore(scryptN int, scryptP int) *keystore.KeyStore {
+	return keystore.NewKeyStore(cli.GethKeystoreDir(c.ChainID()), scryptN, scryptP)
+}
+
+// accountOrDefault returns the supplied account, or the default account from
+// the configuration if no account is supplied.
+func accountOrDefault(input string) string {
+	if input != "" {
+		return input
+	}
+	return viper.GetString("default-account")
+}
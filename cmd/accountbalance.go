@@ -0,0 +1,135 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var accountBalanceAddresses []string
+var accountBalanceBlock string
+var accountBalanceCompareBlock string
+
+// accountBalanceCmd represents the account balance command
+var accountBalanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Obtain the balances of accounts",
+	Long: `Obtain the Ether balances of one or more accounts.  For example:
+
+    ethereal account balance --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4,0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF --block=-100
+
+Balances are obtained at the latest block unless --block is supplied.  If --compare-block is supplied the change in balance since that block is also shown.  Balances are shown in the unit selected with --unit.
+
+In quiet mode this will return 0 if the balances can be obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(len(accountBalanceAddresses) > 0, quiet, "--address is required")
+		addresses := make([]common.Address, len(accountBalanceAddresses))
+		for i, input := range accountBalanceAddresses {
+			address, err := c.Resolve(strings.TrimSpace(input))
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", input))
+			addresses[i] = address
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		blockNumber, err := parseBlockNumber(ctx, accountBalanceBlock)
+		cli.ErrCheck(err, quiet, "Failed to parse block")
+		balances, err := c.Balances(ctx, addresses, blockNumber)
+		cli.Assert(err == nil || !strings.Contains(err.Error(), "missing trie node"), quiet, "Connection does not have information on that block, please change the connection parameter to point to a full synced node")
+		cli.ErrCheck(err, quiet, "Failed to obtain balances")
+
+		var deltas []*big.Int
+		if accountBalanceCompareBlock != "" {
+			compareBlockNumber, err := parseBlockNumber(ctx, accountBalanceCompareBlock)
+			cli.ErrCheck(err, quiet, "Failed to parse comparison block")
+			compareBalances, err := c.Balances(ctx, addresses, compareBlockNumber)
+			cli.Assert(err == nil || !strings.Contains(err.Error(), "missing trie node"), quiet, "Connection does not have information on that block, please change the connection parameter to point to a full synced node")
+			cli.ErrCheck(err, quiet, "Failed to obtain balances at comparison block")
+			deltas = make([]*big.Int, len(balances))
+			for i := range balances {
+				deltas[i] = new(big.Int).Sub(balances[i], compareBalances[i])
+			}
+		}
+
+		var price *fiatPrice
+		if !quiet {
+			price = etherPrice()
+		}
+
+		if jsonOutput() {
+			res := make([]*accountBalanceJSON, len(addresses))
+			for i := range addresses {
+				res[i] = &accountBalanceJSON{
+					Address: addresses[i].Hex(),
+					Balance: balances[i].String(),
+				}
+				if deltas != nil {
+					res[i].Delta = deltas[i].String()
+				}
+				if price != nil {
+					res[i].Currency = price.currency
+					res[i].FiatBalance = price.value(balances[i])
+				}
+			}
+			outputJSON(res)
+		}
+
+		if !quiet {
+			for i := range addresses {
+				line := fmt.Sprintf("%s\t%s", addresses[i].Hex(), formatWeiFiat(balances[i], price))
+				if deltas != nil {
+					line = fmt.Sprintf("%s\t%s", line, accountBalanceFormatDelta(deltas[i]))
+				}
+				fmt.Println(line)
+			}
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// accountBalanceJSON is the JSON output for the balance of an account.
+type accountBalanceJSON struct {
+	Address     string `json:"address"`
+	Balance     string `json:"balance"`
+	Delta       string `json:"delta,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	FiatBalance string `json:"fiat_balance,omitempty"`
+}
+
+// accountBalanceFormatDelta formats a change in balance with its sign.
+func accountBalanceFormatDelta(delta *big.Int) string {
+	switch delta.Sign() {
+	case -1:
+		return fmt.Sprintf("-%s", formatWei(new(big.Int).Neg(delta)))
+	case 1:
+		return fmt.Sprintf("+%s", formatWei(delta))
+	default:
+		return formatWei(delta)
+	}
+}
+
+func init() {
+	accountCmd.AddCommand(accountBalanceCmd)
+	accountBalanceCmd.Flags().StringSliceVar(&accountBalanceAddresses, "address", nil, "Addresses of the accounts for which to obtain balances (may be comma-separated or supplied multiple times)")
+	accountBalanceCmd.Flags().StringVar(&accountBalanceBlock, "block", "latest", "block hash, number or offset at which to obtain balances")
+	accountBalanceCmd.Flags().StringVar(&accountBalanceCompareBlock, "compare-block", "", "block hash, number or offset against which to show the change in balances")
+}
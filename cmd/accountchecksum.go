@@ -55,9 +55,15 @@ In quiet mode this will return 0 if the provided address is correctly checksumme
 			if accountChecksumAddress != checksummedAddress {
 				cli.Err(quiet, "checksum is incorrect")
 			}
+			if jsonOutput() {
+				outputJSON(map[string]interface{}{"address": checksummedAddress, "valid": true})
+			}
 			outputIf(!quiet, "Checksum is correct")
 			os.Exit(exitSuccess)
 		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": checksummedAddress})
+		}
 		fmt.Printf("%s\n", checksummedAddress)
 		os.Exit(exitSuccess)
 	},
@@ -0,0 +1,74 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var accountDefaultAddress string
+
+// accountDefaultCmd represents the account default command
+var accountDefaultCmd = &cobra.Command{
+	Use:   "default",
+	Short: "Show or set the default account",
+	Long: `Show or set the default account from which to send transactions when --from is not supplied.  For example to set the default account:
+
+    ethereal account default --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+The default account is stored as 'default-account' in the configuration file.  Only this entry is changed; the rest of the file is left as-is.  If --address is not supplied the current default account is shown.
+
+In quiet mode this will return 0 if a default account is set, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if accountDefaultAddress == "" {
+			defaultAccount := viper.GetString("default-account")
+			cli.Assert(defaultAccount != "", quiet, "No default account set")
+			if quiet {
+				os.Exit(exitSuccess)
+			}
+			if jsonOutput() {
+				outputJSON(map[string]interface{}{"address": defaultAccount})
+			}
+			fmt.Println(defaultAccount)
+			os.Exit(exitSuccess)
+		}
+
+		address, err := c.Resolve(accountDefaultAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain address")
+
+		path := viper.ConfigFileUsed()
+		if path == "" {
+			home, err := homedir.Dir()
+			cli.ErrCheck(err, quiet, "Failed to obtain home directory")
+			path = filepath.Join(home, ".ethereal.yaml")
+		}
+
+		cli.ErrCheck(util.SetConfigValue(path, "default-account", address.Hex()), quiet, fmt.Sprintf("Failed to write configuration file %s", path))
+		outputIf(verbose, fmt.Sprintf("Default account %s written to %s", address.Hex(), path))
+	},
+}
+
+func init() {
+	offlineCmds["account:default"] = true
+	accountCmd.AddCommand(accountDefaultCmd)
+	accountDefaultCmd.Flags().StringVar(&accountDefaultAddress, "address", "", "address of the new default account")
+}
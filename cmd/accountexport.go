@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var accountExportAddress string
+var accountExportPassphrase string
+var accountExportNewPassphrase string
+var accountExportFile string
+
+// accountExportCmd represents the account export command
+var accountExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export an account",
+	Long: `Export an account from the local keystore as a keystore file.  For example:
+
+    ethereal account export --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret --file=account.json
+
+The exported keystore is encrypted with --new-passphrase, which defaults to the passphrase.  If --file is not supplied the keystore is printed.
+
+In quiet mode this will return 0 if the account was exported, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountExportAddress != "", quiet, "--address is required")
+		cli.Assert(accountExportPassphrase != "", quiet, "--passphrase is required")
+		address, err := c.Resolve(accountExportAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain address")
+
+		ks := accountKeystore(keystore.StandardScryptN, keystore.StandardScryptP)
+		cli.Assert(ks.HasAddress(address), quiet, fmt.Sprintf("Account %s not found in local keystore", address.Hex()))
+		account, err := ks.Find(accounts.Account{Address: address})
+		cli.ErrCheck(err, quiet, "Failed to obtain account")
+
+		newPassphrase := accountExportNewPassphrase
+		if newPassphrase == "" {
+			newPassphrase = accountExportPassphrase
+		}
+		data, err := ks.Export(account, accountExportPassphrase, newPassphrase)
+		cli.ErrCheck(err, quiet, "Failed to export account")
+
+		if accountExportFile != "" {
+			cli.ErrCheck(ioutil.WriteFile(accountExportFile, data, 0600), quiet, "Failed to write keystore")
+			outputIf(verbose, fmt.Sprintf("Keystore written to %s", accountExportFile))
+			os.Exit(exitSuccess)
+		}
+		if !quiet {
+			fmt.Println(string(data))
+		}
+	},
+}
+
+func init() {
+	offlineCmds["account:export"] = true
+	accountCmd.AddCommand(accountExportCmd)
+	accountExportCmd.Flags().StringVar(&accountExportAddress, "address", "", "address of the account to export")
+	accountExportCmd.Flags().StringVar(&accountExportPassphrase, "passphrase", "", "passphrase for the account")
+	accountExportCmd.Flags().StringVar(&accountExportNewPassphrase, "new-passphrase", "", "passphrase with which to encrypt the exported keystore (default the passphrase)")
+	accountExportCmd.Flags().StringVar(&accountExportFile, "file", "", "file to which to write the exported keystore")
+}
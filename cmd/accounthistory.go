@@ -0,0 +1,197 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+)
+
+var accountHistoryAddress string
+var accountHistoryInternal bool
+var accountHistoryFromBlock string
+var accountHistoryToBlock string
+var accountHistoryCount int
+
+// accountHistoryCmd represents the account history command
+var accountHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Obtain the transaction history of an account",
+	Long: `Obtain the transactions sent to and from an account, oldest first.  For example:
+
+    ethereal account history --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --from-block=-10000
+
+With --internal the internal transactions of the account, being transfers of Ether made by contracts, are shown instead.
+
+The history is not available over JSON-RPC, so this requires an Etherscan API key configured with etherscan-api-key or ETHERSCAN_API_KEY, or an Etherscan-compatible endpoint with etherscan-url.
+
+In quiet mode this will return 0 if the account has any transactions in the range, otherwise 1.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountHistoryAddress != "", quiet, "--address is required")
+		client := etherscanClient()
+		cli.Assert(client != nil, quiet, "Transaction history requires an Etherscan API key or URL")
+		address, err := c.Resolve(accountHistoryAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", accountHistoryAddress))
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		fromBlock, err := parseBlockNumber(ctx, accountHistoryFromBlock)
+		cli.ErrCheck(err, quiet, "Invalid from block")
+		toBlock, err := parseBlockNumber(ctx, accountHistoryToBlock)
+		cli.ErrCheck(err, quiet, "Invalid to block")
+		var from uint64
+		if fromBlock != nil {
+			from = fromBlock.Uint64()
+		}
+		var to uint64
+		if toBlock == nil {
+			to, err = c.Client().BlockNumber(ctx)
+			cli.ErrCheck(err, quiet, "Failed to obtain latest block")
+		} else {
+			to = toBlock.Uint64()
+		}
+		cli.Assert(from <= to, quiet, "From block cannot be after to block")
+
+		var res []*accountHistoryJSON
+		if accountHistoryInternal {
+			txs, err := client.InternalTransactions(ctx, c.ChainID(), address, from, to, accountHistoryCount)
+			cli.ErrCheck(err, quiet, "Failed to obtain internal transactions")
+			res = make([]*accountHistoryJSON, len(txs))
+			for i, tx := range txs {
+				res[i] = newAccountHistoryInternalJSON(tx)
+			}
+		} else {
+			txs, err := client.Transactions(ctx, c.ChainID(), address, from, to, accountHistoryCount)
+			cli.ErrCheck(err, quiet, "Failed to obtain transactions")
+			res = make([]*accountHistoryJSON, len(txs))
+			for i, tx := range txs {
+				res[i] = newAccountHistoryJSON(tx)
+			}
+		}
+
+		if jsonOutput() {
+			outputJSON(res)
+		}
+		if quiet {
+			if len(res) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		builder := new(strings.Builder)
+		for _, tx := range res {
+			direction := "in"
+			if tx.From == address.Hex() {
+				direction = "out"
+			}
+			counterparty := "(contract creation)"
+			switch {
+			case direction == "in":
+				counterparty = tx.From
+			case tx.To != "":
+				counterparty = tx.To
+			case tx.ContractAddress != "":
+				counterparty = fmt.Sprintf("%s (created)", tx.ContractAddress)
+			}
+			value, _ := new(big.Int).SetString(tx.Value, 10)
+			builder.WriteString(fmt.Sprintf("%d\t%s\t%s\t%-3s\t%s\t%s", tx.BlockNumber, time.Unix(tx.Timestamp, 0).Format(time.RFC3339), tx.Hash, direction, counterparty, formatWei(value)))
+			if tx.Function != "" && verbose {
+				builder.WriteString(fmt.Sprintf("\t%s", tx.Function))
+			}
+			if tx.Failed {
+				builder.WriteString("\t(failed)")
+			}
+			builder.WriteString("\n")
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// accountHistoryJSON is the JSON output for a transaction in the history of an account.
+type accountHistoryJSON struct {
+	Hash            string `json:"hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	Timestamp       int64  `json:"timestamp"`
+	From            string `json:"from"`
+	To              string `json:"to,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	Value           string `json:"value"`
+	GasUsed         uint64 `json:"gas_used,omitempty"`
+	GasPrice        string `json:"gas_price,omitempty"`
+	Function        string `json:"function,omitempty"`
+	Type            string `json:"type,omitempty"`
+	TraceID         string `json:"trace_id,omitempty"`
+	Failed          bool   `json:"failed"`
+}
+
+func newAccountHistoryJSON(tx *etherscan.HistoryTransaction) *accountHistoryJSON {
+	res := &accountHistoryJSON{
+		Hash:        tx.Hash.Hex(),
+		BlockNumber: tx.BlockNumber,
+		Timestamp:   tx.Timestamp.Unix(),
+		From:        tx.From.Hex(),
+		Value:       tx.Value.String(),
+		GasUsed:     tx.GasUsed,
+		GasPrice:    tx.GasPrice.String(),
+		Function:    tx.Function,
+		Failed:      tx.Failed,
+	}
+	if tx.To != nil {
+		res.To = tx.To.Hex()
+	}
+	if tx.ContractAddress != nil {
+		res.ContractAddress = tx.ContractAddress.Hex()
+	}
+	return res
+}
+
+func newAccountHistoryInternalJSON(tx *etherscan.InternalTransaction) *accountHistoryJSON {
+	res := &accountHistoryJSON{
+		Hash:        tx.Hash.Hex(),
+		BlockNumber: tx.BlockNumber,
+		Timestamp:   tx.Timestamp.Unix(),
+		From:        tx.From.Hex(),
+		Value:       tx.Value.String(),
+		Type:        tx.Type,
+		TraceID:     tx.TraceID,
+		Failed:      tx.Failed,
+	}
+	if tx.To != nil {
+		res.To = tx.To.Hex()
+	}
+	if tx.ContractAddress != nil {
+		res.ContractAddress = tx.ContractAddress.Hex()
+	}
+	return res
+}
+
+func init() {
+	accountCmd.AddCommand(accountHistoryCmd)
+	accountHistoryCmd.Flags().StringVar(&accountHistoryAddress, "address", "", "Address of the account for which to obtain the history")
+	accountHistoryCmd.Flags().BoolVar(&accountHistoryInternal, "internal", false, "Obtain internal transactions rather than transactions")
+	accountHistoryCmd.Flags().StringVar(&accountHistoryFromBlock, "from-block", "earliest", "Block from which to obtain transactions")
+	accountHistoryCmd.Flags().StringVar(&accountHistoryToBlock, "to-block", "latest", "Block to which to obtain transactions")
+	accountHistoryCmd.Flags().IntVar(&accountHistoryCount, "count", 0, "Maximum number of transactions to obtain (default all)")
+}
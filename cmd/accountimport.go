@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var accountImportPassphrase string
+var accountImportPrivateKey string
+var accountImportPresale string
+var accountImportKeystore string
+var accountImportKeystorePassphrase string
+var accountImportScryptN int
+var accountImportScryptP int
+
+// accountImportCmd represents the account import command
+var accountImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import an account",
+	Long: `Import an account in to the local keystore from a private key, a presale wallet or a keystore file.  For example:
+
+    ethereal account import --privatekey=0x... --passphrase=secret
+
+The passphrase is used to encrypt the imported account.  Presale wallets are decrypted with the same passphrase.  Keystore files are decrypted with --keystore-passphrase, which defaults to the passphrase.
+
+In quiet mode this will return 0 if the account was imported, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountImportPassphrase != "", quiet, "--passphrase is required")
+		sources := 0
+		for _, source := range []string{accountImportPrivateKey, accountImportPresale, accountImportKeystore} {
+			if source != "" {
+				sources++
+			}
+		}
+		cli.Assert(sources == 1, quiet, "one of --privatekey, --presale or --keystore is required")
+
+		ks := accountKeystore(accountImportScryptN, accountImportScryptP)
+		var account accounts.Account
+		switch {
+		case accountImportPrivateKey != "":
+			key, err := crypto.HexToECDSA(strings.TrimPrefix(accountImportPrivateKey, "0x"))
+			cli.ErrCheck(err, quiet, "Invalid private key")
+			account, err = ks.ImportECDSA(key, accountImportPassphrase)
+			cli.ErrCheck(err, quiet, "Failed to import private key")
+		case accountImportPresale != "":
+			data, err := ioutil.ReadFile(accountImportPresale)
+			cli.ErrCheck(err, quiet, "Failed to read presale wallet")
+			account, err = ks.ImportPreSaleKey(data, accountImportPassphrase)
+			cli.ErrCheck(err, quiet, "Failed to import presale wallet")
+		default:
+			data, err := ioutil.ReadFile(accountImportKeystore)
+			cli.ErrCheck(err, quiet, "Failed to read keystore")
+			passphrase := accountImportKeystorePassphrase
+			if passphrase == "" {
+				passphrase = accountImportPassphrase
+			}
+			account, err = ks.Import(data, passphrase, accountImportPassphrase)
+			cli.ErrCheck(err, quiet, "Failed to import keystore")
+		}
+		outputIf(verbose, fmt.Sprintf("Keystore written to %s", account.URL.Path))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"address":  account.Address.Hex(),
+				"location": account.URL.String(),
+			})
+		}
+		fmt.Println(account.Address.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["account:import"] = true
+	accountCmd.AddCommand(accountImportCmd)
+	accountImportCmd.Flags().StringVar(&accountImportPassphrase, "passphrase", "", "passphrase with which to encrypt the imported account")
+	accountImportCmd.Flags().StringVar(&accountImportPrivateKey, "privatekey", "", "private key to import")
+	accountImportCmd.Flags().StringVar(&accountImportPresale, "presale", "", "path to presale wallet to import")
+	accountImportCmd.Flags().StringVar(&accountImportKeystore, "keystore", "", "path to keystore file to import")
+	accountImportCmd.Flags().StringVar(&accountImportKeystorePassphrase, "keystore-passphrase", "", "passphrase for the keystore file to import (default the passphrase)")
+	accountImportCmd.Flags().IntVar(&accountImportScryptN, "scrypt-n", keystore.StandardScryptN, "scrypt N parameter for encrypting the imported account")
+	accountImportCmd.Flags().IntVar(&accountImportScryptP, "scrypt-p", keystore.StandardScryptP, "scrypt P parameter for encrypting the imported account")
+}
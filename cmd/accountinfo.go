@@ -0,0 +1,152 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+)
+
+var accountInfoAddress string
+
+// accountInfoCmd represents the account info command
+var accountInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about an account",
+	Long: `Obtain information about an account, including its balance, nonce, code and ENS name.  For example:
+
+    ethereal account info --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+If an Etherscan API key is configured with etherscan-api-key or ETHERSCAN_API_KEY, or an Etherscan-compatible endpoint with etherscan-url, the first and last transactions of the account are also shown.
+
+In quiet mode this will return 0 if the information can be obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountInfoAddress != "", quiet, "--address is required")
+		address, err := c.Resolve(accountInfoAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", accountInfoAddress))
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		balance, err := c.Client().BalanceAt(ctx, address, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain balance")
+		nonce, err := c.Client().NonceAt(ctx, address, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain nonce")
+		pendingNonce, err := c.Client().PendingNonceAt(ctx, address)
+		cli.ErrCheck(err, quiet, "Failed to obtain pending nonce")
+		code, err := c.Client().CodeAt(ctx, address, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain code")
+		// Not all addresses have reverse records, so errors are ignored.
+		name, _ := c.ReverseResolve(address)
+
+		var activity *etherscan.Activity
+		if client := etherscanClient(); client != nil {
+			activity, err = client.Activity(ctx, c.ChainID(), address)
+			cli.ErrCheck(err, quiet, "Failed to obtain activity")
+		}
+
+		res := &accountInfoJSON{
+			Address:      address.Hex(),
+			Name:         name,
+			Balance:      balance.String(),
+			Nonce:        nonce,
+			PendingNonce: pendingNonce,
+			Contract:     len(code) > 0,
+		}
+		if res.Contract {
+			res.CodeSize = len(code)
+			res.CodeHash = crypto.Keccak256Hash(code).Hex()
+		}
+		if activity != nil {
+			res.FirstTransaction = newAccountInfoTransactionJSON(activity.First)
+			res.LastTransaction = newAccountInfoTransactionJSON(activity.Last)
+		}
+
+		if jsonOutput() {
+			outputJSON(res)
+		}
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Address:\t\t%s\n", res.Address))
+		if name != "" {
+			builder.WriteString(fmt.Sprintf("ENS name:\t\t%s\n", name))
+		}
+		builder.WriteString(fmt.Sprintf("Balance:\t\t%s\n", formatWei(balance)))
+		builder.WriteString(fmt.Sprintf("Nonce:\t\t\t%d\n", nonce))
+		builder.WriteString(fmt.Sprintf("Pending nonce:\t\t%d\n", pendingNonce))
+		if res.Contract {
+			builder.WriteString(fmt.Sprintf("Contract:\t\tyes (%d bytes)\n", res.CodeSize))
+			builder.WriteString(fmt.Sprintf("Code hash:\t\t%s\n", res.CodeHash))
+		} else {
+			builder.WriteString("Contract:\t\tno\n")
+		}
+		if activity != nil {
+			if activity.First == nil {
+				builder.WriteString("Activity:\t\tnone\n")
+			} else {
+				builder.WriteString(fmt.Sprintf("First transaction:\t%s (block %d, %s)\n", activity.First.Hash.Hex(), activity.First.BlockNumber, activity.First.Timestamp.Format(time.RFC3339)))
+				builder.WriteString(fmt.Sprintf("Last transaction:\t%s (block %d, %s)\n", activity.Last.Hash.Hex(), activity.Last.BlockNumber, activity.Last.Timestamp.Format(time.RFC3339)))
+			}
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// accountInfoJSON is the JSON output for information about an account.
+type accountInfoJSON struct {
+	Address          string                      `json:"address"`
+	Name             string                      `json:"name,omitempty"`
+	Balance          string                      `json:"balance"`
+	Nonce            uint64                      `json:"nonce"`
+	PendingNonce     uint64                      `json:"pending_nonce"`
+	Contract         bool                        `json:"contract"`
+	CodeSize         int                         `json:"code_size,omitempty"`
+	CodeHash         string                      `json:"code_hash,omitempty"`
+	FirstTransaction *accountInfoTransactionJSON `json:"first_transaction,omitempty"`
+	LastTransaction  *accountInfoTransactionJSON `json:"last_transaction,omitempty"`
+}
+
+// accountInfoTransactionJSON is the JSON output for a transaction of an account.
+type accountInfoTransactionJSON struct {
+	Hash        string `json:"hash"`
+	BlockNumber uint64 `json:"block_number"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+func newAccountInfoTransactionJSON(tx *etherscan.Transaction) *accountInfoTransactionJSON {
+	if tx == nil {
+		return nil
+	}
+	return &accountInfoTransactionJSON{
+		Hash:        tx.Hash.Hex(),
+		BlockNumber: tx.BlockNumber,
+		Timestamp:   tx.Timestamp.Unix(),
+	}
+}
+
+func init() {
+	accountCmd.AddCommand(accountInfoCmd)
+	accountInfoCmd.Flags().StringVar(&accountInfoAddress, "address", "", "Address of the account for which to obtain information")
+}
@@ -20,7 +20,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
@@ -50,7 +49,8 @@ In quiet mode this will return 0 if the account was successfully decoded, otherw
 			key, err = crypto.HexToECDSA(strings.TrimPrefix(accountKeysPrivateKey, "0x"))
 			cli.ErrCheck(err, quiet, "Invalid private key")
 		} else {
-			address := common.HexToAddress(accountKeysAddress)
+			address, err := c.Resolve(accountKeysAddress)
+			cli.ErrCheck(err, quiet, "Failed to obtain address")
 			key, err = util.PrivateKeyForAccount(c.ChainID(), address, accountKeysPassphrase)
 		}
 		cli.ErrCheck(err, quiet, "Failed to access account")
@@ -58,6 +58,14 @@ In quiet mode this will return 0 if the account was successfully decoded, otherw
 			os.Exit(exitSuccess)
 		}
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"private_key": fmt.Sprintf("0x%032x", key.D),
+				"public_key":  fmt.Sprintf("0x%s", hex.EncodeToString(crypto.FromECDSAPub(&key.PublicKey))),
+				"address":     crypto.PubkeyToAddress(key.PublicKey).Hex(),
+			})
+		}
+
 		fmt.Printf("Private key:\t\t0x%032x\n", key.D)
 		fmt.Printf("Public key:\t\t0x%s\n", hex.EncodeToString(crypto.FromECDSAPub(&key.PublicKey)))
 		fmt.Printf("Ethereum address:\t%s\n", crypto.PubkeyToAddress(key.PublicKey).Hex())
@@ -17,11 +17,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	string2eth "github.com/wealdtech/go-string2eth"
 )
 
+var accountListBalances bool
+
 // accountListCmd represents the account list command
 var accountListCmd = &cobra.Command{
 	Use:   "list",
@@ -30,38 +32,55 @@ var accountListCmd = &cobra.Command{
 
     ethereal account list
 
+The balance of each account is shown alongside its address if --balances is supplied.
+
 In quiet mode this will return 0 if any accounts are found, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		wallets, err := cli.ObtainWallets(c.ChainID())
 		foundAccounts := false
+		accounts := make([]*accountListJSON, 0)
 		if err == nil {
 			for _, wallet := range wallets {
 				for _, account := range wallet.Accounts() {
 					foundAccounts = true
-					if !quiet {
-						if !verbose {
-							fmt.Println(account.Address.Hex())
-						} else {
-							fmt.Printf("Location:\t%s\n", account.URL)
-							fmt.Printf("Address:\t%s\n", account.Address.Hex())
-							if !offline {
-								name, err := c.ReverseResolve(account.Address)
-								if err == nil {
-									fmt.Printf("Name:\t\t%s\n", name)
-								}
-								ctx, cancel := localContext()
-								defer cancel()
-								balance, err := c.Client().BalanceAt(ctx, account.Address, nil)
-								if err == nil {
-									fmt.Printf("Balance:\t%s\n", string2eth.WeiToString(balance, true))
-								}
-								nonce, err := c.Client().PendingNonceAt(ctx, account.Address)
-								if err == nil {
-									fmt.Printf("Next nonce:\t%v\n", nonce)
-								}
+					if quiet {
+						continue
+					}
+					if jsonOutput() {
+						accounts = append(accounts, newAccountListJSON(account.Address, account.URL.String()))
+						continue
+					}
+					if !verbose {
+						if accountListBalances && !offline {
+							ctx, cancel := localContext()
+							balance, err := c.Client().BalanceAt(ctx, account.Address, nil)
+							cancel()
+							if err == nil {
+								fmt.Printf("%s\t%s\n", account.Address.Hex(), formatWei(balance))
+								continue
 							}
-							fmt.Println("")
 						}
+						fmt.Println(account.Address.Hex())
+					} else {
+						fmt.Printf("Location:\t%s\n", account.URL)
+						fmt.Printf("Address:\t%s\n", account.Address.Hex())
+						if !offline {
+							name, err := c.ReverseResolve(account.Address)
+							if err == nil {
+								fmt.Printf("Name:\t\t%s\n", name)
+							}
+							ctx, cancel := localContext()
+							defer cancel()
+							balance, err := c.Client().BalanceAt(ctx, account.Address, nil)
+							if err == nil {
+								fmt.Printf("Balance:\t%s\n", formatWei(balance))
+							}
+							nonce, err := c.Client().PendingNonceAt(ctx, account.Address)
+							if err == nil {
+								fmt.Printf("Next nonce:\t%v\n", nonce)
+							}
+						}
+						fmt.Println("")
 					}
 				}
 			}
@@ -74,9 +93,44 @@ In quiet mode this will return 0 if any accounts are found, otherwise 1.`,
 				os.Exit(exitFailure)
 			}
 		}
+		if jsonOutput() {
+			outputJSON(accounts)
+		}
 	},
 }
 
+// accountListJSON is the JSON output for an account.
+type accountListJSON struct {
+	Address   string  `json:"address"`
+	Location  string  `json:"location"`
+	Name      string  `json:"name,omitempty"`
+	Balance   string  `json:"balance,omitempty"`
+	NextNonce *uint64 `json:"next_nonce,omitempty"`
+}
+
+func newAccountListJSON(address common.Address, location string) *accountListJSON {
+	res := &accountListJSON{
+		Address:  address.Hex(),
+		Location: location,
+	}
+	if offline || !(verbose || accountListBalances) {
+		return res
+	}
+	if name, err := c.ReverseResolve(address); err == nil {
+		res.Name = name
+	}
+	ctx, cancel := localContext()
+	defer cancel()
+	if balance, err := c.Client().BalanceAt(ctx, address, nil); err == nil {
+		res.Balance = balance.String()
+	}
+	if nonce, err := c.Client().PendingNonceAt(ctx, address); err == nil {
+		res.NextNonce = &nonce
+	}
+	return res
+}
+
 func init() {
 	accountCmd.AddCommand(accountListCmd)
+	accountListCmd.Flags().BoolVar(&accountListBalances, "balances", false, "show the balance of each account")
 }
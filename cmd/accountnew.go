@@ -0,0 +1,69 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var accountNewPassphrase string
+var accountNewScryptN int
+var accountNewScryptP int
+
+// accountNewCmd represents the account new command
+var accountNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Create a new account",
+	Long: `Create a new account in the local keystore.  For example:
+
+    ethereal account new --passphrase=secret
+
+The cost of decrypting the account can be altered with the --scrypt-n and --scrypt-p flags; lower values are faster but less secure.
+
+In quiet mode this will return 0 if the account was created, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountNewPassphrase != "", quiet, "--passphrase is required")
+		cli.Assert(accountNewScryptN > 1 && accountNewScryptN&(accountNewScryptN-1) == 0, quiet, "--scrypt-n must be a power of 2 greater than 1")
+		cli.Assert(accountNewScryptP > 0, quiet, "--scrypt-p must be greater than 0")
+
+		ks := accountKeystore(accountNewScryptN, accountNewScryptP)
+		account, err := ks.NewAccount(accountNewPassphrase)
+		cli.ErrCheck(err, quiet, "Failed to create account")
+		outputIf(verbose, fmt.Sprintf("Keystore written to %s", account.URL.Path))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"address":  account.Address.Hex(),
+				"location": account.URL.String(),
+			})
+		}
+		fmt.Println(account.Address.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["account:new"] = true
+	accountCmd.AddCommand(accountNewCmd)
+	accountNewCmd.Flags().StringVar(&accountNewPassphrase, "passphrase", "", "passphrase with which to encrypt the new account")
+	accountNewCmd.Flags().IntVar(&accountNewScryptN, "scrypt-n", keystore.StandardScryptN, "scrypt N parameter for encrypting the new account")
+	accountNewCmd.Flags().IntVar(&accountNewScryptP, "scrypt-p", keystore.StandardScryptP, "scrypt P parameter for encrypting the new account")
+}
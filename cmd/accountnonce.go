@@ -44,6 +44,9 @@ In quiet mode this will return 0 if the nonce can be obtained, otherwise 1.`,
 		nonce, err := c.Client().PendingNonceAt(ctx, address)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain nonce for %s", accountNonceAddress))
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": address.Hex(), "nonce": nonce})
+		}
 		if !quiet {
 			fmt.Println(nonce)
 		}
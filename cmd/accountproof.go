@@ -0,0 +1,156 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var accountProofAddress string
+var accountProofKeys []string
+var accountProofBlock string
+var accountProofStateRoot string
+
+// accountProofCmd represents the account proof command
+var accountProofCmd = &cobra.Command{
+	Use:   "proof",
+	Short: "Obtain verified account and storage values",
+	Long: `Obtain the Merkle-Patricia proof of an account and optionally some of its storage with eth_getProof, verify it locally against the state root of a block, and show the verified values.  For example:
+
+    ethereal account proof --address=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --key=0 --key=1 --block=-10
+
+The state root is taken from the block header unless a trusted state root is supplied with --state-root.
+
+In quiet mode this will return 0 if the proof is valid, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountProofAddress != "", quiet, "--address is required")
+		address, err := c.Resolve(accountProofAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", accountProofAddress))
+
+		keys := make([]common.Hash, 0, len(accountProofKeys))
+		for _, key := range accountProofKeys {
+			slot, err := util.StorageSlot(key)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid storage key %s", key))
+			keys = append(keys, slot)
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		blockNumber, err := parseBlockNumber(ctx, accountProofBlock)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		header, err := c.Client().HeaderByNumber(ctx, blockNumber)
+		cli.ErrCheck(err, quiet, "Failed to obtain block header")
+		stateRoot := header.Root
+		if accountProofStateRoot != "" {
+			stateRoot = common.HexToHash(strings.TrimPrefix(accountProofStateRoot, "0x"))
+			outputIf(verbose && stateRoot != header.Root, fmt.Sprintf("Supplied state root differs from state root %s of block %v", header.Root.Hex(), header.Number))
+		}
+
+		proof, err := c.Proof(ctx, address, keys, header.Number)
+		cli.ErrCheck(err, quiet, "Failed to obtain proof")
+
+		account, err := util.VerifyAccountProof(stateRoot, address, bytesList(proof.AccountProof))
+		cli.ErrCheck(err, quiet, "Account proof is invalid")
+		cli.Assert(util.AccountMatches(account, uint64(proof.Nonce), proof.Balance.ToInt(), proof.StorageHash, proof.CodeHash), quiet, "Account proof does not match the returned account")
+
+		values := make([]*big.Int, len(keys))
+		for i, key := range keys {
+			storageProof := proof.StorageProof[i]
+			values[i], err = util.VerifyStorageProof(account.Root, key, bytesList(storageProof.Proof))
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Storage proof for %s is invalid", key.Hex()))
+			cli.Assert(storageProof.Value != nil && values[i].Cmp(storageProof.Value.ToInt()) == 0, quiet, fmt.Sprintf("Storage proof for %s does not match the returned value", key.Hex()))
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			res := &accountProofJSON{
+				Address:     address.Hex(),
+				Block:       header.Number.Uint64(),
+				StateRoot:   stateRoot.Hex(),
+				Nonce:       account.Nonce,
+				Balance:     account.Balance.String(),
+				CodeHash:    common.BytesToHash(account.CodeHash).Hex(),
+				StorageRoot: account.Root.Hex(),
+				Storage:     make([]*accountProofStorageJSON, len(keys)),
+			}
+			for i, key := range keys {
+				res.Storage[i] = &accountProofStorageJSON{
+					Key:   key.Hex(),
+					Value: common.BigToHash(values[i]).Hex(),
+				}
+			}
+			outputJSON(res)
+		}
+
+		fmt.Printf("Address: %s\n", formatAddress(address))
+		fmt.Printf("Block: %v\n", header.Number)
+		fmt.Printf("State root: %s\n", stateRoot.Hex())
+		fmt.Printf("Nonce: %d\n", account.Nonce)
+		fmt.Printf("Balance: %s\n", formatWei(account.Balance.ToBig()))
+		fmt.Printf("Code hash: %s\n", common.BytesToHash(account.CodeHash).Hex())
+		fmt.Printf("Storage root: %s\n", account.Root.Hex())
+		if len(keys) > 0 {
+			fmt.Println("Storage:")
+			for i, key := range keys {
+				fmt.Printf("  %s: %s\n", key.Hex(), common.BigToHash(values[i]).Hex())
+			}
+		}
+	},
+}
+
+type accountProofJSON struct {
+	Address     string                     `json:"address"`
+	Block       uint64                     `json:"block"`
+	StateRoot   string                     `json:"state_root"`
+	Nonce       uint64                     `json:"nonce"`
+	Balance     string                     `json:"balance"`
+	CodeHash    string                     `json:"code_hash"`
+	StorageRoot string                     `json:"storage_root"`
+	Storage     []*accountProofStorageJSON `json:"storage"`
+}
+
+type accountProofStorageJSON struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// bytesList converts proof nodes to byte slices.
+func bytesList(nodes []hexutil.Bytes) [][]byte {
+	res := make([][]byte, len(nodes))
+	for i := range nodes {
+		res[i] = nodes[i]
+	}
+	return res
+}
+
+func init() {
+	accountCmd.AddCommand(accountProofCmd)
+	accountProofCmd.Flags().StringVar(&accountProofAddress, "address", "", "Address of the account for which to obtain the proof")
+	accountProofCmd.Flags().StringSliceVar(&accountProofKeys, "key", nil, "Storage key to prove (decimal or hex; can be repeated)")
+	accountProofCmd.Flags().StringVar(&accountProofBlock, "block", "", "Block at which to obtain the proof (default latest)")
+	accountProofCmd.Flags().StringVar(&accountProofStateRoot, "state-root", "", "Trusted state root against which to verify the proof (default the state root of the block)")
+}
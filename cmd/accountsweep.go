@@ -0,0 +1,170 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var accountSweepFromAddress string
+var accountSweepToAddress string
+var accountSweepTokens []string
+
+// accountSweepCmd represents the account sweep command
+var accountSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Sweep all funds from an account",
+	Long: `Sweep all Ether, and optionally tokens, from one address to another.  For example:
+
+    ethereal account sweep --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x52f1A3027d3aA514F17E454C93ae1F79b3B12d5d --token=dai --token=usdc --passphrase=secret
+
+Tokens supplied with --token are swept first, with each transfer mined within the time given by --timeout before continuing.  The Ether is swept last in the same way as "ether sweep", sending the balance less the maximum fee for the transaction.
+
+Some Ether can remain in the account if the transaction costs less than its maximum fee, if the recipient is a contract that uses less gas than estimated, or on layer 2 chains where the fee for layer 1 data can change before the transaction is included.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+
+		accountSweepFromAddress = accountOrDefault(accountSweepFromAddress)
+		cli.Assert(accountSweepFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(accountSweepFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", accountSweepFromAddress))
+
+		cli.Assert(accountSweepToAddress != "", quiet, "--to is required")
+		toAddress, err := c.Resolve(accountSweepToAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", accountSweepToAddress))
+		cli.Assert(fromAddress != toAddress, quiet, "--from and --to must be different")
+
+		tokensSwept := 0
+		for _, token := range accountSweepTokens {
+			if accountSweepToken(token, fromAddress, toAddress) {
+				tokensSwept++
+			}
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to sweep funds")
+		if balance.Sign() == 0 {
+			cli.Assert(tokensSwept > 0, quiet, "Balance is 0; nothing to sweep")
+			outputIf(!quiet, "Balance is 0; no Ether to sweep")
+			return
+		}
+
+		code, err := c.Client().CodeAt(ctx, toAddress, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain code of address to which to sweep funds")
+		outputIf(len(code) > 0 && !quiet, "Recipient is a contract; any gas that it does not use will be refunded to the account")
+
+		txData, fee := etherSweepTransactionData(ctx, fromAddress, toAddress, balance)
+		signedTx, err := c.CreateSignedTransaction(context.Background(), txData)
+		cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+		// Layer 2 chains can charge a separate fee for layer 1 data, which depends on the signed
+		// transaction and so is calculated from it.
+		l1Fee, err := c.L1Fee(ctx, signedTx)
+		cli.ErrCheck(err, quiet, "Failed to obtain layer 1 fee")
+		if l1Fee != nil && l1Fee.Gas == 0 && l1Fee.Fee.Sign() > 0 {
+			// Allow for the layer 1 fee to increase before the transaction is included.
+			l1Cost := new(big.Int).Div(new(big.Int).Mul(l1Fee.Fee, big.NewInt(11)), big.NewInt(10))
+			outputIf(verbose, fmt.Sprintf("Layer 1 fee is %s", formatWei(l1Cost)))
+			fee = fee.Add(fee, l1Cost)
+			cli.Assert(balance.Cmp(fee) > 0, quiet, fmt.Sprintf("Balance of %s insufficient to cover fee of %s", formatWei(balance), formatWei(fee)))
+			txData.Value = new(big.Int).Sub(balance, fee)
+			outputIf(verbose, fmt.Sprintf("Sweeping %s", formatWei(txData.Value)))
+			signedTx, err = c.CreateSignedTransaction(context.Background(), txData)
+			cli.ErrCheck(err, quiet, "Failed to create transaction")
+		}
+
+		err = sendTransaction(context.Background(), fromAddress, signedTx)
+		cli.ErrCheck(err, quiet, "Failed to send transaction")
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":   "account",
+			"command": "sweep",
+			"from":    fromAddress.Hex(),
+			"to":      toAddress.Hex(),
+			"amount":  txData.Value.String(),
+		}, true)
+	},
+}
+
+// accountSweepToken sweeps the balance of the given token to the recipient, waiting for the
+// transfer to be mined within the command timeout.  It returns false if there was no balance to sweep.
+func accountSweepToken(input string, from common.Address, to common.Address) bool {
+	tokenAddress, err := tokenContractAddress(input)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain token %s", input))
+	token, err := contracts.NewERC20(tokenAddress, c.Client())
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain token contract %s", input))
+	balance, err := token.BalanceOf(nil, from)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain balance of token %s", input))
+	if balance.Sign() == 0 {
+		outputIf(verbose, fmt.Sprintf("No balance of token %s to sweep", input))
+		return false
+	}
+
+	tokenAbi, err := abi.JSON(strings.NewReader(contracts.ERC20ABI))
+	cli.ErrCheck(err, quiet, "Failed to parse token ABI")
+	data, err := tokenAbi.Pack("transfer", to, balance)
+	cli.ErrCheck(err, quiet, "Failed to create token transfer")
+
+	tx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
+		From:  from,
+		To:    &tokenAddress,
+		Value: big.NewInt(0),
+		Data:  data,
+	})
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to create transaction to sweep token %s", input))
+	err = sendTransaction(context.Background(), from, tx)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to send transaction to sweep token %s", input))
+	if dryRun {
+		// The transfer was simulated, so there is nothing to wait for.
+		return true
+	}
+	logTransaction(tx, log.Fields{
+		"group":          "account",
+		"command":        "sweep",
+		"token":          input,
+		"tokenholder":    from.Hex(),
+		"tokenrecipient": to.Hex(),
+		"tokenamount":    balance.String(),
+	})
+	outputIf(!quiet, fmt.Sprintf("Waiting for transaction %#x sweeping token %s to be mined", tx.Hash(), input))
+	mined, err := c.WaitForTransaction(context.Background(), tx.Hash(), 1, viper.GetDuration("timeout"))
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to mine transaction to sweep token %s", input))
+	cli.Assert(mined.Receipt.Status == types.ReceiptStatusSuccessful, quiet, fmt.Sprintf("Transaction to sweep token %s failed", input))
+	return true
+}
+
+func init() {
+	accountCmd.AddCommand(accountSweepCmd)
+	accountSweepCmd.Flags().StringVar(&accountSweepFromAddress, "from", "", "Address from which to sweep funds")
+	accountSweepCmd.Flags().StringVar(&accountSweepToAddress, "to", "", "Address to which to sweep funds")
+	accountSweepCmd.Flags().StringSliceVar(&accountSweepTokens, "token", nil, "Token to sweep before sweeping Ether (can be repeated)")
+	addTransactionFlags(accountSweepCmd, "the address from which to sweep funds")
+}
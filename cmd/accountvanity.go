@@ -0,0 +1,149 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var accountVanityPrefix string
+var accountVanitySuffix string
+var accountVanityPattern string
+var accountVanityChecksum bool
+var accountVanityWorkers int
+var accountVanityPassphrase string
+var accountVanityFile string
+var accountVanityScryptN int
+var accountVanityScryptP int
+
+// accountVanityCmd represents the account vanity command
+var accountVanityCmd = &cobra.Command{
+	Use:   "vanity",
+	Short: "Generate an account with a vanity address",
+	Long: `Generate an account whose address matches a prefix, suffix or regular expression.  For example:
+
+    ethereal account vanity --prefix=cafe --passphrase=secret
+
+Matching is case-insensitive unless --checksum is supplied, in which case the case of the prefix, suffix and pattern must match the checksummed address.  Each additional character makes the search around 16 times longer.  Progress is reported every few seconds; the search can be stopped with Ctrl-C.
+
+The account is stored in the local keystore, or written to the keystore file given by --file, encrypted with the passphrase.
+
+In quiet mode this will return 0 if an account was generated, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(accountVanityPassphrase != "", quiet, "--passphrase is required")
+		cli.Assert(accountVanityWorkers > 0, quiet, "--workers must be greater than 0")
+		cli.Assert(accountVanityScryptN > 1 && accountVanityScryptN&(accountVanityScryptN-1) == 0, quiet, "--scrypt-n must be a power of 2 greater than 1")
+		cli.Assert(accountVanityScryptP > 0, quiet, "--scrypt-p must be greater than 0")
+		matcher, err := util.NewVanityMatcher(accountVanityPrefix, accountVanitySuffix, accountVanityPattern, accountVanityChecksum)
+		cli.ErrCheck(err, quiet, "Invalid vanity specification")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		outputIf(verbose, fmt.Sprintf("Searching with %d workers; expect around %.0f attempts", accountVanityWorkers, matcher.Difficulty()))
+		var attempts uint64
+		started := time.Now()
+		done := make(chan struct{})
+		if !quiet {
+			go accountVanityProgress(&attempts, started, done)
+		}
+		key, err := util.VanitySearch(ctx, matcher, accountVanityWorkers, &attempts)
+		close(done)
+		cli.ErrCheck(err, quiet, "Failed to generate vanity address")
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		outputIf(verbose, fmt.Sprintf("Found %s after %d attempts in %v", address.Hex(), atomic.LoadUint64(&attempts), time.Since(started).Round(time.Millisecond)))
+
+		var location string
+		if accountVanityFile != "" {
+			id, err := uuid.NewRandom()
+			cli.ErrCheck(err, quiet, "Failed to generate key ID")
+			data, err := keystore.EncryptKey(&keystore.Key{
+				Id:         id,
+				Address:    address,
+				PrivateKey: key,
+			}, accountVanityPassphrase, accountVanityScryptN, accountVanityScryptP)
+			cli.ErrCheck(err, quiet, "Failed to encrypt key")
+			cli.ErrCheck(ioutil.WriteFile(accountVanityFile, data, 0600), quiet, "Failed to write keystore")
+			location = accountVanityFile
+		} else {
+			ks := accountKeystore(accountVanityScryptN, accountVanityScryptP)
+			account, err := ks.ImportECDSA(key, accountVanityPassphrase)
+			cli.ErrCheck(err, quiet, "Failed to store account")
+			location = account.URL.Path
+		}
+		outputIf(verbose, fmt.Sprintf("Keystore written to %s", location))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"address":  address.Hex(),
+				"location": location,
+				"attempts": atomic.LoadUint64(&attempts),
+			})
+		}
+		fmt.Println(address.Hex())
+	},
+}
+
+// accountVanityProgress reports the progress of a search until done is closed.
+func accountVanityProgress(attempts *uint64, started time.Time, done <-chan struct{}) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			current := atomic.LoadUint64(attempts)
+			elapsed := time.Since(started)
+			fmt.Fprintf(os.Stderr, "%d attempts in %v (%.0f/s)\n", current, elapsed.Round(time.Second), float64(current)/elapsed.Seconds())
+		}
+	}
+}
+
+func init() {
+	offlineCmds["account:vanity"] = true
+	accountCmd.AddCommand(accountVanityCmd)
+	accountVanityCmd.Flags().StringVar(&accountVanityPrefix, "prefix", "", "hex prefix of the address")
+	accountVanityCmd.Flags().StringVar(&accountVanitySuffix, "suffix", "", "hex suffix of the address")
+	accountVanityCmd.Flags().StringVar(&accountVanityPattern, "pattern", "", "regular expression that the address (without 0x) must match")
+	accountVanityCmd.Flags().BoolVar(&accountVanityChecksum, "checksum", false, "match against the checksummed address, so case-sensitive")
+	accountVanityCmd.Flags().IntVar(&accountVanityWorkers, "workers", runtime.NumCPU(), "number of addresses to generate in parallel")
+	accountVanityCmd.Flags().StringVar(&accountVanityPassphrase, "passphrase", "", "passphrase with which to encrypt the new account")
+	accountVanityCmd.Flags().StringVar(&accountVanityFile, "file", "", "file to which to write the keystore (default local keystore)")
+	accountVanityCmd.Flags().IntVar(&accountVanityScryptN, "scrypt-n", keystore.StandardScryptN, "scrypt N parameter for encrypting the new account")
+	accountVanityCmd.Flags().IntVar(&accountVanityScryptP, "scrypt-p", keystore.StandardScryptP, "scrypt P parameter for encrypting the new account")
+}
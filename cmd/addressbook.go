@@ -0,0 +1,77 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/util/addressbook"
+	"github.com/wealdtech/ethereal/v2/util/labels"
+)
+
+// addresses is the user's address book.
+var addresses *addressbook.Book
+
+// knownContracts are the labels of well-known contracts.
+var knownContracts *labels.Database
+
+// addressbookCmd represents the addressbook command
+var addressbookCmd = &cobra.Command{
+	Use:   "addressbook",
+	Short: "Manage the address book",
+	Long:  `Label addresses so that the labels can be used in place of the addresses, and are shown alongside the addresses in output`,
+}
+
+// dataFile returns the path to a file in the data directory.  The data directory is not
+// created, as it is only required when the file is written.
+func dataFile(name string) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ethereal", name), nil
+}
+
+// loadAddressBook loads the address book from the data directory.
+func loadAddressBook() (*addressbook.Book, error) {
+	path, err := dataFile("addressbook.json")
+	if err != nil {
+		return nil, err
+	}
+	return addressbook.New(path)
+}
+
+// loadKnownContracts loads the labels of well-known contracts, including those obtained with
+// addressbook update.
+func loadKnownContracts() (*labels.Database, error) {
+	path, err := dataFile("labels.json")
+	if err != nil {
+		return nil, err
+	}
+	return labels.New(path)
+}
+
+// saveAddressBook saves the address book to the data directory.
+func saveAddressBook() error {
+	if _, err := dataDir(); err != nil {
+		return err
+	}
+	return addresses.Save()
+}
+
+func init() {
+	RootCmd.AddCommand(addressbookCmd)
+}
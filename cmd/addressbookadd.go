@@ -0,0 +1,48 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// addressbookAddCmd represents the addressbook add command
+var addressbookAddCmd = &cobra.Command{
+	Use:   "add [label] [address]",
+	Short: "Add a labelled address to the address book",
+	Long: `Add a labelled address to the address book.  For example:
+
+    ethereal addressbook add vitalik 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045
+
+Labels are case-insensitive, and cannot start with 0x or contain dots or whitespace so that they cannot be confused with addresses or ENS names.  A label that is already in the address book must be removed before it can be added again.
+
+In quiet mode this will return 0 if the address is added, otherwise 1.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		address, err := c.Resolve(args[1])
+		cli.ErrCheck(err, quiet, "Failed to obtain address")
+
+		cli.ErrCheck(addresses.Add(args[0], address), quiet, "Failed to add address")
+		cli.ErrCheck(saveAddressBook(), quiet, "Failed to save address book")
+		outputIf(verbose, fmt.Sprintf("Added %s as %s", address.Hex(), args[0]))
+	},
+}
+
+func init() {
+	offlineCmds["addressbook:add"] = true
+	addressbookCmd.AddCommand(addressbookAddCmd)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// addressbookKnownCmd represents the addressbook known command
+var addressbookKnownCmd = &cobra.Command{
+	Use:   "known",
+	Short: "List the well-known contracts on the chain",
+	Long: `List the well-known contracts on the chain, which are shown with their names in output.  For example:
+
+    ethereal addressbook known
+
+Well-known contracts are built in, and can be extended with "ethereal addressbook update".
+
+In quiet mode this will return 0 if there are any well-known contracts on the chain, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		known := knownContracts.ForChain(c.ChainID().Uint64())
+		if quiet {
+			if len(known) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(known)
+		}
+
+		for _, label := range known {
+			fmt.Printf("%s\t%s\n", label.Name, label.Address.Hex())
+		}
+	},
+}
+
+func init() {
+	addressbookCmd.AddCommand(addressbookKnownCmd)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// addressbookListCmd represents the addressbook list command
+var addressbookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the labelled addresses in the address book",
+	Long: `List the labelled addresses in the address book.  For example:
+
+    ethereal addressbook list
+
+In quiet mode this will return 0 if the address book has any entries, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries := addresses.Entries()
+		if quiet {
+			if len(entries) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			res := make([]map[string]string, len(entries))
+			for i, entry := range entries {
+				res[i] = map[string]string{
+					"label":   entry.Label,
+					"address": entry.Address.Hex(),
+				}
+			}
+			outputJSON(res)
+		}
+
+		for _, entry := range entries {
+			fmt.Printf("%s\t%s\n", entry.Label, entry.Address.Hex())
+		}
+	},
+}
+
+func init() {
+	offlineCmds["addressbook:list"] = true
+	addressbookCmd.AddCommand(addressbookListCmd)
+}
@@ -0,0 +1,43 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// addressbookRemoveCmd represents the addressbook remove command
+var addressbookRemoveCmd = &cobra.Command{
+	Use:   "remove [label]",
+	Short: "Remove a labelled address from the address book",
+	Long: `Remove a labelled address from the address book.  For example:
+
+    ethereal addressbook remove vitalik
+
+In quiet mode this will return 0 if the address is removed, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.ErrCheck(addresses.Remove(args[0]), quiet, "Failed to remove address")
+		cli.ErrCheck(saveAddressBook(), quiet, "Failed to save address book")
+		outputIf(verbose, fmt.Sprintf("Removed %s", args[0]))
+	},
+}
+
+func init() {
+	offlineCmds["addressbook:remove"] = true
+	addressbookCmd.AddCommand(addressbookRemoveCmd)
+}
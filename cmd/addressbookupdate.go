@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/labels"
+)
+
+var addressbookUpdateURL string
+
+// addressbookUpdateCmd represents the addressbook update command
+var addressbookUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the well-known contracts from a remote source",
+	Long: `Update the well-known contracts from a remote source.  For example:
+
+    ethereal addressbook update --url=https://example.com/labels.json
+
+The source is a JSON array of objects with chain_id, address and name fields.  Its contracts are added to the built-in well-known contracts, replacing them where they have the same chain and address.  The source can also be set with 'labels-url' in the configuration file.
+
+In quiet mode this will return 0 if the well-known contracts are updated, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		url := addressbookUpdateURL
+		if url == "" {
+			url = viper.GetString("labels-url")
+		}
+		cli.Assert(url != "", quiet, "--url is required")
+
+		dir, err := dataDir()
+		cli.ErrCheck(err, quiet, "Failed to obtain data directory")
+
+		ctx, cancel := localContext()
+		defer cancel()
+		count, err := labels.Update(ctx, url, filepath.Join(dir, "labels.json"))
+		cli.ErrCheck(err, quiet, "Failed to update well-known contracts")
+		outputIf(!quiet, fmt.Sprintf("Obtained %d well-known contracts", count))
+	},
+}
+
+func init() {
+	offlineCmds["addressbook:update"] = true
+	addressbookCmd.AddCommand(addressbookUpdateCmd)
+	addressbookUpdateCmd.Flags().StringVar(&addressbookUpdateURL, "url", "", "URL from which to obtain well-known contracts (defaults to labels-url in the configuration file)")
+}
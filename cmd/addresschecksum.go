@@ -0,0 +1,55 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// addressChecksumCmd represents the address checksum command
+var addressChecksumCmd = &cobra.Command{
+	Use:   "checksum [address]",
+	Short: "Output the checksummed form of an address",
+	Long: `Output the EIP-55 checksummed form of an address.  For example:
+
+    ethereal address checksum 0x5ffc014343cd971b7eb70732021e26c35b744cc4
+
+The address can be in any case, and the 0x prefix is optional.  An address with mixed case that does not match its checksum is rejected, as it is likely to have been mistyped; use "ethereal address validate" to find out where.
+
+In quiet mode this will return 0 if the address is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		validation, err := util.ValidateAddress(args[0])
+		cli.ErrCheck(err, quiet, "Invalid address")
+		cli.Assert(validation.Checksum != util.ChecksumInvalid, quiet, "Checksum is incorrect; the address is likely to have been mistyped")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": validation.Address.Hex()})
+		}
+		fmt.Println(validation.Address.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["address:checksum"] = true
+	addressCmd.AddCommand(addressChecksumCmd)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// addressFromKeyCmd represents the address fromkey command
+var addressFromKeyCmd = &cobra.Command{
+	Use:   "fromkey [key]",
+	Short: "Derive the address for a private or public key",
+	Long: `Derive the address for a hex private key or public key.  For example:
+
+    ethereal address fromkey 0x02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5
+
+Private keys are 32 bytes, compressed public keys 33 bytes and uncompressed public keys 65 bytes, or 64 bytes without their 0x04 prefix.
+
+In quiet mode this will return 0 if the key is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		address, keyType, err := util.AddressFromKey(args[0])
+		cli.ErrCheck(err, quiet, "Failed to derive address")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": address.Hex(), "key_type": keyType})
+		}
+		outputIf(verbose, fmt.Sprintf("Key is a %s", keyType))
+		fmt.Println(address.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["address:fromkey"] = true
+	addressCmd.AddCommand(addressFromKeyCmd)
+}
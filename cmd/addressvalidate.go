@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var addressValidateStrict bool
+
+// addressValidateCmd represents the address validate command
+var addressValidateCmd = &cobra.Command{
+	Use:   "validate [address]",
+	Short: "Validate an address and its checksum",
+	Long: `Validate the format of an address and its EIP-55 checksum.  For example:
+
+    ethereal address validate 0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+An address that is all lower-case or all upper-case has no checksum, and is valid unless --strict is supplied.  An address with mixed case that does not match its checksum is likely to have been mistyped, and the characters that do not match are shown.
+
+In quiet mode this will return 0 if the address is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		validation, err := util.ValidateAddress(args[0])
+		cli.ErrCheck(err, quiet, "Invalid address")
+		valid := validation.Checksum == util.ChecksumValid || (validation.Checksum == util.ChecksumNone && !addressValidateStrict)
+
+		if quiet {
+			if !valid {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			writeJSON(map[string]interface{}{
+				"address":    validation.Address.Hex(),
+				"checksum":   validation.Checksum.String(),
+				"mismatches": validation.Mismatches,
+				"valid":      valid,
+			})
+		} else {
+			switch validation.Checksum {
+			case util.ChecksumValid:
+				fmt.Println("Address is valid and correctly checksummed")
+			case util.ChecksumNone:
+				fmt.Printf("Address is valid but not checksummed; checksummed address is %s\n", validation.Address.Hex())
+			case util.ChecksumInvalid:
+				input := strings.TrimSpace(args[0])
+				offset := len(input) - 2*len(validation.Address)
+				markers := []byte(strings.Repeat(" ", len(input)))
+				for _, mismatch := range validation.Mismatches {
+					markers[offset+mismatch] = '^'
+				}
+				fmt.Printf("Checksum is incorrect; the address is likely to have been mistyped\n%s\n%s\n", input, strings.TrimRight(string(markers), " "))
+			}
+		}
+		if !valid {
+			os.Exit(exitFailure)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	offlineCmds["address:validate"] = true
+	addressCmd.AddCommand(addressValidateCmd)
+	addressValidateCmd.Flags().BoolVar(&addressValidateStrict, "strict", false, "Require the address to be checksummed")
+}
@@ -20,8 +20,8 @@ import (
 // beaconCmd represents the beacon command
 var beaconCmd = &cobra.Command{
 	Use:   "beacon",
-	Short: "Manage beacon chain deposits",
-	Long:  `Manage beacon chain deposits.`,
+	Short: "Manage beacon chain deposits and validators",
+	Long:  `Manage beacon chain deposits and obtain information about validators.`,
 }
 
 func init() {
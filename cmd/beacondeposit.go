@@ -16,7 +16,6 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,7 +27,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -37,7 +35,6 @@ import (
 	"github.com/wealdtech/ethereal/v2/conn"
 	"github.com/wealdtech/ethereal/v2/util"
 	"github.com/wealdtech/ethereal/v2/util/contracts"
-	ens "github.com/wealdtech/go-ens/v3"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
 
@@ -53,6 +50,7 @@ var beaconDepositAllowUnknownContract bool
 var beaconDepositAllowDuplicateDeposit bool
 var beaconDepositContractAddress string
 var beaconDepositEth2Network string
+var beaconDepositYes bool
 
 type beaconDepositContract struct {
 	network     string
@@ -109,7 +107,9 @@ var beaconDepositCmd = &cobra.Command{
 
     ethereal beacon deposit --data=/home/me/depositdata.json --from=0x.... --passphrase="my secret passphrase"
 
-The depositdata.json file can be generated by ethdo.  The data can be an array of deposits, in which case they will be processed sequentially.
+The depositdata.json file can be generated by ethdo or staking-deposit-cli.  The data can be an array of deposits, in which case they will be processed sequentially.
+
+Before any deposit is sent the withdrawal credentials, amount, deposit roots and BLS signature of every deposit are checked, and if any fail then no deposits are sent.  A summary of the deposits is then shown and the name of the network must be typed to confirm them; use --yes to skip this confirmation when running non-interactively.
 
 The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
@@ -130,18 +130,35 @@ If you are *completely sure* you know what you are doing, you can use the --allo
 		cli.Assert(c.ChainID().Cmp(contract.chainID) == 0, quiet, "Ethereal is not connected to the correct Ethereum 1 network.  Please ensure that if you are depositing for the mainnet deposit contract you are on the Ethereum 1 mainnet, and likewise for test networks.")
 
 		// Confirm the deposit data before sending any.
+		publicKeys := make(map[string]bool)
 		for i := range depositInfo {
 			cli.Assert(len(depositInfo[i].PublicKey) > 0, quiet, fmt.Sprintf("No public key for deposit %d", i))
 			cli.Assert(len(depositInfo[i].DepositDataRoot) > 0, quiet, fmt.Sprintf("No data root for deposit %d", i))
 			cli.Assert(len(depositInfo[i].Signature) > 0, quiet, fmt.Sprintf("No signature for deposit %d", i))
 			cli.Assert(len(depositInfo[i].WithdrawalCredentials) > 0, quiet, fmt.Sprintf("No withdrawal credentials for deposit %d", i))
+			cli.Assert(!publicKeys[fmt.Sprintf("%x", depositInfo[i].PublicKey)] || beaconDepositAllowDuplicateDeposit, quiet, fmt.Sprintf("Deposit %d duplicates the public key of an earlier deposit.  If you really want to do this use the --allow-duplicate-deposit option.", i))
+			publicKeys[fmt.Sprintf("%x", depositInfo[i].PublicKey)] = true
 			if len(contract.forkVersion) != 0 && len(depositInfo[i].ForkVersion) != 0 {
 				cli.Assert(bytes.Equal(depositInfo[i].ForkVersion, contract.forkVersion), quiet, fmt.Sprintf("Incorrect fork version for deposit %d (expected %#x, found %#x)", i, contract.forkVersion, depositInfo[i].ForkVersion))
 			}
+			if depositInfo[i].Amount == 0 {
+				// Older deposit data does not contain the amount, so it comes from the command line.
+				cli.Assert(viper.GetString("value") != "", quiet, fmt.Sprintf("No value from either deposit data or command line for deposit %d", i))
+				value, err := string2eth.StringToWei(viper.GetString("value"))
+				cli.ErrCheck(err, quiet, "Failed to understand value")
+				depositInfo[i].Amount = new(big.Int).Div(value, big.NewInt(1000000000)).Uint64()
+			}
+			cli.ErrCheck(util.ValidateWithdrawalCredentials(depositInfo[i].WithdrawalCredentials), quiet, fmt.Sprintf("Invalid withdrawal credentials for deposit %d", i))
 			cli.Assert(depositInfo[i].Amount >= 1000000000, quiet, fmt.Sprintf("Deposit too small for deposit %d", i))
-			cli.Assert(depositInfo[i].Amount <= 32000000000 || beaconDepositAllowExcessiveDeposit, quiet, fmt.Sprintf(`Deposit more than 32 Ether for deposit %d.  Any amount above 32 Ether that is deposited will not count towards the validator's effective balance, and is effectively wasted.
+			if depositInfo[i].WithdrawalCredentials[0] == util.CompoundingWithdrawalPrefix {
+				cli.Assert(depositInfo[i].Amount <= 2048000000000 || beaconDepositAllowExcessiveDeposit, quiet, fmt.Sprintf(`Deposit more than 2048 Ether for deposit %d.  Any amount above 2048 Ether that is deposited will not count towards the validator's effective balance.
+
+If you really want to do this use the --allow-excessive-deposit option.`, i))
+			} else {
+				cli.Assert(depositInfo[i].Amount <= 32000000000 || beaconDepositAllowExcessiveDeposit, quiet, fmt.Sprintf(`Deposit more than 32 Ether for deposit %d.  Any amount above 32 Ether that is deposited will not count towards the validator's effective balance, and is effectively wasted.
 
 If you really want to do this use the --allow-excessive-deposit option.`, i))
+			}
 
 			cli.Assert(beaconDepositAllowOldData || depositInfo[i].Version >= contract.minVersion, quiet, `Data generated by ethdo is old and possibly inaccurate.  This means you need to upgrade your version of ethdo (or you are sending your deposit to the wrong contract or network); please do so by visiting https://github.com/wealdtech/ethdo and following the installation instructions there.  Once you have done this please regenerate your deposit data and try again.
 
@@ -149,37 +166,58 @@ If you are *completely sure* you know what you are doing, you can use the --allo
 			cli.Assert(beaconDepositAllowNewData || depositInfo[i].Version <= contract.maxVersion, quiet, `Data generated by ethdo is newer than supported.  This means you need to upgrade your version of ethereal (or you are sending your deposit to the wrong contract or network); please do so by visiting https://github.com/wealdtech/ethereal and following the installation instructions there.  Once you have done this please try again.
 
 If you are *completely sure* you know what you are doing, you can use the --allow-new-data option to carry out this transaction.  Otherwise, please seek support to ensure you do not lose your Ether.`)
+
+			forkVersion := depositInfo[i].ForkVersion
+			if len(forkVersion) == 0 {
+				forkVersion = contract.forkVersion
+			}
+			cli.Assert(len(forkVersion) != 0, quiet, fmt.Sprintf("Fork version for deposit %d is unknown, so its signature cannot be verified.  Please regenerate the deposit data with a tool that includes the fork version.", i))
+			cli.ErrCheck(util.VerifyDeposit(depositInfo[i], forkVersion), quiet, fmt.Sprintf(`Deposit %d failed verification.  This means the deposit data has been altered, is corrupt, or was generated for a different network; a deposit with invalid data will be rejected by the beacon chain and the Ether lost.  Please regenerate your deposit data and try again`, i))
 		}
 
+		beaconDepositFrom = accountOrDefault(beaconDepositFrom)
 		cli.Assert(beaconDepositFrom != "", quiet, "--from is required")
-		fromAddress, err := resolveAddress(c.Client(), beaconDepositFrom)
+		fromAddress, err := c.Resolve(beaconDepositFrom)
 		cli.ErrCheck(err, quiet, "Failed to obtain address for --from")
 
 		if offline {
 			sendOffline(c, depositInfo, contract, fromAddress)
 		} else {
+			if !beaconDepositYes {
+				confirmDeposits(depositInfo, contract, fromAddress)
+			}
 			sendOnline(depositInfo, contract, fromAddress)
 		}
 		os.Exit(exitSuccess)
 	},
 }
 
-func resolveAddress(client *ethclient.Client, input string) (common.Address, error) {
-	switch {
-	case strings.Contains(input, "."):
-		if client == nil {
-			return common.Address{}, errors.New("cannot resolve ENS names when offline")
-		}
-		return ens.Resolve(client, input)
-	case len(strings.TrimPrefix(input, "0x")) > 40:
-		return common.Address{}, errors.New("address to long")
-	default:
-		address := common.HexToAddress(input)
-		if address == ens.UnknownAddress {
-			err = errors.New("could not parse address")
+// confirmDeposits shows a summary of the deposits and requires the user to confirm them by typing the network name.
+func confirmDeposits(deposits []*util.DepositInfo, contractDetails *beaconDepositContract, fromAddress common.Address) {
+	cli.Assert(cli.IsTerminal(), quiet, "Deposits must be confirmed interactively; if you are sure they are correct use the --yes option to send them without confirmation")
+
+	builder := new(strings.Builder)
+	total := big.NewInt(0)
+	for i, deposit := range deposits {
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(deposit.Amount), big.NewInt(1000000000))
+		total.Add(total, amount)
+		builder.WriteString(fmt.Sprintf("Deposit %d:\n", i))
+		builder.WriteString(fmt.Sprintf("  Public key:\t\t\t%#x\n", deposit.PublicKey))
+		builder.WriteString(fmt.Sprintf("  Withdrawal credentials:\t%#x\n", deposit.WithdrawalCredentials))
+		if deposit.WithdrawalCredentials[0] == util.BLSWithdrawalPrefix {
+			builder.WriteString("  WARNING: withdrawal credentials are for a BLS key, and must be changed to an execution address before funds can be withdrawn\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("  Withdrawal address:\t\t%s\n", common.BytesToAddress(deposit.WithdrawalCredentials[12:]).Hex()))
 		}
-		return address, nil
+		builder.WriteString(fmt.Sprintf("  Amount:\t\t\t%s\n", formatWei(amount)))
 	}
+	builder.WriteString(fmt.Sprintf("\nSending %d deposit(s) totalling %s from %s to the %s deposit contract %s.  Deposits cannot be reversed.\n",
+		len(deposits), formatWei(total), fromAddress.Hex(), contractDetails.network, common.BytesToAddress(contractDetails.address).Hex()))
+	fmt.Fprint(os.Stderr, builder.String())
+
+	confirmation, err := cli.Prompt(fmt.Sprintf("Type %q to confirm: ", strings.ToLower(contractDetails.network)))
+	cli.ErrCheck(err, quiet, "Failed to obtain confirmation")
+	cli.Assert(strings.EqualFold(confirmation, contractDetails.network), quiet, "Deposits not confirmed; nothing sent")
 }
 
 func loadDepositInfo(input string) ([]*util.DepositInfo, error) {
@@ -242,10 +280,7 @@ func sendOffline(c *conn.Conn, deposits []*util.DepositInfo, contractDetails *be
 				Data:     dataBytes,
 			})
 		cli.ErrCheck(err, quiet, "Failed to create signed transaction")
-		buf := new(bytes.Buffer)
-		err = signedTx.EncodeRLP(buf)
-		cli.ErrCheck(err, quiet, "Failed to encode signed transaction")
-		fmt.Printf("%#x\n", buf.Bytes())
+		outputSignedTransaction(signedTx)
 	}
 }
 
@@ -274,14 +309,12 @@ func sendOnline(deposits []*util.DepositInfo, contractDetails *beaconDepositCont
 		// https://raw.githubusercontent.com/runtimeverification/deposit-contract-verification/master/deposit-contract-verification.pdf
 		opts.GasLimit = 160000
 
-		// Would be good to recalculate signature to ensure correcteness, but need a pure Go BLS implementation.
-
 		// Check thegraph to see if there is already a deposit for this validator public key.
 		if contractDetails.subgraph != "" {
 			cli.ErrCheck(graphCheck(contractDetails.subgraph, deposit.PublicKey, opts.Value.Uint64(), deposit.WithdrawalCredentials), quiet, "Existing deposit check")
 		}
 
-		outputIf(verbose, fmt.Sprintf("Creating %s deposit for %s", string2eth.WeiToString(big.NewInt(int64(deposit.Amount)), true), deposit.Account))
+		outputIf(verbose, fmt.Sprintf("Creating %s deposit for %s", formatWei(big.NewInt(int64(deposit.Amount))), deposit.Account))
 
 		_, err = c.NextNonce(context.Background(), fromAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain next nonce")
@@ -353,13 +386,13 @@ func graphCheck(subgraph string, validatorPubKey []byte, amount uint64, withdraw
 		if totalDeposited >= 32000000000 {
 			if !beaconDepositAllowDuplicateDeposit {
 				depositedWei := new(big.Int).Mul(big.NewInt(totalDeposited), big.NewInt(1000000000))
-				return fmt.Errorf("there has already been %s deposited to this validator.  If you really want to add more funds to this validator use the --allow-duplicate-deposit option", string2eth.WeiToString(depositedWei, true))
+				return fmt.Errorf("there has already been %s deposited to this validator.  If you really want to add more funds to this validator use the --allow-duplicate-deposit option", formatWei(depositedWei))
 			}
 		}
 		if totalDeposited+int64(amount) > 32000000000 {
 			if !(beaconDepositAllowDuplicateDeposit || beaconDepositAllowExcessiveDeposit) {
 				totalWei := new(big.Int).Mul(big.NewInt(totalDeposited+int64(amount)), big.NewInt(1000000000))
-				return fmt.Errorf("this deposit will increase the validator's total deposits to %s.   If you really want to add these funds to this validator use the --allow-duplicate-deposit and --allow-excessive-deposit options", string2eth.WeiToString(totalWei, true))
+				return fmt.Errorf("this deposit will increase the validator's total deposits to %s.   If you really want to add these funds to this validator use the --allow-duplicate-deposit and --allow-excessive-deposit options", formatWei(totalWei))
 			}
 		}
 	}
@@ -368,12 +401,12 @@ func graphCheck(subgraph string, validatorPubKey []byte, amount uint64, withdraw
 
 func fetchBeaconDepositContract(contractAddress string, network string) (*beaconDepositContract, error) {
 	var address []byte
-	var err error
 	if contractAddress != "" {
-		address, err = hex.DecodeString(strings.TrimPrefix(contractAddress, "0x"))
+		resolved, err := c.Resolve(contractAddress)
 		if err != nil {
 			return nil, errors.Wrap(err, "invalid contract address")
 		}
+		address = resolved.Bytes()
 	}
 
 	if len(address) > 0 {
@@ -420,6 +453,7 @@ func init() {
 	beaconDepositCmd.Flags().BoolVar(&beaconDepositAllowOldData, "allow-old-data", false, "Allow sending from an older version of deposit data than supported (WARNING: only if you know what you are doing)")
 	beaconDepositCmd.Flags().BoolVar(&beaconDepositAllowNewData, "allow-new-data", false, "Allow sending from a newer version of deposit data than supported (WARNING: only if you know what you are doing)")
 	beaconDepositCmd.Flags().BoolVar(&beaconDepositAllowExcessiveDeposit, "allow-excessive-deposit", false, "Allow sending more than 32 Ether in a single deposit (WARNING: only if you know what you are doing)")
+	beaconDepositCmd.Flags().BoolVar(&beaconDepositYes, "yes", false, "Send the deposits without interactive confirmation")
 	beaconDepositCmd.Flags().BoolVar(&beaconDepositAllowDuplicateDeposit, "allow-duplicate-deposit", false, "Allow sending multiple deposits with the same validator public key (WARNING: only if you know what you are doing)")
 	beaconDepositCmd.Flags().StringVar(&beaconDepositContractAddress, "address", "", "The contract address to which to send the deposit (overrides the value obtained from eth2network)")
 	beaconDepositCmd.Flags().StringVar(&beaconDepositEth2Network, "eth2network", "mainnet", "The name of the Ethereum 2 network for which to send the deposit (mainnet/prater/ropsten)")
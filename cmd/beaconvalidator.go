@@ -0,0 +1,140 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/beaconapi"
+)
+
+var beaconValidatorID string
+
+// beaconValidatorCmd represents the beacon validator command
+var beaconValidatorCmd = &cobra.Command{
+	Use:   "validator",
+	Short: "Obtain information about beacon chain validators",
+	Long: `Obtain information about beacon chain validators from a beacon node.
+
+The beacon node is supplied with --beacon-connection, or with beacon-connection in the configuration file or network profile.`,
+}
+
+func init() {
+	beaconCmd.AddCommand(beaconValidatorCmd)
+}
+
+func beaconValidatorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&beaconValidatorID, "validator", "", "The index or public key of the validator")
+	cmd.Flags().String("beacon-connection", "", "The URL of the REST API of a beacon node, for example http://localhost:5052/")
+	beaconFlags(cmd)
+}
+
+// beaconValidatorState is the state of a validator and the chain at the time it was obtained.
+type beaconValidatorState struct {
+	validator *beaconapi.Validator
+	spec      *beaconapi.Spec
+	genesis   time.Time
+	epoch     uint64
+}
+
+// fetchBeaconValidatorState fetches the state of the validator supplied with --validator.
+func fetchBeaconValidatorState(ctx context.Context) (*beaconValidatorState, error) {
+	url := viper.GetString("beacon-connection")
+	if url == "" {
+		return nil, errors.New("no beacon node; supply one with --beacon-connection")
+	}
+	client := beaconapi.New(url)
+
+	validator, err := client.Validator(ctx, beaconValidatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validator")
+	}
+	spec, err := client.Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain chain specification")
+	}
+	genesis, err := client.Genesis(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis")
+	}
+	slot, err := client.HeadSlot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain head of chain")
+	}
+	return &beaconValidatorState{
+		validator: validator,
+		spec:      spec,
+		genesis:   genesis,
+		epoch:     slot / spec.SlotsPerEpoch,
+	}, nil
+}
+
+// beaconValidatorCheckID checks the validator supplied with --validator.
+func beaconValidatorCheckID() {
+	cli.Assert(beaconValidatorID != "", quiet, "--validator is required")
+	if strings.HasPrefix(beaconValidatorID, "0x") {
+		cli.Assert(len(common.FromHex(beaconValidatorID)) == 48, quiet, "Validator public key must be 48 bytes")
+	}
+}
+
+// withdrawalAddress returns the execution address of the validator's withdrawal credentials, or nil
+// if the credentials are for a BLS key.
+func (s *beaconValidatorState) withdrawalAddress() *common.Address {
+	credentials := s.validator.WithdrawalCredentials
+	if len(credentials) != 32 || (credentials[0] != util.ExecutionWithdrawalPrefix && credentials[0] != util.CompoundingWithdrawalPrefix) {
+		return nil
+	}
+	address := common.BytesToAddress(credentials[12:])
+	return &address
+}
+
+// exitReadiness returns true if the validator can submit a voluntary exit, otherwise false with the reason.
+func (s *beaconValidatorState) exitReadiness() (bool, string) {
+	validator := s.validator
+	switch {
+	case validator.Slashed:
+		return false, "validator has been slashed"
+	case validator.ExitEpoch != beaconapi.FarFutureEpoch:
+		return false, fmt.Sprintf("validator is already exiting at epoch %s", s.formatEpoch(validator.ExitEpoch))
+	case validator.ActivationEpoch == beaconapi.FarFutureEpoch || validator.ActivationEpoch > s.epoch:
+		return false, "validator is not yet active"
+	}
+	if exitable := validator.ExitableEpoch(s.spec); exitable > s.epoch {
+		return false, fmt.Sprintf("validator can exit from epoch %s", s.formatEpoch(exitable))
+	}
+	return true, ""
+}
+
+// formatEpoch formats an epoch with its approximate time.
+func (s *beaconValidatorState) formatEpoch(epoch uint64) string {
+	if epoch == beaconapi.FarFutureEpoch {
+		return "never"
+	}
+	return fmt.Sprintf("%d (%s)", epoch, s.spec.EpochTime(s.genesis, epoch).Format(time.RFC3339))
+}
+
+// gweiToWei converts a value in gwei to wei.
+func gweiToWei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(1000000000))
+}
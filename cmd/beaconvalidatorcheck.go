@@ -0,0 +1,109 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var beaconValidatorCheckWithdrawalAddress string
+
+// beaconValidatorCheckCmd represents the beacon validator check command
+var beaconValidatorCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that a validator can exit and withdraw",
+	Long: `Check that a beacon chain validator can submit a voluntary exit, and that its funds will be withdrawn to an execution address.  For example:
+
+    ethereal beacon validator check --validator=1234 --withdrawal-address=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --beacon-connection=http://localhost:5052/
+
+If --withdrawal-address is supplied the validator's withdrawal credentials must be for that address.
+
+This will return an exit status of 0 if all checks pass, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		beaconValidatorCheckID()
+
+		ctx, cancel := localContext()
+		defer cancel()
+		state, err := fetchBeaconValidatorState(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain validator state")
+
+		res := &beaconValidatorCheckJSON{
+			Index: state.validator.Index,
+		}
+		withdrawalAddress := state.withdrawalAddress()
+		switch {
+		case withdrawalAddress == nil:
+			res.WithdrawalAddressReason = "withdrawal credentials are for a BLS key, and must be changed to an execution address"
+		case beaconValidatorCheckWithdrawalAddress != "":
+			res.WithdrawalAddress = withdrawalAddress.Hex()
+			expected, err := c.Resolve(beaconValidatorCheckWithdrawalAddress)
+			cli.ErrCheck(err, quiet, "Failed to resolve withdrawal address")
+			if *withdrawalAddress == expected {
+				res.WithdrawalAddressPassed = true
+			} else {
+				res.WithdrawalAddressReason = fmt.Sprintf("withdrawal credentials are for %s rather than %s", withdrawalAddress.Hex(), expected.Hex())
+			}
+		default:
+			res.WithdrawalAddress = withdrawalAddress.Hex()
+			res.WithdrawalAddressPassed = true
+		}
+		res.ExitPassed, res.ExitReason = state.exitReadiness()
+		res.Passed = res.WithdrawalAddressPassed && res.ExitPassed
+
+		switch {
+		case quiet:
+		case jsonOutput():
+			writeJSON(res)
+		default:
+			builder := new(strings.Builder)
+			if res.WithdrawalAddressPassed {
+				builder.WriteString(fmt.Sprintf("Withdrawal address:\tpassed; %s\n", res.WithdrawalAddress))
+			} else {
+				builder.WriteString(fmt.Sprintf("Withdrawal address:\tfailed; %s\n", res.WithdrawalAddressReason))
+			}
+			if res.ExitPassed {
+				builder.WriteString("Exit:\t\t\tpassed\n")
+			} else {
+				builder.WriteString(fmt.Sprintf("Exit:\t\t\tfailed; %s\n", res.ExitReason))
+			}
+			fmt.Print(builder.String())
+		}
+		if !res.Passed {
+			os.Exit(exitFailure)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// beaconValidatorCheckJSON is the JSON output for the checks of a validator.
+type beaconValidatorCheckJSON struct {
+	Index                   uint64 `json:"index"`
+	WithdrawalAddress       string `json:"withdrawal_address,omitempty"`
+	WithdrawalAddressPassed bool   `json:"withdrawal_address_passed"`
+	WithdrawalAddressReason string `json:"withdrawal_address_reason,omitempty"`
+	ExitPassed              bool   `json:"exit_passed"`
+	ExitReason              string `json:"exit_reason,omitempty"`
+	Passed                  bool   `json:"passed"`
+}
+
+func init() {
+	beaconValidatorCmd.AddCommand(beaconValidatorCheckCmd)
+	beaconValidatorFlags(beaconValidatorCheckCmd)
+	beaconValidatorCheckCmd.Flags().StringVar(&beaconValidatorCheckWithdrawalAddress, "withdrawal-address", "", "The address to which the validator's funds should be withdrawn")
+}
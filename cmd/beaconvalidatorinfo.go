@@ -0,0 +1,137 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/beaconapi"
+)
+
+// beaconValidatorInfoCmd represents the beacon validator info command
+var beaconValidatorInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about a validator",
+	Long: `Obtain information about a beacon chain validator, along with the execution layer balance of its withdrawal address.  For example:
+
+    ethereal beacon validator info --validator=1234 --beacon-connection=http://localhost:5052/
+
+The validator can be supplied as its index or its public key.
+
+In quiet mode this will return 0 if the validator exists, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		beaconValidatorCheckID()
+
+		ctx, cancel := localContext()
+		defer cancel()
+		state, err := fetchBeaconValidatorState(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain validator state")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		validator := state.validator
+		exitable, exitReason := state.exitReadiness()
+		withdrawalAddress := state.withdrawalAddress()
+
+		if jsonOutput() {
+			res := &beaconValidatorInfoJSON{
+				Index:                 validator.Index,
+				PublicKey:             fmt.Sprintf("%#x", validator.PublicKey),
+				Status:                validator.Status,
+				Balance:               gweiToWei(validator.Balance).String(),
+				EffectiveBalance:      gweiToWei(validator.EffectiveBalance).String(),
+				Slashed:               validator.Slashed,
+				ActivationEpoch:       epochJSON(validator.ActivationEpoch),
+				ExitEpoch:             epochJSON(validator.ExitEpoch),
+				WithdrawableEpoch:     epochJSON(validator.WithdrawableEpoch),
+				WithdrawalCredentials: fmt.Sprintf("%#x", validator.WithdrawalCredentials),
+				Exitable:              exitable,
+				ExitableEpoch:         epochJSON(validator.ExitableEpoch(state.spec)),
+			}
+			if withdrawalAddress != nil {
+				res.WithdrawalAddress = withdrawalAddress.Hex()
+				balance, err := c.Client().BalanceAt(ctx, *withdrawalAddress, nil)
+				cli.ErrCheck(err, quiet, "Failed to obtain balance of withdrawal address")
+				res.WithdrawalAddressBalance = balance.String()
+			}
+			outputJSON(res)
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Index:\t\t\t%d\n", validator.Index))
+		builder.WriteString(fmt.Sprintf("Public key:\t\t%#x\n", validator.PublicKey))
+		builder.WriteString(fmt.Sprintf("Status:\t\t\t%s\n", validator.Status))
+		builder.WriteString(fmt.Sprintf("Balance:\t\t%s\n", formatWei(gweiToWei(validator.Balance))))
+		builder.WriteString(fmt.Sprintf("Effective balance:\t%s\n", formatWei(gweiToWei(validator.EffectiveBalance))))
+		if validator.Slashed {
+			builder.WriteString("Slashed:\t\ttrue\n")
+		}
+		builder.WriteString(fmt.Sprintf("Activation epoch:\t%s\n", state.formatEpoch(validator.ActivationEpoch)))
+		if validator.ExitEpoch != beaconapi.FarFutureEpoch {
+			builder.WriteString(fmt.Sprintf("Exit epoch:\t\t%s\n", state.formatEpoch(validator.ExitEpoch)))
+			builder.WriteString(fmt.Sprintf("Withdrawable epoch:\t%s\n", state.formatEpoch(validator.WithdrawableEpoch)))
+		}
+		builder.WriteString(fmt.Sprintf("Withdrawal credentials:\t%#x\n", validator.WithdrawalCredentials))
+		if withdrawalAddress == nil {
+			builder.WriteString("Withdrawal address:\tNone (credentials are for a BLS key)\n")
+		} else {
+			balance, err := c.Client().BalanceAt(ctx, *withdrawalAddress, nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain balance of withdrawal address")
+			builder.WriteString(fmt.Sprintf("Withdrawal address:\t%s (balance %s)\n", withdrawalAddress.Hex(), formatWei(balance)))
+		}
+		if exitable {
+			builder.WriteString("Can exit:\t\tyes\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("Can exit:\t\tno; %s\n", exitReason))
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// beaconValidatorInfoJSON is the JSON output for information about a validator.
+type beaconValidatorInfoJSON struct {
+	Index                    uint64  `json:"index"`
+	PublicKey                string  `json:"public_key"`
+	Status                   string  `json:"status"`
+	Balance                  string  `json:"balance"`
+	EffectiveBalance         string  `json:"effective_balance"`
+	Slashed                  bool    `json:"slashed"`
+	ActivationEpoch          *uint64 `json:"activation_epoch,omitempty"`
+	ExitEpoch                *uint64 `json:"exit_epoch,omitempty"`
+	WithdrawableEpoch        *uint64 `json:"withdrawable_epoch,omitempty"`
+	WithdrawalCredentials    string  `json:"withdrawal_credentials"`
+	WithdrawalAddress        string  `json:"withdrawal_address,omitempty"`
+	WithdrawalAddressBalance string  `json:"withdrawal_address_balance,omitempty"`
+	Exitable                 bool    `json:"exitable"`
+	ExitableEpoch            *uint64 `json:"exitable_epoch,omitempty"`
+}
+
+// epochJSON returns the epoch for JSON output, or nil if it is not scheduled.
+func epochJSON(epoch uint64) *uint64 {
+	if epoch == beaconapi.FarFutureEpoch {
+		return nil
+	}
+	return &epoch
+}
+
+func init() {
+	beaconValidatorCmd.AddCommand(beaconValidatorInfoCmd)
+	beaconValidatorFlags(beaconValidatorInfoCmd)
+}
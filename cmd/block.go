@@ -14,7 +14,16 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
 )
 
 var blockStr string
@@ -29,6 +38,64 @@ var blockCmd = &cobra.Command{
 func init() {
 	RootCmd.AddCommand(blockCmd)
 }
+
 func blockFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&blockStr, "block", "latest", "block hash or number, or 'latest'")
 }
+
+// blockCallOpts returns the options for read-only contract calls at the given block, or nil for
+// the latest block.
+func blockCallOpts(input string) *bind.CallOpts {
+	ctx, cancel := localContext()
+	defer cancel()
+	blockNumber, err := parseBlockNumber(ctx, input)
+	cli.ErrCheck(err, quiet, "Invalid block")
+	if blockNumber == nil {
+		return nil
+	}
+	return &bind.CallOpts{BlockNumber: blockNumber}
+}
+
+// parseBlockNumber parses a block number supplied as a decimal or hex number, as a block hash,
+// as an offset from the latest block (for example "-100"), or as "latest" or "earliest".
+// A nil result refers to the latest block.
+func parseBlockNumber(ctx context.Context, input string) (*big.Int, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	switch {
+	case input == "" || input == "latest":
+		return nil, nil
+	case input == "earliest":
+		return big.NewInt(0), nil
+	case strings.HasPrefix(input, "0x") && len(input) == 66:
+		header, err := c.Client().HeaderByHash(ctx, common.HexToHash(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain block %s: %v", input, err)
+		}
+		return header.Number, nil
+	case strings.HasPrefix(input, "0x"):
+		number, success := new(big.Int).SetString(input[2:], 16)
+		if !success {
+			return nil, fmt.Errorf("invalid block number %s", input)
+		}
+		return number, nil
+	case strings.HasPrefix(input, "-"):
+		offset, err := strconv.ParseUint(input[1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block offset %s", input)
+		}
+		latest, err := c.Client().BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if offset > latest {
+			return big.NewInt(0), nil
+		}
+		return new(big.Int).SetUint64(latest - offset), nil
+	default:
+		number, success := new(big.Int).SetString(input, 10)
+		if !success {
+			return nil, fmt.Errorf("invalid block number %s", input)
+		}
+		return number, nil
+	}
+}
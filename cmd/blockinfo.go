@@ -15,7 +15,6 @@ package cmd
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"math/big"
 	"os"
@@ -24,17 +23,15 @@ import (
 	"time"
 	"unicode/utf8"
 
-	execclient "github.com/attestantio/go-execution-client"
-	"github.com/attestantio/go-execution-client/jsonrpc"
-	"github.com/attestantio/go-execution-client/spec"
-	"github.com/attestantio/go-execution-client/types"
-	"github.com/rs/zerolog"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	string2eth "github.com/wealdtech/go-string2eth"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util/output"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
 )
 
-var blockInfoTransactions bool
+var blockInfoOpts blockInfoOptions
 
 var blockInfoNumberRegexp = regexp.MustCompile("^[0-9]+$")
 
@@ -46,108 +43,161 @@ var blockInfoCmd = &cobra.Command{
 
     ethereal block info --block=0xfdf173c82f1e3e393166719ddc580c161b622fa504fa4b2ddd55f174af554fb7
 
+The block can be supplied as a number, a hash, an offset from the latest block (for example -100), or one of the tags latest, earliest, pending, safe or finalized.  With --transactions a summary of each transaction in the block is shown.  With --withdrawals each withdrawal in the block is shown, and with --uncles the headers of the block's uncles are shown.
+
 In quiet mode this will return 0 if the block exists, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
 		cli.Assert(blockStr != "", quiet, "--block is required")
+		outputBlock(blockStr, &blockInfoOpts)
+	},
+}
 
-		connectionAddress, err := connectionAddress(ctx)
-		cli.ErrCheck(err, quiet, "Failed to obtain connection address")
-
-		execClient, err := jsonrpc.New(context.Background(),
-			jsonrpc.WithLogLevel(zerolog.Disabled),
-			jsonrpc.WithAddress(connectionAddress),
-		)
-		cli.ErrCheck(err, quiet, "Failed to access client")
-		block, err := execClient.(execclient.BlocksProvider).Block(ctx, blockStr)
-		cli.ErrCheck(err, quiet, "Failed to access block")
-
-		if quiet {
-			os.Exit(exitSuccess)
-		}
+// blockInfoOptions are the options for the information output about a block.
+type blockInfoOptions struct {
+	transactions bool
+	withdrawals  bool
+	uncles       bool
+}
 
-		res := strings.Builder{}
-
-		switch block.Fork {
-		case spec.ForkBerlin:
-			info, err := outputBerlinText(ctx, block.Berlin)
-			cli.ErrCheck(err, quiet, "failed to obtain berlin block info")
-			res.WriteString(info)
-		case spec.ForkLondon:
-			info, err := outputLondonText(ctx, execClient, block.London)
-			cli.ErrCheck(err, quiet, "failed to obtain london block info")
-			res.WriteString(info)
-		default:
-			res.WriteString("Unhandled block fork ")
-			res.WriteString(block.Fork.String())
+// blockInfoFlags adds the flags for the information output about a block.
+func blockInfoFlags(cmd *cobra.Command, opts *blockInfoOptions) {
+	cmd.Flags().BoolVar(&opts.transactions, "transactions", false, "Display a summary of each transaction in the block")
+	cmd.Flags().BoolVar(&opts.withdrawals, "withdrawals", false, "Display each withdrawal in the block")
+	cmd.Flags().BoolVar(&opts.uncles, "uncles", false, "Display the headers of the block's uncles")
+}
 
-		}
-		fmt.Println(res.String())
-	},
+// blockInfoJSON is the JSON output for information about a block.
+type blockInfoJSON struct {
+	*conn.Block
+	UncleHeaders []*conn.Block `json:"uncle_headers,omitempty"`
 }
 
-func outputBerlinText(ctx context.Context, block *spec.BerlinBlock) (string, error) {
-	builder := new(strings.Builder)
-	outputNumber(builder, block.Number)
-	outputHash(builder, block.Hash)
-	outputTimestamp(builder, block.Timestamp)
-	outputGas(builder, block.GasUsed, block.GasLimit)
-	if verbose {
-		outputCoinbase(builder, block.Miner)
-		outputExtraData(builder, block.ExtraData)
-		outputDifficulty(builder, block.Difficulty)
-		outputTotalDifficulty(builder, block.TotalDifficulty)
+// outputBlock obtains the block with the given ID and outputs information about it, then exits.
+func outputBlock(id string, opts *blockInfoOptions) {
+	ctx, cancel := localContext()
+	defer cancel()
+
+	if strings.HasPrefix(id, "-") {
+		// Offset from the latest block.
+		number, err := parseBlockNumber(ctx, id)
+		cli.ErrCheck(err, quiet, "Failed to parse block")
+		id = number.String()
+	}
+	block, err := c.Block(ctx, id, opts.transactions)
+	cli.ErrCheck(err, quiet, "Failed to obtain block")
+	var uncles []*conn.Block
+	if opts.uncles {
+		uncles, err = c.Uncles(ctx, block)
+		cli.ErrCheck(err, quiet, "Failed to obtain uncles")
 	}
-	outputUncles(builder, block.Uncles, verbose)
-	outputTransactions(builder, block.Transactions, verbose)
 
-	return builder.String(), nil
-}
+	if quiet {
+		os.Exit(exitSuccess)
+	}
+	if jsonOutput() {
+		outputJSON(&blockInfoJSON{
+			Block:        block,
+			UncleHeaders: uncles,
+		})
+	}
 
-func outputLondonText(ctx context.Context, execClient execclient.Service, block *spec.LondonBlock) (string, error) {
 	builder := new(strings.Builder)
 	outputNumber(builder, block.Number)
 	outputHash(builder, block.Hash)
+	outputParentHash(builder, block.ParentHash)
 	outputTimestamp(builder, block.Timestamp)
-	outputBaseFee(builder, block.BaseFeePerGas)
+	if block.BaseFeePerGas != nil {
+		outputBaseFee(builder, block.BaseFeePerGas)
+	}
 	outputGas(builder, block.GasUsed, block.GasLimit)
+	if block.BlobGasUsed != nil {
+		outputBlobGas(builder, *block.BlobGasUsed, block.ExcessBlobGas)
+	}
+	if block.WithdrawalsRoot != nil {
+		outputWithdrawals(builder, *block.WithdrawalsRoot, block.Withdrawals, opts.withdrawals)
+	}
 	if verbose {
 		outputCoinbase(builder, block.Miner)
 		outputExtraData(builder, block.ExtraData)
-		outputDifficulty(builder, block.Difficulty)
-		outputTotalDifficulty(builder, block.TotalDifficulty)
+		if block.Difficulty != nil && block.Difficulty.Sign() > 0 {
+			outputDifficulty(builder, block.Difficulty)
+			if block.TotalDifficulty != nil {
+				outputTotalDifficulty(builder, block.TotalDifficulty)
+			}
+		}
+		if block.ParentBeaconBlockRoot != nil {
+			builder.WriteString(fmt.Sprintf("Parent beacon block root: %#x\n", *block.ParentBeaconBlockRoot))
+		}
 	}
-	outputUncles(builder, block.Uncles, verbose)
-	outputTimeToMerge(ctx, execClient, builder, block.TotalDifficulty, block.Difficulty)
-	outputTransactions(builder, block.Transactions, verbose)
-
-	return builder.String(), nil
+	if opts.uncles {
+		outputUncleHeaders(builder, uncles)
+	} else {
+		outputUncles(builder, block.Uncles, verbose)
+	}
+	outputTransactions(builder, block, opts.transactions)
+	fmt.Print(builder.String())
+	os.Exit(exitSuccess)
 }
 
-func outputNumber(builder *strings.Builder, number uint32) {
+func outputNumber(builder *strings.Builder, number uint64) {
 	builder.WriteString(fmt.Sprintf("Number: %d\n", number))
 }
 
-func outputHash(builder *strings.Builder, hash types.Hash) {
+func outputHash(builder *strings.Builder, hash common.Hash) {
 	builder.WriteString(fmt.Sprintf("Hash: %#x\n", hash))
 }
 
-func outputCoinbase(builder *strings.Builder, coinbase types.Address) {
-	builder.WriteString(fmt.Sprintf("Coinbase: %#x\n", coinbase))
+func outputParentHash(builder *strings.Builder, hash common.Hash) {
+	builder.WriteString(fmt.Sprintf("Parent hash: %#x\n", hash))
+}
+
+func outputCoinbase(builder *strings.Builder, coinbase common.Address) {
+	builder.WriteString(fmt.Sprintf("Coinbase: %s\n", coinbase.Hex()))
 }
 
 func outputTimestamp(builder *strings.Builder, timestamp time.Time) {
 	builder.WriteString(fmt.Sprintf("Timestamp: %s (%d)\n", timestamp, timestamp.Unix()))
 }
 
-func outputBaseFee(builder *strings.Builder, baseFee uint64) {
-	builder.WriteString(fmt.Sprintf("Base fee: %s\n", string2eth.WeiToGWeiString(big.NewInt(int64(baseFee)))))
+func outputBaseFee(builder *strings.Builder, baseFee *big.Int) {
+	builder.WriteString(fmt.Sprintf("Base fee: %s\n", formatWei(baseFee)))
 }
 
-func outputGas(builder *strings.Builder, gasUsed uint32, gasLimit uint32) {
+func outputGas(builder *strings.Builder, gasUsed uint64, gasLimit uint64) {
 	builder.WriteString(fmt.Sprintf("Gas used: %d/%d (%0.2f%%)\n", gasUsed, gasLimit, float64(gasUsed)*100.0/float64(gasLimit)))
 }
 
+func outputBlobGas(builder *strings.Builder, blobGasUsed uint64, excessBlobGas *uint64) {
+	builder.WriteString(fmt.Sprintf("Blob gas used: %d\n", blobGasUsed))
+	if verbose && excessBlobGas != nil {
+		builder.WriteString(fmt.Sprintf("Excess blob gas: %d\n", *excessBlobGas))
+	}
+}
+
+func outputWithdrawals(builder *strings.Builder, root common.Hash, withdrawals []*conn.Withdrawal, all bool) {
+	builder.WriteString(fmt.Sprintf("Withdrawals root: %#x\n", root))
+	total := new(big.Int)
+	for _, withdrawal := range withdrawals {
+		total.Add(total, withdrawal.Amount)
+	}
+	builder.WriteString(fmt.Sprintf("Withdrawals: %d (%s)\n", len(withdrawals), formatWithdrawalAmount(total)))
+	if !all {
+		return
+	}
+	for _, withdrawal := range withdrawals {
+		builder.WriteString(fmt.Sprintf("  %d validator %d -> %s %s\n", withdrawal.Index, withdrawal.ValidatorIndex, withdrawal.Address.Hex(), formatWithdrawalAmount(withdrawal.Amount)))
+	}
+}
+
+// formatWithdrawalAmount formats a withdrawal amount, in Ether unless the user has requested
+// a specific unit.
+func formatWithdrawalAmount(amount *big.Int) string {
+	if outputUnit == output.Auto {
+		return output.FormatWei(amount, output.Ether)
+	}
+	return formatWei(amount)
+}
+
 func outputExtraData(builder *strings.Builder, extraData []byte) {
 	extraData = bytes.TrimRight(extraData, "\u0000")
 	if len(extraData) > 0 {
@@ -159,67 +209,66 @@ func outputExtraData(builder *strings.Builder, extraData []byte) {
 	}
 }
 
-func outputDifficulty(builder *strings.Builder, difficulty uint64) {
-	builder.WriteString(fmt.Sprintf("Difficulty: %d\n", difficulty))
+func outputDifficulty(builder *strings.Builder, difficulty *big.Int) {
+	builder.WriteString(fmt.Sprintf("Difficulty: %s\n", difficulty.String()))
 }
 
 func outputTotalDifficulty(builder *strings.Builder, totalDifficulty *big.Int) {
 	builder.WriteString(fmt.Sprintf("Total difficulty: %s\n", totalDifficulty.String()))
 }
 
-func outputTimeToMerge(ctx context.Context,
-	execClient execclient.Service,
-	builder *strings.Builder,
-	totalDifficulty *big.Int,
-	difficulty uint64,
-) {
-	chainID, err := execClient.(execclient.ChainIDProvider).ChainID(ctx)
-	if err != nil {
+func outputUncles(builder *strings.Builder, uncles []common.Hash, verbose bool) {
+	if len(uncles) == 0 {
 		return
 	}
-
-	ttds := map[uint64]*big.Int{
-		3: big.NewInt(50000000000000000),
+	if verbose {
+		builder.WriteString("Uncles:\n")
+		for _, uncleHash := range uncles {
+			builder.WriteString(fmt.Sprintf("  %#x\n", uncleHash))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("Uncles: %d\n", len(uncles)))
 	}
+}
 
-	if difficulty <= 0 {
+func outputUncleHeaders(builder *strings.Builder, uncles []*conn.Block) {
+	if len(uncles) == 0 {
 		return
 	}
-
-	ttd, exists := ttds[chainID]
-	if !exists {
-		return
+	builder.WriteString("Uncles:\n")
+	for _, uncle := range uncles {
+		builder.WriteString(fmt.Sprintf("  %#x\n", uncle.Hash))
+		builder.WriteString(fmt.Sprintf("    Number: %d\n", uncle.Number))
+		builder.WriteString(fmt.Sprintf("    Coinbase: %s\n", uncle.Miner.Hex()))
+		builder.WriteString(fmt.Sprintf("    Timestamp: %s\n", uncle.Timestamp))
+		builder.WriteString(fmt.Sprintf("    Difficulty: %s\n", uncle.Difficulty.String()))
+		builder.WriteString(fmt.Sprintf("    Gas used: %d/%d\n", uncle.GasUsed, uncle.GasLimit))
 	}
-
-	left := new(big.Int).Sub(ttd, totalDifficulty)
-	blocksLeft := new(big.Int).Div(left, big.NewInt(int64(difficulty))).Int64()
-	timeLeft := time.Duration(blocksLeft*13) * time.Second
-	when := time.Now().Add(timeLeft)
-	builder.WriteString(fmt.Sprintf("Approximate merge time: %s (%v to go)\n", when.Format(time.RFC3339), timeLeft))
 }
 
-func outputUncles(builder *strings.Builder, uncles []types.Hash, verbose bool) {
-	if verbose {
-		if len(uncles) > 0 {
-			builder.WriteString("Uncles:\n")
-			for _, uncleHash := range uncles {
-				builder.WriteString(fmt.Sprintf("  %#x\n", uncleHash))
-			}
+func outputTransactions(builder *strings.Builder, block *conn.Block, transactions bool) {
+	builder.WriteString(fmt.Sprintf("Transactions: %d\n", len(block.TransactionHashes)))
+	if !transactions {
+		return
+	}
+	txdata.InitFunctionMap()
+	for _, tx := range block.Transactions {
+		to := "(contract creation)"
+		if tx.To != nil {
+			to = tx.To.Hex()
 		}
-	} else {
-		if len(uncles) > 0 {
-			builder.WriteString(fmt.Sprintf("Uncles %d", len(uncles)))
+		builder.WriteString(fmt.Sprintf("  %#x %s -> %s %s", tx.Hash, tx.From.Hex(), to, formatWei(tx.Value)))
+		if function := txdata.FunctionName(tx.Input); function != "" {
+			builder.WriteString(fmt.Sprintf(" %s()", function))
+		} else if len(tx.Input) >= 4 {
+			builder.WriteString(fmt.Sprintf(" %#x", tx.Input[:4]))
 		}
+		builder.WriteString("\n")
 	}
 }
 
-func outputTransactions(builder *strings.Builder, transactions []*spec.Transaction, verbose bool) {
-	builder.WriteString("Transactions: ")
-	builder.WriteString(fmt.Sprintf("%d", len(transactions)))
-}
-
 func init() {
 	blockCmd.AddCommand(blockInfoCmd)
-	blockInfoCmd.Flags().BoolVar(&blockInfoTransactions, "transactions", false, "Display hashes of all block transactions")
+	blockInfoFlags(blockInfoCmd, &blockInfoOpts)
 	blockFlags(blockInfoCmd)
 }
@@ -0,0 +1,41 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var blockLatestOpts blockInfoOptions
+
+// blockLatestCmd represents the block latest command
+var blockLatestCmd = &cobra.Command{
+	Use:   "latest",
+	Short: "Obtain information about the latest block",
+	Long: `Obtain information about the latest block.  For example:
+
+    ethereal block latest
+
+This is the same as block info --block=latest, and takes the same --transactions, --withdrawals and --uncles flags.
+
+In quiet mode this will return 0 if the block exists, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		outputBlock("latest", &blockLatestOpts)
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockLatestCmd)
+	blockInfoFlags(blockLatestCmd, &blockLatestOpts)
+}
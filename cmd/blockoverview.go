@@ -14,13 +14,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"math/big"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	ens "github.com/wealdtech/go-ens/v3"
 )
 
 var blockOverviewBlocks int64
@@ -33,33 +33,61 @@ var blockOverviewCmd = &cobra.Command{
 
     ethereal block overview
 
+The overview starts at the block given by --block, which defaults to the latest block.
+
 In quiet mode this will return 0 if the blocks exist, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var blockNumber *big.Int
+		// Each request for a block has its own timeout.
+		ctx := context.Background()
+		id := blockStr
+		if strings.HasPrefix(id, "-") {
+			parseCtx, cancel := localContext()
+			number, err := parseBlockNumber(parseCtx, id)
+			cancel()
+			cli.ErrCheck(err, quiet, "Failed to parse block")
+			id = number.String()
+		}
+
 		var lastBlockTime *time.Time
-		if verbose {
-			fmt.Printf("Block\t Gas used/Gas limit\tBlock time\t\tGap\tCoinbase\n")
+		blocks := make([]map[string]interface{}, 0, blockOverviewBlocks)
+		if verbose && !jsonOutput() {
+			fmt.Printf("Block\t Gas used/Gas limit\tTxs\tBlock time\t\tGap\tCoinbase\n")
 		}
 		for i := blockOverviewBlocks; i > 0; i-- {
-			ctx, cancel := localContext()
-			defer cancel()
-			block, err := c.Client().BlockByNumber(ctx, blockNumber)
-			cli.ErrCheck(err, quiet, "Failed to obtain information about latest block")
-			blockNumber = big.NewInt(0).Set(block.Number())
-			blockTime := time.Unix(int64(block.Time()), 0)
+			block, err := c.Block(ctx, id, false)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain information about block %s", id))
 
-			if !quiet {
-				fmt.Printf("%v\t%9d/%9d\t", blockNumber, block.GasUsed(), block.GasLimit())
-				fmt.Printf("%s\t", blockTime.Format("06/01/02 15:04:05"))
+			if jsonOutput() {
+				res := map[string]interface{}{
+					"number":       block.Number,
+					"gas_used":     block.GasUsed,
+					"gas_limit":    block.GasLimit,
+					"transactions": len(block.TransactionHashes),
+					"timestamp":    block.Timestamp.Unix(),
+					"coinbase":     block.Miner.Hex(),
+				}
+				if block.BaseFeePerGas != nil {
+					res["base_fee_per_gas"] = block.BaseFeePerGas.String()
+				}
+				blocks = append(blocks, res)
+			} else if !quiet {
+				fmt.Printf("%v\t%9d/%9d\t%d\t", block.Number, block.GasUsed, block.GasLimit, len(block.TransactionHashes))
+				fmt.Printf("%s\t", block.Timestamp.Format("06/01/02 15:04:05"))
 				if lastBlockTime != nil {
-					gap := lastBlockTime.Sub(blockTime)
+					gap := lastBlockTime.Sub(block.Timestamp)
 					fmt.Printf("%v", gap)
 				}
-				coinbase := block.Coinbase()
-				fmt.Printf("\t%s\n", ens.Format(c.Client(), coinbase))
+				fmt.Printf("\t%s\n", formatAddress(block.Miner))
+				blockTime := block.Timestamp
 				lastBlockTime = &blockTime
 			}
-			blockNumber = blockNumber.Sub(blockNumber, big.NewInt(1))
+			if block.Number == 0 {
+				break
+			}
+			id = fmt.Sprintf("%d", block.Number-1)
+		}
+		if jsonOutput() {
+			outputJSON(blocks)
 		}
 	},
 }
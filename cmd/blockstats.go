@@ -0,0 +1,339 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+var blockStatsBlocks uint64
+var blockStatsWorkers int
+var blockStatsTop int
+
+// blockStatsCmd represents the block stats command
+var blockStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Obtain statistics about a range of blocks",
+	Long: `Obtain aggregate statistics about a range of blocks.  For example:
+
+    ethereal block stats --blocks=1000
+
+Statistics cover the number of blocks given by --blocks, ending with the block given by --block which defaults to the latest block.  They include the average block time, gas utilization, the base fee at the start and end of the range, the mix of transaction types and the addresses that consumed the most gas.  Finding the addresses that consumed the most gas requires the receipts of all transactions in the range, so can be disabled with --top=0.  Blocks are fetched concurrently by the number of workers given by --workers, in groups of 10 with a single GraphQL query if the node provides a GraphQL endpoint, otherwise individually with JSON-RPC.
+
+In quiet mode this will return 0 if the statistics can be obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(blockStatsBlocks > 0, quiet, "--blocks must be greater than 0")
+		cli.Assert(blockStatsWorkers > 0, quiet, "--workers must be greater than 0")
+		cli.Assert(blockStatsTop >= 0, quiet, "--top cannot be negative")
+
+		// Each request has its own timeout.
+		ctx := context.Background()
+		id := blockStr
+		if strings.HasPrefix(id, "-") {
+			parseCtx, cancel := localContext()
+			number, err := parseBlockNumber(parseCtx, id)
+			cancel()
+			cli.ErrCheck(err, quiet, "Failed to parse block")
+			id = number.String()
+		}
+		last, err := c.Block(ctx, id, false)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain block %s", id))
+		first := uint64(0)
+		if last.Number >= blockStatsBlocks {
+			first = last.Number - blockStatsBlocks + 1
+		}
+
+		results, err := blockStatsFetch(ctx, first, last.Number, blockStatsWorkers, blockStatsTop > 0)
+		cli.ErrCheck(err, quiet, "Failed to obtain blocks")
+		stats := blockStatsCalculate(results, blockStatsTop)
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(stats)
+		}
+		blockStatsOutput(stats)
+	},
+}
+
+// blockStatsResult is a block and, optionally, its receipts.
+type blockStatsResult struct {
+	block    *conn.Block
+	receipts []*conn.BlockReceipt
+}
+
+// blockStatsChunkSize is the number of blocks fetched in a single GraphQL query.
+const blockStatsChunkSize = 10
+
+// blockStatsFetch fetches the blocks in the given range using the given number of workers.
+func blockStatsFetch(ctx context.Context, first uint64, last uint64, workers int, receipts bool) ([]*blockStatsResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*blockStatsResult, last-first+1)
+	chunks := make(chan uint64)
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr error
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				end := start + blockStatsChunkSize - 1
+				if end > last {
+					end = last
+				}
+				chunkResults, err := blockStatsFetchChunk(ctx, start, end, receipts)
+				if err != nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMu.Unlock()
+					cancel()
+					continue
+				}
+				copy(results[start-first:], chunkResults)
+			}
+		}()
+	}
+	for start := first; start <= last && ctx.Err() == nil; start += blockStatsChunkSize {
+		chunks <- start
+	}
+	close(chunks)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// blockStatsFetchChunk fetches a range of blocks and, optionally, their receipts.  The blocks
+// are fetched with a single GraphQL query if the connection supports it, otherwise
+// individually with JSON-RPC.
+func blockStatsFetchChunk(ctx context.Context, start uint64, end uint64, receipts bool) ([]*blockStatsResult, error) {
+	results := make([]*blockStatsResult, end-start+1)
+	blocks, blockReceipts, err := c.GraphQLBlocks(ctx, start, end, receipts)
+	if err == nil {
+		for i := range blocks {
+			results[i] = &blockStatsResult{
+				block:    blocks[i],
+				receipts: blockReceipts[i],
+			}
+		}
+		return results, nil
+	}
+	if !errors.Is(err, conn.ErrGraphQLUnavailable) {
+		return nil, fmt.Errorf("failed to obtain blocks %d-%d: %v", start, end, err)
+	}
+
+	for number := start; number <= end; number++ {
+		result, err := blockStatsFetchBlock(ctx, number, receipts)
+		if err != nil {
+			return nil, err
+		}
+		results[number-start] = result
+	}
+	return results, nil
+}
+
+// blockStatsFetchBlock fetches a single block and, optionally, its receipts.
+func blockStatsFetchBlock(ctx context.Context, number uint64, receipts bool) (*blockStatsResult, error) {
+	block, err := c.Block(ctx, fmt.Sprintf("%d", number), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain block %d: %v", number, err)
+	}
+	result := &blockStatsResult{block: block}
+	if receipts && len(block.TransactionHashes) > 0 {
+		result.receipts, err = c.BlockReceipts(ctx, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain receipts for block %d: %v", number, err)
+		}
+	}
+	return result, nil
+}
+
+// blockStatsJSON is the JSON output for statistics about a range of blocks.
+type blockStatsJSON struct {
+	FirstBlock       uint64                    `json:"first_block"`
+	LastBlock        uint64                    `json:"last_block"`
+	Blocks           int                       `json:"blocks"`
+	AverageBlockTime float64                   `json:"average_block_time"`
+	GasUsed          uint64                    `json:"gas_used"`
+	GasLimit         uint64                    `json:"gas_limit"`
+	GasUtilization   float64                   `json:"gas_utilization"`
+	FirstBaseFee     *big.Int                  `json:"first_base_fee_per_gas,omitempty"`
+	LastBaseFee      *big.Int                  `json:"last_base_fee_per_gas,omitempty"`
+	MinBaseFee       *big.Int                  `json:"min_base_fee_per_gas,omitempty"`
+	MaxBaseFee       *big.Int                  `json:"max_base_fee_per_gas,omitempty"`
+	Transactions     int                       `json:"transactions"`
+	TransactionTypes map[string]int            `json:"transaction_types"`
+	BlobGasUsed      uint64                    `json:"blob_gas_used,omitempty"`
+	TopGasConsumers  []*blockStatsConsumerJSON `json:"top_gas_consumers,omitempty"`
+}
+
+// blockStatsConsumerJSON is the JSON output for an address that consumed gas.
+type blockStatsConsumerJSON struct {
+	Address string `json:"address"`
+	GasUsed uint64 `json:"gas_used"`
+}
+
+// blockStatsCalculate calculates statistics for the blocks.
+func blockStatsCalculate(results []*blockStatsResult, top int) *blockStatsJSON {
+	stats := &blockStatsJSON{
+		FirstBlock:       results[0].block.Number,
+		LastBlock:        results[len(results)-1].block.Number,
+		Blocks:           len(results),
+		TransactionTypes: make(map[string]int),
+	}
+	if len(results) > 1 {
+		elapsed := results[len(results)-1].block.Timestamp.Sub(results[0].block.Timestamp)
+		stats.AverageBlockTime = elapsed.Seconds() / float64(len(results)-1)
+	}
+
+	consumers := make(map[common.Address]uint64)
+	for _, result := range results {
+		block := result.block
+		stats.GasUsed += block.GasUsed
+		stats.GasLimit += block.GasLimit
+		if block.BaseFeePerGas != nil {
+			if stats.FirstBaseFee == nil {
+				stats.FirstBaseFee = block.BaseFeePerGas
+				stats.MinBaseFee = block.BaseFeePerGas
+				stats.MaxBaseFee = block.BaseFeePerGas
+			}
+			stats.LastBaseFee = block.BaseFeePerGas
+			if block.BaseFeePerGas.Cmp(stats.MinBaseFee) < 0 {
+				stats.MinBaseFee = block.BaseFeePerGas
+			}
+			if block.BaseFeePerGas.Cmp(stats.MaxBaseFee) > 0 {
+				stats.MaxBaseFee = block.BaseFeePerGas
+			}
+		}
+		if block.BlobGasUsed != nil {
+			stats.BlobGasUsed += *block.BlobGasUsed
+		}
+		stats.Transactions += len(block.Transactions)
+		for _, tx := range block.Transactions {
+			stats.TransactionTypes[transactionTypeName(tx.Type)]++
+		}
+		for _, receipt := range result.receipts {
+			switch {
+			case receipt.To != nil:
+				consumers[*receipt.To] += receipt.GasUsed
+			case receipt.ContractAddress != nil:
+				consumers[*receipt.ContractAddress] += receipt.GasUsed
+			}
+		}
+	}
+	if stats.GasLimit > 0 {
+		stats.GasUtilization = float64(stats.GasUsed) * 100.0 / float64(stats.GasLimit)
+	}
+
+	addresses := make([]common.Address, 0, len(consumers))
+	for address := range consumers {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return consumers[addresses[i]] > consumers[addresses[j]]
+	})
+	if len(addresses) > top {
+		addresses = addresses[:top]
+	}
+	for _, address := range addresses {
+		stats.TopGasConsumers = append(stats.TopGasConsumers, &blockStatsConsumerJSON{
+			Address: address.Hex(),
+			GasUsed: consumers[address],
+		})
+	}
+
+	return stats
+}
+
+// transactionTypeName returns a readable name for a transaction type.
+func transactionTypeName(txType uint64) string {
+	switch txType {
+	case 0:
+		return "legacy"
+	case 1:
+		return "access list"
+	case 2:
+		return "EIP-1559"
+	case 3:
+		return "blob"
+	case 4:
+		return "set code"
+	default:
+		return fmt.Sprintf("type %d", txType)
+	}
+}
+
+// blockStatsOutput outputs statistics in text form.
+func blockStatsOutput(stats *blockStatsJSON) {
+	builder := new(strings.Builder)
+	builder.WriteString(fmt.Sprintf("Blocks: %d (%d-%d)\n", stats.Blocks, stats.FirstBlock, stats.LastBlock))
+	if stats.Blocks > 1 {
+		averageBlockTime := time.Duration(stats.AverageBlockTime * float64(time.Second))
+		builder.WriteString(fmt.Sprintf("Average block time: %v\n", averageBlockTime.Round(time.Millisecond)))
+	}
+	builder.WriteString(fmt.Sprintf("Gas utilization: %0.2f%% (%d/%d)\n", stats.GasUtilization, stats.GasUsed, stats.GasLimit))
+	if stats.FirstBaseFee != nil {
+		builder.WriteString(fmt.Sprintf("Base fee: %s -> %s (min %s, max %s)\n", formatWei(stats.FirstBaseFee), formatWei(stats.LastBaseFee), formatWei(stats.MinBaseFee), formatWei(stats.MaxBaseFee)))
+	}
+	builder.WriteString(fmt.Sprintf("Transactions: %d (%0.1f per block)\n", stats.Transactions, float64(stats.Transactions)/float64(stats.Blocks)))
+	names := make([]string, 0, len(stats.TransactionTypes))
+	for name := range stats.TransactionTypes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return stats.TransactionTypes[names[i]] > stats.TransactionTypes[names[j]]
+	})
+	for _, name := range names {
+		builder.WriteString(fmt.Sprintf("  %s: %d (%0.2f%%)\n", name, stats.TransactionTypes[name], float64(stats.TransactionTypes[name])*100.0/float64(stats.Transactions)))
+	}
+	if stats.BlobGasUsed > 0 {
+		builder.WriteString(fmt.Sprintf("Blob gas used: %d\n", stats.BlobGasUsed))
+	}
+	if len(stats.TopGasConsumers) > 0 {
+		builder.WriteString("Top gas consumers:\n")
+		for _, consumer := range stats.TopGasConsumers {
+			builder.WriteString(fmt.Sprintf("  %s: %d (%0.2f%%)\n", formatAddress(common.HexToAddress(consumer.Address)), consumer.GasUsed, float64(consumer.GasUsed)*100.0/float64(stats.GasUsed)))
+		}
+	}
+	fmt.Print(builder.String())
+}
+
+func init() {
+	blockCmd.AddCommand(blockStatsCmd)
+	blockFlags(blockStatsCmd)
+	blockStatsCmd.Flags().Uint64Var(&blockStatsBlocks, "blocks", 100, "Number of blocks for which to obtain statistics")
+	blockStatsCmd.Flags().IntVar(&blockStatsWorkers, "workers", 8, "Number of blocks to fetch concurrently")
+	blockStatsCmd.Flags().IntVar(&blockStatsTop, "top", 10, "Number of top gas consumers to show (0 to disable)")
+}
@@ -0,0 +1,179 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+var blockWatchFormat string
+var blockWatchInterval time.Duration
+var blockWatchWebhook string
+
+// blockWatchCmd represents the block watch command
+var blockWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch new blocks as they arrive",
+	Long: `Watch new heads of the chain as they arrive, and report reorgs.  For example:
+
+   ethereal block watch --webhook=http://localhost:8080/reorg
+
+Reorgs are detected by tracking the parent hashes of recent blocks.  If --webhook is supplied then details of each reorg are sent to it as a JSON POST request.  If the connection supports subscriptions (websocket or IPC) they are used, otherwise the node is polled for new blocks.
+
+This command runs until interrupted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(blockWatchFormat == "text" || blockWatchFormat == "json", quiet, fmt.Sprintf("Unknown format %s", blockWatchFormat))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		events := make(chan *conn.HeadEvent)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- c.SubscribeHeads(ctx, blockWatchInterval, events)
+		}()
+
+		for {
+			select {
+			case err := <-errCh:
+				cli.ErrCheck(err, quiet, "Failed to watch for blocks")
+				os.Exit(exitSuccess)
+			case event := <-events:
+				data := newBlockWatchJSON(event)
+				if data.Reorg != nil && blockWatchWebhook != "" {
+					if err := blockWatchCallWebhook(ctx, data); err != nil {
+						outputIf(!quiet, fmt.Sprintf("Failed to call webhook: %v", err))
+					}
+				}
+				if quiet {
+					continue
+				}
+				if blockWatchFormat == "json" || jsonOutput() {
+					writeJSON(data)
+				} else {
+					blockWatchOutput(data)
+				}
+			}
+		}
+	},
+}
+
+// blockWatchJSON is the JSON output for a new head.
+type blockWatchJSON struct {
+	Number        uint64               `json:"number"`
+	Hash          common.Hash          `json:"hash"`
+	ParentHash    common.Hash          `json:"parent_hash"`
+	Timestamp     time.Time            `json:"timestamp"`
+	GasUsed       uint64               `json:"gas_used"`
+	GasLimit      uint64               `json:"gas_limit"`
+	BaseFeePerGas *big.Int             `json:"base_fee_per_gas,omitempty"`
+	Reorg         *blockWatchReorgJSON `json:"reorg,omitempty"`
+}
+
+// blockWatchReorgJSON is the JSON output for a reorg.
+type blockWatchReorgJSON struct {
+	Depth          uint64      `json:"depth"`
+	OldHead        common.Hash `json:"old_head"`
+	OldHeadNumber  uint64      `json:"old_head_number"`
+	CommonAncestor uint64      `json:"common_ancestor"`
+}
+
+func newBlockWatchJSON(event *conn.HeadEvent) *blockWatchJSON {
+	header := event.Header
+	data := &blockWatchJSON{
+		Number:        header.Number.Uint64(),
+		Hash:          header.Hash(),
+		ParentHash:    header.ParentHash,
+		Timestamp:     time.Unix(int64(header.Time), 0),
+		GasUsed:       header.GasUsed,
+		GasLimit:      header.GasLimit,
+		BaseFeePerGas: header.BaseFee,
+	}
+	if event.Reorg != nil {
+		data.Reorg = &blockWatchReorgJSON{
+			Depth:          event.Reorg.Depth,
+			OldHead:        event.Reorg.OldHead,
+			OldHeadNumber:  event.Reorg.OldHeadNumber,
+			CommonAncestor: event.Reorg.CommonAncestor,
+		}
+	}
+	return data
+}
+
+// blockWatchOutput outputs a new head in text form.
+func blockWatchOutput(data *blockWatchJSON) {
+	if data.Reorg != nil {
+		fmt.Printf("Reorg of depth %d: head %d (%s) replaced, common ancestor %d\n", data.Reorg.Depth, data.Reorg.OldHeadNumber, data.Reorg.OldHead.Hex(), data.Reorg.CommonAncestor)
+	}
+	utilization := float64(0)
+	if data.GasLimit > 0 {
+		utilization = float64(data.GasUsed) * 100.0 / float64(data.GasLimit)
+	}
+	line := fmt.Sprintf("%d\t%s\t%s\t%d/%d (%0.2f%%)", data.Number, data.Hash.Hex(), data.Timestamp.Format("06/01/02 15:04:05"), data.GasUsed, data.GasLimit, utilization)
+	if data.BaseFeePerGas != nil {
+		line = fmt.Sprintf("%s\t%s", line, formatWei(data.BaseFeePerGas))
+	}
+	fmt.Println(line)
+}
+
+// blockWatchCallWebhook sends details of a new head to the webhook.
+func blockWatchCallWebhook(ctx context.Context, data *blockWatchJSON) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration("timeout"))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, blockWatchWebhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func init() {
+	blockCmd.AddCommand(blockWatchCmd)
+	blockWatchCmd.Flags().StringVar(&blockWatchFormat, "format", "text", "Output format (text or json)")
+	blockWatchCmd.Flags().DurationVar(&blockWatchInterval, "interval", 12*time.Second, "Interval between polls if the connection does not support subscriptions")
+	blockWatchCmd.Flags().StringVar(&blockWatchWebhook, "webhook", "", "URL to which details of reorgs are sent")
+}
@@ -0,0 +1,332 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var bridgeL2Network string
+var bridgeL2Connection string
+var bridgePortalAddress string
+var bridgeL1BridgeAddress string
+
+// bridgeCmd represents the bridge command
+var bridgeCmd = &cobra.Command{
+	Use:   "bridge",
+	Short: "Bridge Ether and tokens to and from OP-stack chains",
+	Long:  `Deposit Ether and tokens to OP-stack chains such as Optimism and Base through the standard bridge, and prove and finalize withdrawals from them.`,
+}
+
+// opStackDeployment contains the layer 1 contracts of an OP-stack chain.
+type opStackDeployment struct {
+	chainID          *big.Int
+	l1ChainID        *big.Int
+	l1StandardBridge common.Address
+	optimismPortal   common.Address
+}
+
+var opStackDeployments = []*opStackDeployment{
+	{
+		chainID:          big.NewInt(10),
+		l1ChainID:        big.NewInt(1),
+		l1StandardBridge: common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1"),
+		optimismPortal:   common.HexToAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed"),
+	},
+	{
+		chainID:          big.NewInt(8453),
+		l1ChainID:        big.NewInt(1),
+		l1StandardBridge: common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35"),
+		optimismPortal:   common.HexToAddress("0x49048044D57e1C92A77f79988d21Fa8fAF74E97e"),
+	},
+	{
+		chainID:          big.NewInt(11155420),
+		l1ChainID:        big.NewInt(11155111),
+		l1StandardBridge: common.HexToAddress("0xFBb0621E0B23b5478B630BD55a5f21f67730B0F1"),
+		optimismPortal:   common.HexToAddress("0x16Fc5058F25648194471939df75CF27A2fdC48BC"),
+	},
+	{
+		chainID:          big.NewInt(84532),
+		l1ChainID:        big.NewInt(11155111),
+		l1StandardBridge: common.HexToAddress("0xfd0Bf71F60660E2f608ed56e1659C450eB113120"),
+		optimismPortal:   common.HexToAddress("0x49f53e41452C74589E85cA1677426Ba426459e85"),
+	},
+}
+
+// bridgeDeployment returns the layer 1 contracts of the OP-stack chain with the given
+// chain ID, or of the chain named with --l2-network if the chain ID is nil.  Contracts
+// supplied with --portal and --l1-bridge override those of known chains.
+func bridgeDeployment(l2ChainID *big.Int) (*opStackDeployment, error) {
+	if l2ChainID == nil && bridgeL2Network != "" {
+		chain := chains.ByName(bridgeL2Network)
+		if chain == nil {
+			return nil, fmt.Errorf("unknown network %s", bridgeL2Network)
+		}
+		l2ChainID = new(big.Int).SetUint64(chain.ID)
+	}
+
+	deployment := &opStackDeployment{}
+	if l2ChainID != nil {
+		for _, known := range opStackDeployments {
+			if known.chainID.Cmp(l2ChainID) == 0 {
+				tmp := *known
+				deployment = &tmp
+				break
+			}
+		}
+	}
+	if bridgePortalAddress != "" {
+		address, err := c.Resolve(bridgePortalAddress)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve portal address")
+		}
+		deployment.optimismPortal = address
+	}
+	if bridgeL1BridgeAddress != "" {
+		address, err := c.Resolve(bridgeL1BridgeAddress)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve L1 bridge address")
+		}
+		deployment.l1StandardBridge = address
+	}
+
+	if deployment.l1ChainID != nil && c.ChainID() != nil && deployment.l1ChainID.Cmp(c.ChainID()) != 0 {
+		return nil, fmt.Errorf("connected to chain %s but the bridge is on chain %s", c.ChainID(), deployment.l1ChainID)
+	}
+
+	return deployment, nil
+}
+
+// bridgeL2Conn returns a connection to the OP-stack chain supplied with --l2-connection.
+func bridgeL2Conn(ctx context.Context) (*conn.Conn, error) {
+	if bridgeL2Connection == "" {
+		return nil, errors.New("--l2-connection is required")
+	}
+	l2, err := conn.New(ctx, bridgeL2Connection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to L2")
+	}
+	rollup, err := l2.Rollup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rollup != conn.RollupOPStack {
+		return nil, fmt.Errorf("chain %s is not an OP-stack chain", l2.ChainID())
+	}
+	return l2, nil
+}
+
+// Withdrawal states.
+const (
+	withdrawalWaitingToProve    = "waiting to prove"
+	withdrawalReadyToProve      = "ready to prove"
+	withdrawalWaitingToFinalize = "waiting to finalize"
+	withdrawalReadyToFinalize   = "ready to finalize"
+	withdrawalFinalized         = "finalized"
+)
+
+// disputeGameChallengerWins is the status of a dispute game whose root claim was defeated.
+const disputeGameChallengerWins = 1
+
+// maxDisputeGameSearch is the number of dispute games to search for the latest usable game.
+const maxDisputeGameSearch = 100
+
+// bridgeWithdrawal is the state of a withdrawal from an OP-stack chain.
+type bridgeWithdrawal struct {
+	withdrawal *util.OPStackWithdrawal
+	// l2Block is the L2 block in which the withdrawal was initiated.
+	l2Block uint64
+	state   string
+	// gameIndex, game and gameL2Block are the latest dispute game, if it covers the withdrawal.
+	gameIndex   *big.Int
+	game        common.Address
+	gameL2Block uint64
+	// proofSubmitter, provenAt and finalizableAt are set once the withdrawal is proven.
+	proofSubmitter common.Address
+	provenAt       time.Time
+	finalizableAt  time.Time
+	// notFinalizable is the reason a proven withdrawal cannot yet be finalized.
+	notFinalizable error
+}
+
+// bridgeWithdrawalState obtains the state of the withdrawal initiated by the given L2
+// transaction.  If submitter is supplied then the proof state is that of its proof, otherwise
+// of the first proof submitted.
+func bridgeWithdrawalState(ctx context.Context,
+	l2 *conn.Conn,
+	portal *contracts.OptimismPortal2,
+	txHash common.Hash,
+	submitter *common.Address,
+) (
+	*bridgeWithdrawal,
+	error,
+) {
+	receipt, err := l2.Client().TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain L2 transaction receipt")
+	}
+	withdrawals, err := util.OPStackWithdrawals(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if len(withdrawals) != 1 {
+		return nil, fmt.Errorf("transaction has %d withdrawals; expected 1", len(withdrawals))
+	}
+	res := &bridgeWithdrawal{
+		withdrawal: withdrawals[0],
+		l2Block:    receipt.BlockNumber.Uint64(),
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	finalized, err := portal.FinalizedWithdrawals(opts, res.withdrawal.Hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain finalization state")
+	}
+	if finalized {
+		res.state = withdrawalFinalized
+		return res, nil
+	}
+
+	if submitter == nil {
+		submitters, err := portal.NumProofSubmitters(opts, res.withdrawal.Hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain proof submitters")
+		}
+		if submitters.Sign() > 0 {
+			first, err := portal.ProofSubmitters(opts, res.withdrawal.Hash, big.NewInt(0))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain proof submitter")
+			}
+			submitter = &first
+		}
+	}
+	if submitter != nil {
+		proven, err := portal.ProvenWithdrawals(opts, res.withdrawal.Hash, *submitter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain proof state")
+		}
+		if proven.Timestamp > 0 {
+			res.proofSubmitter = *submitter
+			res.provenAt = time.Unix(int64(proven.Timestamp), 0)
+			res.game = proven.DisputeGameProxy
+			delay, err := portal.ProofMaturityDelaySeconds(opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain proof maturity delay")
+			}
+			res.finalizableAt = res.provenAt.Add(time.Duration(delay.Int64()) * time.Second)
+			// The portal checks the proof and its dispute game, reverting if the withdrawal cannot be finalized.
+			res.notFinalizable = portal.CheckWithdrawal(opts, res.withdrawal.Hash, *submitter)
+			if res.notFinalizable == nil {
+				res.state = withdrawalReadyToFinalize
+			} else {
+				res.state = withdrawalWaitingToFinalize
+			}
+			return res, nil
+		}
+	}
+
+	if err := res.latestDisputeGame(ctx, portal); err != nil {
+		return nil, err
+	}
+	if res.gameIndex != nil && res.gameL2Block >= res.l2Block {
+		res.state = withdrawalReadyToProve
+	} else {
+		res.state = withdrawalWaitingToProve
+	}
+	return res, nil
+}
+
+// latestDisputeGame finds the latest dispute game of the type respected by the portal that
+// has not been lost by its proposer.
+func (w *bridgeWithdrawal) latestDisputeGame(ctx context.Context, portal *contracts.OptimismPortal2) error {
+	opts := &bind.CallOpts{Context: ctx}
+	factoryAddress, err := portal.DisputeGameFactory(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain dispute game factory")
+	}
+	factory, err := contracts.NewDisputeGameFactory(factoryAddress, c.Client())
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain dispute game factory")
+	}
+	gameType, err := portal.RespectedGameType(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain respected game type")
+	}
+	count, err := factory.GameCount(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain dispute game count")
+	}
+
+	index := new(big.Int).Sub(count, big.NewInt(1))
+	for i := 0; i < maxDisputeGameSearch && index.Sign() >= 0; i++ {
+		game, err := factory.GameAtIndex(opts, index)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain dispute game")
+		}
+		if game.GameType == gameType {
+			faultGame, err := contracts.NewFaultDisputeGame(game.Proxy, c.Client())
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain dispute game")
+			}
+			status, err := faultGame.Status(opts)
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain dispute game status")
+			}
+			if status != disputeGameChallengerWins {
+				l2Block, err := faultGame.L2BlockNumber(opts)
+				if err != nil {
+					return errors.Wrap(err, "failed to obtain dispute game L2 block")
+				}
+				w.gameIndex = index
+				w.game = game.Proxy
+				w.gameL2Block = l2Block.Uint64()
+				return nil
+			}
+		}
+		index = new(big.Int).Sub(index, big.NewInt(1))
+	}
+
+	return nil
+}
+
+// bridgePortal returns the portal of the OP-stack chain.
+func bridgePortal(deployment *opStackDeployment) (*contracts.OptimismPortal2, error) {
+	if deployment.optimismPortal == (common.Address{}) {
+		return nil, errors.New("portal is not known for this chain; supply it with --portal")
+	}
+	return contracts.NewOptimismPortal2(deployment.optimismPortal, c.Client())
+}
+
+func init() {
+	RootCmd.AddCommand(bridgeCmd)
+}
+
+func bridgeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&bridgeL2Network, "l2-network", "", "Name of the OP-stack chain, for example \"optimism\" or \"base\"")
+	cmd.Flags().StringVar(&bridgeL2Connection, "l2-connection", "", "Connection to the OP-stack chain")
+	cmd.Flags().StringVar(&bridgePortalAddress, "portal", "", "Address of the OptimismPortal contract, if the chain is not known")
+	cmd.Flags().StringVar(&bridgeL1BridgeAddress, "l1-bridge", "", "Address of the L1StandardBridge contract, if the chain is not known")
+}
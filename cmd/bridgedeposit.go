@@ -0,0 +1,134 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var bridgeDepositAmount string
+var bridgeDepositFromAddress string
+var bridgeDepositToAddress string
+var bridgeDepositToken string
+var bridgeDepositL2Token string
+var bridgeDepositMinGasLimit uint32
+
+// bridgeDepositCmd represents the bridge deposit command
+var bridgeDepositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit Ether or tokens to an OP-stack chain",
+	Long: `Deposit Ether or ERC-20 tokens to an OP-stack chain through its standard bridge.  This command is run against the layer 1 chain.  For example:
+
+    ethereal bridge deposit --l2-network=base --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --amount=0.1ether --passphrase=secret
+
+To deposit tokens supply the layer 1 token with --token and its layer 2 counterpart with --l2-token; the bridge must first be approved to transfer the tokens, for example with "ethereal token approve".
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(bridgeL2Network != "" || bridgeL1BridgeAddress != "", quiet, "--l2-network or --l1-bridge is required")
+		deployment, err := bridgeDeployment(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge")
+		cli.Assert(deployment.l1StandardBridge != unknownAddress, quiet, "Bridge is not known for this chain; supply it with --l1-bridge")
+
+		bridgeDepositFromAddress = accountOrDefault(bridgeDepositFromAddress)
+		cli.Assert(bridgeDepositFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(bridgeDepositFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", bridgeDepositFromAddress))
+
+		toAddress := fromAddress
+		if bridgeDepositToAddress != "" {
+			toAddress, err = c.Resolve(bridgeDepositToAddress)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", bridgeDepositToAddress))
+		}
+
+		cli.Assert(bridgeDepositAmount != "", quiet, "--amount is required")
+
+		bridge, err := contracts.NewL1StandardBridge(deployment.l1StandardBridge, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge contract")
+
+		var amount *big.Int
+		var token *contracts.ERC20
+		if bridgeDepositToken != "" {
+			cli.Assert(!offline, quiet, "Cannot deposit tokens when offline")
+			cli.Assert(bridgeDepositL2Token != "", quiet, "--l2-token is required when depositing tokens")
+			token, err = tokenContract(bridgeDepositToken)
+			cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+			decimals, err := token.Decimals(nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
+			amount, err = util.StringToTokenValue(bridgeDepositAmount, decimals)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+			balance, err := token.BalanceOf(nil, fromAddress)
+			cli.ErrCheck(err, quiet, "Failed to obtain token balance")
+			cli.Assert(balance.Cmp(amount) >= 0, quiet, fmt.Sprintf("Balance of %s insufficient for deposit", formatTokens(balance, decimals, false)))
+			allowance, err := token.Allowance(nil, fromAddress, deployment.l1StandardBridge)
+			cli.ErrCheck(err, quiet, "Failed to obtain token allowance")
+			cli.Assert(allowance.Cmp(amount) >= 0, quiet, fmt.Sprintf("Bridge %s is not approved to transfer %s tokens", deployment.l1StandardBridge.Hex(), formatTokens(amount, decimals, false)))
+		} else {
+			amount, err = string2eth.StringToWei(bridgeDepositAmount)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+		}
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+
+		fields := log.Fields{
+			"group":     "bridge",
+			"command":   "deposit",
+			"bridge":    deployment.l1StandardBridge.Hex(),
+			"recipient": toAddress.Hex(),
+			"amount":    amount.String(),
+		}
+		if token == nil {
+			opts.Value = amount
+			signedTx, err := bridge.DepositETHTo(opts, toAddress, bridgeDepositMinGasLimit, nil)
+			cli.ErrCheck(err, quiet, "Failed to create deposit transaction")
+			if offline {
+				outputSignedTransaction(signedTx)
+				os.Exit(exitSuccess)
+			}
+			handleSubmittedTransaction(signedTx, fields, true)
+		}
+
+		l1Token, err := tokenContractAddress(bridgeDepositToken)
+		cli.ErrCheck(err, quiet, "Failed to obtain token address")
+		l2Token, err := c.Resolve(bridgeDepositL2Token)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve L2 token address %s", bridgeDepositL2Token))
+		signedTx, err := bridge.DepositERC20To(opts, l1Token, l2Token, toAddress, amount, bridgeDepositMinGasLimit, nil)
+		cli.ErrCheck(err, quiet, "Failed to create deposit transaction")
+		fields["token"] = l1Token.Hex()
+		fields["l2token"] = l2Token.Hex()
+		handleSubmittedTransaction(signedTx, fields, true)
+	},
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeDepositCmd)
+	bridgeFlags(bridgeDepositCmd)
+	bridgeDepositCmd.Flags().StringVar(&bridgeDepositAmount, "amount", "", "Amount of Ether or tokens to deposit")
+	bridgeDepositCmd.Flags().StringVar(&bridgeDepositFromAddress, "from", "", "Address from which to deposit")
+	bridgeDepositCmd.Flags().StringVar(&bridgeDepositToAddress, "to", "", "Address on the OP-stack chain to receive the deposit (defaults to the from address)")
+	bridgeDepositCmd.Flags().StringVar(&bridgeDepositToken, "token", "", "Token to deposit on layer 1 (defaults to Ether)")
+	bridgeDepositCmd.Flags().StringVar(&bridgeDepositL2Token, "l2-token", "", "Address of the token on the OP-stack chain")
+	bridgeDepositCmd.Flags().Uint32Var(&bridgeDepositMinGasLimit, "min-gas-limit", 200000, "Minimum gas limit for the deposit on the OP-stack chain")
+	addTransactionFlags(bridgeDepositCmd, "the address from which to deposit")
+}
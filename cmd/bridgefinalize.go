@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var bridgeFinalizeFromAddress string
+
+// bridgeFinalizeCmd represents the bridge finalize command
+var bridgeFinalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Finalize a withdrawal from an OP-stack chain",
+	Long: `Finalize a proven withdrawal from an OP-stack chain, releasing the funds on layer 1.  This command is run against the layer 1 chain, with the withdrawal identified by the hash of the transaction that started it on the OP-stack chain.  For example:
+
+    ethereal bridge finalize --l2-connection=https://mainnet.base.org --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1 --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret
+
+The withdrawal must be finalized from the address that proved it, once the proof has matured and its dispute game has been resolved.  "ethereal bridge status" shows when a withdrawal can be finalized.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot finalize withdrawals when offline")
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+		bridgeFinalizeFromAddress = accountOrDefault(bridgeFinalizeFromAddress)
+		cli.Assert(bridgeFinalizeFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(bridgeFinalizeFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", bridgeFinalizeFromAddress))
+
+		ctx := context.Background()
+		l2, err := bridgeL2Conn(ctx)
+		cli.ErrCheck(err, quiet, "Failed to connect to OP-stack chain")
+		deployment, err := bridgeDeployment(l2.ChainID())
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge")
+		portal, err := bridgePortal(deployment)
+		cli.ErrCheck(err, quiet, "Failed to obtain portal contract")
+
+		withdrawal, err := bridgeWithdrawalState(ctx, l2, portal, common.HexToHash(transactionStr), &fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain withdrawal")
+		switch withdrawal.state {
+		case withdrawalFinalized:
+			cli.Err(quiet, "Withdrawal has already been finalized")
+		case withdrawalWaitingToProve, withdrawalReadyToProve:
+			cli.Err(quiet, fmt.Sprintf("Withdrawal has not been proven by %s", fromAddress.Hex()))
+		case withdrawalWaitingToFinalize:
+			cli.Err(quiet, fmt.Sprintf("Withdrawal cannot be finalized until at least %s: %v", withdrawal.finalizableAt.Format(time.RFC3339), withdrawal.notFinalizable))
+		}
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		signedTx, err := portal.FinalizeWithdrawalTransaction(opts, withdrawal.withdrawal.Transaction)
+		cli.ErrCheck(err, quiet, "Failed to create finalize transaction")
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":          "bridge",
+			"command":        "finalize",
+			"withdrawalhash": withdrawal.withdrawal.Hash.Hex(),
+		}, true)
+	},
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeFinalizeCmd)
+	bridgeFlags(bridgeFinalizeCmd)
+	transactionFlags(bridgeFinalizeCmd)
+	bridgeFinalizeCmd.Flags().StringVar(&bridgeFinalizeFromAddress, "from", "", "Address from which to finalize the withdrawal")
+	addTransactionFlags(bridgeFinalizeCmd, "the address from which to finalize the withdrawal")
+}
@@ -0,0 +1,112 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var bridgeProveFromAddress string
+
+// bridgeProveCmd represents the bridge prove command
+var bridgeProveCmd = &cobra.Command{
+	Use:   "prove",
+	Short: "Prove a withdrawal from an OP-stack chain",
+	Long: `Prove a withdrawal from an OP-stack chain against the latest dispute game, the first step in completing the withdrawal on layer 1.  This command is run against the layer 1 chain, with the withdrawal identified by the hash of the transaction that started it on the OP-stack chain.  For example:
+
+    ethereal bridge prove --l2-connection=https://mainnet.base.org --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1 --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret
+
+A withdrawal can only be proven once a dispute game covers the L2 block in which it was started, which can take an hour or more.  "ethereal bridge status" shows if a withdrawal is ready to prove.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot prove withdrawals when offline")
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+		bridgeProveFromAddress = accountOrDefault(bridgeProveFromAddress)
+		cli.Assert(bridgeProveFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(bridgeProveFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", bridgeProveFromAddress))
+
+		ctx := context.Background()
+		l2, err := bridgeL2Conn(ctx)
+		cli.ErrCheck(err, quiet, "Failed to connect to OP-stack chain")
+		deployment, err := bridgeDeployment(l2.ChainID())
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge")
+		portal, err := bridgePortal(deployment)
+		cli.ErrCheck(err, quiet, "Failed to obtain portal contract")
+
+		withdrawal, err := bridgeWithdrawalState(ctx, l2, portal, common.HexToHash(transactionStr), &fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain withdrawal")
+		switch withdrawal.state {
+		case withdrawalFinalized:
+			cli.Err(quiet, "Withdrawal has already been finalized")
+		case withdrawalWaitingToFinalize, withdrawalReadyToFinalize:
+			cli.Err(quiet, fmt.Sprintf("Withdrawal has already been proven by %s", fromAddress.Hex()))
+		case withdrawalWaitingToProve:
+			cli.Err(quiet, fmt.Sprintf("Withdrawal is in L2 block %d but the latest dispute game is for L2 block %d; try again later", withdrawal.l2Block, withdrawal.gameL2Block))
+		}
+		outputIf(verbose, fmt.Sprintf("Proving against dispute game %s for L2 block %d", withdrawal.game.Hex(), withdrawal.gameL2Block))
+
+		// Build the proof of the output root claimed by the dispute game, and of the withdrawal within it.
+		block, err := l2.Block(ctx, fmt.Sprintf("%d", withdrawal.gameL2Block), false)
+		cli.ErrCheck(err, quiet, "Failed to obtain L2 block")
+		proof, err := l2.Proof(ctx, util.OPStackL2ToL1MessagePasser, []common.Hash{util.OPStackWithdrawalSlot(withdrawal.withdrawal.Hash)}, new(big.Int).SetUint64(block.Number))
+		cli.ErrCheck(err, quiet, "Failed to obtain withdrawal proof")
+		cli.Assert(proof.StorageProof[0].Value != nil && proof.StorageProof[0].Value.ToInt().Sign() != 0, quiet, "Withdrawal is not present in L2 state")
+		outputRootProof := contracts.TypesOutputRootProof{
+			StateRoot:                block.StateRoot,
+			MessagePasserStorageRoot: proof.StorageHash,
+			LatestBlockhash:          block.Hash,
+		}
+		game, err := contracts.NewFaultDisputeGame(withdrawal.game, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain dispute game")
+		rootClaim, err := game.RootClaim(&bind.CallOpts{Context: ctx})
+		cli.ErrCheck(err, quiet, "Failed to obtain dispute game root claim")
+		cli.Assert(util.OPStackOutputRoot(&outputRootProof) == rootClaim, quiet, "Output root of L2 block does not match that of the dispute game")
+		withdrawalProof := make([][]byte, len(proof.StorageProof[0].Proof))
+		for i := range proof.StorageProof[0].Proof {
+			withdrawalProof[i] = proof.StorageProof[0].Proof[i]
+		}
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		signedTx, err := portal.ProveWithdrawalTransaction(opts, withdrawal.withdrawal.Transaction, withdrawal.gameIndex, outputRootProof, withdrawalProof)
+		cli.ErrCheck(err, quiet, "Failed to create prove transaction")
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":          "bridge",
+			"command":        "prove",
+			"withdrawalhash": withdrawal.withdrawal.Hash.Hex(),
+			"disputegame":    withdrawal.game.Hex(),
+		}, true)
+	},
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeProveCmd)
+	bridgeFlags(bridgeProveCmd)
+	transactionFlags(bridgeProveCmd)
+	bridgeProveCmd.Flags().StringVar(&bridgeProveFromAddress, "from", "", "Address from which to prove the withdrawal")
+	addTransactionFlags(bridgeProveCmd, "the address from which to prove the withdrawal")
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// bridgeStatusCmd represents the bridge status command
+var bridgeStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Obtain the status of a withdrawal from an OP-stack chain",
+	Long: `Obtain the status of a withdrawal from an OP-stack chain as it moves through proving and finalization.  This command is run against the layer 1 chain, with the withdrawal identified by the hash of the transaction that started it on the OP-stack chain.  For example:
+
+    ethereal bridge status --l2-connection=https://mainnet.base.org --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1
+
+The status is one of:
+
+  - waiting to prove: the withdrawal is not yet covered by a dispute game
+  - ready to prove: the withdrawal can be proven with "ethereal bridge prove"
+  - waiting to finalize: the withdrawal has been proven, but the proof has not yet matured or its dispute game is not resolved
+  - ready to finalize: the withdrawal can be finalized with "ethereal bridge finalize"
+  - finalized: the withdrawal is complete
+
+In quiet mode this will return 0 if the withdrawal has been finalized, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain withdrawal status when offline")
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+
+		ctx := context.Background()
+		l2, err := bridgeL2Conn(ctx)
+		cli.ErrCheck(err, quiet, "Failed to connect to OP-stack chain")
+		deployment, err := bridgeDeployment(l2.ChainID())
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge")
+		portal, err := bridgePortal(deployment)
+		cli.ErrCheck(err, quiet, "Failed to obtain portal contract")
+
+		withdrawal, err := bridgeWithdrawalState(ctx, l2, portal, common.HexToHash(transactionStr), nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain withdrawal")
+
+		if quiet {
+			if withdrawal.state == withdrawalFinalized {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(newBridgeWithdrawalJSON(withdrawal))
+		}
+
+		fmt.Print(withdrawal.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// bridgeWithdrawalJSON is the JSON output for the status of a withdrawal.
+type bridgeWithdrawalJSON struct {
+	WithdrawalHash     string     `json:"withdrawal_hash"`
+	L2Block            uint64     `json:"l2_block"`
+	Status             string     `json:"status"`
+	DisputeGame        string     `json:"dispute_game,omitempty"`
+	DisputeGameL2Block uint64     `json:"dispute_game_l2_block,omitempty"`
+	ProofSubmitter     string     `json:"proof_submitter,omitempty"`
+	ProvenAt           *time.Time `json:"proven_at,omitempty"`
+	FinalizableAt      *time.Time `json:"finalizable_at,omitempty"`
+}
+
+func newBridgeWithdrawalJSON(w *bridgeWithdrawal) *bridgeWithdrawalJSON {
+	res := &bridgeWithdrawalJSON{
+		WithdrawalHash:     w.withdrawal.Hash.Hex(),
+		L2Block:            w.l2Block,
+		Status:             w.state,
+		DisputeGameL2Block: w.gameL2Block,
+	}
+	if w.game != unknownAddress {
+		res.DisputeGame = w.game.Hex()
+	}
+	if !w.provenAt.IsZero() {
+		res.ProofSubmitter = w.proofSubmitter.Hex()
+		res.ProvenAt = &w.provenAt
+		res.FinalizableAt = &w.finalizableAt
+	}
+	return res
+}
+
+// String returns a text description of the withdrawal.
+func (w *bridgeWithdrawal) String() string {
+	builder := new(strings.Builder)
+	builder.WriteString(fmt.Sprintf("Withdrawal hash:\t%s\n", w.withdrawal.Hash.Hex()))
+	builder.WriteString(fmt.Sprintf("L2 block:\t\t%d\n", w.l2Block))
+	builder.WriteString(fmt.Sprintf("Status:\t\t\t%s\n", w.state))
+	switch w.state {
+	case withdrawalWaitingToProve:
+		if w.gameIndex != nil {
+			builder.WriteString(fmt.Sprintf("Latest dispute game:\tL2 block %d\n", w.gameL2Block))
+		}
+	case withdrawalReadyToProve:
+		if verbose {
+			builder.WriteString(fmt.Sprintf("Dispute game:\t\t%s (L2 block %d)\n", w.game.Hex(), w.gameL2Block))
+		}
+	case withdrawalWaitingToFinalize, withdrawalReadyToFinalize:
+		builder.WriteString(fmt.Sprintf("Proven by:\t\t%s\n", w.proofSubmitter.Hex()))
+		builder.WriteString(fmt.Sprintf("Proven at:\t\t%s\n", w.provenAt.Format(time.RFC3339)))
+		builder.WriteString(fmt.Sprintf("Finalizable at:\t\t%s\n", w.finalizableAt.Format(time.RFC3339)))
+		if verbose {
+			builder.WriteString(fmt.Sprintf("Dispute game:\t\t%s\n", w.game.Hex()))
+			if w.notFinalizable != nil {
+				builder.WriteString(fmt.Sprintf("Not finalizable:\t%v\n", w.notFinalizable))
+			}
+		}
+	}
+	return builder.String()
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeStatusCmd)
+	bridgeFlags(bridgeStatusCmd)
+	transactionFlags(bridgeStatusCmd)
+}
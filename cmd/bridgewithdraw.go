@@ -0,0 +1,125 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var bridgeWithdrawAmount string
+var bridgeWithdrawFromAddress string
+var bridgeWithdrawToAddress string
+var bridgeWithdrawToken string
+var bridgeWithdrawMinGasLimit uint32
+
+// bridgeWithdrawCmd represents the bridge withdraw command
+var bridgeWithdrawCmd = &cobra.Command{
+	Use:   "withdraw",
+	Short: "Start a withdrawal of Ether or tokens from an OP-stack chain",
+	Long: `Start a withdrawal of Ether or ERC-20 tokens from an OP-stack chain through its standard bridge.  This command is run against the OP-stack chain.  For example:
+
+    ethereal bridge withdraw --connection=https://mainnet.base.org --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --amount=0.1ether --passphrase=secret
+
+To withdraw tokens supply the layer 2 token with --token.
+
+The hash of the transaction identifies the withdrawal, which must then be proven with "ethereal bridge prove" and, once the proof has matured, finalized with "ethereal bridge finalize".  Both of these are run against the layer 1 chain.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !offline {
+			ctx, cancel := localContext()
+			defer cancel()
+			rollup, err := c.Rollup(ctx)
+			cli.ErrCheck(err, quiet, "Failed to obtain rollup type of chain")
+			cli.Assert(rollup == conn.RollupOPStack, quiet, "Withdrawals must be started on an OP-stack chain")
+		}
+
+		bridgeWithdrawFromAddress = accountOrDefault(bridgeWithdrawFromAddress)
+		cli.Assert(bridgeWithdrawFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(bridgeWithdrawFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", bridgeWithdrawFromAddress))
+
+		toAddress := fromAddress
+		if bridgeWithdrawToAddress != "" {
+			toAddress, err = c.Resolve(bridgeWithdrawToAddress)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", bridgeWithdrawToAddress))
+		}
+
+		cli.Assert(bridgeWithdrawAmount != "", quiet, "--amount is required")
+		l2Token := util.OPStackETH
+		var amount *big.Int
+		if bridgeWithdrawToken != "" {
+			cli.Assert(!offline, quiet, "Cannot withdraw tokens when offline")
+			l2Token, err = tokenContractAddress(bridgeWithdrawToken)
+			cli.ErrCheck(err, quiet, "Failed to obtain token address")
+			token, err := contracts.NewERC20(l2Token, c.Client())
+			cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+			decimals, err := token.Decimals(nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
+			amount, err = util.StringToTokenValue(bridgeWithdrawAmount, decimals)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+			balance, err := token.BalanceOf(nil, fromAddress)
+			cli.ErrCheck(err, quiet, "Failed to obtain token balance")
+			cli.Assert(balance.Cmp(amount) >= 0, quiet, fmt.Sprintf("Balance of %s insufficient for withdrawal", formatTokens(balance, decimals, false)))
+		} else {
+			amount, err = string2eth.StringToWei(bridgeWithdrawAmount)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+		}
+
+		bridge, err := contracts.NewL2StandardBridge(util.OPStackL2StandardBridge, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain bridge contract")
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		if l2Token == util.OPStackETH {
+			opts.Value = amount
+		}
+
+		signedTx, err := bridge.WithdrawTo(opts, l2Token, toAddress, amount, bridgeWithdrawMinGasLimit, nil)
+		cli.ErrCheck(err, quiet, "Failed to create withdrawal transaction")
+
+		if offline {
+			outputSignedTransaction(signedTx)
+			os.Exit(exitSuccess)
+		}
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":     "bridge",
+			"command":   "withdraw",
+			"token":     l2Token.Hex(),
+			"recipient": toAddress.Hex(),
+			"amount":    amount.String(),
+		}, true)
+	},
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeWithdrawCmd)
+	bridgeWithdrawCmd.Flags().StringVar(&bridgeWithdrawAmount, "amount", "", "Amount of Ether or tokens to withdraw")
+	bridgeWithdrawCmd.Flags().StringVar(&bridgeWithdrawFromAddress, "from", "", "Address from which to withdraw")
+	bridgeWithdrawCmd.Flags().StringVar(&bridgeWithdrawToAddress, "to", "", "Address on layer 1 to receive the withdrawal (defaults to the from address)")
+	bridgeWithdrawCmd.Flags().StringVar(&bridgeWithdrawToken, "token", "", "Token to withdraw on the OP-stack chain (defaults to Ether)")
+	bridgeWithdrawCmd.Flags().Uint32Var(&bridgeWithdrawMinGasLimit, "min-gas-limit", 0, "Minimum gas limit for the withdrawal on layer 1")
+	addTransactionFlags(bridgeWithdrawCmd, "the address from which to withdraw")
+}
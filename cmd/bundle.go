@@ -0,0 +1,183 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+var bundleRaw []string
+var bundleRelay string
+var bundleBlockStr string
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Simulate and send bundles of transactions",
+	Long: `Simulate and send bundles of signed transactions to a block builder relay such as Flashbots.  The transactions in a bundle are included together, in order, or not at all.
+
+Requests to the relay are signed with the key supplied with private-relay-key in the configuration file, or a new key if none is supplied.`,
+}
+
+func init() {
+	RootCmd.AddCommand(bundleCmd)
+}
+
+func bundleFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&bundleRaw, "raw", nil, "signed transaction (as a hex string), or path to a file of signed transactions; can be repeated, with transactions added to the bundle in order")
+	cmd.Flags().StringVar(&bundleRelay, "relay", conn.DefaultBundleRelay, "relay to which to send the bundle")
+	cmd.Flags().StringVar(&bundleBlockStr, "block", "", "block in which the bundle should be included (default the next block)")
+}
+
+// bundleTransactions obtains the transactions supplied with --raw.
+func bundleTransactions() []*types.Transaction {
+	cli.Assert(len(bundleRaw) > 0, quiet, "--raw is required")
+	txs := make([]*types.Transaction, 0)
+	for _, raw := range bundleRaw {
+		signedTxs, err := readRawTransactions(raw)
+		cli.ErrCheck(err, quiet, "Failed to decode transactions")
+		txs = append(txs, signedTxs...)
+	}
+	cli.Assert(len(txs) > 0, quiet, "No transactions in bundle")
+	for _, tx := range txs {
+		if tx.Protected() || tx.Type() != types.LegacyTxType {
+			cli.Assert(tx.ChainId().Cmp(c.ChainID()) == 0, quiet, fmt.Sprintf("Transaction %s is for chain %v, not %v", tx.Hash().Hex(), tx.ChainId(), c.ChainID()))
+		}
+	}
+	return txs
+}
+
+// bundleTargetBlock returns the block in which the bundle should be included.
+func bundleTargetBlock(ctx context.Context) uint64 {
+	if bundleBlockStr != "" {
+		block, err := strconv.ParseUint(bundleBlockStr, 10, 64)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		return block
+	}
+	head, err := c.Client().BlockNumber(ctx)
+	cli.ErrCheck(err, quiet, "Failed to obtain current block")
+	return head + 1
+}
+
+// bundleSimulationJSON is the JSON output for a bundle simulation.
+type bundleSimulationJSON struct {
+	BundleHash        string                         `json:"bundle_hash"`
+	Block             uint64                         `json:"block"`
+	StateBlock        uint64                         `json:"state_block"`
+	Success           bool                           `json:"success"`
+	GasUsed           uint64                         `json:"gas_used"`
+	BundleGasPrice    string                         `json:"bundle_gas_price"`
+	GasFees           string                         `json:"gas_fees"`
+	EthSentToCoinbase string                         `json:"eth_sent_to_coinbase"`
+	CoinbasePayment   string                         `json:"coinbase_payment"`
+	Transactions      []*bundleTransactionResultJSON `json:"transactions"`
+}
+
+// bundleTransactionResultJSON is the JSON output for a transaction in a bundle simulation.
+type bundleTransactionResultJSON struct {
+	Hash              string `json:"hash"`
+	From              string `json:"from"`
+	To                string `json:"to,omitempty"`
+	Success           bool   `json:"success"`
+	GasUsed           uint64 `json:"gas_used"`
+	GasPrice          string `json:"gas_price"`
+	EthSentToCoinbase string `json:"eth_sent_to_coinbase"`
+	CoinbasePayment   string `json:"coinbase_payment"`
+	Error             string `json:"error,omitempty"`
+	Revert            string `json:"revert,omitempty"`
+}
+
+// bundleSucceeded returns true if all transactions in the simulated bundle succeeded.
+func bundleSucceeded(simulation *conn.BundleSimulation) bool {
+	for _, result := range simulation.Results {
+		if result.Error != "" || result.Revert != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func newBundleSimulationJSON(simulation *conn.BundleSimulation, block uint64) *bundleSimulationJSON {
+	res := &bundleSimulationJSON{
+		BundleHash:        simulation.BundleHash.Hex(),
+		Block:             block,
+		StateBlock:        simulation.StateBlockNumber,
+		Success:           bundleSucceeded(simulation),
+		GasUsed:           simulation.TotalGasUsed,
+		BundleGasPrice:    simulation.BundleGasPrice.String(),
+		GasFees:           simulation.GasFees.String(),
+		EthSentToCoinbase: simulation.EthSentToCoinbase.String(),
+		CoinbasePayment:   simulation.CoinbaseDiff.String(),
+		Transactions:      make([]*bundleTransactionResultJSON, len(simulation.Results)),
+	}
+	for i, result := range simulation.Results {
+		res.Transactions[i] = &bundleTransactionResultJSON{
+			Hash:              result.TxHash.Hex(),
+			From:              result.From.Hex(),
+			Success:           result.Error == "" && result.Revert == "",
+			GasUsed:           result.GasUsed,
+			GasPrice:          result.GasPrice.String(),
+			EthSentToCoinbase: result.EthSentToCoinbase.String(),
+			CoinbasePayment:   result.CoinbaseDiff.String(),
+			Error:             result.Error,
+			Revert:            result.Revert,
+		}
+		if result.To != nil {
+			res.Transactions[i].To = result.To.Hex()
+		}
+	}
+	return res
+}
+
+// bundleSimulationString returns a text description of a bundle simulation.
+func bundleSimulationString(simulation *conn.BundleSimulation, block uint64) string {
+	builder := new(strings.Builder)
+	for i, result := range simulation.Results {
+		builder.WriteString(fmt.Sprintf("Transaction %d:\t\t%s\n", i+1, result.TxHash.Hex()))
+		if verbose {
+			builder.WriteString(fmt.Sprintf("  From:\t\t\t%s\n", result.From.Hex()))
+			if result.To != nil {
+				builder.WriteString(fmt.Sprintf("  To:\t\t\t%s\n", result.To.Hex()))
+			}
+		}
+		switch {
+		case result.Error != "":
+			builder.WriteString(fmt.Sprintf("  Status:\t\tFailed (%s)\n", result.Error))
+		case result.Revert != "":
+			builder.WriteString(fmt.Sprintf("  Status:\t\tReverted (%s)\n", result.Revert))
+		default:
+			builder.WriteString("  Status:\t\tSucceeded\n")
+		}
+		builder.WriteString(fmt.Sprintf("  Gas used:\t\t%d\n", result.GasUsed))
+		builder.WriteString(fmt.Sprintf("  Gas price:\t\t%s\n", formatWei(result.GasPrice)))
+		builder.WriteString(fmt.Sprintf("  Coinbase payment:\t%s\n", formatWei(result.CoinbaseDiff)))
+	}
+	if verbose {
+		builder.WriteString(fmt.Sprintf("Bundle hash:\t\t%s\n", simulation.BundleHash.Hex()))
+		builder.WriteString(fmt.Sprintf("Block:\t\t\t%d (state from block %d)\n", block, simulation.StateBlockNumber))
+	}
+	builder.WriteString(fmt.Sprintf("Total gas used:\t\t%d\n", simulation.TotalGasUsed))
+	builder.WriteString(fmt.Sprintf("Bundle gas price:\t%s\n", formatWei(simulation.BundleGasPrice)))
+	builder.WriteString(fmt.Sprintf("Sent to coinbase:\t%s\n", formatWei(simulation.EthSentToCoinbase)))
+	builder.WriteString(fmt.Sprintf("Coinbase payment:\t%s\n", formatWei(simulation.CoinbaseDiff)))
+	return builder.String()
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var bundleSendBlocks uint64
+var bundleSendSkipSimulation bool
+
+// bundleSendCmd represents the bundle send command
+var bundleSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send a bundle of transactions to a relay",
+	Long: `Send a bundle of signed transactions to a relay for inclusion in a block.  For example:
+
+    ethereal bundle send --raw=0x02f86b... --raw=0x02f86c... --blocks=5
+
+The bundle is first simulated, and is not sent if any of its transactions fail unless --skip-simulation is supplied.  A bundle targets a single block, so with --blocks it is sent for that many consecutive blocks to increase the chance of it being included.
+
+This will return an exit status of 0 if the bundle is successfully sent (and included if --wait is supplied), 1 if the bundle is not successfully sent, and 2 if the bundle is successfully sent but not included in any of its target blocks.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot send bundles when offline")
+		cli.Assert(bundleSendBlocks > 0, quiet, "--blocks must be at least 1")
+		if dryRun {
+			// The bundle is simulated rather than sent.
+			bundleSimulateCmd.Run(cmd, args)
+		}
+		ctx, cancel := localContext()
+		defer cancel()
+
+		txs := bundleTransactions()
+		block := bundleTargetBlock(ctx)
+
+		if !bundleSendSkipSimulation {
+			simulation, err := c.SimulateBundle(ctx, bundleRelay, txs, block)
+			cli.ErrCheck(err, quiet, "Failed to simulate bundle")
+			if !bundleSucceeded(simulation) {
+				if !quiet {
+					fmt.Print(bundleSimulationString(simulation, block))
+				}
+				cli.Err(quiet, "Bundle simulation failed; not sending")
+			}
+			outputIf(verbose, fmt.Sprintf("Bundle simulation succeeded, with coinbase payment of %s", formatWei(simulation.CoinbaseDiff)))
+		}
+
+		for _, tx := range txs {
+			confirmTransaction(tx)
+		}
+
+		var bundleHash common.Hash
+		for i := uint64(0); i < bundleSendBlocks; i++ {
+			hash, err := c.SendBundle(ctx, bundleRelay, txs, block+i)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to send bundle for block %d", block+i))
+			bundleHash = hash
+			outputIf(debug, fmt.Sprintf("Sent bundle %s for block %d", hash.Hex(), block+i))
+		}
+		lastBlock := block + bundleSendBlocks - 1
+		for _, tx := range txs {
+			logTransaction(tx, log.Fields{
+				"group":   "bundle",
+				"command": "send",
+				"bundle":  bundleHash.Hex(),
+				"relay":   bundleRelay,
+			})
+		}
+
+		if !viper.GetBool("wait") {
+			if jsonOutput() {
+				outputJSON(&bundleSendJSON{Hash: bundleHash.Hex(), FirstBlock: block, LastBlock: lastBlock})
+			}
+			outputIf(!quiet, bundleHash.Hex())
+			os.Exit(exitSuccess)
+		}
+
+		included, err := waitForBundle(txs[0].Hash(), lastBlock)
+		cli.ErrCheck(err, quiet, "Failed to wait for bundle")
+		if jsonOutput() {
+			inBlock := included > 0
+			writeJSON(&bundleSendJSON{Hash: bundleHash.Hex(), FirstBlock: block, LastBlock: lastBlock, Included: &inBlock, Block: included})
+		} else if included > 0 {
+			outputIf(!quiet, fmt.Sprintf("%s included in block %d", bundleHash.Hex(), included))
+		} else {
+			outputIf(!quiet, fmt.Sprintf("%s sent but not included by block %d", bundleHash.Hex(), lastBlock))
+		}
+		if included == 0 {
+			os.Exit(exitNotMined)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// bundleSendJSON is the JSON output for the bundle send command.
+type bundleSendJSON struct {
+	Hash       string `json:"bundle_hash"`
+	FirstBlock uint64 `json:"first_block"`
+	LastBlock  uint64 `json:"last_block"`
+	Included   *bool  `json:"included,omitempty"`
+	Block      uint64 `json:"block,omitempty"`
+}
+
+// waitForBundle waits until the last target block of a bundle has been produced, returning the
+// block in which the first transaction of the bundle was included, or 0 if it was not included.
+func waitForBundle(firstTx common.Hash, lastBlock uint64) (uint64, error) {
+	for {
+		head, err := c.Client().BlockNumber(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		receipt, err := c.Client().TransactionReceipt(context.Background(), firstTx)
+		if err == nil && receipt != nil {
+			return receipt.BlockNumber.Uint64(), nil
+		}
+		if head > lastBlock {
+			return 0, nil
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func init() {
+	bundleCmd.AddCommand(bundleSendCmd)
+	bundleFlags(bundleSendCmd)
+	bundleSendCmd.Flags().Uint64Var(&bundleSendBlocks, "blocks", 1, "number of consecutive blocks for which to send the bundle")
+	bundleSendCmd.Flags().BoolVar(&bundleSendSkipSimulation, "skip-simulation", false, "send the bundle without first simulating it")
+	bundleSendCmd.Flags().Bool("wait", false, "wait for the bundle to be included, or for its target blocks to pass, before returning")
+}
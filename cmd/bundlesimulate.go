@@ -0,0 +1,62 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// bundleSimulateCmd represents the bundle simulate command
+var bundleSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate a bundle of transactions",
+	Long: `Simulate a bundle of signed transactions with a relay, reporting the result of each transaction and the payment to the block's coinbase.  For example:
+
+    ethereal bundle simulate --raw=0x02f86b... --raw=0x02f86c...
+
+In quiet mode this will return 0 if all transactions in the bundle succeed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot simulate bundles when offline")
+		ctx, cancel := localContext()
+		defer cancel()
+
+		txs := bundleTransactions()
+		block := bundleTargetBlock(ctx)
+		simulation, err := c.SimulateBundle(ctx, bundleRelay, txs, block)
+		cli.ErrCheck(err, quiet, "Failed to simulate bundle")
+
+		if quiet {
+			if bundleSucceeded(simulation) {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(newBundleSimulationJSON(simulation, block))
+		}
+
+		fmt.Print(bundleSimulationString(simulation, block))
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	bundleCmd.AddCommand(bundleSimulateCmd)
+	bundleFlags(bundleSimulateCmd)
+}
@@ -0,0 +1,216 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+)
+
+// chainInfoCmd represents the chain info command
+var chainInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about the chain",
+	Long: `Obtain information about the chain and the node to which ethereal is connected.  For example:
+
+    ethereal chain info
+
+This reports the chain ID, the latest block, if the chain has passed the merge, the client version and the sync status of the node.  If the chain is known, or if the node reports its fork schedule, hardfork activation is also reported.
+
+In quiet mode this will return 0 if the information is obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain chain information when offline")
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		info := &chainInfoJSON{
+			ChainID: c.ChainID(),
+			Network: c.NetworkName(),
+		}
+		if chain := chains.ByID(c.ChainID()); chain != nil {
+			info.Network = chain.Name
+			info.Currency = chain.Currency
+			info.Explorer = chain.Explorer
+		}
+
+		block, err := c.Block(ctx, "latest", false)
+		cli.ErrCheck(err, quiet, "Failed to obtain latest block")
+		info.LatestBlock = block.Number
+		info.LatestBlockTime = block.Timestamp
+		info.PostMerge = block.Difficulty == nil || block.Difficulty.Sign() == 0
+
+		// The following are not supported by all nodes, so failures are not fatal.
+		info.ClientVersion, err = c.ClientVersion(ctx)
+		if err != nil {
+			outputIf(verbose, fmt.Sprintf("Failed to obtain client version: %v", err))
+		}
+		info.Syncing, info.SyncCurrentBlock, info.SyncHighestBlock, err = chainInfoSyncStatus(ctx)
+		if err != nil {
+			outputIf(verbose, fmt.Sprintf("Failed to obtain sync status: %v", err))
+		}
+		info.ForkSchedule, err = c.ForkSchedule(ctx)
+		if err != nil {
+			outputIf(verbose, fmt.Sprintf("Failed to obtain fork schedule: %v", err))
+		}
+		if config := c.ChainConfig(); config != nil {
+			info.Hardforks = chainHardforks(config)
+			info.TerminalTotalDifficulty = config.TerminalTotalDifficulty
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(info)
+		}
+		chainInfoOutput(info)
+		os.Exit(exitSuccess)
+	},
+}
+
+// chainInfoJSON is the JSON output for information about the chain.
+type chainInfoJSON struct {
+	ChainID                 *big.Int           `json:"chain_id"`
+	Network                 string             `json:"network,omitempty"`
+	Currency                string             `json:"currency,omitempty"`
+	Explorer                string             `json:"explorer,omitempty"`
+	LatestBlock             uint64             `json:"latest_block"`
+	LatestBlockTime         time.Time          `json:"latest_block_time"`
+	PostMerge               bool               `json:"post_merge"`
+	ClientVersion           string             `json:"client_version,omitempty"`
+	Syncing                 bool               `json:"syncing"`
+	SyncCurrentBlock        uint64             `json:"sync_current_block,omitempty"`
+	SyncHighestBlock        uint64             `json:"sync_highest_block,omitempty"`
+	Hardforks               []*chainForkJSON   `json:"hardforks,omitempty"`
+	TerminalTotalDifficulty *big.Int           `json:"terminal_total_difficulty,omitempty"`
+	ForkSchedule            *conn.ForkSchedule `json:"fork_schedule,omitempty"`
+}
+
+// chainForkJSON is the JSON output for the activation of a hardfork.
+type chainForkJSON struct {
+	Name  string   `json:"name"`
+	Block *big.Int `json:"block"`
+}
+
+// chainInfoSyncStatus returns the sync status of the node.
+func chainInfoSyncStatus(ctx context.Context) (bool, uint64, uint64, error) {
+	syncProgress, err := c.Client().SyncProgress(ctx)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if syncProgress == nil {
+		return false, 0, 0, nil
+	}
+	return true, syncProgress.CurrentBlock, syncProgress.HighestBlock, nil
+}
+
+// chainHardforks returns the block-based hardforks that are configured for the chain.
+func chainHardforks(config *params.ChainConfig) []*chainForkJSON {
+	forks := []*chainForkJSON{
+		{Name: "Homestead", Block: config.HomesteadBlock},
+		{Name: "DAO", Block: config.DAOForkBlock},
+		{Name: "Tangerine Whistle", Block: config.EIP150Block},
+		{Name: "Spurious Dragon", Block: config.EIP158Block},
+		{Name: "Byzantium", Block: config.ByzantiumBlock},
+		{Name: "Constantinople", Block: config.ConstantinopleBlock},
+		{Name: "Petersburg", Block: config.PetersburgBlock},
+		{Name: "Istanbul", Block: config.IstanbulBlock},
+		{Name: "Muir Glacier", Block: config.MuirGlacierBlock},
+		{Name: "Berlin", Block: config.BerlinBlock},
+		{Name: "London", Block: config.LondonBlock},
+		{Name: "Arrow Glacier", Block: config.ArrowGlacierBlock},
+		{Name: "Merge", Block: config.MergeNetsplitBlock},
+	}
+	res := make([]*chainForkJSON, 0, len(forks))
+	for _, fork := range forks {
+		if fork.Block != nil {
+			res = append(res, fork)
+		}
+	}
+	return res
+}
+
+// chainInfoOutput outputs information about the chain in text form.
+func chainInfoOutput(info *chainInfoJSON) {
+	builder := new(strings.Builder)
+	if info.Network != "" {
+		builder.WriteString(fmt.Sprintf("Chain ID: %s (%s)\n", info.ChainID, info.Network))
+	} else {
+		builder.WriteString(fmt.Sprintf("Chain ID: %s\n", info.ChainID))
+	}
+	if info.Currency != "" {
+		builder.WriteString(fmt.Sprintf("Currency: %s\n", info.Currency))
+	}
+	if info.Explorer != "" {
+		builder.WriteString(fmt.Sprintf("Explorer: %s\n", info.Explorer))
+	}
+	builder.WriteString(fmt.Sprintf("Latest block: %d (%s)\n", info.LatestBlock, info.LatestBlockTime))
+	builder.WriteString(fmt.Sprintf("Post-merge: %t\n", info.PostMerge))
+	if info.ClientVersion != "" {
+		builder.WriteString(fmt.Sprintf("Client version: %s\n", info.ClientVersion))
+	}
+	if info.Syncing {
+		builder.WriteString(fmt.Sprintf("Sync status: syncing, at block %d of %d\n", info.SyncCurrentBlock, info.SyncHighestBlock))
+	} else {
+		builder.WriteString("Sync status: synchronised\n")
+	}
+	if info.ForkSchedule != nil {
+		builder.WriteString(fmt.Sprintf("Current fork: %#x, activated %s\n", []byte(info.ForkSchedule.Current.ForkID), info.ForkSchedule.Current.ActivationTime))
+		if info.ForkSchedule.Next != nil {
+			builder.WriteString(fmt.Sprintf("Next fork: %#x, activates %s\n", []byte(info.ForkSchedule.Next.ForkID), info.ForkSchedule.Next.ActivationTime))
+		}
+	}
+	if len(info.Hardforks) > 0 {
+		if verbose {
+			builder.WriteString("Hardforks:\n")
+			for _, fork := range info.Hardforks {
+				if fork.Block.Uint64() > info.LatestBlock {
+					builder.WriteString(fmt.Sprintf("  %s: block %s (not yet active)\n", fork.Name, fork.Block))
+				} else {
+					builder.WriteString(fmt.Sprintf("  %s: block %s\n", fork.Name, fork.Block))
+				}
+			}
+			if info.TerminalTotalDifficulty != nil {
+				builder.WriteString(fmt.Sprintf("  Terminal total difficulty: %s\n", info.TerminalTotalDifficulty))
+			}
+		} else {
+			var latest *chainForkJSON
+			for _, fork := range info.Hardforks {
+				if fork.Block.Uint64() <= info.LatestBlock {
+					latest = fork
+				}
+			}
+			if latest != nil {
+				builder.WriteString(fmt.Sprintf("Latest block-based hardfork: %s (block %s)\n", latest.Name, latest.Block))
+			}
+		}
+	}
+	fmt.Print(builder.String())
+}
+
+func init() {
+	chainCmd.AddCommand(chainInfoCmd)
+	chainFlags(chainInfoCmd)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// confirmMu serialises confirmation prompts for commands that send transactions concurrently.
+var confirmMu sync.Mutex
+
+// confirmTransaction asks the user to confirm the transaction before it is sent if its value
+// or maximum fee is above the thresholds set with --confirm-value and --confirm-fee.  It exits
+// if the transaction is not confirmed.  No confirmation is required if --yes is supplied.
+func confirmTransaction(tx *types.Transaction) {
+	if viper.GetBool("yes") {
+		return
+	}
+	maxFee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+	if !confirmationRequired(tx.Value(), maxFee) {
+		return
+	}
+
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+	cli.Assert(cli.IsTerminal(), quiet, "Transaction requires confirmation; supply --yes to send it without confirmation")
+	fmt.Fprint(os.Stderr, confirmationString(tx, maxFee))
+	answer, err := cli.Prompt("Send transaction? [y/N] ")
+	cli.ErrCheck(err, quiet, "Failed to obtain confirmation")
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		cli.Err(quiet, "Transaction not confirmed; not sending")
+	}
+}
+
+// confirmationRequired returns true if the value or maximum fee of a transaction is above the
+// threshold that requires confirmation.
+func confirmationRequired(value *big.Int, maxFee *big.Int) bool {
+	if threshold := viper.GetString("confirm-value"); threshold != "" {
+		limit, err := string2eth.StringToWei(threshold)
+		cli.ErrCheck(err, quiet, "Invalid confirm-value")
+		if value.Cmp(limit) > 0 {
+			return true
+		}
+	}
+	if threshold := viper.GetString("confirm-fee"); threshold != "" {
+		limit, err := string2eth.StringToWei(threshold)
+		cli.ErrCheck(err, quiet, "Invalid confirm-fee")
+		if maxFee.Cmp(limit) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmationString returns a summary of a transaction for the user to confirm.
+func confirmationString(tx *types.Transaction, maxFee *big.Int) string {
+	builder := strings.Builder{}
+	if tx.To() == nil {
+		builder.WriteString("To:\t\t(contract creation)\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("To:\t\t%s\n", formatAddress(*tx.To())))
+	}
+	if tx.To() != nil && len(tx.Data()) > 0 {
+		builder.WriteString(fmt.Sprintf("Function:\t%s\n", confirmationFunction(tx.Data())))
+	}
+	builder.WriteString(fmt.Sprintf("Value:\t\t%s\n", formatWei(tx.Value())))
+	builder.WriteString(fmt.Sprintf("Maximum fee:\t%s\n", formatWei(maxFee)))
+	builder.WriteString(fmt.Sprintf("Maximum total:\t%s\n", formatWei(new(big.Int).Add(tx.Value(), maxFee))))
+	return builder.String()
+}
+
+// confirmationFunction decodes the function called by transaction data, using the token
+// standards and well-known signatures, falling back to the selector if it is not known.
+func confirmationFunction(data []byte) string {
+	if len(data) < 4 {
+		return fmt.Sprintf("%#x", data)
+	}
+	for _, contractAbi := range util.StandardABIs() {
+		if decoded, err := abiDecode(contractAbi, data); err == nil {
+			if res, err := fourByteDecodedToString(decoded); err == nil {
+				return res
+			}
+		}
+	}
+	if res := txdata.DataToString(c.Client(), data); !strings.HasPrefix(res, "0x") {
+		return res
+	}
+	return fmt.Sprintf("unknown (selector %#x, %d bytes of data)", data[:4], len(data))
+}
@@ -19,16 +19,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"reflect"
 	"regexp"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/wealdtech/ethereal/v2/cli"
 	"github.com/wealdtech/ethereal/v2/util"
-	ens "github.com/wealdtech/go-ens/v3"
+	"github.com/wealdtech/ethereal/v2/util/abisource"
 )
 
 var contractStr string
@@ -36,6 +36,9 @@ var contractAbi string
 var contractFunction string
 var contractJSON string
 var contractName string
+var contractFetchABI bool
+var contractABISource string
+var contractResolveProxy bool
 
 // contractCmd represents the contract command
 var contractCmd = &cobra.Command{
@@ -54,6 +57,8 @@ func contractFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&contractFunction, "function", "", "Signature of function")
 	cmd.Flags().StringVar(&contractJSON, "json", "", "JSON, or path to JSON, for the contract as output by solc --combined-json=bin,abi")
 	cmd.Flags().StringVar(&contractName, "name", "", "Name of the contract (required when using json)")
+	cmd.Flags().BoolVar(&contractFetchABI, "fetch-abi", false, "Fetch the verified ABI for the contract if not supplied with --abi or --json")
+	cmd.Flags().StringVar(&contractABISource, "abi-source", "", "Source from which to fetch the ABI (etherscan/sourcify/blockscout) (default sourcify)")
 }
 
 // parse contract given the information from various flags
@@ -75,10 +80,12 @@ func parseContract(binStr string) *util.Contract {
 		}
 
 		// Add binary if present
-		var bin []byte
-		bin, err = hex.DecodeString(strings.TrimPrefix(binStr, "0x"))
-		cli.ErrCheck(err, quiet, "Failed to decode data")
-		contract = &util.Contract{Binary: bin}
+		if util.IsUnlinked(binStr) {
+			contract.UnlinkedBinary = strings.TrimPrefix(binStr, "0x")
+		} else {
+			contract.Binary, err = hex.DecodeString(strings.TrimPrefix(binStr, "0x"))
+			cli.ErrCheck(err, quiet, "Failed to decode data")
+		}
 
 		// Add ABI if present either directly or via a function
 		if contractAbi != "" {
@@ -89,15 +96,63 @@ func parseContract(binStr string) *util.Contract {
 			abi, err := contractParseFunction(contractFunction)
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse function %s", contractFunction))
 			contract.Abi = *abi
+		} else if contractFetchABI {
+			abi, err := contractFetchAbi()
+			cli.ErrCheck(err, quiet, "Failed to fetch ABI")
+			contract.Abi = abi
 		}
 	}
 	return contract
 }
 
+// contractFetchAbi fetches the verified ABI for the contract from an online source.
+func contractFetchAbi() (abi.ABI, error) {
+	cli.Assert(contractStr != "", quiet, "--contract is required to fetch the ABI")
+	address, err := c.Resolve(contractStr)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	if contractResolveProxy {
+		address = contractImplementation(address)
+	}
+	return fetchAbi(address)
+}
+
+// fetchAbi fetches the verified ABI for the given address from an online source.
+func fetchAbi(address common.Address) (abi.ABI, error) {
+	sourceName := contractABISource
+	if sourceName == "" {
+		sourceName = viper.GetString("abi-source")
+	}
+	if sourceName == "" {
+		sourceName = "sourcify"
+	}
+	key := viper.GetString("etherscan-api-key")
+	if key == "" {
+		key = os.Getenv("ETHERSCAN_API_KEY")
+	}
+	source, err := abisource.New(sourceName, key, serviceURL(sourceName))
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	if dir, err := dataDir(); err == nil {
+		source = abisource.NewCache(source, filepath.Join(dir, "abis"))
+	}
+
+	ctx, cancel := localContext()
+	defer cancel()
+	outputIf(verbose, fmt.Sprintf("Fetching ABI for %s from %s", address.Hex(), sourceName))
+	data, err := source.ABI(ctx, c.ChainID(), address)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	return abi.JSON(strings.NewReader(data))
+}
+
 func contractParseAbi(input string) (output abi.ABI, err error) {
 	var reader io.Reader
 
-	if strings.HasPrefix(contractAbi, "[") {
+	if strings.HasPrefix(input, "[") {
 		// ABI is direct
 		reader = strings.NewReader(input)
 	} else {
@@ -170,60 +225,68 @@ func contractParseFunction(input string) (*abi.ABI, error) {
 	return res, nil
 }
 
-func contractValueToString(argType abi.Type, val interface{}) (string, error) {
-	switch argType.T {
-	case abi.IntTy:
-		return fmt.Sprintf("%v", val), nil
-	case abi.UintTy:
-		return fmt.Sprintf("%v", val), nil
-	case abi.BoolTy:
-		if val.(bool) {
-			return "true", nil
-		}
-		return "false", nil
-	case abi.StringTy:
-		return val.(string), nil
-	case abi.SliceTy:
-		res := make([]string, 0)
-		arrayVal := reflect.ValueOf(val)
-		for i := 0; i < arrayVal.Len(); i++ {
-			elemRes, err := contractValueToString(*argType.Elem, arrayVal.Index(i).Interface())
-			if err != nil {
-				return "", err
-			}
-			res = append(res, elemRes)
-		}
-		return "[" + strings.Join(res, ",") + "]", nil
-	case abi.ArrayTy:
-		res := make([]string, 0)
-		arrayVal := reflect.ValueOf(val)
-		for i := 0; i < arrayVal.Len(); i++ {
-			elemRes, err := contractValueToString(*argType.Elem, arrayVal.Index(i).Interface())
-			if err != nil {
-				return "", err
-			}
-			res = append(res, elemRes)
+// contractImplementation returns the implementation of the contract if it is a proxy, otherwise
+// the contract itself.
+func contractImplementation(address common.Address) common.Address {
+	ctx, cancel := localContext()
+	defer cancel()
+	proxy, err := util.ProxyDetails(ctx, c.Client(), address, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain proxy details")
+	if proxy == nil {
+		return address
+	}
+	return proxy.Implementation
+}
+
+// create2Factory returns the address of the CREATE2 factory, which is the supplied address if
+// present, otherwise the factory in the configuration file or the deterministic deployment proxy.
+func create2Factory(input string) (common.Address, error) {
+	if input == "" {
+		input = viper.GetString("create2-factory")
+	}
+	if input == "" {
+		return util.DefaultCreate2Factory, nil
+	}
+	return c.Resolve(input)
+}
+
+// revertCheck checks for an error from a call or gas estimate and quits if it is present,
+// decoding the revert reason where possible.
+func revertCheck(err error, contractAbi *abi.ABI, msg string) {
+	if err != nil {
+		cli.Err(quiet, fmt.Sprintf("%s: %s", msg, util.RevertReason(contractAbi, err)))
+	}
+}
+
+// argumentJSON is the JSON output for a decoded argument.
+type argumentJSON struct {
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// argumentsJSON creates the JSON output for decoded arguments.
+func argumentsJSON(args abi.Arguments, values []interface{}) ([]*argumentJSON, error) {
+	res := make([]*argumentJSON, len(values))
+	for i := range values {
+		val, err := contractValueToString(args[i].Type, values[i])
+		if err != nil {
+			return nil, err
 		}
-		return "[" + strings.Join(res, ",") + "]", nil
-	case abi.AddressTy:
-		addr := val.(common.Address)
-		return ens.Format(c.Client(), addr), nil
-	case abi.FixedBytesTy:
-		arrayVal := reflect.ValueOf(val)
-		castVal := make([]byte, arrayVal.Len())
-		for i := 0; i < arrayVal.Len(); i++ {
-			castVal[i] = byte(arrayVal.Index(i).Uint())
+		res[i] = &argumentJSON{
+			Name:  args[i].Name,
+			Type:  args[i].Type.String(),
+			Value: val,
 		}
-		return fmt.Sprintf("0x%s", hex.EncodeToString(castVal)), nil
-	case abi.BytesTy:
-		return fmt.Sprintf("0x%s", hex.EncodeToString(val.([]byte))), nil
-	case abi.HashTy:
-		return val.(common.Hash).Hex(), nil
-	case abi.FixedPointTy:
-		return "", fmt.Errorf("unhandled type %v", argType)
-	case abi.FunctionTy:
-		return "", fmt.Errorf("unhandled type %v", argType)
-	default:
-		return "", fmt.Errorf("unknown type %v", argType)
 	}
+	return res, nil
+}
+
+// contractValueToString formats a value unpacked from ABI-encoded data according to its type,
+// resolving addresses to their ENS names when online.
+func contractValueToString(argType abi.Type, val interface{}) (string, error) {
+	if offline {
+		return util.ABIValueToString(argType, val, nil)
+	}
+	return util.ABIValueToString(argType, val, formatAddress)
 }
@@ -0,0 +1,141 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var contractAddressDeployer string
+var contractAddressNonce string
+var contractAddressCreate2 bool
+var contractAddressSalt string
+var contractAddressInitCode string
+var contractAddressInitCodeHash string
+var contractAddressCount uint64
+
+// contractAddressCmd represents the contract address command
+var contractAddressCmd = &cobra.Command{
+	Use:   "address",
+	Short: "Calculate the address of a contract",
+	Long: `Calculate the address at which a contract will be deployed.  For example:
+
+    ethereal contract address --deployer=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --nonce=5
+
+If --deployer is not supplied the default account is used.  If --nonce is not supplied the next nonce of the deployer, including pending transactions, is used.  The addresses of contracts deployed with the following nonces can be shown with --count, which is useful when pre-funding or pre-authorizing a number of contracts before they are deployed.
+
+With --create2 the address of a contract deployed with CREATE2 is calculated from the salt and the init code (the contract binary including any constructor arguments) or its hash, for example:
+
+    ethereal contract address --create2 --salt=0x01 --initcode=0x6080...0033
+
+In this case the deployer defaults to the CREATE2 factory used by contract deploy --create2.
+
+In quiet mode this will return 0 if the address can be calculated, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if contractAddressCreate2 {
+			deployer, err := create2Factory(contractAddressDeployer)
+			cli.ErrCheck(err, quiet, "Failed to obtain deployer address")
+			salt, err := util.ParseSalt(contractAddressSalt)
+			cli.ErrCheck(err, quiet, "Failed to parse salt")
+			var initCodeHash []byte
+			switch {
+			case contractAddressInitCodeHash != "":
+				cli.Assert(contractAddressInitCode == "", quiet, "--initcode and --initcode-hash are mutually exclusive")
+				initCodeHash, err = hex.DecodeString(strings.TrimPrefix(contractAddressInitCodeHash, "0x"))
+				cli.ErrCheck(err, quiet, "Failed to parse init code hash")
+				cli.Assert(len(initCodeHash) == common.HashLength, quiet, "Init code hash must be 32 bytes")
+			case contractAddressInitCode != "":
+				initCode, err := hex.DecodeString(strings.TrimPrefix(contractAddressInitCode, "0x"))
+				cli.ErrCheck(err, quiet, "Failed to parse init code")
+				initCodeHash = crypto.Keccak256(initCode)
+			default:
+				cli.Err(quiet, "--initcode or --initcode-hash is required")
+			}
+			outputIf(verbose, fmt.Sprintf("Deployer is %s", deployer.Hex()))
+			address := util.Create2Address(deployer, salt, initCodeHash)
+
+			if quiet {
+				os.Exit(exitSuccess)
+			}
+			if jsonOutput() {
+				outputJSON(&contractAddressJSON{Address: address.Hex()})
+			}
+			fmt.Println(address.Hex())
+			os.Exit(exitSuccess)
+		}
+
+		cli.Assert(contractAddressCount > 0, quiet, "--count must be greater than 0")
+		contractAddressDeployer = accountOrDefault(contractAddressDeployer)
+		cli.Assert(contractAddressDeployer != "", quiet, "--deployer is required")
+		deployer, err := c.Resolve(contractAddressDeployer)
+		cli.ErrCheck(err, quiet, "Failed to obtain deployer address")
+		// The nonce flag is bound to the connection's nonce, so this uses it if supplied.
+		nonce, err := c.CurrentNonce(context.Background(), deployer)
+		cli.ErrCheck(err, quiet, "Failed to obtain nonce")
+		outputIf(verbose, fmt.Sprintf("Nonce is %d", nonce))
+
+		res := make([]*contractAddressJSON, contractAddressCount)
+		for i := range res {
+			entryNonce := nonce + uint64(i)
+			res[i] = &contractAddressJSON{
+				Nonce:   &entryNonce,
+				Address: crypto.CreateAddress(deployer, entryNonce).Hex(),
+			}
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			if contractAddressCount == 1 {
+				outputJSON(res[0])
+			}
+			outputJSON(res)
+		}
+		if contractAddressCount == 1 {
+			fmt.Println(res[0].Address)
+			os.Exit(exitSuccess)
+		}
+		for _, entry := range res {
+			fmt.Printf("%d\t%s\n", *entry.Nonce, entry.Address)
+		}
+	},
+}
+
+// contractAddressJSON is the JSON output for the address of a contract.
+type contractAddressJSON struct {
+	Nonce   *uint64 `json:"nonce,omitempty"`
+	Address string  `json:"address"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractAddressCmd)
+	contractAddressCmd.Flags().StringVar(&contractAddressDeployer, "deployer", "", "Address that deploys the contract (default the default account, or the CREATE2 factory with --create2)")
+	contractAddressCmd.Flags().StringVar(&contractAddressNonce, "nonce", "", "Nonce of the deployment transaction, or 'pending' or 'latest' to use the deployer's current nonce (default pending)")
+	contractAddressCmd.Flags().Uint64Var(&contractAddressCount, "count", 1, "Number of consecutive nonces for which to calculate addresses")
+	contractAddressCmd.Flags().BoolVar(&contractAddressCreate2, "create2", false, "Calculate the address for a CREATE2 deployment")
+	contractAddressCmd.Flags().StringVar(&contractAddressSalt, "salt", "", "Salt for a CREATE2 deployment (hex, up to 32 bytes)")
+	contractAddressCmd.Flags().StringVar(&contractAddressInitCode, "initcode", "", "Init code for a CREATE2 deployment (hex)")
+	contractAddressCmd.Flags().StringVar(&contractAddressInitCodeHash, "initcode-hash", "", "Keccak-256 hash of the init code for a CREATE2 deployment (hex)")
+}
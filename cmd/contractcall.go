@@ -14,20 +14,27 @@
 package cmd
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
 
 	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
 	"github.com/wealdtech/ethereal/v2/util/funcparser"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
 )
 
 var contractCallFromAddress string
 var contractCallCall string
 var contractCallData string
+var contractCallBlock string
+var contractCallTrace bool
 
 // contractCallCmd represents the contract call command
 var contractCallCmd = &cobra.Command{
@@ -39,8 +46,35 @@ var contractCallCmd = &cobra.Command{
 
    ethereal contract call --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --signature="balanceOf(address)" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call="balanceOf(@wealdtech.eth)"
 
+Arguments that are arrays, either dynamic or fixed-size, are supplied as bracketed comma-separated lists, which can be nested, for example:
+
+   ethereal contract call --contract=0x... --abi="./MyContract.abi" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call='foo([1,2,3],[0x5FfC014343cd971B7eb70732021E26C35B744cc4,0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845],[[1],[2,3]])'
+
+Arguments that are tuples (Solidity structs) are supplied as quoted JSON, either as an object keyed by component name or as an array of components in order, for example:
+
+   ethereal contract call --contract=0x... --abi="./MyContract.abi" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call='getQuote('"'"'{"token":"0x5FfC014343cd971B7eb70732021E26C35B744cc4","amount":100}'"'"')'
+
+If the contract has overloaded methods (methods with the same name but different arguments) the method is selected by its number of arguments.  If that is ambiguous the signature of the method should precede its arguments, for example:
+
+   ethereal contract call --contract=0x... --abi="./MyContract.abi" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call='getValue(uint256)(5)'
+
+If the ABI is not supplied it can be fetched from an online source of verified contracts with --fetch-abi.  If the contract is a proxy then --resolve-proxy will detect its implementation and fetch the ABI of the implementation instead, for example:
+
+   ethereal contract call --contract=0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 --fetch-abi --resolve-proxy --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call="name()"
+
+The source of the ABI is selected with --abi-source; Etherscan requires an API key, supplied through the etherscan-api-key configuration value or the ETHERSCAN_API_KEY environment variable, and Blockscout requires its URL, supplied through the blockscout-url configuration value.
+
+The call is made against the state at the latest block unless --block is supplied, which can be a block number, hash or offset from the latest block (e.g. -10).  Calls against old blocks require an archive node.
+
+The internal calls made by the call can be displayed with --trace, which requires a node that supports the debug namespace.
+
+State can be overridden for the duration of the call with --override-balance, --override-nonce, --override-code and --override-storage, for example to call a contract as if the caller held a balance:
+
+   ethereal contract call --contract=0x... --abi="./MyContract.abi" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call='deposit()' --override-balance=0x5FfC014343cd971B7eb70732021E26C35B744cc4=100Ether
+
 In quiet mode this will return 0 if the contract is successfully called, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		contractCallFromAddress = accountOrDefault(contractCallFromAddress)
 		cli.Assert(contractCallFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(contractCallFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", contractCallFromAddress))
@@ -49,6 +83,18 @@ In quiet mode this will return 0 if the contract is successfully called, otherwi
 		contractAddress, err := c.Resolve(contractStr)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
 
+		ctx, cancel := localContext()
+		defer cancel()
+		blockNumber, err := parseBlockNumber(ctx, contractCallBlock)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		overrides, err := parseStateOverrides()
+		cli.ErrCheck(err, quiet, "Invalid state override")
+
+		if contractResolveProxy && verbose {
+			implementation := contractImplementation(contractAddress)
+			outputIf(implementation != contractAddress, fmt.Sprintf("Contract is a proxy for %s", implementation.Hex()))
+		}
+
 		if contractCallData != "" {
 			// Raw data in and out
 			data, err := hex.DecodeString(strings.TrimPrefix(contractCallData, "0x"))
@@ -61,8 +107,11 @@ In quiet mode this will return 0 if the contract is successfully called, otherwi
 			}
 			ctx, cancel := localContext()
 			defer cancel()
-			result, err := c.Client().CallContract(ctx, msg, nil)
-			cli.ErrCheck(err, quiet, "Call failed")
+			result, err := c.CallContract(ctx, msg, blockNumber, overrides)
+			revertCheck(err, nil, "Call failed")
+			if jsonOutput() {
+				outputJSON(map[string]interface{}{"result": fmt.Sprintf("%#x", result)})
+			}
 			outputIf(!quiet, fmt.Sprintf("%x", result))
 			os.Exit(exitSuccess)
 		}
@@ -84,12 +133,25 @@ In quiet mode this will return 0 if the contract is successfully called, otherwi
 			To:   &contractAddress,
 			Data: data,
 		}
-		ctx, cancel := localContext()
+		var trace *conn.CallFrame
+		if contractCallTrace {
+			trace, err = c.TraceCall(context.Background(), msg, blockNumber, overrides)
+			cli.ErrCheck(err, quiet, "Failed to trace call")
+			if !quiet && !jsonOutput() {
+				txdata.InitFunctionMap()
+				printCallFrame(trace, 0, map[common.Address]*abi.ABI{contractAddress: &contract.Abi})
+			}
+		}
+
+		ctx, cancel = localContext()
 		defer cancel()
-		result, err := c.Client().CallContract(ctx, msg, nil)
-		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to call %s", method.Name))
+		result, err := c.CallContract(ctx, msg, blockNumber, overrides)
+		revertCheck(err, &contract.Abi, fmt.Sprintf("Failed to call %s", method.Name))
 		if len(method.Outputs) == 0 {
 			// No output
+			if jsonOutput() {
+				outputJSON(&contractCallJSON{Method: method.Name, Results: []*argumentJSON{}, Trace: trace})
+			}
 			os.Exit(exitSuccess)
 		}
 		cli.Assert(len(result) > 0, quiet, fmt.Sprintf("Call to %s did not return expected data", method.Name))
@@ -103,6 +165,12 @@ In quiet mode this will return 0 if the contract is successfully called, otherwi
 		outputs, err := contract.Abi.Unpack(method.Name, result)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse output of %s: %v", method.Name, err))
 
+		if jsonOutput() {
+			results, err := argumentsJSON(method.Outputs, outputs)
+			cli.ErrCheck(err, quiet, "Failed to turn values in to suitable output")
+			outputJSON(&contractCallJSON{Method: method.Name, Results: results, Trace: trace})
+		}
+
 		results := []string{}
 		for i := range outputs {
 			val, err := contractValueToString(method.Outputs[i].Type, outputs[i])
@@ -115,10 +183,21 @@ In quiet mode this will return 0 if the contract is successfully called, otherwi
 	},
 }
 
+// contractCallJSON is the JSON output for a contract call.
+type contractCallJSON struct {
+	Method  string          `json:"method"`
+	Results []*argumentJSON `json:"results"`
+	Trace   *conn.CallFrame `json:"trace,omitempty"`
+}
+
 func init() {
 	contractCmd.AddCommand(contractCallCmd)
 	contractFlags(contractCallCmd)
 	contractCallCmd.Flags().StringVar(&contractCallFromAddress, "from", "", "Address from which to call the contract method")
 	contractCallCmd.Flags().StringVar(&contractCallData, "data", "", "Raw hex data to use in the call")
 	contractCallCmd.Flags().StringVar(&contractCallCall, "call", "", "Contract method to call")
+	stateOverrideFlags(contractCallCmd)
+	contractCallCmd.Flags().BoolVar(&contractCallTrace, "trace", false, "Display a trace of the internal calls made by the call")
+	contractCallCmd.Flags().StringVar(&contractCallBlock, "block", "latest", "Block at which to make the call (number, hash or offset from latest)")
+	contractCallCmd.Flags().BoolVar(&contractResolveProxy, "resolve-proxy", false, "Use the ABI of the implementation if the contract is a proxy")
 }
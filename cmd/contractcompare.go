@@ -0,0 +1,128 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var contractCompareBlock string
+
+// contractCompareCmd represents the contract compare command
+var contractCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the code of a contract with compiled code",
+	Long: `Compare the runtime code of a deployed contract with the runtime code output by the compiler, to confirm that the contract matches the given source.  For example:
+
+   solc --combined-json=abi,bin,bin-runtime contracts/Sample.sol > Sample.json
+   ethereal contract compare --contract=0x3c24F71e826D3762f5145f6a27d41545A7dfc8cF --json=Sample.json --name=SampleContract
+
+The metadata hash appended by the compiler is ignored, as it changes with irrelevant details such as comments and file paths; whether it matches is reported separately.  Immutable values and library addresses are filled in at deployment, so they are also ignored.
+
+In quiet mode this will return 0 if the code matches, otherwise 1.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		cli.Assert(contractJSON != "", quiet, "--json is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+		contract := parseContract("")
+		cli.Assert(contract.RuntimeBinary != "", quiet, "JSON does not contain runtime code; compile with --combined-json=bin-runtime")
+
+		ctx, cancel := localContext()
+		defer cancel()
+		blockNumber, err := parseBlockNumber(ctx, contractCompareBlock)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		code, err := c.Client().CodeAt(ctx, contractAddress, blockNumber)
+		cli.ErrCheck(err, quiet, "Failed to obtain code")
+		cli.Assert(len(code) > 0, quiet, fmt.Sprintf("%s has no code", contractStr))
+
+		comparison, err := util.CompareCode(code, contract.RuntimeBinary)
+		cli.ErrCheck(err, quiet, "Failed to compare code")
+
+		if jsonOutput() {
+			res := &contractCompareJSON{
+				Address:       contractAddress.Hex(),
+				Name:          contract.Name,
+				Match:         comparison.Match,
+				MetadataMatch: comparison.MetadataMatch,
+				Immutables:    comparison.Immutables,
+				Libraries:     comparison.Libraries,
+				Reason:        comparison.Reason,
+			}
+			if !comparison.Match {
+				res.Mismatch = &comparison.Mismatch
+			}
+			writeJSON(res)
+			if comparison.Match {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+		cli.Assert(comparison.Match, quiet, fmt.Sprintf("Code does not match: %s", comparison.Reason))
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		notes := make([]string, 0)
+		if !comparison.MetadataMatch {
+			notes = append(notes, "metadata differs")
+		}
+		if len(comparison.Immutables) > 0 {
+			notes = append(notes, fmt.Sprintf("%d immutable values", len(comparison.Immutables)))
+		}
+		if len(comparison.Libraries) > 0 {
+			notes = append(notes, fmt.Sprintf("%d library addresses", len(comparison.Libraries)))
+		}
+		if len(notes) > 0 {
+			fmt.Printf("Code matches (%s)\n", strings.Join(notes, ", "))
+		} else {
+			fmt.Println("Code matches")
+		}
+		if verbose {
+			for _, offset := range comparison.Immutables {
+				fmt.Printf("Immutable value at offset %d: 0x%x\n", offset, code[offset:offset+32])
+			}
+			for _, offset := range comparison.Libraries {
+				fmt.Printf("Library address at offset %d: %s\n", offset, formatAddress(common.BytesToAddress(code[offset:offset+20])))
+			}
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// contractCompareJSON is the JSON output for the comparison of a contract's code.
+type contractCompareJSON struct {
+	Address       string   `json:"address"`
+	Name          string   `json:"name"`
+	Match         bool     `json:"match"`
+	MetadataMatch bool     `json:"metadata_match"`
+	Immutables    []uint64 `json:"immutables"`
+	Libraries     []uint64 `json:"libraries"`
+	Mismatch      *uint64  `json:"mismatch,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractCompareCmd)
+	contractFlags(contractCompareCmd)
+	contractCompareCmd.Flags().StringVar(&contractCompareBlock, "block", "", "Block at which to obtain the code (default latest)")
+}
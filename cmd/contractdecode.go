@@ -0,0 +1,143 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/fourbyte"
+)
+
+var contractDecodeData string
+
+// contractDecodeCmd represents the contract decode command
+var contractDecodeCmd = &cobra.Command{
+	Use:   "decode",
+	Short: "Decode contract call data",
+	Long: `Decode the data for a contract call without contacting a node.  For example:
+
+   ethereal contract decode --abi="./erc20.abi" --data=0xa9059cbb0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc400000000000000000000000000000000000000000000000000000000000003e8
+
+If no ABI is supplied then the function selector is looked up in the 4byte signature directory, and the arguments decoded against the matching signature.
+
+In quiet mode this will return 0 if the data is decoded, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractDecodeData != "", quiet, "--data is required")
+		data, err := hex.DecodeString(strings.TrimPrefix(contractDecodeData, "0x"))
+		cli.ErrCheck(err, quiet, "Failed to decode data")
+		cli.Assert(len(data) >= 4, quiet, "Data is too short to contain a function selector")
+
+		var decoded *fourbyte.Decoded
+		contract := parseContract("")
+		if len(contract.Abi.Methods) > 0 {
+			decoded, err = abiDecode(&contract.Abi, data)
+			cli.ErrCheck(err, quiet, "Failed to decode data")
+		} else {
+			decoded, err = fourByteDecode(data)
+			cli.ErrCheck(err, quiet, "Failed to decode data")
+		}
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			args, err := argumentsJSON(decoded.Inputs, decoded.Values)
+			cli.ErrCheck(err, quiet, "Failed to format arguments")
+			outputJSON(&decodedCallJSON{Method: decoded.Name, Signature: decoded.Signature, Args: args})
+		}
+		outputIf(verbose, fmt.Sprintf("Signature is %s", decoded.Signature))
+		if verbose {
+			// Output each argument on its own line.
+			fmt.Println(decoded.Name)
+			for i := range decoded.Values {
+				val, err := contractValueToString(decoded.Inputs[i].Type, decoded.Values[i])
+				cli.ErrCheck(err, quiet, "Failed to format argument")
+				name := decoded.Inputs[i].Name
+				if name == "" {
+					name = fmt.Sprintf("%d", i)
+				}
+				fmt.Printf("  %s (%s):\t%s\n", name, decoded.Inputs[i].Type.String(), val)
+			}
+			os.Exit(exitSuccess)
+		}
+		res, err := fourByteDecodedToString(decoded)
+		cli.ErrCheck(err, quiet, "Failed to format data")
+		fmt.Println(res)
+	},
+}
+
+// decodedCallJSON is the JSON output for decoded call data.
+type decodedCallJSON struct {
+	Method    string          `json:"method"`
+	Signature string          `json:"signature"`
+	Args      []*argumentJSON `json:"args"`
+}
+
+// abiDecode decodes call data against the methods in an ABI.
+func abiDecode(contractAbi *abi.ABI, data []byte) (*fourbyte.Decoded, error) {
+	method, values, err := util.DecodeCallData(contractAbi, data)
+	if err != nil {
+		return nil, err
+	}
+	return &fourbyte.Decoded{
+		Signature: method.Sig,
+		Name:      method.RawName,
+		Inputs:    method.Inputs,
+		Values:    values,
+	}, nil
+}
+
+// fourByteDecode decodes call data using signatures from the 4byte directory.
+func fourByteDecode(data []byte) (*fourbyte.Decoded, error) {
+	cacheDir := ""
+	if dir, err := dataDir(); err == nil {
+		cacheDir = filepath.Join(dir, "4byte")
+	}
+	directory := fourbyte.New(viper.GetString("4byte-url"), cacheDir)
+	ctx, cancel := localContext()
+	defer cancel()
+	signatures, err := directory.Signatures(ctx, data[:4])
+	if err != nil {
+		return nil, err
+	}
+	return fourbyte.Decode(signatures, data)
+}
+
+// fourByteDecodedToString formats decoded call data.
+func fourByteDecodedToString(decoded *fourbyte.Decoded) (string, error) {
+	values := make([]string, len(decoded.Values))
+	for i := range decoded.Values {
+		val, err := contractValueToString(decoded.Inputs[i].Type, decoded.Values[i])
+		if err != nil {
+			return "", err
+		}
+		values[i] = val
+	}
+	return fmt.Sprintf("%s(%s)", decoded.Name, strings.Join(values, ",")), nil
+}
+
+func init() {
+	offlineCmds["contract:decode"] = true
+	contractCmd.AddCommand(contractDecodeCmd)
+	contractFlags(contractDecodeCmd)
+	contractDecodeCmd.Flags().StringVar(&contractDecodeData, "data", "", "Call data to decode (as a hex string)")
+}
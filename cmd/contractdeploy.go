@@ -14,19 +14,22 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethereal/v2/cli"
 	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
 	"github.com/wealdtech/ethereal/v2/util/funcparser"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
@@ -36,6 +39,10 @@ var contractDeployConstructor string
 var contractDeployData string
 var contractDeployAmount string
 var contractDeployRepeat int
+var contractDeployLibraries []string
+var contractDeployCreate2 bool
+var contractDeploySalt string
+var contractDeployFactory string
 
 // contractDeployCmd represents the contract deploy command
 var contractDeployCmd = &cobra.Command{
@@ -53,14 +60,39 @@ Usually the easiest way to deploy a contract is to use the combined JSON output
 
    ethereal contract deploy --json='./MyContract.json' --constructor='constructor(1,2,3') --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret
 
+If the contract binary references libraries they must be linked before deployment by supplying the address of each library, for example:
+
+   ethereal contract deploy --json='./MyContract.json' --library='contracts/MyLib.sol:MyLib=0x8A0ba5Dc3A2d9E5e40f7aBe0b4dC0C4fB4A8bD0E' --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret
+
+If --create2 is supplied the contract is deployed through a CREATE2 factory with the salt given by --salt, so that its address is known in advance and is the same on every chain with the factory.  The factory defaults to the deterministic deployment proxy at 0x4e59b44847b379578588920cA78FbF26c0B4956C, and can be changed with --create2-factory or create2-factory in the configuration file.  The address can be calculated with contract address --create2.
+
+If --wait is supplied then the address of the deployed contract will be printed once the transaction has been mined.
+
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		contractDeployFromAddress = accountOrDefault(contractDeployFromAddress)
 		cli.Assert(contractDeployFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(contractDeployFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", contractDeployFromAddress))
 		cli.Assert(contractDeployData != "" || contractJSON != "", quiet, "either --data or --json is required")
 
 		contract := parseContract(contractDeployData)
+		if contract.UnlinkedBinary != "" || len(contractDeployLibraries) > 0 {
+			libraries := make(map[string]common.Address)
+			for _, library := range contractDeployLibraries {
+				parts := strings.Split(library, "=")
+				cli.Assert(len(parts) == 2, quiet, fmt.Sprintf("Library %s must be in the form name=address", library))
+				libraryAddress, err := c.Resolve(parts[1])
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve library address %s", parts[1]))
+				libraries[parts[0]] = libraryAddress
+			}
+			bin := contract.UnlinkedBinary
+			if bin == "" {
+				bin = hex.EncodeToString(contract.Binary)
+			}
+			contract.Binary, err = util.LinkBinary(bin, libraries)
+			cli.ErrCheck(err, quiet, "Failed to link contract")
+		}
 		cli.Assert(len(contract.Binary) > 0, quiet, "failed to obtain contract binary data")
 		if contractDeployConstructor != "" {
 			_, constructorArgs, err := funcparser.ParseCall(c.Client(), contract, contractDeployConstructor)
@@ -84,28 +116,46 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			gasLimit = &limit
 		}
 
+		txData := &conn.TransactionData{
+			From:     fromAddress,
+			Value:    amount,
+			GasLimit: gasLimit,
+			Data:     contract.Binary,
+		}
+		var create2Address common.Address
+		if contractDeployCreate2 {
+			cli.Assert(contractDeployRepeat == 1, quiet, "--repeat cannot be used with --create2")
+			factory, err := create2Factory(contractDeployFactory)
+			cli.ErrCheck(err, quiet, "Failed to obtain CREATE2 factory address")
+			salt, err := util.ParseSalt(contractDeploySalt)
+			cli.ErrCheck(err, quiet, "Failed to parse salt")
+			create2Address = util.Create2Address(factory, salt, crypto.Keccak256(contract.Binary))
+			outputIf(verbose, fmt.Sprintf("Contract will be deployed at %s by factory %s", create2Address.Hex(), factory.Hex()))
+			if !offline {
+				code, err := c.Client().CodeAt(context.Background(), factory, nil)
+				cli.ErrCheck(err, quiet, "Failed to obtain CREATE2 factory code")
+				cli.Assert(len(code) > 0, quiet, fmt.Sprintf("No CREATE2 factory at %s", factory.Hex()))
+				code, err = c.Client().CodeAt(context.Background(), create2Address, nil)
+				cli.ErrCheck(err, quiet, "Failed to obtain code")
+				cli.Assert(len(code) == 0, quiet, fmt.Sprintf("Contract already deployed at %s", create2Address.Hex()))
+			}
+			txData.To = &factory
+			txData.Data = util.Create2FactoryData(salt, contract.Binary)
+		}
+
 		var signedTx *types.Transaction
 		for i := 0; i < contractDeployRepeat; i++ {
 			// Create and sign the transaction
-			signedTx, err = c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
-				From:     fromAddress,
-				Value:    amount,
-				GasLimit: gasLimit,
-				Data:     contract.Binary,
-			})
+			signedTx, err = c.CreateSignedTransaction(context.Background(), txData)
 			cli.ErrCheck(err, quiet, "Failed to create contract deployment transaction")
 			outputIf(verbose, fmt.Sprintf("Transaction data is %x", signedTx.Data()))
 			outputIf(verbose, fmt.Sprintf("Transaction data size is %d", len(signedTx.Data())))
 
 			if offline {
-				if !quiet {
-					buf := new(bytes.Buffer)
-					cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-					fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-				}
+				outputSignedTransaction(signedTx)
 				os.Exit(exitSuccess)
 			} else {
-				err = c.SendTransaction(context.Background(), signedTx)
+				err = sendTransaction(context.Background(), fromAddress, signedTx)
 				cli.ErrCheck(err, quiet, "Failed to send transaction")
 				logTransaction(signedTx, log.Fields{
 					"group":   "contract",
@@ -115,7 +165,26 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		}
 
 		// Wait for the last transaction if requested
-		handleSubmittedTransaction(signedTx, nil, false)
+		if !handleSubmittedTransaction(signedTx, nil, false) {
+			os.Exit(exitNotMined)
+		}
+		if dryRun || !viper.GetBool("wait") || jsonOutput() {
+			// JSON output includes the contract address, and a simulated deployment has no receipt.
+			os.Exit(successExitStatus())
+		}
+
+		receipt, err := c.Client().TransactionReceipt(context.Background(), signedTx.Hash())
+		cli.ErrCheck(err, quiet, "Failed to obtain transaction receipt")
+		cli.Assert(receipt.Status == types.ReceiptStatusSuccessful, quiet, "Contract deployment failed")
+		if contractDeployCreate2 {
+			code, err := c.Client().CodeAt(context.Background(), create2Address, nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain code")
+			cli.Assert(len(code) > 0, quiet, "Contract deployment failed")
+			outputIf(!quiet, fmt.Sprintf("Contract deployed at %s", create2Address.Hex()))
+			os.Exit(exitSuccess)
+		}
+		outputIf(!quiet, fmt.Sprintf("Contract deployed at %s", receipt.ContractAddress.Hex()))
+		os.Exit(exitSuccess)
 	},
 }
 
@@ -126,6 +195,10 @@ func init() {
 	contractDeployCmd.Flags().StringVar(&contractDeployConstructor, "constructor", "", "Constructor invocation (if required)")
 	contractDeployCmd.Flags().StringVar(&contractDeployData, "data", "", "Contract data (as a hex string)")
 	contractDeployCmd.Flags().StringVar(&contractDeployFromAddress, "from", "", "Address from which to deploy the contract")
+	contractDeployCmd.Flags().StringArrayVar(&contractDeployLibraries, "library", nil, "Library to link, in the form name=address (can be repeated)")
 	contractDeployCmd.Flags().IntVar(&contractDeployRepeat, "repeat", 1, "Number of times to repeat sending the transaction (incrementing the nonce each time)")
+	contractDeployCmd.Flags().BoolVar(&contractDeployCreate2, "create2", false, "Deploy the contract through a CREATE2 factory")
+	contractDeployCmd.Flags().StringVar(&contractDeploySalt, "salt", "", "Salt for a CREATE2 deployment (hex, up to 32 bytes)")
+	contractDeployCmd.Flags().StringVar(&contractDeployFactory, "create2-factory", "", fmt.Sprintf("Address of the CREATE2 factory (default %s)", util.DefaultCreate2Factory.Hex()))
 	addTransactionFlags(contractDeployCmd, "Passphrase for the address from which to deploy the conract")
 }
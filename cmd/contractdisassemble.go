@@ -0,0 +1,207 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+)
+
+var contractDisassembleBlock string
+
+// contractDisassembleCmd represents the contract disassemble command
+var contractDisassembleCmd = &cobra.Command{
+	Use:   "disassemble",
+	Short: "Disassemble the code of a contract",
+	Long: `Disassemble the runtime code of a contract, showing its instructions along with the function selectors it dispatches on and the metadata appended by the compiler.  For example:
+
+   ethereal contract disassemble --contract=0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48
+
+Function selectors are named from the ABI if supplied with --abi, --json or --fetch-abi, otherwise from a list of well-known signatures.  Jumps to fixed destinations are annotated with their destination, and flagged if the destination is invalid.
+
+In quiet mode this will return 0 if the contract has code, otherwise 1.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		ctx, cancel := localContext()
+		defer cancel()
+		blockNumber, err := parseBlockNumber(ctx, contractDisassembleBlock)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		code, err := c.Client().CodeAt(ctx, contractAddress, blockNumber)
+		cli.ErrCheck(err, quiet, "Failed to obtain code")
+		cli.Assert(len(code) > 0, quiet, fmt.Sprintf("%s has no code", contractStr))
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		// The metadata is data rather than code, so it is not disassembled.
+		metadata := util.ParseCodeMetadata(code)
+		body := code
+		if metadata != nil {
+			body = code[:len(code)-metadata.Length]
+		}
+		instructions := util.Disassemble(body)
+		jumpDests := util.JumpDestinations(instructions)
+		selectors := util.FunctionSelectors(instructions)
+		names := contractDisassembleNames(selectors)
+
+		if jsonOutput() {
+			res := &contractDisassembleJSON{
+				Address:      contractAddress.Hex(),
+				CodeSize:     len(code),
+				Selectors:    make([]*contractDisassembleSelectorJSON, len(selectors)),
+				Instructions: make([]*contractDisassembleInstructionJSON, len(instructions)),
+			}
+			if metadata != nil {
+				res.Metadata = &contractDisassembleMetadataJSON{
+					IPFS:         metadata.IPFS,
+					Swarm:        metadata.Swarm,
+					Compiler:     metadata.Compiler,
+					Experimental: metadata.Experimental,
+					Length:       metadata.Length,
+				}
+			}
+			for i, selector := range selectors {
+				res.Selectors[i] = &contractDisassembleSelectorJSON{
+					Selector: fmt.Sprintf("0x%x", selector),
+					Name:     names[selector],
+				}
+			}
+			for i, instruction := range instructions {
+				res.Instructions[i] = &contractDisassembleInstructionJSON{
+					PC: instruction.PC,
+					Op: instruction.Op.String(),
+				}
+				if instruction.Op.IsPush() && instruction.Op != vm.PUSH0 {
+					res.Instructions[i].Arg = fmt.Sprintf("0x%x", instruction.Arg)
+				}
+			}
+			outputJSON(res)
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Code size:\t%d bytes\n", len(code)))
+		if metadata != nil {
+			if metadata.Compiler != "" {
+				builder.WriteString(fmt.Sprintf("Compiler:\t%s\n", metadata.Compiler))
+			}
+			if metadata.IPFS != "" {
+				builder.WriteString(fmt.Sprintf("Metadata:\tipfs://%s\n", metadata.IPFS))
+			}
+			if metadata.Swarm != "" {
+				builder.WriteString(fmt.Sprintf("Metadata:\tbzz://%s\n", metadata.Swarm))
+			}
+			if metadata.Experimental {
+				builder.WriteString("Experimental:\tyes\n")
+			}
+		}
+		if len(selectors) > 0 {
+			builder.WriteString("Selectors:\n")
+			for _, selector := range selectors {
+				builder.WriteString(fmt.Sprintf("\t0x%x", selector))
+				if names[selector] != "" {
+					builder.WriteString(fmt.Sprintf("\t%s", names[selector]))
+				}
+				builder.WriteString("\n")
+			}
+		}
+		builder.WriteString("Instructions:\n")
+		for i, instruction := range instructions {
+			builder.WriteString(fmt.Sprintf("%04x\t%s", instruction.PC, instruction.String()))
+			if instruction.Truncated {
+				builder.WriteString("\t; truncated")
+			}
+			if instruction.Op.IsPush() && i+1 < len(instructions) && (instructions[i+1].Op == vm.JUMP || instructions[i+1].Op == vm.JUMPI) {
+				dest := new(big.Int).SetBytes(instruction.Arg)
+				if dest.IsUint64() && jumpDests[dest.Uint64()] {
+					builder.WriteString(fmt.Sprintf("\t; -> %04x", dest.Uint64()))
+				} else {
+					builder.WriteString("\t; invalid jump destination")
+				}
+			}
+			builder.WriteString("\n")
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// contractDisassembleNames returns the names of the functions for the given selectors, where known.
+func contractDisassembleNames(selectors [][4]byte) map[[4]byte]string {
+	res := make(map[[4]byte]string)
+	contract := parseContract("")
+	if len(contract.Abi.Methods) > 0 {
+		for _, selector := range selectors {
+			if method, err := contract.Abi.MethodById(selector[:]); err == nil {
+				res[selector] = method.Sig
+			}
+		}
+		return res
+	}
+	txdata.InitFunctionMap()
+	for _, selector := range selectors {
+		if name := txdata.FunctionName(selector[:]); name != "" {
+			res[selector] = name
+		}
+	}
+	return res
+}
+
+// contractDisassembleJSON is the JSON output for the disassembly of a contract.
+type contractDisassembleJSON struct {
+	Address      string                                `json:"address"`
+	CodeSize     int                                   `json:"code_size"`
+	Metadata     *contractDisassembleMetadataJSON      `json:"metadata,omitempty"`
+	Selectors    []*contractDisassembleSelectorJSON    `json:"selectors"`
+	Instructions []*contractDisassembleInstructionJSON `json:"instructions"`
+}
+
+// contractDisassembleMetadataJSON is the JSON output for the compiler metadata of a contract.
+type contractDisassembleMetadataJSON struct {
+	IPFS         string `json:"ipfs,omitempty"`
+	Swarm        string `json:"swarm,omitempty"`
+	Compiler     string `json:"compiler,omitempty"`
+	Experimental bool   `json:"experimental,omitempty"`
+	Length       int    `json:"length"`
+}
+
+// contractDisassembleSelectorJSON is the JSON output for a function selector.
+type contractDisassembleSelectorJSON struct {
+	Selector string `json:"selector"`
+	Name     string `json:"name,omitempty"`
+}
+
+// contractDisassembleInstructionJSON is the JSON output for an instruction.
+type contractDisassembleInstructionJSON struct {
+	PC  uint64 `json:"pc"`
+	Op  string `json:"op"`
+	Arg string `json:"arg,omitempty"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractDisassembleCmd)
+	contractFlags(contractDisassembleCmd)
+	contractDisassembleCmd.Flags().StringVar(&contractDisassembleBlock, "block", "", "Block at which to obtain the code (default latest)")
+}
@@ -0,0 +1,63 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/funcparser"
+)
+
+var contractEncodeCall string
+
+// contractEncodeCmd represents the contract encode command
+var contractEncodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Encode contract call data",
+	Long: `Encode the data for a contract call without contacting a node.  For example:
+
+   ethereal contract encode --abi="./erc20.abi" --call="transfer(0x5FfC014343cd971B7eb70732021E26C35B744cc4, 1000)"
+
+   ethereal contract encode --function="transfer(address,uint256)" --call="transfer(0x5FfC014343cd971B7eb70732021E26C35B744cc4, 1000)"
+
+In quiet mode this will return 0 if the data is encoded, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractEncodeCall != "", quiet, "--call is required")
+
+		contract := parseContract("")
+		method, methodArgs, err := funcparser.ParseCall(c.Client(), contract, contractEncodeCall)
+		cli.ErrCheck(err, quiet, "Failed to parse call")
+		data, err := contract.Abi.Pack(method.Name, methodArgs...)
+		cli.ErrCheck(err, quiet, "Failed to convert arguments")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"signature": method.Sig, "data": fmt.Sprintf("%#x", data)})
+		}
+		outputIf(verbose, fmt.Sprintf("Signature is %s", method.Sig))
+		fmt.Printf("0x%x\n", data)
+	},
+}
+
+func init() {
+	offlineCmds["contract:encode"] = true
+	contractCmd.AddCommand(contractEncodeCmd)
+	contractFlags(contractEncodeCmd)
+	contractEncodeCmd.Flags().StringVar(&contractEncodeCall, "call", "", "Contract method call to encode")
+}
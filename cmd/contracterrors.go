@@ -0,0 +1,97 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var contractErrorsData string
+
+// contractErrorsCmd represents the contract errors command
+var contractErrorsCmd = &cobra.Command{
+	Use:   "errors",
+	Short: "List or decode custom errors for a contract",
+	Long: `List the custom errors defined in a contract's ABI.  For example:
+
+   ethereal contract errors --abi="./MyContract.abi"
+
+Revert data can be decoded against the errors in the ABI, as well as the standard Error(string) and Panic(uint256) errors.  For example:
+
+   ethereal contract errors --abi="./MyContract.abi" --data=0xcf479181000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000014
+
+In quiet mode this will return 0 if the ABI contains errors or the data is decoded, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		contract := parseContract("")
+
+		if contractErrorsData != "" {
+			data, err := hex.DecodeString(strings.TrimPrefix(contractErrorsData, "0x"))
+			cli.ErrCheck(err, quiet, "Failed to decode data")
+			reason, err := util.DecodeRevert(&contract.Abi, data)
+			cli.Assert(!errors.Is(err, util.ErrUnknownRevert), quiet, "No matching error for revert data")
+			cli.ErrCheck(err, quiet, "Failed to decode revert data")
+			if jsonOutput() {
+				outputJSON(map[string]interface{}{"reason": reason})
+			}
+			outputIf(!quiet, reason)
+			os.Exit(exitSuccess)
+		}
+
+		cli.Assert(len(contract.Abi.Errors) > 0, quiet, "ABI does not define any errors")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		names := make([]string, 0, len(contract.Abi.Errors))
+		for name := range contract.Abi.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if jsonOutput() {
+			errs := make([]map[string]interface{}, 0, len(names))
+			for _, name := range names {
+				abiErr := contract.Abi.Errors[name]
+				errs = append(errs, map[string]interface{}{
+					"selector":  fmt.Sprintf("%#x", abiErr.ID.Bytes()[:4]),
+					"signature": abiErr.Sig,
+				})
+			}
+			outputJSON(errs)
+		}
+		for _, name := range names {
+			abiErr := contract.Abi.Errors[name]
+			if verbose {
+				fmt.Printf("0x%x\t%s\n", abiErr.ID.Bytes()[:4], abiErr.String())
+			} else {
+				fmt.Printf("0x%x\t%s\n", abiErr.ID.Bytes()[:4], abiErr.Sig)
+			}
+		}
+	},
+}
+
+func init() {
+	offlineCmds["contract:errors"] = true
+	contractCmd.AddCommand(contractErrorsCmd)
+	contractFlags(contractErrorsCmd)
+	contractErrorsCmd.Flags().StringVar(&contractErrorsData, "data", "", "Revert data to decode (as a hex string)")
+}
@@ -0,0 +1,183 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/funcparser"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var contractEstimateAmount string
+var contractEstimateFromAddress string
+var contractEstimateCall string
+
+// contractEstimateCmd represents the contract estimate command
+var contractEstimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate and simulate a contract method transaction",
+	Long: `Estimate the gas required for a contract method transaction and simulate its result, without sending it to the blockchain.  For example:
+
+   ethereal contract estimate --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --abi="./erc20.abi" --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --call="transfer(0x5FfC014343cd971B7eb70732021E26C35B744cc4, 10)"
+
+If the transaction would fail then the reason is printed.
+
+In quiet mode this will return 0 if the transaction would succeed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		contractEstimateFromAddress = accountOrDefault(contractEstimateFromAddress)
+		cli.Assert(contractEstimateFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(contractEstimateFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", contractEstimateFromAddress))
+
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		cli.Assert(contractEstimateCall != "", quiet, "--call is required")
+		contract := parseContract("")
+		method, methodArgs, err := funcparser.ParseCall(c.Client(), contract, contractEstimateCall)
+		cli.ErrCheck(err, quiet, "Failed to parse call")
+		data, err := contract.Abi.Pack(method.Name, methodArgs...)
+		cli.ErrCheck(err, quiet, "Failed to convert arguments")
+		outputIf(verbose, fmt.Sprintf("Data is %x", data))
+
+		amount := big.NewInt(0)
+		if contractEstimateAmount != "" {
+			amount, err = string2eth.StringToWei(contractEstimateAmount)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid amount %s", contractEstimateAmount))
+		}
+
+		gas, err := c.EstimateGas(context.Background(), &conn.TransactionData{
+			From:  fromAddress,
+			To:    &contractAddress,
+			Value: amount,
+			Data:  data,
+		})
+		if err != nil {
+			outputIf(!quiet, fmt.Sprintf("Transaction would fail: %s", util.RevertReason(&contract.Abi, err)))
+			os.Exit(exitFailure)
+		}
+
+		// Simulate the call to obtain its return values.
+		result, err := c.CallContract(context.Background(), ethereum.CallMsg{
+			From:  fromAddress,
+			To:    &contractAddress,
+			Gas:   gas,
+			Value: amount,
+			Data:  data,
+		}, nil, nil)
+		if err != nil {
+			outputIf(!quiet, fmt.Sprintf("Transaction would fail: %s", util.RevertReason(&contract.Abi, err)))
+			os.Exit(exitFailure)
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		// On layer 2 rollups the transaction also pays to post its data to layer 1.
+		l1Fee, err := estimatedL1Fee(context.Background(), &conn.TransactionData{
+			From:     fromAddress,
+			To:       &contractAddress,
+			Value:    amount,
+			GasLimit: &gas,
+			Data:     data,
+		})
+		outputIf(verbose && err != nil, fmt.Sprintf("Failed to obtain L1 data fee: %v", err))
+
+		if jsonOutput() {
+			res := &contractEstimateJSON{
+				Gas:     gas,
+				Results: []*argumentJSON{},
+			}
+			if baseFee, err := c.CurrentBaseFee(context.Background()); err == nil {
+				cost := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gas))
+				res.CostAtBaseFee = cost.String()
+				if price := etherPrice(); price != nil {
+					res.Currency = price.currency
+					res.FiatCostAtBaseFee = price.value(cost)
+				}
+			}
+			if l1Fee != nil {
+				res.Rollup = l1Fee.Rollup
+				res.L1Fee = l1Fee.Fee.String()
+				res.L1Gas = l1Fee.Gas
+			}
+			if len(method.Outputs) > 0 {
+				outputs, err := method.Outputs.Unpack(result)
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse output of %s", method.Name))
+				res.Results, err = argumentsJSON(method.Outputs, outputs)
+				cli.ErrCheck(err, quiet, "Failed to turn values in to suitable output")
+			}
+			outputJSON(res)
+		}
+
+		fmt.Printf("Gas:\t\t%d\n", gas)
+		if verbose {
+			baseFee, err := c.CurrentBaseFee(context.Background())
+			if err == nil {
+				price := etherPrice()
+				cost := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gas))
+				fmt.Printf("Cost at base fee:\t%s\n", formatWeiFiat(cost, price))
+				if l1Fee != nil && l1Fee.Gas == 0 {
+					fmt.Printf("L1 data fee:\t\t%s\n", formatWeiFiat(l1Fee.Fee, price))
+					fmt.Printf("Total cost:\t\t%s\n", formatWeiFiat(cost.Add(cost, l1Fee.Fee), price))
+				}
+			}
+		}
+		if l1Fee != nil && l1Fee.Gas > 0 {
+			fmt.Printf("L1 data gas:\t\t%d\n", l1Fee.Gas)
+		}
+		if len(method.Outputs) > 0 {
+			outputs, err := method.Outputs.Unpack(result)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse output of %s", method.Name))
+			results := make([]string, len(outputs))
+			for i := range outputs {
+				results[i], err = contractValueToString(method.Outputs[i].Type, outputs[i])
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to turn value %v in to suitable output", outputs[i]))
+			}
+			fmt.Printf("Result:\t\t%s\n", strings.Join(results, ","))
+		}
+	},
+}
+
+// contractEstimateJSON is the JSON output for a contract estimate.
+type contractEstimateJSON struct {
+	Gas               uint64          `json:"gas"`
+	CostAtBaseFee     string          `json:"cost_at_base_fee,omitempty"`
+	Currency          string          `json:"currency,omitempty"`
+	FiatCostAtBaseFee string          `json:"fiat_cost_at_base_fee,omitempty"`
+	Rollup            string          `json:"rollup,omitempty"`
+	L1Fee             string          `json:"l1_fee,omitempty"`
+	L1Gas             uint64          `json:"l1_gas,omitempty"`
+	Results           []*argumentJSON `json:"results"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractEstimateCmd)
+	contractFlags(contractEstimateCmd)
+	contractEstimateCmd.Flags().StringVar(&contractEstimateAmount, "amount", "", "Amount of Ether to send with the contract method")
+	contractEstimateCmd.Flags().StringVar(&contractEstimateFromAddress, "from", "", "Address from which to send the contract method")
+	contractEstimateCmd.Flags().StringVar(&contractEstimateCall, "call", "", "Contract method to estimate")
+}
@@ -0,0 +1,362 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var contractEventsFromBlock string
+var contractEventsToBlock string
+var contractEventsEvent string
+var contractEventsFormat string
+var contractEventsColumns string
+
+// contractEventsDefaultColumns are the columns output in CSV and NDJSON formats if none are supplied.
+const contractEventsDefaultColumns = "block,tx_hash,log_index,address,event,args"
+
+// contractEventsCmd represents the contract events command
+var contractEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Obtain historical events for a contract",
+	Long: `Obtain and decode the events emitted by a contract over a range of blocks.  For example:
+
+   ethereal contract events --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --abi="./erc20.abi" --event=Transfer --from-block=-1000
+
+Blocks can be supplied as numbers, as offsets from the latest block (e.g. -1000), or as "latest" or "earliest".  Output can be in text (the default), JSON, CSV or NDJSON (newline-delimited JSON) format.
+
+For CSV and NDJSON the columns can be selected with --columns, from block, tx_hash, log_index, address, event, args, topics and data, along with the names of individual event arguments.  If --event is supplied then args is expanded to a column for each argument of the event.  For example:
+
+   ethereal contract events --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --abi="./erc20.abi" --event=Transfer --from-block=-1000 --format=csv --columns=block,tx_hash,from,to,value
+
+In quiet mode this will return 0 if any events are found, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		contract := parseContract("")
+		cli.Assert(len(contract.Abi.Events) > 0, quiet, "ABI with events is required; supply it with --abi or --json")
+
+		fromBlock, err := parseBlockNumber(ctx, contractEventsFromBlock)
+		cli.ErrCheck(err, quiet, "Invalid from block")
+		toBlock, err := parseBlockNumber(ctx, contractEventsToBlock)
+		cli.ErrCheck(err, quiet, "Invalid to block")
+		if fromBlock == nil {
+			// Default to the latest block.
+			fromBlock = toBlock
+		}
+
+		query := ethereum.FilterQuery{
+			FromBlock: fromBlock,
+			ToBlock:   toBlock,
+			Addresses: []common.Address{contractAddress},
+		}
+		var selectedEvent *abi.Event
+		if contractEventsEvent != "" {
+			event, exists := contract.Abi.Events[contractEventsEvent]
+			cli.Assert(exists, quiet, fmt.Sprintf("Unknown event %s", contractEventsEvent))
+			query.Topics = [][]common.Hash{{event.ID}}
+			selectedEvent = &event
+		}
+
+		format := contractEventsFormat
+		if jsonOutput() && !cmd.Flags().Changed("format") {
+			format = "json"
+		}
+		var columns []string
+		switch format {
+		case "csv", "ndjson":
+			columns, err = eventColumns(&contract.Abi, selectedEvent, contractEventsColumns)
+			cli.ErrCheck(err, quiet, "Invalid columns")
+		case "text", "json":
+			cli.Assert(contractEventsColumns == "", quiet, "--columns is only used with CSV and NDJSON formats")
+		default:
+			cli.Err(quiet, fmt.Sprintf("Unknown format %s", contractEventsFormat))
+		}
+
+		logs, err := c.Client().FilterLogs(ctx, query)
+		cli.ErrCheck(err, quiet, "Failed to obtain logs")
+
+		if quiet {
+			if len(logs) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		switch format {
+		case "json":
+			res := make([]*decodedEvent, 0, len(logs))
+			for i := range logs {
+				res = append(res, decodeEvent(&contract.Abi, &logs[i]))
+			}
+			outputJSON(res)
+		case "text":
+			for i := range logs {
+				fmt.Println(decodeEvent(&contract.Abi, &logs[i]).String())
+			}
+		case "csv":
+			writer := csv.NewWriter(os.Stdout)
+			cli.ErrCheck(writer.Write(columns), quiet, "Failed to write CSV")
+			for i := range logs {
+				event := decodeEvent(&contract.Abi, &logs[i])
+				record := make([]string, len(columns))
+				for j, column := range columns {
+					record[j] = event.csvValue(column)
+				}
+				cli.ErrCheck(writer.Write(record), quiet, "Failed to write CSV")
+			}
+			writer.Flush()
+			cli.ErrCheck(writer.Error(), quiet, "Failed to write CSV")
+		case "ndjson":
+			encoder := json.NewEncoder(os.Stdout)
+			for i := range logs {
+				event := decodeEvent(&contract.Abi, &logs[i])
+				record := make(map[string]interface{}, len(columns))
+				for _, column := range columns {
+					record[column] = event.jsonValue(column)
+				}
+				cli.ErrCheck(encoder.Encode(record), quiet, "Failed to write JSON")
+			}
+		}
+	},
+}
+
+// decodedEvent is a log decoded against a contract ABI.
+type decodedEvent struct {
+	BlockNumber uint64            `json:"block_number"`
+	TxHash      string            `json:"transaction_hash"`
+	LogIndex    uint              `json:"log_index"`
+	Address     string            `json:"address"`
+	Event       string            `json:"event,omitempty"`
+	Args        []decodedEventArg `json:"args,omitempty"`
+	Topics      []string          `json:"topics,omitempty"`
+	Data        string            `json:"data,omitempty"`
+}
+
+// decodedEventArg is a single argument of a decoded event.
+type decodedEventArg struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+	Value   string `json:"value"`
+}
+
+// String provides a single-line representation of the event.
+func (e *decodedEvent) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d\t%s\t%d\t", e.BlockNumber, e.TxHash, e.LogIndex))
+	if e.Event == "" {
+		builder.WriteString(fmt.Sprintf("topics=[%s] data=%s", strings.Join(e.Topics, ","), e.Data))
+		return builder.String()
+	}
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		if arg.Name == "" {
+			args[i] = arg.Value
+		} else {
+			args[i] = fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+		}
+	}
+	builder.WriteString(fmt.Sprintf("%s(%s)", e.Event, strings.Join(args, ", ")))
+	return builder.String()
+}
+
+// eventBaseColumns are the columns available for all events.
+var eventBaseColumns = map[string]bool{
+	"block":     true,
+	"tx_hash":   true,
+	"log_index": true,
+	"address":   true,
+	"event":     true,
+	"args":      true,
+	"topics":    true,
+	"data":      true,
+}
+
+// eventColumns parses a comma-separated list of columns, which can be base columns or the
+// names of event arguments.  If an event is supplied then "args" is expanded to its arguments.
+func eventColumns(contractAbi *abi.ABI, event *abi.Event, input string) ([]string, error) {
+	if input == "" {
+		input = contractEventsDefaultColumns
+	}
+
+	argNames := make(map[string]bool)
+	for _, abiEvent := range contractAbi.Events {
+		for i, arg := range abiEvent.Inputs {
+			argNames[eventArgName(arg.Name, i)] = true
+		}
+	}
+
+	columns := make([]string, 0)
+	for _, column := range strings.Split(input, ",") {
+		column = strings.TrimSpace(column)
+		switch {
+		case column == "":
+			continue
+		case column == "args" && event != nil:
+			for i, arg := range event.Inputs {
+				columns = append(columns, eventArgName(arg.Name, i))
+			}
+		case eventBaseColumns[column], argNames[column]:
+			columns = append(columns, column)
+		default:
+			return nil, fmt.Errorf("unknown column %s", column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no columns supplied")
+	}
+	return columns, nil
+}
+
+// eventArgName returns the name of an event argument, or argN for unnamed arguments.
+func eventArgName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("arg%d", index)
+	}
+	return name
+}
+
+// arg returns the value of the named argument, or an empty string if the event does not have it.
+func (e *decodedEvent) arg(name string) string {
+	for i, arg := range e.Args {
+		if eventArgName(arg.Name, i) == name {
+			return arg.Value
+		}
+	}
+	return ""
+}
+
+// csvValue returns the value of the given column for CSV output.
+func (e *decodedEvent) csvValue(column string) string {
+	switch column {
+	case "block":
+		return fmt.Sprintf("%d", e.BlockNumber)
+	case "tx_hash":
+		return e.TxHash
+	case "log_index":
+		return fmt.Sprintf("%d", e.LogIndex)
+	case "address":
+		return e.Address
+	case "event":
+		return e.Event
+	case "args":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprintf("%s=%s", eventArgName(arg.Name, i), arg.Value)
+		}
+		return strings.Join(args, " ")
+	case "topics":
+		return strings.Join(e.Topics, " ")
+	case "data":
+		return e.Data
+	default:
+		return e.arg(column)
+	}
+}
+
+// jsonValue returns the value of the given column for NDJSON output.
+func (e *decodedEvent) jsonValue(column string) interface{} {
+	switch column {
+	case "block":
+		return e.BlockNumber
+	case "log_index":
+		return e.LogIndex
+	case "args":
+		args := make(map[string]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[eventArgName(arg.Name, i)] = arg.Value
+		}
+		return args
+	case "topics":
+		return e.Topics
+	default:
+		return e.csvValue(column)
+	}
+}
+
+// decodeEvent decodes a log against an ABI.
+// If the log cannot be decoded its raw topics and data are retained.
+func decodeEvent(contractAbi *abi.ABI, log *types.Log) *decodedEvent {
+	res := &decodedEvent{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash.Hex(),
+		LogIndex:    log.Index,
+		Address:     log.Address.Hex(),
+	}
+
+	event, values, err := util.DecodeEvent(contractAbi, log)
+	if err != nil {
+		outputIf(debug, fmt.Sprintf("Failed to decode log %d of transaction %s: %v", log.Index, log.TxHash.Hex(), err))
+		res.Topics = make([]string, len(log.Topics))
+		for i := range log.Topics {
+			res.Topics[i] = log.Topics[i].Hex()
+		}
+		res.Data = fmt.Sprintf("%#x", log.Data)
+		return res
+	}
+
+	res.Event = event.Name
+	res.Args = make([]decodedEventArg, len(event.Inputs))
+	for i, input := range event.Inputs {
+		res.Args[i] = decodedEventArg{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+			Value:   eventValueToString(input, values[i]),
+		}
+	}
+	return res
+}
+
+// eventValueToString turns an event value in to a string, allowing for indexed dynamic values
+// that are only available as hashes.
+func eventValueToString(input abi.Argument, val interface{}) string {
+	if hash, isHash := val.(common.Hash); isHash && input.Type.T != abi.HashTy && input.Type.T != abi.FixedBytesTy {
+		return hash.Hex()
+	}
+	res, err := contractValueToString(input.Type, val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return res
+}
+
+func init() {
+	contractCmd.AddCommand(contractEventsCmd)
+	contractFlags(contractEventsCmd)
+	contractEventsCmd.Flags().StringVar(&contractEventsFromBlock, "from-block", "", "Block from which to obtain events (defaults to the to block)")
+	contractEventsCmd.Flags().StringVar(&contractEventsToBlock, "to-block", "latest", "Block to which to obtain events")
+	contractEventsCmd.Flags().StringVar(&contractEventsEvent, "event", "", "Name of the event to obtain (defaults to all events)")
+	contractEventsCmd.Flags().StringVar(&contractEventsFormat, "format", "text", "Output format (text, json, csv or ndjson)")
+	contractEventsCmd.Flags().StringVar(&contractEventsColumns, "columns", "", fmt.Sprintf("Comma-separated columns for CSV and NDJSON output (default %s)", contractEventsDefaultColumns))
+}
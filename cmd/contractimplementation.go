@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// contractImplementationCmd represents the contract implementation command
+var contractImplementationCmd = &cobra.Command{
+	Use:   "implementation",
+	Short: "Obtain the implementation of a proxy contract",
+	Long: `Obtain the implementation and admin addresses of a proxy contract.  For example:
+
+   ethereal contract implementation --contract=0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48
+
+EIP-1967 (including beacon proxies), EIP-1822 and ZeppelinOS proxies are recognised.
+
+In quiet mode this will return 0 if the contract is a recognised proxy, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		ctx, cancel := localContext()
+		defer cancel()
+		proxy, err := util.ProxyDetails(ctx, c.Client(), contractAddress, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain proxy details")
+		cli.Assert(proxy != nil, quiet, fmt.Sprintf("%s is not a recognised proxy", contractStr))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			res := map[string]interface{}{
+				"type":           proxy.Type,
+				"implementation": proxy.Implementation.Hex(),
+			}
+			if proxy.Beacon != nil {
+				res["beacon"] = proxy.Beacon.Hex()
+			}
+			if proxy.Admin != nil {
+				res["admin"] = proxy.Admin.Hex()
+			}
+			outputJSON(res)
+		}
+
+		outputIf(verbose, fmt.Sprintf("Proxy type:\t%s", proxy.Type))
+		fmt.Printf("Implementation:\t%s\n", formatAddress(proxy.Implementation))
+		if proxy.Beacon != nil {
+			fmt.Printf("Beacon:\t\t%s\n", formatAddress(*proxy.Beacon))
+		}
+		if proxy.Admin != nil {
+			fmt.Printf("Admin:\t\t%s\n", formatAddress(*proxy.Admin))
+		}
+	},
+}
+
+func init() {
+	contractCmd.AddCommand(contractImplementationCmd)
+	contractFlags(contractImplementationCmd)
+}
@@ -0,0 +1,214 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/funcparser"
+	"github.com/wealdtech/ethereal/v2/util/multicall"
+)
+
+var contractMulticallCalls []string
+var contractMulticallFile string
+var contractMulticallAddress string
+var contractMulticallBlock string
+var contractMulticallRequireSuccess bool
+
+// contractMulticallEntry is an entry in a multicall file.
+type contractMulticallEntry struct {
+	Contract string `json:"contract"`
+	ABI      string `json:"abi"`
+	Function string `json:"function"`
+	Call     string `json:"call"`
+}
+
+// contractMulticallCmd represents the contract multicall command
+var contractMulticallCmd = &cobra.Command{
+	Use:   "multicall",
+	Short: "Make multiple contract calls in a single request",
+	Long: `Make multiple read-only contract calls in a single request using the Multicall3 contract.  For example:
+
+   ethereal contract multicall --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --abi="./erc20.abi" --call="name()" --call="symbol()" --call="balanceOf(0x5FfC014343cd971B7eb70732021E26C35B744cc4)"
+
+Calls to multiple contracts can be supplied in a JSON file containing an array of calls, each of which has a contract, call and either abi or function, for example:
+
+   [{"contract":"0xd26114cd6EE289AccF82350c8d8487fedB8A0C07","abi":"./erc20.abi","call":"totalSupply()"},{"contract":"wealdtech.eth","function":"owner() returns (address)","call":"owner()"}]
+
+and is passed with --calls.  The result of each call is output on its own line, in the same order as the calls were supplied.  By default a failed call outputs its error and does not stop the remaining calls; --require-success makes the entire request fail if any call fails.
+
+In quiet mode this will return 0 if all calls succeed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(len(contractMulticallCalls) > 0 || contractMulticallFile != "", quiet, "--call or --calls is required")
+
+		contracts := make([]*util.Contract, 0)
+		methods := make([]*abi.Method, 0)
+		calls := make([]multicall.Call, 0)
+
+		if len(contractMulticallCalls) > 0 {
+			cli.Assert(contractStr != "", quiet, "--contract is required with --call")
+			contractAddress, err := c.Resolve(contractStr)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+			contract := parseContract("")
+			for _, call := range contractMulticallCalls {
+				method, data := contractMulticallData(contract, call)
+				contracts = append(contracts, contract)
+				methods = append(methods, method)
+				calls = append(calls, multicall.Call{Target: contractAddress, AllowFailure: !contractMulticallRequireSuccess, CallData: data})
+			}
+		}
+
+		if contractMulticallFile != "" {
+			data, err := ioutil.ReadFile(contractMulticallFile)
+			cli.ErrCheck(err, quiet, "Failed to read calls")
+			var entries []*contractMulticallEntry
+			cli.ErrCheck(json.Unmarshal(data, &entries), quiet, "Failed to parse calls")
+			for i, entry := range entries {
+				cli.Assert(entry.Contract != "", quiet, fmt.Sprintf("Call %d: contract is required", i))
+				contractAddress, err := c.Resolve(entry.Contract)
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Call %d: failed to resolve contract address %s", i, entry.Contract))
+				contract := &util.Contract{}
+				switch {
+				case entry.ABI != "":
+					contract.Abi, err = contractParseAbi(entry.ABI)
+					cli.ErrCheck(err, quiet, fmt.Sprintf("Call %d: failed to parse ABI", i))
+				case entry.Function != "":
+					contractAbi, err := contractParseFunction(entry.Function)
+					cli.ErrCheck(err, quiet, fmt.Sprintf("Call %d: failed to parse function", i))
+					contract.Abi = *contractAbi
+				default:
+					cli.Err(quiet, fmt.Sprintf("Call %d: abi or function is required", i))
+				}
+				method, data := contractMulticallData(contract, entry.Call)
+				contracts = append(contracts, contract)
+				methods = append(methods, method)
+				calls = append(calls, multicall.Call{Target: contractAddress, AllowFailure: !contractMulticallRequireSuccess, CallData: data})
+			}
+		}
+
+		multicallAddress := multicall.Address
+		if contractMulticallAddress != "" {
+			var err error
+			multicallAddress, err = c.Resolve(contractMulticallAddress)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve multicall address %s", contractMulticallAddress))
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+		blockNumber, err := parseBlockNumber(ctx, contractMulticallBlock)
+		cli.ErrCheck(err, quiet, "Invalid block")
+		results, err := multicall.Aggregate(ctx, c.Client(), multicallAddress, calls, blockNumber)
+		revertCheck(err, nil, "Multicall failed")
+
+		success := true
+		jsonResults := make([]*contractMulticallJSON, 0, len(results))
+		for i, result := range results {
+			if !result.Success {
+				success = false
+				reason, err := util.DecodeRevert(&contracts[i].Abi, result.ReturnData)
+				if err != nil {
+					reason = fmt.Sprintf("0x%x", result.ReturnData)
+				}
+				if jsonOutput() {
+					jsonResults = append(jsonResults, &contractMulticallJSON{Method: methods[i].Name, Error: reason})
+				} else {
+					outputIf(!quiet, fmt.Sprintf("Error: %s", reason))
+				}
+				continue
+			}
+			if quiet {
+				continue
+			}
+			if len(methods[i].Outputs) == 0 {
+				if jsonOutput() {
+					jsonResults = append(jsonResults, &contractMulticallJSON{Method: methods[i].Name, Success: true, Results: []*argumentJSON{}})
+				} else {
+					fmt.Println()
+				}
+				continue
+			}
+			outputs, err := methods[i].Outputs.Unpack(result.ReturnData)
+			if err != nil {
+				if jsonOutput() {
+					jsonResults = append(jsonResults, &contractMulticallJSON{Method: methods[i].Name, Error: fmt.Sprintf("failed to parse output: %v", err)})
+				} else {
+					fmt.Printf("Error: failed to parse output of %s: %v\n", methods[i].Name, err)
+				}
+				success = false
+				continue
+			}
+			if jsonOutput() {
+				args, err := argumentsJSON(methods[i].Outputs, outputs)
+				if err != nil {
+					jsonResults = append(jsonResults, &contractMulticallJSON{Method: methods[i].Name, Error: fmt.Sprintf("failed to format output: %v", err)})
+					success = false
+				} else {
+					jsonResults = append(jsonResults, &contractMulticallJSON{Method: methods[i].Name, Success: true, Results: args})
+				}
+				continue
+			}
+			values := make([]string, len(outputs))
+			for j := range outputs {
+				values[j], err = contractValueToString(methods[i].Outputs[j].Type, outputs[j])
+				if err != nil {
+					values[j] = fmt.Sprintf("%v", outputs[j])
+				}
+			}
+			fmt.Println(strings.Join(values, ","))
+		}
+
+		if jsonOutput() {
+			writeJSON(jsonResults)
+		}
+		if !success {
+			os.Exit(exitFailure)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// contractMulticallData obtains the method and call data for a call.
+func contractMulticallData(contract *util.Contract, call string) (*abi.Method, []byte) {
+	method, methodArgs, err := funcparser.ParseCall(c.Client(), contract, call)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse call %s", call))
+	data, err := contract.Abi.Pack(method.Name, methodArgs...)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to convert arguments for %s", call))
+	return method, data
+}
+
+// contractMulticallJSON is the JSON output for a single call of a multicall.
+type contractMulticallJSON struct {
+	Method  string          `json:"method"`
+	Success bool            `json:"success"`
+	Results []*argumentJSON `json:"results,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractMulticallCmd)
+	contractFlags(contractMulticallCmd)
+	contractMulticallCmd.Flags().StringArrayVar(&contractMulticallCalls, "call", nil, "Contract method to call (can be repeated)")
+	contractMulticallCmd.Flags().StringVar(&contractMulticallFile, "calls", "", "Path to a JSON file containing the calls to make")
+	contractMulticallCmd.Flags().StringVar(&contractMulticallAddress, "multicall", "", fmt.Sprintf("Address of the Multicall3 contract (default %s)", multicall.Address.Hex()))
+	contractMulticallCmd.Flags().StringVar(&contractMulticallBlock, "block", "latest", "Block at which to make the calls (number, hash or offset from latest)")
+	contractMulticallCmd.Flags().BoolVar(&contractMulticallRequireSuccess, "require-success", false, "Fail the entire request if any call fails")
+}
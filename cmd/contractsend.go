@@ -14,9 +14,7 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
@@ -33,6 +31,7 @@ import (
 var contractSendAmount string
 var contractSendFromAddress string
 var contractSendCall string
+var contractSendAccessList string
 
 // contractSendCmd represents the contract call command
 var contractSendCmd = &cobra.Command{
@@ -47,6 +46,7 @@ var contractSendCmd = &cobra.Command{
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Aliases: []string{"transaction", "transmit"},
 	Run: func(cmd *cobra.Command, args []string) {
+		contractSendFromAddress = accountOrDefault(contractSendFromAddress)
 		cli.Assert(contractSendFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(contractSendFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", contractSendFromAddress))
@@ -78,25 +78,31 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			gasLimit = &limit
 		}
 
-		// Create and sign the transaction
-		signedTx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
+		accessList, err := parseAccessList(contractSendAccessList, &conn.TransactionData{
 			From:     fromAddress,
 			To:       &contractAddress,
 			Value:    amount,
 			GasLimit: gasLimit,
 			Data:     data,
 		})
-		cli.ErrCheck(err, quiet, "Failed to create contract method transaction")
+		revertCheck(err, &contract.Abi, "Failed to obtain access list")
+
+		// Create and sign the transaction
+		signedTx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
+			From:       fromAddress,
+			To:         &contractAddress,
+			Value:      amount,
+			GasLimit:   gasLimit,
+			Data:       data,
+			AccessList: accessList,
+		})
+		revertCheck(err, &contract.Abi, "Failed to create contract method transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		} else {
-			err = c.SendTransaction(context.Background(), signedTx)
+			err = sendTransaction(context.Background(), fromAddress, signedTx)
 			cli.ErrCheck(err, quiet, "Failed to send transaction")
 			handleSubmittedTransaction(signedTx, log.Fields{
 				"group":   "contract",
@@ -112,5 +118,6 @@ func init() {
 	contractSendCmd.Flags().StringVar(&contractSendAmount, "amount", "", "Amount of Ether to send with the contract method")
 	contractSendCmd.Flags().StringVar(&contractSendFromAddress, "from", "", "Address from which to call the contract function")
 	contractSendCmd.Flags().StringVar(&contractSendCall, "call", "", "Contract function to call")
+	accessListFlag(contractSendCmd, &contractSendAccessList)
 	addTransactionFlags(contractSendCmd, "Passphrase for the address from which to send the contract transaction")
 }
@@ -0,0 +1,211 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/contractsource"
+)
+
+var contractSourceOutputDir string
+var contractSourceProvider string
+
+// contractSourceCmd represents the contract source command
+var contractSourceCmd = &cobra.Command{
+	Use:   "source [address]",
+	Short: "Obtain the verified source of a contract",
+	Long: `Obtain the verified source of a contract, along with its compiler settings and the transaction that created it.  For example:
+
+   ethereal contract source 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 --output-dir=./usdc
+
+The address of the contract can also be supplied with --contract.  The source files are written to the directory supplied with --output-dir, preserving their paths, along with the ABI of the contract; without it the files are listed.
+
+The provider of the source is selected with --source, or the source-provider configuration value, and is one of etherscan, sourcify or blockscout.  If not selected, Etherscan is used if an API key is configured with etherscan-api-key or ETHERSCAN_API_KEY, or an Etherscan-compatible endpoint with etherscan-url, and Sourcify otherwise.  Blockscout requires its URL, supplied through the blockscout-url configuration value, and the Sourcify server can be changed with the sourcify-url configuration value.
+
+In quiet mode this will return 0 if the source of the contract is verified, otherwise 1.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			cli.Assert(contractStr == "", quiet, "Cannot supply both an address and --contract")
+			contractStr = args[0]
+		}
+		cli.Assert(contractStr != "", quiet, "address or --contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		providerName := contractSourceProvider
+		if providerName == "" {
+			providerName = viper.GetString("source-provider")
+		}
+		if providerName == "" {
+			providerName = "sourcify"
+			if etherscanClient() != nil {
+				providerName = "etherscan"
+			}
+		}
+		key := viper.GetString("etherscan-api-key")
+		if key == "" {
+			key = os.Getenv("ETHERSCAN_API_KEY")
+		}
+		provider, err := contractsource.New(providerName, key, serviceURL(providerName))
+		cli.ErrCheck(err, quiet, "Failed to create source provider")
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		outputIf(verbose, fmt.Sprintf("Fetching source for %s from %s", contractAddress.Hex(), providerName))
+		contract, err := provider.Contract(ctx, c.ChainID(), contractAddress)
+		if errors.Is(err, contractsource.ErrNotFound) {
+			cli.Err(quiet, fmt.Sprintf("Source of %s is not verified", contractStr))
+		}
+		cli.ErrCheck(err, quiet, "Failed to obtain contract source")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if contractSourceOutputDir != "" {
+			cli.ErrCheck(writeContractSource(contractSourceOutputDir, contract), quiet, "Failed to write contract source")
+		}
+
+		paths := make([]string, 0, len(contract.Files))
+		for path := range contract.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		if jsonOutput() {
+			res := &contractSourceJSON{
+				Address:         contractAddress.Hex(),
+				Name:            contract.Name,
+				CompilerVersion: contract.CompilerVersion,
+				Optimized:       contract.Optimized,
+				Runs:            contract.Runs,
+				EVMVersion:      contract.EVMVersion,
+				License:         contract.License,
+				Match:           contract.Match,
+				ABI:             contract.ABI,
+				Files:           contract.Files,
+			}
+			if len(contract.ConstructorArguments) > 0 {
+				res.ConstructorArguments = fmt.Sprintf("0x%s", hex.EncodeToString(contract.ConstructorArguments))
+			}
+			if contract.Implementation != nil {
+				res.Implementation = contract.Implementation.Hex()
+			}
+			if contract.Creator != nil {
+				res.Creator = contract.Creator.Hex()
+			}
+			if contract.CreationTransaction != nil {
+				res.CreationTransaction = contract.CreationTransaction.Hex()
+			}
+			outputJSON(res)
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Name:\t\t\t%s\n", contract.Name))
+		builder.WriteString(fmt.Sprintf("Compiler:\t\t%s\n", contract.CompilerVersion))
+		if contract.Optimized {
+			builder.WriteString(fmt.Sprintf("Optimization:\t\tyes (%d runs)\n", contract.Runs))
+		} else {
+			builder.WriteString("Optimization:\t\tno\n")
+		}
+		if contract.EVMVersion != "" {
+			builder.WriteString(fmt.Sprintf("EVM version:\t\t%s\n", contract.EVMVersion))
+		}
+		if contract.License != "" {
+			builder.WriteString(fmt.Sprintf("License:\t\t%s\n", contract.License))
+		}
+		if contract.Match != "" {
+			builder.WriteString(fmt.Sprintf("Match:\t\t\t%s\n", contract.Match))
+		}
+		if contract.Implementation != nil {
+			builder.WriteString(fmt.Sprintf("Implementation:\t\t%s\n", formatAddress(*contract.Implementation)))
+		}
+		if contract.Creator != nil {
+			builder.WriteString(fmt.Sprintf("Creator:\t\t%s\n", formatAddress(*contract.Creator)))
+		}
+		if contract.CreationTransaction != nil {
+			builder.WriteString(fmt.Sprintf("Creation transaction:\t%s\n", contract.CreationTransaction.Hex()))
+		}
+		builder.WriteString("Files:\n")
+		for _, path := range paths {
+			builder.WriteString(fmt.Sprintf("\t%s\n", path))
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// writeContractSource writes the source files and ABI of the contract to the given directory.
+func writeContractSource(dir string, contract *contractsource.Contract) error {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	for path, content := range contract.Files {
+		target := filepath.Join(base, filepath.FromSlash(path))
+		// Paths are supplied by the explorer, so ensure they cannot escape the output directory.
+		if !strings.HasPrefix(target, base+string(filepath.Separator)) {
+			return fmt.Errorf("invalid source path %s", path)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(target, []byte(content), 0600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", path))
+		}
+	}
+	if contract.ABI != "" && contract.Name != "" {
+		if err := ioutil.WriteFile(filepath.Join(base, fmt.Sprintf("%s.abi", contract.Name)), []byte(contract.ABI), 0600); err != nil {
+			return errors.Wrap(err, "failed to write ABI")
+		}
+	}
+	return nil
+}
+
+// contractSourceJSON is the JSON output for the source of a contract.
+type contractSourceJSON struct {
+	Address              string            `json:"address"`
+	Name                 string            `json:"name"`
+	CompilerVersion      string            `json:"compiler_version"`
+	Optimized            bool              `json:"optimized"`
+	Runs                 uint64            `json:"runs,omitempty"`
+	EVMVersion           string            `json:"evm_version,omitempty"`
+	License              string            `json:"license,omitempty"`
+	ConstructorArguments string            `json:"constructor_arguments,omitempty"`
+	Implementation       string            `json:"implementation,omitempty"`
+	Creator              string            `json:"creator,omitempty"`
+	CreationTransaction  string            `json:"creation_transaction,omitempty"`
+	Match                string            `json:"match,omitempty"`
+	ABI                  string            `json:"abi"`
+	Files                map[string]string `json:"files"`
+}
+
+func init() {
+	contractCmd.AddCommand(contractSourceCmd)
+	contractFlags(contractSourceCmd)
+	contractSourceCmd.Flags().StringVar(&contractSourceOutputDir, "output-dir", "", "Directory to which to write the source files")
+	contractSourceCmd.Flags().StringVar(&contractSourceProvider, "source", "", "Provider from which to fetch the source (etherscan/sourcify/blockscout) (default etherscan if configured, otherwise sourcify)")
+}
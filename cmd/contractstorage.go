@@ -19,11 +19,19 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var contractStorageKey string
+var contractStorageSlot string
+var contractStorageMappingKeys []string
+var contractStorageKeyTypes []string
+var contractStorageIndex string
+var contractStorageElementSize uint64
+var contractStorageOffset uint64
 
 // contractStorageCmd represents the contract storage command
 var contractStorageCmd = &cobra.Command{
@@ -33,14 +41,50 @@ var contractStorageCmd = &cobra.Command{
 
    ethereal contract storage --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --key=0x01
 
+Storage for values held in mappings and dynamic arrays can be obtained by supplying the slot of the variable along with the mapping key(s) or array index.  For example, to obtain the value for an address in a mapping(address=>uint256) declared at slot 1:
+
+   ethereal contract storage --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --slot=1 --mapping-key=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+Nested mappings take multiple --mapping-key flags in order.  Mapping keys have their type inferred from their value, or it can be supplied explicitly in the form type:value, for example --mapping-key=bytes4:0x01020304, or with --key-type, for example --key-type=string.  --key-type can be supplied once for all mapping keys or once for each mapping key, in the same order.  An element in a dynamic array is obtained with --index, with --element-size for elements that occupy more than one slot, and --offset selects a later slot within a struct.
+
 In quiet mode this will return 0 if the storage contains a non-zero value, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(contractStr != "", quiet, "--contract is required")
 		contractAddress, err := c.Resolve(contractStr)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
 
-		cli.Assert(contractStorageKey != "", quiet, "--key is required")
-		hash := common.HexToHash(strings.TrimPrefix(contractStorageKey, "0x"))
+		cli.Assert(contractStorageKey != "" || contractStorageSlot != "", quiet, "--key or --slot is required")
+		cli.Assert(contractStorageKey == "" || contractStorageSlot == "", quiet, "only one of --key and --slot can be supplied")
+		var hash common.Hash
+		if contractStorageKey != "" {
+			hash = common.HexToHash(strings.TrimPrefix(contractStorageKey, "0x"))
+		} else {
+			hash, err = util.StorageSlot(contractStorageSlot)
+			cli.ErrCheck(err, quiet, "Invalid slot")
+		}
+		cli.Assert(len(contractStorageKeyTypes) <= 1 || len(contractStorageKeyTypes) == len(contractStorageMappingKeys), quiet, "--key-type must be supplied once, or once for each --mapping-key")
+		for i, mappingKey := range contractStorageMappingKeys {
+			keyType := ""
+			switch len(contractStorageKeyTypes) {
+			case 0:
+			case 1:
+				keyType = contractStorageKeyTypes[0]
+			default:
+				keyType = contractStorageKeyTypes[i]
+			}
+			key, err := util.MappingKey(mappingKey, keyType)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid mapping key %s", mappingKey))
+			hash = util.MappingSlot(hash, key)
+		}
+		if contractStorageIndex != "" {
+			index, success := math.ParseBig256(contractStorageIndex)
+			cli.Assert(success, quiet, fmt.Sprintf("Invalid index %s", contractStorageIndex))
+			cli.Assert(contractStorageElementSize > 0, quiet, "--element-size must be at least 1")
+			hash = util.ArraySlot(hash, index, contractStorageElementSize)
+		}
+		hash = util.OffsetSlot(hash, contractStorageOffset)
+		outputIf(verbose, fmt.Sprintf("Storage key is %s", hash.Hex()))
+
 		ctx, cancel := localContext()
 		defer cancel()
 		value, err := c.Client().StorageAt(ctx, contractAddress, hash, nil)
@@ -55,6 +99,10 @@ In quiet mode this will return 0 if the storage contains a non-zero value, other
 			os.Exit(exitFailure)
 		}
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"key": hash.Hex(), "value": fmt.Sprintf("%#x", value)})
+		}
+
 		// Output the result
 		fmt.Printf("0x%x\n", value)
 	},
@@ -64,4 +112,10 @@ func init() {
 	contractCmd.AddCommand(contractStorageCmd)
 	contractFlags(contractStorageCmd)
 	contractStorageCmd.Flags().StringVar(&contractStorageKey, "key", "", "Storage key")
+	contractStorageCmd.Flags().StringVar(&contractStorageSlot, "slot", "", "Storage slot of the variable (decimal or hex)")
+	contractStorageCmd.Flags().StringArrayVar(&contractStorageMappingKeys, "mapping-key", nil, "Key for a mapping, optionally in the form type:value (can be repeated for nested mappings)")
+	contractStorageCmd.Flags().StringArrayVar(&contractStorageKeyTypes, "key-type", nil, "Type of the mapping key, for example address, uint, int, bytes32 or string (once for all mapping keys, or once for each)")
+	contractStorageCmd.Flags().StringVar(&contractStorageIndex, "index", "", "Index of an element in a dynamic array")
+	contractStorageCmd.Flags().Uint64Var(&contractStorageElementSize, "element-size", 1, "Number of slots occupied by each element of a dynamic array")
+	contractStorageCmd.Flags().Uint64Var(&contractStorageOffset, "offset", 0, "Number of slots to offset from the calculated slot (e.g. for struct members)")
 }
@@ -0,0 +1,159 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/verifier"
+)
+
+// contractVerifyPollInterval is the interval between checks of the status of a verification.
+var contractVerifyPollInterval = 5 * time.Second
+
+var contractVerifyContract string
+var contractVerifyName string
+var contractVerifyInput string
+var contractVerifyCompilerVersion string
+var contractVerifyConstructorArgs string
+var contractVerifier string
+
+// contractVerifyCmd represents the contract verify command
+var contractVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Submit the source of a deployed contract for verification",
+	Long: `Submit the source of a deployed contract for verification, as Solidity standard JSON input along with the compiler version.  For example:
+
+   ethereal contract verify --contract=0x3c24F71e826D3762f5145f6a27d41545A7dfc8cF --input=./input.json --name=contracts/Sample.sol:SampleContract --compiler-version=v0.8.13+commit.abaa5c0e --wait
+
+The name of the contract can be left unqualified if the input contains a single source.  Etherscan and Blockscout require the ABI-encoded constructor arguments, if any, supplied with --constructor-args; Sourcify obtains them from the creation transaction.
+
+The service is selected with --verifier, or the verifier configuration value, and is one of etherscan, sourcify (the default) or blockscout.  Etherscan requires an API key, supplied through the etherscan-api-key configuration value or the ETHERSCAN_API_KEY environment variable, and Blockscout requires its URL, supplied through the blockscout-url configuration value, and the Sourcify server can be changed with the sourcify-url configuration value.
+
+If --wait is supplied then the status of the verification is polled until it completes, for at most the time given by --limit.
+
+This will return an exit status of 0 if the verification is successfully submitted (and succeeds if --wait is supplied), 1 if the verification is not submitted or fails, and 2 if the verification does not complete within the supplied time limit.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractVerifyContract != "", quiet, "--contract is required")
+		cli.Assert(contractVerifyName != "", quiet, "--name is required")
+		cli.Assert(contractVerifyInput != "", quiet, "--input is required")
+		cli.Assert(contractVerifyCompilerVersion != "", quiet, "--compiler-version is required")
+		address, err := c.Resolve(contractVerifyContract)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractVerifyContract))
+
+		input, err := contractVerifyParseInput(contractVerifyInput)
+		cli.ErrCheck(err, quiet, "Failed to parse standard JSON input")
+		name, err := verifier.QualifiedName(input, contractVerifyName)
+		cli.ErrCheck(err, quiet, "Invalid contract name")
+		var constructorArgs []byte
+		if contractVerifyConstructorArgs != "" {
+			constructorArgs, err = hexutil.Decode(contractVerifyConstructorArgs)
+			cli.ErrCheck(err, quiet, "Invalid constructor arguments")
+		}
+
+		verifierName := contractVerifier
+		if verifierName == "" {
+			verifierName = viper.GetString("verifier")
+		}
+		if verifierName == "" {
+			verifierName = "sourcify"
+		}
+		key := viper.GetString("etherscan-api-key")
+		if key == "" {
+			key = os.Getenv("ETHERSCAN_API_KEY")
+		}
+		v, err := verifier.New(verifierName, key, serviceURL(verifierName))
+		cli.ErrCheck(err, quiet, "Failed to create verifier")
+
+		ctx, cancel := localContext()
+		defer cancel()
+		outputIf(verbose, fmt.Sprintf("Submitting %s at %s to %s", name, address.Hex(), verifierName))
+		id, err := v.Submit(ctx, c.ChainID(), &verifier.Request{
+			Address:              address,
+			Name:                 name,
+			CompilerVersion:      contractVerifyCompilerVersion,
+			Input:                input,
+			ConstructorArguments: constructorArgs,
+		})
+		if errors.Is(err, verifier.ErrAlreadyVerified) {
+			outputIf(!quiet, fmt.Sprintf("%s is already verified", address.Hex()))
+			os.Exit(exitSuccess)
+		}
+		cli.ErrCheck(err, quiet, "Failed to submit verification")
+
+		if !viper.GetBool("wait") {
+			outputIf(!quiet, fmt.Sprintf("Verification submitted with ID %s", id))
+			os.Exit(exitSuccess)
+		}
+		outputIf(verbose, fmt.Sprintf("Verification submitted with ID %s", id))
+
+		var waitCtx context.Context
+		var waitCancel context.CancelFunc
+		if limit := viper.GetDuration("limit"); limit > 0 {
+			waitCtx, waitCancel = context.WithTimeout(context.Background(), limit)
+		} else {
+			waitCtx, waitCancel = context.WithCancel(context.Background())
+		}
+		defer waitCancel()
+		status, err := verifier.Wait(waitCtx, v, c.ChainID(), id, contractVerifyPollInterval)
+		if errors.Is(err, context.DeadlineExceeded) {
+			outputIf(!quiet, fmt.Sprintf("Verification %s not completed within the time limit", id))
+			os.Exit(exitNotMined)
+		}
+		cli.ErrCheck(err, quiet, "Failed to obtain verification status")
+		cli.Assert(status.Verified, quiet, fmt.Sprintf("Verification failed: %s", status.Message))
+		outputIf(!quiet, fmt.Sprintf("Contract verified (%s)", status.Message))
+		os.Exit(exitSuccess)
+	},
+}
+
+// contractVerifyParseInput parses the standard JSON input, supplied directly or as a path.
+func contractVerifyParseInput(input string) (json.RawMessage, error) {
+	data := []byte(input)
+	if !strings.HasPrefix(strings.TrimSpace(input), "{") {
+		var err error
+		data, err = ioutil.ReadFile(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("invalid JSON")
+	}
+	return json.RawMessage(data), nil
+}
+
+func init() {
+	contractCmd.AddCommand(contractVerifyCmd)
+	contractVerifyCmd.Flags().StringVar(&contractVerifyContract, "contract", "", "address of the contract")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyName, "name", "", "Name of the contract, in the form path:Name")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyInput, "input", "", "Solidity standard JSON input, or path to the input, used to compile the contract")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyCompilerVersion, "compiler-version", "", "Full version of the compiler, for example v0.8.13+commit.abaa5c0e")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyConstructorArgs, "constructor-args", "", "ABI-encoded constructor arguments (as a hex string)")
+	contractVerifyCmd.Flags().StringVar(&contractVerifier, "verifier", "", "Service with which to verify the contract (etherscan/sourcify/blockscout) (default sourcify)")
+	contractVerifyCmd.Flags().Bool("wait", false, "wait for the verification to complete before returning")
+	contractVerifyCmd.Flags().Duration("limit", 0, "maximum time to wait for the verification to complete before failing (default forever)")
+}
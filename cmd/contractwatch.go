@@ -0,0 +1,167 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/funcparser"
+)
+
+var contractWatchEvent string
+var contractWatchFilters []string
+var contractWatchFormat string
+var contractWatchInterval time.Duration
+
+// contractWatchCmd represents the contract watch command
+var contractWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch events for a contract as they occur",
+	Long: `Watch and decode the events emitted by a contract as they occur.  For example:
+
+   ethereal contract watch --contract=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --abi="./erc20.abi" --event=Transfer --filter=to=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+Filters can be supplied for any indexed input of the event, in the form name=value.  If the connection supports subscriptions (websocket or IPC) they are used, otherwise the node is polled for new events.
+
+This command runs until interrupted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(contractStr != "", quiet, "--contract is required")
+		contractAddress, err := c.Resolve(contractStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve contract address %s", contractStr))
+
+		contract := parseContract("")
+		cli.Assert(len(contract.Abi.Events) > 0, quiet, "ABI with events is required; supply it with --abi or --json")
+		cli.Assert(contractWatchFormat == "text" || contractWatchFormat == "json", quiet, fmt.Sprintf("Unknown format %s", contractWatchFormat))
+
+		query := ethereum.FilterQuery{
+			Addresses: []common.Address{contractAddress},
+		}
+		if contractWatchEvent != "" {
+			event, exists := contract.Abi.Events[contractWatchEvent]
+			cli.Assert(exists, quiet, fmt.Sprintf("Unknown event %s", contractWatchEvent))
+			topics, err := contractWatchTopics(&event, contractWatchFilters)
+			cli.ErrCheck(err, quiet, "Invalid filter")
+			query.Topics = topics
+		} else {
+			cli.Assert(len(contractWatchFilters) == 0, quiet, "--filter requires --event")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		logs := make(chan types.Log)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- c.SubscribeLogs(ctx, query, contractWatchInterval, logs)
+		}()
+
+		for {
+			select {
+			case err := <-errCh:
+				cli.ErrCheck(err, quiet, "Failed to watch for events")
+				os.Exit(exitSuccess)
+			case log := <-logs:
+				if quiet {
+					continue
+				}
+				event := decodeEvent(&contract.Abi, &log)
+				if log.Removed {
+					outputIf(verbose, fmt.Sprintf("Log %d of transaction %s removed due to reorg", log.Index, log.TxHash.Hex()))
+					continue
+				}
+				if contractWatchFormat == "json" || jsonOutput() {
+					writeJSON(event)
+				} else {
+					fmt.Println(event.String())
+				}
+			}
+		}
+	},
+}
+
+// contractWatchTopics generates the topics for an event given filters of the form name=value.
+func contractWatchTopics(event *abi.Event, filters []string) ([][]common.Hash, error) {
+	indexed := make([]abi.Argument, 0)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	topics := make([][]common.Hash, 1+len(indexed))
+	topics[0] = []common.Hash{event.ID}
+	for _, filter := range filters {
+		parts := strings.SplitN(filter, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("filter %q must be of the form name=value", filter)
+		}
+		found := false
+		for i := range indexed {
+			if indexed[i].Name != parts[0] {
+				continue
+			}
+			found = true
+			var val interface{}
+			var err error
+			if indexed[i].Type.T == abi.AddressTy {
+				val, err = c.Resolve(parts[1])
+			} else {
+				val, err = funcparser.StrTo(&indexed[i].Type, parts[1])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %v", parts[0], err)
+			}
+			topic, err := abi.MakeTopics([]interface{}{val})
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %v", parts[0], err)
+			}
+			topics[1+i] = append(topics[1+i], topic[0]...)
+		}
+		if !found {
+			return nil, fmt.Errorf("event %s has no indexed input %s", event.Name, parts[0])
+		}
+	}
+
+	// Trim unused trailing topics.
+	for len(topics) > 1 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+	return topics, nil
+}
+
+func init() {
+	contractCmd.AddCommand(contractWatchCmd)
+	contractFlags(contractWatchCmd)
+	contractWatchCmd.Flags().StringVar(&contractWatchEvent, "event", "", "Name of the event to watch (defaults to all events)")
+	contractWatchCmd.Flags().StringArrayVar(&contractWatchFilters, "filter", nil, "Filter on an indexed input of the event, in the form name=value (can be repeated)")
+	contractWatchCmd.Flags().StringVar(&contractWatchFormat, "format", "text", "Output format (text or json)")
+	contractWatchCmd.Flags().DurationVar(&contractWatchInterval, "interval", 12*time.Second, "Interval between polls if the connection does not support subscriptions")
+}
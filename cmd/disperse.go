@@ -0,0 +1,165 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/disperse"
+)
+
+var disperseFromAddress string
+var disperseFile string
+var disperseContractAddress string
+var disperseDeploy bool
+var disperseFactory string
+
+// disperseFlags adds the flags for commands that send payments through the Disperse contract.
+func disperseFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&disperseFromAddress, "from", "", "Address from which to send the payments")
+	cmd.Flags().StringVar(&disperseFile, "file", "", "CSV or JSON file containing the recipients and amounts of the payments")
+	cmd.Flags().StringVar(&disperseContractAddress, "disperse", "", fmt.Sprintf("Address of the disperse contract (default %s if present)", disperse.Address.Hex()))
+	cmd.Flags().BoolVar(&disperseDeploy, "deploy", false, "Deploy a disperse contract if there is not one on the chain")
+	cmd.Flags().StringVar(&disperseFactory, "create2-factory", "", fmt.Sprintf("Address of the CREATE2 factory used to deploy the disperse contract (default %s)", util.DefaultCreate2Factory.Hex()))
+	addTransactionFlags(cmd, "the address from which to send the payments")
+}
+
+// disperseSender returns the address from which to send the payments.
+func disperseSender() common.Address {
+	disperseFromAddress = accountOrDefault(disperseFromAddress)
+	cli.Assert(disperseFromAddress != "", quiet, "--from is required")
+	fromAddress, err := c.Resolve(disperseFromAddress)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", disperseFromAddress))
+	return fromAddress
+}
+
+// dispersePayments returns the recipients and values of the payments in the file, using the
+// supplied function to parse the values.
+func dispersePayments(parseValue func(string) (*big.Int, error)) ([]common.Address, []*big.Int) {
+	cli.Assert(disperseFile != "", quiet, "--file is required")
+	data, err := ioutil.ReadFile(disperseFile)
+	cli.ErrCheck(err, quiet, "Failed to read payments file")
+	rows, err := util.ParseBatch(data)
+	cli.ErrCheck(err, quiet, "Failed to parse payments file")
+
+	recipients := make([]common.Address, len(rows))
+	values := make([]*big.Int, len(rows))
+	for i, row := range rows {
+		cli.Assert(row.Data == "", quiet, fmt.Sprintf("Payment %d has data, which is not supported", i+1))
+		recipients[i], err = c.Resolve(row.To)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve recipient %s of payment %d", row.To, i+1))
+		cli.Assert(row.Value != "", quiet, fmt.Sprintf("Payment %d has no amount", i+1))
+		values[i], err = parseValue(row.Value)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid amount %s for payment %d", row.Value, i+1))
+	}
+	return recipients, values
+}
+
+// disperseContract returns the address of the disperse contract.  This is the supplied contract
+// if present, otherwise Disperse or a previously deployed disperse contract.  If there is no
+// contract and --deploy is set then the contract is deployed, and mined before returning.
+func disperseContract(from common.Address) common.Address {
+	ctx, cancel := localContext()
+	defer cancel()
+
+	if disperseContractAddress != "" {
+		address, err := c.Resolve(disperseContractAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve disperse contract %s", disperseContractAddress))
+		code, err := c.Client().CodeAt(ctx, address, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain disperse contract code")
+		cli.Assert(len(code) > 0, quiet, fmt.Sprintf("No contract at %s", address.Hex()))
+		return address
+	}
+
+	code, err := c.Client().CodeAt(ctx, disperse.Address, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain disperse contract code")
+	if len(code) > 0 {
+		return disperse.Address
+	}
+
+	factory, err := create2Factory(disperseFactory)
+	cli.ErrCheck(err, quiet, "Failed to obtain CREATE2 factory address")
+	address := disperse.DeployedAddress(factory)
+	code, err = c.Client().CodeAt(ctx, address, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain disperse contract code")
+	if len(code) > 0 {
+		return address
+	}
+
+	cli.Assert(disperseDeploy, quiet, "No disperse contract on this chain; use --deploy to deploy one")
+	code, err = c.Client().CodeAt(ctx, factory, nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain CREATE2 factory code")
+	cli.Assert(len(code) > 0, quiet, fmt.Sprintf("No CREATE2 factory at %s", factory.Hex()))
+
+	tx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
+		From:  from,
+		To:    &factory,
+		Value: big.NewInt(0),
+		Data:  disperse.DeployData(),
+	})
+	cli.ErrCheck(err, quiet, "Failed to create disperse contract deployment transaction")
+	err = sendTransaction(context.Background(), from, tx)
+	cli.ErrCheck(err, quiet, "Failed to send disperse contract deployment transaction")
+	if dryRun {
+		// The deployment was simulated, so there is nothing to wait for.
+		return address
+	}
+	logTransaction(tx, log.Fields{
+		"group":   "disperse",
+		"command": "deploy",
+		"address": address.Hex(),
+	})
+	outputIf(!quiet, fmt.Sprintf("Waiting for transaction %#x deploying the disperse contract to be mined", tx.Hash()))
+	mined, err := c.WaitForTransaction(context.Background(), tx.Hash(), 1, 0)
+	cli.ErrCheck(err, quiet, "Failed to mine disperse contract deployment transaction")
+	cli.Assert(mined.Receipt.Status == types.ReceiptStatusSuccessful, quiet, "Disperse contract deployment failed")
+	outputIf(verbose, fmt.Sprintf("Disperse contract deployed at %s", address.Hex()))
+
+	return address
+}
+
+// sendDisperseTransaction sends the transaction with the given data and value to the disperse
+// contract, and handles it as a submitted transaction.
+func sendDisperseTransaction(from common.Address, contract common.Address, value *big.Int, data []byte, logFields log.Fields) {
+	var gasLimit *uint64
+	limit := uint64(viper.GetInt64("gaslimit"))
+	if limit > 0 {
+		gasLimit = &limit
+	}
+
+	signedTx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
+		From:     from,
+		To:       &contract,
+		Value:    value,
+		GasLimit: gasLimit,
+		Data:     data,
+	})
+	cli.ErrCheck(err, quiet, "Failed to create transaction")
+	err = sendTransaction(context.Background(), from, signedTx)
+	cli.ErrCheck(err, quiet, "Failed to send transaction")
+
+	logFields["disperse"] = contract.Hex()
+	handleSubmittedTransaction(signedTx, logFields, true)
+}
@@ -14,7 +14,11 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/miekg/dns"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -48,3 +52,51 @@ func dnsFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&dnsResource, "resource", "", "The resource (A, NS, CNAME etc.)")
 	cmd.Flags().StringVar(&dnsName, "name", "", "The name for the resource (end with \".\" for fully-qualified domain, otherwise domain will be added)")
 }
+
+// dnsRRSetToWire encodes a resource record set in to wire format.
+// Each value is the presentation format of the record data, for example
+// "10 20 5060 sip.example.com." for an SRV record.
+func dnsRRSetToWire(name string, ttl uint32, rrType string, values []string) ([]byte, error) {
+	if _, exists := stringToType[rrType]; !exists {
+		return nil, fmt.Errorf("unknown resource %s", rrType)
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no records supplied")
+	}
+
+	data := make([]byte, 0)
+	for _, value := range values {
+		source := fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(name), ttl, rrType, strings.TrimSpace(value))
+		outputIf(verbose, fmt.Sprintf("Adding record %s", source))
+		rr, err := dns.NewRR(source)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to generate resource record from source %s", source))
+		}
+		if rr == nil {
+			return nil, fmt.Errorf("no resource record generated from source %s", source)
+		}
+		buf := make([]byte, dns.Len(rr))
+		offset, err := dns.PackRR(rr, buf, 0, nil, false)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to pack resource record %v", rr))
+		}
+		data = append(data, buf[:offset]...)
+	}
+	return data, nil
+}
+
+// dnsWireToRRs decodes resource records in wire format.
+func dnsWireToRRs(data []byte) ([]dns.RR, error) {
+	rrs := make([]dns.RR, 0)
+	offset := 0
+	for offset < len(data) {
+		var rr dns.RR
+		var err error
+		rr, offset, err = dns.UnpackRR(data, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unpack resource record")
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
@@ -15,7 +15,6 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
@@ -58,7 +57,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Cannot obtain owner")
 
 		cli.Assert(!bytes.Equal(domainOwner.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Owner is not set")
-		outputIf(verbose, fmt.Sprintf("Domain owner is %s", ens.Format(c.Client(), domainOwner)))
+		outputIf(verbose, fmt.Sprintf("Domain owner is %s", formatAddress(domainOwner)))
 
 		// Obtain resolver for the domain
 		resolver, err := ens.NewDNSResolver(c.Client(), ensDomain)
@@ -70,11 +69,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		signedTx, err := resolver.ClearDNSZone(opts)
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
@@ -19,7 +19,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/miekg/dns"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
 	ens "github.com/wealdtech/go-ens/v3"
@@ -65,6 +64,7 @@ In quiet mode this will return 0 if the resource exists, otherwise 1.`,
 
 		var data []byte
 		// Attempt to fetch record
+		cli.Assert(dnsResource != "", quiet, "--resource is required")
 		dnsResource := strings.ToUpper(dnsResource)
 		resourceNum, exists := stringToType[dnsResource]
 		cli.Assert(exists, quiet, fmt.Sprintf("Unknown resource %s", dnsResource))
@@ -77,17 +77,27 @@ In quiet mode this will return 0 if the resource exists, otherwise 1.`,
 			os.Exit(exitSuccess)
 		}
 
+		rrs, err := dnsWireToRRs(data)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to decode %s resource %s for %s", dnsResource, dnsName, dnsDomain))
+
+		if jsonOutput() {
+			records := make([]string, len(rrs))
+			for i := range rrs {
+				records[i] = rrs[i].String()
+			}
+			outputJSON(map[string]interface{}{
+				"name":     dnsName,
+				"resource": dnsResource,
+				"records":  records,
+				"wire":     hex.EncodeToString(data),
+			})
+		}
+
 		if dnsGetWire {
 			fmt.Println(hex.EncodeToString(data))
 		} else {
-			// Decode the data resource record(s)
-			offset := 0
-			var result dns.RR
-			for offset < len(data) {
-				result, offset, err = dns.UnpackRR(data, offset)
-				if err == nil {
-					fmt.Println(result)
-				}
+			for _, rr := range rrs {
+				fmt.Println(rr)
 			}
 		}
 	},
@@ -15,7 +15,6 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
@@ -66,14 +65,13 @@ In This will return an exit status of 0 if the transaction is successfully submi
 		cli.ErrCheck(err, quiet, "Cannot obtain owner")
 
 		cli.Assert(!bytes.Equal(domainOwner.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Owner is not set")
-		outputIf(verbose, fmt.Sprintf("Domain owner is %s", ens.Format(c.Client(), domainOwner)))
+		outputIf(verbose, fmt.Sprintf("Domain owner is %s", formatAddress(domainOwner)))
 
 		// Obtain DNS resolver for the domain
 		resolver, err := ens.NewDNSResolver(c.Client(), ensDomain)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain resolver contract for %s", dnsDomain))
 
 		var signedTx *types.Transaction
-		data := make([]byte, 32768)
 		dnsName = strings.ToLower(dnsName)
 		if dnsName == "" {
 			dnsName = dnsDomain
@@ -93,18 +91,9 @@ In This will return an exit status of 0 if the transaction is successfully submi
 
 		cli.Assert(dnsSetRecord != "", quiet, "--record is required")
 
-		// Create the data resource record(s)
-		offset := 0
-		values := strings.Split(dnsSetRecord, "&&")
-		for _, value := range values {
-			source := fmt.Sprintf("%s %d %s %s", dnsName, int(dnsSetTTL.Seconds()), dnsResource, value)
-			outputIf(verbose, fmt.Sprintf("Adding record %s", source))
-			resource, err := dns.NewRR(source)
-			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to generate resource record from source %s", source))
-			offset, err = dns.PackRR(resource, data, offset, nil, false)
-			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to pack resource record %v", resource))
-		}
-		data = data[0:offset]
+		// Create the data resource record set
+		data, err := dnsRRSetToWire(dnsName, uint32(dnsSetTTL.Seconds()), dnsResource, strings.Split(dnsSetRecord, "&&"))
+		cli.ErrCheck(err, quiet, "Failed to create resource record set")
 
 		if dnsResource != "SOA" && !dnsSetNoSoa {
 			// Obtain the current SOA
@@ -133,11 +122,7 @@ In This will return an exit status of 0 if the transaction is successfully submi
 		signedTx, err = resolver.SetRecords(opts, data)
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
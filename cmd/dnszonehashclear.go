@@ -60,7 +60,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Cannot obtain owner")
 
 		cli.Assert(!bytes.Equal(domainOwner.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Owner is not set")
-		outputIf(verbose, fmt.Sprintf("Domain owner is %s", ens.Format(c.Client(), domainOwner)))
+		outputIf(verbose, fmt.Sprintf("Domain owner is %s", formatAddress(domainOwner)))
 
 		// Obtain DNS resolver for the domain
 		resolver, err := ens.NewDNSResolver(c.Client(), ensDomain)
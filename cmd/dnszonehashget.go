@@ -68,6 +68,9 @@ In quiet mode this will return 0 if the name has a valid zone hash, otherwise 1.
 		res, err := ens.ContenthashToString(bytes)
 		cli.ErrCheck(err, quiet, "Invalid content hash data")
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "zonehash": res})
+		}
 		if !quiet {
 			fmt.Printf("%s\n", res)
 		}
@@ -0,0 +1,133 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// dryRunFailed is set if a transaction simulated with --dry-run fails or cannot be afforded.
+var dryRunFailed bool
+
+// dryRunJSON is the JSON output for a transaction that is simulated rather than sent.
+type dryRunJSON struct {
+	Transaction json.RawMessage `json:"transaction"`
+	Success     bool            `json:"success"`
+	Error       string          `json:"error,omitempty"`
+	GasEstimate uint64          `json:"gas_estimate,omitempty"`
+	ReturnData  string          `json:"return_data,omitempty"`
+	Balance     string          `json:"balance"`
+	MaxCost     string          `json:"max_cost"`
+}
+
+// sendTransaction sends the transaction, or simulates it if --dry-run is supplied.  The user is
+// asked to confirm the transaction first if it is above the confirmation thresholds.
+func sendTransaction(ctx context.Context, from common.Address, tx *types.Transaction) error {
+	if dryRun {
+		dryRunTransaction(from, tx)
+		return nil
+	}
+	confirmTransaction(tx)
+	return c.SendTransaction(ctx, tx)
+}
+
+// dryRunTransaction simulates the transaction from the given sender in place of sending it, and
+// outputs the transaction that would have been sent along with the result of the simulation.  The
+// transaction does not need to be signed.  It returns false if the transaction fails or the sender
+// cannot afford it.
+func dryRunTransaction(from common.Address, tx *types.Transaction) bool {
+	ctx, cancel := localContext()
+	defer cancel()
+
+	simulation, err := c.SimulateTransaction(ctx, from, tx)
+	cli.ErrCheck(err, quiet, "Failed to simulate transaction")
+	txJSON, err := util.MarshalUnsignedTransaction(tx, &from)
+	cli.ErrCheck(err, quiet, "Failed to encode transaction")
+
+	res := &dryRunJSON{
+		Transaction: txJSON,
+		Success:     simulation.Err == nil,
+		Balance:     simulation.Balance.String(),
+		MaxCost:     simulation.MaxCost.String(),
+	}
+	if simulation.Err != nil {
+		res.Error = util.RevertReason(nil, simulation.Err)
+	} else {
+		res.GasEstimate = simulation.GasEstimate
+		if len(simulation.ReturnData) > 0 {
+			res.ReturnData = hexutil.Encode(simulation.ReturnData)
+		}
+	}
+
+	switch {
+	case quiet:
+	case jsonOutput():
+		writeJSON(res)
+	default:
+		fmt.Print(dryRunString(res, txJSON, simulation))
+	}
+
+	succeeded := res.Success && simulation.Balance.Cmp(simulation.MaxCost) >= 0
+	if !succeeded {
+		dryRunFailed = true
+	}
+	return succeeded
+}
+
+// successExitStatus returns the exit status for a command that has submitted its transactions,
+// which is a failure if any transaction simulated with --dry-run failed.
+func successExitStatus() int {
+	if dryRunFailed {
+		return exitFailure
+	}
+	return exitSuccess
+}
+
+// dryRunString returns the text output for a transaction that is simulated rather than sent.
+func dryRunString(res *dryRunJSON, txJSON []byte, simulation *conn.Simulation) string {
+	affordable := simulation.Balance.Cmp(simulation.MaxCost) >= 0
+	builder := strings.Builder{}
+	builder.WriteString("Dry run; transaction not sent\n")
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, txJSON, "", "  "); err == nil {
+		builder.WriteString(indented.String())
+	} else {
+		builder.Write(txJSON)
+	}
+	builder.WriteString("\n")
+	if res.Success {
+		builder.WriteString("Simulation:\tsucceeded\n")
+		builder.WriteString(fmt.Sprintf("Gas estimate:\t%d\n", res.GasEstimate))
+		if res.ReturnData != "" {
+			builder.WriteString(fmt.Sprintf("Return data:\t%s\n", res.ReturnData))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("Simulation:\tfailed (%s)\n", res.Error))
+	}
+	if !affordable {
+		builder.WriteString(fmt.Sprintf("Balance of %s insufficient for maximum cost of %s\n", formatWei(simulation.Balance), formatWei(simulation.MaxCost)))
+	}
+	return builder.String()
+}
@@ -14,10 +14,26 @@
 package cmd
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	ens "github.com/wealdtech/go-ens/v3"
+	"github.com/wealdtech/go-ens/v3/contracts/resolver"
 )
 
 var ensDomain string
+var ensUniversalResolverStr string
+var ensRegistrarControllerStr string
+var ensDurationStr string
+var ensBlock string
 
 // ensCmd represents the ens command
 var ensCmd = &cobra.Command{
@@ -33,3 +49,112 @@ func init() {
 func ensFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&ensDomain, "domain", "", "Domain against which to operate (e.g. wealdtech.eth)")
 }
+
+func ensBlockFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ensBlock, "block", "latest", "Block at which to obtain information (number, hash or offset from latest)")
+}
+
+// ensCallOpts returns the options for read-only ENS calls, using the block supplied with --block.
+func ensCallOpts() *bind.CallOpts {
+	return blockCallOpts(ensBlock)
+}
+
+// ensResolverContract returns the resolver contract for the domain as of the block in the
+// options, along with the name hash of the domain.
+func ensResolverContract(opts *bind.CallOpts, domain string) (*resolver.Contract, [32]byte, error) {
+	node, err := ens.NameHash(domain)
+	if err != nil {
+		return nil, node, err
+	}
+	registry, err := ens.NewRegistry(c.Client())
+	if err != nil {
+		return nil, node, err
+	}
+	owner, err := registry.Contract.Owner(opts, node)
+	if err != nil {
+		return nil, node, err
+	}
+	if owner == ens.UnknownAddress {
+		return nil, node, errors.New("unregistered name")
+	}
+	address, err := registry.Contract.Resolver(opts, node)
+	if err != nil {
+		return nil, node, err
+	}
+	if address == ens.UnknownAddress {
+		return nil, node, errors.New("no resolver")
+	}
+	contract, err := resolver.NewContract(address, c.Client())
+	return contract, node, err
+}
+
+func ensUniversalResolverFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ensUniversalResolverStr, "universal-resolver", "", "Address of the ENS universal resolver (defaults to the mainnet address)")
+}
+
+// ensUniversalResolver returns the address of the ENS universal resolver, and false if there
+// is no universal resolver on the current chain.
+func ensUniversalResolver() (common.Address, bool) {
+	addressStr := ensUniversalResolverStr
+	if addressStr == "" {
+		addressStr = viper.GetString("ens-universal-resolver")
+	}
+	address := util.UniversalResolverAddress
+	if addressStr != "" {
+		var err error
+		address, err = c.Resolve(addressStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain universal resolver address")
+	}
+
+	ctx, cancel := localContext()
+	defer cancel()
+	code, err := c.Client().CodeAt(ctx, address, nil)
+	if err != nil || len(code) == 0 {
+		outputIf(debug, "No ENS universal resolver available")
+		return common.Address{}, false
+	}
+	return address, true
+}
+
+func ensRegistrationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ensDurationStr, "duration", "1y", "Duration of the registration, for example 90d or 2y")
+	cmd.Flags().StringVar(&ensRegistrarControllerStr, "registrar-controller", "", "Address of the .eth registrar controller (defaults to the controller registered for .eth)")
+}
+
+// ensDuration returns the duration supplied by the user.
+func ensDuration() time.Duration {
+	duration, err := util.StringToDuration(ensDurationStr)
+	cli.ErrCheck(err, quiet, "Invalid duration")
+	cli.Assert(duration > 0, quiet, "Duration must be positive")
+	return duration
+}
+
+// ensRegistrarController returns the .eth registrar controller.
+func ensRegistrarController() *contracts.ETHRegistrarController {
+	var address common.Address
+	var err error
+	if ensRegistrarControllerStr != "" {
+		address, err = c.Resolve(ensRegistrarControllerStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain registrar controller address")
+	} else {
+		registry, err := ens.NewRegistry(c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain ENS registry")
+		resolver, err := registry.Resolver("eth")
+		cli.ErrCheck(err, quiet, "Failed to obtain resolver for eth")
+		address, err = resolver.InterfaceImplementer(util.ETHRegistrarControllerInterfaceID)
+		cli.ErrCheck(err, quiet, "Failed to obtain registrar controller")
+		cli.Assert(address != ens.UnknownAddress, quiet, "No registrar controller for eth; supply one with --registrar-controller")
+	}
+	outputIf(debug, fmt.Sprintf("Registrar controller is %s", address.Hex()))
+
+	controller, err := contracts.NewETHRegistrarController(address, c.Client())
+	cli.ErrCheck(err, quiet, "Failed to obtain registrar controller")
+	return controller
+}
+
+// ensRegistrationLabel returns the label of a .eth domain to pass to the registrar controller.
+func ensRegistrationLabel(domain string) string {
+	label, err := ens.UnqualifiedName(domain, "eth")
+	cli.Assert(err == nil && label != "", quiet, fmt.Sprintf("%s is not a .eth second-level domain", domain))
+	return label
+}
@@ -15,8 +15,11 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
+var ensAddressCoinTypeStr string
 var ensAddressCoinType uint64
 
 // ensAddressCmd represents the ens address command
@@ -32,6 +35,13 @@ func init() {
 }
 
 func ensAddressFlags(cmd *cobra.Command) {
-	cmd.Flags().Uint64Var(&ensAddressCoinType, "cointype", 60, "The coin type of the address (default 60 for Ethereum)")
+	cmd.Flags().StringVar(&ensAddressCoinTypeStr, "cointype", "eth", "The coin of the address, either as a symbol (btc, ltc, doge, eth, etc) or a SLIP-44 coin type")
 	ensFlags(cmd)
 }
+
+// ensAddressParseCoinType parses the coin type supplied by the user.
+func ensAddressParseCoinType() {
+	var err error
+	ensAddressCoinType, err = util.CoinType(ensAddressCoinTypeStr)
+	cli.ErrCheck(err, quiet, "Invalid coin type")
+}
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
@@ -37,6 +38,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		ensAddressParseCoinType()
 
 		registry, err := ens.NewRegistry(c.Client())
 		cli.ErrCheck(err, quiet, "cannot obtain ENS registry contract")
@@ -52,13 +54,19 @@ This will return an exit status of 0 if the transaction is successfully submitte
 
 		opts, err := generateTxOpts(owner)
 		cli.ErrCheck(err, quiet, "failed to generate transaction options")
-		signedTx, err := resolver.SetAddress(opts, ens.UnknownAddress)
+		var signedTx *types.Transaction
+		if ensAddressCoinType == 60 {
+			signedTx, err = resolver.SetAddress(opts, ens.UnknownAddress)
+		} else {
+			signedTx, err = resolver.SetMultiAddress(opts, ensAddressCoinType, []byte{})
+		}
 		cli.ErrCheck(err, quiet, "failed to send transaction")
 
 		handleSubmittedTransaction(signedTx, log.Fields{
 			"group":     "ens/address",
 			"command":   "clear",
 			"ensdomain": ensDomain,
+			"cointype":  ensAddressCoinType,
 		}, true)
 	},
 }
@@ -15,12 +15,12 @@ package cmd
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	ens "github.com/wealdtech/go-ens/v3"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
 // ensAddressGetCmd represents the address get command
@@ -31,15 +31,21 @@ var ensAddressGetCmd = &cobra.Command{
 
     ethereal ens address get --domain=enstest.eth
 
+Addresses for other coins can be obtained with --cointype, for example --cointype=btc.  Addresses for Bitcoin, Litecoin, Dogecoin and EVM chains are shown in their native format, and addresses for other coins as hex.
+
+Use --block to obtain the address as of an earlier block.
+
 In quiet mode this will return 0 if the name has an address, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		ensAddressParseCoinType()
 
-		resolver, err := ens.NewResolver(c.Client(), ensDomain)
+		opts := ensCallOpts()
+		resolver, node, err := ensResolverContract(opts, ensDomain)
 		cli.ErrCheck(err, quiet, "failed to obtain resolver")
 
-		bytes, err := resolver.MultiAddress(ensAddressCoinType)
+		bytes, err := resolver.Addr0(opts, node, new(big.Int).SetUint64(ensAddressCoinType))
 		cli.ErrCheck(err, quiet, "failed to obtain address")
 		if len(bytes) == 0 {
 			outputIf(verbose, "no address")
@@ -49,18 +55,24 @@ In quiet mode this will return 0 if the name has an address, otherwise 1.`,
 			os.Exit(exitSuccess)
 		}
 
-		switch ensAddressCoinType {
-		case 60:
-			address := common.BytesToAddress(bytes)
-			fmt.Printf("%s\n", address.Hex())
-		default:
-			fmt.Printf("%#x\n", bytes)
+		address, err := util.DecodeCoinAddress(ensAddressCoinType, bytes)
+		cli.ErrCheck(err, quiet, "failed to decode address")
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"domain":    ensDomain,
+				"coin_type": ensAddressCoinType,
+				"address":   address,
+			})
 		}
+
+		fmt.Printf("%s\n", address)
 		os.Exit(exitSuccess)
 	},
 }
 
 func init() {
 	ensAddressFlags(ensAddressGetCmd)
+	ensBlockFlags(ensAddressGetCmd)
 	ensAddressCmd.AddCommand(ensAddressGetCmd)
 }
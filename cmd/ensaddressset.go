@@ -15,13 +15,13 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 	ens "github.com/wealdtech/go-ens/v3"
 )
 
@@ -35,12 +35,17 @@ var ensAddressSetCmd = &cobra.Command{
 
     ethereal ens address set --domain=enstest.eth --address=0x1234...5678 --passphrase="my secret passphrase"
 
+Addresses for other coins can be set with --cointype, in which case the address is supplied in the coin's native format, for example:
+
+    ethereal ens address set --domain=enstest.eth --cointype=btc --address=bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4 --passphrase="my secret passphrase"
+
 The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		ensAddressParseCoinType()
 
 		registry, err := ens.NewRegistry(c.Client())
 		cli.ErrCheck(err, quiet, "Cannot obtain ENS registry contract")
@@ -49,26 +54,25 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		owner, err := registry.Owner(ensDomain)
 		cli.ErrCheck(err, quiet, "Cannot obtain owner")
 		cli.Assert(!bytes.Equal(owner.Bytes(), ens.UnknownAddress.Bytes()), quiet, fmt.Sprintf("owner of %s is not set", ensDomain))
-		outputIf(verbose, fmt.Sprintf("Domain is owned by %s", ens.Format(c.Client(), owner)))
+		outputIf(verbose, fmt.Sprintf("Domain is owned by %s", formatAddress(owner)))
 
-		// Obtain the address: could be an ENS name or a number
+		// Obtain the address: could be an ENS name or an address in the coin's native format
 		var data []byte
-		if strings.Contains(ensAddressSetAddressStr, ".") {
+		if ensAddressCoinType == 60 && strings.Contains(ensAddressSetAddressStr, ".") {
 			// Assume ENS address
 			address, err := c.Resolve(ensAddressSetAddressStr)
-			cli.Assert(!bytes.Equal(address.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Invalid address; if you are trying to clear an existing address use \"ens address clear\"")
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid name/address %s", ensAddressSetAddressStr))
+			cli.Assert(!bytes.Equal(address.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Invalid address; if you are trying to clear an existing address use \"ens address clear\"")
 			data = address.Bytes()
 		} else {
-			// Assume hex string
-			data, err = hex.DecodeString(strings.TrimPrefix(ensAddressSetAddressStr, "0x"))
+			data, err = util.EncodeCoinAddress(ensAddressCoinType, ensAddressSetAddressStr)
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Unrecognised name/address %s", ensAddressSetAddressStr))
 		}
 
 		// Obtain the resolver for this name
 		resolver, err := ens.NewResolver(c.Client(), ensDomain)
 		cli.ErrCheck(err, quiet, "No resolver for that name")
-		outputIf(verbose, fmt.Sprintf("Resolver is %s", ens.Format(c.Client(), resolver.ContractAddr)))
+		outputIf(verbose, fmt.Sprintf("Resolver is %s", formatAddress(resolver.ContractAddr)))
 
 		opts, err := generateTxOpts(owner)
 		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
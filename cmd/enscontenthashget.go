@@ -32,19 +32,25 @@ var ensContenthashGetCmd = &cobra.Command{
 
     ethereal ens contenthash get --domain=enstest.eth
 
+Use --block to obtain the content hash as of an earlier block.
+
 In quiet mode this will return 0 if the name has a valid content hash, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
 
 		// Obtain resolver for the domain
-		resolver, err := ens.NewResolver(c.Client(), ensDomain)
+		opts := ensCallOpts()
+		resolver, node, err := ensResolverContract(opts, ensDomain)
 		cli.ErrCheck(err, quiet, "No resolver for that name")
 
-		bytes, err := resolver.Contenthash()
+		bytes, err := resolver.Contenthash(opts, node)
 		cli.ErrCheck(err, quiet, "Failed to obtain content hash for that domain")
 		cli.Assert(len(bytes) > 0, quiet, "No content hash for that domain")
 
+		if ensContenthashGetRaw && jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "contenthash": fmt.Sprintf("%#x", bytes)})
+		}
 		if ensContenthashGetRaw {
 			if !quiet {
 				fmt.Printf("%x\n", bytes)
@@ -56,6 +62,9 @@ In quiet mode this will return 0 if the name has a valid content hash, otherwise
 		res, err := ens.ContenthashToString(bytes)
 		cli.ErrCheck(err, quiet, "Invalid content hash data")
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "contenthash": res})
+		}
 		if !quiet {
 			fmt.Printf("%s\n", res)
 		}
@@ -30,18 +30,25 @@ var ensControllerGetCmd = &cobra.Command{
 
     ethereal ens controller get --domain=enstest.eth
 
+Use --block to obtain the controller as of an earlier block.
+
 In quiet mode this will return 0 if the name has a controller, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
 
+		node, err := ens.NameHash(ensDomain)
+		cli.ErrCheck(err, quiet, "failed to obtain name hash")
 		registry, err := ens.NewRegistry(c.Client())
 		cli.ErrCheck(err, quiet, "failed to obtain registry contract")
-		controller, err := registry.Owner(ensDomain)
+		controller, err := registry.Contract.Owner(ensCallOpts(), node)
 		cli.ErrCheck(err, quiet, "failed to obtain controller")
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "controller": controller.Hex()})
+		}
 		if !quiet {
-			fmt.Printf("%s\n", ens.Format(c.Client(), controller))
+			fmt.Printf("%s\n", formatAddress(controller))
 		}
 		os.Exit(exitSuccess)
 	},
@@ -49,5 +56,6 @@ In quiet mode this will return 0 if the name has a controller, otherwise 1.`,
 
 func init() {
 	ensControllerFlags(ensControllerGetCmd)
+	ensBlockFlags(ensControllerGetCmd)
 	ensControllerCmd.AddCommand(ensControllerGetCmd)
 }
@@ -16,7 +16,9 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
@@ -33,6 +35,8 @@ var ensControllerSetCmd = &cobra.Command{
 
     ethereal ens controller set --domain=enstest.eth --controller=0x1234...5678 --passphrase="my secret passphrase"
 
+If the domain is wrapped then the wrapped domain is transferred to the new controller through the name wrapper.
+
 The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
@@ -53,10 +57,23 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.Assert(!bytes.Equal(newControllerAddress.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Attempt to set controller to 0x00 disallowed")
 		cli.ErrCheck(err, quiet, "Failed to obtain new controller address")
 
-		opts, err := generateTxOpts(controller)
-		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
-		signedTx, err := registry.SetOwner(opts, ensDomain, newControllerAddress)
-		cli.ErrCheck(err, quiet, "Failed to send transaction")
+		var signedTx *types.Transaction
+		if ensIsWrapper(controller) {
+			// Wrapped names are transferred through the name wrapper.
+			wrapper, _ := ensNameWrapper()
+			owner := ensWrappedOwner(wrapper, ensDomain)
+			node, err := ens.NameHash(ensDomain)
+			cli.ErrCheck(err, quiet, "Failed to obtain name hash")
+			opts, err := generateTxOpts(owner)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = wrapper.SafeTransferFrom(opts, owner, newControllerAddress, new(big.Int).SetBytes(node[:]), big.NewInt(1), []byte{})
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		} else {
+			opts, err := generateTxOpts(controller)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = registry.SetOwner(opts, ensDomain, newControllerAddress)
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		}
 
 		handleSubmittedTransaction(signedTx, log.Fields{
 			"group":         "ens/controller",
@@ -71,5 +88,6 @@ func init() {
 	ensControllerCmd.AddCommand(ensControllerSetCmd)
 	ensControllerFlags(ensControllerSetCmd)
 	ensControllerSetCmd.Flags().StringVar(&ensControllerSetControllerStr, "controller", "", "The new controller's name or address")
+	ensNameWrapperFlags(ensControllerSetCmd)
 	addTransactionFlags(ensControllerSetCmd, "passphrase for the account that owns the domain")
 }
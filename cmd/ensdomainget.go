@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -46,7 +45,10 @@ In quiet mode this will return 0 if the address has a reverse resolution, otherw
 				cli.ErrCheck(err, quiet, "Failed to check reverse resolution")
 			}
 		}
-		fmt.Println(domain)
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": address.Hex(), "domain": domain})
+		}
+		outputIf(!quiet, domain)
 	},
 }
 
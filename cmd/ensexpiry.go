@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"time"
 
@@ -33,6 +34,8 @@ var ensExpiryCmd = &cobra.Command{
 
     ens expiry enstest.eth
 
+Use --block to obtain the expiry date as of an earlier block.
+
 In quiet mode this will return 0 if the domain has an expiry date in the future, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -44,18 +47,33 @@ In quiet mode this will return 0 if the domain has an expiry date in the future,
 		registrar, err := ens.NewBaseRegistrar(c.Client(), ens.Tld(ensDomain))
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain ENS registrar contract for %s", ens.Tld(ensDomain)))
 
-		expiryTS, err := registrar.Expiry(ensDomain)
+		label, err := ens.UnqualifiedName(ensDomain, ens.Tld(ensDomain))
+		cli.ErrCheck(err, quiet, "Failed to obtain label")
+		labelHash, err := ens.LabelHash(label)
+		cli.ErrCheck(err, quiet, "Failed to obtain label hash")
+		expiryTS, err := registrar.Contract.NameExpires(ensCallOpts(), new(big.Int).SetBytes(labelHash[:]))
 		cli.ErrCheck(err, quiet, "Failed to obtain expiry")
 
 		if expiryTS.Uint64() == uint64(0) {
 			// No expiry
-			outputIf(!quiet, "Domain is not registered")
+			if jsonOutput() {
+				writeJSON(map[string]interface{}{"domain": ensDomain, "registered": false})
+			} else {
+				outputIf(!quiet, "Domain is not registered")
+			}
 			os.Exit(exitFailure)
 		}
 
 		expiry := time.Unix(int64(expiryTS.Uint64()), 0)
 
-		if !quiet {
+		if jsonOutput() {
+			writeJSON(map[string]interface{}{
+				"domain":    ensDomain,
+				"expiry":    expiry.UTC().Format(time.RFC3339),
+				"timestamp": expiryTS.Uint64(),
+				"expired":   time.Until(expiry) < 0,
+			})
+		} else if !quiet {
 			if ensExpiryTimestamp {
 				fmt.Printf("%v\n", expiryTS)
 			} else {
@@ -75,4 +93,5 @@ func init() {
 	ensCmd.AddCommand(ensExpiryCmd)
 	ensExpiryCmd.Flags().BoolVar(&ensExpiryTimestamp, "timestamp", false, "Output the expiry as a Unix timestamp")
 	ensFlags(ensExpiryCmd)
+	ensBlockFlags(ensExpiryCmd)
 }
@@ -32,8 +32,9 @@ var ensExtendDomains string
 
 // ensExtendCmd represents the extend command
 var ensExtendCmd = &cobra.Command{
-	Use:   "extend",
-	Short: "Extend the registration of an ENS domain",
+	Use:        "extend",
+	Short:      "Extend the registration of an ENS domain",
+	Deprecated: "use \"ens renew\", which renews for a given duration",
 	Long: `Extend the registration of an Ethereum Name Service (ENS) domain.  For example:
 
     ethereal ens extend --domain=enstest.eth --value="0.1 Ether" --passphrase="my secret passphrase"
@@ -57,6 +57,10 @@ In quiet mode this will return 0 if the domain is owned, otherwise 1.`,
 		cli.ErrCheck(err, quiet, "Failed to obtain label hash of ENS domain")
 		outputIf(verbose, fmt.Sprintf("Label hash of %s is 0x%x", label, labelHash))
 
+		if jsonOutput() {
+			outputJSON(newENSInfoJSON(ensDomain, nameHash, labelHash))
+		}
+
 		if ens.DomainLevel(ensDomain) == 1 && ens.Tld(ensDomain) == "eth" {
 			// Work out if this is on the old or new .eth registrar and act accordingly
 			registrar, err := ens.NewBaseRegistrar(c.Client(), ens.Tld(ensDomain))
@@ -80,7 +84,7 @@ In quiet mode this will return 0 if the domain is owned, otherwise 1.`,
 				os.Exit(exitFailure)
 			}
 
-			outputIf(verbose, fmt.Sprintf("Registrar is %s", ens.Format(c.Client(), registrar.ContractAddr)))
+			outputIf(verbose, fmt.Sprintf("Registrar is %s", formatAddress(registrar.ContractAddr)))
 			registrantName, _ := c.ReverseResolve(registrant)
 			if registrantName == "" {
 				fmt.Printf("Registrant is %s\n", registrant.Hex())
@@ -97,7 +101,7 @@ In quiet mode this will return 0 if the domain is owned, otherwise 1.`,
 			if err == nil {
 				// Select (approximate) cost per year
 				rentPerYear := new(big.Int).Mul(big.NewInt(31536000), rentPerSec)
-				fmt.Printf("Approximate rent per year is %s\n", string2eth.WeiToString(rentPerYear, true))
+				fmt.Printf("Approximate rent per year is %s\n", formatWei(rentPerYear))
 			}
 
 			// See if there is an outstanding deed.
@@ -109,7 +113,7 @@ In quiet mode this will return 0 if the domain is owned, otherwise 1.`,
 				if entry.Value.Cmp(zero) == 0 {
 					entry.Value, _ = string2eth.StringToWei("0.01 ether")
 				}
-				fmt.Printf("Deed value is %s; release with 'ethereal ens release'\n", string2eth.WeiToString(entry.Value, true))
+				fmt.Printf("Deed value is %s; release with 'ethereal ens release'\n", formatWei(entry.Value))
 			}
 			genericInfo(ensDomain)
 		}
@@ -142,6 +146,75 @@ a malicious part could register the domain and change the resolution.`)
 
 // genericInfo prints generic info about any ENS domain.
 // It returns true if the domain exists, otherwise false
+// ensInfoJSON is the JSON output for ENS domain information.
+type ensInfoJSON struct {
+	Domain        string `json:"domain"`
+	NameHash      string `json:"name_hash"`
+	LabelHash     string `json:"label_hash"`
+	Registrant    string `json:"registrant,omitempty"`
+	Expiry        string `json:"expiry,omitempty"`
+	RentPerYear   string `json:"rent_per_year,omitempty"`
+	Controller    string `json:"controller,omitempty"`
+	Resolver      string `json:"resolver,omitempty"`
+	Address       string `json:"address,omitempty"`
+	ReverseDomain string `json:"reverse_domain,omitempty"`
+	Contenthash   string `json:"contenthash,omitempty"`
+}
+
+// newENSInfoJSON creates the JSON output for ENS domain information.  Information that
+// cannot be obtained is omitted.
+func newENSInfoJSON(name string, nameHash [32]byte, labelHash [32]byte) *ensInfoJSON {
+	res := &ensInfoJSON{
+		Domain:    name,
+		NameHash:  fmt.Sprintf("%#x", nameHash),
+		LabelHash: fmt.Sprintf("%#x", labelHash),
+	}
+
+	if ens.DomainLevel(name) == 1 && ens.Tld(name) == "eth" {
+		registrar, err := ens.NewBaseRegistrar(c.Client(), ens.Tld(name))
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain ENS registrar contract for %s", ens.Tld(name)))
+		domain, err := ens.DomainPart(name, 1)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain domain part for %s", name))
+		if registrant, err := registrar.Owner(domain); err == nil && registrant != ens.UnknownAddress {
+			res.Registrant = registrant.Hex()
+			if expiry, err := registrar.Expiry(domain); err == nil {
+				res.Expiry = time.Unix(int64(expiry.Uint64()), 0).UTC().Format(time.RFC3339)
+			}
+			if controller, err := ens.NewETHController(c.Client(), ens.Domain(name)); err == nil {
+				if rentPerSec, err := controller.RentCost(name); err == nil {
+					res.RentPerYear = new(big.Int).Mul(big.NewInt(31536000), rentPerSec).String()
+				}
+			}
+		}
+	}
+
+	registry, err := ens.NewRegistry(c.Client())
+	cli.ErrCheck(err, quiet, "Failed to obtain registry contract")
+	if controller, err := registry.Owner(name); err == nil && controller != ens.UnknownAddress {
+		res.Controller = controller.Hex()
+	}
+	resolverAddress, err := registry.ResolverAddress(name)
+	if err != nil || resolverAddress == ens.UnknownAddress {
+		return res
+	}
+	res.Resolver = resolverAddress.Hex()
+	if address, err := c.Resolve(name); err == nil && address != ens.UnknownAddress {
+		res.Address = address.Hex()
+		if reverseDomain, err := c.ReverseResolve(address); err == nil {
+			res.ReverseDomain = reverseDomain
+		}
+	}
+	if resolver, err := ens.NewResolverAt(c.Client(), name, resolverAddress); err == nil {
+		if bytes, err := resolver.Contenthash(); err == nil && len(bytes) > 0 {
+			if contenthash, err := ens.ContenthashToString(bytes); err == nil {
+				res.Contenthash = contenthash
+			}
+		}
+	}
+
+	return res
+}
+
 func genericInfo(name string) bool {
 	registry, err := ens.NewRegistry(c.Client())
 	cli.ErrCheck(err, quiet, "Failed to obtain registry contract")
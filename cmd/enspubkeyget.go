@@ -19,7 +19,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	ens "github.com/wealdtech/go-ens/v3"
 )
 
 // ensPubkeyGetCmd represents the pubkey get command
@@ -30,17 +29,24 @@ var ensPubkeyGetCmd = &cobra.Command{
 
     ethereal ens pubkey get --domain=enstest.eth
 
+Use --block to obtain the public key as of an earlier block.
+
 In quiet mode this will return 0 if the name has a public key, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
 
 		// Obtain resolver for the domain
-		resolver, err := ens.NewResolver(c.Client(), ensDomain)
+		opts := ensCallOpts()
+		resolver, node, err := ensResolverContract(opts, ensDomain)
 		cli.ErrCheck(err, quiet, "No resolver for that name")
 
-		x, y, err := resolver.PubKey()
+		pubkey, err := resolver.Pubkey(opts, node)
 		cli.ErrCheck(err, quiet, "Failed to obtain public key for that domain")
+		x, y := pubkey.X, pubkey.Y
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "x": fmt.Sprintf("0x%032x", x), "y": fmt.Sprintf("0x%032x", y)})
+		}
 		if !quiet {
 			fmt.Printf("(0x%032x,0x%032x)\n", x, y)
 		}
@@ -50,5 +56,6 @@ In quiet mode this will return 0 if the name has a public key, otherwise 1.`,
 
 func init() {
 	ensPubkeyFlags(ensPubkeyGetCmd)
+	ensBlockFlags(ensPubkeyGetCmd)
 	ensPubkeyCmd.AddCommand(ensPubkeyGetCmd)
 }
@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
@@ -35,6 +34,16 @@ import (
 
 var ensRegisterDomains string
 var ensRegisterOwnerStr string
+var ensRegisterResolverStr string
+var ensRegisterReverseRecord bool
+
+// ensRegistration contains the details of a single domain being registered.
+type ensRegistration struct {
+	domain string
+	label  string
+	secret [32]byte
+	value  *big.Int
+}
 
 // ensRegisterCmd represents the register command
 var ensRegisterCmd = &cobra.Command{
@@ -42,11 +51,17 @@ var ensRegisterCmd = &cobra.Command{
 	Short: "Register an ENS domain",
 	Long: `Register an Ethereum Name Service (ENS) domain.  For example:
 
-    ethereal ens register --domain=enstest.eth --passphrase="my secret passphrase"
+    ethereal ens register --domain=enstest.eth --owner=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --duration=1y --passphrase="my secret passphrase"
 
 Multiple domains can be registered with a single command (note this still creates one transaction for each name).  For example:
 
-    ethereal ens register --domains=mydomain1.eth&&mydomain2.eth --passphrase="my secret passphrase"
+    ethereal ens register --domains=mydomain1.eth&&mydomain2.eth --owner=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase="my secret passphrase"
+
+Registration is a two-stage process: a commitment to the registration is sent first, and the registration itself is sent once the commitment has aged past the controller's minimum commitment age.  This command carries out both stages, waiting between them.
+
+The price of the registration is quoted by the registrar controller before any transactions are sent.  The value sent with the registration is the quoted price plus 5% to cover price movements, with any excess refunded.  --value can be supplied to set the maximum price that will be paid for each domain.
+
+The domain uses the public resolver unless another resolver is supplied with --resolver.  If --reverse-record is supplied then the primary name of the owner is set to the domain.
 
 The keystore for the domain(s) owner must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
@@ -58,110 +73,133 @@ This will return an exit status of 0 if the transactions are successfully submit
 		cli.Assert(ensRegisterOwnerStr != "", quiet, "--owner is required")
 		owner, err := c.Resolve(ensRegisterOwnerStr)
 		cli.ErrCheck(err, quiet, "Failed to obtain new owner address")
-		cli.Assert(!bytes.Equal(owner.Bytes(), ens.UnknownAddress.Bytes()), quiet, "Unknown owner")
+		cli.Assert(owner != ens.UnknownAddress, quiet, "Unknown owner")
 
-		cli.Assert(viper.GetString("value") != "", quiet, "--value is required")
-		value, err := string2eth.StringToWei(viper.GetString("value"))
-		cli.ErrCheck(err, quiet, "Could not understand value")
+		var maxValue *big.Int
+		if viper.GetString("value") != "" {
+			maxValue, err = string2eth.StringToWei(viper.GetString("value"))
+			cli.ErrCheck(err, quiet, "Could not understand value")
+		}
 
-		var domains []string
+		resolverStr := ensRegisterResolverStr
+		if resolverStr == "" {
+			resolverStr = "resolver.eth"
+		}
+		resolver, err := c.Resolve(resolverStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain resolver address")
+		outputIf(verbose, fmt.Sprintf("Resolver is %s", formatAddress(resolver)))
+
+		var domainNames []string
 		if ensRegisterDomains != "" {
-			domains = strings.Split(ensRegisterDomains, "&&")
+			domainNames = strings.Split(ensRegisterDomains, "&&")
 		} else {
-			domains = make([]string, 1)
-			domains[0] = ensDomain
+			domainNames = []string{ensDomain}
 		}
 
-		controller, err := ens.NewETHController(c.Client(), ens.Domain(domains[0]))
-		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain %s controller", ens.Domain(domains[0])))
+		controller := ensRegistrarController()
 
-		tmp, err := controller.MinCommitmentInterval()
-		cli.ErrCheck(err, quiet, "Failed to find out minimum commitment interval")
-		// Interval is min commitment interval plus 2 minutes
-		interval := time.Duration(tmp.Int64()+120) * time.Second
-		minDuration, err := controller.MinRegistrationDuration()
+		duration := ensDuration()
+		durationSecs := big.NewInt(int64(duration.Seconds()))
+		minDuration, err := controller.MINREGISTRATIONDURATION(nil)
 		cli.ErrCheck(err, quiet, "Failed to obtain minimum registration duration")
+		cli.Assert(durationSecs.Cmp(minDuration) >= 0, quiet, fmt.Sprintf("Duration is less than the minimum registration duration of %v", time.Duration(minDuration.Int64())*time.Second))
+
+		minCommitmentAge, err := controller.MinCommitmentAge(nil)
+		cli.ErrCheck(err, quiet, "Failed to find out minimum commitment age")
+		// Interval is min commitment age plus 2 minutes
+		interval := time.Duration(minCommitmentAge.Int64()+120) * time.Second
 
 		// Check loop
-		for _, domain := range domains {
+		registrations := make([]*ensRegistration, 0, len(domainNames))
+		for _, domain := range domainNames {
 			domain, err = ens.NormaliseDomain(domain)
 			cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+			label := ensRegistrationLabel(domain)
 
-			valid, err := controller.IsValid(domain)
+			valid, err := controller.Valid(nil, label)
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to find out if %s is valid", domain))
 			cli.Assert(valid, quiet, fmt.Sprintf("%s is not valid", domain))
 
-			available, err := controller.IsAvailable(domain)
+			available, err := controller.Available(nil, label)
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to find out if %s is available", domain))
 			cli.Assert(available, quiet, fmt.Sprintf("%s is not available", domain))
 
-			costPerSecond, err := controller.RentCost(domain)
-			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain rental cost for %s", domain))
-			duration := new(big.Int).Div(value, costPerSecond)
-			// Ensure duration is greater than minimum duration
-			cli.Assert(big.NewInt(int64(minDuration.Seconds())).Cmp(duration) <= 0, quiet, fmt.Sprintf("Not enough funds to cover minimum duration of %v for %s", minDuration, domain))
-			outputIf(verbose, fmt.Sprintf("%s will be registered until approximately %v", domain, time.Now().Add(time.Duration(duration.Int64())*time.Second).Format("2006-01-02 15:04")))
+			price, err := controller.RentPrice(nil, label, durationSecs)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain price for %s", domain))
+			value := util.RegistrationValue(price)
+			if price.Premium.Sign() > 0 {
+				outputIf(!quiet, fmt.Sprintf("%s costs %s (including premium of %s) to register until approximately %s", domain, formatWei(new(big.Int).Add(price.Base, price.Premium)), formatWei(price.Premium), time.Now().Add(duration).Format("2006-01-02 15:04")))
+			} else {
+				outputIf(!quiet, fmt.Sprintf("%s costs %s to register until approximately %s", domain, formatWei(price.Base), time.Now().Add(duration).Format("2006-01-02 15:04")))
+			}
+			if maxValue != nil {
+				cli.Assert(value.Cmp(maxValue) <= 0, quiet, fmt.Sprintf("Price of %s is more than the supplied value of %s", domain, formatWei(maxValue)))
+			}
+
+			registrations = append(registrations, &ensRegistration{
+				domain: domain,
+				label:  label,
+				value:  value,
+			})
 		}
 
 		// Commit loop
-		secrets := make(map[string][32]byte)
 		var lastTx *types.Transaction
-		for _, domain := range domains {
-			domain, err = ens.NormaliseDomain(domain)
-			cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
-
-			var secret [32]byte
-			_, err = rand.Read(secret[:])
+		for _, registration := range registrations {
+			_, err = rand.Read(registration.secret[:])
 			cli.ErrCheck(err, quiet, "failed to generate secret")
-			secrets[domain] = secret
 
-			opts, err := generateTxOpts(owner)
-			cli.ErrCheck(err, quiet, "failed to generate transaction options")
+			commitment, err := controller.MakeCommitment(nil, registration.label, owner, durationSecs, registration.secret, resolver, [][]byte{}, ensRegisterReverseRecord, 0)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to create commitment for %s", registration.domain))
 
+			opts, err := generateTxOpts(owner)
+			cli.ErrCheck(err, quiet, "failed to generate commit transaction options")
 			// Commitments have no value
 			opts.Value = nil
-			cli.ErrCheck(err, quiet, "failed to generate commit transaction options")
-			lastTx, err = controller.Commit(opts, domain, owner, secrets[domain])
-			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to submit commit transaction for %s", domain))
+			lastTx, err = controller.Commit(opts, commitment)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to submit commit transaction for %s", registration.domain))
 			logTransaction(lastTx, log.Fields{
 				"group":     "ens",
 				"command":   "register",
 				"stage":     "commit",
-				"ensdomain": domain,
+				"ensdomain": registration.domain,
 				"ensowner":  owner.Hex(),
-				"secret":    hex.EncodeToString(secret[:]),
+				"secret":    hex.EncodeToString(registration.secret[:]),
 			})
-			outputIf(verbose, fmt.Sprintf("Commit transaction %x submitted for %s", lastTx.Hash(), domain))
+			outputIf(verbose, fmt.Sprintf("Commit transaction %x submitted for %s", lastTx.Hash(), registration.domain))
 			_, err = c.NextNonce(context.Background(), owner)
 			cli.ErrCheck(err, quiet, "failed to increment nonce")
 		}
 
+		if dryRun {
+			// The registrations cannot be simulated until the commitments have been mined.
+			handleSubmittedTransaction(lastTx, nil, true)
+		}
+
 		// Wait
 		outputIf(!quiet, "Waiting for commit transaction(s) to be mined")
-		mined := util.WaitForTransaction(c.Client(), lastTx.Hash(), 0)
-		cli.Assert(mined, quiet, "Failed to mine commit transaction(s)")
-		outputIf(!quiet, fmt.Sprintf("Waiting for commit/reveal interval to pass (done at %s)", time.Now().Add(interval).Format("15:04:05")))
+		_, err = c.WaitForTransaction(context.Background(), lastTx.Hash(), 1, 0)
+		cli.ErrCheck(err, quiet, "Failed to mine commit transaction(s)")
+		outputIf(!quiet, fmt.Sprintf("Waiting for commitment age to pass (done at %s)", time.Now().Add(interval).Format("15:04:05")))
 		time.Sleep(interval)
 
-		// Reveal loop
-		for _, domain := range domains {
-			domain, err = ens.NormaliseDomain(domain)
-			cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
-
+		// Register loop
+		for _, registration := range registrations {
 			opts, err := generateTxOpts(owner)
-			cli.ErrCheck(err, quiet, "failed to generate reveal transaction options")
-			lastTx, err = controller.Reveal(opts, domain, owner, secrets[domain])
-			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to submit reveal transaction for %s", domain))
-			secret := secrets[domain]
+			cli.ErrCheck(err, quiet, "failed to generate register transaction options")
+			opts.Value = registration.value
+			lastTx, err = controller.Register(opts, registration.label, owner, durationSecs, registration.secret, resolver, [][]byte{}, ensRegisterReverseRecord, 0)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to submit register transaction for %s", registration.domain))
 			logTransaction(lastTx, log.Fields{
 				"group":     "ens",
 				"command":   "register",
-				"stage":     "reveal",
-				"ensdomain": domain,
+				"stage":     "register",
+				"ensdomain": registration.domain,
 				"ensowner":  owner.Hex(),
-				"secret":    hex.EncodeToString(secret[:]),
+				"duration":  duration.String(),
+				"secret":    hex.EncodeToString(registration.secret[:]),
 			})
-			outputIf(verbose, fmt.Sprintf("Reveal transaction %x submitted for %s", lastTx.Hash(), domain))
+			outputIf(verbose, fmt.Sprintf("Register transaction %x submitted for %s", lastTx.Hash(), registration.domain))
 			_, err = c.NextNonce(context.Background(), owner)
 			cli.ErrCheck(err, quiet, "failed to increment nonce")
 		}
@@ -172,7 +210,10 @@ This will return an exit status of 0 if the transactions are successfully submit
 func init() {
 	ensCmd.AddCommand(ensRegisterCmd)
 	ensFlags(ensRegisterCmd)
+	ensRegistrationFlags(ensRegisterCmd)
 	ensRegisterCmd.Flags().StringVar(&ensRegisterDomains, "domains", "", "multiple ENS domains to register at the same time; separate with \"&&\" e.g. --domains='mydomain1.eth&&mydomain2.eth'")
 	ensRegisterCmd.Flags().StringVar(&ensRegisterOwnerStr, "owner", "", "The owner's name or address")
+	ensRegisterCmd.Flags().StringVar(&ensRegisterResolverStr, "resolver", "", "The name or address of the resolver for the domain (default the public resolver)")
+	ensRegisterCmd.Flags().BoolVar(&ensRegisterReverseRecord, "reverse-record", false, "Set the primary name of the owner to the domain")
 	addTransactionFlags(ensRegisterCmd, "passphrase for the account that owns the domain")
 }
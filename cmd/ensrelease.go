@@ -69,7 +69,7 @@ This will return an exit status of 0 if the transactions are successfully submit
 			owner, err := auctionRegistrar.Owner(domain)
 			cli.ErrCheck(err, quiet, "Failed to obtain domain owner")
 
-			outputIf(verbose, fmt.Sprintf("Domain %s owner is %s", domain, ens.Format(c.Client(), owner)))
+			outputIf(verbose, fmt.Sprintf("Domain %s owner is %s", domain, formatAddress(owner)))
 
 			opts, err := generateTxOpts(owner)
 			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	ens "github.com/wealdtech/go-ens/v3"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var ensRenewDomains string
+var ensRenewFromStr string
+
+// ensRenewCmd represents the ens renew command
+var ensRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "Renew the registration of an ENS domain",
+	Long: `Renew the registration of an Ethereum Name Service (ENS) domain for a given duration.  For example:
+
+    ethereal ens renew --domain=enstest.eth --duration=1y --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase="my secret passphrase"
+
+Multiple domains can be renewed with a single command (note this still creates one transaction for each name).  For example:
+
+    ethereal ens renew --domains="mydomain1.eth&&mydomain2.eth" --duration=1y --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase="my secret passphrase"
+
+The price of the renewal is quoted by the registrar controller before any transactions are sent.  The value sent with the renewal is the quoted price plus 5% to cover price movements, with any excess refunded.  --value can be supplied to set the maximum price that will be paid for each domain.
+
+Any account can renew a domain.  If --from is not supplied the renewal is sent from the owner of the domain.
+
+The keystore for the account sending the renewal must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
+
+This will return an exit status of 0 if the transactions are successfully submitted (and mined if --wait is supplied), 1 if the transactions are not successfully submitted, and 2 if the transactions are successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+		cli.Assert(ensDomain != "" || ensRenewDomains != "", quiet, "--domain or --domains is required")
+
+		var domains []string
+		if ensRenewDomains != "" {
+			domains = strings.Split(ensRenewDomains, "&&")
+		} else {
+			domains = []string{ensDomain}
+		}
+
+		var maxValue *big.Int
+		var err error
+		if viper.GetString("value") != "" {
+			maxValue, err = string2eth.StringToWei(viper.GetString("value"))
+			cli.ErrCheck(err, quiet, "Could not understand value")
+		}
+
+		registry, err := ens.NewRegistry(c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain ENS registry")
+		registrar, err := ens.NewBaseRegistrar(c.Client(), "eth")
+		cli.ErrCheck(err, quiet, "Failed to obtain eth registrar")
+		controller := ensRegistrarController()
+
+		duration := ensDuration()
+		durationSecs := big.NewInt(int64(duration.Seconds()))
+
+		// Renew loop
+		var lastTx *types.Transaction
+		for _, domain := range domains {
+			domain, err = ens.NormaliseDomain(domain)
+			cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+			label := ensRegistrationLabel(domain)
+
+			// Ensure the domain is registered
+			expiryTS, err := registrar.Expiry(domain)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain expiry for %s", domain))
+			cli.Assert(expiryTS.Sign() > 0, quiet, fmt.Sprintf("%s is not registered", domain))
+			expiry := time.Unix(expiryTS.Int64(), 0)
+			outputIf(verbose, fmt.Sprintf("%s expires at %s", domain, expiry.Format("2006-01-02 15:04")))
+
+			price, err := controller.RentPrice(nil, label, durationSecs)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain price for %s", domain))
+			value := util.RegistrationValue(price)
+			outputIf(!quiet, fmt.Sprintf("%s costs %s to renew until approximately %s", domain, formatWei(new(big.Int).Add(price.Base, price.Premium)), expiry.Add(duration).Format("2006-01-02 15:04")))
+			if maxValue != nil {
+				cli.Assert(value.Cmp(maxValue) <= 0, quiet, fmt.Sprintf("Price of %s is more than the supplied value of %s", domain, formatWei(maxValue)))
+			}
+
+			fromStr := ensRenewFromStr
+			if fromStr == "" {
+				owner, err := registry.Owner(domain)
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain owner for %s", domain))
+				fromStr = owner.Hex()
+			}
+			from, err := c.Resolve(fromStr)
+			cli.ErrCheck(err, quiet, "Failed to obtain address from which to renew")
+
+			opts, err := generateTxOpts(from)
+			cli.ErrCheck(err, quiet, "failed to generate transaction options")
+			opts.Value = value
+			lastTx, err = controller.Renew(opts, label, durationSecs)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to submit renew transaction for %s", domain))
+			logTransaction(lastTx, log.Fields{
+				"group":     "ens",
+				"command":   "renew",
+				"ensdomain": domain,
+				"duration":  duration.String(),
+				"expiry":    expiry.Add(duration).Format("2006-01-02 15:04"),
+			})
+			_, err = c.NextNonce(context.Background(), from)
+			cli.ErrCheck(err, quiet, "failed to increment nonce")
+		}
+		handleSubmittedTransaction(lastTx, nil, true)
+	},
+}
+
+func init() {
+	ensCmd.AddCommand(ensRenewCmd)
+	ensFlags(ensRenewCmd)
+	ensRegistrationFlags(ensRenewCmd)
+	ensRenewCmd.Flags().StringVar(&ensRenewDomains, "domains", "", "multiple ENS domains to renew at the same time; separate with \"&&\" e.g. --domains='mydomain1.eth&&mydomain2.eth'")
+	ensRenewCmd.Flags().StringVar(&ensRenewFromStr, "from", "", "The name or address of the account from which to renew (default the owner of the domain)")
+	addTransactionFlags(ensRenewCmd, "passphrase for the account from which to renew")
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+var ensResolveCoinTypeStr string
+
+// ensResolveCmd represents the ens resolve command
+var ensResolveCmd = &cobra.Command{
+	Use:   "resolve <name>",
+	Short: "Resolve an ENS name to an address",
+	Long: `Resolve a name registered with the Ethereum Name Service (ENS) to an address.  For example:
+
+    ethereal ens resolve enstest.eth
+
+Addresses for other coins can be obtained with --cointype, for example --cointype=btc.  Addresses for Bitcoin, Litecoin, Dogecoin and EVM chains are shown in their native format, and addresses for other coins as hex.
+
+Resolution uses the ENS universal resolver where it is available, which supports wildcard and off-chain names, and otherwise the resolver of the name directly.
+
+In quiet mode this will return 0 if the name has an address, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		coinType, err := util.CoinType(ensResolveCoinTypeStr)
+		cli.ErrCheck(err, quiet, "Invalid coin type")
+
+		var data []byte
+		if universalResolver, exists := ensUniversalResolver(); exists {
+			outputIf(debug, fmt.Sprintf("Resolving through universal resolver %s", universalResolver.Hex()))
+			ctx, cancel := localContext()
+			defer cancel()
+			data, err = util.UniversalResolveAddress(ctx, c.Client(), universalResolver, name, coinType)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve %s", name))
+		} else {
+			resolver, err := ens.NewResolver(c.Client(), name)
+			cli.ErrCheck(err, quiet, "Failed to obtain resolver")
+			data, err = resolver.MultiAddress(coinType)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve %s", name))
+		}
+		if len(data) == 0 {
+			outputIf(verbose, "No address")
+			os.Exit(exitFailure)
+		}
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		address, err := util.DecodeCoinAddress(coinType, data)
+		cli.ErrCheck(err, quiet, "Failed to decode address")
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"name":      name,
+				"coin_type": coinType,
+				"address":   address,
+			})
+		}
+
+		fmt.Println(address)
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	ensCmd.AddCommand(ensResolveCmd)
+	ensResolveCmd.Flags().StringVar(&ensResolveCoinTypeStr, "cointype", "eth", "The coin of the address, either as a symbol (btc, ltc, doge, eth, etc) or a SLIP-44 coin type")
+	ensUniversalResolverFlags(ensResolveCmd)
+}
@@ -30,17 +30,24 @@ var ensResolverGetCmd = &cobra.Command{
 
     ethereal ens resolver get --domain=enstest.eth
 
+Use --block to obtain the resolver as of an earlier block.
+
 In quiet mode this will return 0 if the name has a resolver, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
 
+		node, err := ens.NameHash(ensDomain)
+		cli.ErrCheck(err, quiet, "Failed to obtain name hash")
 		registry, err := ens.NewRegistry(c.Client())
 		cli.ErrCheck(err, quiet, "Failed to obtain registry contract")
-		resolver, err := registry.ResolverAddress(ensDomain)
+		resolver, err := registry.Contract.Resolver(ensCallOpts(), node)
 		cli.ErrCheck(err, quiet, "No resolver for that name")
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "resolver": resolver.Hex()})
+		}
 		if !quiet {
-			fmt.Printf("%s\n", ens.Format(c.Client(), resolver))
+			fmt.Printf("%s\n", formatAddress(resolver))
 		}
 		os.Exit(exitSuccess)
 	},
@@ -48,5 +55,6 @@ In quiet mode this will return 0 if the name has a resolver, otherwise 1.`,
 
 func init() {
 	ensResolverFlags(ensResolverGetCmd)
+	ensBlockFlags(ensResolverGetCmd)
 	ensResolverCmd.AddCommand(ensResolverGetCmd)
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
@@ -34,7 +35,7 @@ var ensResolverSetCmd = &cobra.Command{
 
     ethereal ens resolver set --domain=enstest.eth --resolver=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase="my secret passphrase"
 
-If the resolver is not supplied then the public resolver for the network will be used.
+If the resolver is not supplied then the public resolver for the network will be used.  If the domain is wrapped then the resolver is set through the name wrapper by the owner of the wrapped domain.
 
 The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
@@ -64,10 +65,24 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid name/address %s", ensAddressSetAddressStr))
 		}
 
-		opts, err := generateTxOpts(owner)
-		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
-		signedTx, err := registry.SetResolver(opts, ensDomain, resolverAddress)
-		cli.ErrCheck(err, quiet, "Failed to send transaction")
+		var signedTx *types.Transaction
+		if ensIsWrapper(owner) {
+			// Wrapped names have their resolver set through the name wrapper.
+			wrapper, _ := ensNameWrapper()
+			owner = ensWrappedOwner(wrapper, ensDomain)
+			outputIf(debug, fmt.Sprintf("Wrapped owner of %s is %#x", ensDomain, owner))
+			node, err := ens.NameHash(ensDomain)
+			cli.ErrCheck(err, quiet, "Failed to obtain name hash")
+			opts, err := generateTxOpts(owner)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = wrapper.SetResolver(opts, node, resolverAddress)
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		} else {
+			opts, err := generateTxOpts(owner)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = registry.SetResolver(opts, ensDomain, resolverAddress)
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		}
 
 		handleSubmittedTransaction(signedTx, log.Fields{
 			"group":       "ens/resolver",
@@ -82,5 +97,6 @@ func init() {
 	ensResolverCmd.AddCommand(ensResolverSetCmd)
 	ensResolverFlags(ensResolverSetCmd)
 	ensResolverSetCmd.Flags().StringVar(&ensResolverSetResolverStr, "resolver", "", "The resolver's name or address")
+	ensNameWrapperFlags(ensResolverSetCmd)
 	addTransactionFlags(ensResolverSetCmd, "passphrase for the account that owns the domain")
 }
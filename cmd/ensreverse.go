@@ -0,0 +1,85 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// ensReverseCmd represents the ens reverse command
+var ensReverseCmd = &cobra.Command{
+	Use:   "reverse <address>",
+	Short: "Obtain the primary ENS name of an address",
+	Long: `Obtain the primary Ethereum Name Service (ENS) name of an address.  For example:
+
+    ethereal ens reverse 0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+The name is only returned if it resolves back to the address.  Reverse resolution uses the ENS universal resolver where it is available, and otherwise the reverse registrar directly.
+
+In quiet mode this will return 0 if the address has a primary name, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		address, err := c.Resolve(args[0])
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", args[0]))
+
+		var name string
+		if universalResolver, exists := ensUniversalResolver(); exists {
+			outputIf(debug, fmt.Sprintf("Reverse resolving through universal resolver %s", universalResolver.Hex()))
+			ctx, cancel := localContext()
+			defer cancel()
+			name, err = util.UniversalReverseResolve(ctx, c.Client(), universalResolver, address)
+			cli.ErrCheck(err, quiet, "Failed to obtain reverse resolution")
+		} else {
+			name, err = c.ReverseResolve(address)
+			if err != nil && err.Error() != "no resolution" {
+				cli.ErrCheck(err, quiet, "Failed to obtain reverse resolution")
+			}
+			if name != "" {
+				// Confirm that the name resolves back to the address.
+				resolved, err := c.Resolve(name)
+				if err != nil || resolved != address {
+					outputIf(verbose, fmt.Sprintf("%s does not resolve to %s", name, address.Hex()))
+					name = ""
+				}
+			}
+		}
+		if name == "" {
+			outputIf(verbose, "No primary name")
+			os.Exit(exitFailure)
+		}
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"address": address.Hex(),
+				"name":    name,
+			})
+		}
+
+		fmt.Println(name)
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	ensCmd.AddCommand(ensReverseCmd)
+	ensUniversalResolverFlags(ensReverseCmd)
+}
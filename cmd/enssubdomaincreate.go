@@ -16,17 +16,21 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 	ens "github.com/wealdtech/go-ens/v3"
 )
 
 var ensSubdomainCreateSubdomain string
 var ensSubdomainCreateOwnerStr string
+var ensSubdomainCreateFuses string
 
 // ensSubdomainCreateCmd represents the ens subdomain create command
 var ensSubdomainCreateCmd = &cobra.Command{
@@ -36,6 +40,8 @@ var ensSubdomainCreateCmd = &cobra.Command{
 
     ethereal ens subdomain create --domain=enstest.eth --subdomain=sub --passphrase="my secret passphrase"
 
+If the domain is wrapped then the subdomain is created in the name wrapper, in which case fuses can be burned on the subdomain with --fuses.  The subdomain expires at the same time as the domain.
+
 The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
 
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
@@ -66,11 +72,32 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		}
 		outputIf(debug, fmt.Sprintf("Controller of subdomain will be %s", subdomainOwner.Hex()))
 
-		// Create the subdomain
-		opts, err := generateTxOpts(controller)
-		cli.ErrCheck(err, quiet, "failed to generate transaction options")
-		signedTx, err := registry.SetSubdomainOwner(opts, ensDomain, ensSubdomainCreateSubdomain, subdomainOwner)
-		cli.ErrCheck(err, quiet, "failed to broadcast transaction")
+		fuses, err := util.ParseFuses(ensSubdomainCreateFuses)
+		cli.ErrCheck(err, quiet, "Invalid fuses")
+
+		var signedTx *types.Transaction
+		if ensIsWrapper(controller) {
+			// Create the subdomain through the name wrapper.
+			wrapper, _ := ensNameWrapper()
+			parentNode, err := ens.NameHash(ensDomain)
+			cli.ErrCheck(err, quiet, "failed to obtain name hash")
+			data, err := wrapper.GetData(nil, new(big.Int).SetBytes(parentNode[:]))
+			cli.ErrCheck(err, quiet, "failed to obtain wrapped data")
+			outputIf(debug, fmt.Sprintf("Wrapped owner is %s", data.Owner.Hex()))
+			if ensSubdomainCreateOwnerStr == "" {
+				subdomainOwner = data.Owner
+			}
+			opts, err := generateTxOpts(data.Owner)
+			cli.ErrCheck(err, quiet, "failed to generate transaction options")
+			signedTx, err = wrapper.SetSubnodeOwner(opts, parentNode, ensSubdomainCreateSubdomain, subdomainOwner, fuses, data.Expiry)
+			cli.ErrCheck(err, quiet, "failed to broadcast transaction")
+		} else {
+			cli.Assert(fuses == 0, quiet, "fuses can only be burned on subdomains of wrapped domains")
+			opts, err := generateTxOpts(controller)
+			cli.ErrCheck(err, quiet, "failed to generate transaction options")
+			signedTx, err = registry.SetSubdomainOwner(opts, ensDomain, ensSubdomainCreateSubdomain, subdomainOwner)
+			cli.ErrCheck(err, quiet, "failed to broadcast transaction")
+		}
 
 		handleSubmittedTransaction(signedTx, log.Fields{
 			"group":             "ens/subdomain",
@@ -87,5 +114,7 @@ func init() {
 	ensSubdomainFlags(ensSubdomainCreateCmd)
 	ensSubdomainCreateCmd.Flags().StringVar(&ensSubdomainCreateSubdomain, "subdomain", "", "The name of the subdomain")
 	ensSubdomainCreateCmd.Flags().StringVar(&ensSubdomainCreateOwnerStr, "owner", "", "The owner of the subdomain (defaults to the owner of the domain)")
+	ensSubdomainCreateCmd.Flags().StringVar(&ensSubdomainCreateFuses, "fuses", "", "Comma-separated list of fuses to burn on the subdomain of a wrapped domain")
+	ensNameWrapperFlags(ensSubdomainCreateCmd)
 	addTransactionFlags(ensSubdomainCreateCmd, "passphrase for the account that owns the domain")
 }
@@ -19,7 +19,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	ens "github.com/wealdtech/go-ens/v3"
 )
 
 // ensTextGetCmd represents the text get command
@@ -30,18 +29,24 @@ var ensTextGetCmd = &cobra.Command{
 
     ethereal ens text get --domain=enstest.eth --enskey="My key"
 
+Use --block to obtain the text as of an earlier block.
+
 In quiet mode this will return 0 if the key has text, otherwise 1.`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(ensDomain != "", quiet, "--domain is required")
 
 		// Obtain resolver for the domain
-		resolver, err := ens.NewResolver(c.Client(), ensDomain)
+		opts := ensCallOpts()
+		resolver, node, err := ensResolverContract(opts, ensDomain)
 		cli.ErrCheck(err, quiet, "No resolver for that name")
 
-		value, err := resolver.Text(ensTextKey)
+		value, err := resolver.Text(opts, node, ensTextKey)
 		cli.ErrCheck(err, quiet, "Failed to obtain value for that domain")
 		cli.Assert(len(value) > 0, quiet, "No value for that domain")
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"domain": ensDomain, "key": ensTextKey, "value": value})
+		}
 		if !quiet {
 			fmt.Printf("%s\n", value)
 		}
@@ -51,5 +56,6 @@ In quiet mode this will return 0 if the key has text, otherwise 1.`,
 
 func init() {
 	ensTextFlags(ensTextGetCmd)
+	ensBlockFlags(ensTextGetCmd)
 	ensTextCmd.AddCommand(ensTextGetCmd)
 }
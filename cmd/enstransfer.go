@@ -74,7 +74,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		}
 		cli.Assert(registrant != ens.UnknownAddress, quiet, "Failed to obtain registrant")
 
-		outputIf(verbose, fmt.Sprintf("Current registrant is %s", ens.Format(c.Client(), registrant)))
+		outputIf(verbose, fmt.Sprintf("Current registrant is %s", formatAddress(registrant)))
 
 		// Transfer the registration
 		newRegistrantAddress, err := c.Resolve(ensTransferNewRegistrantStr)
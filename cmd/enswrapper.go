@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+var ensNameWrapperStr string
+
+// ensWrapperCmd represents the ens wrapper command
+var ensWrapperCmd = &cobra.Command{
+	Use:   "wrapper",
+	Short: "Manage wrapped ENS domains",
+	Long:  `Wrap and unwrap Ethereum Name Service domains with the name wrapper, and manage their fuses`,
+}
+
+func init() {
+	ensCmd.AddCommand(ensWrapperCmd)
+}
+
+func ensWrapperFlags(cmd *cobra.Command) {
+	ensFlags(cmd)
+	ensNameWrapperFlags(cmd)
+}
+
+func ensNameWrapperFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ensNameWrapperStr, "name-wrapper", "", "Address of the ENS name wrapper (defaults to the name wrapper for the network)")
+}
+
+// ensNameWrapperAddress returns the address of the name wrapper, and false if there is no
+// known name wrapper for the current chain.
+func ensNameWrapperAddress() (common.Address, bool) {
+	addressStr := ensNameWrapperStr
+	if addressStr == "" {
+		addressStr = viper.GetString("ens-name-wrapper")
+	}
+	if addressStr != "" {
+		address, err := c.Resolve(addressStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain name wrapper address")
+		return address, true
+	}
+	address, exists := util.NameWrapperAddresses[c.ChainID().Uint64()]
+	return address, exists
+}
+
+// ensNameWrapper returns the name wrapper.
+func ensNameWrapper() (*contracts.NameWrapper, common.Address) {
+	address, exists := ensNameWrapperAddress()
+	cli.Assert(exists, quiet, fmt.Sprintf("No name wrapper known for chain %v; supply one with --name-wrapper", c.ChainID()))
+	outputIf(debug, fmt.Sprintf("Name wrapper is %s", address.Hex()))
+	wrapper, err := contracts.NewNameWrapper(address, c.Client())
+	cli.ErrCheck(err, quiet, "Failed to obtain name wrapper")
+	return wrapper, address
+}
+
+// ensIsWrapper returns true if the given address is the name wrapper.
+func ensIsWrapper(address common.Address) bool {
+	wrapperAddress, exists := ensNameWrapperAddress()
+	return exists && address == wrapperAddress
+}
+
+// ensWrappedOwner returns the owner of a wrapped domain.
+func ensWrappedOwner(wrapper *contracts.NameWrapper, domain string) common.Address {
+	node, err := ens.NameHash(domain)
+	cli.ErrCheck(err, quiet, "Failed to obtain name hash")
+	owner, err := wrapper.OwnerOf(nil, new(big.Int).SetBytes(node[:]))
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain wrapped owner of %s", domain))
+	cli.Assert(owner != ens.UnknownAddress, quiet, fmt.Sprintf("%s is not wrapped", domain))
+	return owner
+}
+
+// ensIsETH2LD returns true if the domain is a second-level .eth domain.
+func ensIsETH2LD(domain string) bool {
+	return ens.DomainLevel(domain) == 1 && ens.Tld(domain) == "eth"
+}
+
+// ensLabel returns the first label of a domain.
+func ensLabel(domain string) string {
+	return strings.Split(domain, ".")[0]
+}
+
+// ensApproveWrapper ensures that the name wrapper is approved to transfer names owned by
+// the owner in the given contract, which is either the ENS registry or the .eth registrar.
+// If approval is required the approval transaction is sent and mined before returning.
+func ensApproveWrapper(contract common.Address, owner common.Address, wrapperAddress common.Address) {
+	// Both the registry and the registrar use the ERC-721 approval functions.
+	approvals, err := contracts.NewERC721(contract, c.Client())
+	cli.ErrCheck(err, quiet, "Failed to obtain approval contract")
+	approved, err := approvals.IsApprovedForAll(nil, owner, wrapperAddress)
+	cli.ErrCheck(err, quiet, "Failed to find out if name wrapper is approved")
+	if approved {
+		return
+	}
+
+	opts, err := generateTxOpts(owner)
+	cli.ErrCheck(err, quiet, "Failed to generate approval transaction options")
+	opts.Value = nil
+	tx, err := approvals.SetApprovalForAll(opts, wrapperAddress, true)
+	cli.ErrCheck(err, quiet, "Failed to send approval transaction")
+	if dryRun {
+		// The approval was simulated, so there is nothing to wait for.
+		return
+	}
+	logTransaction(tx, log.Fields{
+		"group":    "ens/wrapper",
+		"command":  "approve",
+		"contract": contract.Hex(),
+		"operator": wrapperAddress.Hex(),
+	})
+	outputIf(!quiet, fmt.Sprintf("Waiting for transaction %#x approving the name wrapper to be mined", tx.Hash()))
+	_, err = c.WaitForTransaction(context.Background(), tx.Hash(), 1, 0)
+	cli.ErrCheck(err, quiet, "Failed to mine approval transaction")
+	_, err = c.NextNonce(context.Background(), owner)
+	cli.ErrCheck(err, quiet, "failed to increment nonce")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+var ensWrapperBurnFuses string
+
+// ensWrapperBurnCmd represents the ens wrapper burn command
+var ensWrapperBurnCmd = &cobra.Command{
+	Use:     "burn",
+	Aliases: []string{"set-fuses"},
+	Short:   "Burn fuses of a wrapped ENS domain",
+	Long: `Burn fuses of a domain wrapped by the Ethereum Name Service (ENS) name wrapper.  For example:
+
+    ethereal ens wrapper burn --domain=enstest.eth --fuses=CANNOT_UNWRAP,CANNOT_TRANSFER --passphrase="my secret passphrase"
+
+Fuses are supplied as a comma-separated list of names, for example CANNOT_UNWRAP, CANNOT_BURN_FUSES, CANNOT_TRANSFER, CANNOT_SET_RESOLVER, CANNOT_SET_TTL, CANNOT_CREATE_SUBDOMAIN, CANNOT_APPROVE, PARENT_CANNOT_CONTROL and CAN_EXTEND_EXPIRY.  Burned fuses cannot be restored until the name expires.
+
+Owner-controlled fuses are burned by the owner of the domain.  If parent-controlled fuses such as PARENT_CANNOT_CONTROL are included then all of the fuses are burned by the owner of the parent domain instead.
+
+The keystore for the account that burns the fuses must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		domain, err := ens.NormaliseDomain(ensDomain)
+		cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+
+		cli.Assert(ensWrapperBurnFuses != "", quiet, "--fuses is required")
+		fuses, err := util.ParseFuses(ensWrapperBurnFuses)
+		cli.ErrCheck(err, quiet, "Invalid fuses")
+		cli.Assert(fuses != 0, quiet, "No fuses to burn")
+		outputIf(verbose, fmt.Sprintf("Burning %s", strings.Join(util.FuseNames(fuses), ", ")))
+
+		wrapper, _ := ensNameWrapper()
+		node, err := ens.NameHash(domain)
+		cli.ErrCheck(err, quiet, "Failed to obtain name hash")
+
+		var signedTx *types.Transaction
+		if fuses&^util.OwnerControlledFuses == 0 {
+			owner := ensWrappedOwner(wrapper, domain)
+			opts, err := generateTxOpts(owner)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = wrapper.SetFuses(opts, node, uint16(fuses))
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		} else {
+			cli.Assert(!ensIsETH2LD(domain), quiet, "Parent-controlled fuses of second-level .eth domains are set by the registrar")
+			parent := ens.Domain(domain)
+			parentOwner := ensWrappedOwner(wrapper, parent)
+			parentNode, err := ens.NameHash(parent)
+			cli.ErrCheck(err, quiet, "Failed to obtain name hash of parent")
+			labelHash, err := ens.LabelHash(ensLabel(domain))
+			cli.ErrCheck(err, quiet, "Failed to obtain label hash")
+			// Keep the current expiry of the domain.
+			data, err := wrapper.GetData(nil, new(big.Int).SetBytes(node[:]))
+			cli.ErrCheck(err, quiet, "Failed to obtain wrapped data")
+			opts, err := generateTxOpts(parentOwner)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err = wrapper.SetChildFuses(opts, parentNode, labelHash, fuses, data.Expiry)
+			cli.ErrCheck(err, quiet, "Failed to send transaction")
+		}
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":     "ens/wrapper",
+			"command":   "burn",
+			"ensdomain": domain,
+			"fuses":     fuses,
+		}, true)
+	},
+}
+
+func init() {
+	ensWrapperCmd.AddCommand(ensWrapperBurnCmd)
+	ensWrapperFlags(ensWrapperBurnCmd)
+	ensWrapperBurnCmd.Flags().StringVar(&ensWrapperBurnFuses, "fuses", "", "Comma-separated list of fuses to burn")
+	addTransactionFlags(ensWrapperBurnCmd, "passphrase for the account that burns the fuses")
+}
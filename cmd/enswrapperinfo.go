@@ -0,0 +1,108 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+type ensWrapperInfoJSON struct {
+	Domain  string   `json:"domain"`
+	Wrapped bool     `json:"wrapped"`
+	Owner   string   `json:"owner,omitempty"`
+	Fuses   uint32   `json:"fuses"`
+	Burned  []string `json:"burned_fuses"`
+	Expiry  int64    `json:"expiry,omitempty"`
+}
+
+// ensWrapperInfoCmd represents the ens wrapper info command
+var ensWrapperInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain the wrapped state of an ENS domain",
+	Long: `Obtain the state of an Ethereum Name Service (ENS) domain in the name wrapper, including its owner, burned fuses and expiry.  For example:
+
+    ethereal ens wrapper info --domain=enstest.eth
+
+Use --block to obtain the state as of an earlier block.
+
+In quiet mode this will return 0 if the domain is wrapped, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		domain, err := ens.NormaliseDomain(ensDomain)
+		cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+
+		wrapper, _ := ensNameWrapper()
+		node, err := ens.NameHash(domain)
+		cli.ErrCheck(err, quiet, "Failed to obtain name hash")
+		data, err := wrapper.GetData(ensCallOpts(), new(big.Int).SetBytes(node[:]))
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain wrapped data for %s", domain))
+
+		wrapped := data.Owner != ens.UnknownAddress
+		if quiet {
+			if wrapped {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			res := &ensWrapperInfoJSON{
+				Domain:  domain,
+				Wrapped: wrapped,
+				Fuses:   data.Fuses,
+				Burned:  util.FuseNames(data.Fuses),
+			}
+			if wrapped {
+				res.Owner = data.Owner.Hex()
+				res.Expiry = int64(data.Expiry)
+			}
+			outputJSON(res)
+		}
+
+		if !wrapped {
+			fmt.Printf("%s is not wrapped\n", domain)
+			os.Exit(exitFailure)
+		}
+		fmt.Printf("Owner is %s\n", formatAddress(data.Owner))
+		if data.Fuses == 0 {
+			fmt.Println("No fuses burned")
+		} else {
+			fmt.Printf("Burned fuses are %s\n", strings.Join(util.FuseNames(data.Fuses), ", "))
+		}
+		if data.Expiry != 0 {
+			expiry := time.Unix(int64(data.Expiry), 0)
+			if expiry.Before(time.Now()) {
+				fmt.Printf("Fuses expired at %v\n", expiry)
+			} else {
+				fmt.Printf("Fuses expire at %v\n", expiry)
+			}
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	ensWrapperCmd.AddCommand(ensWrapperInfoCmd)
+	ensWrapperFlags(ensWrapperInfoCmd)
+	ensBlockFlags(ensWrapperInfoCmd)
+}
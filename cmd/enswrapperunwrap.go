@@ -0,0 +1,88 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+var ensWrapperUnwrapOwnerStr string
+
+// ensWrapperUnwrapCmd represents the ens wrapper unwrap command
+var ensWrapperUnwrapCmd = &cobra.Command{
+	Use:   "unwrap",
+	Short: "Unwrap an ENS domain",
+	Long: `Unwrap a domain registered with the Ethereum Name Service (ENS) from the name wrapper.  For example:
+
+    ethereal ens wrapper unwrap --domain=enstest.eth --passphrase="my secret passphrase"
+
+The unwrapped domain is owned by the current owner of the wrapped domain unless --owner is supplied.  Domains with the CANNOT_UNWRAP fuse burned cannot be unwrapped.
+
+The keystore for the account that owns the wrapped name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		domain, err := ens.NormaliseDomain(ensDomain)
+		cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+
+		wrapper, _ := ensNameWrapper()
+		owner := ensWrappedOwner(wrapper, domain)
+		outputIf(verbose, fmt.Sprintf("Wrapped domain is owned by %s", formatAddress(owner)))
+
+		newOwner := owner
+		if ensWrapperUnwrapOwnerStr != "" {
+			newOwner, err = c.Resolve(ensWrapperUnwrapOwnerStr)
+			cli.ErrCheck(err, quiet, "Invalid owner")
+		}
+		cli.Assert(newOwner != ens.UnknownAddress, quiet, "Cannot unwrap to the zero address")
+
+		labelHash, err := ens.LabelHash(ensLabel(domain))
+		cli.ErrCheck(err, quiet, "Failed to obtain label hash")
+
+		opts, err := generateTxOpts(owner)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		var signedTx *types.Transaction
+		if ensIsETH2LD(domain) {
+			signedTx, err = wrapper.UnwrapETH2LD(opts, labelHash, newOwner, newOwner)
+		} else {
+			var parentNode [32]byte
+			parentNode, err = ens.NameHash(ens.Domain(domain))
+			cli.ErrCheck(err, quiet, "Failed to obtain name hash of parent")
+			signedTx, err = wrapper.Unwrap(opts, parentNode, labelHash, newOwner)
+		}
+		cli.ErrCheck(err, quiet, "Failed to send transaction")
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":     "ens/wrapper",
+			"command":   "unwrap",
+			"ensdomain": domain,
+			"ensowner":  newOwner.Hex(),
+		}, true)
+	},
+}
+
+func init() {
+	ensWrapperCmd.AddCommand(ensWrapperUnwrapCmd)
+	ensWrapperFlags(ensWrapperUnwrapCmd)
+	ensWrapperUnwrapCmd.Flags().StringVar(&ensWrapperUnwrapOwnerStr, "owner", "", "The owner of the unwrapped domain (defaults to the current owner)")
+	addTransactionFlags(ensWrapperUnwrapCmd, "passphrase for the account that owns the wrapped domain")
+}
@@ -0,0 +1,124 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+var ensWrapperWrapOwnerStr string
+var ensWrapperWrapResolverStr string
+var ensWrapperWrapFuses string
+
+// ensWrapperWrapCmd represents the ens wrapper wrap command
+var ensWrapperWrapCmd = &cobra.Command{
+	Use:   "wrap",
+	Short: "Wrap an ENS domain",
+	Long: `Wrap a domain registered with the Ethereum Name Service (ENS) in the name wrapper.  For example:
+
+    ethereal ens wrapper wrap --domain=enstest.eth --passphrase="my secret passphrase"
+
+The wrapped domain is owned by the current owner of the domain unless --owner is supplied, and keeps its current resolver unless --resolver is supplied.  Second-level .eth domains can have fuses burned as they are wrapped with --fuses, for example --fuses=CANNOT_UNWRAP,CANNOT_TRANSFER.
+
+If the name wrapper is not yet approved to transfer the owner's names an approval transaction is sent and mined before the domain is wrapped.
+
+The keystore for the account that owns the name must be local (i.e. listed with 'get accounts list') and unlockable with the supplied passphrase.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+		cli.Assert(ensDomain != "", quiet, "--domain is required")
+		domain, err := ens.NormaliseDomain(ensDomain)
+		cli.ErrCheck(err, quiet, "Failed to normalise ENS domain")
+
+		fuses, err := util.ParseFuses(ensWrapperWrapFuses)
+		cli.ErrCheck(err, quiet, "Invalid fuses")
+		cli.Assert(fuses&^util.OwnerControlledFuses == 0, quiet, "Only owner-controlled fuses can be burned when wrapping")
+
+		wrapper, wrapperAddress := ensNameWrapper()
+		registry, err := ens.NewRegistry(c.Client())
+		cli.ErrCheck(err, quiet, "Cannot obtain ENS registry contract")
+
+		registryOwner, err := registry.Owner(domain)
+		cli.ErrCheck(err, quiet, "Cannot obtain owner")
+		cli.Assert(registryOwner != ens.UnknownAddress, quiet, fmt.Sprintf("owner of %s is not set", domain))
+		cli.Assert(registryOwner != wrapperAddress, quiet, fmt.Sprintf("%s is already wrapped", domain))
+
+		resolver, err := registry.ResolverAddress(domain)
+		cli.ErrCheck(err, quiet, "Cannot obtain resolver")
+		if ensWrapperWrapResolverStr != "" {
+			resolver, err = c.Resolve(ensWrapperWrapResolverStr)
+			cli.ErrCheck(err, quiet, "Invalid resolver")
+		}
+
+		var owner common.Address
+		var contract common.Address
+		if ensIsETH2LD(domain) {
+			// The owner is the registrant of the domain, which may not be the registry owner.
+			registrar, err := ens.NewBaseRegistrar(c.Client(), "eth")
+			cli.ErrCheck(err, quiet, "Failed to obtain eth registrar")
+			owner, err = registrar.Owner(domain)
+			cli.ErrCheck(err, quiet, "Failed to obtain registrant")
+			contract = registrar.ContractAddr
+		} else {
+			cli.Assert(fuses == 0, quiet, "Fuses can only be burned when wrapping second-level .eth domains; use \"ens wrapper burn\" once wrapped")
+			owner = registryOwner
+			contract = registry.ContractAddr
+		}
+		outputIf(verbose, fmt.Sprintf("Domain is owned by %s", formatAddress(owner)))
+
+		wrappedOwner := owner
+		if ensWrapperWrapOwnerStr != "" {
+			wrappedOwner, err = c.Resolve(ensWrapperWrapOwnerStr)
+			cli.ErrCheck(err, quiet, "Invalid owner")
+		}
+
+		ensApproveWrapper(contract, owner, wrapperAddress)
+
+		opts, err := generateTxOpts(owner)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		var signedTx *types.Transaction
+		if ensIsETH2LD(domain) {
+			signedTx, err = wrapper.WrapETH2LD(opts, ensLabel(domain), wrappedOwner, uint16(fuses), resolver)
+		} else {
+			signedTx, err = wrapper.Wrap(opts, ens.DNSWireFormat(domain), wrappedOwner, resolver)
+		}
+		cli.ErrCheck(err, quiet, "Failed to send transaction")
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":     "ens/wrapper",
+			"command":   "wrap",
+			"ensdomain": domain,
+			"ensowner":  wrappedOwner.Hex(),
+			"fuses":     fuses,
+		}, true)
+	},
+}
+
+func init() {
+	ensWrapperCmd.AddCommand(ensWrapperWrapCmd)
+	ensWrapperFlags(ensWrapperWrapCmd)
+	ensWrapperWrapCmd.Flags().StringVar(&ensWrapperWrapOwnerStr, "owner", "", "The owner of the wrapped domain (defaults to the current owner)")
+	ensWrapperWrapCmd.Flags().StringVar(&ensWrapperWrapResolverStr, "resolver", "", "The resolver of the wrapped domain (defaults to the current resolver)")
+	ensWrapperWrapCmd.Flags().StringVar(&ensWrapperWrapFuses, "fuses", "", "Comma-separated list of fuses to burn when wrapping a second-level .eth domain")
+	addTransactionFlags(ensWrapperWrapCmd, "passphrase for the account that owns the domain")
+}
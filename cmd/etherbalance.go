@@ -22,7 +22,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 var etherBalanceAddress string
@@ -65,6 +64,24 @@ In quiet mode this will return 0 if the balance is greater than 0, otherwise 1.`
 		cli.Assert(err == nil || !strings.HasPrefix(err.Error(), "missing trie node"), quiet, "Connection does not have information on that block, please change the connection parameter to point to a full synced node")
 		cli.ErrCheck(err, quiet, "Failed to obtain balance")
 
+		var price *fiatPrice
+		if !quiet {
+			price = etherPrice()
+		}
+
+		if jsonOutput() {
+			res := map[string]interface{}{"address": address.Hex(), "balance": balance.String()}
+			if price != nil {
+				res["currency"] = price.currency
+				res["fiat_balance"] = price.value(balance)
+			}
+			writeJSON(res)
+			if balance.Cmp(big.NewInt(0)) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
 		if balance.Cmp(big.NewInt(0)) == 0 {
 			outputIf(!quiet, "0")
 			os.Exit(exitFailure)
@@ -73,7 +90,7 @@ In quiet mode this will return 0 if the balance is greater than 0, otherwise 1.`
 				if etherBalanceWei {
 					fmt.Printf("%s\n", balance.String())
 				} else {
-					fmt.Printf("%s\n", string2eth.WeiToString(balance, true))
+					fmt.Printf("%s\n", formatWeiFiat(balance, price))
 				}
 			}
 			os.Exit(exitSuccess)
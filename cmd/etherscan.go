@@ -0,0 +1,41 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+)
+
+// etherscanClient returns a client for Etherscan if it has been configured, otherwise nil.
+func etherscanClient() *etherscan.Client {
+	key := viper.GetString("etherscan-api-key")
+	if key == "" {
+		key = os.Getenv("ETHERSCAN_API_KEY")
+	}
+	url := viper.GetString("etherscan-url")
+	if key == "" && url == "" {
+		return nil
+	}
+	return etherscan.New(key, url)
+}
+
+// serviceURL returns the configured URL for the API of the named service, if any.
+func serviceURL(name string) string {
+	return viper.GetString(fmt.Sprintf("%s-url", strings.ToLower(name)))
+}
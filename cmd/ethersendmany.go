@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/disperse"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// etherSendManyCmd represents the ether sendmany command
+var etherSendManyCmd = &cobra.Command{
+	Use:   "sendmany",
+	Short: "Send Ether to many addresses in a single transaction",
+	Long: `Send Ether to many addresses in a single transaction through a disperse contract.  For example:
+
+    ethereal ether sendmany --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --file=payouts.csv --passphrase=secret
+
+The file contains one payment per row, either as CSV with to and value columns or as a JSON array of objects with to and value fields.  Amounts are in Ether unless otherwise specified, for example "0.5 ether" or "1000 gwei".
+
+If the Disperse contract is not present on the chain then --deploy will deploy a compatible contract before sending the payments.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+
+		fromAddress := disperseSender()
+		recipients, values := dispersePayments(string2eth.StringToWei)
+		total := disperse.Total(values)
+		outputIf(verbose, fmt.Sprintf("Sending %s to %d recipients", formatWei(total), len(recipients)))
+
+		ctx, cancel := localContext()
+		defer cancel()
+		balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
+		cli.Assert(balance.Cmp(total) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for payments totalling %s", formatWei(balance), formatWei(total)))
+
+		contract := disperseContract(fromAddress)
+
+		data, err := disperse.EtherData(recipients, values)
+		cli.ErrCheck(err, quiet, "Failed to create payments")
+
+		sendDisperseTransaction(fromAddress, contract, total, data, log.Fields{
+			"group":      "ether",
+			"command":    "sendmany",
+			"recipients": len(recipients),
+			"amount":     total.String(),
+		})
+	},
+}
+
+func init() {
+	etherCmd.AddCommand(etherSendManyCmd)
+	disperseFlags(etherSendManyCmd)
+}
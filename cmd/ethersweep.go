@@ -14,20 +14,17 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethereal/v2/cli"
 	"github.com/wealdtech/ethereal/v2/conn"
-	ens "github.com/wealdtech/go-ens/v3"
-	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 var etherSweepFromAddress string
@@ -41,8 +38,11 @@ var etherSweepCmd = &cobra.Command{
 
     etherereal ether sweep --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x52f1A3027d3aA514F17E454C93ae1F79b3B12d5d --passphrase=secret
 
+The maximum fee for the transaction is deducted from the amount swept, so some Ether can remain in the account if the transaction costs less than its maximum fee.
+
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		etherSweepFromAddress = accountOrDefault(etherSweepFromAddress)
 		cli.Assert(etherSweepFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(etherSweepFromAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain from address for sweep")
@@ -56,53 +56,19 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		defer cancel()
 		balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
 		cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
-		cli.Assert(balance.Cmp(big.NewInt(0)) > 0, quiet, fmt.Sprintf("Balance of %s is 0; nothing to sweep", ens.Format(c.Client(), fromAddress)))
-
-		// Obtain the amount of gas required to send the transaction, and calculate the amount to send
-		gas, err := c.EstimateGas(context.Background(), &conn.TransactionData{
-			From:  fromAddress,
-			To:    &toAddress,
-			Value: balance,
-		})
-		cli.ErrCheck(err, quiet, "Failed to estimate gas required to sweep funds")
-		outputIf(verbose, fmt.Sprintf("Gas estimation is %v", gas))
+		cli.Assert(balance.Cmp(big.NewInt(0)) > 0, quiet, fmt.Sprintf("Balance of %s is 0; nothing to sweep", formatAddress(fromAddress)))
 
-		// Obtain current base fee, multiply it by 150%.
-		baseFee, err := c.CurrentBaseFee(context.Background())
-		cli.ErrCheck(err, quiet, "failed to obtain current base fee")
-		gasFee := new(big.Int).Div(baseFee.Mul(baseFee, big.NewInt(3)), big.NewInt(2))
-
-		gasCost := new(big.Int).Mul(big.NewInt(int64(gas)), gasFee)
-		outputIf(verbose, fmt.Sprintf("Gas cost is %v", string2eth.WeiToString(gasCost, true)))
-		amount := balance.Sub(balance, gasCost)
-		outputIf(verbose, fmt.Sprintf("Sweeping %s", string2eth.WeiToString(amount, true)))
-
-		var gasLimit *uint64
-		limit := uint64(viper.GetInt64("gaslimit"))
-		if limit > 0 {
-			gasLimit = &limit
-		}
+		txData, _ := etherSweepTransactionData(ctx, fromAddress, toAddress, balance)
 
 		// Create and sign the transaction
-		signedTx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
-			From:                 fromAddress,
-			To:                   &toAddress,
-			Value:                amount,
-			GasLimit:             gasLimit,
-			MaxFeePerGas:         gasFee,
-			MaxPriorityFeePerGas: gasFee,
-		})
+		signedTx, err := c.CreateSignedTransaction(context.Background(), txData)
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		} else {
-			err = c.SendTransaction(context.Background(), signedTx)
+			err = sendTransaction(context.Background(), fromAddress, signedTx)
 			cli.ErrCheck(err, quiet, "Failed to send transaction")
 			handleSubmittedTransaction(signedTx, log.Fields{
 				"group":   "ether",
@@ -112,6 +78,57 @@ This will return an exit status of 0 if the transaction is successfully submitte
 	},
 }
 
+// etherSweepTransactionData creates the data for a transaction that sweeps the balance of the
+// sender to the recipient, less the maximum fee for the transaction, and returns it along with
+// the fee.  The maximum fee per gas is 150% of the current base fee plus the priority fee, or the
+// gas price on chains that do not support EIP-1559.
+func etherSweepTransactionData(ctx context.Context, from common.Address, to common.Address, balance *big.Int) (*conn.TransactionData, *big.Int) {
+	txData := &conn.TransactionData{
+		From:  from,
+		To:    &to,
+		Value: balance,
+	}
+
+	limit := uint64(viper.GetInt64("gaslimit"))
+	if limit > 0 {
+		txData.GasLimit = &limit
+	} else {
+		// Obtain the amount of gas required to send the transaction.
+		gas, err := c.EstimateGas(ctx, txData)
+		cli.ErrCheck(err, quiet, "Failed to estimate gas required to sweep funds")
+		outputIf(verbose, fmt.Sprintf("Gas estimation is %v", gas))
+		txData.GasLimit = &gas
+	}
+
+	london, err := c.SupportsLondon(ctx)
+	cli.ErrCheck(err, quiet, "Failed to find out if the chain supports EIP-1559")
+	if london {
+		feePerGas, priorityFeePerGas, err := calculateFees()
+		cli.ErrCheck(err, quiet, "Failed to calculate fees")
+		// Obtain current base fee, multiply it by 150% and add the priority fee, within the
+		// maximum fee per gas.
+		baseFee, err := c.CurrentBaseFee(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain current base fee")
+		txData.MaxFeePerGas = new(big.Int).Div(new(big.Int).Mul(baseFee, big.NewInt(3)), big.NewInt(2))
+		txData.MaxFeePerGas = txData.MaxFeePerGas.Add(txData.MaxFeePerGas, priorityFeePerGas)
+		if txData.MaxFeePerGas.Cmp(feePerGas) > 0 {
+			txData.MaxFeePerGas = feePerGas
+		}
+		txData.MaxPriorityFeePerGas = priorityFeePerGas
+	} else {
+		txData.MaxFeePerGas, err = c.CalculateGasPrice(ctx)
+		cli.ErrCheck(err, quiet, "Failed to calculate gas price")
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(*txData.GasLimit), txData.MaxFeePerGas)
+	outputIf(verbose, fmt.Sprintf("Gas cost is %v", formatWei(fee)))
+	cli.Assert(balance.Cmp(fee) > 0, quiet, fmt.Sprintf("Balance of %s insufficient to cover fee of %s", formatWei(balance), formatWei(fee)))
+	txData.Value = new(big.Int).Sub(balance, fee)
+	outputIf(verbose, fmt.Sprintf("Sweeping %s", formatWei(txData.Value)))
+
+	return txData, fee
+}
+
 func init() {
 	etherCmd.AddCommand(etherSweepCmd)
 	etherSweepCmd.Flags().StringVar(&etherSweepFromAddress, "from", "", "Address from which to sweep Ether")
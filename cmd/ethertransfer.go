@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -44,6 +43,7 @@ var etherTransferCmd = &cobra.Command{
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Aliases: []string{"send"},
 	Run: func(cmd *cobra.Command, args []string) {
+		etherTransferFromAddress = accountOrDefault(etherTransferFromAddress)
 		cli.Assert(etherTransferFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(etherTransferFromAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain from address for transfer")
@@ -62,7 +62,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			defer cancel()
 			balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
 			cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
-			cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", string2eth.WeiToString(balance, true)))
+			cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", formatWei(balance)))
 		}
 
 		// Turn the data string in to hex
@@ -91,13 +91,9 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 		} else {
-			err = c.SendTransaction(context.Background(), signedTx)
+			err = sendTransaction(context.Background(), fromAddress, signedTx)
 			cli.ErrCheck(err, quiet, "Failed to send transaction")
 			handleSubmittedTransaction(signedTx, log.Fields{
 				"group":   "ether",
@@ -0,0 +1,46 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/util/feeds"
+)
+
+// feedCmd represents the feed command
+var feedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Manage price feeds",
+	Long:  `Obtain information from Chainlink-compatible price feeds`,
+}
+
+// feedAddress returns the address of a feed given either its address or ENS name, or a pair
+// such as ETH/USD known on the connected chain.
+func feedAddress(input string) (common.Address, error) {
+	if feed := feeds.ByPair(c.ChainID().Uint64(), input); feed != nil {
+		return feed.Address, nil
+	}
+	address, err := c.Resolve(input)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%s is not a known pair on this chain or a valid address", input)
+	}
+	return address, nil
+}
+
+func init() {
+	RootCmd.AddCommand(feedCmd)
+}
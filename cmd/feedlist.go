@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/feeds"
+)
+
+// feedListCmd represents the feed list command
+var feedListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List well-known price feeds",
+	Long: `List the well-known Chainlink price feeds on the connected chain.  For example:
+
+    ethereal feed list
+
+In quiet mode this will return 0 if there are known feeds on the chain, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		knownFeeds := feeds.ForChain(c.ChainID().Uint64())
+		if quiet {
+			if len(knownFeeds) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(knownFeeds)
+		}
+
+		cli.Assert(len(knownFeeds) > 0, quiet, fmt.Sprintf("No known feeds on chain %s", c.ChainID()))
+		for _, feed := range knownFeeds {
+			fmt.Printf("%-10s\t%s\n", feed.Pair, feed.Address.Hex())
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	feedCmd.AddCommand(feedListCmd)
+}
@@ -0,0 +1,114 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var feedPriceFeed string
+var feedPriceMaxAge time.Duration
+
+// feedPriceCmd represents the feed price command
+var feedPriceCmd = &cobra.Command{
+	Use:   "price",
+	Short: "Obtain the latest price from a price feed",
+	Long: `Obtain the latest price from a Chainlink-compatible price feed, adjusted for the feed's decimals.  For example:
+
+    ethereal feed price --feed=ETH/USD
+
+The feed can be supplied as an address or ENS name, or as a pair such as ETH/USD that is known on the connected chain; known pairs are listed by 'ethereal feed list'.  If --max-age is supplied the price is considered stale if it has not been updated within that time.
+
+In quiet mode this will return 0 if the price is obtained and is not stale, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain price when offline")
+		cli.Assert(feedPriceFeed != "", quiet, "--feed is required")
+		address, err := feedAddress(feedPriceFeed)
+		cli.ErrCheck(err, quiet, "Failed to obtain feed address")
+
+		ctx, cancel := localContext()
+		defer cancel()
+		round, err := c.LatestRoundData(ctx, address)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain data from feed %s", address.Hex()))
+
+		price := feedPriceString(round.Answer, round.Decimals)
+		age := time.Since(round.UpdatedAt).Round(time.Second)
+		stale := feedPriceMaxAge > 0 && age > feedPriceMaxAge
+
+		if jsonOutput() {
+			writeJSON(&feedPriceJSON{
+				Feed:            address.Hex(),
+				Description:     round.Description,
+				Decimals:        round.Decimals,
+				RoundID:         round.RoundID.String(),
+				Answer:          round.Answer.String(),
+				Price:           price,
+				StartedAt:       round.StartedAt.Unix(),
+				UpdatedAt:       round.UpdatedAt.Unix(),
+				AnsweredInRound: round.AnsweredInRound.String(),
+				Stale:           stale,
+			})
+		} else if !quiet {
+			if verbose {
+				fmt.Printf("Feed:\t\t%s\n", address.Hex())
+				fmt.Printf("Description:\t%s\n", round.Description)
+				fmt.Printf("Round:\t\t%s\n", round.RoundID)
+				fmt.Printf("Price:\t\t%s\n", price)
+				fmt.Printf("Updated:\t%s (%v ago)\n", round.UpdatedAt.Format(time.RFC3339), age)
+			} else {
+				fmt.Println(price)
+			}
+		}
+		if stale {
+			outputIf(!quiet && !jsonOutput(), fmt.Sprintf("Price is stale; last updated %v ago", age))
+			os.Exit(exitFailure)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// feedPriceJSON is the JSON output for the price from a feed.
+type feedPriceJSON struct {
+	Feed            string `json:"feed"`
+	Description     string `json:"description"`
+	Decimals        uint8  `json:"decimals"`
+	RoundID         string `json:"round_id"`
+	Answer          string `json:"answer"`
+	Price           string `json:"price"`
+	StartedAt       int64  `json:"started_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+	AnsweredInRound string `json:"answered_in_round"`
+	Stale           bool   `json:"stale"`
+}
+
+// feedPriceString formats an answer from a feed, adjusted for the feed's decimals.
+func feedPriceString(answer *big.Int, decimals uint8) string {
+	if answer.Sign() < 0 {
+		return "-" + util.TokenValueToString(new(big.Int).Neg(answer), decimals, false)
+	}
+	return util.TokenValueToString(answer, decimals, false)
+}
+
+func init() {
+	feedCmd.AddCommand(feedPriceCmd)
+	feedPriceCmd.Flags().StringVar(&feedPriceFeed, "feed", "", "Address, ENS name or pair (e.g. ETH/USD) of the feed")
+	feedPriceCmd.Flags().DurationVar(&feedPriceMaxAge, "max-age", 0, "Maximum time since the price was last updated before it is considered stale")
+}
@@ -0,0 +1,53 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// gasBlobPriceCmd represents the gas blobprice command
+var gasBlobPriceCmd = &cobra.Command{
+	Use:   "blobprice",
+	Short: "Obtain the current blob base fee",
+	Long: `Obtain the base fee per blob gas for the next block.  For example:
+
+    ethereal gas blobprice
+
+This requires a chain and node that support EIP-4844.
+
+In quiet mode this will return 0 if it can obtain the blob base fee, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+		fee, err := c.BlobBaseFee(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain blob base fee")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"blob_base_fee_per_gas": fee.String()})
+		}
+		fmt.Printf("%s\n", gasPriceString(fee))
+	},
+}
+
+func init() {
+	gasCmd.AddCommand(gasBlobPriceCmd)
+	gasBlobPriceCmd.Flags().BoolVar(&gasPriceWei, "wei", false, "Display output in number of Wei")
+}
@@ -14,136 +14,99 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"os"
-	"sort"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
-	string2eth "github.com/wealdtech/go-string2eth"
+	"github.com/wealdtech/ethereal/v2/conn"
 )
 
-var gasPriceBlocks int64
+var gasPriceBlocks uint64
+var gasPricePercentiles []float64
 var gasPriceWei bool
-var gasPriceLowest bool
-var gas uint64
 
 // gasPriceCmd represents the gas price command
 var gasPriceCmd = &cobra.Command{
 	Use:   "price",
-	Short: "Calculate an expected gas price",
-	Long: `Calculate an expected gas price for inclusion based on prior blocks.  For example:
+	Short: "Suggest fees for a transaction",
+	Long: `Suggest slow, standard and fast fees for a transaction based on the fee history of prior blocks.  For example:
 
-    ethereal gas price --blocks=5
+    ethereal gas price --blocks=20
 
-The per-block expected inclusion price is based on the average of the gas price of the transactions in the 9th decile when ordering the blocks' transactions by descending gas price.  The overall inclusion price is the average of the gas price of the transactions over all blocks.
+The priority fee for each speed is the median, over the blocks, of the priority fee paid at the given percentile of each block's transactions.  The percentiles for slow, standard and fast can be supplied with --percentiles, and default to 10, 50 and 90.  The max fee allows for the base fee to double before the transaction is included.
 
-If the optional --gas parameter is supplied the price will be based on a transaction with the given supplied gas rather than the 9th decile transactions.  This should provide a more accurate indication of gas price for transactions that require the supplied gas.
+On chains that do not support EIP-1559 the gas price suggested by the node is returned instead.
 
-In quiet mode this will return 0 if it can calculate a gas price, otherwise 1.`,
+In quiet mode this will return 0 if it can suggest fees, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(gasPriceBlocks > 0, quiet, "--blocks must be greater than 0")
 
-		lowestGasPrice := big.NewInt(0)
-		var err error
-
-		totalGasPrice := big.NewInt(0)
-		totalTxs := int64(0)
-
-		if gas > 0 {
-			lowestGasPrice, err = util.GasPriceForBlocks(c.Client(), gasPriceBlocks, gas, verbose)
+		london, err := c.SupportsLondon(context.Background())
+		cli.ErrCheck(err, quiet, "Failed to establish if chain supports EIP-1559")
+		if !london {
+			gasPrice, err := c.SuggestGasPrice(context.Background())
 			cli.ErrCheck(err, quiet, "Failed to obtain gas price")
-		} else {
-			var blockNumber *big.Int
-			for blocks := gasPriceBlocks; blocks > 0; blocks-- {
-				ctx, cancel := localContext()
-				defer cancel()
-				block, err := c.Client().BlockByNumber(ctx, blockNumber)
-				cli.ErrCheck(err, quiet, "Failed to obtain information about latest block")
-				blockNumber = big.NewInt(0).Set(block.Number())
-				blockTime := time.Unix(int64(block.Time()), 0)
-
-				if util.BlockHasMinerTransactions(block, c.ChainID()) {
-					outputIf(verbose, fmt.Sprintf("Block %v contains self-mined transactions; ignoring", blockNumber))
-					blockNumber = blockNumber.Sub(blockNumber, big.NewInt(1))
-					blocks++
-					continue
-				}
-
-				txs := block.Transactions()
-				if len(txs) > 0 {
-					// Order transactions by gas price
-					sort.Slice(txs, func(i, j int) bool {
-						return txs[i].GasPrice().Cmp(txs[j].GasPrice()) > 0
-					})
-					// Remove any 0 gas-price transactions
-					validTxs := txs[:0]
-					for _, tx := range txs {
-						if tx.GasPrice().Cmp(zero) != 0 {
-							validTxs = append(validTxs, tx)
-						}
-					}
-					if len(validTxs) > 0 {
-						if gasPriceLowest {
-							blockLowestGasPrice := validTxs[len(validTxs)-1].GasPrice()
-							outputIf(verbose, fmt.Sprintf("Lowest inclusion price for block %v (%s) is %s", blockNumber, blockTime.Format("06/01/02 15:04:05"), string2eth.WeiToString(blockLowestGasPrice, true)))
-							if lowestGasPrice.Cmp(zero) == 0 || blockLowestGasPrice.Cmp(lowestGasPrice) < 0 {
-								lowestGasPrice = blockLowestGasPrice
-							}
-						} else {
-							// Take the average gas price of the 9th decile of transactions
-							blockGasPrice := big.NewInt(0)
-							blockTxs := int64(0)
-							for _, tx := range validTxs[(len(validTxs)*8)/10 : (len(validTxs)*9)/10+1] {
-								blockGasPrice = blockGasPrice.Add(blockGasPrice, tx.GasPrice())
-								blockTxs++
-							}
-							totalGasPrice = totalGasPrice.Add(totalGasPrice, blockGasPrice)
-							totalTxs += blockTxs
-							blockGasPrice = blockGasPrice.Div(blockGasPrice, big.NewInt(blockTxs))
-							outputIf(verbose, fmt.Sprintf("Expected inclusion price for block %v (%s) over %d transactions is %s", blockNumber, blockTime.Format("06/01/02 15:04:05"), blockTxs, string2eth.WeiToString(blockGasPrice, true)))
-						}
-					}
-				}
-
-				blockNumber = blockNumber.Sub(blockNumber, big.NewInt(1))
-				if blockNumber.Cmp(zero) < 0 {
-					// We've reached the beginning of the chain; stop
-					break
-				}
+			if quiet {
+				os.Exit(exitSuccess)
 			}
-		}
-
-		// Obtain final value
-		var finalGasPrice *big.Int
-		if gasPriceLowest || gas > 0 {
-			finalGasPrice = lowestGasPrice
-		} else {
-			if totalTxs == 0 {
-				cli.Err(quiet, "No transactions obtained")
+			if jsonOutput() {
+				outputJSON(map[string]interface{}{"gas_price": gasPrice.String()})
 			}
-			finalGasPrice = totalGasPrice.Div(totalGasPrice, big.NewInt(totalTxs))
+			fmt.Printf("%s\n", gasPriceString(gasPrice))
+			os.Exit(exitSuccess)
 		}
 
+		suggestions, err := c.SuggestFees(context.Background(), gasPriceBlocks, gasPricePercentiles)
+		cli.ErrCheck(err, quiet, "Failed to suggest fees")
 		if quiet {
 			os.Exit(exitSuccess)
 		}
 
-		if gasPriceWei {
-			fmt.Printf("%s\n", finalGasPrice.String())
-		} else {
-			fmt.Printf("%s\n", string2eth.WeiToString(finalGasPrice, true))
+		if jsonOutput() {
+			res := map[string]interface{}{
+				"base_fee_per_gas": suggestions.BaseFeePerGas.String(),
+			}
+			for name, suggestion := range map[string]*conn.FeeSuggestion{
+				"slow":     suggestions.Slow,
+				"standard": suggestions.Standard,
+				"fast":     suggestions.Fast,
+			} {
+				res[name] = map[string]interface{}{
+					"max_fee_per_gas":          suggestion.MaxFeePerGas.String(),
+					"max_priority_fee_per_gas": suggestion.MaxPriorityFeePerGas.String(),
+				}
+			}
+			outputJSON(res)
+		}
+
+		outputIf(verbose, fmt.Sprintf("Base fee per gas: %s", gasPriceString(suggestions.BaseFeePerGas)))
+		for _, suggestion := range []struct {
+			name  string
+			value *conn.FeeSuggestion
+		}{
+			{name: "Slow", value: suggestions.Slow},
+			{name: "Standard", value: suggestions.Standard},
+			{name: "Fast", value: suggestions.Fast},
+		} {
+			fmt.Printf("%-9s max fee per gas %s, max priority fee per gas %s\n", suggestion.name+":", gasPriceString(suggestion.value.MaxFeePerGas), gasPriceString(suggestion.value.MaxPriorityFeePerGas))
 		}
 	},
 }
 
+// gasPriceString returns a string representation of the gas price.
+func gasPriceString(price *big.Int) string {
+	if gasPriceWei {
+		return price.String()
+	}
+	return formatWei(price)
+}
+
 func init() {
 	gasCmd.AddCommand(gasPriceCmd)
 	gasPriceCmd.Flags().BoolVar(&gasPriceWei, "wei", false, "Display output in number of Wei")
-	gasPriceCmd.Flags().Int64Var(&gasPriceBlocks, "blocks", 5, "Number of blocks to go back to average gas price")
-	gasPriceCmd.Flags().Uint64Var(&gas, "gas", 0, "Provide gas price based on the amount of gas used by the transaction")
-	gasPriceCmd.Flags().BoolVar(&gasPriceLowest, "lowest", false, "Lowest inclusion price over the blocks")
+	gasPriceCmd.Flags().Uint64Var(&gasPriceBlocks, "blocks", conn.DefaultFeeHistoryBlocks, "Number of blocks of fee history to use")
+	gasPriceCmd.Flags().Float64SliceVar(&gasPricePercentiles, "percentiles", conn.DefaultFeeHistoryPercentiles, "Percentiles of priority fees in each block for slow, standard and fast fees")
 }
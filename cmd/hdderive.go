@@ -0,0 +1,81 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var hdDeriveSeed string
+var hdDeriveSecret string
+var hdDerivePath string
+var hdDeriveCount int
+
+// hdDeriveCmd represents the hd derive command
+var hdDeriveCmd = &cobra.Command{
+	Use:   "derive",
+	Short: "Display addresses derived from a seed",
+	Long: `Display a number of consecutive addresses derived from a seed, starting at the given path.  For example:
+
+    ethereal hd derive --seed="correct horse battery staple" --path="m/44'/60'/0'/0/0" --count=5
+
+Subsequent addresses are derived by incrementing the final component of the path.
+
+In quiet mode this will return 0 if the addresses were successfully derived, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(hdDeriveSeed != "", quiet, "--seed is required")
+		cli.Assert(hdDeriveCount > 0, quiet, "--count must be greater than 0")
+
+		path, err := accounts.ParseDerivationPath(hdDerivePath)
+		cli.ErrCheck(err, quiet, "Invalid path")
+		cli.Assert(len(path) > 0, quiet, "Invalid path")
+
+		keys, err := util.HDPrivateKeys(hdDeriveSeed, hdDeriveSecret, hdDerivePath, hdDeriveCount)
+		cli.ErrCheck(err, quiet, "Failed to derive keys")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		results := make([]map[string]interface{}, len(keys))
+		for i, key := range keys {
+			results[i] = map[string]interface{}{
+				"path":    path.String(),
+				"address": crypto.PubkeyToAddress(key.PublicKey).Hex(),
+			}
+			path[len(path)-1]++
+		}
+		if jsonOutput() {
+			outputJSON(results)
+		}
+		for _, result := range results {
+			fmt.Printf("%s\t%s\n", result["path"], result["address"])
+		}
+	},
+}
+
+func init() {
+	offlineCmds["hd:derive"] = true
+	hdCmd.AddCommand(hdDeriveCmd)
+	hdDeriveCmd.Flags().StringVar(&hdDeriveSeed, "seed", "", "12- or 24-word BIP-39 seed phrase")
+	hdDeriveCmd.Flags().StringVar(&hdDeriveSecret, "secret", "", "optional secret to add to seed")
+	hdDeriveCmd.Flags().StringVar(&hdDerivePath, "path", util.DefaultHDPath, "path of the first address")
+	hdDeriveCmd.Flags().IntVar(&hdDeriveCount, "count", 10, "number of addresses to derive")
+}
@@ -19,9 +19,10 @@ import (
 
 // networkCmd represents the network command
 var networkCmd = &cobra.Command{
-	Use:   "network",
-	Short: "Network information",
-	Long:  `Obtain information about the network`,
+	Use:     "network",
+	Aliases: []string{"networks"},
+	Short:   "Network information",
+	Long:    `Obtain information about the network`,
 }
 
 func init() {
@@ -119,6 +119,13 @@ In quiet mode this will return 0 if the blocks exist, otherwise 1.`,
 		}
 
 		gap := lastBlockTime.Sub(oldBlockTime) / time.Duration(new(big.Int).Sub(lastBlockNumber, oldBlockNumber).Int64())
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"from_block": oldBlockNumber.Uint64(),
+				"to_block":   lastBlockNumber.Uint64(),
+				"block_time": gap.Seconds(),
+			})
+		}
 		fmt.Printf("%v\n", (gap/10000000)*10000000)
 	},
 }
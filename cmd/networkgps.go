@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"os"
 	"time"
@@ -55,9 +56,7 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 				lastBlockNumber = lastBlockNumber.Add(lastBlockNumber, big.NewInt(1))
 				blockDuration := lastBlockTime.Sub(blockTime).Seconds()
 				duration += blockDuration
-				if verbose {
-					fmt.Printf("Block %v used %v gas in %v seconds\n", lastBlockNumber, lastBlockGas, blockDuration)
-				}
+				outputIf(verbose, fmt.Sprintf("Block %v used %v gas in %v seconds", lastBlockNumber, lastBlockGas, blockDuration))
 			}
 
 			blockNumber = big.NewInt(0).Set(block.Number())
@@ -76,6 +75,9 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 		}
 
 		gasPerSecond := float64(gas) / duration
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"gas_per_second": math.Round(gasPerSecond)})
+		}
 		fmt.Printf("%.0f\n", gasPerSecond)
 	},
 }
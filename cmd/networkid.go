@@ -37,6 +37,9 @@ In quiet mode this will return 0 if the network ID is obtained, otherwise 1.`,
 		defer cancel()
 		id, err := c.Client().NetworkID(ctx)
 		cli.ErrCheck(err, quiet, "Failed to obtain network ID")
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"network_id": id.String()})
+		}
 		if !quiet {
 			fmt.Printf("%v\n", id)
 		}
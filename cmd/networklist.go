@@ -0,0 +1,88 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+)
+
+var networkListTestnets bool
+var networkListMainnets bool
+
+// networkListCmd represents the network list command
+var networkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List known networks",
+	Long: `List the networks known to ethereal, along with their chain ID, native currency and block explorer.  For example:
+
+    ethereal network list --testnets
+
+In quiet mode this will return 0 if any networks are listed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		networks := make([]*chains.Chain, 0)
+		for _, chain := range chains.All() {
+			if networkListTestnets && !chain.Testnet {
+				continue
+			}
+			if networkListMainnets && chain.Testnet {
+				continue
+			}
+			if chain.Deprecated && !verbose {
+				continue
+			}
+			networks = append(networks, chain)
+		}
+
+		if quiet {
+			if len(networks) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(networks)
+		}
+
+		builder := new(strings.Builder)
+		for _, chain := range networks {
+			builder.WriteString(fmt.Sprintf("%d\t%s (%s)\t%s", chain.ID, chain.Name, chain.ShortName, chain.Currency))
+			if chain.Explorer != "" {
+				builder.WriteString(fmt.Sprintf("\t%s", chain.Explorer))
+			}
+			if chain.Testnet {
+				builder.WriteString("\ttestnet")
+			}
+			if chain.Deprecated {
+				builder.WriteString("\tdeprecated")
+			}
+			builder.WriteString("\n")
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	offlineCmds["network:list"] = true
+	networkCmd.AddCommand(networkListCmd)
+	networkFlags(networkListCmd)
+	networkListCmd.Flags().BoolVar(&networkListTestnets, "testnets", false, "Only list test networks")
+	networkListCmd.Flags().BoolVar(&networkListMainnets, "mainnets", false, "Only list production networks")
+}
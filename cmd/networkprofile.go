@@ -0,0 +1,133 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+)
+
+// networkProfile is a named set of network settings from the configuration file, for example:
+//
+//	networks:
+//	  sepolia:
+//	    connection: https://sepolia.example.com/
+//	    chainid: 11155111
+//	    explorer: https://sepolia.etherscan.io/
+//	    beacon-connection: http://localhost:5052/
+//	    bundler-url: https://bundler.example.com/
+//	    default-account: 0x5FfC014343cd971B7eb70732021E26C35B744cc4
+//	    price-feed: 0x694AA1769357215DE4FAC081bf1f309aDC325306
+type networkProfile struct {
+	name             string
+	connection       []string
+	chainID          string
+	explorer         string
+	beaconConnection string
+	bundlerURL       string
+	defaultAccount   string
+	priceFeed        string
+	trustedBlock     string
+}
+
+// profile is the network profile in use, if any.
+var profile *networkProfile
+
+// selectNetworkProfile obtains the profile for the network selected with --network, if it exists.
+func selectNetworkProfile() *networkProfile {
+	name := strings.ToLower(viper.GetString("network"))
+	key := fmt.Sprintf("networks.%s", name)
+	if name == "" || !viper.IsSet(key) {
+		return nil
+	}
+	return &networkProfile{
+		name:             name,
+		connection:       viper.GetStringSlice(key + ".connection"),
+		chainID:          viper.GetString(key + ".chainid"),
+		explorer:         strings.TrimSuffix(viper.GetString(key+".explorer"), "/"),
+		beaconConnection: viper.GetString(key + ".beacon-connection"),
+		bundlerURL:       viper.GetString(key + ".bundler-url"),
+		defaultAccount:   viper.GetString(key + ".default-account"),
+		priceFeed:        viper.GetString(key + ".price-feed"),
+		trustedBlock:     viper.GetString(key + ".trusted-block"),
+	}
+}
+
+// apply applies the settings in the profile.  Settings supplied on the command line take precedence.
+func (p *networkProfile) apply(cmd *cobra.Command) {
+	if len(p.connection) > 0 && !flagChanged(cmd, "connection") {
+		viper.Set("connection", p.connection)
+	}
+	if p.chainID != "" && !flagChanged(cmd, "chainid") {
+		viper.Set("chainid", p.chainID)
+	}
+	if p.beaconConnection != "" && !flagChanged(cmd, "beacon-connection") {
+		viper.Set("beacon-connection", p.beaconConnection)
+	}
+	if p.bundlerURL != "" {
+		viper.Set("bundler-url", p.bundlerURL)
+	}
+	if p.defaultAccount != "" {
+		viper.Set("default-account", p.defaultAccount)
+	}
+	if p.priceFeed != "" {
+		viper.Set("price-feed", p.priceFeed)
+	}
+	if p.trustedBlock != "" && !flagChanged(cmd, "trusted-block") {
+		viper.Set("trusted-block", p.trustedBlock)
+	}
+}
+
+// checkChainID checks that the chain ID of the connection matches that in the profile.
+func (p *networkProfile) checkChainID(chainID *big.Int) error {
+	if p.chainID == "" {
+		return nil
+	}
+	expected, success := new(big.Int).SetString(p.chainID, 0)
+	if !success {
+		return fmt.Errorf("invalid chain ID %s in network profile %s", p.chainID, p.name)
+	}
+	if expected.Cmp(chainID) != 0 {
+		return fmt.Errorf("connected to chain %s but network profile %s is for chain %s", chainID, p.name, expected)
+	}
+	return nil
+}
+
+// flagChanged returns true if the flag was supplied on the command line.
+func flagChanged(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flag(name)
+	return flag != nil && flag.Changed
+}
+
+// explorerTransactionURL returns the URL of the transaction in the block explorer of the
+// network profile or, failing that, of the chain if it is known.  It returns an empty string
+// if there is no explorer.
+func explorerTransactionURL(hash common.Hash) string {
+	if profile != nil && profile.explorer != "" {
+		return fmt.Sprintf("%s/tx/%s", profile.explorer, hash.Hex())
+	}
+	if c == nil {
+		return ""
+	}
+	if chain := chains.ByID(c.ChainID()); chain != nil {
+		return chain.TransactionURL(hash.Hex())
+	}
+	return ""
+}
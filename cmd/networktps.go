@@ -55,9 +55,7 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 				lastBlockNumber = lastBlockNumber.Add(lastBlockNumber, big.NewInt(1))
 				blockDuration := lastBlockTime.Sub(blockTime).Seconds()
 				duration += blockDuration
-				if verbose {
-					fmt.Printf("Block %v processed %v transactions in %v seconds\n", lastBlockNumber, lastBlockTransactions, blockDuration)
-				}
+				outputIf(verbose, fmt.Sprintf("Block %v processed %v transactions in %v seconds", lastBlockNumber, lastBlockTransactions, blockDuration))
 			}
 
 			blockNumber = big.NewInt(0).Set(block.Number())
@@ -76,6 +74,9 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 		}
 
 		transactionsPerSecond := float64(transactions) / duration
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"transactions_per_second": transactionsPerSecond})
+		}
 		fmt.Printf("%.2f\n", transactionsPerSecond)
 	},
 }
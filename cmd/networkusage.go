@@ -44,9 +44,7 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 			cli.ErrCheck(err, quiet, "Failed to obtain information about block")
 
 			gasPct := big.NewFloat(0).Quo(big.NewFloat(0).Mul(big.NewFloat(100), big.NewFloat(0).SetInt(big.NewInt(int64(block.GasUsed())))), big.NewFloat(0).SetInt(big.NewInt(int64(block.GasLimit()))))
-			if verbose {
-				fmt.Printf("Block %v used %s%% of gas limit (%v/%v)\n", block.Number(), gasPct.Text('f', 2), block.GasUsed(), block.GasLimit())
-			}
+			outputIf(verbose, fmt.Sprintf("Block %v used %s%% of gas limit (%v/%v)", block.Number(), gasPct.Text('f', 2), block.GasUsed(), block.GasLimit()))
 
 			gas += block.GasUsed()
 			gasLimit += block.GasLimit()
@@ -66,6 +64,10 @@ In quiet mode this will return 0 if the network is processing transactions, othe
 		}
 
 		gasPct := big.NewFloat(0).Quo(big.NewFloat(0).Mul(big.NewFloat(100), big.NewFloat(0).SetInt(big.NewInt(int64(gas)))), big.NewFloat(0).SetInt(big.NewInt(int64(gasLimit))))
+		if jsonOutput() {
+			usage, _ := gasPct.Float64()
+			outputJSON(map[string]interface{}{"gas_used": gas, "gas_limit": gasLimit, "gas_used_percent": usage})
+		}
 		fmt.Printf("%s%%\n", gasPct.Text('f', 2))
 	},
 }
@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var nftContractStr string
+var nftTokenIDStr string
+
+// nftCmd represents the nft command
+var nftCmd = &cobra.Command{
+	Use:   "nft",
+	Short: "Manage non-fungible tokens",
+	Long:  `Obtain information about, approve and transfer ERC-721 non-fungible tokens.`,
+}
+
+// nftContract obtains the NFT contract given an address or ENS name.
+func nftContract(input string) (*contracts.ERC721, error) {
+	address, err := c.Resolve(input)
+	if err != nil {
+		return nil, err
+	}
+	return contracts.NewERC721(address, c.Client())
+}
+
+// nftTokenID parses a token ID, which can be decimal or hex with a 0x prefix.
+func nftTokenID(input string) (*big.Int, error) {
+	value, base := input, 10
+	if strings.HasPrefix(input, "0x") {
+		value, base = input[2:], 16
+	}
+	tokenID, ok := new(big.Int).SetString(value, base)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token ID %s", input)
+	}
+	return tokenID, nil
+}
+
+func init() {
+	RootCmd.AddCommand(nftCmd)
+}
+
+func nftFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&nftContractStr, "contract", "", "Address or ENS name of the NFT contract")
+}
+
+func nftTokenIDFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&nftTokenIDStr, "token-id", "", "ID of the token")
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var nftApproveHolderAddress string
+var nftApproveSpenderAddress string
+var nftApproveAll bool
+var nftApproveRevoke bool
+
+// nftApproveCmd represents the nft approve command
+var nftApproveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve an address to transfer NFTs",
+	Long: `Approve an address to transfer a token from an NFT contract on behalf of its holder.  For example:
+
+    ethereal nft approve --contract=0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D --token-id=1 --holder=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --spender=0x52f1A3027d3aA514F17E454C93ae1F79b3B12d5d --passphrase=secret
+
+If --all is supplied instead of --token-id the spender is approved to transfer all of the holder's tokens from the contract.  If --revoke is supplied the approval is removed; when revoking the approval for a single token --spender is not required.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(nftApproveHolderAddress != "", quiet, "--holder is required")
+		holderAddress, err := c.Resolve(nftApproveHolderAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve holder address %s", nftApproveHolderAddress))
+
+		var spenderAddress common.Address
+		if nftApproveSpenderAddress != "" {
+			spenderAddress, err = c.Resolve(nftApproveSpenderAddress)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve spender address %s", nftApproveSpenderAddress))
+		} else {
+			cli.Assert(nftApproveRevoke && !nftApproveAll, quiet, "--spender is required")
+		}
+
+		cli.Assert(nftContractStr != "", quiet, "--contract is required")
+		contract, err := nftContract(nftContractStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT contract")
+
+		if offline {
+			cli.Assert(viper.GetInt64("gaslimit") > 0, quiet, "--gaslimit is required if offline")
+		}
+
+		opts, err := generateTxOpts(holderAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+
+		fields := log.Fields{
+			"group":        "nft",
+			"command":      "approve",
+			"contract":     nftContractStr,
+			"tokenholder":  holderAddress.Hex(),
+			"tokenspender": spenderAddress.Hex(),
+			"approved":     !nftApproveRevoke,
+		}
+		var signedTx *types.Transaction
+		if nftApproveAll {
+			cli.Assert(nftTokenIDStr == "", quiet, "--token-id cannot be supplied with --all")
+			signedTx, err = contract.SetApprovalForAll(opts, spenderAddress, !nftApproveRevoke)
+			cli.ErrCheck(err, quiet, "Failed to create transaction")
+		} else {
+			cli.Assert(nftTokenIDStr != "", quiet, "--token-id or --all is required")
+			tokenID, err := nftTokenID(nftTokenIDStr)
+			cli.ErrCheck(err, quiet, "Invalid token ID")
+			fields["tokenid"] = tokenID.String()
+			if nftApproveRevoke {
+				spenderAddress = common.Address{}
+			}
+			signedTx, err = contract.Approve(opts, spenderAddress, tokenID)
+			cli.ErrCheck(err, quiet, "Failed to create transaction")
+		}
+
+		if offline {
+			outputSignedTransaction(signedTx)
+			os.Exit(exitSuccess)
+		}
+
+		handleSubmittedTransaction(signedTx, fields, true)
+	},
+}
+
+func init() {
+	nftCmd.AddCommand(nftApproveCmd)
+	nftFlags(nftApproveCmd)
+	nftTokenIDFlags(nftApproveCmd)
+	nftApproveCmd.Flags().StringVar(&nftApproveHolderAddress, "holder", "", "Address that holds the token")
+	nftApproveCmd.Flags().StringVar(&nftApproveSpenderAddress, "spender", "", "Address that can transfer the token")
+	nftApproveCmd.Flags().BoolVar(&nftApproveAll, "all", false, "Approve the spender for all of the holder's tokens")
+	nftApproveCmd.Flags().BoolVar(&nftApproveRevoke, "revoke", false, "Revoke the approval")
+	addTransactionFlags(nftApproveCmd, "the address that holds the token")
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var nftBalanceHolderAddress string
+
+// nftBalanceCmd represents the nft balance command
+var nftBalanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Obtain the number of NFTs held by an address",
+	Long: `Obtain the number of tokens from an NFT contract held by an address.  For example:
+
+    ethereal nft balance --contract=0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D --holder=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+In quiet mode this will return 0 if the balance is greater than 0, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(nftBalanceHolderAddress != "", quiet, "--holder is required")
+		address, err := c.Resolve(nftBalanceHolderAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve holder address %s", nftBalanceHolderAddress))
+
+		cli.Assert(nftContractStr != "", quiet, "--contract is required")
+		contract, err := nftContract(nftContractStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT contract")
+
+		balance, err := contract.BalanceOf(tokenCallOpts(), address)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT balance")
+
+		if quiet {
+			if balance.Sign() == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"holder":  address.Hex(),
+				"balance": balance.String(),
+			})
+		}
+
+		fmt.Printf("%s\n", balance.String())
+	},
+}
+
+func init() {
+	nftCmd.AddCommand(nftBalanceCmd)
+	nftFlags(nftBalanceCmd)
+	tokenBlockFlags(nftBalanceCmd)
+	nftBalanceCmd.Flags().StringVar(&nftBalanceHolderAddress, "holder", "", "Holder of tokens")
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/nftmetadata"
+)
+
+var nftMetadataIPFSGateway string
+
+// nftMetadataCmd represents the nft metadata command
+var nftMetadataCmd = &cobra.Command{
+	Use:   "metadata",
+	Short: "Obtain the metadata of an NFT",
+	Long: `Obtain the metadata of a token from an NFT contract, as referenced by its token URI.  For example:
+
+    ethereal nft metadata --contract=0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D --token-id=1
+
+Token URIs on IPFS and Arweave are fetched through a gateway, which can be changed with --ipfs-gateway.  Token URIs that contain the metadata directly as data: URIs are also supported.
+
+In quiet mode this will return 0 if the metadata can be obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(nftContractStr != "", quiet, "--contract is required")
+		contract, err := nftContract(nftContractStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT contract")
+
+		cli.Assert(nftTokenIDStr != "", quiet, "--token-id is required")
+		tokenID, err := nftTokenID(nftTokenIDStr)
+		cli.ErrCheck(err, quiet, "Invalid token ID")
+
+		tokenURI, err := contract.TokenURI(tokenCallOpts(), tokenID)
+		cli.ErrCheck(err, quiet, "Failed to obtain token URI")
+		cli.Assert(tokenURI != "", quiet, "Token does not have a URI")
+
+		gateway := nftMetadataIPFSGateway
+		if gateway == "" {
+			gateway = viper.GetString("ipfs-gateway")
+		}
+		fetcher := nftmetadata.New(gateway)
+		ctx, cancel := localContext()
+		defer cancel()
+		metadata, err := fetcher.Fetch(ctx, tokenURI)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain metadata from %s", tokenURI))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&nftMetadataJSON{
+				TokenID:  tokenID.String(),
+				TokenURI: tokenURI,
+				Metadata: metadata.Raw,
+			})
+		}
+
+		if verbose && len(tokenURI) < 256 {
+			fmt.Printf("Token URI:\t%s\n", tokenURI)
+		}
+		if metadata.Name != "" {
+			fmt.Printf("Name:\t\t%s\n", metadata.Name)
+		}
+		if metadata.Description != "" {
+			fmt.Printf("Description:\t%s\n", metadata.Description)
+		}
+		switch {
+		case strings.HasPrefix(metadata.Image, "data:"):
+			fmt.Println("Image:\t\tEmbedded in metadata")
+		case metadata.Image != "":
+			fmt.Printf("Image:\t\t%s\n", fetcher.ResolveURI(metadata.Image))
+		}
+		if metadata.ExternalURL != "" {
+			fmt.Printf("External URL:\t%s\n", metadata.ExternalURL)
+		}
+		if len(metadata.Attributes) > 0 {
+			fmt.Println("Attributes:")
+			for _, attribute := range metadata.Attributes {
+				if attribute.TraitType == "" {
+					fmt.Printf("\t%v\n", attribute.Value)
+				} else {
+					fmt.Printf("\t%s: %v\n", attribute.TraitType, attribute.Value)
+				}
+			}
+		}
+	},
+}
+
+// nftMetadataJSON is the JSON output for the nft metadata command.
+type nftMetadataJSON struct {
+	TokenID  string          `json:"token_id"`
+	TokenURI string          `json:"token_uri"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+func init() {
+	nftCmd.AddCommand(nftMetadataCmd)
+	nftFlags(nftMetadataCmd)
+	nftTokenIDFlags(nftMetadataCmd)
+	tokenBlockFlags(nftMetadataCmd)
+	nftMetadataCmd.Flags().StringVar(&nftMetadataIPFSGateway, "ipfs-gateway", "", fmt.Sprintf("Gateway through which to fetch IPFS content (default %s)", nftmetadata.DefaultIPFSGateway))
+}
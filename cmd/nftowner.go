@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// nftOwnerCmd represents the nft owner command
+var nftOwnerCmd = &cobra.Command{
+	Use:   "owner",
+	Short: "Obtain the owner of an NFT",
+	Long: `Obtain the owner of a token from an NFT contract.  For example:
+
+    ethereal nft owner --contract=0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D --token-id=1
+
+In quiet mode this will return 0 if the token has an owner, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(nftContractStr != "", quiet, "--contract is required")
+		contract, err := nftContract(nftContractStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT contract")
+
+		cli.Assert(nftTokenIDStr != "", quiet, "--token-id is required")
+		tokenID, err := nftTokenID(nftTokenIDStr)
+		cli.ErrCheck(err, quiet, "Invalid token ID")
+
+		opts := tokenCallOpts()
+		owner, err := contract.OwnerOf(opts, tokenID)
+		cli.ErrCheck(err, quiet, "Failed to obtain owner")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			res := map[string]interface{}{
+				"token_id": tokenID.String(),
+				"owner":    owner.Hex(),
+			}
+			if verbose {
+				approved, err := contract.GetApproved(opts, tokenID)
+				if err == nil {
+					res["approved"] = approved.Hex()
+				}
+			}
+			outputJSON(res)
+		}
+
+		fmt.Printf("%s\n", formatAddress(owner))
+		if verbose {
+			approved, err := contract.GetApproved(opts, tokenID)
+			if err == nil && approved != unknownAddress {
+				fmt.Printf("Approved: %s\n", formatAddress(approved))
+			}
+		}
+	},
+}
+
+func init() {
+	nftCmd.AddCommand(nftOwnerCmd)
+	nftFlags(nftOwnerCmd)
+	nftTokenIDFlags(nftOwnerCmd)
+	tokenBlockFlags(nftOwnerCmd)
+}
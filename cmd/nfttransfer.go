@@ -0,0 +1,96 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+var nftTransferFromAddress string
+var nftTransferToAddress string
+
+// nftTransferCmd represents the nft transfer command
+var nftTransferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Transfer an NFT to a given address",
+	Long: `Transfer a token from an NFT contract from one address to another.  For example:
+
+    ethereal nft transfer --contract=0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D --token-id=1 --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x52f1A3027d3aA514F17E454C93ae1F79b3B12d5d --passphrase=secret
+
+The transfer uses safeTransferFrom(), so will fail if the recipient is a contract that does not accept NFTs.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nftTransferFromAddress = accountOrDefault(nftTransferFromAddress)
+		cli.Assert(nftTransferFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(nftTransferFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", nftTransferFromAddress))
+
+		cli.Assert(nftTransferToAddress != "", quiet, "--to is required")
+		toAddress, err := c.Resolve(nftTransferToAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", nftTransferToAddress))
+
+		cli.Assert(nftContractStr != "", quiet, "--contract is required")
+		contract, err := nftContract(nftContractStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain NFT contract")
+
+		cli.Assert(nftTokenIDStr != "", quiet, "--token-id is required")
+		tokenID, err := nftTokenID(nftTokenIDStr)
+		cli.ErrCheck(err, quiet, "Invalid token ID")
+
+		if offline {
+			cli.Assert(viper.GetInt64("gaslimit") > 0, quiet, "--gaslimit is required if offline")
+		} else {
+			// Ensure that the sender owns the token.
+			owner, err := contract.OwnerOf(nil, tokenID)
+			cli.ErrCheck(err, quiet, "Failed to obtain owner of token")
+			cli.Assert(owner == fromAddress, quiet, fmt.Sprintf("Token is owned by %s", owner.Hex()))
+		}
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+
+		signedTx, err := contract.SafeTransferFrom(opts, fromAddress, toAddress, tokenID)
+		cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+		if offline {
+			outputSignedTransaction(signedTx)
+			os.Exit(exitSuccess)
+		}
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":          "nft",
+			"command":        "transfer",
+			"contract":       nftContractStr,
+			"tokenid":        tokenID.String(),
+			"tokenholder":    fromAddress.Hex(),
+			"tokenrecipient": toAddress.Hex(),
+		}, true)
+	},
+}
+
+func init() {
+	nftCmd.AddCommand(nftTransferCmd)
+	nftFlags(nftTransferCmd)
+	nftTokenIDFlags(nftTransferCmd)
+	nftTransferCmd.Flags().StringVar(&nftTransferFromAddress, "from", "", "Address from which to transfer the token")
+	nftTransferCmd.Flags().StringVar(&nftTransferToAddress, "to", "", "Address to which to transfer the token")
+	addTransactionFlags(nftTransferCmd, "the address from which to transfer the token")
+}
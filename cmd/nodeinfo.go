@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// nodeInfoCmd represents the node info command
+var nodeInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about the node",
+	Long: `Obtain information about the node, including its client, identity and network addresses.  For example:
+
+    ethereal node info --connection=/home/ethereum/.ethereum/geth.ipc
+
+This requires the node to expose the admin API, which is usually only available over IPC.
+
+In quiet mode this will return 0 if the information is obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain node information when offline")
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		info, err := c.NodeInfo(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain node information")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+		if jsonOutput() {
+			outputJSON(info)
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Name: %s\n", info.Name))
+		builder.WriteString(fmt.Sprintf("ID: %s\n", info.ID))
+		builder.WriteString(fmt.Sprintf("Enode: %s\n", info.Enode))
+		if info.ENR != "" {
+			builder.WriteString(fmt.Sprintf("ENR: %s\n", info.ENR))
+		}
+		builder.WriteString(fmt.Sprintf("IP: %s\n", info.IP))
+		builder.WriteString(fmt.Sprintf("Listen address: %s\n", info.ListenAddr))
+		builder.WriteString(fmt.Sprintf("Ports: %d (listener), %d (discovery)\n", info.Ports.Listener, info.Ports.Discovery))
+		if len(info.Protocols) > 0 {
+			protocols := make([]string, 0, len(info.Protocols))
+			for protocol := range info.Protocols {
+				protocols = append(protocols, protocol)
+			}
+			sort.Strings(protocols)
+			builder.WriteString(fmt.Sprintf("Protocols: %s\n", strings.Join(protocols, ", ")))
+		}
+		if eth, isMap := info.Protocols["eth"].(map[string]interface{}); isMap && verbose {
+			if network, exists := eth["network"]; exists {
+				builder.WriteString(fmt.Sprintf("Network ID: %v\n", network))
+			}
+			if genesis, exists := eth["genesis"]; exists {
+				builder.WriteString(fmt.Sprintf("Genesis: %v\n", genesis))
+			}
+			if head, exists := eth["head"]; exists {
+				builder.WriteString(fmt.Sprintf("Head: %v\n", head))
+			}
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeInfoCmd)
+	nodeFlags(nodeInfoCmd)
+}
@@ -0,0 +1,87 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// nodePeersCmd represents the node peers command
+var nodePeersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "Obtain the peers of the node",
+	Long: `Obtain information about the peers of the node.  For example:
+
+    ethereal node peers --connection=/home/ethereum/.ethereum/geth.ipc
+
+This requires the node to expose the admin API, which is usually only available over IPC.
+
+In quiet mode this will return 0 if the node has at least one peer, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain peers when offline")
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		peers, err := c.Peers(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain peers")
+
+		if quiet {
+			if len(peers) > 0 {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(peers)
+		}
+
+		inbound := 0
+		for _, peer := range peers {
+			if peer.Network.Inbound {
+				inbound++
+			}
+		}
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Peers: %d (%d inbound, %d outbound)\n", len(peers), inbound, len(peers)-inbound))
+		for _, peer := range peers {
+			direction := "outbound"
+			if peer.Network.Inbound {
+				direction = "inbound"
+			}
+			id := peer.ID
+			if len(id) > 16 {
+				id = id[:16]
+			}
+			builder.WriteString(fmt.Sprintf("  %s\t%s\t%s\t%s\n", id, peer.Network.RemoteAddress, direction, peer.Name))
+			if verbose {
+				builder.WriteString(fmt.Sprintf("    Enode: %s\n", peer.Enode))
+				builder.WriteString(fmt.Sprintf("    Capabilities: %s\n", strings.Join(peer.Caps, ", ")))
+			}
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodePeersCmd)
+	nodeFlags(nodePeersCmd)
+}
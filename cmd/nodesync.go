@@ -23,8 +23,9 @@ import (
 
 // nodeSyncCmd represents the node sync command
 var nodeSyncCmd = &cobra.Command{
-	Use:   "sync",
-	Short: "Obtain sync information",
+	Use:     "sync",
+	Aliases: []string{"syncing"},
+	Short:   "Obtain sync information",
 	Long: `Obtain information about the synchronisation state of the node.  For example:
 
     ethereal node sync
@@ -45,6 +46,15 @@ In quiet mode this will return 0 if the node is synchronised, otherwise 1.`,
 			os.Exit(exitFailure)
 		}
 
+		if jsonOutput() {
+			res := map[string]interface{}{"synced": syncProgress == nil}
+			if syncProgress != nil {
+				res["current_block"] = syncProgress.CurrentBlock
+				res["highest_block"] = syncProgress.HighestBlock
+			}
+			outputJSON(res)
+		}
+
 		if syncProgress == nil {
 			fmt.Printf("Node is synchronised\n")
 		} else {
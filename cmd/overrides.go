@@ -0,0 +1,132 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/conn"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var overrideBalances []string
+var overrideNonces []string
+var overrideCodes []string
+var overrideStorage []string
+
+// stateOverrideFlags adds flags for overriding state for the duration of a call.
+func stateOverrideFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&overrideBalances, "override-balance", nil, "Override the balance of an address for the call, in the form address=amount (can be repeated)")
+	cmd.Flags().StringArrayVar(&overrideNonces, "override-nonce", nil, "Override the nonce of an address for the call, in the form address=nonce (can be repeated)")
+	cmd.Flags().StringArrayVar(&overrideCodes, "override-code", nil, "Override the code of an address for the call, in the form address=code (can be repeated)")
+	cmd.Flags().StringArrayVar(&overrideStorage, "override-storage", nil, "Override a storage slot of an address for the call, in the form address:slot=value (can be repeated)")
+}
+
+// parseStateOverrides parses the state override flags.
+func parseStateOverrides() (map[common.Address]*conn.OverrideAccount, error) {
+	overrides := make(map[common.Address]*conn.OverrideAccount)
+	account := func(input string) (*conn.OverrideAccount, error) {
+		address, err := c.Resolve(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve address %s: %v", input, err)
+		}
+		if _, exists := overrides[address]; !exists {
+			overrides[address] = &conn.OverrideAccount{}
+		}
+		return overrides[address], nil
+	}
+
+	for _, override := range overrideBalances {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("balance override %s must be in the form address=amount", override)
+		}
+		amount, err := string2eth.StringToWei(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid balance %s: %v", parts[1], err)
+		}
+		acc, err := account(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		acc.Balance = (*hexutil.Big)(amount)
+	}
+
+	for _, override := range overrideNonces {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("nonce override %s must be in the form address=nonce", override)
+		}
+		nonce, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce %s", parts[1])
+		}
+		acc, err := account(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		acc.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+
+	for _, override := range overrideCodes {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("code override %s must be in the form address=code", override)
+		}
+		code, err := hexutil.Decode(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid code %s: %v", parts[1], err)
+		}
+		acc, err := account(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		acc.Code = (*hexutil.Bytes)(&code)
+	}
+
+	for _, override := range overrideStorage {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("storage override %s must be in the form address:slot=value", override)
+		}
+		addressAndSlot := strings.SplitN(parts[0], ":", 2)
+		if len(addressAndSlot) != 2 {
+			return nil, fmt.Errorf("storage override %s must be in the form address:slot=value", override)
+		}
+		slot, success := new(big.Int).SetString(addressAndSlot[1], 0)
+		if !success {
+			return nil, fmt.Errorf("invalid storage slot %s", addressAndSlot[1])
+		}
+		value, success := new(big.Int).SetString(parts[1], 0)
+		if !success {
+			return nil, fmt.Errorf("invalid storage value %s", parts[1])
+		}
+		acc, err := account(addressAndSlot[0])
+		if err != nil {
+			return nil, err
+		}
+		if acc.StateDiff == nil {
+			acc.StateDiff = make(map[common.Hash]common.Hash)
+		}
+		acc.StateDiff[common.BigToHash(slot)] = common.BigToHash(value)
+	}
+
+	return overrides, nil
+}
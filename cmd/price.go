@@ -0,0 +1,102 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/price"
+)
+
+// defaultPriceCacheTTL is the time for which prices are cached if price-cache-ttl is not set.
+const defaultPriceCacheTTL = 5 * time.Minute
+
+// fiatPrice is the price of 1 Ether in a fiat currency.
+type fiatPrice struct {
+	currency string
+	price    *big.Float
+}
+
+// value returns the value of an amount of Wei in the currency, to 2 decimal places.
+func (p *fiatPrice) value(wei *big.Int) string {
+	return price.Value(p.price, wei).Text('f', 2)
+}
+
+// format formats the value of an amount of Wei along with the currency.
+func (p *fiatPrice) format(wei *big.Int) string {
+	return price.Format(price.Value(p.price, wei), p.currency)
+}
+
+// formatWeiFiat formats a value in Wei, followed by its value in the fiat currency of the price if any.
+func formatWeiFiat(value *big.Int, price *fiatPrice) string {
+	if price == nil {
+		return formatWei(value)
+	}
+	return fmt.Sprintf("%s (%s)", formatWei(value), price.format(value))
+}
+
+// etherPrice returns the price of Ether in the fiat currency selected with --fiat, or nil if no
+// currency is selected or --no-price is supplied.  The price is obtained from the price feed if
+// one is configured, in which case the currency defaults to that of the feed, otherwise from the
+// price source, by default CoinGecko.
+func etherPrice() *fiatPrice {
+	if viper.GetBool("no-price") {
+		return nil
+	}
+	currency := strings.ToUpper(viper.GetString("fiat"))
+	feed := viper.GetString("price-feed")
+	if currency == "" && feed == "" {
+		return nil
+	}
+
+	ctx, cancel := localContext()
+	defer cancel()
+
+	if feed != "" {
+		cli.Assert(!offline, quiet, "Cannot obtain price from price feed when offline")
+		feedAddress, err := c.Resolve(feed)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve price feed %s", feed))
+		feedPrice, err := c.EtherPrice(ctx, feedAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain price of Ether from price feed")
+		cli.Assert(currency == "" || strings.EqualFold(feedPrice.Currency, currency), quiet, fmt.Sprintf("Price feed is for %s rather than %s", feedPrice.Currency, currency))
+		outputIf(verbose, fmt.Sprintf("Price of Ether last updated at %s", feedPrice.UpdatedAt.Format(time.RFC3339)))
+		return &fiatPrice{
+			currency: strings.ToUpper(feedPrice.Currency),
+			price:    feedPrice.Value(big.NewInt(params.Ether)),
+		}
+	}
+
+	source, err := price.New(viper.GetString("price-source"), viper.GetString("coingecko-api-key"), viper.GetString("coingecko-url"))
+	cli.ErrCheck(err, quiet, "Failed to create price source")
+	if dir, err := dataDir(); err == nil {
+		ttl := defaultPriceCacheTTL
+		if viper.IsSet("price-cache-ttl") {
+			ttl = viper.GetDuration("price-cache-ttl")
+		}
+		source = price.NewCache(source, filepath.Join(dir, "prices.json"), ttl)
+	}
+	etherPrice, err := source.Price(ctx, currency)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain price of Ether in %s", currency))
+	return &fiatPrice{
+		currency: currency,
+		price:    etherPrice,
+	}
+}
@@ -48,8 +48,11 @@ In quiet mode this will return 0 if the implementer has an address, otherwise 1.
 		if *implementer == ens.UnknownAddress {
 			os.Exit(exitFailure)
 		}
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": address.Hex(), "interface": registryImplementerInterface, "implementer": implementer.Hex()})
+		}
 		if !quiet {
-			fmt.Printf("%s\n", ens.Format(c.Client(), *implementer))
+			fmt.Printf("%s\n", formatAddress(*implementer))
 		}
 		os.Exit(exitSuccess)
 	},
@@ -50,7 +50,9 @@ In quiet mode this will return 0 if the address implements the interface, otherw
 		implementsInterface, err := implementer.ImplementsInterface(registryImplementsInterface, &anyone)
 		cli.ErrCheck(err, quiet, "failed to obtain implementation status")
 
-		if !quiet {
+		if jsonOutput() {
+			writeJSON(map[string]interface{}{"address": address.Hex(), "interface": registryImplementsInterface, "implements": implementsInterface})
+		} else if !quiet {
 			if implementsInterface {
 				fmt.Println("Yes")
 			} else {
@@ -49,8 +49,11 @@ In quiet mode this will return 0 if the manager was obtained without error, othe
 			manager = &address
 		}
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"address": address.Hex(), "manager": manager.Hex()})
+		}
 		if !quiet {
-			fmt.Printf("%s\n", ens.Format(c.Client(), *manager))
+			fmt.Printf("%s\n", formatAddress(*manager))
 		}
 		os.Exit(exitSuccess)
 	},
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -35,6 +36,9 @@ import (
 	"github.com/wealdtech/ethereal/v2/cli"
 	"github.com/wealdtech/ethereal/v2/conn"
 	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+	"github.com/wealdtech/ethereal/v2/util/output"
+	ens "github.com/wealdtech/go-ens/v3"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
 
@@ -43,6 +47,9 @@ var quiet bool
 var verbose bool
 var debug bool
 var offline bool
+var dryRun bool
+var outputFormat output.Format
+var outputUnit output.Unit
 
 // c is the connection to the execution node.
 var c *conn.Conn
@@ -69,6 +76,11 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	outputFormat, err = output.ParseFormat(viper.GetString("output"))
+	cli.ErrCheck(err, false, "Invalid output format")
+	outputUnit, err = output.ParseUnit(viper.GetString("unit"))
+	cli.ErrCheck(err, false, "Invalid unit")
+
 	if cmd.Name() == "version" {
 		// User just wants the version
 		return
@@ -79,6 +91,10 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 	verbose = viper.GetBool("verbose")
 	debug = viper.GetBool("debug")
 	offline = viper.GetBool("offline")
+	dryRun = viper.GetBool("dry-run")
+	if offline && dryRun {
+		cli.Err(false, "Cannot supply both offline and dry-run flags")
+	}
 
 	// If the command does not require access to the chain then override offline accordingly
 	if offlineCmds[cmdPath(cmd)] {
@@ -101,6 +117,21 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 	if cmd.Flags().Lookup("privatekey") != nil {
 		cli.ErrCheck(viper.BindPFlag("privatekey", cmd.Flags().Lookup("privatekey")), quiet, "failed to bind flag")
 	}
+	if cmd.Flags().Lookup("mnemonic") != nil {
+		cli.ErrCheck(viper.BindPFlag("mnemonic", cmd.Flags().Lookup("mnemonic")), quiet, "failed to bind flag")
+	}
+	if cmd.Flags().Lookup("hd-path") != nil {
+		cli.ErrCheck(viper.BindPFlag("hd-path", cmd.Flags().Lookup("hd-path")), quiet, "failed to bind flag")
+	}
+	if cmd.Flags().Lookup("external-signer") != nil {
+		cli.ErrCheck(viper.BindPFlag("external-signer", cmd.Flags().Lookup("external-signer")), quiet, "failed to bind flag")
+	}
+	if cmd.Flags().Lookup("ledger") != nil {
+		cli.ErrCheck(viper.BindPFlag("ledger", cmd.Flags().Lookup("ledger")), quiet, "failed to bind flag")
+	}
+	if cmd.Flags().Lookup("trezor") != nil {
+		cli.ErrCheck(viper.BindPFlag("trezor", cmd.Flags().Lookup("trezor")), quiet, "failed to bind flag")
+	}
 	if cmd.Flags().Lookup("value") != nil {
 		cli.ErrCheck(viper.BindPFlag("value", cmd.Flags().Lookup("value")), quiet, "failed to bind flag")
 	}
@@ -110,6 +141,12 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 	if cmd.Flags().Lookup("limit") != nil {
 		cli.ErrCheck(viper.BindPFlag("limit", cmd.Flags().Lookup("limit")), quiet, "failed to bind flag")
 	}
+	if cmd.Flags().Lookup("beacon-connection") != nil {
+		cli.ErrCheck(viper.BindPFlag("beacon-connection", cmd.Flags().Lookup("beacon-connection")), quiet, "failed to bind flag")
+	}
+	if cmd.Flags().Lookup("confirmations") != nil {
+		cli.ErrCheck(viper.BindPFlag("confirmations", cmd.Flags().Lookup("confirmations")), quiet, "failed to bind flag")
+	}
 
 	// Items that must be manually supplied if we are attempting to create transactions offline.
 	if cmd.Flags().Lookup("chainid") != nil {
@@ -121,6 +158,9 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 	if cmd.Flags().Lookup("nonce") != nil {
 		cli.ErrCheck(viper.BindPFlag("nonce", cmd.Flags().Lookup("nonce")), quiet, "failed to bind flag")
 	}
+	if cmd.Flags().Lookup("signed-tx-file") != nil {
+		cli.ErrCheck(viper.BindPFlag("signed-tx-file", cmd.Flags().Lookup("signed-tx-file")), quiet, "failed to bind flag")
+	}
 
 	// Set up gas prices.
 	setUpGasPrices(cmd)
@@ -129,34 +169,56 @@ func persistentPreRun(cmd *cobra.Command, args []string) {
 		cli.ErrCheck(viper.BindPFlag("gaslimit", cmd.Flags().Lookup("gaslimit")), quiet, "failed to bind flag")
 	}
 
+	// Apply the settings for the selected network.
+	profile = selectNetworkProfile()
+	if profile != nil {
+		profile.apply(cmd)
+	}
+
 	// Create a connection to an Ethereum node (or mock).
 	err = connect(context.Background())
 	cli.ErrCheck(err, quiet, "Failed to connect to Ethereum node")
+	if profile != nil && !offline {
+		cli.ErrCheck(profile.checkChainID(c.ChainID()), quiet, "Connection does not match network")
+	}
 }
 
 func setUpGasPrices(cmd *cobra.Command) {
-	if cmd.Flags().Lookup("priority-fee-per-gas") == nil {
+	if cmd.Flags().Lookup("max-priority-fee-per-gas") == nil {
 		// No gas price required.
 		return
 	}
 
 	// Check for priority fee per gas, and confirm it can be used.
-	cli.ErrCheck(viper.BindPFlag("priority-fee-per-gas", cmd.Flags().Lookup("priority-fee-per-gas")), quiet, "failed to bind flag")
-	if viper.GetString("priority-fee-per-gas") == "" {
-		// Set a default priority fee.
-		viper.Set("priority-fee-per-gas", "1.5gwei")
+	cli.ErrCheck(viper.BindPFlag("max-priority-fee-per-gas", cmd.Flags().Lookup("max-priority-fee-per-gas")), quiet, "failed to bind flag")
+	if viper.GetString("max-priority-fee-per-gas") == "" {
+		// Fall back to the deprecated name for the flag.
+		if cmd.Flags().Changed("priority-fee-per-gas") {
+			viper.Set("max-priority-fee-per-gas", cmd.Flags().Lookup("priority-fee-per-gas").Value.String())
+		} else if viper.GetString("priority-fee-per-gas") != "" {
+			viper.Set("max-priority-fee-per-gas", viper.GetString("priority-fee-per-gas"))
+		}
+	}
+	if viper.GetString("max-priority-fee-per-gas") != "" {
+		_, err := string2eth.StringToWei(viper.GetString("max-priority-fee-per-gas"))
+		cli.ErrCheck(err, quiet, "Invalid priority fee")
 	}
-	_, err := string2eth.StringToWei(viper.GetString("priority-fee-per-gas"))
-	cli.ErrCheck(err, quiet, "Invalid priority fee")
 
 	// Check for max fee per gas, and confirm it can be used.
 	cli.ErrCheck(viper.BindPFlag("max-fee-per-gas", cmd.Flags().Lookup("max-fee-per-gas")), quiet, "failed to bind flag")
-	if viper.GetString("max-fee-per-gas") == "" {
-		// Set a default fee.
-		viper.Set("max-fee-per-gas", "200gwei")
+	if viper.GetString("max-fee-per-gas") != "" {
+		_, err := string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
+		cli.ErrCheck(err, quiet, "Invalid fee")
+	}
+
+	// Check for max fee per blob gas, for commands that can send blob transactions.
+	if cmd.Flags().Lookup("max-fee-per-blob-gas") != nil {
+		cli.ErrCheck(viper.BindPFlag("max-fee-per-blob-gas", cmd.Flags().Lookup("max-fee-per-blob-gas")), quiet, "failed to bind flag")
+		if viper.GetString("max-fee-per-blob-gas") != "" {
+			_, err := string2eth.StringToWei(viper.GetString("max-fee-per-blob-gas"))
+			cli.ErrCheck(err, quiet, "Invalid blob fee")
+		}
 	}
-	_, err = string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
-	cli.ErrCheck(err, quiet, "Invalid fee")
 }
 
 // connect connects to an Ethereum node.
@@ -167,6 +229,13 @@ func connect(ctx context.Context) error {
 		// Handle offline connection.
 		c, err = conn.New(ctx, "offline")
 	} else {
+		if viper.GetBool("cache") && viper.GetString("cache-dir") == "" {
+			dir, err := dataDir()
+			if err != nil {
+				return err
+			}
+			viper.Set("cache-dir", filepath.Join(dir, "calls"))
+		}
 		var address string
 		address, err = connectionAddress(ctx)
 		if err == nil {
@@ -177,6 +246,19 @@ func connect(ctx context.Context) error {
 		return err
 	}
 
+	addresses, err = loadAddressBook()
+	if err != nil {
+		return err
+	}
+	c.SetLabels(addresses)
+	c.SetHeaderWarnings(func(msg string) {
+		cli.Warn(quiet, msg)
+	})
+	knownContracts, err = loadKnownContracts()
+	if err != nil {
+		return err
+	}
+
 	signer = types.NewLondonSigner(c.ChainID())
 
 	return nil
@@ -184,8 +266,9 @@ func connect(ctx context.Context) error {
 
 // connectionAddress provides the address of an execution client.
 func connectionAddress(ctx context.Context) (string, error) {
-	if viper.GetString("connection") != "" {
-		return viper.GetString("connection"), nil
+	if connections := viper.GetStringSlice("connection"); len(connections) > 0 {
+		// Multiple connections are passed on as a comma-separated list.
+		return strings.Join(connections, ","), nil
 	}
 
 	switch strings.ToLower(viper.GetString("network")) {
@@ -200,6 +283,9 @@ func connectionAddress(ctx context.Context) (string, error) {
 	case "sepolia":
 		return "https://sepolia.infura.io/v3/831a5442dc2e4536a9f8dee4ea1707a6", nil
 	default:
+		if chain := chains.ByName(viper.GetString("network")); chain != nil {
+			return "", fmt.Errorf("no default connection for %s; supply one with --connection or a network profile", chain.Name)
+		}
 		return "", fmt.Errorf("unknown network %s", viper.GetString("network"))
 	}
 }
@@ -231,7 +317,15 @@ func setupLogging() {
 // If exit is true this function will exit with a suitable status.
 // If exit is false this function will return false if asked to wait and the transaction is not
 // mined, otherwise true.
+// A transaction simulated with --dry-run has already been output, so is not logged or waited for.
 func handleSubmittedTransaction(tx *types.Transaction, logFields log.Fields, exit bool) bool {
+	if dryRun {
+		if exit {
+			os.Exit(successExitStatus())
+		}
+		return true
+	}
+
 	if logFields != nil {
 		logTransaction(tx, logFields)
 	}
@@ -241,31 +335,135 @@ func handleSubmittedTransaction(tx *types.Transaction, logFields log.Fields, exi
 	}
 
 	if !viper.GetBool("wait") {
-		outputIf(!quiet, tx.Hash().Hex())
+		if jsonOutput() {
+			writeJSON(&transactionJSON{Hash: tx.Hash().Hex()})
+		} else {
+			outputIf(!quiet, tx.Hash().Hex())
+			if url := explorerTransactionURL(tx.Hash()); url != "" {
+				outputIf(verbose, url)
+			}
+		}
 		if exit {
 			os.Exit(exitSuccess)
 		} else {
 			return true
 		}
 	}
-	mined := util.WaitForTransaction(c.Client(), tx.Hash(), viper.GetDuration("limit"))
-	if mined {
-		outputIf(!quiet, fmt.Sprintf("%s mined", tx.Hash().Hex()))
+	mined, err := c.WaitForTransaction(context.Background(), tx.Hash(), viper.GetUint64("confirmations"), viper.GetDuration("limit"))
+	if err == nil {
+		if jsonOutput() {
+			writeJSON(newMinedTransactionJSON(mined))
+		} else {
+			outputIf(!quiet, fmt.Sprintf("%s mined", tx.Hash().Hex()))
+			if url := explorerTransactionURL(tx.Hash()); url != "" {
+				outputIf(verbose, url)
+			}
+			outputMinedTransaction(mined)
+		}
 		if exit {
 			os.Exit(exitSuccess)
 		} else {
 			return true
 		}
 	}
-	outputIf(!quiet, fmt.Sprintf("%s submitted but not mined", tx.Hash().Hex()))
+	if !errors.Is(err, conn.ErrNotMined) {
+		outputIf(debug, fmt.Sprintf("Failed to wait for transaction: %v", err))
+	}
+	if jsonOutput() {
+		notMined := false
+		writeJSON(&transactionJSON{Hash: tx.Hash().Hex(), Mined: &notMined})
+	} else {
+		outputIf(!quiet, fmt.Sprintf("%s submitted but not mined", tx.Hash().Hex()))
+	}
 	if exit {
 		os.Exit(exitNotMined)
 	}
 	return false
 }
 
-// logTransaction logs a transaction
+// outputSignedTransaction outputs a signed transaction that has been created offline.  The
+// transaction is written as hex to the file given by --signed-tx-file if present, otherwise it is
+// printed.
+func outputSignedTransaction(tx *types.Transaction) {
+	data, err := tx.MarshalBinary()
+	cli.ErrCheck(err, quiet, "Failed to encode transaction")
+
+	if viper.GetString("signed-tx-file") == "" {
+		if jsonOutput() {
+			writeJSON(&transactionJSON{Hash: tx.Hash().Hex(), Transaction: fmt.Sprintf("%#x", data)})
+		} else {
+			outputIf(!quiet, fmt.Sprintf("%#x", data))
+		}
+		return
+	}
+
+	// Append, so that multiple transactions can be written to the same file.
+	f, err := os.OpenFile(viper.GetString("signed-tx-file"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	cli.ErrCheck(err, quiet, "Failed to open signed transaction file")
+	_, err = fmt.Fprintf(f, "%#x\n", data)
+	cli.ErrCheck(err, quiet, "Failed to write signed transaction")
+	cli.ErrCheck(f.Close(), quiet, "Failed to close signed transaction file")
+	outputIf(verbose, fmt.Sprintf("Transaction %s written to %s", tx.Hash().Hex(), viper.GetString("signed-tx-file")))
+}
+
+// outputMinedTransaction outputs information about a mined transaction.
+func outputMinedTransaction(mined *conn.MinedTransaction) {
+	if quiet {
+		return
+	}
+	fmt.Printf("Block:\t\t\t%v\n", mined.Receipt.BlockNumber)
+	if mined.Receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Println("Status:\t\t\tSucceeded")
+	} else {
+		fmt.Println("Status:\t\t\tFailed")
+	}
+	fmt.Printf("Gas used:\t\t%v\n", mined.Receipt.GasUsed)
+	if mined.EffectiveGasPrice != nil {
+		fmt.Printf("Effective gas price:\t%v\n", formatWei(mined.EffectiveGasPrice))
+	}
+	outputIf(verbose, fmt.Sprintf("Confirmations:\t\t%d", mined.Confirmations))
+}
+
+// transactionJSON is the JSON output for a transaction created by a command.
+type transactionJSON struct {
+	Hash              string `json:"transaction_hash"`
+	Transaction       string `json:"transaction,omitempty"`
+	Mined             *bool  `json:"mined,omitempty"`
+	BlockNumber       uint64 `json:"block_number,omitempty"`
+	Succeeded         *bool  `json:"succeeded,omitempty"`
+	GasUsed           uint64 `json:"gas_used,omitempty"`
+	EffectiveGasPrice string `json:"effective_gas_price,omitempty"`
+	Confirmations     uint64 `json:"confirmations,omitempty"`
+	ContractAddress   string `json:"contract_address,omitempty"`
+}
+
+// newMinedTransactionJSON creates the JSON output for a mined transaction.
+func newMinedTransactionJSON(mined *conn.MinedTransaction) *transactionJSON {
+	isMined := true
+	succeeded := mined.Receipt.Status == types.ReceiptStatusSuccessful
+	res := &transactionJSON{
+		Hash:          mined.Receipt.TxHash.Hex(),
+		Mined:         &isMined,
+		BlockNumber:   mined.Receipt.BlockNumber.Uint64(),
+		Succeeded:     &succeeded,
+		GasUsed:       mined.Receipt.GasUsed,
+		Confirmations: mined.Confirmations,
+	}
+	if mined.EffectiveGasPrice != nil {
+		res.EffectiveGasPrice = mined.EffectiveGasPrice.String()
+	}
+	if mined.Receipt.ContractAddress != (common.Address{}) && succeeded {
+		res.ContractAddress = mined.Receipt.ContractAddress.Hex()
+	}
+	return res
+}
+
+// logTransaction logs a transaction.  Transactions simulated with --dry-run are not sent, so are
+// not logged.
 func logTransaction(tx *types.Transaction, fields log.Fields) {
+	if dryRun {
+		return
+	}
 	setupLogging()
 
 	txFields := log.Fields{
@@ -295,6 +493,9 @@ func Execute() {
 		fmt.Println(err)
 		os.Exit(exitFailure)
 	}
+	if dryRunFailed {
+		os.Exit(exitFailure)
+	}
 }
 
 func init() {
@@ -313,15 +514,35 @@ func init() {
 	if err := viper.BindPFlag("verbose", RootCmd.PersistentFlags().Lookup("verbose")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("output", "text", "format of the output (text/json)")
+	if err := viper.BindPFlag("output", RootCmd.PersistentFlags().Lookup("output")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("unit", "auto", "unit in which to output values (auto/wei/gwei/ether)")
+	if err := viper.BindPFlag("unit", RootCmd.PersistentFlags().Lookup("unit")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("debug", false, "generate debug output")
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "the custom IPC or RPC path to an Ethereum node (overrides network option).  If you are running your own local instance of Ethereum this might be /home/user/.ethereum/geth.ipc (IPC) or http://localhost:8545/ (RPC)")
+	RootCmd.PersistentFlags().StringSlice("connection", nil, "the custom IPC or RPC path to an Ethereum node (overrides network option).  If you are running your own local instance of Ethereum this might be /home/user/.ethereum/geth.ipc (IPC) or http://localhost:8545/ (RPC).  Can be repeated or comma-separated to supply multiple endpoints, in which case those that are available are used in order")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("network", "mainnet", "network to access (mainnet/ropsten/kovan/rinkeby/goerli/sepolia) (overridden by connection option)")
+	RootCmd.PersistentFlags().Bool("broadcast-all", false, "send transactions to all connection endpoints rather than just the first available")
+	if err := viper.BindPFlag("broadcast-all", RootCmd.PersistentFlags().Lookup("broadcast-all")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("private", false, "send transactions to a private relay rather than the public mempool; requires an HTTP connection")
+	if err := viper.BindPFlag("private", RootCmd.PersistentFlags().Lookup("private")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("private-relay", "", "the relay to which to send private transactions (default Flashbots Protect)")
+	if err := viper.BindPFlag("private-relay", RootCmd.PersistentFlags().Lookup("private-relay")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network", "mainnet", "network to access (mainnet/ropsten/kovan/rinkeby/goerli/sepolia, or the name of a network profile in the configuration file) (overridden by connection option)")
 	if err := viper.BindPFlag("network", RootCmd.PersistentFlags().Lookup("network")); err != nil {
 		panic(err)
 	}
@@ -329,10 +550,62 @@ func init() {
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Int("retries", 3, "the number of times to retry a request to an HTTP connection that fails with a transient error, such as being rate limited by the provider")
+	if err := viper.BindPFlag("retries", RootCmd.PersistentFlags().Lookup("retries")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Float64("rate-limit", 0, "the maximum number of requests per second to make to an HTTP connection (0 for no limit)")
+	if err := viper.BindPFlag("rate-limit", RootCmd.PersistentFlags().Lookup("rate-limit")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("cache", false, "cache the results of contract calls made over an HTTP connection on disk")
+	if err := viper.BindPFlag("cache", RootCmd.PersistentFlags().Lookup("cache")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("no-cache", false, "do not cache the results of contract calls, even if enabled in the configuration file")
+	if err := viper.BindPFlag("no-cache", RootCmd.PersistentFlags().Lookup("no-cache")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Duration("cache-ttl", 5*time.Minute, "the time for which the results of contract calls against the latest block are cached")
+	if err := viper.BindPFlag("cache-ttl", RootCmd.PersistentFlags().Lookup("cache-ttl")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("trusted-block", "", "a trusted block, in the form number:hash, to which block headers returned by an HTTP connection are checked to link")
+	if err := viper.BindPFlag("trusted-block", RootCmd.PersistentFlags().Lookup("trusted-block")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("offline", false, "work without a connection to an execution node")
 	if err := viper.BindPFlag("offline", RootCmd.PersistentFlags().Lookup("offline")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Bool("dry-run", false, "simulate transactions and output them rather than sending them")
+	if err := viper.BindPFlag("dry-run", RootCmd.PersistentFlags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("confirm-value", "", "ask for confirmation before sending transactions with a value above this amount")
+	if err := viper.BindPFlag("confirm-value", RootCmd.PersistentFlags().Lookup("confirm-value")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("confirm-fee", "", "ask for confirmation before sending transactions with a maximum fee above this amount")
+	if err := viper.BindPFlag("confirm-fee", RootCmd.PersistentFlags().Lookup("confirm-fee")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("yes", false, "send transactions without asking for confirmation")
+	if err := viper.BindPFlag("yes", RootCmd.PersistentFlags().Lookup("yes")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("fiat", "", "fiat currency in which to also show values of Ether, for example USD")
+	if err := viper.BindPFlag("fiat", RootCmd.PersistentFlags().Lookup("fiat")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("price-feed", "", "address of a Chainlink-compatible price feed from which to obtain the price of Ether")
+	if err := viper.BindPFlag("price-feed", RootCmd.PersistentFlags().Lookup("price-feed")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("no-price", false, "do not obtain the price of Ether or show fiat values")
+	if err := viper.BindPFlag("no-price", RootCmd.PersistentFlags().Lookup("no-price")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Int("usbwallets", 1, "number of USB wallets to show")
 	if err := viper.BindPFlag("usbwallets", RootCmd.PersistentFlags().Lookup("usbwallets")); err != nil {
 		panic(err)
@@ -383,33 +656,83 @@ func initConfig() {
 func addTransactionFlags(cmd *cobra.Command, explanation string) {
 	cmd.Flags().String("passphrase", "", fmt.Sprintf("passphrase for %s", explanation))
 	cmd.Flags().String("privatekey", "", fmt.Sprintf("private key for %s", explanation))
-	cmd.Flags().String("max-fee-per-gas", "200Gwei", "Maximum fee per gas for transaction")
-	cmd.Flags().String("priority-fee-per-gas", "1.5 Gwei", "Priority fee per gas for transaction")
+	cmd.Flags().String("mnemonic", "", fmt.Sprintf("BIP-39 mnemonic for %s", explanation))
+	cmd.Flags().String("hd-path", util.DefaultHDPath, "derivation path for the key when using a mnemonic or hardware wallet")
+	cmd.Flags().Bool("ledger", false, fmt.Sprintf("use a Ledger hardware wallet for %s", explanation))
+	cmd.Flags().Bool("trezor", false, fmt.Sprintf("use a Trezor hardware wallet for %s", explanation))
+	cmd.Flags().String("external-signer", "", fmt.Sprintf("external signer for %s, for example clef:///path/to/clef.ipc", explanation))
+	cmd.Flags().String("max-fee-per-gas", "", "Maximum fee per gas for transaction (default twice the current base fee plus the priority fee)")
+	cmd.Flags().String("max-priority-fee-per-gas", "", "Maximum priority fee per gas for transaction (default suggested from recent fee history)")
+	cmd.Flags().String("priority-fee-per-gas", "", "Priority fee per gas for transaction")
+	cli.ErrCheck(cmd.Flags().MarkDeprecated("priority-fee-per-gas", "use --max-priority-fee-per-gas"), quiet, "failed to deprecate flag")
 	cmd.Flags().String("value", "", "Ether to send with the transaction")
 	cmd.Flags().Int64("gaslimit", 0, "Gas limit for the transaction; 0 is auto-select")
 	cmd.Flags().String("chainid", "", "chain ID; only needed when offline")
 	cmd.Flags().String("base-fee-per-gas", "", "base fee per gas; only needed when offline")
-	cmd.Flags().String("nonce", "", "nonce for account; only needed when offline")
+	cmd.Flags().String("nonce", "", "nonce for the transaction: auto (the higher of pending and latest), pending, latest or a number (default auto); must be a number when offline; a number is used as the starting nonce for every sender in commands with multiple senders")
+	cmd.Flags().String("signed-tx-file", "", "file to which to write the signed transaction rather than printing it; only used when offline")
 	cmd.Flags().Bool("wait", false, "wait for the transaction to be mined before returning")
 	cmd.Flags().Duration("limit", 0, "maximum time to wait for transaction to complete before failing (default forever)")
+	cmd.Flags().Uint64("confirmations", 1, "number of confirmations to wait for when waiting for the transaction to be mined")
+}
+
+// localSigningKey obtains the private key for the address from the passphrase, private key or
+// mnemonic supplied with the transaction flags, for commands that sign data as well as transactions.
+func localSigningKey(address common.Address) (*ecdsa.PrivateKey, error) {
+	if err := cli.LoadCredentials(c.ChainID(), address); err != nil {
+		return nil, err
+	}
+	var key *ecdsa.PrivateKey
+	var err error
+	switch {
+	case viper.GetString("passphrase") != "":
+		key, err = util.PrivateKeyForAccount(c.ChainID(), address, viper.GetString("passphrase"))
+	case viper.GetString("privatekey") != "":
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(viper.GetString("privatekey"), "0x"))
+	case viper.GetString("mnemonic") != "":
+		key, err = util.HDPrivateKey(viper.GetString("mnemonic"), "", viper.GetString("hd-path"))
+	default:
+		return nil, fmt.Errorf("no signer; please supply passphrase, private key or mnemonic")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(key.PublicKey) != address {
+		return nil, fmt.Errorf("private key is not for %s", address.Hex())
+	}
+	return key, nil
 }
 
 func generateTxOpts(sender common.Address) (*bind.TransactOpts, error) {
-	// Signer depends on what information is available to us
+	hardwareWallet, err := cli.HardwareWalletType()
+	if err != nil {
+		return nil, err
+	}
+
 	var signer bind.SignerFn
-	if viper.GetString("passphrase") != "" {
-		wallet, account, err := cli.ObtainWalletAndAccount(c.ChainID(), sender)
+	if dryRun {
+		// The transaction is simulated before it would be signed, and is returned unsigned
+		// rather than sent, so no keys are required.
+		signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			dryRunTransaction(address, tx)
+			return tx, nil
+		}
+	} else {
+		signer, err = transactionSigner(sender, hardwareWallet)
 		if err != nil {
 			return nil, err
 		}
-		signer = util.AccountSigner(c.ChainID(), &wallet, account, viper.GetString("passphrase"))
-	} else if viper.GetString("privatekey") != "" {
-		key, err := crypto.HexToECDSA(strings.TrimPrefix(viper.GetString("privatekey"), "0x"))
-		cli.ErrCheck(err, quiet, "Invalid private key")
-		signer = util.KeySigner(c.ChainID(), key)
-	}
-	if signer == nil {
-		return nil, fmt.Errorf("no signer; please supply either passphrase or private key")
+		if !offline {
+			// Confirm the transaction once it has been signed, before it is sent.
+			txSigner := signer
+			signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				signedTx, err := txSigner(address, tx)
+				if err == nil {
+					confirmTransaction(signedTx)
+				}
+				return signedTx, err
+			}
+		}
 	}
 
 	var value *big.Int
@@ -423,20 +746,26 @@ func generateTxOpts(sender common.Address) (*bind.TransactOpts, error) {
 		return nil, err
 	}
 
+	opts := &bind.TransactOpts{
+		From:   sender,
+		Signer: signer,
+		Value:  value,
+		NoSend: offline || dryRun,
+		Nonce:  big.NewInt(0).SetUint64(curNonce),
+	}
+
 	// Calculate the fees.
-	feePerGas, priorityFeePerGas, err := calculateFees()
+	london, err := c.SupportsLondon(context.Background())
 	if err != nil {
 		return nil, err
 	}
-
-	opts := &bind.TransactOpts{
-		From:      sender,
-		Signer:    signer,
-		GasFeeCap: feePerGas,
-		GasTipCap: priorityFeePerGas,
-		Value:     value,
-		NoSend:    offline,
-		Nonce:     big.NewInt(0).SetUint64(curNonce),
+	if london {
+		opts.GasFeeCap, opts.GasTipCap, err = calculateFees()
+	} else {
+		opts.GasPrice, err = c.CalculateGasPrice(context.Background())
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	limit := uint64(viper.GetInt64("gaslimit"))
@@ -447,49 +776,137 @@ func generateTxOpts(sender common.Address) (*bind.TransactOpts, error) {
 	return opts, nil
 }
 
+// transactionSigner creates a signer for transactions from the sender, using the key or wallet
+// supplied with the transaction flags.
+func transactionSigner(sender common.Address, hardwareWallet string) (bind.SignerFn, error) {
+	if err := cli.LoadCredentials(c.ChainID(), sender); err != nil {
+		return nil, err
+	}
+
+	// Signer depends on what information is available to us
+	var signer bind.SignerFn
+	if cli.ExternalSigner() != "" {
+		wallet, account, err := cli.ObtainExternalSignerAccount(cli.ExternalSigner(), sender)
+		if err != nil {
+			return nil, err
+		}
+		signer = util.ExternalSigner(c.ChainID(), wallet, account)
+	} else if hardwareWallet != "" {
+		wallet, account, err := cli.ObtainHardwareWalletAccount(hardwareWallet, viper.GetString("hd-path"))
+		if err != nil {
+			return nil, err
+		}
+		if account.Address != sender {
+			return nil, fmt.Errorf("%s account at path %s is %s, not %s", hardwareWallet, viper.GetString("hd-path"), account.Address.Hex(), sender.Hex())
+		}
+		signer = util.HardwareSigner(c.ChainID(), wallet, account)
+	} else if viper.GetString("passphrase") != "" {
+		wallet, account, err := cli.ObtainWalletAndAccount(c.ChainID(), sender)
+		if err != nil {
+			return nil, err
+		}
+		signer = util.AccountSigner(c.ChainID(), &wallet, account, viper.GetString("passphrase"))
+	} else if viper.GetString("privatekey") != "" {
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(viper.GetString("privatekey"), "0x"))
+		cli.ErrCheck(err, quiet, "Invalid private key")
+		signer = util.KeySigner(c.ChainID(), key)
+	} else if viper.GetString("mnemonic") != "" {
+		key, err := util.HDPrivateKey(viper.GetString("mnemonic"), "", viper.GetString("hd-path"))
+		cli.ErrCheck(err, quiet, "Failed to obtain key from mnemonic")
+		keyAddress := crypto.PubkeyToAddress(key.PublicKey)
+		if keyAddress != sender {
+			return nil, fmt.Errorf("mnemonic and path %s provide the key for %s, not %s", viper.GetString("hd-path"), keyAddress.Hex(), sender.Hex())
+		}
+		signer = util.KeySigner(c.ChainID(), key)
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("no signer; please supply passphrase, private key, mnemonic, hardware wallet or external signer")
+	}
+
+	return signer, nil
+}
+
 func outputIf(condition bool, msg string) {
 	if condition {
-		fmt.Println(msg)
+		if jsonOutput() {
+			// Keep standard output for the JSON result.
+			fmt.Fprintln(os.Stderr, msg)
+		} else {
+			fmt.Println(msg)
+		}
 	}
 }
 
-func localContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
+// formatWei formats a value in Wei in the unit requested by the user.
+func formatWei(value *big.Int) string {
+	return output.FormatWei(value, outputUnit)
 }
 
-func calculateFees() (*big.Int, *big.Int, error) {
-	// Set max fee per gas.
-	feePerGas, err := c.CurrentBaseFee(context.Background())
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to obtain current base fee")
-	}
-	outputIf(debug, fmt.Sprintf("Current base fee per gas: %v", string2eth.WeiToString(feePerGas, true)))
+// formatTokens formats a token amount, adjusting for decimals unless raw output is
+// requested or the user has asked for values in Wei.
+func formatTokens(value *big.Int, decimals uint8, raw bool) string {
+	return output.FormatTokens(value, decimals, raw || outputUnit == output.Wei)
+}
 
-	if viper.GetString("max-fee-per-gas") == "" {
-		viper.Set("max-fee-per-gas", "200gwei")
+// formatAddress formats an address for output, annotating it with its label if it is in the
+// address book or is a well-known contract, and otherwise using its ENS name if it has one.
+func formatAddress(address common.Address) string {
+	if addresses != nil {
+		if label, exists := addresses.Label(address); exists {
+			return fmt.Sprintf("%s (%s)", address.Hex(), label)
+		}
 	}
-	maxFeePerGas, err := string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
-	if err != nil {
-		return nil, nil, err
+	if knownContracts != nil && c.ChainID() != nil {
+		if name, exists := knownContracts.Name(c.ChainID().Uint64(), address); exists {
+			return fmt.Sprintf("%s (%s)", address.Hex(), name)
+		}
 	}
+	return ens.Format(c.Client(), address)
+}
+
+// jsonOutput returns true if results should be output as JSON.
+func jsonOutput() bool {
+	return outputFormat == output.JSON
+}
+
+// outputJSON outputs a result as JSON and exits.
+func outputJSON(result interface{}) {
+	writeJSON(result)
+	os.Exit(exitSuccess)
+}
 
-	// Set priority fee per gas.
-	priorityFeePerGas, err := string2eth.StringToWei(viper.GetString("priority-fee-per-gas"))
-	cli.ErrCheck(err, quiet, "Failed to obtain priority fee per gas")
+// writeJSON outputs a result as JSON, for commands that output more than one result or
+// that do not exit successfully.
+func writeJSON(result interface{}) {
+	if !quiet {
+		cli.ErrCheck(output.Write(os.Stdout, result), quiet, "Failed to output JSON")
+	}
+}
 
-	// Ensure that the total fee per gas does not exceed the max allowed.
-	totalFeePerGas := new(big.Int).Add(feePerGas, priorityFeePerGas)
-	if totalFeePerGas.Cmp(maxFeePerGas) > 0 {
-		return nil, nil, fmt.Errorf("calculated total fee per gas of %s too high; increase with --max-fee-per-gas if you are sure you want to do this", string2eth.WeiToString(totalFeePerGas, true))
+// dataDir returns the directory in which ethereal stores its data, creating it if required.
+func dataDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
 	}
+	dir := filepath.Join(home, ".ethereal")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func localContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
+}
 
-	// Try to double the base fee, but not exceed the max allowed.
-	feePerGas = new(big.Int).Mul(feePerGas, big.NewInt(2))
-	totalFeePerGas = totalFeePerGas.Add(feePerGas, priorityFeePerGas)
-	if totalFeePerGas.Cmp(maxFeePerGas) >= 0 {
-		feePerGas = feePerGas.Sub(maxFeePerGas, priorityFeePerGas)
+func calculateFees() (*big.Int, *big.Int, error) {
+	feePerGas, priorityFeePerGas, err := c.CalculateFees()
+	if err != nil {
+		return nil, nil, err
 	}
-	outputIf(debug, fmt.Sprintf("Calculated fee per gas is %s", string2eth.WeiToString(feePerGas, true)))
+	outputIf(debug, fmt.Sprintf("Calculated fee per gas is %s", formatWei(feePerGas)))
+	outputIf(debug, fmt.Sprintf("Calculated priority fee per gas is %s", formatWei(priorityFeePerGas)))
 
 	return feePerGas, priorityFeePerGas, nil
 }
@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// rpcCallCmd represents the rpc call command
+var rpcCallCmd = &cobra.Command{
+	Use:   "call [method] [params]",
+	Short: "Call a JSON-RPC method",
+	Long: `Call a JSON-RPC method on the node, with parameters supplied as a JSON array, and show the result.  For example:
+
+    ethereal rpc call debug_traceBlockByNumber '["0x10",{"tracer":"callTracer"}]'
+
+The parameters can be omitted if the method takes none, or supplied as "-" to read them from standard input.
+
+In quiet mode this will return 0 if the call succeeds, otherwise 1.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		method := args[0]
+		params := make([]json.RawMessage, 0)
+		if len(args) > 1 {
+			input := []byte(args[1])
+			if args[1] == "-" {
+				var err error
+				input, err = ioutil.ReadAll(os.Stdin)
+				cli.ErrCheck(err, quiet, "Failed to read parameters")
+			}
+			input = bytes.TrimSpace(input)
+			cli.Assert(len(input) > 0 && input[0] == '[', quiet, "Parameters must be a JSON array")
+			cli.ErrCheck(json.Unmarshal(input, &params), quiet, "Invalid parameters")
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+
+		res, err := c.RawCall(ctx, method, params)
+		if err != nil {
+			msg := fmt.Sprintf("Call to %s failed", method)
+			var dataErr rpc.DataError
+			if verbose && errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+				msg = fmt.Sprintf("%s (data %v)", msg, dataErr.ErrorData())
+			}
+			cli.ErrCheck(err, quiet, msg)
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(res)
+		}
+
+		var pretty bytes.Buffer
+		cli.ErrCheck(json.Indent(&pretty, res, "", "  "), quiet, "Failed to format result")
+		fmt.Println(strings.TrimSpace(pretty.String()))
+	},
+}
+
+func init() {
+	rpcCmd.AddCommand(rpcCallCmd)
+}
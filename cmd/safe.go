@@ -0,0 +1,251 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	"github.com/wealdtech/ethereal/v2/util/safeapi"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var safeStr string
+var safeToStr string
+var safeAmountStr string
+var safeDataStr string
+var safeOperationStr string
+var safeNonceStr string
+var safeTxHashStr string
+var safeSignatureStrs []string
+var safeService bool
+
+// safeCmd represents the safe command
+var safeCmd = &cobra.Command{
+	Use:   "safe",
+	Short: "Manage Safe multisig wallets",
+	Long: `Obtain information about, sign and execute transactions for Safe multisig wallets.
+
+Signatures can be exchanged directly between owners, or through the Safe Transaction Service with --service.  The service for the chain is used by default; it can be changed with safe-service-url in the configuration file.`,
+}
+
+func init() {
+	RootCmd.AddCommand(safeCmd)
+}
+
+func safeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&safeStr, "safe", "", "Address of the Safe")
+}
+
+// safeTransactionFlags adds the flags that define a Safe transaction.
+func safeTransactionFlags(cmd *cobra.Command) {
+	safeFlags(cmd)
+	cmd.Flags().StringVar(&safeToStr, "to", "", "Address to which the Safe sends the transaction")
+	cmd.Flags().StringVar(&safeAmountStr, "amount", "0", "Amount of Ether the Safe sends with the transaction")
+	cmd.Flags().StringVar(&safeDataStr, "data", "", "Data the Safe sends with the transaction, as hex")
+	cmd.Flags().StringVar(&safeOperationStr, "operation", "call", "Operation of the transaction (call/delegatecall)")
+	cmd.Flags().StringVar(&safeNonceStr, "safe-nonce", "", "Nonce of the Safe transaction (default the next nonce of the Safe)")
+	cmd.Flags().StringVar(&safeTxHashStr, "safe-tx-hash", "", "Hash of a Safe transaction to obtain from the Safe Transaction Service, in place of the transaction flags")
+	cmd.Flags().BoolVar(&safeService, "service", false, "Use the Safe Transaction Service")
+}
+
+// safeSignatureFlags adds the flags that supply owner signatures.
+func safeSignatureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&safeSignatureStrs, "signatures", nil, "Signatures of owners over the Safe transaction hash, as hex; can be repeated or comma-separated")
+}
+
+// safeContract obtains the Safe supplied with --safe.
+func safeContract() (common.Address, *contracts.GnosisSafe) {
+	cli.Assert(safeStr != "", quiet, "--safe is required")
+	address, err := c.Resolve(safeStr)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve Safe address %s", safeStr))
+	contract, err := contracts.NewGnosisSafe(address, c.Client())
+	cli.ErrCheck(err, quiet, "Failed to obtain Safe contract")
+	return address, contract
+}
+
+// safeServiceClient returns a client for the Safe Transaction Service.
+func safeServiceClient() *safeapi.Client {
+	url := viper.GetString("safe-service-url")
+	if url == "" {
+		var err error
+		url, err = safeapi.DefaultURL(c.ChainID())
+		cli.ErrCheck(err, quiet, "Failed to obtain Safe Transaction Service; set one with safe-service-url in the configuration file")
+	}
+	return safeapi.New(url)
+}
+
+// safeTransactionState is a Safe transaction along with the details required to sign and execute it.
+type safeTransactionState struct {
+	address common.Address
+	safe    *contracts.GnosisSafe
+	tx      *util.SafeTransaction
+	hash    common.Hash
+	// known is true if the transaction is known to the Safe Transaction Service.
+	known bool
+	// signatures are signatures obtained from the Safe Transaction Service.
+	signatures []*util.SafeSignature
+}
+
+// obtainSafeTransaction obtains the Safe transaction defined by the flags, or from the Safe
+// Transaction Service if --safe-tx-hash is supplied.  The hash of the transaction is checked
+// against that calculated by the Safe.
+func obtainSafeTransaction(ctx context.Context) *safeTransactionState {
+	address, safe := safeContract()
+	state := &safeTransactionState{
+		address: address,
+		safe:    safe,
+	}
+
+	if safeTxHashStr != "" {
+		cli.Assert(safeService, quiet, "--safe-tx-hash requires --service")
+		hash := common.HexToHash(safeTxHashStr)
+		tx, signatures, err := safeServiceClient().Transaction(ctx, hash)
+		cli.ErrCheck(err, quiet, "Failed to obtain transaction from Safe Transaction Service")
+		cli.Assert(tx != nil, quiet, "Transaction not known to Safe Transaction Service")
+		state.tx = tx
+		state.known = true
+		state.signatures = signatures
+	} else {
+		state.tx = safeTransactionFromFlags(safe)
+	}
+
+	version, err := safe.VERSION(nil)
+	cli.ErrCheck(err, quiet, "Failed to obtain Safe version; is this a Safe?")
+	state.hash = util.SafeTransactionHash(util.SafeDomainSeparator(c.ChainID(), address, version), state.tx)
+	tx := state.tx
+	contractHash, err := safe.GetTransactionHash(nil, tx.To, tx.Value, tx.Data, tx.Operation, tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver, tx.Nonce)
+	cli.ErrCheck(err, quiet, "Failed to obtain transaction hash from Safe")
+	cli.Assert(contractHash == state.hash, quiet, fmt.Sprintf("Safe calculated transaction hash %s rather than %s", common.Hash(contractHash).Hex(), state.hash.Hex()))
+	if safeTxHashStr != "" {
+		cli.Assert(state.hash == common.HexToHash(safeTxHashStr), quiet, "Transaction from Safe Transaction Service does not match its hash")
+	}
+
+	if safeService && safeTxHashStr == "" {
+		tx, signatures, err := safeServiceClient().Transaction(ctx, state.hash)
+		cli.ErrCheck(err, quiet, "Failed to obtain transaction from Safe Transaction Service")
+		state.known = tx != nil
+		state.signatures = signatures
+	}
+	return state
+}
+
+// safeTransactionFromFlags creates a Safe transaction from the flags.
+func safeTransactionFromFlags(safe *contracts.GnosisSafe) *util.SafeTransaction {
+	cli.Assert(safeToStr != "", quiet, "--to is required")
+	to, err := c.Resolve(safeToStr)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve address %s", safeToStr))
+	value, err := string2eth.StringToWei(safeAmountStr)
+	cli.ErrCheck(err, quiet, "Invalid amount")
+	var data []byte
+	if safeDataStr != "" {
+		cli.Assert(strings.HasPrefix(safeDataStr, "0x"), quiet, "--data must be hex")
+		data = common.FromHex(safeDataStr)
+	}
+
+	var operation uint8
+	switch strings.ToLower(safeOperationStr) {
+	case "call":
+		operation = util.SafeCall
+	case "delegatecall":
+		operation = util.SafeDelegateCall
+	default:
+		cli.Err(quiet, fmt.Sprintf("Unknown operation %s", safeOperationStr))
+	}
+
+	var nonce *big.Int
+	if safeNonceStr == "" {
+		nonce, err = safe.Nonce(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain nonce of Safe")
+	} else {
+		var success bool
+		nonce, success = new(big.Int).SetString(safeNonceStr, 10)
+		cli.Assert(success, quiet, "Invalid Safe nonce")
+	}
+
+	return &util.SafeTransaction{
+		To:        to,
+		Value:     value,
+		Data:      data,
+		Operation: operation,
+		SafeTxGas: big.NewInt(0),
+		BaseGas:   big.NewInt(0),
+		GasPrice:  big.NewInt(0),
+		Nonce:     nonce,
+	}
+}
+
+// safeCheckedSignature is a signature along with the result of checking it.
+type safeCheckedSignature struct {
+	signature *util.SafeSignature
+	err       error
+}
+
+// checkSignatures checks the signatures supplied with --signatures and obtained from the Safe
+// Transaction Service, returning those that are from owners of the Safe.
+func (s *safeTransactionState) checkSignatures(owners []common.Address) ([]*util.SafeSignature, []*safeCheckedSignature) {
+	isOwner := make(map[common.Address]bool)
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+
+	candidates := make([][]byte, 0, len(safeSignatureStrs)+len(s.signatures))
+	for _, signatureStr := range safeSignatureStrs {
+		cli.Assert(strings.HasPrefix(signatureStr, "0x"), quiet, "Signatures must be hex")
+		candidates = append(candidates, common.FromHex(signatureStr))
+	}
+	for _, signature := range s.signatures {
+		candidates = append(candidates, signature.Signature)
+	}
+
+	valid := make([]*util.SafeSignature, 0, len(candidates))
+	checked := make([]*safeCheckedSignature, 0, len(candidates))
+	seen := make(map[common.Address]bool)
+	for _, candidate := range candidates {
+		owner, err := util.SafeSigner(s.hash, candidate)
+		signature := &util.SafeSignature{Owner: owner, Signature: candidate}
+		switch {
+		case err != nil:
+		case !isOwner[owner]:
+			err = fmt.Errorf("%s is not an owner", owner.Hex())
+		case candidate[64] == 1:
+			// Approvals are only valid if the owner has approved the hash on-chain.
+			approved, approvedErr := s.safe.ApprovedHashes(nil, owner, s.hash)
+			if approvedErr != nil {
+				err = errors.Wrap(approvedErr, "failed to obtain approval")
+			} else if approved.Sign() == 0 {
+				err = fmt.Errorf("%s has not approved the transaction", owner.Hex())
+			}
+		}
+		if err == nil && seen[owner] {
+			// Duplicate signatures are common when signatures come from both flags and the service.
+			continue
+		}
+		checked = append(checked, &safeCheckedSignature{signature: signature, err: err})
+		if err == nil {
+			seen[owner] = true
+			valid = append(valid, signature)
+		}
+	}
+	return valid, checked
+}
@@ -0,0 +1,108 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var safeExecuteFromStr string
+
+// safeExecuteCmd represents the safe execute command
+var safeExecuteCmd = &cobra.Command{
+	Use:   "execute",
+	Short: "Execute a Safe transaction",
+	Long: `Execute a Safe transaction once enough of its owners have signed it.  For example:
+
+    ethereal safe execute --safe=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --safe-tx-hash=0x... --service --from=0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF --passphrase=secret
+
+Signatures are supplied with --signatures, and obtained from the Safe Transaction Service with --service.  Owners that have approved the transaction on-chain are counted, as is the sender of the transaction if it is an owner.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+
+		ctx, cancel := localContext()
+		defer cancel()
+		state := obtainSafeTransaction(ctx)
+
+		safeExecuteFromStr = accountOrDefault(safeExecuteFromStr)
+		cli.Assert(safeExecuteFromStr != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(safeExecuteFromStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", safeExecuteFromStr))
+
+		owners, err := state.safe.GetOwners(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain owners")
+		threshold, err := state.safe.GetThreshold(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain threshold")
+		nonce, err := state.safe.Nonce(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain nonce")
+		cli.Assert(state.tx.Nonce.Cmp(nonce) == 0, quiet, fmt.Sprintf("Transaction has nonce %s but the Safe is at nonce %s", state.tx.Nonce.String(), nonce.String()))
+
+		signatures, checked := state.checkSignatures(owners)
+		for _, signature := range checked {
+			outputIf(verbose && signature.err != nil, fmt.Sprintf("Ignoring signature %#x: %v", signature.signature.Signature, signature.err))
+		}
+		signed := make(map[common.Address]bool)
+		for _, signature := range signatures {
+			signed[signature.Owner] = true
+		}
+		for _, owner := range owners {
+			if signed[owner] {
+				continue
+			}
+			if owner == fromAddress {
+				// The Safe accepts the sender of the transaction as having approved it.
+				signatures = append(signatures, &util.SafeSignature{Owner: owner, Signature: util.SafeApprovalSignature(owner)})
+				continue
+			}
+			approved, err := state.safe.ApprovedHashes(nil, owner, state.hash)
+			cli.ErrCheck(err, quiet, "Failed to obtain on-chain approvals")
+			if approved.Sign() != 0 {
+				signatures = append(signatures, &util.SafeSignature{Owner: owner, Signature: util.SafeApprovalSignature(owner)})
+			}
+		}
+		cli.Assert(uint64(len(signatures)) >= threshold.Uint64(), quiet, fmt.Sprintf("Transaction has %d of %d required signatures", len(signatures), threshold.Uint64()))
+
+		opts, err := generateTxOpts(fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+		tx := state.tx
+		signedTx, err := state.safe.ExecTransaction(opts, tx.To, tx.Value, tx.Data, tx.Operation, tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver, util.SafeSignatures(signatures))
+		cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":      "safe",
+			"command":    "execute",
+			"safe":       state.address.Hex(),
+			"safeTxHash": state.hash.Hex(),
+			"safeTo":     tx.To.Hex(),
+			"safeValue":  tx.Value.String(),
+			"safeNonce":  tx.Nonce.String(),
+		}, true)
+	},
+}
+
+func init() {
+	safeCmd.AddCommand(safeExecuteCmd)
+	safeTransactionFlags(safeExecuteCmd)
+	safeSignatureFlags(safeExecuteCmd)
+	safeExecuteCmd.Flags().StringVar(&safeExecuteFromStr, "from", "", "Address from which to send the transaction")
+	addTransactionFlags(safeExecuteCmd, "the address from which to send the transaction")
+}
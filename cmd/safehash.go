@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// safeHashCmd represents the safe hash command
+var safeHashCmd = &cobra.Command{
+	Use:   "hash",
+	Short: "Obtain the hash of a Safe transaction",
+	Long: `Obtain the hash of a Safe transaction, which is the value signed by its owners.  For example:
+
+    ethereal safe hash --safe=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf --amount=1ether
+
+The nonce of the transaction is the next nonce of the Safe unless --safe-nonce is supplied.
+
+In quiet mode this will return 0 if the hash can be calculated, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+		state := obtainSafeTransaction(ctx)
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(newSafeTransactionJSON(state))
+		}
+
+		outputIf(verbose, fmt.Sprintf("To:\t\t%s", state.tx.To.Hex()))
+		outputIf(verbose, fmt.Sprintf("Value:\t\t%s", formatWei(state.tx.Value)))
+		outputIf(verbose && len(state.tx.Data) > 0, fmt.Sprintf("Data:\t\t%#x", state.tx.Data))
+		outputIf(verbose && state.tx.Operation == util.SafeDelegateCall, "Operation:\tdelegatecall")
+		outputIf(verbose, fmt.Sprintf("Nonce:\t\t%s", state.tx.Nonce.String()))
+		if verbose {
+			fmt.Printf("Hash:\t\t%s\n", state.hash.Hex())
+		} else {
+			fmt.Println(state.hash.Hex())
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// safeTransactionJSON is the JSON output for a Safe transaction.
+type safeTransactionJSON struct {
+	Safe      string `json:"safe"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data,omitempty"`
+	Operation uint8  `json:"operation"`
+	Nonce     string `json:"nonce"`
+	Hash      string `json:"safe_tx_hash"`
+}
+
+func newSafeTransactionJSON(state *safeTransactionState) *safeTransactionJSON {
+	res := &safeTransactionJSON{
+		Safe:      state.address.Hex(),
+		To:        state.tx.To.Hex(),
+		Value:     state.tx.Value.String(),
+		Operation: state.tx.Operation,
+		Nonce:     state.tx.Nonce.String(),
+		Hash:      state.hash.Hex(),
+	}
+	if len(state.tx.Data) > 0 {
+		res.Data = fmt.Sprintf("%#x", state.tx.Data)
+	}
+	return res
+}
+
+func init() {
+	safeCmd.AddCommand(safeHashCmd)
+	safeTransactionFlags(safeHashCmd)
+}
@@ -0,0 +1,96 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// safeInfoCmd represents the safe info command
+var safeInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about a Safe",
+	Long: `Obtain information about a Safe, including its owners, threshold and nonce.  For example:
+
+    ethereal safe info --safe=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+In quiet mode this will return 0 if the address is a Safe, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		address, safe := safeContract()
+
+		version, err := safe.VERSION(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain Safe version; is this a Safe?")
+		owners, err := safe.GetOwners(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain owners")
+		threshold, err := safe.GetThreshold(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain threshold")
+		nonce, err := safe.Nonce(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain nonce")
+		balance, err := c.Client().BalanceAt(context.Background(), address, nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain balance")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			ownerStrs := make([]string, len(owners))
+			for i := range owners {
+				ownerStrs[i] = owners[i].Hex()
+			}
+			outputJSON(&safeInfoJSON{
+				Address:   address.Hex(),
+				Version:   version,
+				Owners:    ownerStrs,
+				Threshold: threshold.Uint64(),
+				Nonce:     nonce.String(),
+				Balance:   balance.String(),
+			})
+		}
+
+		builder := new(strings.Builder)
+		builder.WriteString(fmt.Sprintf("Address:\t%s\n", address.Hex()))
+		builder.WriteString(fmt.Sprintf("Version:\t%s\n", version))
+		builder.WriteString(fmt.Sprintf("Threshold:\t%d of %d owners\n", threshold.Uint64(), len(owners)))
+		builder.WriteString("Owners:\n")
+		for _, owner := range owners {
+			builder.WriteString(fmt.Sprintf("\t%s\n", owner.Hex()))
+		}
+		builder.WriteString(fmt.Sprintf("Nonce:\t\t%s\n", nonce.String()))
+		builder.WriteString(fmt.Sprintf("Balance:\t%s\n", formatWei(balance)))
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// safeInfoJSON is the JSON output for information about a Safe.
+type safeInfoJSON struct {
+	Address   string   `json:"address"`
+	Version   string   `json:"version"`
+	Owners    []string `json:"owners"`
+	Threshold uint64   `json:"threshold"`
+	Nonce     string   `json:"nonce"`
+	Balance   string   `json:"balance"`
+}
+
+func init() {
+	safeCmd.AddCommand(safeInfoCmd)
+	safeFlags(safeInfoCmd)
+}
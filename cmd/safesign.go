@@ -0,0 +1,113 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var safeSignOwnerStr string
+var safeSignPropose bool
+
+// safeSignCmd represents the safe sign command
+var safeSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a Safe transaction as an owner",
+	Long: `Sign a Safe transaction as one of its owners.  For example:
+
+    ethereal safe sign --safe=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf --amount=1ether --owner=0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF --passphrase=secret
+
+The signature can be passed to the other owners, or with --propose sent to the Safe Transaction Service.  If the transaction is not already known to the service it is proposed, otherwise the signature is added to it.
+
+In quiet mode this will return 0 if the transaction is signed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+		if safeSignPropose {
+			safeService = true
+		}
+		state := obtainSafeTransaction(ctx)
+
+		cli.Assert(safeSignOwnerStr != "", quiet, "--owner is required")
+		owner, err := c.Resolve(safeSignOwnerStr)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve owner address %s", safeSignOwnerStr))
+		isOwner, err := state.safe.IsOwner(nil, owner)
+		cli.ErrCheck(err, quiet, "Failed to check owner")
+		cli.Assert(isOwner, quiet, fmt.Sprintf("%s is not an owner of the Safe", owner.Hex()))
+
+		key, err := localSigningKey(owner)
+		cli.ErrCheck(err, quiet, "Failed to obtain key for owner")
+		signature, err := crypto.Sign(state.hash.Bytes(), key)
+		cli.ErrCheck(err, quiet, "Failed to sign transaction")
+		// Safe signatures over the hash use the legacy recovery ID of 27 or 28.
+		signature[crypto.RecoveryIDOffset] += 27
+
+		if safeSignPropose {
+			client := safeServiceClient()
+			if !state.known {
+				err = client.Propose(ctx, state.address, state.tx, state.hash, &util.SafeSignature{Owner: owner, Signature: signature})
+				cli.ErrCheck(err, quiet, "Failed to propose transaction to Safe Transaction Service")
+				outputIf(verbose, "Proposed transaction to Safe Transaction Service")
+			} else {
+				err = client.Confirm(ctx, state.hash, signature)
+				cli.ErrCheck(err, quiet, "Failed to send signature to Safe Transaction Service")
+				outputIf(verbose, "Sent signature to Safe Transaction Service")
+			}
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&safeSignJSON{
+				Hash:      state.hash.Hex(),
+				Owner:     owner.Hex(),
+				Signature: fmt.Sprintf("%#x", signature),
+			})
+		}
+
+		outputIf(verbose, fmt.Sprintf("Hash:\t\t%s", state.hash.Hex()))
+		if verbose {
+			fmt.Printf("Signature:\t%#x\n", signature)
+		} else {
+			fmt.Printf("%#x\n", signature)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// safeSignJSON is the JSON output for the safe sign command.
+type safeSignJSON struct {
+	Hash      string `json:"safe_tx_hash"`
+	Owner     string `json:"owner"`
+	Signature string `json:"signature"`
+}
+
+func init() {
+	safeCmd.AddCommand(safeSignCmd)
+	safeTransactionFlags(safeSignCmd)
+	safeSignCmd.Flags().StringVar(&safeSignOwnerStr, "owner", "", "Address of the owner signing the transaction")
+	safeSignCmd.Flags().BoolVar(&safeSignPropose, "propose", false, "Send the signature to the Safe Transaction Service, proposing the transaction if it is not already known")
+	safeSignCmd.Flags().String("passphrase", "", "passphrase for the owner")
+	safeSignCmd.Flags().String("privatekey", "", "private key for the owner")
+	safeSignCmd.Flags().String("mnemonic", "", "BIP-39 mnemonic for the owner")
+	safeSignCmd.Flags().String("hd-path", util.DefaultHDPath, "derivation path for the key when using a mnemonic")
+}
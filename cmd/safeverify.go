@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// safeVerifyCmd represents the safe verify command
+var safeVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the signatures for a Safe transaction",
+	Long: `Verify the signatures of owners for a Safe transaction, and whether there are enough to execute it.  For example:
+
+    ethereal safe verify --safe=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf --amount=1ether --signatures=0x...,0x...
+
+Signatures are supplied with --signatures, and obtained from the Safe Transaction Service with --service.
+
+This will return an exit status of 0 if there are enough valid signatures to execute the transaction, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+		state := obtainSafeTransaction(ctx)
+
+		owners, err := state.safe.GetOwners(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain owners")
+		threshold, err := state.safe.GetThreshold(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain threshold")
+		valid, checked := state.checkSignatures(owners)
+		passed := uint64(len(valid)) >= threshold.Uint64()
+
+		switch {
+		case quiet:
+		case jsonOutput():
+			res := &safeVerifyJSON{
+				Hash:       state.hash.Hex(),
+				Threshold:  threshold.Uint64(),
+				Signatures: make([]*safeVerifySignatureJSON, len(checked)),
+				Passed:     passed,
+			}
+			for i, signature := range checked {
+				res.Signatures[i] = &safeVerifySignatureJSON{
+					Owner:     signature.signature.Owner.Hex(),
+					Signature: fmt.Sprintf("%#x", signature.signature.Signature),
+					Valid:     signature.err == nil,
+				}
+				if signature.err != nil {
+					res.Signatures[i].Error = signature.err.Error()
+				}
+			}
+			writeJSON(res)
+		default:
+			builder := new(strings.Builder)
+			builder.WriteString(fmt.Sprintf("Hash:\t\t%s\n", state.hash.Hex()))
+			if len(checked) > 0 {
+				builder.WriteString("Signatures:\n")
+			}
+			for _, signature := range checked {
+				if signature.err == nil {
+					builder.WriteString(fmt.Sprintf("\t%s: valid\n", signature.signature.Owner.Hex()))
+				} else {
+					builder.WriteString(fmt.Sprintf("\t%#x: invalid; %v\n", signature.signature.Signature, signature.err))
+				}
+			}
+			builder.WriteString(fmt.Sprintf("Threshold:\t%d of %d signatures\n", len(valid), threshold.Uint64()))
+			fmt.Print(builder.String())
+		}
+		if !passed {
+			os.Exit(exitFailure)
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// safeVerifyJSON is the JSON output for the safe verify command.
+type safeVerifyJSON struct {
+	Hash       string                     `json:"safe_tx_hash"`
+	Threshold  uint64                     `json:"threshold"`
+	Signatures []*safeVerifySignatureJSON `json:"signatures"`
+	Passed     bool                       `json:"passed"`
+}
+
+// safeVerifySignatureJSON is the JSON output for a single signature.
+type safeVerifySignatureJSON struct {
+	Owner     string `json:"owner"`
+	Signature string `json:"signature"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
+}
+
+func init() {
+	safeCmd.AddCommand(safeVerifyCmd)
+	safeTransactionFlags(safeVerifyCmd)
+	safeSignatureFlags(safeVerifyCmd)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/wealdtech/ethereal/v2/util/scanner"
+)
+
+// scanBlocks returns the first and last blocks of a scan, where the last block defaults to the
+// latest block and the first block defaults to the last block.
+func scanBlocks(ctx context.Context, fromBlock string, toBlock string) (uint64, uint64, error) {
+	to, err := parseBlockNumber(ctx, toBlock)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid to block: %v", err)
+	}
+	if to == nil {
+		latest, err := c.Client().BlockNumber(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to obtain latest block: %v", err)
+		}
+		return scanFromBlock(ctx, fromBlock, latest)
+	}
+	return scanFromBlock(ctx, fromBlock, to.Uint64())
+}
+
+// scanFromBlock returns the first block of a scan along with the last block.
+func scanFromBlock(ctx context.Context, fromBlock string, to uint64) (uint64, uint64, error) {
+	if fromBlock == "" {
+		return to, to, nil
+	}
+	from, err := parseBlockNumber(ctx, fromBlock)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid from block: %v", err)
+	}
+	if from == nil {
+		return to, to, nil
+	}
+	if from.Uint64() > to {
+		return 0, 0, fmt.Errorf("from block %d is after to block %d", from.Uint64(), to)
+	}
+	return from.Uint64(), to, nil
+}
+
+// scanLogs returns a fetch function that obtains the logs matching any of the queries, in the
+// order in which they were emitted and without duplicates.
+func scanLogs(queries ...ethereum.FilterQuery) scanner.FetchFunc {
+	fetches := make([]scanner.FetchFunc, len(queries))
+	for i := range queries {
+		fetches[i] = scanner.Logs(c.Client(), queries[i])
+	}
+	return func(ctx context.Context, from uint64, to uint64) (interface{}, error) {
+		type logKey struct {
+			block uint64
+			index uint
+		}
+		seen := make(map[logKey]bool)
+		res := make([]types.Log, 0)
+		for _, fetch := range fetches {
+			logs, err := fetch(ctx, from, to)
+			if err != nil {
+				return nil, err
+			}
+			for _, log := range logs.([]types.Log) {
+				key := logKey{block: log.BlockNumber, index: log.Index}
+				if !seen[key] && !log.Removed {
+					seen[key] = true
+					res = append(res, log)
+				}
+			}
+		}
+		sort.Slice(res, func(i, j int) bool {
+			if res[i].BlockNumber != res[j].BlockNumber {
+				return res[i].BlockNumber < res[j].BlockNumber
+			}
+			return res[i].Index < res[j].Index
+		})
+		return res, nil
+	}
+}
+
+// scanProgress returns a function that reports the progress of a scan on standard error in
+// verbose mode, or nil otherwise.
+func scanProgress() scanner.ProgressFunc {
+	if !verbose {
+		return nil
+	}
+	return func(progress *scanner.Progress) {
+		fmt.Fprintf(os.Stderr, "\rScanned %d/%d blocks", progress.Completed(), progress.Total())
+		if progress.Next > progress.To {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// secretCmd represents the secret command
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage secrets",
+	Long: `Store and remove account credentials in the operating system's keychain.
+
+Credentials in the keychain are used to sign transactions for the account when no passphrase, private key or other signer is supplied on the command line.`,
+}
+
+func init() {
+	RootCmd.AddCommand(secretCmd)
+}
+
+// secretKind checks the kind of secret supplied on the command line.
+func secretKind(kind string) string {
+	switch kind {
+	case cli.CredentialPassphrase, cli.CredentialPrivateKey:
+		return kind
+	default:
+		cli.Err(quiet, fmt.Sprintf("Unknown kind of secret %q; must be %q or %q", kind, cli.CredentialPassphrase, cli.CredentialPrivateKey))
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/keychain"
+)
+
+var secretDeleteAccount string
+var secretDeleteKind string
+
+// secretDeleteCmd represents the secret delete command
+var secretDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Remove a secret from the keychain",
+	Long: `Remove the passphrase or private key for an account from the operating system's keychain.  For example:
+
+    ethereal secret delete --account=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --kind=passphrase
+
+In quiet mode this will return 0 if the secret was removed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(secretDeleteAccount != "", quiet, "--account is required")
+		kind := secretKind(secretDeleteKind)
+		address, err := c.Resolve(secretDeleteAccount)
+		cli.ErrCheck(err, quiet, "Failed to obtain account")
+
+		err = keychain.Delete(cli.CredentialKey(address, kind))
+		if err == keychain.ErrNotFound {
+			cli.Err(quiet, fmt.Sprintf("No %s stored for %s", kind, address.Hex()))
+		}
+		cli.ErrCheck(err, quiet, "Failed to remove secret")
+		outputIf(verbose, fmt.Sprintf("Removed %s for %s", kind, address.Hex()))
+	},
+}
+
+func init() {
+	offlineCmds["secret:delete"] = true
+	secretCmd.AddCommand(secretDeleteCmd)
+	secretDeleteCmd.Flags().StringVar(&secretDeleteAccount, "account", "", "account for which to remove the secret")
+	secretDeleteCmd.Flags().StringVar(&secretDeleteKind, "kind", cli.CredentialPassphrase, "kind of secret (passphrase/privatekey)")
+}
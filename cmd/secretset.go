@@ -0,0 +1,72 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/keychain"
+)
+
+var secretSetAccount string
+var secretSetKind string
+
+// secretSetCmd represents the secret set command
+var secretSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Store a secret in the keychain",
+	Long: `Store the passphrase or private key for an account in the operating system's keychain.  For example:
+
+    ethereal secret set --account=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --kind=passphrase
+
+The secret is entered at a prompt, with input hidden if running in a terminal, so that it does not appear in shell history.  It is checked against the account before being stored.
+
+In quiet mode this will return 0 if the secret was stored, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(secretSetAccount != "", quiet, "--account is required")
+		kind := secretKind(secretSetKind)
+		address, err := c.Resolve(secretSetAccount)
+		cli.ErrCheck(err, quiet, "Failed to obtain account")
+
+		secret, err := cli.PromptSecret(fmt.Sprintf("Enter %s for %s: ", kind, address.Hex()))
+		cli.ErrCheck(err, quiet, "Failed to obtain secret")
+		cli.Assert(secret != "", quiet, "No secret supplied")
+
+		switch kind {
+		case cli.CredentialPassphrase:
+			wallet, account, err := cli.ObtainWalletAndAccount(c.ChainID(), address)
+			cli.ErrCheck(err, quiet, "Failed to obtain account")
+			cli.Assert(cli.VerifyPassphrase(wallet, *account, secret), quiet, "Invalid passphrase")
+		case cli.CredentialPrivateKey:
+			key, err := crypto.HexToECDSA(strings.TrimPrefix(secret, "0x"))
+			cli.ErrCheck(err, quiet, "Invalid private key")
+			cli.Assert(crypto.PubkeyToAddress(key.PublicKey) == address, quiet, fmt.Sprintf("Private key is not for %s", address.Hex()))
+		}
+
+		err = keychain.Set(cli.CredentialKey(address, kind), secret)
+		cli.ErrCheck(err, quiet, "Failed to store secret")
+		outputIf(verbose, fmt.Sprintf("Stored %s for %s", kind, address.Hex()))
+	},
+}
+
+func init() {
+	offlineCmds["secret:set"] = true
+	secretCmd.AddCommand(secretSetCmd)
+	secretSetCmd.Flags().StringVar(&secretSetAccount, "account", "", "account for which to store the secret")
+	secretSetCmd.Flags().StringVar(&secretSetKind, "kind", cli.CredentialPassphrase, "kind of secret (passphrase/privatekey)")
+}
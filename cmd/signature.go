@@ -17,12 +17,14 @@ import (
 	"encoding/csv"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 	"github.com/wealdtech/ethereal/v2/util/funcparser"
 )
 
@@ -30,6 +32,8 @@ var signatureDataStr string
 var signatureTypes string
 var signatureNoHash bool
 var signaturePacked bool
+var signatureMessage string
+var signatureMessageFile string
 
 // signatureCmd represents the signature command
 var signatureCmd = &cobra.Command{
@@ -39,7 +43,56 @@ var signatureCmd = &cobra.Command{
 	Long:    `Sign and verify information.`,
 }
 
+// signatureMessageMode returns true if an EIP-191 message has been supplied in place of data.
+func signatureMessageMode() bool {
+	return signatureMessage != "" || signatureMessageFile != ""
+}
+
+// signatureInputCheck ensures that a single form of input to be signed has been supplied.
+func signatureInputCheck() {
+	cli.Assert(signatureDataStr != "" || signatureMessageMode(), quiet, "--data, --message or --message-file is required")
+	cli.Assert(!(signatureDataStr != "" && signatureMessageMode()), quiet, "--data cannot be supplied with --message or --message-file")
+	cli.Assert(!(signatureMessage != "" && signatureMessageFile != ""), quiet, "only one of --message and --message-file can be supplied")
+}
+
+// signatureMessageData returns the message supplied with --message or --message-file.
+// A message starting with 0x is treated as hex.
+func signatureMessageData() []byte {
+	if signatureMessageFile != "" {
+		data, err := ioutil.ReadFile(signatureMessageFile)
+		cli.ErrCheck(err, quiet, "Failed to read message file")
+		return data
+	}
+	if strings.HasPrefix(signatureMessage, "0x") {
+		data, err := hex.DecodeString(strings.TrimPrefix(signatureMessage, "0x"))
+		cli.ErrCheck(err, quiet, "Invalid hex message")
+		return data
+	}
+	return []byte(signatureMessage)
+}
+
+// generateDataHash generates the hash to be signed, which is the EIP-191 message hash of the payload.
 func generateDataHash() []byte {
+	if signatureMessageMode() {
+		message := signatureMessageData()
+		outputIf(verbose, fmt.Sprintf("Message is %x", message))
+		return util.MessageHash(message)
+	}
+
+	data := generateDataPayload()
+	buffer := make([]byte, 0)
+	buffer = append(buffer, []byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data)))...)
+	buffer = append(buffer, data...)
+	outputIf(verbose, fmt.Sprintf("Data to sign is %x", buffer))
+	return crypto.Keccak256(buffer)
+}
+
+// generateDataPayload generates the payload of the message to be signed.
+func generateDataPayload() []byte {
+	if signatureMessageMode() {
+		return signatureMessageData()
+	}
+
 	var data []byte
 	if signatureTypes == "" {
 		// No types; might be a hex string or a non-hex string
@@ -94,11 +147,7 @@ func generateDataHash() []byte {
 		data = crypto.Keccak256(data)
 		outputIf(verbose, fmt.Sprintf("Hashed data is %x", data))
 	}
-	buffer := make([]byte, 0)
-	buffer = append(buffer, []byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data)))...)
-	buffer = append(buffer, data...)
-	outputIf(verbose, fmt.Sprintf("Data to sign is %x", buffer))
-	return crypto.Keccak256(buffer)
+	return data
 }
 
 func argumentsAndValues(items string, types string) (abi.Arguments, []interface{}) {
@@ -140,4 +189,6 @@ func signatureFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&signatureTypes, "types", "", "Comma-separated list of data types")
 	cmd.Flags().BoolVar(&signatureNoHash, "nohash", false, "do not hash the message prior to signing")
 	cmd.Flags().BoolVar(&signaturePacked, "packed", false, "use Solidity packed encoding")
+	cmd.Flags().StringVar(&signatureMessage, "message", "", "the message, signed as per personal_sign; hex if prefixed with 0x")
+	cmd.Flags().StringVar(&signatureMessageFile, "message-file", "", "path to a file containing the message, signed as per personal_sign")
 }
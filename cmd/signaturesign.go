@@ -19,7 +19,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
@@ -29,6 +29,9 @@ import (
 var signatureSignSigner string
 var signatureSignPrivateKey string
 var signatureSignPassphrase string
+var signatureSignMnemonic string
+var signatureSignHDPath string
+var signatureSignExternalSigner string
 
 // signatureSignCmd represents the signature sign command
 var signatureSignCmd = &cobra.Command{
@@ -60,35 +63,77 @@ provided below:
   - the message is signed with the provided account or private key
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		cli.Assert(signatureDataStr != "", quiet, "--data is required")
+		signatureInputCheck()
 
-		dataHash := generateDataHash()
-
-		// Sign the hash
 		var signature []byte
-		var key *ecdsa.PrivateKey
-		var err error
-		if signatureSignPassphrase != "" {
-			signer := common.HexToAddress(signatureSignSigner)
-			key, err = util.PrivateKeyForAccount(c.ChainID(), signer, signatureSignPassphrase)
-			cli.ErrCheck(err, quiet, "Invalid account or passphrse")
-		} else if signatureSignPrivateKey != "" {
-			key, err = crypto.HexToECDSA(strings.TrimPrefix(signatureSignPrivateKey, "0x"))
-			cli.ErrCheck(err, quiet, "Invalid private key")
+		if signatureSignExternalSigner != "" {
+			signature = signatureSignExternal()
 		} else {
-			cli.Err(quiet, "no passphrase or private key; cannot sign")
+			signature = signatureSignLocal()
 		}
-		signature, err = crypto.Sign(dataHash, key)
-		cli.ErrCheck(err, quiet, "Failed to sign data")
 
 		if quiet {
 			os.Exit(exitSuccess)
 		}
 
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"signature": fmt.Sprintf("%#x", signature)})
+		}
 		fmt.Printf("%x\n", signature)
 	},
 }
 
+// signatureSignLocal signs the data with a locally-held key.
+func signatureSignLocal() []byte {
+	dataHash := generateDataHash()
+
+	var key *ecdsa.PrivateKey
+	var err error
+	if signatureSignPassphrase != "" {
+		signer, err := c.Resolve(signatureSignSigner)
+		cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+		key, err = util.PrivateKeyForAccount(c.ChainID(), signer, signatureSignPassphrase)
+		cli.ErrCheck(err, quiet, "Invalid account or passphrse")
+	} else if signatureSignPrivateKey != "" {
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(signatureSignPrivateKey, "0x"))
+		cli.ErrCheck(err, quiet, "Invalid private key")
+	} else if signatureSignMnemonic != "" {
+		key, err = util.HDPrivateKey(signatureSignMnemonic, "", signatureSignHDPath)
+		cli.ErrCheck(err, quiet, "Failed to obtain key from mnemonic")
+	} else {
+		cli.Err(quiet, "no passphrase, private key, mnemonic or external signer; cannot sign")
+	}
+	signature, err := crypto.Sign(dataHash, key)
+	cli.ErrCheck(err, quiet, "Failed to sign data")
+	if signatureMessageMode() {
+		// personal_sign uses the legacy recovery ID of 27 or 28.
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+	return signature
+}
+
+// signatureSignExternal signs the data with an external signer.
+func signatureSignExternal() []byte {
+	cli.Assert(signatureSignSigner != "", quiet, "--signer is required when signing with an external signer")
+	signer, err := c.Resolve(signatureSignSigner)
+	cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+	wallet, account, err := cli.ObtainExternalSignerAccount(signatureSignExternalSigner, signer)
+	cli.ErrCheck(err, quiet, "Failed to access external signer")
+
+	// The external signer adds the EIP-191 prefix to the payload itself.
+	signature, err := wallet.SignData(*account, accounts.MimetypeTextPlain, generateDataPayload())
+	cli.ErrCheck(err, quiet, "Failed to sign data")
+	cli.Assert(len(signature) == crypto.SignatureLength, quiet, "External signer returned an invalid signature")
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+	if !signatureMessageMode() {
+		// Data signatures use a recovery ID of 0 or 1.
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	return signature
+}
+
 func init() {
 	offlineCmds["signature:sign"] = true
 	signatureCmd.AddCommand(signatureSignCmd)
@@ -96,4 +141,7 @@ func init() {
 	signatureSignCmd.Flags().StringVar(&signatureSignSigner, "signer", "", "Address of the account to sign the data")
 	signatureSignCmd.Flags().StringVar(&signatureSignPassphrase, "passphrase", "", "Passphrase of the account to sign the data")
 	signatureSignCmd.Flags().StringVar(&signatureSignPrivateKey, "privatekey", "", "Private key to sign the data")
+	signatureSignCmd.Flags().StringVar(&signatureSignMnemonic, "mnemonic", "", "BIP-39 mnemonic of the key to sign the data")
+	signatureSignCmd.Flags().StringVar(&signatureSignExternalSigner, "external-signer", "", "External signer to sign the data, for example clef:///path/to/clef.ipc")
+	signatureSignCmd.Flags().StringVar(&signatureSignHDPath, "hd-path", util.DefaultHDPath, "Derivation path of the key when using a mnemonic")
 }
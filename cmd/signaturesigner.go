@@ -19,41 +19,46 @@ import (
 	"os"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	ens "github.com/wealdtech/go-ens/v3"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var signatureSignerSignature string
 
 // signatureSignerCmd represents the signature signer command
 var signatureSignerCmd = &cobra.Command{
-	Use:   "signer",
-	Short: "Signer of a signature",
+	Use:     "signer",
+	Aliases: []string{"recover"},
+	Short:   "Signer of a signature",
 	Long: `Obtain the signer of a presented signature.  For example:
 
     ethereal signature signer --data="false,2,0x5FfC014343cd971B7eb70732021E26C35B744cc4" --types="bool,uint256,address" --signature=0xcefd09e935b867a231086f41d98644655081a6e4e87c43e05fbbf621dfda69ea305c64fcf73907e09ce242c8ab8bcb953c4b45dd78262d8e34b22a8e4309734f00
 
+The signer of a message signed with personal_sign can be obtained by supplying the message with --message or --message-file.  For example:
+
+    ethereal signature recover --message="Hello, world" --signature=0x16f7a3ddacbffa12a1c416c72b4d3aed54fe605490c48bbca1cdf6ff2b3c3b122102a40374587a963968fae5cf75dda47f97f8f2e5992144edd59b1e782782151b
+
 In quiet mode this will return 0 if the signature provides a valid signer, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		cli.Assert(signatureDataStr != "", quiet, "--data is required")
+		signatureInputCheck()
 
 		dataHash := generateDataHash()
 
 		signature, err := hex.DecodeString(strings.TrimPrefix(signatureSignerSignature, "0x"))
 		cli.ErrCheck(err, quiet, "Invalid signature")
 
-		key, err := crypto.SigToPub(dataHash, signature)
-		cli.ErrCheck(err, quiet, "Failed to signer signature")
-		// nolint:staticcheck
-		address := crypto.PubkeyToAddress(*key)
+		address, err := util.RecoverSigner(dataHash, signature)
+		cli.ErrCheck(err, quiet, "Failed to obtain signer of signature")
 
 		if quiet {
 			os.Exit(exitSuccess)
 		}
 
-		fmt.Printf("%s\n", ens.Format(c.Client(), address))
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"signer": address.Hex()})
+		}
+		fmt.Printf("%s\n", formatAddress(address))
 	},
 }
 
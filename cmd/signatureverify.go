@@ -16,13 +16,14 @@ package cmd
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var signatureVerifySignature string
@@ -36,9 +37,11 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethereal data verify --data="false,2,0x5FfC014343cd971B7eb70732021E26C35B744cc4" --types="bool,uint256,address" --signature=0xcefd09e935b867a231086f41d98644655081a6e4e87c43e05fbbf621dfda69ea305c64fcf73907e09ce242c8ab8bcb953c4b45dd78262d8e34b22a8e4309734f00 --signer=0x0x5FfC014343cd971B7eb70732021E26C35B744cc4
 
+If the signer is a contract, such as a smart contract wallet, the signature is verified by the contract as per ERC-1271.  This requires access to a node; if --offline is supplied the signature is only checked against the signer's key.
+
 In quiet mode this will return 0 if the signature is valid, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		cli.Assert(signatureDataStr != "", quiet, "--data is required")
+		signatureInputCheck()
 		cli.Assert(signatureVerifySigner != "", quiet, "--signer is required")
 
 		dataHash := generateDataHash()
@@ -46,14 +49,45 @@ In quiet mode this will return 0 if the signature is valid, otherwise 1.`,
 		signature, err := hex.DecodeString(strings.TrimPrefix(signatureVerifySignature, "0x"))
 		cli.ErrCheck(err, quiet, "Invalid signature")
 
-		key, err := crypto.SigToPub(dataHash, signature)
-		cli.ErrCheck(err, quiet, "Failed to signer signature")
-		// nolint:staticcheck
-		signer := crypto.PubkeyToAddress(*key)
+		verifySigner, err := c.Resolve(signatureVerifySigner)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve signer %s", signatureVerifySigner))
 
-		verifySigner := common.HexToAddress(signatureVerifySigner)
+		var isContract bool
+		if !offline {
+			ctx, cancel := localContext()
+			defer cancel()
+			code, err := c.Client().CodeAt(ctx, verifySigner, nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain code for signer")
+			isContract = len(code) > 0
+		}
 
-		if bytes.Equal(signer.Bytes(), verifySigner.Bytes()) {
+		var verified bool
+		var signer common.Address
+		if isContract {
+			// Signer is a contract, so ask it to verify the signature as per ERC-1271.
+			outputIf(verbose, "Signer is a contract; verifying with isValidSignature()")
+			ctx, cancel := localContext()
+			defer cancel()
+			verified, err = util.IsValidContractSignature(ctx, c.Client(), verifySigner, common.BytesToHash(dataHash), signature)
+			if err != nil {
+				outputIf(debug, fmt.Sprintf("Contract rejected signature: %v", err))
+				verified = false
+			}
+			signer = verifySigner
+		} else {
+			signer, err = util.RecoverSigner(dataHash, signature)
+			cli.ErrCheck(err, quiet, "Failed to obtain signer of signature")
+			verified = bytes.Equal(signer.Bytes(), verifySigner.Bytes())
+		}
+
+		if jsonOutput() {
+			writeJSON(map[string]interface{}{"signer": signer.Hex(), "verified": verified, "contract": isContract})
+			if !verified {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+		if verified {
 			outputIf(!quiet, "Verified")
 			os.Exit(exitSuccess)
 		} else {
@@ -64,7 +98,6 @@ In quiet mode this will return 0 if the signature is valid, otherwise 1.`,
 }
 
 func init() {
-	offlineCmds["signature:verify"] = true
 	signatureCmd.AddCommand(signatureVerifyCmd)
 	signatureFlags(signatureVerifyCmd)
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySignature, "signature", "", "Hex string signature from which to verify the signer")
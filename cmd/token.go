@@ -17,12 +17,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/util/contracts"
 )
 
 var tokenStr string
+var tokenBlock string
 
 // tokenCmd represents the token command
 var tokenCmd = &cobra.Command{
@@ -56,6 +58,11 @@ func tokenContract(input string) (contract *contracts.ERC20, err error) {
 	return
 }
 
+// tokenCallOpts returns the options for read-only token calls, using the block supplied with --block.
+func tokenCallOpts() *bind.CallOpts {
+	return blockCallOpts(tokenBlock)
+}
+
 func init() {
 	RootCmd.AddCommand(tokenCmd)
 }
@@ -63,3 +70,7 @@ func init() {
 func tokenFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&tokenStr, "token", "", "Name (resolved as <name>.thetoken.eth) or address of the token contract")
 }
+
+func tokenBlockFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&tokenBlock, "block", "latest", "Block at which to obtain information (number, hash or offset from latest)")
+}
@@ -20,7 +20,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var tokenAllowanceRaw bool
@@ -48,11 +47,12 @@ In quiet mode this will return 0 if the allowance is greater than 0, otherwise 1
 		cli.Assert(tokenStr != "", quiet, "--token is required")
 		token, err := tokenContract(tokenStr)
 		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+		opts := tokenCallOpts()
 
-		decimals, err := token.Decimals(nil)
+		decimals, err := token.Decimals(opts)
 		cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
 
-		allowance, err := token.Allowance(nil, holderAddress, spenderAddress)
+		allowance, err := token.Allowance(opts, holderAddress, spenderAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain allowance")
 
 		if quiet {
@@ -63,17 +63,23 @@ In quiet mode this will return 0 if the allowance is greater than 0, otherwise 1
 			}
 		}
 
-		if tokenAllowanceRaw {
-			fmt.Printf("%s\n", allowance.String())
-		} else {
-			fmt.Printf("%s\n", util.TokenValueToString(allowance, decimals, false))
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"holder":    holderAddress.Hex(),
+				"spender":   spenderAddress.Hex(),
+				"allowance": allowance.String(),
+				"decimals":  decimals,
+			})
 		}
+
+		fmt.Printf("%s\n", formatTokens(allowance, decimals, tokenAllowanceRaw))
 	},
 }
 
 func init() {
 	tokenCmd.AddCommand(tokenAllowanceCmd)
 	tokenFlags(tokenAllowanceCmd)
+	tokenBlockFlags(tokenAllowanceCmd)
 	tokenAllowanceCmd.Flags().BoolVar(&tokenAllowanceRaw, "raw", false, "Display raw output (no decimals)")
 	tokenAllowanceCmd.Flags().StringVar(&tokenAllowanceHolderAddress, "holder", "", "Address that holds tokens")
 	tokenAllowanceCmd.Flags().StringVar(&tokenAllowanceSpenderAddress, "spender", "", "Address that can spend tokens")
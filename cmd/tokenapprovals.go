@@ -0,0 +1,380 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	"github.com/wealdtech/ethereal/v2/util/scanner"
+)
+
+var tokenApprovalsAddress string
+var tokenApprovalsTokens []string
+var tokenApprovalsSpenders []string
+var tokenApprovalsFromBlock string
+var tokenApprovalsToBlock string
+var tokenApprovalsChunkSize uint64
+var tokenApprovalsWorkers int
+var tokenApprovalsRevoke bool
+
+// approvalEventID is the topic of the Approval event emitted by ERC-20 and ERC-721 tokens.
+var approvalEventID = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+// approvalForAllEventID is the topic of the ApprovalForAll event emitted by ERC-721 and ERC-1155 tokens.
+var approvalForAllEventID = crypto.Keccak256Hash([]byte("ApprovalForAll(address,address,bool)"))
+
+// erc1155InterfaceID is the ERC-165 interface ID of ERC-1155.
+var erc1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
+// unlimitedAllowance is the allowance above which an allowance is considered unlimited.
+var unlimitedAllowance = new(big.Int).Lsh(big.NewInt(1), 255)
+
+// tokenApprovalsCmd represents the token approvals command
+var tokenApprovalsCmd = &cobra.Command{
+	Use:   "approvals",
+	Short: "Obtain and revoke outstanding token approvals of an address",
+	Long: `Obtain the outstanding ERC-20 allowances and ERC-721 and ERC-1155 approvals granted by an address, and optionally revoke them.  For example:
+
+    ethereal token approvals --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --from-block=earliest
+
+Approvals are discovered by scanning the Approval and ApprovalForAll events emitted over a range of blocks, and are listed only if they are still in place.  Approvals can be restricted to particular tokens with --token and particular spenders with --spender, both of which can be comma-separated or supplied multiple times.
+
+If --revoke is supplied then a transaction is sent to revoke each of the listed approvals.
+
+In quiet mode this will return 0 if any approvals are found (or all revocations are submitted if --revoke is supplied), otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+		tokenApprovalsAddress = accountOrDefault(tokenApprovalsAddress)
+		cli.Assert(tokenApprovalsAddress != "", quiet, "--address is required")
+		address, err := c.Resolve(tokenApprovalsAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve address %s", tokenApprovalsAddress))
+
+		tokens := make([]common.Address, 0)
+		for _, input := range tokenApprovalsTokens {
+			token, err := tokenContractAddress(strings.TrimSpace(input))
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain token %s", input))
+			tokens = append(tokens, token)
+		}
+		spenders := make(map[common.Address]bool)
+		for _, input := range tokenApprovalsSpenders {
+			spender, err := c.Resolve(strings.TrimSpace(input))
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve spender %s", input))
+			spenders[spender] = true
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+		fromBlock, toBlock, err := scanBlocks(ctx, tokenApprovalsFromBlock, tokenApprovalsToBlock)
+		cli.ErrCheck(err, quiet, "Invalid block range")
+
+		// Later events for the same approval replace earlier ones, as logs are handled in order.
+		approvals := make(map[string]*tokenApproval)
+		err = scanner.Scan(context.Background(), &scanner.Config{
+			From: fromBlock,
+			To:   toBlock,
+			Fetch: scanLogs(ethereum.FilterQuery{
+				Addresses: tokens,
+				Topics:    [][]common.Hash{{approvalEventID, approvalForAllEventID}, {common.BytesToHash(address.Bytes())}},
+			}),
+			Handle: func(from uint64, to uint64, result interface{}) error {
+				logs := result.([]types.Log)
+				for i := range logs {
+					if approval := parseTokenApproval(&logs[i]); approval != nil {
+						approvals[approval.key()] = approval
+					}
+				}
+				return nil
+			},
+			Progress:  scanProgress(),
+			ChunkSize: tokenApprovalsChunkSize,
+			Workers:   tokenApprovalsWorkers,
+		})
+		cli.ErrCheck(err, quiet, "Failed to scan for approvals")
+
+		outstanding := make([]*tokenApproval, 0, len(approvals))
+		for _, approval := range approvals {
+			if len(spenders) > 0 && !spenders[approval.spender] {
+				continue
+			}
+			current, err := approval.current(address)
+			if err != nil {
+				outputIf(verbose, fmt.Sprintf("Failed to obtain current state of approval for %s on %s: %v", approval.spender.Hex(), approval.token.symbol, err))
+				continue
+			}
+			if current {
+				outstanding = append(outstanding, approval)
+			}
+		}
+		sort.Slice(outstanding, func(i, j int) bool {
+			if outstanding[i].log.BlockNumber != outstanding[j].log.BlockNumber {
+				return outstanding[i].log.BlockNumber < outstanding[j].log.BlockNumber
+			}
+			return outstanding[i].log.Index < outstanding[j].log.Index
+		})
+
+		if tokenApprovalsRevoke {
+			tokenApprovalsRevokeAll(address, outstanding)
+			os.Exit(exitSuccess)
+		}
+
+		if quiet {
+			if len(outstanding) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			res := make([]*tokenApprovalJSON, len(outstanding))
+			for i, approval := range outstanding {
+				res[i] = approval.json()
+			}
+			outputJSON(res)
+		}
+
+		for _, approval := range outstanding {
+			fmt.Println(approval.String())
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// tokenApproval is an approval granted by an address.
+type tokenApproval struct {
+	log      *types.Log
+	token    *tokenTransferToken
+	standard string
+	spender  common.Address
+	// allowance is set for ERC-20 allowances.
+	allowance *big.Int
+	// tokenID is set for ERC-721 approvals of single tokens.
+	tokenID *big.Int
+	// all is set for ERC-721 and ERC-1155 approvals of all tokens.
+	all bool
+}
+
+// tokenApprovalJSON is the JSON output for an approval.
+type tokenApprovalJSON struct {
+	Token           string `json:"token"`
+	Symbol          string `json:"symbol,omitempty"`
+	Standard        string `json:"standard"`
+	Spender         string `json:"spender"`
+	Allowance       string `json:"allowance,omitempty"`
+	TokenID         string `json:"token_id,omitempty"`
+	All             bool   `json:"all,omitempty"`
+	BlockNumber     uint64 `json:"block_number"`
+	TransactionHash string `json:"transaction_hash"`
+}
+
+// parseTokenApproval parses an Approval or ApprovalForAll log, returning nil if it is not a
+// valid approval or if it removes an approval.
+func parseTokenApproval(log *types.Log) *tokenApproval {
+	approval := &tokenApproval{
+		log: log,
+	}
+	switch {
+	case log.Topics[0] == approvalEventID && len(log.Topics) == 3 && len(log.Data) == 32:
+		approval.standard = "ERC-20"
+		approval.spender = common.BytesToAddress(log.Topics[2].Bytes())
+		approval.allowance = new(big.Int).SetBytes(log.Data)
+	case log.Topics[0] == approvalEventID && len(log.Topics) == 4:
+		approval.standard = "ERC-721"
+		approval.spender = common.BytesToAddress(log.Topics[2].Bytes())
+		approval.tokenID = log.Topics[3].Big()
+	case log.Topics[0] == approvalForAllEventID && len(log.Topics) == 3 && len(log.Data) == 32:
+		approval.standard = "ERC-721"
+		approval.spender = common.BytesToAddress(log.Topics[2].Bytes())
+		approval.all = new(big.Int).SetBytes(log.Data).Sign() != 0
+	default:
+		outputIf(debug, fmt.Sprintf("Ignoring non-standard approval log %d of transaction %s", log.Index, log.TxHash.Hex()))
+		return nil
+	}
+	approval.token = tokenTransferTokenFor(log.Address, approval.standard != "ERC-20")
+	if log.Topics[0] == approvalForAllEventID {
+		if contract, err := contracts.NewERC721(log.Address, c.Client()); err == nil {
+			if supported, err := contract.SupportsInterface(nil, erc1155InterfaceID); err == nil && supported {
+				approval.standard = "ERC-1155"
+			}
+		}
+	}
+	return approval
+}
+
+// key returns a key for the approval, such that a later approval with the same key replaces it.
+func (a *tokenApproval) key() string {
+	switch {
+	case a.tokenID != nil:
+		return fmt.Sprintf("%s:%s", a.token.address.Hex(), a.tokenID)
+	case a.log.Topics[0] == approvalForAllEventID:
+		return fmt.Sprintf("%s:%s:all", a.token.address.Hex(), a.spender.Hex())
+	default:
+		return fmt.Sprintf("%s:%s", a.token.address.Hex(), a.spender.Hex())
+	}
+}
+
+// current returns true if the approval is still in place, updating the allowance of ERC-20
+// approvals to the current value.
+func (a *tokenApproval) current(owner common.Address) (bool, error) {
+	switch {
+	case a.allowance != nil:
+		contract, err := contracts.NewERC20(a.token.address, c.Client())
+		if err != nil {
+			return false, err
+		}
+		a.allowance, err = contract.Allowance(nil, owner, a.spender)
+		if err != nil {
+			return false, err
+		}
+		return a.allowance.Sign() != 0, nil
+	case a.tokenID != nil:
+		if a.spender == (common.Address{}) {
+			return false, nil
+		}
+		contract, err := contracts.NewERC721(a.token.address, c.Client())
+		if err != nil {
+			return false, err
+		}
+		tokenOwner, err := contract.OwnerOf(nil, a.tokenID)
+		if err != nil {
+			return false, err
+		}
+		approved, err := contract.GetApproved(nil, a.tokenID)
+		if err != nil {
+			return false, err
+		}
+		return tokenOwner == owner && approved == a.spender, nil
+	default:
+		if !a.all {
+			return false, nil
+		}
+		contract, err := contracts.NewERC721(a.token.address, c.Client())
+		if err != nil {
+			return false, err
+		}
+		return contract.IsApprovedForAll(nil, owner, a.spender)
+	}
+}
+
+// description describes what the approval allows the spender to do.
+func (a *tokenApproval) description() string {
+	switch {
+	case a.allowance != nil && a.allowance.Cmp(unlimitedAllowance) >= 0:
+		return fmt.Sprintf("unlimited %s", a.token.symbol)
+	case a.allowance != nil:
+		return a.token.amount(a.allowance)
+	case a.tokenID != nil:
+		return fmt.Sprintf("%s #%s", a.token.symbol, a.tokenID)
+	default:
+		return fmt.Sprintf("all %s", a.token.symbol)
+	}
+}
+
+// String provides a single-line representation of the approval.
+func (a *tokenApproval) String() string {
+	return fmt.Sprintf("%s\t%s\t%s\tby %s", a.standard, a.description(), formatAddress(a.spender), a.log.TxHash.Hex())
+}
+
+// json returns the JSON representation of the approval.
+func (a *tokenApproval) json() *tokenApprovalJSON {
+	res := &tokenApprovalJSON{
+		Token:           a.token.address.Hex(),
+		Standard:        a.standard,
+		Spender:         a.spender.Hex(),
+		All:             a.all,
+		BlockNumber:     a.log.BlockNumber,
+		TransactionHash: a.log.TxHash.Hex(),
+	}
+	if a.token.symbol != a.token.address.Hex() {
+		res.Symbol = a.token.symbol
+	}
+	if a.allowance != nil {
+		res.Allowance = a.allowance.String()
+	}
+	if a.tokenID != nil {
+		res.TokenID = a.tokenID.String()
+	}
+	return res
+}
+
+// revoke creates a transaction that revokes the approval.
+func (a *tokenApproval) revoke(opts *bind.TransactOpts) (*types.Transaction, error) {
+	switch {
+	case a.allowance != nil:
+		contract, err := contracts.NewERC20(a.token.address, c.Client())
+		if err != nil {
+			return nil, err
+		}
+		return contract.Approve(opts, a.spender, big.NewInt(0))
+	case a.tokenID != nil:
+		contract, err := contracts.NewERC721(a.token.address, c.Client())
+		if err != nil {
+			return nil, err
+		}
+		return contract.Approve(opts, common.Address{}, a.tokenID)
+	default:
+		contract, err := contracts.NewERC721(a.token.address, c.Client())
+		if err != nil {
+			return nil, err
+		}
+		return contract.SetApprovalForAll(opts, a.spender, false)
+	}
+}
+
+// tokenApprovalsRevokeAll sends a transaction to revoke each of the approvals.
+func tokenApprovalsRevokeAll(owner common.Address, approvals []*tokenApproval) {
+	cli.Assert(len(approvals) > 0, quiet, "No approvals to revoke")
+
+	opts, err := generateTxOpts(owner)
+	cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+	for _, approval := range approvals {
+		outputIf(verbose, fmt.Sprintf("Revoking approval of %s for %s", approval.description(), approval.spender.Hex()))
+		signedTx, err := approval.revoke(opts)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to revoke approval of %s for %s", approval.description(), approval.spender.Hex()))
+		handleSubmittedTransaction(signedTx, log.Fields{
+			"group":        "token",
+			"command":      "approvals",
+			"token":        approval.token.address.Hex(),
+			"tokenholder":  owner.Hex(),
+			"tokenspender": approval.spender.Hex(),
+		}, false)
+		opts.Nonce = new(big.Int).Add(opts.Nonce, big.NewInt(1))
+	}
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenApprovalsCmd)
+	tokenApprovalsCmd.Flags().StringVar(&tokenApprovalsAddress, "address", "", "Address for which to obtain approvals")
+	tokenApprovalsCmd.Flags().StringSliceVar(&tokenApprovalsTokens, "token", nil, "Tokens for which to obtain approvals (defaults to all tokens)")
+	tokenApprovalsCmd.Flags().StringSliceVar(&tokenApprovalsSpenders, "spender", nil, "Spenders for which to obtain approvals (defaults to all spenders)")
+	tokenApprovalsCmd.Flags().StringVar(&tokenApprovalsFromBlock, "from-block", "", "Block from which to scan for approvals (defaults to the to block)")
+	tokenApprovalsCmd.Flags().StringVar(&tokenApprovalsToBlock, "to-block", "latest", "Block to which to scan for approvals")
+	tokenApprovalsCmd.Flags().Uint64Var(&tokenApprovalsChunkSize, "chunk-size", scanner.DefaultChunkSize, "Number of blocks to request logs for at a time")
+	tokenApprovalsCmd.Flags().IntVar(&tokenApprovalsWorkers, "workers", scanner.DefaultWorkers, "Number of requests to make in parallel")
+	tokenApprovalsCmd.Flags().BoolVar(&tokenApprovalsRevoke, "revoke", false, "Revoke the approvals")
+	addTransactionFlags(tokenApprovalsCmd, "the address that granted the approvals")
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
@@ -64,7 +62,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		allowance, err := token.Allowance(nil, holderAddress, spenderAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain allowance")
 
-		cli.Assert(allowance.Cmp(big.NewInt(0)) == 0 || amount.Cmp(big.NewInt(0)) == 0, quiet, fmt.Sprintf("Allowance is currently %s; it must be set to zero before being changed to avoid a potential double spend", util.TokenValueToString(allowance, decimals, false)))
+		cli.Assert(allowance.Cmp(big.NewInt(0)) == 0 || amount.Cmp(big.NewInt(0)) == 0, quiet, fmt.Sprintf("Allowance is currently %s; it must be set to zero before being changed to avoid a potential double spend", formatTokens(allowance, decimals, false)))
 
 		opts, err := generateTxOpts(holderAddress)
 		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
@@ -73,11 +71,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
@@ -95,7 +89,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 func init() {
 	tokenCmd.AddCommand(tokenApproveCmd)
 	tokenFlags(tokenApproveCmd)
-	tokenApproveCmd.Flags().StringVar(&tokenApproveAmount, "amount", "", "Amount to approve")
+	tokenApproveCmd.Flags().StringVar(&tokenApproveAmount, "amount", "", "Amount to approve, for example \"1.5 tokens\"")
 	tokenApproveCmd.Flags().StringVar(&tokenApproveHolderAddress, "holder", "", "Address that holds tokens")
 	tokenApproveCmd.Flags().StringVar(&tokenApproveSpenderAddress, "spender", "", "Address that can spend tokens")
 	addTransactionFlags(tokenApproveCmd, "the address from which to approve tokens")
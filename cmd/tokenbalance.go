@@ -20,7 +20,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var tokenBalanceHolderAddress string
@@ -43,11 +42,12 @@ In quiet mode this will return 0 if the balance is greater than 0, otherwise 1.`
 		cli.Assert(tokenStr != "", quiet, "--token is required")
 		token, err := tokenContract(tokenStr)
 		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+		opts := tokenCallOpts()
 
-		decimals, err := token.Decimals(nil)
+		decimals, err := token.Decimals(opts)
 		cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
 
-		balance, err := token.BalanceOf(nil, address)
+		balance, err := token.BalanceOf(opts, address)
 		cli.ErrCheck(err, quiet, "Failed to obtain token balance")
 
 		if quiet {
@@ -58,16 +58,21 @@ In quiet mode this will return 0 if the balance is greater than 0, otherwise 1.`
 			}
 		}
 
-		if tokenBalanceRaw {
-			fmt.Printf("%s\n", balance.String())
-		} else {
-			fmt.Printf("%s\n", util.TokenValueToString(balance, decimals, false))
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"holder":   address.Hex(),
+				"balance":  balance.String(),
+				"decimals": decimals,
+			})
 		}
+
+		fmt.Printf("%s\n", formatTokens(balance, decimals, tokenBalanceRaw))
 	},
 }
 
 func init() {
 	tokenFlags(tokenBalanceCmd)
+	tokenBlockFlags(tokenBalanceCmd)
 	tokenCmd.AddCommand(tokenBalanceCmd)
 	tokenBalanceCmd.Flags().BoolVar(&tokenBalanceRaw, "raw", false, "Display raw output (no decimals)")
 	tokenBalanceCmd.Flags().StringVar(&tokenBalanceHolderAddress, "holder", "", "Holder of tokens")
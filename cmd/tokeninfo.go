@@ -19,8 +19,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
-	ens "github.com/wealdtech/go-ens/v3"
 )
 
 // tokenInfoCmd represents the token info command
@@ -36,12 +34,33 @@ In quiet mode this will return 0 if the token exists, otherwise 1.`,
 		cli.Assert(tokenStr != "", quiet, "--token is required")
 		token, err := tokenContract(tokenStr)
 		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+		opts := tokenCallOpts()
 
 		if quiet {
 			os.Exit(exitSuccess)
 		}
 
-		name, err := token.Name(nil)
+		if jsonOutput() {
+			res := make(map[string]interface{})
+			if address, err := tokenContractAddress(tokenStr); err == nil {
+				res["address"] = address.Hex()
+			}
+			if name, err := token.Name(opts); err == nil {
+				res["name"] = name
+			}
+			if symbol, err := token.Symbol(opts); err == nil {
+				res["symbol"] = symbol
+			}
+			if decimals, err := token.Decimals(opts); err == nil {
+				res["decimals"] = decimals
+			}
+			if totalSupply, err := token.TotalSupply(opts); err == nil {
+				res["total_supply"] = totalSupply.String()
+			}
+			outputJSON(res)
+		}
+
+		name, err := token.Name(opts)
 		if err == nil {
 			fmt.Printf("Name:\t\t%s\n", name)
 		}
@@ -49,28 +68,29 @@ In quiet mode this will return 0 if the token exists, otherwise 1.`,
 		if verbose {
 			address, err := tokenContractAddress(tokenStr)
 			if err == nil {
-				fmt.Printf("Address:\t%s\n", ens.Format(c.Client(), address))
+				fmt.Printf("Address:\t%s\n", formatAddress(address))
 			}
 		}
 
-		symbol, err := token.Symbol(nil)
+		symbol, err := token.Symbol(opts)
 		if err == nil {
 			fmt.Printf("Symbol:\t\t%s\n", symbol)
 		}
 
-		decimals, err := token.Decimals(nil)
+		decimals, err := token.Decimals(opts)
 		if err == nil {
 			fmt.Printf("Decimals:\t%d\n", decimals)
 		}
 
-		totalSupply, err := token.TotalSupply(nil)
+		totalSupply, err := token.TotalSupply(opts)
 		if err == nil {
-			fmt.Printf("Total supply:\t%s\n", util.TokenValueToString(totalSupply, decimals, true))
+			fmt.Printf("Total supply:\t%s\n", formatTokens(totalSupply, decimals, false))
 		}
 	},
 }
 
 func init() {
 	tokenFlags(tokenInfoCmd)
+	tokenBlockFlags(tokenInfoCmd)
 	tokenCmd.AddCommand(tokenInfoCmd)
 }
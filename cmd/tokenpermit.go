@@ -0,0 +1,204 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var tokenPermitAmount string
+var tokenPermitHolderAddress string
+var tokenPermitSpenderAddress string
+var tokenPermitDeadline string
+var tokenPermitVersion string
+var tokenPermitSubmit bool
+
+// tokenPermitCmd represents the token permit command
+var tokenPermitCmd = &cobra.Command{
+	Use:   "permit",
+	Short: "Sign a permit for an address to transfer tokens",
+	Long: `Sign an EIP-2612 permit allowing one address to spend tokens on behalf of another.  For example:
+
+    ethereal token permit --token=0x6B175474E89094C44Da98b954EedeAC495271d0F --holder=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --spender=0x52f1A3027d3aA514F17E454C93ae1F79b3B12d5d --amount=10 --deadline=1h --passphrase=secret
+
+The permit can be passed to the spender, or anyone else, to submit to the token contract.  If --submit is supplied the permit is submitted by the holder.
+
+--amount can be "max" to allow the spender to transfer any amount.  --deadline can be a duration from now, for example "1h", or a Unix timestamp.  The version of the token's EIP-712 domain is obtained from the token if possible, otherwise it defaults to "1"; it can be set with --permit-version.
+
+In quiet mode this will return 0 if the permit is signed, otherwise 1.  If --submit is supplied this will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+
+		cli.Assert(tokenPermitHolderAddress != "", quiet, "--holder is required")
+		holderAddress, err := c.Resolve(tokenPermitHolderAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve holder address %s", tokenPermitHolderAddress))
+
+		cli.Assert(tokenPermitSpenderAddress != "", quiet, "--spender is required")
+		spenderAddress, err := c.Resolve(tokenPermitSpenderAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve spender address %s", tokenPermitSpenderAddress))
+
+		cli.Assert(tokenStr != "", quiet, "--token is required")
+		tokenAddress, err := tokenContractAddress(tokenStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain token address")
+		token, err := contracts.NewERC20(tokenAddress, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+		permitToken, err := contracts.NewERC20Permit(tokenAddress, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+
+		cli.Assert(tokenPermitAmount != "", quiet, "--amount is required")
+		var amount *big.Int
+		if strings.EqualFold(tokenPermitAmount, "max") {
+			amount = math.MaxBig256
+		} else {
+			decimals, err := token.Decimals(nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
+			amount, err = util.StringToTokenValue(tokenPermitAmount, decimals)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+		}
+
+		deadline, err := tokenPermitParseDeadline(tokenPermitDeadline)
+		cli.ErrCheck(err, quiet, "Invalid deadline")
+
+		domain := &util.PermitDomain{
+			ChainID:           c.ChainID(),
+			VerifyingContract: tokenAddress,
+			Version:           tokenPermitVersion,
+		}
+		domain.Name, err = token.Name(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain token name")
+		if domain.Version == "" {
+			domain.Version, err = permitToken.Version(nil)
+			if err != nil || domain.Version == "" {
+				domain.Version = "1"
+			}
+		}
+		domainSeparator, err := permitToken.DOMAINSEPARATOR(nil)
+		cli.ErrCheck(err, quiet, "Token does not support permits")
+		cli.Assert(domainSeparator == domain.Separator(), quiet, "Token domain separator does not match; supply the token's version with --permit-version")
+
+		nonce, err := permitToken.Nonces(nil, holderAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain permit nonce")
+
+		permit := &util.Permit{
+			Owner:    holderAddress,
+			Spender:  spenderAddress,
+			Value:    amount,
+			Nonce:    nonce,
+			Deadline: deadline,
+		}
+		key, err := localSigningKey(holderAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain key for holder")
+		signature, err := crypto.Sign(util.PermitHash(domainSeparator, permit).Bytes(), key)
+		cli.ErrCheck(err, quiet, "Failed to sign permit")
+		// Permits use the legacy recovery ID of 27 or 28.
+		signature[64] += 27
+		v := signature[64]
+		var r, s [32]byte
+		copy(r[:], signature[0:32])
+		copy(s[:], signature[32:64])
+
+		if tokenPermitSubmit {
+			opts, err := generateTxOpts(holderAddress)
+			cli.ErrCheck(err, quiet, "Failed to generate transaction options")
+			signedTx, err := permitToken.Permit(opts, holderAddress, spenderAddress, amount, deadline, v, r, s)
+			cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+			handleSubmittedTransaction(signedTx, log.Fields{
+				"group":        "token",
+				"command":      "permit",
+				"token":        tokenStr,
+				"tokenholder":  holderAddress.Hex(),
+				"tokenspender": spenderAddress.Hex(),
+				"tokenamount":  amount.String(),
+				"deadline":     deadline.String(),
+			}, true)
+			return
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&tokenPermitJSON{
+				Owner:     holderAddress.Hex(),
+				Spender:   spenderAddress.Hex(),
+				Value:     amount.String(),
+				Nonce:     nonce.String(),
+				Deadline:  deadline.String(),
+				V:         v,
+				R:         fmt.Sprintf("%#x", r),
+				S:         fmt.Sprintf("%#x", s),
+				Signature: fmt.Sprintf("%#x", signature),
+			})
+		}
+
+		outputIf(verbose, fmt.Sprintf("Nonce:\t\t%s", nonce.String()))
+		fmt.Printf("Deadline:\t%s\n", deadline.String())
+		fmt.Printf("v:\t\t%d\n", v)
+		fmt.Printf("r:\t\t%#x\n", r)
+		fmt.Printf("s:\t\t%#x\n", s)
+	},
+}
+
+// tokenPermitJSON is the JSON output for the token permit command.
+type tokenPermitJSON struct {
+	Owner     string `json:"owner"`
+	Spender   string `json:"spender"`
+	Value     string `json:"value"`
+	Nonce     string `json:"nonce"`
+	Deadline  string `json:"deadline"`
+	V         uint8  `json:"v"`
+	R         string `json:"r"`
+	S         string `json:"s"`
+	Signature string `json:"signature"`
+}
+
+// tokenPermitParseDeadline parses a deadline supplied as either a duration from now or
+// a Unix timestamp.
+func tokenPermitParseDeadline(input string) (*big.Int, error) {
+	if duration, err := time.ParseDuration(input); err == nil {
+		return big.NewInt(time.Now().Add(duration).Unix()), nil
+	}
+	timestamp, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("deadline %s is neither a duration nor a timestamp", input)
+	}
+	return big.NewInt(timestamp), nil
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenPermitCmd)
+	tokenFlags(tokenPermitCmd)
+	tokenPermitCmd.Flags().StringVar(&tokenPermitAmount, "amount", "", "Amount to permit, for example \"1.5 tokens\", or \"max\"")
+	tokenPermitCmd.Flags().StringVar(&tokenPermitHolderAddress, "holder", "", "Address that holds tokens")
+	tokenPermitCmd.Flags().StringVar(&tokenPermitSpenderAddress, "spender", "", "Address that can spend tokens")
+	tokenPermitCmd.Flags().StringVar(&tokenPermitDeadline, "deadline", "1h", "Time until which the permit is valid, as a duration from now or a Unix timestamp")
+	tokenPermitCmd.Flags().StringVar(&tokenPermitVersion, "permit-version", "", "Version of the token's EIP-712 domain (default obtained from the token, or 1)")
+	tokenPermitCmd.Flags().BoolVar(&tokenPermitSubmit, "submit", false, "Submit the permit to the token contract rather than printing it")
+	addTransactionFlags(tokenPermitCmd, "the address that holds tokens")
+}
@@ -0,0 +1,129 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	"github.com/wealdtech/ethereal/v2/util/disperse"
+)
+
+var tokenSendManyApprove bool
+
+// tokenSendManyCmd represents the token sendmany command
+var tokenSendManyCmd = &cobra.Command{
+	Use:   "sendmany",
+	Short: "Send tokens to many addresses in a single transaction",
+	Long: `Send tokens to many addresses in a single transaction through a disperse contract.  For example:
+
+    ethereal token sendmany --token=omg --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --file=payouts.csv --approve --passphrase=secret
+
+The file contains one payment per row, either as CSV with to and value columns or as a JSON array of objects with to and value fields.  Amounts are in tokens, for example "1.5".
+
+The disperse contract must be approved to spend the total of the payments.  If the current allowance is insufficient then --approve will approve it before sending the payments.  If the Disperse contract is not present on the chain then --deploy will deploy a compatible contract before sending the payments.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
+
+		fromAddress := disperseSender()
+
+		cli.Assert(tokenStr != "", quiet, "--token is required")
+		tokenAddress, err := tokenContractAddress(tokenStr)
+		cli.ErrCheck(err, quiet, "Failed to obtain token address")
+		token, err := contracts.NewERC20(tokenAddress, c.Client())
+		cli.ErrCheck(err, quiet, "Failed to obtain token contract")
+		decimals, err := token.Decimals(nil)
+		cli.ErrCheck(err, quiet, "Failed to obtain token decimals")
+
+		recipients, values := dispersePayments(func(input string) (*big.Int, error) {
+			return util.StringToTokenValue(input, decimals)
+		})
+		total := disperse.Total(values)
+		outputIf(verbose, fmt.Sprintf("Sending %s to %d recipients", formatTokens(total, decimals, false), len(recipients)))
+
+		balance, err := token.BalanceOf(nil, fromAddress)
+		cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send tokens")
+		cli.Assert(balance.Cmp(total) >= 0, quiet, fmt.Sprintf("Balance of %s insufficient for payments totalling %s", formatTokens(balance, decimals, false), formatTokens(total, decimals, false)))
+
+		contract := disperseContract(fromAddress)
+		tokenApproveDisperse(token, fromAddress, contract, total, decimals)
+
+		data, err := disperse.TokenData(tokenAddress, recipients, values)
+		cli.ErrCheck(err, quiet, "Failed to create payments")
+
+		sendDisperseTransaction(fromAddress, contract, big.NewInt(0), data, log.Fields{
+			"group":       "token",
+			"command":     "sendmany",
+			"token":       tokenStr,
+			"tokenholder": fromAddress.Hex(),
+			"recipients":  len(recipients),
+			"tokenamount": total.String(),
+		})
+	},
+}
+
+// tokenApproveDisperse ensures that the disperse contract is approved to spend at least the total
+// of the payments.  If approval is required and --approve is set then the approval transaction is
+// sent and mined before returning.
+func tokenApproveDisperse(token *contracts.ERC20, holder common.Address, spender common.Address, total *big.Int, decimals uint8) {
+	allowance, err := token.Allowance(nil, holder, spender)
+	cli.ErrCheck(err, quiet, "Failed to obtain allowance")
+	if allowance.Cmp(total) >= 0 {
+		return
+	}
+	cli.Assert(tokenSendManyApprove, quiet, fmt.Sprintf("Allowance of %s for %s insufficient for payments; use --approve to approve it", formatTokens(allowance, decimals, false), spender.Hex()))
+	cli.Assert(allowance.Sign() == 0, quiet, fmt.Sprintf("Allowance is currently %s; it must be set to zero before being changed to avoid a potential double spend", formatTokens(allowance, decimals, false)))
+
+	opts, err := generateTxOpts(holder)
+	cli.ErrCheck(err, quiet, "Failed to generate approval transaction options")
+	opts.Value = nil
+	opts.GasLimit = 0
+	tx, err := token.Approve(opts, spender, total)
+	cli.ErrCheck(err, quiet, "Failed to send approval transaction")
+	if dryRun {
+		// The approval was simulated, so there is nothing to wait for.
+		return
+	}
+	logTransaction(tx, log.Fields{
+		"group":        "token",
+		"command":      "approve",
+		"token":        tokenStr,
+		"tokenholder":  holder.Hex(),
+		"tokenspender": spender.Hex(),
+		"tokenamount":  total.String(),
+	})
+	outputIf(!quiet, fmt.Sprintf("Waiting for transaction %#x approving the disperse contract to be mined", tx.Hash()))
+	mined, err := c.WaitForTransaction(context.Background(), tx.Hash(), 1, 0)
+	cli.ErrCheck(err, quiet, "Failed to mine approval transaction")
+	cli.Assert(mined.Receipt.Status == types.ReceiptStatusSuccessful, quiet, "Approval failed")
+	_, err = c.NextNonce(context.Background(), holder)
+	cli.ErrCheck(err, quiet, "failed to increment nonce")
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenSendManyCmd)
+	tokenFlags(tokenSendManyCmd)
+	disperseFlags(tokenSendManyCmd)
+	tokenSendManyCmd.Flags().BoolVar(&tokenSendManyApprove, "approve", false, "Approve the disperse contract to spend the tokens if required")
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
@@ -23,7 +21,6 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
 )
 
 var tokenSweepFromAddress string
@@ -41,6 +38,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(!offline, quiet, "Offline mode not supported at current with this command")
 
+		tokenSweepFromAddress = accountOrDefault(tokenSweepFromAddress)
 		cli.Assert(tokenSweepFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(tokenSweepFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", tokenSweepFromAddress))
@@ -63,7 +61,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			if err == nil {
 				decimals, err := token.Decimals(nil)
 				if err == nil {
-					fmt.Printf("Sweeping %s %s\n", util.TokenValueToString(balance, decimals, false), symbol)
+					outputIf(verbose, fmt.Sprintf("Sweeping %s %s", formatTokens(balance, decimals, false), symbol))
 				}
 			}
 		}
@@ -75,11 +73,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"strconv"
@@ -42,6 +40,7 @@ var tokenTransferCmd = &cobra.Command{
 
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		tokenTransferFromAddress = accountOrDefault(tokenTransferFromAddress)
 		cli.Assert(tokenTransferFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(tokenTransferFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", tokenTransferFromAddress))
@@ -75,7 +74,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		if !offline {
 			balance, err := token.BalanceOf(nil, fromAddress)
 			cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
-			cli.Assert(balance.Cmp(amount) >= 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", util.TokenValueToString(balance, decimals, false)))
+			cli.Assert(balance.Cmp(amount) >= 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", formatTokens(balance, decimals, false)))
 		}
 
 		opts, err := generateTxOpts(fromAddress)
@@ -85,11 +84,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
@@ -107,7 +102,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 func init() {
 	tokenCmd.AddCommand(tokenTransferCmd)
 	tokenFlags(tokenTransferCmd)
-	tokenTransferCmd.Flags().StringVar(&tokenTransferAmount, "amount", "", "Amount to transfer")
+	tokenTransferCmd.Flags().StringVar(&tokenTransferAmount, "amount", "", "Amount to transfer, for example \"1.5 tokens\"")
 	tokenTransferCmd.Flags().StringVar(&tokenTransferFromAddress, "from", "", "Address from which to transfer tokens")
 	tokenTransferCmd.Flags().StringVar(&tokenTransferToAddress, "to", "", "Address to which to transfer tokens")
 	tokenTransferCmd.Flags().StringVar(&tokenTransferDecimals, "decimals", "18", "Number of decimals for the transfer (only required if offline)")
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -68,12 +66,12 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		// Obtain the balance of the address
 		balance, err := token.BalanceOf(nil, fromAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
-		cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", util.TokenValueToString(balance, decimals, false)))
+		cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", formatTokens(balance, decimals, false)))
 
 		// Obtain the allowance of the address
 		allowance, err := token.Allowance(nil, fromAddress, byAddress)
 		cli.ErrCheck(err, quiet, "Failed to obtain allowance of address from which to send funds")
-		cli.Assert(allowance.Cmp(amount) > 0, quiet, fmt.Sprintf("Allowance of %s insufficient for transfer", util.TokenValueToString(allowance, decimals, false)))
+		cli.Assert(allowance.Cmp(amount) > 0, quiet, fmt.Sprintf("Allowance of %s insufficient for transfer", formatTokens(allowance, decimals, false)))
 
 		opts, err := generateTxOpts(byAddress)
 		cli.ErrCheck(err, quiet, "Failed to generate transaction options")
@@ -81,11 +79,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		signedTx, err := token.TransferFrom(opts, fromAddress, toAddress, amount)
 		cli.ErrCheck(err, quiet, "Failed to create transaction")
 		if offline {
-			if !quiet {
-				buf := new(bytes.Buffer)
-				cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-				fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-			}
+			outputSignedTransaction(signedTx)
 			os.Exit(exitSuccess)
 		}
 
@@ -104,7 +98,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 func init() {
 	tokenCmd.AddCommand(tokenTransferFromCmd)
 	tokenFlags(tokenTransferFromCmd)
-	tokenTransferFromCmd.Flags().StringVar(&tokenTransferFromAmount, "amount", "", "Amount to transfer")
+	tokenTransferFromCmd.Flags().StringVar(&tokenTransferFromAmount, "amount", "", "Amount to transfer, for example \"1.5 tokens\"")
 	tokenTransferFromCmd.Flags().StringVar(&tokenTransferFromFromAddress, "from", "", "Address from which to transfer tokens")
 	tokenTransferFromCmd.Flags().StringVar(&tokenTransferFromToAddress, "to", "", "Address to which to transfer tokens")
 	tokenTransferFromCmd.Flags().StringVar(&tokenTransferFromByAddress, "by", "", "Address allowed to transfer tokens")
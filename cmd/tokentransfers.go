@@ -0,0 +1,305 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+	"github.com/wealdtech/ethereal/v2/util/scanner"
+)
+
+var tokenTransfersAddress string
+var tokenTransfersTokens []string
+var tokenTransfersFromBlock string
+var tokenTransfersToBlock string
+var tokenTransfersChunkSize uint64
+var tokenTransfersWorkers int
+
+// transferEventID is the topic of the Transfer event emitted by ERC-20 and ERC-721 tokens.
+var transferEventID = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// tokenTransfersCmd represents the token transfers command
+var tokenTransfersCmd = &cobra.Command{
+	Use:   "transfers",
+	Short: "Obtain the token transfer history of an address",
+	Long: `Obtain the ERC-20 and ERC-721 token transfers to and from an address over a range of blocks, as a chronological statement with running balances.  For example:
+
+    ethereal token transfers --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --from-block=-100000
+
+Blocks can be supplied as numbers, as offsets from the latest block (e.g. -1000), or as "latest" or "earliest".  Transfers can be restricted to particular tokens with --token, which can be comma-separated or supplied multiple times.
+
+Running balances are calculated back from the balance of each token at the last block of the range, and so are correct at the start of the range even if it does not cover all transfers.  If the balance at the last block cannot be obtained, for example because it requires an archive node, running balances start from 0.
+
+In quiet mode this will return 0 if any transfers are found, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain transfers when offline")
+		tokenTransfersAddress = accountOrDefault(tokenTransfersAddress)
+		cli.Assert(tokenTransfersAddress != "", quiet, "--address is required")
+		address, err := c.Resolve(tokenTransfersAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve address %s", tokenTransfersAddress))
+
+		tokens := make([]common.Address, 0)
+		for _, input := range tokenTransfersTokens {
+			token, err := tokenContractAddress(strings.TrimSpace(input))
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain token %s", input))
+			tokens = append(tokens, token)
+		}
+
+		ctx, cancel := localContext()
+		defer cancel()
+		fromBlock, toBlock, err := scanBlocks(ctx, tokenTransfersFromBlock, tokenTransfersToBlock)
+		cli.ErrCheck(err, quiet, "Invalid block range")
+
+		addressTopic := common.BytesToHash(address.Bytes())
+		logs := make([]types.Log, 0)
+		err = scanner.Scan(context.Background(), &scanner.Config{
+			From: fromBlock,
+			To:   toBlock,
+			Fetch: scanLogs(
+				ethereum.FilterQuery{Addresses: tokens, Topics: [][]common.Hash{{transferEventID}, {addressTopic}}},
+				ethereum.FilterQuery{Addresses: tokens, Topics: [][]common.Hash{{transferEventID}, nil, {addressTopic}}},
+			),
+			Handle: func(from uint64, to uint64, result interface{}) error {
+				logs = append(logs, result.([]types.Log)...)
+				return nil
+			},
+			Progress:  scanProgress(),
+			ChunkSize: tokenTransfersChunkSize,
+			Workers:   tokenTransfersWorkers,
+		})
+		cli.ErrCheck(err, quiet, "Failed to scan for transfers")
+
+		transfers := make([]*tokenTransfer, 0, len(logs))
+		for i := range logs {
+			if transfer := parseTokenTransfer(&logs[i], address); transfer != nil {
+				transfers = append(transfers, transfer)
+			}
+		}
+
+		if quiet {
+			if len(transfers) == 0 {
+				os.Exit(exitFailure)
+			}
+			os.Exit(exitSuccess)
+		}
+
+		tokenTransfersBalances(transfers, address, toBlock)
+
+		if jsonOutput() {
+			res := make([]*tokenTransferJSON, len(transfers))
+			for i, transfer := range transfers {
+				res[i] = transfer.json()
+			}
+			outputJSON(res)
+		}
+
+		for _, transfer := range transfers {
+			fmt.Println(transfer.String())
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// tokenTransferToken is a token involved in transfers.
+type tokenTransferToken struct {
+	address  common.Address
+	symbol   string
+	decimals uint8
+	nft      bool
+	balance  *big.Int
+}
+
+// tokenTransfer is a single token transfer to or from an address.
+type tokenTransfer struct {
+	log     *types.Log
+	token   *tokenTransferToken
+	from    common.Address
+	to      common.Address
+	value   *big.Int
+	tokenID *big.Int
+	delta   *big.Int
+	balance *big.Int
+}
+
+// tokenTransferJSON is the JSON output for a token transfer.
+type tokenTransferJSON struct {
+	BlockNumber     uint64 `json:"block_number"`
+	TransactionHash string `json:"transaction_hash"`
+	LogIndex        uint   `json:"log_index"`
+	Token           string `json:"token"`
+	Symbol          string `json:"symbol,omitempty"`
+	Standard        string `json:"standard"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Value           string `json:"value,omitempty"`
+	TokenID         string `json:"token_id,omitempty"`
+	Balance         string `json:"balance,omitempty"`
+}
+
+// tokenTransferTokens caches tokens by address.
+var tokenTransferTokens = make(map[common.Address]*tokenTransferToken)
+
+// parseTokenTransfer parses a Transfer log, returning nil if it is not a valid ERC-20 or
+// ERC-721 transfer.
+func parseTokenTransfer(log *types.Log, address common.Address) *tokenTransfer {
+	transfer := &tokenTransfer{
+		log: log,
+	}
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == 32:
+		transfer.value = new(big.Int).SetBytes(log.Data)
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		transfer.tokenID = log.Topics[3].Big()
+		transfer.value = big.NewInt(1)
+	default:
+		outputIf(debug, fmt.Sprintf("Ignoring non-standard transfer log %d of transaction %s", log.Index, log.TxHash.Hex()))
+		return nil
+	}
+	transfer.from = common.BytesToAddress(log.Topics[1].Bytes())
+	transfer.to = common.BytesToAddress(log.Topics[2].Bytes())
+	transfer.delta = new(big.Int)
+	if transfer.to == address {
+		transfer.delta.Add(transfer.delta, transfer.value)
+	}
+	if transfer.from == address {
+		transfer.delta.Sub(transfer.delta, transfer.value)
+	}
+	transfer.token = tokenTransferTokenFor(log.Address, transfer.tokenID != nil)
+	return transfer
+}
+
+// tokenTransferTokenFor returns the information for the token at the given address.
+func tokenTransferTokenFor(address common.Address, nft bool) *tokenTransferToken {
+	if token, exists := tokenTransferTokens[address]; exists {
+		return token
+	}
+	token := &tokenTransferToken{
+		address: address,
+		symbol:  address.Hex(),
+		nft:     nft,
+	}
+	if contract, err := contracts.NewERC20(address, c.Client()); err == nil {
+		if symbol, err := contract.Symbol(nil); err == nil && symbol != "" {
+			token.symbol = symbol
+		}
+		if !nft {
+			if decimals, err := contract.Decimals(nil); err == nil {
+				token.decimals = decimals
+			}
+		}
+	}
+	tokenTransferTokens[address] = token
+	return token
+}
+
+// tokenTransfersBalances sets the running balances of the transfers, working back from the
+// balance of each token at the last block.
+func tokenTransfersBalances(transfers []*tokenTransfer, address common.Address, toBlock uint64) {
+	net := make(map[common.Address]*big.Int)
+	for _, transfer := range transfers {
+		if net[transfer.token.address] == nil {
+			net[transfer.token.address] = new(big.Int)
+		}
+		net[transfer.token.address].Add(net[transfer.token.address], transfer.delta)
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(toBlock)}
+	for tokenAddress, change := range net {
+		token := tokenTransferTokens[tokenAddress]
+		token.balance = new(big.Int)
+		contract, err := contracts.NewERC20(tokenAddress, c.Client())
+		if err == nil {
+			var balance *big.Int
+			balance, err = contract.BalanceOf(opts, address)
+			if err == nil {
+				token.balance.Sub(balance, change)
+			}
+		}
+		outputIf(verbose && err != nil, fmt.Sprintf("Failed to obtain balance of %s; running balance starts from 0", token.symbol))
+	}
+	for _, transfer := range transfers {
+		transfer.token.balance.Add(transfer.token.balance, transfer.delta)
+		transfer.balance = new(big.Int).Set(transfer.token.balance)
+	}
+}
+
+// amount formats an amount of the token.
+func (t *tokenTransferToken) amount(value *big.Int) string {
+	return fmt.Sprintf("%s %s", formatTokens(value, t.decimals, false), t.symbol)
+}
+
+// String provides a single-line representation of the transfer.
+func (t *tokenTransfer) String() string {
+	var amount string
+	if t.tokenID != nil {
+		amount = fmt.Sprintf("%s #%s", t.token.symbol, t.tokenID)
+	} else {
+		amount = t.token.amount(t.value)
+	}
+	var movement string
+	switch t.delta.Sign() {
+	case 1:
+		movement = fmt.Sprintf("+%s\tfrom %s", amount, t.from.Hex())
+	case -1:
+		movement = fmt.Sprintf("-%s\tto %s", amount, t.to.Hex())
+	default:
+		movement = fmt.Sprintf("%s\tto self", amount)
+	}
+	return fmt.Sprintf("%d\t%s\t%s\tbalance %s", t.log.BlockNumber, t.log.TxHash.Hex(), movement, t.token.amount(t.balance))
+}
+
+// json returns the JSON representation of the transfer.
+func (t *tokenTransfer) json() *tokenTransferJSON {
+	res := &tokenTransferJSON{
+		BlockNumber:     t.log.BlockNumber,
+		TransactionHash: t.log.TxHash.Hex(),
+		LogIndex:        t.log.Index,
+		Token:           t.token.address.Hex(),
+		Standard:        "ERC-20",
+		From:            t.from.Hex(),
+		To:              t.to.Hex(),
+		Balance:         t.balance.String(),
+	}
+	if t.token.symbol != t.token.address.Hex() {
+		res.Symbol = t.token.symbol
+	}
+	if t.tokenID != nil {
+		res.Standard = "ERC-721"
+		res.TokenID = t.tokenID.String()
+	} else {
+		res.Value = t.value.String()
+	}
+	return res
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenTransfersCmd)
+	tokenTransfersCmd.Flags().StringVar(&tokenTransfersAddress, "address", "", "Address for which to obtain transfers")
+	tokenTransfersCmd.Flags().StringSliceVar(&tokenTransfersTokens, "token", nil, "Tokens for which to obtain transfers (defaults to all tokens)")
+	tokenTransfersCmd.Flags().StringVar(&tokenTransfersFromBlock, "from-block", "", "Block from which to obtain transfers (defaults to the to block)")
+	tokenTransfersCmd.Flags().StringVar(&tokenTransfersToBlock, "to-block", "latest", "Block to which to obtain transfers")
+	tokenTransfersCmd.Flags().Uint64Var(&tokenTransfersChunkSize, "chunk-size", scanner.DefaultChunkSize, "Number of blocks to request logs for at a time")
+	tokenTransfersCmd.Flags().IntVar(&tokenTransfersWorkers, "workers", scanner.DefaultWorkers, "Number of requests to make in parallel")
+}
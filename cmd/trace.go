@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+)
+
+// printCallFrame prints a call frame and its sub-calls as a tree.  Function names are decoded
+// using the ABIs supplied for specific addresses, falling back to well-known signatures.
+func printCallFrame(frame *conn.CallFrame, depth int, abis map[common.Address]*abi.ABI) {
+	indent := strings.Repeat("  ", depth)
+
+	to := "(creation)"
+	var contractAbi *abi.ABI
+	if frame.To != nil {
+		to = frame.To.Hex()
+		contractAbi = abis[*frame.To]
+	}
+
+	line := fmt.Sprintf("%s%s %s -> %s", indent, frame.Type, frame.From.Hex(), to)
+	if frame.Value != nil && (*big.Int)(frame.Value).Sign() > 0 {
+		line = fmt.Sprintf("%s value %s", line, formatWei((*big.Int)(frame.Value)))
+	}
+	line = fmt.Sprintf("%s gas %d/%d", line, uint64(frame.GasUsed), uint64(frame.Gas))
+	fmt.Println(line)
+
+	if frame.To != nil && len(frame.Input) > 0 {
+		fmt.Printf("%s  %s\n", indent, traceCallData(contractAbi, frame.Input))
+	}
+	if frame.Error != "" {
+		reason := frame.Error
+		if len(frame.Output) > 0 {
+			decoded, err := util.DecodeRevert(contractAbi, frame.Output)
+			switch {
+			case errors.Is(err, util.ErrUnknownRevert):
+				reason = fmt.Sprintf("%s: %s", frame.Error, err.Error())
+			case err == nil:
+				reason = fmt.Sprintf("%s: %s", frame.Error, decoded)
+			}
+		}
+		fmt.Printf("%s  error: %s\n", indent, reason)
+	} else if verbose && len(frame.Output) > 0 {
+		fmt.Printf("%s  output: %#x\n", indent, []byte(frame.Output))
+	}
+
+	for _, call := range frame.Calls {
+		printCallFrame(call, depth+1, abis)
+	}
+}
+
+// traceCallData provides a readable version of the call data for a trace.
+func traceCallData(contractAbi *abi.ABI, data []byte) string {
+	if contractAbi != nil && len(data) >= 4 {
+		if method, err := contractAbi.MethodById(data); err == nil {
+			values, err := method.Inputs.Unpack(data[4:])
+			if err == nil {
+				args := make([]string, len(values))
+				for i := range values {
+					args[i], err = contractValueToString(method.Inputs[i].Type, values[i])
+					if err != nil {
+						args[i] = fmt.Sprintf("%v", values[i])
+					}
+				}
+				return fmt.Sprintf("%s(%s)", method.RawName, strings.Join(args, ","))
+			}
+		}
+	}
+	return txdata.DataToString(c.Client(), data)
+}
@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var transactionAccessListFromAddress string
+var transactionAccessListToAddress string
+var transactionAccessListData string
+var transactionAccessListAmount string
+
+// transactionAccessListCmd represents the transaction accesslist command
+var transactionAccessListCmd = &cobra.Command{
+	Use:   "accesslist",
+	Short: "Create an access list for a transaction",
+	Long: `Create an EIP-2930 access list for a transaction.  For example:
+
+    ethereal transaction accesslist --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0xd26114cd6EE289AccF82350c8d8487fedB8A0C07 --data=0xa9059cbb...
+
+The access list is output as JSON, and can be supplied to commands that send transactions with --access-list.
+
+In quiet mode this will return 0 if the access list is created, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		transactionAccessListFromAddress = accountOrDefault(transactionAccessListFromAddress)
+		cli.Assert(transactionAccessListFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(transactionAccessListFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", transactionAccessListFromAddress))
+
+		var toAddress *common.Address
+		if transactionAccessListToAddress != "" {
+			tmp, err := c.Resolve(transactionAccessListToAddress)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", transactionAccessListToAddress))
+			toAddress = &tmp
+		}
+
+		data, err := hex.DecodeString(strings.TrimPrefix(transactionAccessListData, "0x"))
+		cli.ErrCheck(err, quiet, "Failed to parse data")
+
+		amount := big.NewInt(0)
+		if transactionAccessListAmount != "" {
+			amount, err = string2eth.StringToWei(transactionAccessListAmount)
+			cli.ErrCheck(err, quiet, "Invalid amount")
+		}
+
+		accessList, gasUsed, err := c.CreateAccessList(context.Background(), &conn.TransactionData{
+			From:  fromAddress,
+			To:    toAddress,
+			Value: amount,
+			Data:  data,
+		})
+		cli.ErrCheck(err, quiet, "Failed to create access list")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&transactionAccessListJSON{
+				AccessList: accessList,
+				GasUsed:    gasUsed,
+			})
+		}
+
+		output, err := json.Marshal(accessList)
+		cli.ErrCheck(err, quiet, "Failed to generate JSON")
+		fmt.Printf("%s\n", string(output))
+		outputIf(verbose, fmt.Sprintf("Gas used with access list: %d", gasUsed))
+	},
+}
+
+// accessListFlag adds a flag to supply an access list for a transaction.
+func accessListFlag(cmd *cobra.Command, value *string) {
+	cmd.Flags().StringVar(value, "access-list", "", "Access list for the transaction: JSON, path to JSON, or 'auto' to generate one")
+}
+
+// parseAccessList parses an access list supplied as JSON, a path to JSON, or "auto" to generate
+// one for the transaction.
+func parseAccessList(input string, txData *conn.TransactionData) (types.AccessList, error) {
+	if input == "" {
+		return nil, nil
+	}
+	if input == "auto" {
+		accessList, gasUsed, err := c.CreateAccessList(context.Background(), txData)
+		if err != nil {
+			return nil, err
+		}
+		outputIf(verbose, fmt.Sprintf("Generated access list with %d entries; gas used with access list is %d", len(accessList), gasUsed))
+		return accessList, nil
+	}
+
+	data := []byte(input)
+	if !strings.HasPrefix(strings.TrimSpace(input), "[") {
+		var err error
+		data, err = ioutil.ReadFile(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var accessList types.AccessList
+	if err := json.Unmarshal(data, &accessList); err != nil {
+		return nil, err
+	}
+	return accessList, nil
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionAccessListCmd)
+	transactionAccessListCmd.Flags().StringVar(&transactionAccessListFromAddress, "from", "", "Address from which the transaction will be sent")
+	transactionAccessListCmd.Flags().StringVar(&transactionAccessListToAddress, "to", "", "Address to which the transaction will be sent")
+	transactionAccessListCmd.Flags().StringVar(&transactionAccessListData, "data", "", "Data for the transaction (as a hex string)")
+	transactionAccessListCmd.Flags().StringVar(&transactionAccessListAmount, "amount", "", "Amount of Ether for the transaction")
+}
+
+// transactionAccessListJSON is the JSON output for the transaction accesslist command.
+type transactionAccessListJSON struct {
+	AccessList types.AccessList `json:"access_list"`
+	GasUsed    uint64           `json:"gas_used"`
+}
@@ -0,0 +1,353 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var transactionBatchFromAddress string
+var transactionBatchFile string
+var transactionBatchResults string
+var transactionBatchConcurrency int
+var transactionBatchFeeStrategy string
+
+const (
+	batchSubmitted = "submitted"
+	batchMined     = "mined"
+	batchReverted  = "reverted"
+	batchNotMined  = "not mined"
+	batchFailed    = "failed"
+	batchSkipped   = "skipped"
+	batchSigned    = "signed"
+	batchSimulated = "simulated"
+)
+
+// transactionBatchCmd represents the transaction batch command
+var transactionBatchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Send a batch of transactions from a file",
+	Long: `Send a batch of transactions, for example for an airdrop or payouts, from a file.  For example:
+
+    ethereal transaction batch --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --file=payouts.csv --results=results.csv --passphrase=secret
+
+The file can be CSV with to, value and data columns, or a JSON array of objects with to, value and data fields.  The value and data are optional.  For example:
+
+    to,value,data
+    0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845,0.1 ether,
+    alice.eth,0.25 ether,
+
+All recipients and values are checked before any transaction is sent.  Transactions are signed in order with sequential nonces, and submitted by the number of workers given by --concurrency.  With --fee-strategy=fixed (the default) fees are calculated once and used for all transactions; with --fee-strategy=dynamic they are calculated as each transaction is signed.  If a transaction cannot be submitted then no further transactions are signed, as their nonces could not be used until the failed transaction is replaced.  With --dry-run every transaction is simulated rather than sent, and has a status of simulated, or failed if its simulation fails.
+
+The result of each transaction, including its hash and status, is written to the file given by --results, as CSV if its name ends with .csv and otherwise as JSON, or output if --results is not supplied.
+
+This will return an exit status of 0 if all transactions are successfully submitted (and mined if --wait is supplied), 1 if any transaction is not successfully submitted or fails, and 2 if all transactions are successfully submitted but some are not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(transactionBatchFile != "", quiet, "--file is required")
+		cli.Assert(transactionBatchConcurrency > 0, quiet, "--concurrency must be at least 1")
+		transactionBatchFromAddress = accountOrDefault(transactionBatchFromAddress)
+		cli.Assert(transactionBatchFromAddress != "", quiet, "--from is required")
+		fromAddress, err := c.Resolve(transactionBatchFromAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", transactionBatchFromAddress))
+
+		data, err := ioutil.ReadFile(transactionBatchFile)
+		cli.ErrCheck(err, quiet, "Failed to read batch file")
+		rows, err := util.ParseBatch(data)
+		cli.ErrCheck(err, quiet, "Failed to parse batch file")
+		txDatas, total := batchTransactionData(fromAddress, rows)
+		outputIf(verbose, fmt.Sprintf("%d transactions sending a total of %s", len(txDatas), formatWei(total)))
+
+		if !offline {
+			ctx, cancel := localContext()
+			defer cancel()
+			balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
+			cli.Assert(balance.Cmp(total) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for batch total of %s", formatWei(balance), formatWei(total)))
+		}
+
+		switch strings.ToLower(transactionBatchFeeStrategy) {
+		case "fixed":
+			london, err := c.SupportsLondon(context.Background())
+			cli.ErrCheck(err, quiet, "Failed to establish transaction type")
+			if london {
+				maxFeePerGas, maxPriorityFeePerGas, err := calculateFees()
+				cli.ErrCheck(err, quiet, "Failed to calculate fees")
+				for _, txData := range txDatas {
+					txData.MaxFeePerGas = maxFeePerGas
+					txData.MaxPriorityFeePerGas = maxPriorityFeePerGas
+				}
+			}
+		case "dynamic":
+		default:
+			cli.Err(quiet, fmt.Sprintf("Unknown fee strategy %s", transactionBatchFeeStrategy))
+		}
+
+		results := sendBatch(txDatas)
+		writeBatchResults(results)
+
+		exitCode := exitSuccess
+		for _, result := range results {
+			switch result.Status {
+			case batchFailed, batchReverted, batchSkipped:
+				os.Exit(exitFailure)
+			case batchNotMined:
+				exitCode = exitNotMined
+			}
+		}
+		os.Exit(exitCode)
+	},
+}
+
+// batchTransactionData creates the data for the transactions in the batch, and returns it
+// along with the total value of the transactions.
+func batchTransactionData(fromAddress common.Address, rows []*util.BatchRow) ([]*conn.TransactionData, *big.Int) {
+	var gasLimit *uint64
+	if limit := uint64(viper.GetInt64("gaslimit")); limit > 0 {
+		gasLimit = &limit
+	}
+
+	total := big.NewInt(0)
+	txDatas := make([]*conn.TransactionData, len(rows))
+	for i, row := range rows {
+		to, err := c.Resolve(row.To)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve address %s for transaction %d", row.To, i+1))
+		value := big.NewInt(0)
+		if row.Value != "" {
+			value, err = string2eth.StringToWei(row.Value)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid value %s for transaction %d", row.Value, i+1))
+		}
+		total = total.Add(total, value)
+		txDatas[i] = &conn.TransactionData{
+			From:     fromAddress,
+			To:       &to,
+			Value:    value,
+			Data:     common.FromHex(row.Data),
+			GasLimit: gasLimit,
+		}
+	}
+	return txDatas, total
+}
+
+// batchResult is the result of a transaction in a batch.
+type batchResult struct {
+	Row    int    `json:"row"`
+	To     string `json:"to"`
+	Value  string `json:"value"`
+	Nonce  *int64 `json:"nonce,omitempty"`
+	Hash   string `json:"transaction_hash,omitempty"`
+	Status string `json:"status"`
+	Block  uint64 `json:"block_number,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batchItem is a signed transaction in a batch awaiting submission.
+type batchItem struct {
+	from   common.Address
+	tx     *types.Transaction
+	result *batchResult
+}
+
+// sendBatch signs the transactions in order and submits them with the configured number of
+// workers.  Signing stops if a transaction cannot be signed or submitted.
+func sendBatch(txDatas []*conn.TransactionData) []*batchResult {
+	results := make([]*batchResult, len(txDatas))
+	for i, txData := range txDatas {
+		results[i] = &batchResult{
+			Row:    i + 1,
+			To:     txData.To.Hex(),
+			Value:  txData.Value.String(),
+			Status: batchSkipped,
+		}
+	}
+
+	var failed bool
+	var mu sync.Mutex
+	items := make(chan *batchItem)
+	var wg sync.WaitGroup
+	if !offline {
+		for i := 0; i < transactionBatchConcurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for item := range items {
+					submitBatchItem(item, &mu)
+					if item.result.Status == batchFailed && !dryRun {
+						mu.Lock()
+						failed = true
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+	}
+
+	for i, txData := range txDatas {
+		mu.Lock()
+		stop := failed
+		mu.Unlock()
+		if stop {
+			break
+		}
+		signedTx, err := c.CreateSignedTransaction(context.Background(), txData)
+		if err != nil {
+			results[i].Status = batchFailed
+			results[i].Error = errors.Wrap(err, "failed to create transaction").Error()
+			break
+		}
+		results[i].Nonce = txData.Nonce
+		if !dryRun {
+			// Transactions simulated with --dry-run are not signed, so do not have a final hash.
+			results[i].Hash = signedTx.Hash().Hex()
+		}
+		if offline {
+			results[i].Status = batchSigned
+			outputSignedTransaction(signedTx)
+			continue
+		}
+		items <- &batchItem{from: txData.From, tx: signedTx, result: results[i]}
+	}
+	close(items)
+	wg.Wait()
+
+	return results
+}
+
+// submitBatchItem submits a transaction in a batch, waiting for it to be mined if requested.
+// With --dry-run the transaction is simulated rather than submitted.
+func submitBatchItem(item *batchItem, mu *sync.Mutex) {
+	if dryRun {
+		mu.Lock()
+		succeeded := dryRunTransaction(item.from, item.tx)
+		mu.Unlock()
+		item.result.Status = batchSimulated
+		if !succeeded {
+			item.result.Status = batchFailed
+			item.result.Error = "simulation failed"
+		}
+		return
+	}
+	if err := sendTransaction(context.Background(), item.from, item.tx); err != nil {
+		item.result.Status = batchFailed
+		item.result.Error = err.Error()
+		outputIf(verbose, fmt.Sprintf("Transaction %d failed: %v", item.result.Row, err))
+		return
+	}
+	item.result.Status = batchSubmitted
+	mu.Lock()
+	logTransaction(item.tx, log.Fields{
+		"group":   "transaction",
+		"command": "batch",
+		"row":     item.result.Row,
+	})
+	mu.Unlock()
+	outputIf(verbose, fmt.Sprintf("Transaction %d submitted as %s", item.result.Row, item.tx.Hash().Hex()))
+
+	if !viper.GetBool("wait") {
+		return
+	}
+	mined, err := c.WaitForTransaction(context.Background(), item.tx.Hash(), viper.GetUint64("confirmations"), viper.GetDuration("limit"))
+	if err != nil {
+		item.result.Status = batchNotMined
+		if !errors.Is(err, conn.ErrNotMined) {
+			item.result.Error = err.Error()
+		}
+		return
+	}
+	item.result.Block = mined.Receipt.BlockNumber.Uint64()
+	if mined.Receipt.Status == types.ReceiptStatusSuccessful {
+		item.result.Status = batchMined
+	} else {
+		item.result.Status = batchReverted
+	}
+}
+
+// writeBatchResults writes the results of a batch to the file given by --results, or outputs
+// them if no file is given.
+func writeBatchResults(results []*batchResult) {
+	if transactionBatchResults == "" {
+		if offline || quiet {
+			return
+		}
+		if jsonOutput() {
+			writeJSON(results)
+			return
+		}
+		for _, result := range results {
+			line := fmt.Sprintf("%d\t%s\t%s", result.Row, result.Hash, result.Status)
+			if result.Error != "" {
+				line = fmt.Sprintf("%s (%s)", line, result.Error)
+			}
+			fmt.Println(line)
+		}
+		return
+	}
+
+	var data []byte
+	if strings.HasSuffix(strings.ToLower(transactionBatchResults), ".csv") {
+		builder := new(strings.Builder)
+		writer := csv.NewWriter(builder)
+		cli.ErrCheck(writer.Write([]string{"row", "to", "value", "nonce", "transaction_hash", "status", "block_number", "error"}), quiet, "Failed to write results")
+		for _, result := range results {
+			nonce := ""
+			if result.Nonce != nil {
+				nonce = strconv.FormatInt(*result.Nonce, 10)
+			}
+			block := ""
+			if result.Block != 0 {
+				block = strconv.FormatUint(result.Block, 10)
+			}
+			cli.ErrCheck(writer.Write([]string{strconv.Itoa(result.Row), result.To, result.Value, nonce, result.Hash, result.Status, block, result.Error}), quiet, "Failed to write results")
+		}
+		writer.Flush()
+		cli.ErrCheck(writer.Error(), quiet, "Failed to write results")
+		data = []byte(builder.String())
+	} else {
+		var err error
+		data, err = json.MarshalIndent(results, "", "  ")
+		cli.ErrCheck(err, quiet, "Failed to encode results")
+		data = append(data, '\n')
+	}
+	cli.ErrCheck(ioutil.WriteFile(transactionBatchResults, data, 0600), quiet, "Failed to write results file")
+	outputIf(verbose, fmt.Sprintf("Results written to %s", transactionBatchResults))
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionBatchCmd)
+	transactionBatchCmd.Flags().StringVar(&transactionBatchFromAddress, "from", "", "Address from which to send the transactions")
+	transactionBatchCmd.Flags().StringVar(&transactionBatchFile, "file", "", "CSV or JSON file of transactions to send")
+	transactionBatchCmd.Flags().StringVar(&transactionBatchResults, "results", "", "file to which to write the results of the transactions, as CSV if it ends with .csv, otherwise as JSON")
+	transactionBatchCmd.Flags().IntVar(&transactionBatchConcurrency, "concurrency", 4, "number of transactions to submit at the same time")
+	transactionBatchCmd.Flags().StringVar(&transactionBatchFeeStrategy, "fee-strategy", "fixed", "fee strategy: fixed (calculate fees once for all transactions) or dynamic (calculate fees for each transaction)")
+	addTransactionFlags(transactionBatchCmd, "the address from which to send the transactions")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var transactionBroadcastRaw string
+
+// transactionBroadcastCmd represents the transaction broadcast command
+var transactionBroadcastCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Broadcast signed transactions",
+	Long: `Broadcast one or more signed transactions, for example those created with --offline.  For example:
+
+    ethereal transaction broadcast --raw=0x02f86b...
+
+--raw can be either a single transaction in hex, or a path to a file containing one transaction in hex per line.
+
+This will return an exit status of 0 if the transactions are successfully submitted (and mined if --wait is supplied), 1 if the transactions are not successfully submitted, and 2 if the transactions are successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot broadcast transactions when offline")
+		cli.Assert(transactionBroadcastRaw != "", quiet, "--raw is required")
+
+		signedTxs, err := readRawTransactions(transactionBroadcastRaw)
+		cli.ErrCheck(err, quiet, "Failed to decode transactions")
+		cli.Assert(len(signedTxs) > 0, quiet, "No transactions to broadcast")
+
+		mined := true
+		for _, signedTx := range signedTxs {
+			fromAddress, err := types.Sender(signer, signedTx)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain sender of transaction %s", signedTx.Hash().Hex()))
+			err = sendTransaction(context.Background(), fromAddress, signedTx)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to send transaction %s", signedTx.Hash().Hex()))
+			mined = handleSubmittedTransaction(signedTx, log.Fields{
+				"group":   "transaction",
+				"command": "broadcast",
+			}, false) && mined
+		}
+		if !mined {
+			os.Exit(exitNotMined)
+		}
+		os.Exit(successExitStatus())
+	},
+}
+
+// readRawTransactions reads signed transactions supplied either directly as hex or as the path
+// to a file containing one transaction in hex per line.
+func readRawTransactions(input string) ([]*types.Transaction, error) {
+	if !strings.HasPrefix(input, "0x") {
+		// Input is a file.
+		data, err := ioutil.ReadFile(input)
+		if err != nil {
+			return nil, err
+		}
+		input = string(data)
+	}
+	return util.DecodeRawTransactions(input)
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionBroadcastCmd)
+	transactionBroadcastCmd.Flags().StringVar(&transactionBroadcastRaw, "raw", "", "signed transaction (as a hex string), or path to a file of signed transactions")
+	transactionBroadcastCmd.Flags().Bool("wait", false, "wait for the transactions to be mined before returning")
+	transactionBroadcastCmd.Flags().Duration("limit", 0, "maximum time to wait for each transaction to be mined before failing (default forever)")
+	transactionBroadcastCmd.Flags().Uint64("confirmations", 1, "number of confirmations to wait for when waiting for the transactions to be mined")
+}
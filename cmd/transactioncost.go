@@ -0,0 +1,345 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util/output"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var transactionCostAmount string
+var transactionCostFromAddress string
+var transactionCostToAddress string
+var transactionCostData string
+
+// transactionCostCmd represents the transaction cost command
+var transactionCostCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Obtain the cost of a transaction",
+	Long: `Obtain the cost of a mined transaction, or estimate the cost of a new transaction.  For example:
+
+    ethereal transaction cost --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1
+
+    ethereal transaction cost --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845 --amount=1ether
+
+The execution fee is broken down into the base fee, which is burnt, and the priority fee, which is paid to the block producer.  On layer 2 rollups the cost includes the fee for posting the transaction data to layer 1.  On OP-stack chains such as Optimism and Base this is charged in addition to layer 2 gas; on Arbitrum it is charged as part of layer 2 gas.
+
+If a fiat currency is supplied with --fiat, or a price feed with --price-feed, the cost is also given in the fiat currency.
+
+In quiet mode this will return 0 if the cost is obtained, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot obtain transaction cost when offline")
+
+		var cost *transactionCostJSON
+		if transactionStr != "" {
+			cost = minedTransactionCost(common.HexToHash(transactionStr))
+		} else {
+			cost = estimatedTransactionCost()
+		}
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		price := etherPrice()
+		cost.setFiat(price)
+
+		if jsonOutput() {
+			outputJSON(cost)
+		}
+
+		// fee formats a fee, with its value in the currency of the price feed if available.
+		fee := func(value *big.Int, perGas *big.Int) string {
+			res := formatWei(value)
+			if perGas != nil {
+				res = fmt.Sprintf("%s at %s per gas", res, formatWei(perGas))
+			}
+			if price != nil {
+				res = fmt.Sprintf("%s (%s)", res, price.format(value))
+			}
+			return res
+		}
+
+		builder := new(strings.Builder)
+		if cost.Estimate {
+			builder.WriteString(fmt.Sprintf("Gas limit:\t%d\n", cost.Gas))
+		} else {
+			builder.WriteString(fmt.Sprintf("Gas used:\t%d\n", cost.Gas))
+		}
+		builder.WriteString(fmt.Sprintf("Gas price:\t%s\n", formatWei(cost.gasPrice)))
+		if cost.baseFeePerGas != nil {
+			builder.WriteString(fmt.Sprintf("Base fee:\t%s\n", fee(cost.baseFee, cost.baseFeePerGas)))
+			builder.WriteString(fmt.Sprintf("Priority fee:\t%s\n", fee(cost.priorityFee, cost.priorityFeePerGas)))
+		}
+		builder.WriteString(fmt.Sprintf("Execution fee:\t%s\n", fee(cost.executionFee, nil)))
+		if cost.blobFee != nil {
+			builder.WriteString(fmt.Sprintf("Blob fee:\t%s\n", fee(cost.blobFee, nil)))
+		}
+		if cost.l1Fee != nil {
+			if cost.L1Gas > 0 {
+				builder.WriteString(fmt.Sprintf("L1 data fee:\t%s (%d gas, included in execution fee)\n", fee(cost.l1Fee, nil), cost.L1Gas))
+			} else {
+				builder.WriteString(fmt.Sprintf("L1 data fee:\t%s\n", fee(cost.l1Fee, nil)))
+			}
+		}
+		builder.WriteString(fmt.Sprintf("Total:\t\t%s (%s, %s)\n", output.FormatWei(cost.total, output.Wei), output.FormatWei(cost.total, output.GWei), output.FormatWei(cost.total, output.Ether)))
+		if price != nil {
+			builder.WriteString(fmt.Sprintf("Total value:\t%s at %s per Ether\n", price.format(cost.total), price.format(big.NewInt(params.Ether))))
+		}
+		if cost.maxTotal != nil && verbose {
+			builder.WriteString(fmt.Sprintf("Maximum total:\t%s\n", fee(cost.maxTotal, nil)))
+		}
+		fmt.Print(builder.String())
+		os.Exit(exitSuccess)
+	},
+}
+
+// transactionCostJSON is the JSON output for the cost of a transaction.
+type transactionCostJSON struct {
+	Estimate          bool              `json:"estimate"`
+	Rollup            string            `json:"rollup,omitempty"`
+	Gas               uint64            `json:"gas"`
+	GasPrice          string            `json:"gas_price"`
+	BaseFeePerGas     string            `json:"base_fee_per_gas,omitempty"`
+	PriorityFeePerGas string            `json:"priority_fee_per_gas,omitempty"`
+	BaseFee           string            `json:"base_fee,omitempty"`
+	PriorityFee       string            `json:"priority_fee,omitempty"`
+	ExecutionFee      string            `json:"execution_fee"`
+	BlobFee           string            `json:"blob_fee,omitempty"`
+	L1Fee             string            `json:"l1_fee,omitempty"`
+	L1Gas             uint64            `json:"l1_gas,omitempty"`
+	Total             string            `json:"total"`
+	MaxTotal          string            `json:"max_total,omitempty"`
+	Currency          string            `json:"currency,omitempty"`
+	EtherPrice        string            `json:"ether_price,omitempty"`
+	Fiat              map[string]string `json:"fiat,omitempty"`
+
+	gasPrice          *big.Int
+	baseFeePerGas     *big.Int
+	priorityFeePerGas *big.Int
+	baseFee           *big.Int
+	priorityFee       *big.Int
+	executionFee      *big.Int
+	blobFee           *big.Int
+	l1Fee             *big.Int
+	total             *big.Int
+	maxTotal          *big.Int
+}
+
+// setStrings sets the string representations of the values for JSON output.
+func (j *transactionCostJSON) setStrings() {
+	str := func(value *big.Int) string {
+		if value == nil {
+			return ""
+		}
+		return value.String()
+	}
+	j.GasPrice = str(j.gasPrice)
+	j.BaseFeePerGas = str(j.baseFeePerGas)
+	j.PriorityFeePerGas = str(j.priorityFeePerGas)
+	j.BaseFee = str(j.baseFee)
+	j.PriorityFee = str(j.priorityFee)
+	j.ExecutionFee = str(j.executionFee)
+	j.BlobFee = str(j.blobFee)
+	j.L1Fee = str(j.l1Fee)
+	j.Total = str(j.total)
+	j.MaxTotal = str(j.maxTotal)
+}
+
+// setBaseFee breaks down the gas price of the transaction into the base fee and priority fee.
+func (j *transactionCostJSON) setBaseFee(baseFee *big.Int) {
+	j.baseFeePerGas = baseFee
+	if j.baseFeePerGas.Cmp(j.gasPrice) > 0 {
+		j.baseFeePerGas = j.gasPrice
+	}
+	j.priorityFeePerGas = new(big.Int).Sub(j.gasPrice, j.baseFeePerGas)
+	gas := new(big.Int).SetUint64(j.Gas)
+	j.baseFee = new(big.Int).Mul(j.baseFeePerGas, gas)
+	j.priorityFee = new(big.Int).Mul(j.priorityFeePerGas, gas)
+}
+
+// setFiat sets the values of the components of the cost in the currency of the price, if any.
+func (j *transactionCostJSON) setFiat(price *fiatPrice) {
+	if price == nil {
+		return
+	}
+	j.Currency = price.currency
+	j.EtherPrice = price.value(big.NewInt(params.Ether))
+	j.Fiat = make(map[string]string)
+	components := map[string]*big.Int{
+		"base_fee":      j.baseFee,
+		"priority_fee":  j.priorityFee,
+		"execution_fee": j.executionFee,
+		"blob_fee":      j.blobFee,
+		"l1_fee":        j.l1Fee,
+		"total":         j.total,
+		"max_total":     j.maxTotal,
+	}
+	for name, value := range components {
+		if value != nil {
+			j.Fiat[name] = price.value(value)
+		}
+	}
+}
+
+// minedTransactionCost returns the cost of a mined transaction.
+func minedTransactionCost(hash common.Hash) *transactionCostJSON {
+	ctx, cancel := localContext()
+	defer cancel()
+	txCost, err := c.TransactionCost(ctx, hash)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain cost of transaction %s", hash.Hex()))
+	rollup, err := c.Rollup(ctx)
+	cli.ErrCheck(err, quiet, "Failed to obtain rollup type of chain")
+
+	cost := &transactionCostJSON{
+		Rollup:       rollup,
+		Gas:          txCost.GasUsed,
+		L1Gas:        txCost.L1Gas,
+		gasPrice:     txCost.GasPrice,
+		executionFee: txCost.ExecutionFee,
+		blobFee:      txCost.BlobFee,
+		l1Fee:        txCost.L1Fee,
+		total:        txCost.Total,
+	}
+	header, err := c.Client().HeaderByNumber(ctx, new(big.Int).SetUint64(txCost.BlockNumber))
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain block %d", txCost.BlockNumber))
+	if header.BaseFee != nil {
+		cost.setBaseFee(header.BaseFee)
+	}
+	cost.setStrings()
+	return cost
+}
+
+// estimatedTransactionCost returns the estimated cost of a new transaction.
+func estimatedTransactionCost() *transactionCostJSON {
+	transactionCostFromAddress = accountOrDefault(transactionCostFromAddress)
+	cli.Assert(transactionCostFromAddress != "", quiet, "--transaction or --from is required")
+	fromAddress, err := c.Resolve(transactionCostFromAddress)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", transactionCostFromAddress))
+
+	var toAddress *common.Address
+	if transactionCostToAddress == "" {
+		cli.Assert(transactionCostData != "", quiet, "Transactions without a to address are contract creations and must have data")
+	} else {
+		tmp, err := c.Resolve(transactionCostToAddress)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve to address %s", transactionCostToAddress))
+		toAddress = &tmp
+	}
+
+	amount := big.NewInt(0)
+	if transactionCostAmount != "" {
+		amount, err = string2eth.StringToWei(transactionCostAmount)
+		cli.ErrCheck(err, quiet, "Invalid amount")
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(transactionCostData, "0x"))
+	cli.ErrCheck(err, quiet, "Failed to parse data")
+
+	var gasLimit *uint64
+	limit := uint64(viper.GetInt64("gaslimit"))
+	if limit > 0 {
+		gasLimit = &limit
+	}
+
+	ctx, cancel := localContext()
+	defer cancel()
+	tx, err := c.CreateTransaction(ctx, &conn.TransactionData{
+		From:     fromAddress,
+		To:       toAddress,
+		Value:    amount,
+		GasLimit: gasLimit,
+		Data:     data,
+	})
+	cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+	cost := &transactionCostJSON{
+		Estimate: true,
+		Gas:      tx.Gas(),
+		gasPrice: tx.GasPrice(),
+	}
+	if tx.Type() != types.LegacyTxType {
+		// The expected price is the base fee plus tip, capped at the maximum fee.
+		baseFee, err := c.CurrentBaseFee(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain base fee")
+		cost.gasPrice = new(big.Int).Add(baseFee, tx.GasTipCap())
+		if cost.gasPrice.Cmp(tx.GasFeeCap()) > 0 {
+			cost.gasPrice = tx.GasFeeCap()
+		}
+		cost.setBaseFee(baseFee)
+	}
+	cost.executionFee = new(big.Int).Mul(cost.gasPrice, new(big.Int).SetUint64(cost.Gas))
+	cost.total = new(big.Int).Set(cost.executionFee)
+	cost.maxTotal = new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(cost.Gas))
+
+	l1Fee, err := c.L1Fee(ctx, tx)
+	cli.ErrCheck(err, quiet, "Failed to obtain L1 data fee")
+	addL1Fee(cost, l1Fee)
+
+	cost.setStrings()
+	return cost
+}
+
+// addL1Fee adds the layer 1 data fee, if any, to the cost of a transaction.
+func addL1Fee(cost *transactionCostJSON, l1Fee *conn.L1Fee) {
+	if l1Fee == nil {
+		return
+	}
+	cost.Rollup = l1Fee.Rollup
+	cost.l1Fee = l1Fee.Fee
+	cost.L1Gas = l1Fee.Gas
+	if l1Fee.Gas == 0 {
+		// The fee is charged in addition to layer 2 gas.
+		cost.total.Add(cost.total, l1Fee.Fee)
+		if cost.maxTotal != nil {
+			cost.maxTotal.Add(cost.maxTotal, l1Fee.Fee)
+		}
+	}
+}
+
+// estimatedL1Fee returns the layer 1 data fee for a transaction, or nil if the chain is
+// not a known rollup.
+func estimatedL1Fee(ctx context.Context, txData *conn.TransactionData) (*conn.L1Fee, error) {
+	rollup, err := c.Rollup(ctx)
+	if err != nil || rollup == conn.RollupNone {
+		return nil, err
+	}
+	tx, err := c.CreateTransaction(ctx, txData)
+	if err != nil {
+		return nil, err
+	}
+	return c.L1Fee(ctx, tx)
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionCostCmd)
+	transactionFlags(transactionCostCmd)
+	transactionCostCmd.Flags().StringVar(&transactionCostAmount, "amount", "", "Amount of Ether to transfer")
+	transactionCostCmd.Flags().StringVar(&transactionCostFromAddress, "from", "", "Address from which to transfer Ether")
+	transactionCostCmd.Flags().StringVar(&transactionCostToAddress, "to", "", "Address to which to transfer Ether")
+	transactionCostCmd.Flags().StringVar(&transactionCostData, "data", "", "data to send with transaction (as a hex string)")
+}
@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -23,19 +22,22 @@ import (
 	"os"
 	"strings"
 
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/fourbyte"
 	"github.com/wealdtech/ethereal/v2/util/txdata"
-	ens "github.com/wealdtech/go-ens/v3"
-	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 var transactionInfoRaw bool
 var transactionInfoJSON bool
 var transactionInfoSignatures string
+var transactionInfoAbi string
 
 // transactionInfoCmd represents the transaction info command
 var transactionInfoCmd = &cobra.Command{
@@ -45,6 +47,8 @@ var transactionInfoCmd = &cobra.Command{
 
     ethereal transaction info --transaction=0x5FfC014343cd971B7eb70732021E26C35B744cc4
 
+Transaction data is decoded against the ERC-20 and ERC-721 token standards and known signatures; data for other contracts can be decoded by supplying the contract's ABI with --abi.
+
 In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(transactionStr != "", quiet, "--transaction is required")
@@ -61,9 +65,7 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 			// Assume input is a raw transaction
 			data, err := hex.DecodeString(strings.TrimPrefix(transactionStr, "0x"))
 			cli.ErrCheck(err, quiet, "Failed to decode data")
-			tx = &types.Transaction{}
-			stream := rlp.NewStream(bytes.NewReader(data), 0)
-			err = tx.DecodeRLP(stream)
+			tx, err = util.DecodeRawTransaction(data)
 			cli.ErrCheck(err, quiet, "Failed to decode raw transaction")
 			txHash = tx.Hash()
 			// Assume pending.
@@ -83,13 +85,13 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 		}
 
 		if transactionInfoRaw {
-			buf := new(bytes.Buffer)
-			cli.ErrCheck(tx.EncodeRLP(buf), quiet, "failed to encode transaction")
-			fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
+			data, err := tx.MarshalBinary()
+			cli.ErrCheck(err, quiet, "failed to encode transaction")
+			fmt.Printf("%#x\n", data)
 			os.Exit(exitSuccess)
 		}
 
-		if transactionInfoJSON {
+		if transactionInfoJSON || jsonOutput() {
 			json, err := tx.MarshalJSON()
 			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain JSON for transaction %s", txHash.Hex()))
 			fmt.Printf("%s\n", string(json))
@@ -122,6 +124,9 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 			if receipt != nil {
 				if receipt.Status == 0 {
 					fmt.Printf("Result:\t\t\tFailed\n")
+					if reason := transactionRevertReason(tx, receipt); reason != "" {
+						fmt.Printf("Revert reason:\t\t%s\n", reason)
+					}
 				} else {
 					fmt.Printf("Result:\t\t\tSucceeded\n")
 				}
@@ -135,16 +140,16 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 
 		fromAddress, err := types.Sender(signer, tx)
 		if err == nil {
-			fmt.Printf("From:\t\t\t%v\n", ens.Format(c.Client(), fromAddress))
+			fmt.Printf("From:\t\t\t%v\n", formatAddress(fromAddress))
 		}
 
 		// To
 		if tx.To() == nil {
 			if receipt != nil {
-				fmt.Printf("Contract address:\t%v\n", ens.Format(c.Client(), receipt.ContractAddress))
+				fmt.Printf("Contract address:\t%v\n", formatAddress(receipt.ContractAddress))
 			}
 		} else {
-			fmt.Printf("To:\t\t\t%v\n", ens.Format(c.Client(), *tx.To()))
+			fmt.Printf("To:\t\t\t%v\n", formatAddress(*tx.To()))
 		}
 
 		if verbose {
@@ -166,9 +171,9 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 		}
 		switch tx.Type() {
 		case types.LegacyTxType, types.AccessListTxType:
-			fmt.Printf("Gas price:\t\t%v\n", string2eth.WeiToString(tx.GasPrice(), true))
+			fmt.Printf("Gas price:\t\t%v\n", formatWei(tx.GasPrice()))
 		case types.DynamicFeeTxType:
-			fmt.Printf("Max fee per gas:\t%v\n", string2eth.WeiToString(tx.GasFeeCap(), true))
+			fmt.Printf("Max fee per gas:\t%v\n", formatWei(tx.GasFeeCap()))
 		}
 
 		var block *types.Block
@@ -182,43 +187,65 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 
 		if tx.Type() == types.DynamicFeeTxType {
 			if receipt != nil && block != nil {
-				fmt.Printf("Actual fee per gas:\t%v\n", string2eth.WeiToString(block.BaseFee(), true))
+				fmt.Printf("Actual fee per gas:\t%v\n", formatWei(block.BaseFee()))
 			}
-			fmt.Printf("Tip per gas:\t\t%v\n", string2eth.WeiToString(tx.GasTipCap(), true))
+			fmt.Printf("Tip per gas:\t\t%v\n", formatWei(tx.GasTipCap()))
 		}
 
 		if receipt != nil {
 			gasUsed := big.NewInt(int64(receipt.GasUsed))
 			switch tx.Type() {
 			case types.LegacyTxType, types.AccessListTxType:
-				fmt.Printf("Total fee:\t\t%v", string2eth.WeiToString(new(big.Int).Mul(tx.GasPrice(), gasUsed), true))
+				fmt.Printf("Total fee:\t\t%v", formatWei(new(big.Int).Mul(tx.GasPrice(), gasUsed)))
 				if verbose {
-					fmt.Printf(" (%v * %v)\n", string2eth.WeiToString(tx.GasPrice(), true), gasUsed)
+					fmt.Printf(" (%v * %v)\n", formatWei(tx.GasPrice()), gasUsed)
 				} else {
 					fmt.Println()
 				}
 			case types.DynamicFeeTxType:
 				if block != nil {
-					fmt.Printf("Total fee:\t\t%v", string2eth.WeiToString(new(big.Int).Mul(new(big.Int).Add(block.BaseFee(), tx.GasTipCap()), gasUsed), true))
+					fmt.Printf("Total fee:\t\t%v", formatWei(new(big.Int).Mul(new(big.Int).Add(block.BaseFee(), tx.GasTipCap()), gasUsed)))
 					if verbose {
-						fmt.Printf(" ((%v + %v) * %v)\n", string2eth.WeiToString(block.BaseFee(), true), string2eth.WeiToString(tx.GasTipCap(), true), gasUsed)
+						fmt.Printf(" ((%v + %v) * %v)\n", formatWei(block.BaseFee()), formatWei(tx.GasTipCap()), gasUsed)
 					} else {
 						fmt.Println()
 					}
 				}
 			}
 		}
-		fmt.Printf("Value:\t\t\t%v\n", string2eth.WeiToString(tx.Value(), true))
+		fmt.Printf("Value:\t\t\t%v\n", formatWei(tx.Value()))
 
 		if tx.To() != nil && len(tx.Data()) > 0 {
-			fmt.Printf("Data:\t\t\t%v\n", txdata.DataToString(c.Client(), tx.Data()))
+			decoded := transactionInfoDecode(tx.Data())
+			if decoded == nil {
+				fmt.Printf("Data:\t\t\t%v\n", txdata.DataToString(c.Client(), tx.Data()))
+			} else {
+				res, err := fourByteDecodedToString(decoded)
+				if err != nil {
+					res = fmt.Sprintf("%#x", tx.Data())
+				}
+				fmt.Printf("Data:\t\t\t%v\n", res)
+				if verbose {
+					for i := range decoded.Values {
+						val, err := contractValueToString(decoded.Inputs[i].Type, decoded.Values[i])
+						if err != nil {
+							val = fmt.Sprintf("%v", decoded.Values[i])
+						}
+						name := decoded.Inputs[i].Name
+						if name == "" {
+							name = fmt.Sprintf("%d", i)
+						}
+						fmt.Printf("  %s (%s):\t%s\n", name, decoded.Inputs[i].Type.String(), val)
+					}
+				}
+			}
 		}
 
 		if verbose && receipt != nil && len(receipt.Logs) > 0 {
 			fmt.Printf("Logs:\n")
 			for i, log := range receipt.Logs {
 				fmt.Printf("\t%d:\n", i)
-				fmt.Printf("\t\tFrom:\t%v\n", ens.Format(c.Client(), log.Address))
+				fmt.Printf("\t\tFrom:\t%v\n", formatAddress(log.Address))
 				// Try to obtain decoded log
 				decoded := txdata.EventToString(c.Client(), log)
 				if decoded != "" {
@@ -242,10 +269,80 @@ In quiet mode this will return 0 if the transaction exists, otherwise 1.`,
 	},
 }
 
+// transactionInfoDecode decodes transaction data, trying in turn the ABI supplied with --abi, the
+// ERC-20 and ERC-721 token standards, custom and well-known signatures, and the 4byte directory.
+// It returns nil if the data cannot be decoded.
+func transactionInfoDecode(data []byte) *fourbyte.Decoded {
+	if len(data) < 4 {
+		return nil
+	}
+
+	abis := util.StandardABIs()
+	if transactionInfoAbi != "" {
+		contractAbi, err := contractParseAbi(transactionInfoAbi)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to parse ABI %s", transactionInfoAbi))
+		abis = append([]*abi.ABI{&contractAbi}, abis...)
+	}
+	for _, contractAbi := range abis {
+		if decoded, err := abiDecode(contractAbi, data); err == nil {
+			return decoded
+		}
+	}
+
+	if res := txdata.DataToString(c.Client(), data); !strings.HasPrefix(res, "0x") {
+		// A known signature, but without argument names or types; leave it to the caller.
+		return nil
+	}
+
+	decoded, err := fourByteDecode(data)
+	if err != nil {
+		outputIf(debug, fmt.Sprintf("Failed to decode data with 4byte directory: %v", err))
+		return nil
+	}
+	return decoded
+}
+
+// transactionRevertReason replays a failed transaction to obtain its revert reason.
+// The replay is against the state at the end of the previous block so may not be
+// accurate if the transaction relied on earlier transactions in the same block.
+func transactionRevertReason(tx *types.Transaction, receipt *types.Receipt) string {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return ""
+	}
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	ctx, cancel := localContext()
+	defer cancel()
+	_, err = c.Client().CallContract(ctx, msg, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err == nil {
+		return ""
+	}
+	data, exists := util.RevertData(err)
+	if !exists {
+		return ""
+	}
+	reason, err := util.DecodeRevert(nil, data)
+	switch {
+	case errors.Is(err, util.ErrUnknownRevert):
+		return err.Error()
+	case err != nil:
+		return ""
+	}
+	return reason
+}
+
 func init() {
 	transactionCmd.AddCommand(transactionInfoCmd)
 	transactionFlags(transactionInfoCmd)
 	transactionInfoCmd.Flags().BoolVar(&transactionInfoRaw, "raw", false, "Output the transaction as raw hex")
 	transactionInfoCmd.Flags().BoolVar(&transactionInfoJSON, "json", false, "Output the transaction as json")
+	transactionInfoCmd.Flags().StringVar(&transactionInfoAbi, "abi", "", "ABI, or path to ABI, with which to decode the transaction data")
 	transactionInfoCmd.Flags().StringVar(&transactionInfoSignatures, "signatures", "", "Semicolon-separated list of custom transaction signatures (e.g. myFunc(address,bytes32);myFunc2(bool)")
 }
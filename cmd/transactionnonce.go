@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// transactionNonceCmd represents the transaction nonce command
+var transactionNonceCmd = &cobra.Command{
+	Use:   "nonce <address>",
+	Short: "Obtain the nonce for the next transaction from an address",
+	Long: `Obtain the nonce for the next transaction from an address, taking in to account pending transactions.  For example:
+
+    ethereal transaction nonce 0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+In verbose mode this will also show the nonce according to the latest block, and the number of pending transactions.
+
+In quiet mode this will return 0 if the nonce can be obtained, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		address, err := c.Resolve(args[0])
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain address of %s", args[0]))
+
+		ctx, cancel := localContext()
+		defer cancel()
+		pendingNonce, err := c.Client().PendingNonceAt(ctx, address)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain pending nonce for %s", args[0]))
+		latestNonce, err := c.Client().NonceAt(ctx, address, nil)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain latest nonce for %s", args[0]))
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			pendingTransactions := uint64(0)
+			if pendingNonce > latestNonce {
+				pendingTransactions = pendingNonce - latestNonce
+			}
+			outputJSON(&transactionNonceJSON{
+				PendingNonce:        pendingNonce,
+				LatestNonce:         latestNonce,
+				PendingTransactions: pendingTransactions,
+			})
+		}
+
+		if !verbose {
+			fmt.Println(pendingNonce)
+			os.Exit(exitSuccess)
+		}
+		fmt.Printf("Pending nonce:\t\t%d\n", pendingNonce)
+		fmt.Printf("Latest nonce:\t\t%d\n", latestNonce)
+		if pendingNonce > latestNonce {
+			fmt.Printf("Pending transactions:\t%d\n", pendingNonce-latestNonce)
+		} else {
+			fmt.Printf("Pending transactions:\t0\n")
+		}
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionNonceCmd)
+}
+
+// transactionNonceJSON is the JSON output for the transaction nonce command.
+type transactionNonceJSON struct {
+	PendingNonce        uint64 `json:"pending_nonce"`
+	LatestNonce         uint64 `json:"latest_nonce"`
+	PendingTransactions uint64 `json:"pending_transactions"`
+}
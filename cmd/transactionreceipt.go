@@ -0,0 +1,235 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+)
+
+var transactionReceiptJSON bool
+
+// transactionReceiptCmd represents the transaction receipt command
+var transactionReceiptCmd = &cobra.Command{
+	Use:   "receipt",
+	Short: "Obtain the receipt for a transaction",
+	Long: `Obtain the receipt for a mined transaction, with decoded logs.  For example:
+
+    ethereal transaction receipt --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1
+
+Logs are decoded using the ABI supplied with --abi, or with ABIs fetched for each contract that emitted a log if --fetch-abi is supplied, falling back to well-known event signatures.
+
+In quiet mode this will return 0 if the transaction has been mined and succeeded, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+		txHash := common.HexToHash(transactionStr)
+
+		ctx, cancel := localContext()
+		defer cancel()
+		receipt, err := c.Client().TransactionReceipt(ctx, txHash)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain receipt for transaction %s", txHash.Hex()))
+		tx, _, err := c.Client().TransactionByHash(ctx, txHash)
+		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain transaction %s", txHash.Hex()))
+		header, err := c.Client().HeaderByHash(ctx, receipt.BlockHash)
+		cli.ErrCheck(err, quiet, "Failed to obtain block")
+
+		if quiet {
+			if receipt.Status == types.ReceiptStatusSuccessful {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		output := newTransactionReceiptOutput(tx, receipt, header.BaseFee, transactionReceiptAbis(receipt))
+
+		if transactionReceiptJSON || jsonOutput() {
+			outputJSON(output)
+		}
+
+		fmt.Printf("Transaction:\t\t%s\n", output.TxHash)
+		fmt.Printf("Block:\t\t\t%d\n", output.BlockNumber)
+		if output.Status {
+			fmt.Println("Status:\t\t\tSucceeded")
+		} else {
+			fmt.Println("Status:\t\t\tFailed")
+			if output.RevertReason != "" {
+				fmt.Printf("Revert reason:\t\t%s\n", output.RevertReason)
+			}
+		}
+		fmt.Printf("From:\t\t\t%s\n", output.From)
+		if output.ContractAddress != "" {
+			fmt.Printf("Contract address:\t%s\n", output.ContractAddress)
+		} else {
+			fmt.Printf("To:\t\t\t%s\n", output.To)
+		}
+		fmt.Printf("Gas used:\t\t%d\n", output.GasUsed)
+		fmt.Printf("Effective gas price:\t%s\n", formatWei(output.effectiveGasPrice))
+		if output.baseFee != nil {
+			fmt.Printf("  Base fee per gas:\t%s\n", formatWei(output.baseFee))
+			fmt.Printf("  Tip per gas:\t\t%s\n", formatWei(new(big.Int).Sub(output.effectiveGasPrice, output.baseFee)))
+		}
+		fmt.Printf("Total fee:\t\t%s\n", formatWei(output.totalFee))
+
+		if len(output.Logs) > 0 {
+			fmt.Println("Logs:")
+			for _, log := range output.Logs {
+				fmt.Printf("\t%d:\t%s\n", log.LogIndex, log.Address)
+				if log.Event != "" {
+					fmt.Printf("\t\t%s\n", log.eventString())
+				} else {
+					for i, topic := range log.Topics {
+						fmt.Printf("\t\tTopic %d:\t%s\n", i, topic)
+					}
+					if log.Data != "" && log.Data != "0x" {
+						fmt.Printf("\t\tData:\t\t%s\n", log.Data)
+					}
+				}
+			}
+		}
+	},
+}
+
+// transactionReceiptOutput is the output of the transaction receipt command.
+type transactionReceiptOutput struct {
+	TxHash            string          `json:"transaction_hash"`
+	BlockNumber       uint64          `json:"block_number"`
+	Status            bool            `json:"status"`
+	RevertReason      string          `json:"revert_reason,omitempty"`
+	From              string          `json:"from"`
+	To                string          `json:"to,omitempty"`
+	ContractAddress   string          `json:"contract_address,omitempty"`
+	GasUsed           uint64          `json:"gas_used"`
+	EffectiveGasPrice string          `json:"effective_gas_price"`
+	BaseFeePerGas     string          `json:"base_fee_per_gas,omitempty"`
+	TipPerGas         string          `json:"tip_per_gas,omitempty"`
+	TotalFee          string          `json:"total_fee"`
+	Logs              []*decodedEvent `json:"logs"`
+
+	effectiveGasPrice *big.Int
+	baseFee           *big.Int
+	totalFee          *big.Int
+}
+
+func newTransactionReceiptOutput(tx *types.Transaction,
+	receipt *types.Receipt,
+	baseFee *big.Int,
+	abis map[common.Address]*abi.ABI,
+) *transactionReceiptOutput {
+	output := &transactionReceiptOutput{
+		TxHash:      receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		Status:      receipt.Status == types.ReceiptStatusSuccessful,
+		GasUsed:     receipt.GasUsed,
+		Logs:        make([]*decodedEvent, 0, len(receipt.Logs)),
+	}
+	if from, err := types.Sender(signer, tx); err == nil {
+		output.From = from.Hex()
+	}
+	if tx.To() == nil {
+		output.ContractAddress = receipt.ContractAddress.Hex()
+	} else {
+		output.To = tx.To().Hex()
+	}
+	if !output.Status {
+		output.RevertReason = transactionRevertReason(tx, receipt)
+	}
+
+	output.effectiveGasPrice = conn.EffectiveGasPrice(tx, baseFee)
+	output.EffectiveGasPrice = output.effectiveGasPrice.String()
+	if baseFee != nil {
+		output.baseFee = baseFee
+		output.BaseFeePerGas = baseFee.String()
+		output.TipPerGas = new(big.Int).Sub(output.effectiveGasPrice, baseFee).String()
+	}
+	output.totalFee = new(big.Int).Mul(output.effectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	output.TotalFee = output.totalFee.String()
+
+	txdata.InitFunctionMap()
+	for _, log := range receipt.Logs {
+		var decoded *decodedEvent
+		if contractAbi, exists := abis[log.Address]; exists {
+			decoded = decodeEvent(contractAbi, log)
+		} else {
+			decoded = decodeEvent(&abi.ABI{}, log)
+			if len(log.Topics) > 0 {
+				// Use well-known event signatures if available.
+				if event := txdata.EventToString(c.Client(), log); event != "" {
+					decoded.Event = event
+					decoded.Topics = nil
+					decoded.Data = ""
+				}
+			}
+		}
+		output.Logs = append(output.Logs, decoded)
+	}
+
+	return output
+}
+
+// eventString provides a representation of the event and its arguments.
+func (e *decodedEvent) eventString() string {
+	if len(e.Args) == 0 {
+		// Either an event without arguments or a pre-formatted event.
+		return e.Event
+	}
+	res := e.String()
+	// Remove the block, transaction and log index prefix.
+	prefix := fmt.Sprintf("%d\t%s\t%d\t", e.BlockNumber, e.TxHash, e.LogIndex)
+	return res[len(prefix):]
+}
+
+// transactionReceiptAbis obtains the ABIs with which to decode the logs of the receipt.
+func transactionReceiptAbis(receipt *types.Receipt) map[common.Address]*abi.ABI {
+	abis := make(map[common.Address]*abi.ABI)
+	if contractAbi != "" {
+		parsed, err := contractParseAbi(contractAbi)
+		cli.ErrCheck(err, quiet, "Failed to parse ABI")
+		for _, log := range receipt.Logs {
+			abis[log.Address] = &parsed
+		}
+		return abis
+	}
+	if contractFetchABI {
+		for _, log := range receipt.Logs {
+			if _, exists := abis[log.Address]; exists {
+				continue
+			}
+			parsed, err := fetchAbi(log.Address)
+			if err != nil {
+				outputIf(verbose, fmt.Sprintf("Failed to fetch ABI for %s: %v", log.Address.Hex(), err))
+				continue
+			}
+			abis[log.Address] = &parsed
+		}
+	}
+	return abis
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionReceiptCmd)
+	transactionFlags(transactionReceiptCmd)
+	transactionReceiptCmd.Flags().StringVar(&contractAbi, "abi", "", "ABI, or path to ABI, with which to decode logs")
+	transactionReceiptCmd.Flags().BoolVar(&contractFetchABI, "fetch-abi", false, "Fetch the verified ABIs of the contracts that emitted logs")
+	transactionReceiptCmd.Flags().StringVar(&contractABISource, "abi-source", "", "Source from which to fetch ABIs (etherscan/sourcify/blockscout) (default sourcify)")
+	transactionReceiptCmd.Flags().BoolVar(&transactionReceiptJSON, "json", false, "Output the receipt as JSON")
+}
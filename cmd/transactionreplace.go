@@ -0,0 +1,149 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+var transactionReplaceBump int64
+
+// transactionReplaceCmd represents the transaction replace command
+var transactionReplaceCmd = &cobra.Command{
+	Use:     "replace",
+	Aliases: []string{"up"},
+	Short:   "Replace a pending transaction with one with higher fees",
+	Long: `Replace a pending transaction with an otherwise identical transaction with higher fees.  For example:
+
+    ethereal transaction replace --passphrase=secret --transaction=0x454d2274155cce506359de6358785ce5366f6c13e825263674c272eec8532c0c
+
+The fees of the existing transaction are increased by the percentage given with --bump, which defaults to 10% as that is the minimum most nodes will accept for a replacement.  If the current network fees are higher than this they are used instead.  For legacy transactions the gas price is increased; for EIP-1559 transactions both the max fee and the priority fee are increased.
+
+This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tx := pendingTransaction()
+
+		gasLimit := tx.Gas()
+		replaceTransaction(tx, &conn.TransactionData{
+			To:         tx.To(),
+			Value:      tx.Value(),
+			GasLimit:   &gasLimit,
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}, transactionReplaceBump, "replace")
+	},
+}
+
+// pendingTransaction obtains the pending transaction supplied with --transaction.
+func pendingTransaction() *types.Transaction {
+	cli.Assert(transactionStr != "", quiet, "--transaction is required")
+	txHash := common.HexToHash(transactionStr)
+	ctx, cancel := localContext()
+	defer cancel()
+	tx, pending, err := c.Client().TransactionByHash(ctx, txHash)
+	cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain transaction %s", txHash.Hex()))
+	cli.Assert(pending, quiet, fmt.Sprintf("Transaction %s has already been mined", txHash.Hex()))
+	return tx
+}
+
+// bumpFee increases a fee by the given percentage (+1 wei, to avoid rounding issues).
+func bumpFee(fee *big.Int, percent int64) *big.Int {
+	increase := new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(percent)), big.NewInt(100))
+	return new(big.Int).Add(new(big.Int).Add(fee, increase), big.NewInt(1))
+}
+
+// replaceTransaction creates, signs and submits a transaction replacing the pending transaction
+// tx with the given data, with fees bumped by the given percentage.  It does not return.
+func replaceTransaction(tx *types.Transaction, txData *conn.TransactionData, bump int64, command string) {
+	cli.Assert(bump >= 10, quiet, "--bump must be at least 10")
+
+	fromAddress, err := types.Sender(signer, tx)
+	cli.ErrCheck(err, quiet, "Failed to obtain sender")
+	nonce := int64(tx.Nonce())
+	txData.From = fromAddress
+	txData.Nonce = &nonce
+
+	// For legacy transactions the fee cap and tip cap are both the gas price.
+	feePerGas := bumpFee(tx.GasFeeCap(), bump)
+	priorityFeePerGas := bumpFee(tx.GasTipCap(), bump)
+
+	london, err := c.SupportsLondon(context.Background())
+	cli.ErrCheck(err, quiet, "Failed to establish if chain supports EIP-1559")
+	if london {
+		// Use current network fees if they are higher.
+		if networkFeePerGas, networkPriorityFeePerGas, err := c.CalculateFees(); err == nil {
+			if networkFeePerGas.Cmp(feePerGas) > 0 {
+				feePerGas = networkFeePerGas
+			}
+			if networkPriorityFeePerGas.Cmp(priorityFeePerGas) > 0 {
+				priorityFeePerGas = networkPriorityFeePerGas
+			}
+		}
+		if priorityFeePerGas.Cmp(feePerGas) > 0 {
+			feePerGas = priorityFeePerGas
+		}
+	} else if networkGasPrice, err := c.SuggestGasPrice(context.Background()); err == nil && networkGasPrice.Cmp(feePerGas) > 0 {
+		feePerGas = networkGasPrice
+	}
+
+	// Ensure that the fee per gas does not exceed the max allowed.
+	if viper.GetString("max-fee-per-gas") == "" {
+		viper.Set("max-fee-per-gas", "200gwei")
+	}
+	maxFeePerGas, err := string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
+	cli.ErrCheck(err, quiet, "failed to obtain max fee per gas")
+	cli.Assert(feePerGas.Cmp(maxFeePerGas) <= 0, quiet, fmt.Sprintf("increased fee per gas of %s too high; increase with --max-fee-per-gas if you are sure you want to do this", formatWei(feePerGas)))
+	outputIf(verbose, fmt.Sprintf("Fee per gas increased from %s to %s", formatWei(tx.GasFeeCap()), formatWei(feePerGas)))
+	if london {
+		outputIf(verbose, fmt.Sprintf("Priority fee per gas increased from %s to %s", formatWei(tx.GasTipCap()), formatWei(priorityFeePerGas)))
+	}
+
+	txData.MaxFeePerGas = feePerGas
+	txData.MaxPriorityFeePerGas = priorityFeePerGas
+	signedTx, err := c.CreateSignedTransaction(context.Background(), txData)
+	cli.ErrCheck(err, quiet, "Failed to create transaction")
+
+	if offline {
+		outputSignedTransaction(signedTx)
+		return
+	}
+
+	err = sendTransaction(context.Background(), fromAddress, signedTx)
+	cli.ErrCheck(err, quiet, "Failed to send transaction")
+	handleSubmittedTransaction(signedTx, log.Fields{
+		"group":                    "transaction",
+		"command":                  command,
+		"oldtransactionid":         tx.Hash().Hex(),
+		"old-fee-per-gas":          tx.GasFeeCap().String(),
+		"old-priority-fee-per-gas": tx.GasTipCap().String(),
+	}, true)
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionReplaceCmd)
+	transactionFlags(transactionReplaceCmd)
+	transactionReplaceCmd.Flags().Int64Var(&transactionReplaceBump, "bump", 10, "Percentage by which to increase the fees of the transaction")
+	addTransactionFlags(transactionReplaceCmd, "the address that sent the transaction")
+}
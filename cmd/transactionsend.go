@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -25,12 +24,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethereal/v2/cli"
 	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
 
@@ -40,6 +39,8 @@ var transactionSendToAddress string
 var transactionSendData string
 var transactionSendRaw string
 var transactionSendRepeat int
+var transactionSendAccessList string
+var transactionSendBlobFile string
 
 // transactionSendCmd represents the transaction send command
 var transactionSendCmd = &cobra.Command{
@@ -49,64 +50,47 @@ var transactionSendCmd = &cobra.Command{
 
     ethereal transaction send --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845	 --amount=1ether --passphrase=secret --data=0x12345
 
+To send a blob transaction, as per EIP-4844, supply the file containing the blob data with --blob-file.  The data is split in to as many blobs as required, with 31 bytes of data in each 32-byte field element, and the KZG commitments and proofs for the blobs are generated and sent with the transaction.  The maximum fee per blob gas defaults to twice the current blob base fee, and can be set with --max-fee-per-blob-gas.  For example:
+
+    ethereal transaction send --from=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --to=0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845 --blob-file=data.bin --passphrase=secret
+
 This will return an exit status of 0 if the transaction is successfully submitted (and mined if --wait is supplied), 1 if the transaction is not successfully submitted, and 2 if the transaction is successfully submitted but not mined within the supplied time limit.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if transactionSendRaw != "" {
 			// Send raw transactions.
-			signedTxs := make([]*types.Transaction, 0)
-
-			if !strings.HasPrefix(transactionSendRaw, "0x") {
-				// Data is a file.
-				data, err := ioutil.ReadFile(transactionSendRaw)
-				cli.ErrCheck(err, quiet, "Failed to read raw transaction from filesystem")
-				lines := bytes.Split(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\n"))
-				for i := range lines {
-					if len(lines[i]) > 2 {
-						data, err := hex.DecodeString(strings.TrimPrefix(string(lines[i]), "0x"))
-						cli.ErrCheck(err, quiet, "Failed to decode transaction")
-						signedTx := &types.Transaction{}
-						stream := rlp.NewStream(bytes.NewReader(data), 0)
-						err = signedTx.DecodeRLP(stream)
-						cli.ErrCheck(err, quiet, "Failed to decode transaction")
-						signedTxs = append(signedTxs, signedTx)
-					}
-				}
-			} else {
-				// Data is a direct transaction.
-				data, err := hex.DecodeString(strings.TrimPrefix(transactionSendRaw, "0x"))
-				cli.ErrCheck(err, quiet, "Failed to decode data")
-				// Decode the raw transaction
-				signedTx := &types.Transaction{}
-				stream := rlp.NewStream(bytes.NewReader(data), 0)
-				err = signedTx.DecodeRLP(stream)
-				cli.ErrCheck(err, quiet, "Failed to decode transaction")
-				signedTxs = append(signedTxs, signedTx)
-			}
+			signedTxs, err := readRawTransactions(transactionSendRaw)
+			cli.ErrCheck(err, quiet, "Failed to decode transactions")
 
 			for i := range signedTxs {
 				if offline {
-					if !quiet {
-						buf := new(bytes.Buffer)
-						cli.ErrCheck(signedTxs[i].EncodeRLP(buf), quiet, "failed to encode transaction")
-						fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-					}
+					outputSignedTransaction(signedTxs[i])
 				} else {
-					err = c.SendTransaction(context.Background(), signedTxs[i])
+					fromAddress, err := types.Sender(signer, signedTxs[i])
+					cli.ErrCheck(err, quiet, "Failed to obtain sender of transaction")
+					err = sendTransaction(context.Background(), fromAddress, signedTxs[i])
 					cli.ErrCheck(err, quiet, "Failed to send transaction")
+					if dryRun {
+						continue
+					}
 
 					logTransaction(signedTxs[i], log.Fields{
 						"group":   "transaction",
 						"command": "send",
 					})
 
-					if !quiet {
+					if jsonOutput() {
+						writeJSON(&transactionJSON{
+							Hash: signedTxs[i].Hash().Hex(),
+						})
+					} else if !quiet {
 						fmt.Println(signedTxs[i].Hash().Hex())
 					}
 				}
 			}
-			os.Exit(exitSuccess)
+			os.Exit(successExitStatus())
 		}
 
+		transactionSendFromAddress = accountOrDefault(transactionSendFromAddress)
 		cli.Assert(transactionSendFromAddress != "", quiet, "--from is required")
 		fromAddress, err := c.Resolve(transactionSendFromAddress)
 		cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to resolve from address %s", transactionSendFromAddress))
@@ -135,7 +119,7 @@ This will return an exit status of 0 if the transaction is successfully submitte
 			defer cancel()
 			balance, err := c.Client().BalanceAt(ctx, fromAddress, nil)
 			cli.ErrCheck(err, quiet, "Failed to obtain balance of address from which to send funds")
-			cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", string2eth.WeiToString(balance, true)))
+			cli.Assert(balance.Cmp(amount) > 0, quiet, fmt.Sprintf("Balance of %s insufficient for transfer", formatWei(balance)))
 		}
 
 		var gasLimit *uint64
@@ -153,25 +137,41 @@ This will return an exit status of 0 if the transaction is successfully submitte
 		data, err := hex.DecodeString(transactionSendData)
 		cli.ErrCheck(err, quiet, "Failed to parse data")
 
+		accessList, err := parseAccessList(transactionSendAccessList, &conn.TransactionData{
+			From:     fromAddress,
+			To:       toAddress,
+			Value:    amount,
+			GasLimit: gasLimit,
+			Data:     data,
+		})
+		cli.ErrCheck(err, quiet, "Failed to obtain access list")
+
+		var blobSidecar *types.BlobTxSidecar
+		if transactionSendBlobFile != "" {
+			cli.Assert(toAddress != nil, quiet, "Blob transactions require a to address")
+			blobData, err := ioutil.ReadFile(transactionSendBlobFile)
+			cli.ErrCheck(err, quiet, "Failed to read blob file")
+			blobSidecar, err = util.BlobSidecar(blobData)
+			cli.ErrCheck(err, quiet, "Failed to create blobs")
+		}
+
 		for i := 0; i < transactionSendRepeat; i++ {
 			// Create and sign the transaction
 			signedTx, err := c.CreateSignedTransaction(context.Background(), &conn.TransactionData{
-				From:     fromAddress,
-				To:       toAddress,
-				Value:    amount,
-				GasLimit: gasLimit,
-				Data:     data,
+				From:        fromAddress,
+				To:          toAddress,
+				Value:       amount,
+				GasLimit:    gasLimit,
+				Data:        data,
+				AccessList:  accessList,
+				BlobSidecar: blobSidecar,
 			})
 			cli.ErrCheck(err, quiet, "Failed to create transaction")
 
 			if offline {
-				if !quiet {
-					buf := new(bytes.Buffer)
-					cli.ErrCheck(signedTx.EncodeRLP(buf), quiet, "failed to encode transaction")
-					fmt.Printf("0x%s\n", hex.EncodeToString(buf.Bytes()))
-				}
+				outputSignedTransaction(signedTx)
 			} else {
-				err = c.SendTransaction(context.Background(), signedTx)
+				err = sendTransaction(context.Background(), fromAddress, signedTx)
 				cli.ErrCheck(err, quiet, "Failed to send transaction")
 				handleSubmittedTransaction(signedTx, log.Fields{
 					"group":   "transaction",
@@ -190,5 +190,8 @@ func init() {
 	transactionSendCmd.Flags().StringVar(&transactionSendData, "data", "", "data to send with transaction (as a hex string)")
 	transactionSendCmd.Flags().StringVar(&transactionSendRaw, "raw", "", "raw transaction (as a hex string).  This overrides all other options")
 	transactionSendCmd.Flags().IntVar(&transactionSendRepeat, "repeat", 1, "Number of times to repeat sending the transaction (incrementing the nonce each time)")
+	transactionSendCmd.Flags().StringVar(&transactionSendBlobFile, "blob-file", "", "file containing data to send in blobs, creating a blob transaction")
+	transactionSendCmd.Flags().String("max-fee-per-blob-gas", "", "Maximum fee per blob gas for a blob transaction (default twice the current blob base fee)")
+	accessListFlag(transactionSendCmd, &transactionSendAccessList)
 	addTransactionFlags(transactionSendCmd, "the address from which to transfer Ether")
 }
@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+var transactionSendPrivateRaw string
+
+// transactionSendPrivateCmd represents the transaction sendprivate command
+var transactionSendPrivateCmd = &cobra.Command{
+	Use:   "sendprivate",
+	Short: "Send signed transactions to a private relay",
+	Long: `Send one or more signed transactions to a private relay rather than the public mempool, for example those created with --offline.  For example:
+
+    ethereal transaction sendprivate --raw=0x02f86b...
+
+--raw can be either a single transaction in hex, or a path to a file containing one transaction in hex per line.  Transactions are sent to Flashbots Protect unless another relay is supplied with --private-relay.  Requests to the relay are signed with the key supplied with private-relay-key in the configuration file, or a new key if none is supplied.
+
+To send transactions created by other commands to the private relay use the --private flag with those commands.
+
+This will return an exit status of 0 if the transactions are successfully submitted (and mined if --wait is supplied), 1 if the transactions are not successfully submitted, and 2 if the transactions are successfully submitted but not mined within the supplied time limit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(!offline, quiet, "Cannot send transactions when offline")
+		cli.Assert(transactionSendPrivateRaw != "", quiet, "--raw is required")
+
+		signedTxs, err := readRawTransactions(transactionSendPrivateRaw)
+		cli.ErrCheck(err, quiet, "Failed to decode transactions")
+		cli.Assert(len(signedTxs) > 0, quiet, "No transactions to send")
+
+		relay := viper.GetString("private-relay")
+		if relay == "" {
+			relay = conn.DefaultPrivateRelay
+		}
+		mined := true
+		for _, signedTx := range signedTxs {
+			if dryRun {
+				fromAddress, err := types.Sender(signer, signedTx)
+				cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to obtain sender of transaction %s", signedTx.Hash().Hex()))
+				dryRunTransaction(fromAddress, signedTx)
+				continue
+			}
+			confirmTransaction(signedTx)
+			err = c.SendPrivateTransaction(context.Background(), signedTx)
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Failed to send transaction %s", signedTx.Hash().Hex()))
+			mined = handleSubmittedTransaction(signedTx, log.Fields{
+				"group":   "transaction",
+				"command": "sendprivate",
+				"relay":   relay,
+			}, false) && mined
+		}
+		if !mined {
+			os.Exit(exitNotMined)
+		}
+		os.Exit(successExitStatus())
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionSendPrivateCmd)
+	transactionSendPrivateCmd.Flags().StringVar(&transactionSendPrivateRaw, "raw", "", "signed transaction (as a hex string), or path to a file of signed transactions")
+	transactionSendPrivateCmd.Flags().Bool("wait", false, "wait for the transactions to be mined before returning")
+	transactionSendPrivateCmd.Flags().Duration("limit", 0, "maximum time to wait for each transaction to be mined before failing (default forever)")
+	transactionSendPrivateCmd.Flags().Uint64("confirmations", 1, "number of confirmations to wait for when waiting for the transactions to be mined")
+}
@@ -0,0 +1,145 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var transactionSignSigner string
+var transactionSignPrivateKey string
+var transactionSignPassphrase string
+var transactionSignMnemonic string
+var transactionSignHDPath string
+var transactionSignExternalSigner string
+
+// transactionSignCmd represents the transaction sign command
+var transactionSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a transaction",
+	Long: `Sign an unsigned transaction without broadcasting it.  For example:
+
+    ethereal transaction sign --transaction='{"chainId":"0x1","nonce":"0x0","to":"0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845","gas":"0x5208","maxFeePerGas":"0x4a817c800","maxPriorityFeePerGas":"0x3b9aca00","value":"0xde0b6b3a7640000"}' --signer=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --passphrase=secret
+
+--transaction can be JSON, the RLP-encoded unsigned transaction in hex, or a path to a file containing either.  Legacy transactions without a chain ID are signed for the chain ID of the current network.
+
+The signed transaction is output in hex, and can be submitted with "ethereal transaction broadcast".
+
+In quiet mode this will return 0 if the transaction can be signed, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+
+		input := transactionStr
+		if !strings.HasPrefix(input, "0x") && !strings.HasPrefix(strings.TrimSpace(input), "{") {
+			// Read from file.
+			data, err := ioutil.ReadFile(input)
+			cli.ErrCheck(err, quiet, "Failed to read transaction from filesystem")
+			input = strings.TrimSpace(string(data))
+		}
+		var data []byte
+		if strings.HasPrefix(input, "0x") {
+			var err error
+			data, err = hex.DecodeString(strings.TrimPrefix(input, "0x"))
+			cli.ErrCheck(err, quiet, "Failed to decode transaction")
+		} else {
+			data = []byte(input)
+		}
+		tx, chainID, err := util.ParseUnsignedTransaction(data)
+		cli.ErrCheck(err, quiet, "Failed to parse transaction")
+		if chainID == nil {
+			chainID = c.ChainID()
+		}
+
+		var key *ecdsa.PrivateKey
+		var signedTx *types.Transaction
+		switch {
+		case transactionSignExternalSigner != "":
+			cli.Assert(transactionSignSigner != "", quiet, "--signer is required when signing with an external signer")
+			signer, err := c.Resolve(transactionSignSigner)
+			cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+			wallet, account, err := cli.ObtainExternalSignerAccount(transactionSignExternalSigner, signer)
+			cli.ErrCheck(err, quiet, "Failed to access external signer")
+			signedTx, err = wallet.SignTx(*account, tx, chainID)
+			cli.ErrCheck(err, quiet, "Failed to sign transaction")
+		case transactionSignPassphrase != "":
+			cli.Assert(transactionSignSigner != "", quiet, "--signer is required when signing with a passphrase")
+			signer, err := c.Resolve(transactionSignSigner)
+			cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+			key, err = util.PrivateKeyForAccount(c.ChainID(), signer, transactionSignPassphrase)
+			cli.ErrCheck(err, quiet, "Invalid account or passphrase")
+		case transactionSignPrivateKey != "":
+			key, err = crypto.HexToECDSA(strings.TrimPrefix(transactionSignPrivateKey, "0x"))
+			cli.ErrCheck(err, quiet, "Invalid private key")
+			if transactionSignSigner != "" {
+				signer, err := c.Resolve(transactionSignSigner)
+				cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+				cli.Assert(crypto.PubkeyToAddress(key.PublicKey) == signer, quiet, "Private key does not match signer")
+			}
+		case transactionSignMnemonic != "":
+			key, err = util.HDPrivateKey(transactionSignMnemonic, "", transactionSignHDPath)
+			cli.ErrCheck(err, quiet, "Failed to obtain key from mnemonic")
+			if transactionSignSigner != "" {
+				signer, err := c.Resolve(transactionSignSigner)
+				cli.ErrCheck(err, quiet, "Failed to obtain signer address")
+				cli.Assert(crypto.PubkeyToAddress(key.PublicKey) == signer, quiet, "Mnemonic and path do not match signer")
+			}
+		default:
+			cli.Err(quiet, "no passphrase, private key, mnemonic or external signer; cannot sign")
+		}
+
+		if signedTx == nil {
+			signedTx, err = types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+			cli.ErrCheck(err, quiet, "Failed to sign transaction")
+		}
+		signedData, err := signedTx.MarshalBinary()
+		cli.ErrCheck(err, quiet, "Failed to encode transaction")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&transactionJSON{
+				Hash:        signedTx.Hash().Hex(),
+				Transaction: fmt.Sprintf("%#x", signedData),
+			})
+		}
+
+		outputIf(verbose, fmt.Sprintf("Transaction hash: %s", signedTx.Hash().Hex()))
+		fmt.Printf("%#x\n", signedData)
+	},
+}
+
+func init() {
+	offlineCmds["transaction:sign"] = true
+	transactionCmd.AddCommand(transactionSignCmd)
+	transactionFlags(transactionSignCmd)
+	transactionSignCmd.Flags().StringVar(&transactionSignSigner, "signer", "", "Address of the signer")
+	transactionSignCmd.Flags().StringVar(&transactionSignPrivateKey, "privatekey", "", "Private key of the signer")
+	transactionSignCmd.Flags().StringVar(&transactionSignPassphrase, "passphrase", "", "Passphrase for the signer's account")
+	transactionSignCmd.Flags().StringVar(&transactionSignMnemonic, "mnemonic", "", "BIP-39 mnemonic of the signer")
+	transactionSignCmd.Flags().StringVar(&transactionSignExternalSigner, "external-signer", "", "External signer to sign the transaction, for example clef:///path/to/clef.ipc")
+	transactionSignCmd.Flags().StringVar(&transactionSignHDPath, "hd-path", util.DefaultHDPath, "Derivation path of the signer's key when using a mnemonic")
+}
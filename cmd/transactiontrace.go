@@ -0,0 +1,73 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+)
+
+var transactionTraceJSON bool
+
+// transactionTraceCmd represents the transaction trace command
+var transactionTraceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Trace the calls made by a transaction",
+	Long: `Trace the internal calls made by a mined transaction.  For example:
+
+    ethereal transaction trace --transaction=0x5097a3c3b2dea7b46e8bfc3a1ec6d2d7fd9b5b7e0a1c4b0f5e36b0a2e1b5f5f1
+
+If the ABI of the contract the transaction was sent to is supplied with --abi then calls to it will be decoded, otherwise well-known function signatures are decoded.
+
+This requires a node that supports the debug namespace, and for older transactions an archive node.
+
+In quiet mode this will return 0 if the transaction can be traced, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+		txHash := common.HexToHash(transactionStr)
+
+		trace, err := c.TraceTransaction(context.Background(), txHash)
+		cli.ErrCheck(err, quiet, "Failed to trace transaction")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if transactionTraceJSON || jsonOutput() {
+			outputJSON(trace)
+		}
+
+		txdata.InitFunctionMap()
+		abis := make(map[common.Address]*abi.ABI)
+		if trace.To != nil {
+			contract := parseContract("")
+			if len(contract.Abi.Methods) > 0 {
+				abis[*trace.To] = &contract.Abi
+			}
+		}
+		printCallFrame(trace, 0, abis)
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionTraceCmd)
+	transactionFlags(transactionTraceCmd)
+	transactionTraceCmd.Flags().StringVar(&contractAbi, "abi", "", "ABI, or path to ABI, for the contract the transaction was sent to")
+	transactionTraceCmd.Flags().BoolVar(&transactionTraceJSON, "json", false, "Output the trace as JSON")
+}
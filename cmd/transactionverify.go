@@ -0,0 +1,150 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/txdata"
+)
+
+// transactionVerifyCmd represents the transaction verify command
+var transactionVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a signed transaction",
+	Long: `Verify and display a signed transaction without broadcasting it.  For example:
+
+    ethereal transaction verify --transaction=0x02f873...
+
+--transaction can be the signed transaction in hex or a path to a file containing it.
+
+In quiet mode this will return 0 if the transaction has a valid signature, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Assert(transactionStr != "", quiet, "--transaction is required")
+
+		input := transactionStr
+		if !strings.HasPrefix(input, "0x") {
+			// Read from file.
+			data, err := ioutil.ReadFile(input)
+			cli.ErrCheck(err, quiet, "Failed to read transaction from filesystem")
+			input = string(data)
+		}
+		txs, err := util.DecodeRawTransactions(input)
+		cli.ErrCheck(err, quiet, "Failed to decode transaction")
+		cli.Assert(len(txs) == 1, quiet, "A single transaction is required")
+		tx := txs[0]
+
+		var txSigner types.Signer
+		if tx.Type() == types.LegacyTxType && !tx.Protected() {
+			txSigner = types.HomesteadSigner{}
+		} else {
+			txSigner = types.LatestSignerForChainID(tx.ChainId())
+		}
+		from, err := types.Sender(txSigner, tx)
+		cli.ErrCheck(err, quiet, "Invalid signature")
+
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(&transactionVerifyJSON{
+				Hash:        tx.Hash().Hex(),
+				From:        from.Hex(),
+				Transaction: tx,
+			})
+		}
+
+		fmt.Printf("Hash:\t\t\t%s\n", tx.Hash().Hex())
+		switch tx.Type() {
+		case types.LegacyTxType:
+			fmt.Println("Transaction type:\tLegacy")
+		case types.DynamicFeeTxType:
+			fmt.Println("Transaction type:\tDynamic")
+		case types.AccessListTxType:
+			fmt.Println("Transaction type:\tAccess list")
+		default:
+			fmt.Println("Transaction type:\tUnknown")
+		}
+		if tx.Type() != types.LegacyTxType || tx.Protected() {
+			fmt.Printf("Chain ID:\t\t%v\n", tx.ChainId())
+		} else {
+			fmt.Println("Chain ID:\t\tNone (replayable across chains)")
+		}
+		fmt.Printf("From:\t\t\t%s\n", from.Hex())
+		if tx.To() == nil {
+			fmt.Println("To:\t\t\tContract creation")
+		} else {
+			fmt.Printf("To:\t\t\t%s\n", tx.To().Hex())
+		}
+		fmt.Printf("Nonce:\t\t\t%v\n", tx.Nonce())
+		fmt.Printf("Gas limit:\t\t%v\n", tx.Gas())
+		switch tx.Type() {
+		case types.LegacyTxType, types.AccessListTxType:
+			fmt.Printf("Gas price:\t\t%v\n", formatWei(tx.GasPrice()))
+		case types.DynamicFeeTxType:
+			fmt.Printf("Max fee per gas:\t%v\n", formatWei(tx.GasFeeCap()))
+			fmt.Printf("Tip per gas:\t\t%v\n", formatWei(tx.GasTipCap()))
+		}
+		fmt.Printf("Value:\t\t\t%v\n", formatWei(tx.Value()))
+
+		if tx.To() != nil && len(tx.Data()) > 0 {
+			data := fmt.Sprintf("%#x", tx.Data())
+			if !offline {
+				txdata.InitFunctionMap()
+				data = txdata.DataToString(c.Client(), tx.Data())
+			}
+			if strings.HasPrefix(data, "0x") && len(tx.Data()) >= 4 {
+				// Not a known function; try the 4byte directory.
+				if decoded, err := fourByteDecode(tx.Data()); err == nil {
+					if res, err := fourByteDecodedToString(decoded); err == nil {
+						data = res
+					}
+				}
+			}
+			fmt.Printf("Data:\t\t\t%v\n", data)
+		} else if len(tx.Data()) > 0 {
+			fmt.Printf("Data:\t\t\t%#x\n", tx.Data())
+		}
+
+		if verbose && len(tx.AccessList()) > 0 {
+			fmt.Println("Access list:")
+			for _, entry := range tx.AccessList() {
+				fmt.Printf("\t%s\n", entry.Address.Hex())
+				for _, key := range entry.StorageKeys {
+					fmt.Printf("\t\t%s\n", key.Hex())
+				}
+			}
+		}
+	},
+}
+
+// transactionVerifyJSON is the JSON output for the transaction verify command.
+type transactionVerifyJSON struct {
+	Hash        string             `json:"transaction_hash"`
+	From        string             `json:"from"`
+	Transaction *types.Transaction `json:"transaction"`
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionVerifyCmd)
+	transactionFlags(transactionVerifyCmd)
+}
@@ -14,16 +14,20 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/wealdtech/ethereal/v2/cli"
-	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/conn"
 )
 
 var transactionWaitLimit time.Duration
+var transactionWaitConfirmations uint64
 
 // transactionWaitCmd represents the transaction info command
 var transactionWaitCmd = &cobra.Command{
@@ -33,19 +37,35 @@ var transactionWaitCmd = &cobra.Command{
 
     ethereal transaction wait --transaction=0x5FfC014343cd971B7eb70732021E26C35B744cc4 --limit=30s
 
+If --confirmations is supplied this will wait until the transaction has the given number of confirmations, where the block containing the transaction is the first confirmation.  If the transaction is removed from its block by a chain reorganisation waiting continues until it is mined again.
+
 In quiet mode this will return 0 if the transaction is mined before the time limit is reached, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.Assert(transactionStr != "", quiet, "--transaction is required")
 		txHash := common.HexToHash(transactionStr)
 
-		mined := util.WaitForTransaction(c.Client(), txHash, transactionWaitLimit)
-		if mined {
-			outputIf(!quiet, "Transaction mined")
-			os.Exit(exitSuccess)
-		} else {
+		mined, err := c.WaitForTransaction(context.Background(), txHash, transactionWaitConfirmations, transactionWaitLimit)
+		if err != nil {
+			if !errors.Is(err, conn.ErrNotMined) {
+				outputIf(debug, fmt.Sprintf("Failed to wait for transaction: %v", err))
+			}
+			if jsonOutput() {
+				notMined := false
+				writeJSON(&transactionJSON{
+					Hash:  txHash.Hex(),
+					Mined: &notMined,
+				})
+				os.Exit(exitFailure)
+			}
 			outputIf(!quiet, "Transaction not mined")
 			os.Exit(exitFailure)
 		}
+		if jsonOutput() {
+			outputJSON(newMinedTransactionJSON(mined))
+		}
+		outputIf(!quiet, "Transaction mined")
+		outputMinedTransaction(mined)
+		os.Exit(exitSuccess)
 	},
 }
 
@@ -53,4 +73,5 @@ func init() {
 	transactionCmd.AddCommand(transactionWaitCmd)
 	transactionFlags(transactionWaitCmd)
 	transactionWaitCmd.Flags().DurationVar(&transactionWaitLimit, "limit", 0, "maximum time to wait before failing (default forever)")
+	transactionWaitCmd.Flags().Uint64Var(&transactionWaitConfirmations, "confirmations", 1, "number of confirmations to wait for")
 }
@@ -0,0 +1,35 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var txpoolAddressStr string
+
+// txpoolCmd represents the txpool command
+var txpoolCmd = &cobra.Command{
+	Use:   "txpool",
+	Short: "Inspect the transaction pool",
+	Long:  `Inspect the pending transaction pool of the node to which Ethereal is connected.  This requires the node to support the txpool namespace.`,
+}
+
+func init() {
+	RootCmd.AddCommand(txpoolCmd)
+}
+
+func txpoolFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&txpoolAddressStr, "address", "", "Address of the sender of transactions (defaults to all senders)")
+}
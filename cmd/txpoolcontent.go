@@ -0,0 +1,158 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+// txpoolContentCmd represents the txpool content command
+var txpoolContentCmd = &cobra.Command{
+	Use:   "content",
+	Short: "Obtain the transactions in the transaction pool",
+	Long: `Obtain the transactions in the transaction pool, with their nonces and fees.  For example:
+
+    ethereal txpool content --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+Queued transactions cannot be included in a block until the gap between the account's nonce and their own nonce is filled; the missing nonces are shown for each sender.
+
+In quiet mode this will return 0 if there are transactions in the pool, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+
+		var content *conn.TxPoolContent
+		if txpoolAddressStr != "" {
+			address, err := c.Resolve(txpoolAddressStr)
+			cli.ErrCheck(err, quiet, "Failed to obtain address")
+			content, err = c.TxPoolContentFrom(ctx, address)
+			cli.ErrCheck(err, quiet, "Failed to obtain transaction pool content")
+		} else {
+			var err error
+			content, err = c.TxPoolContent(ctx)
+			cli.ErrCheck(err, quiet, "Failed to obtain transaction pool content")
+		}
+
+		addresses := txpoolAddresses(content.Pending, content.Queued)
+		if quiet {
+			if len(addresses) > 0 {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(content)
+		}
+
+		for i, address := range addresses {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("Address:\t%s\n", formatAddress(address))
+			nonce, err := c.Client().NonceAt(ctx, address, nil)
+			cli.ErrCheck(err, quiet, "Failed to obtain nonce")
+			fmt.Printf("Nonce:\t\t%d\n", nonce)
+			if txs := content.Pending[address]; len(txs) > 0 {
+				fmt.Println("Pending:")
+				for _, tx := range txs {
+					txpoolOutputTransaction(tx)
+				}
+			}
+			if txs := content.Queued[address]; len(txs) > 0 {
+				fmt.Println("Queued:")
+				for _, tx := range txs {
+					txpoolOutputTransaction(tx)
+				}
+				if missing := txpoolMissingNonces(nonce, content.Pending[address], txs); len(missing) > 0 {
+					fmt.Printf("Missing nonces:\t%v\n", missing)
+				}
+			}
+		}
+		os.Exit(exitSuccess)
+	},
+}
+
+// txpoolAddresses returns the senders of the transactions, ordered by address.
+func txpoolAddresses(pools ...map[common.Address][]*types.Transaction) []common.Address {
+	addresses := make([]common.Address, 0)
+	seen := make(map[common.Address]bool)
+	for _, pool := range pools {
+		for address := range pool {
+			if !seen[address] {
+				seen[address] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	return addresses
+}
+
+// txpoolMissingNonces returns the nonces that need to be filled before the queued transactions
+// can be included in a block.
+func txpoolMissingNonces(nonce uint64, pending []*types.Transaction, queued []*types.Transaction) []uint64 {
+	present := make(map[uint64]bool)
+	for _, tx := range pending {
+		present[tx.Nonce()] = true
+	}
+	for _, tx := range queued {
+		present[tx.Nonce()] = true
+	}
+	missing := make([]uint64, 0)
+	highest := queued[len(queued)-1].Nonce()
+	for i := nonce; i < highest; i++ {
+		if !present[i] {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// txpoolOutputTransaction outputs a single transaction from the pool.
+func txpoolOutputTransaction(tx *types.Transaction) {
+	var fees string
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		fees = fmt.Sprintf("max fee %s, tip %s", formatWei(tx.GasFeeCap()), formatWei(tx.GasTipCap()))
+	default:
+		fees = fmt.Sprintf("gas price %s", formatWei(tx.GasPrice()))
+	}
+	fmt.Printf("  %d:\t%s (%s)\n", tx.Nonce(), tx.Hash().Hex(), fees)
+	if verbose {
+		if tx.To() == nil {
+			fmt.Println("\tTo:\t\tContract creation")
+		} else {
+			fmt.Printf("\tTo:\t\t%s\n", formatAddress(*tx.To()))
+		}
+		fmt.Printf("\tValue:\t\t%s\n", formatWei(tx.Value()))
+		fmt.Printf("\tGas limit:\t%d\n", tx.Gas())
+	}
+}
+
+func init() {
+	txpoolCmd.AddCommand(txpoolContentCmd)
+	txpoolFlags(txpoolContentCmd)
+}
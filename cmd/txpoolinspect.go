@@ -0,0 +1,96 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// txpoolInspectCmd represents the txpool inspect command
+var txpoolInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Summarise the transactions in the transaction pool",
+	Long: `Summarise the transactions in the transaction pool, one line per transaction.  For example:
+
+    ethereal txpool inspect --address=0x5FfC014343cd971B7eb70732021E26C35B744cc4
+
+In quiet mode this will return 0 if there are transactions in the pool, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+
+		inspection, err := c.TxPoolInspect(ctx)
+		cli.ErrCheck(err, quiet, "Failed to inspect transaction pool")
+
+		if txpoolAddressStr != "" {
+			address, err := c.Resolve(txpoolAddressStr)
+			cli.ErrCheck(err, quiet, "Failed to obtain address")
+			for _, pool := range []map[common.Address]map[uint64]string{inspection.Pending, inspection.Queued} {
+				for sender := range pool {
+					if sender != address {
+						delete(pool, sender)
+					}
+				}
+			}
+		}
+
+		if quiet {
+			if len(inspection.Pending) > 0 || len(inspection.Queued) > 0 {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(inspection)
+		}
+
+		txpoolOutputInspection("pending", inspection.Pending)
+		txpoolOutputInspection("queued", inspection.Queued)
+		os.Exit(exitSuccess)
+	},
+}
+
+// txpoolOutputInspection outputs transaction summaries ordered by sender and nonce.
+func txpoolOutputInspection(state string, pool map[common.Address]map[uint64]string) {
+	addresses := make([]common.Address, 0, len(pool))
+	for address := range pool {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	for _, address := range addresses {
+		nonces := make([]uint64, 0, len(pool[address]))
+		for nonce := range pool[address] {
+			nonces = append(nonces, nonce)
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		for _, nonce := range nonces {
+			fmt.Printf("%s\t%s\t%d\t%s\n", state, address.Hex(), nonce, pool[address][nonce])
+		}
+	}
+}
+
+func init() {
+	txpoolCmd.AddCommand(txpoolInspectCmd)
+	txpoolFlags(txpoolInspectCmd)
+}
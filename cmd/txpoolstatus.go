@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+)
+
+// txpoolStatusCmd represents the txpool status command
+var txpoolStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Obtain the status of the transaction pool",
+	Long: `Obtain the number of pending and queued transactions in the transaction pool.  For example:
+
+    ethereal txpool status
+
+In quiet mode this will return 0 if the transaction pool is empty, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := localContext()
+		defer cancel()
+
+		status, err := c.TxPoolStatus(ctx)
+		cli.ErrCheck(err, quiet, "Failed to obtain transaction pool status")
+
+		if quiet {
+			if status.Pending == 0 && status.Queued == 0 {
+				os.Exit(exitSuccess)
+			}
+			os.Exit(exitFailure)
+		}
+
+		if jsonOutput() {
+			outputJSON(status)
+		}
+
+		fmt.Printf("Pending:\t%d\n", status.Pending)
+		fmt.Printf("Queued:\t\t%d\n", status.Queued)
+		os.Exit(exitSuccess)
+	},
+}
+
+func init() {
+	txpoolCmd.AddCommand(txpoolStatusCmd)
+}
@@ -0,0 +1,29 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// utilCmd represents the util command
+var utilCmd = &cobra.Command{
+	Use:   "util",
+	Short: "Convert and hash data",
+	Long:  `Convert data and values between formats, and calculate hashes, selectors and topics`,
+}
+
+func init() {
+	RootCmd.AddCommand(utilCmd)
+}
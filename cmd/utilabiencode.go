@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var utilABIEncodePacked bool
+
+// utilABIEncodeCmd represents the util abiencode command
+var utilABIEncodeCmd = &cobra.Command{
+	Use:   "abiencode [types] [values...]",
+	Short: "ABI encode values",
+	Long: `ABI encode values of the given types.  For example:
+
+    ethereal util abiencode "(address,uint256)" 0x5FfC014343cd971B7eb70732021E26C35B744cc4 100
+
+Types are comma-separated, optionally in parentheses.  Integers can be decimal or 0x-prefixed hex, bytes are hex, and arrays are comma-separated elements within square brackets, for example "[1,2,3]".  Values that start with "-" must follow "--" so that they are not taken to be flags.
+
+With --packed values are encoded as Solidity's abi.encodePacked(), as used for signature digests and CREATE2 salts: static types use only as many bytes as they require, strings and bytes have no length or padding, and array elements are padded to 32 bytes.
+
+In quiet mode this will return 0 if the values can be encoded, otherwise 1.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		types, err := util.ParseTypes(args[0])
+		cli.ErrCheck(err, quiet, "Invalid types")
+		cli.Assert(len(args)-1 == len(types), quiet, fmt.Sprintf("%d types but %d values", len(types), len(args)-1))
+
+		values := make([]interface{}, len(types))
+		for i := range types {
+			values[i], err = util.ParseABIValue(types[i], args[i+1])
+			cli.ErrCheck(err, quiet, fmt.Sprintf("Invalid value %d", i+1))
+		}
+
+		var data []byte
+		if utilABIEncodePacked {
+			data, err = util.EncodePacked(types, values)
+		} else {
+			arguments := make(abi.Arguments, len(types))
+			for i := range types {
+				arguments[i] = abi.Argument{Type: types[i]}
+			}
+			data, err = arguments.Pack(values...)
+		}
+		cli.ErrCheck(err, quiet, "Failed to encode values")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"data": fmt.Sprintf("0x%x", data)})
+		}
+		fmt.Printf("0x%x\n", data)
+	},
+}
+
+func init() {
+	offlineCmds["util:abiencode"] = true
+	utilCmd.AddCommand(utilABIEncodeCmd)
+	utilABIEncodeCmd.Flags().BoolVar(&utilABIEncodePacked, "packed", false, "Encode values as abi.encodePacked()")
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var utilConvertFrom string
+var utilConvertTo string
+
+// utilConvertCmd represents the util convert command
+var utilConvertCmd = &cobra.Command{
+	Use:   "convert [data]",
+	Short: "Convert data between hex, UTF-8 and base64",
+	Long: `Convert data between hex, UTF-8 text and base64.  For example:
+
+    ethereal util convert --from=hex --to=utf8 0x68656c6c6f
+
+--from and --to can be "hex", "utf8" or "base64".
+
+In quiet mode this will return 0 if the data can be converted, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := util.DecodeData(args[0], utilConvertFrom)
+		cli.ErrCheck(err, quiet, "Invalid data")
+		res, err := util.EncodeData(data, utilConvertTo)
+		cli.ErrCheck(err, quiet, "Failed to convert data")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"data": res})
+		}
+		fmt.Println(res)
+	},
+}
+
+func init() {
+	offlineCmds["util:convert"] = true
+	utilCmd.AddCommand(utilConvertCmd)
+	utilConvertCmd.Flags().StringVar(&utilConvertFrom, "from", "utf8", "Encoding of the supplied data (hex, utf8 or base64)")
+	utilConvertCmd.Flags().StringVar(&utilConvertTo, "to", "hex", "Encoding of the output data (hex, utf8 or base64)")
+}
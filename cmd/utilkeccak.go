@@ -0,0 +1,68 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var utilKeccakEncoding string
+
+// utilKeccakCmd represents the util keccak256 command
+var utilKeccakCmd = &cobra.Command{
+	Use:     "keccak256 [data]",
+	Aliases: []string{"keccak"},
+	Short:   "Calculate the Keccak-256 hash of data",
+	Long: `Calculate the Keccak-256 hash of data.  For example:
+
+    ethereal util keccak256 "hello"
+
+By default data starting with 0x is treated as hex and other data as UTF-8 text.  The encoding of the data can be set explicitly with --encoding, which can be "hex", "utf8" or "base64".
+
+In quiet mode this will return 0 if the data is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		encoding := utilKeccakEncoding
+		if encoding == "" {
+			encoding = "utf8"
+			if strings.HasPrefix(args[0], "0x") {
+				encoding = "hex"
+			}
+		}
+		data, err := util.DecodeData(args[0], encoding)
+		cli.ErrCheck(err, quiet, "Invalid data")
+		hash := crypto.Keccak256Hash(data)
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"hash": hash.Hex()})
+		}
+		fmt.Println(hash.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["util:keccak256"] = true
+	utilCmd.AddCommand(utilKeccakCmd)
+	utilKeccakCmd.Flags().StringVar(&utilKeccakEncoding, "encoding", "", "Encoding of the data (hex, utf8 or base64)")
+}
@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+	"github.com/wealdtech/ethereal/v2/util/rlpdump"
+)
+
+// utilRLPDecodeCmd represents the util rlp decode command
+var utilRLPDecodeCmd = &cobra.Command{
+	Use:   "decode [data]",
+	Short: "Decode RLP",
+	Long: `Decode hex RLP data.  For example:
+
+    ethereal util rlp decode 0xc88363617483646f67
+
+Raw transactions and block headers are recognised, and their fields shown by name.  Other data is shown as nested lists of hex byte strings.  With --json the data is output in the form accepted by "ethereal util rlp encode".
+
+In quiet mode this will return 0 if the data is valid RLP, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := util.DecodeData(args[0], "hex")
+		cli.ErrCheck(err, quiet, "Invalid data")
+		structure, err := rlpdump.Recognise(data)
+		cli.ErrCheck(err, quiet, "Invalid RLP")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(structure.JSON())
+		}
+		fmt.Print(structure.String())
+	},
+}
+
+func init() {
+	offlineCmds["util:rlp:decode"] = true
+	utilRLPCmd.AddCommand(utilRLPDecodeCmd)
+}
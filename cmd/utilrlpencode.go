@@ -0,0 +1,54 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/rlpdump"
+)
+
+// utilRLPEncodeCmd represents the util rlp encode command
+var utilRLPEncodeCmd = &cobra.Command{
+	Use:   "encode [json]",
+	Short: "Encode RLP",
+	Long: `Encode a JSON structure as RLP.  For example:
+
+    ethereal util rlp encode '["cat", "0x646f67", 1024]'
+
+Arrays are encoded as lists, strings starting with 0x as hex bytes and other strings as UTF-8 text, and non-negative integers as big-endian integers.
+
+In quiet mode this will return 0 if the structure can be encoded, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := rlpdump.Encode([]byte(args[0]))
+		cli.ErrCheck(err, quiet, "Failed to encode RLP")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"data": fmt.Sprintf("%#x", data)})
+		}
+		fmt.Printf("%#x\n", data)
+	},
+}
+
+func init() {
+	offlineCmds["util:rlp:encode"] = true
+	utilRLPCmd.AddCommand(utilRLPEncodeCmd)
+}
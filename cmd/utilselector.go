@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// utilSelectorCmd represents the util selector command
+var utilSelectorCmd = &cobra.Command{
+	Use:   "selector [signature]",
+	Short: "Calculate the selector of a function or error",
+	Long: `Calculate the 4-byte selector of a function or error from its signature.  For example:
+
+    ethereal util selector "transfer(address,uint256)"
+
+The signature can also be supplied as in Solidity source, with parameter names and modifiers, for example "function transfer(address to, uint amount) external returns (bool)".  The canonical signature is shown in verbose mode.
+
+In quiet mode this will return 0 if the signature is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		signature, err := util.CanonicalSignature(args[0])
+		cli.ErrCheck(err, quiet, "Invalid signature")
+		selector, err := util.Selector(signature)
+		cli.ErrCheck(err, quiet, "Failed to calculate selector")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"signature": signature, "selector": fmt.Sprintf("%#x", selector)})
+		}
+		outputIf(verbose, signature)
+		fmt.Printf("%#x\n", selector)
+	},
+}
+
+func init() {
+	offlineCmds["util:selector"] = true
+	utilCmd.AddCommand(utilSelectorCmd)
+}
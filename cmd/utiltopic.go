@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// utilTopicCmd represents the util topic command
+var utilTopicCmd = &cobra.Command{
+	Use:   "topic [signature]",
+	Short: "Calculate the topic of an event",
+	Long: `Calculate the topic of an event from its signature, as used for the first topic of its logs.  For example:
+
+    ethereal util topic "Transfer(address,address,uint256)"
+
+The signature can also be supplied as in Solidity source, with parameter names and modifiers, for example "event Transfer(address indexed from, address indexed to, uint256 value)".  The canonical signature is shown in verbose mode.
+
+In quiet mode this will return 0 if the signature is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		signature, err := util.CanonicalSignature(args[0])
+		cli.ErrCheck(err, quiet, "Invalid signature")
+		topic := crypto.Keccak256Hash([]byte(signature))
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{"signature": signature, "topic": topic.Hex()})
+		}
+		outputIf(verbose, signature)
+		fmt.Println(topic.Hex())
+	},
+}
+
+func init() {
+	offlineCmds["util:topic"] = true
+	utilCmd.AddCommand(utilTopicCmd)
+}
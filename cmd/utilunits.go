@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util/output"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// utilUnitsCmd represents the util units command
+var utilUnitsCmd = &cobra.Command{
+	Use:   "units [value]",
+	Short: "Convert a value between Wei, GWei and Ether",
+	Long: `Convert a value between Wei, GWei and Ether.  For example:
+
+    ethereal util units "1.5 gwei"
+
+The value is supplied with its unit, for example "100 wei", "1.5 gwei" or "0.1 ether".
+
+In quiet mode this will return 0 if the value is valid, otherwise 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := string2eth.StringToWei(args[0])
+		cli.ErrCheck(err, quiet, "Invalid value")
+		if quiet {
+			os.Exit(exitSuccess)
+		}
+
+		if jsonOutput() {
+			outputJSON(map[string]interface{}{
+				"wei":   value.String(),
+				"gwei":  output.FormatWei(value, output.GWei),
+				"ether": output.FormatWei(value, output.Ether),
+			})
+		}
+		fmt.Println(output.FormatWei(value, output.Wei))
+		fmt.Println(output.FormatWei(value, output.GWei))
+		fmt.Println(output.FormatWei(value, output.Ether))
+	},
+}
+
+func init() {
+	offlineCmds["util:units"] = true
+	utilCmd.AddCommand(utilUnitsCmd)
+}
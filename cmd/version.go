@@ -30,6 +30,11 @@ var versionCmd = &cobra.Command{
 
     ethereal version.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if jsonOutput() {
+			outputJSON(&versionJSON{
+				Version: "2.8.2",
+			})
+		}
 		fmt.Println("2.8.2")
 		if viper.GetBool("verbose") {
 			buildInfo, ok := dbg.ReadBuildInfo()
@@ -48,6 +53,11 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// versionJSON is the JSON output for the version command.
+type versionJSON struct {
+	Version string `json:"version"`
+}
+
 func init() {
 	offlineCmds["version"] = true
 	RootCmd.AddCommand(versionCmd)
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/pkg/errors"
+)
+
+// CreateAccessList creates an access list for the given transaction, returning the access list
+// and the gas used by the transaction when using it.
+func (c *Conn) CreateAccessList(ctx context.Context,
+	txData *TransactionData,
+) (
+	types.AccessList,
+	uint64,
+	error,
+) {
+	if c.offline {
+		return nil, 0, errors.New("cannot create access list when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	msg := ethereum.CallMsg{
+		From:  txData.From,
+		To:    txData.To,
+		Value: txData.Value,
+		Data:  txData.Data,
+	}
+	if txData.GasLimit != nil {
+		msg.Gas = *txData.GasLimit
+	}
+	accessList, gasUsed, vmErr, err := gethclient.New(c.rpcClient).CreateAccessList(ctx, msg)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to create access list")
+	}
+	if vmErr != "" {
+		return nil, 0, errors.Errorf("transaction would fail: %s", vmErr)
+	}
+	if accessList == nil {
+		return types.AccessList{}, gasUsed, nil
+	}
+	return *accessList, gasUsed, nil
+}
@@ -0,0 +1,58 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/pkg/errors"
+)
+
+// Peers returns information about the peers of the node.
+// This requires the node to expose the admin namespace.
+func (c *Conn) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain peers when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res []*p2p.PeerInfo
+	if err := c.rpcClient.CallContext(ctx, &res, "admin_peers"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain peers")
+	}
+	return res, nil
+}
+
+// NodeInfo returns information about the node.
+// This requires the node to expose the admin namespace.
+func (c *Conn) NodeInfo(ctx context.Context) (*p2p.NodeInfo, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain node information when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res *p2p.NodeInfo
+	if err := c.rpcClient.CallContext(ctx, &res, "admin_nodeInfo"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain node information")
+	}
+	if res == nil {
+		return nil, errors.New("node information not available")
+	}
+	return res, nil
+}
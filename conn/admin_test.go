@@ -0,0 +1,94 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testAdminService struct{}
+
+func (s *testAdminService) Peers() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"enode": "enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303",
+			"id":    "2e2ee7e4b0bf0a81b8d8c3c2b6f56d0c2c7f4d2b9e25f1a1b0d7c7ab7c3f9c5a",
+			"name":  "Geth/v1.14.0-stable/linux-amd64/go1.22.2",
+			"caps":  []string{"eth/68", "snap/1"},
+			"network": map[string]interface{}{
+				"localAddress":  "10.0.0.2:40000",
+				"remoteAddress": "52.16.188.185:30303",
+				"inbound":       false,
+			},
+			"protocols": map[string]interface{}{"eth": map[string]interface{}{"version": 68}},
+		},
+	}
+}
+
+func (s *testAdminService) NodeInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"id":    "3d6b2d1c1e2f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b",
+		"name":  "Geth/v1.14.0-stable/linux-amd64/go1.22.2",
+		"enode": "enode://3d6b@127.0.0.1:30303",
+		"ip":    "127.0.0.1",
+		"ports": map[string]interface{}{
+			"discovery": 30303,
+			"listener":  30303,
+		},
+		"listenAddr": "[::]:30303",
+		"protocols": map[string]interface{}{
+			"eth": map[string]interface{}{
+				"network": 1,
+				"genesis": "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			},
+		},
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := conn.New(ctx, newTestURL(t, map[string]interface{}{"admin": &testAdminService{}}))
+	require.NoError(t, err)
+
+	peers, err := c.Peers(ctx)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Equal(t, "Geth/v1.14.0-stable/linux-amd64/go1.22.2", peers[0].Name)
+	require.Equal(t, []string{"eth/68", "snap/1"}, peers[0].Caps)
+	require.Equal(t, "52.16.188.185:30303", peers[0].Network.RemoteAddress)
+	require.False(t, peers[0].Network.Inbound)
+
+	info, err := c.NodeInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", info.IP)
+	require.Equal(t, 30303, info.Ports.Listener)
+	require.Contains(t, info.Protocols, "eth")
+}
+
+func TestAdminUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := conn.New(ctx, newTestURL(t, nil))
+	require.NoError(t, err)
+
+	_, err = c.Peers(ctx)
+	require.Error(t, err)
+	_, err = c.NodeInfo(ctx)
+	require.Error(t, err)
+}
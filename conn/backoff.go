@@ -0,0 +1,55 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// backoff provides exponentially increasing delays between attempts to re-establish
+// a connection or subscription.
+type backoff struct {
+	delay time.Duration
+}
+
+// wait waits for the current delay and increases it for next time.
+// It returns false if the context is done before the delay has passed.
+func (b *backoff) wait(ctx context.Context) bool {
+	if b.delay == 0 {
+		b.delay = minBackoff
+	}
+	select {
+	case <-time.After(b.delay):
+	case <-ctx.Done():
+		return false
+	}
+	if b.delay < maxBackoff {
+		b.delay *= 2
+		if b.delay > maxBackoff {
+			b.delay = maxBackoff
+		}
+	}
+	return true
+}
+
+// reset resets the delay after a successful attempt.
+func (b *backoff) reset() {
+	b.delay = 0
+}
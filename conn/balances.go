@@ -0,0 +1,62 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Balances returns the balances of the given addresses at the given block, or the latest
+// block if the block number is nil.  Requests are batched to reduce round trips to the node.
+func (c *Conn) Balances(ctx context.Context,
+	addresses []common.Address,
+	blockNumber *big.Int,
+) (
+	[]*big.Int,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot obtain balances when offline")
+	}
+
+	results := make([]hexutil.Big, len(addresses))
+	batch := make([]rpc.BatchElem, len(addresses))
+	for i := range addresses {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{addresses[i], toBlockNumArg(blockNumber)},
+			Result: &results[i],
+		}
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain balances")
+	}
+	for i := range batch {
+		if batch[i].Error != nil {
+			return nil, errors.Wrapf(batch[i].Error, "failed to obtain balance of %s", addresses[i].Hex())
+		}
+	}
+
+	balances := make([]*big.Int, len(addresses))
+	for i := range results {
+		balances[i] = results[i].ToInt()
+	}
+	return balances, nil
+}
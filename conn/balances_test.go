@@ -0,0 +1,100 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testBalanceService struct {
+	// balances are the balances of addresses, by block.
+	balances map[string]map[common.Address]*big.Int
+}
+
+func (s *testBalanceService) GetBalance(address common.Address, block string) *hexutil.Big {
+	balance, exists := s.balances[block][address]
+	if !exists {
+		balance = big.NewInt(0)
+	}
+	return (*hexutil.Big)(balance)
+}
+
+func TestBalances(t *testing.T) {
+	ctx := context.Background()
+
+	address1 := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	address2 := common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF")
+	c := newTestConn(t, &testBalanceService{
+		balances: map[string]map[common.Address]*big.Int{
+			"latest": {
+				address1: big.NewInt(3000),
+				address2: big.NewInt(5),
+			},
+			"0x64": {
+				address1: big.NewInt(1000),
+			},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		addresses   []common.Address
+		blockNumber *big.Int
+		balances    []*big.Int
+	}{
+		{
+			name:      "Empty",
+			addresses: []common.Address{},
+			balances:  []*big.Int{},
+		},
+		{
+			name:      "Latest",
+			addresses: []common.Address{address1, address2},
+			balances:  []*big.Int{big.NewInt(3000), big.NewInt(5)},
+		},
+		{
+			name:        "Block",
+			addresses:   []common.Address{address1, address2},
+			blockNumber: big.NewInt(100),
+			balances:    []*big.Int{big.NewInt(1000), big.NewInt(0)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			balances, err := c.Balances(ctx, test.addresses, test.blockNumber)
+			require.NoError(t, err)
+			require.Equal(t, len(test.balances), len(balances))
+			for i := range test.balances {
+				require.Equal(t, 0, test.balances[i].Cmp(balances[i]))
+			}
+		})
+	}
+
+	// More addresses than fit in a single batch.
+	addresses := make([]common.Address, 250)
+	for i := range addresses {
+		addresses[i] = address2
+	}
+	balances, err := c.Balances(ctx, addresses, nil)
+	require.NoError(t, err)
+	require.Len(t, balances, 250)
+	require.Equal(t, big.NewInt(5), balances[249])
+}
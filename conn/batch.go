@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxBatchSize is the maximum number of requests sent to the node in a single batch.
+	maxBatchSize = 100
+	// maxConcurrentRequests is the maximum number of requests made at the same time if the
+	// connection does not support batches.
+	maxConcurrentRequests = 8
+)
+
+// BatchCallContext makes the requests in the batch, setting the result or error of each
+// element.  Requests are sent in batches of up to 100, each in a single round trip to the node.
+// If the connection does not support batches, shown by it rejecting the batch or not returning
+// an array of results, the requests are made individually, a few at a time, instead.
+// An error is returned only if the requests could not be made; errors from individual requests
+// are set in their elements.
+func (c *Conn) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if c.offline {
+		return errors.New("cannot make requests when offline")
+	}
+
+	for start := 0; start < len(batch); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+		elems := batch[start:end]
+
+		if atomic.LoadInt32(&c.batchUnsupported) == 0 {
+			batchCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			err := c.rpcClient.BatchCallContext(batchCtx, elems)
+			cancel()
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil || !batchUnsupportedError(err) {
+				return errors.Wrap(err, "batch request failed")
+			}
+			atomic.StoreInt32(&c.batchUnsupported, 1)
+		}
+		if err := c.callConcurrently(ctx, elems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchUnsupportedError returns true if the error from a batch request shows that the
+// connection does not support batches: the request was rejected as invalid, or the response was
+// not an array.  Other errors, such as server errors and timeouts, can be transient so do not.
+func batchUnsupportedError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusBadRequest &&
+			httpErr.StatusCode < http.StatusInternalServerError &&
+			httpErr.StatusCode != http.StatusRequestTimeout &&
+			httpErr.StatusCode != http.StatusTooManyRequests
+	}
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &typeErr)
+}
+
+// callConcurrently makes the requests in the batch individually, with bounded concurrency.
+func (c *Conn) callConcurrently(ctx context.Context, batch []rpc.BatchElem) error {
+	sem := make(chan struct{}, maxConcurrentRequests)
+	var wg sync.WaitGroup
+	for i := range batch {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(elem *rpc.BatchElem) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			elem.Error = c.rpcClient.CallContext(callCtx, elem.Result, elem.Method, elem.Args...)
+		}(&batch[i])
+	}
+	wg.Wait()
+	return ctx.Err()
+}
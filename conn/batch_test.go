@@ -0,0 +1,152 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testBatchService struct{}
+
+func (s *testBatchService) GetTransactionCount(index hexutil.Uint64) (hexutil.Uint64, error) {
+	if index == 7 {
+		return 0, fmt.Errorf("bad index %d", index)
+	}
+	return index * 2, nil
+}
+
+// newTestBatchServer creates a server that counts the requests it receives, optionally
+// responding to batches with the given status and body rather than serving them.
+func newTestBatchServer(t *testing.T, batchStatus int, batchBody string, requests *int32) *httptest.Server {
+	server := newTestServer(t, map[string]interface{}{"eth": &testBatchService{}})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if batchStatus != 0 && bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(batchStatus)
+			fmt.Fprint(w, batchBody)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestBatchCallContext(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+
+	tests := []struct {
+		name        string
+		batchStatus int
+		batchBody   string
+		elems       int
+		// requests is the expected number of requests after the connection is made.
+		requests int32
+		err      string
+	}{
+		{
+			name:     "Empty",
+			requests: 0,
+		},
+		{
+			name:     "Single",
+			elems:    10,
+			requests: 1,
+		},
+		{
+			name:     "Multiple",
+			elems:    250,
+			requests: 3,
+		},
+		{
+			name:        "Rejected",
+			batchStatus: http.StatusBadRequest,
+			batchBody:   "batches not supported",
+			elems:       150,
+			// One rejected batch, then individual requests.
+			requests: 151,
+		},
+		{
+			name:        "NotArray",
+			batchStatus: http.StatusOK,
+			batchBody:   `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batches not supported"}}`,
+			elems:       150,
+			requests:    151,
+		},
+		{
+			name:        "Unavailable",
+			batchStatus: http.StatusServiceUnavailable,
+			batchBody:   "try again later",
+			elems:       150,
+			// The error is transient, so there is no fallback to individual requests.
+			requests: 1,
+			err:      "batch request failed: 503 Service Unavailable: try again later",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := int32(0)
+			server := newTestBatchServer(t, test.batchStatus, test.batchBody, &requests)
+			c, err := conn.New(ctx, server.URL)
+			require.NoError(t, err)
+			atomic.StoreInt32(&requests, 0)
+
+			results := make([]hexutil.Uint64, test.elems)
+			batch := make([]rpc.BatchElem, test.elems)
+			for i := range batch {
+				batch[i] = rpc.BatchElem{
+					Method: "eth_getTransactionCount",
+					Args:   []interface{}{hexutil.Uint64(i)},
+					Result: &results[i],
+				}
+			}
+			err = c.BatchCallContext(ctx, batch)
+			require.Equal(t, test.requests, atomic.LoadInt32(&requests))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			for i := range batch {
+				if i == 7 {
+					require.Error(t, batch[i].Error)
+					continue
+				}
+				require.NoError(t, batch[i].Error)
+				require.Equal(t, hexutil.Uint64(i*2), results[i])
+			}
+		})
+	}
+}
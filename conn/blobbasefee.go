@@ -0,0 +1,74 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-string2eth"
+)
+
+// BlobBaseFee returns the base fee per blob gas of the next block.
+func (c *Conn) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	if c.client == nil {
+		return nil, errors.New("cannot obtain blob base fee when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var fee hexutil.Big
+	if err := c.rpcClient.CallContext(ctx, &fee, "eth_blobBaseFee"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain blob base fee; chain or node might not support EIP-4844")
+	}
+	return fee.ToInt(), nil
+}
+
+// CalculateBlobFee calculates the max fee per blob gas for a blob transaction.  If the max fee is
+// not supplied it is set to twice the current blob base fee.
+func (c *Conn) CalculateBlobFee(ctx context.Context) (*big.Int, error) {
+	var maxFeePerBlobGas *big.Int
+	if viper.GetString("max-fee-per-blob-gas") != "" {
+		var err error
+		maxFeePerBlobGas, err = string2eth.StringToWei(viper.GetString("max-fee-per-blob-gas"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain max fee per blob gas")
+		}
+	}
+	if c.client == nil {
+		if maxFeePerBlobGas == nil {
+			return nil, errors.New("max fee per blob gas is required when offline")
+		}
+		return maxFeePerBlobGas, nil
+	}
+
+	blobBaseFee, err := c.BlobBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if maxFeePerBlobGas == nil {
+		// Default max fee per blob gas allows for the blob base fee to double.
+		return new(big.Int).Mul(blobBaseFee, big.NewInt(2)), nil
+	}
+
+	if blobBaseFee.Cmp(maxFeePerBlobGas) > 0 {
+		return nil, fmt.Errorf("blob base fee %s is higher than specified maximum (%s); increase with --max-fee-per-blob-gas if you are sure you want to do this", string2eth.WeiToGWeiString(blobBaseFee), string2eth.WeiToGWeiString(maxFeePerBlobGas))
+	}
+	return maxFeePerBlobGas, nil
+}
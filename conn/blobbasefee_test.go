@@ -0,0 +1,77 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlobBaseFeeService struct {
+	fee *big.Int
+}
+
+func (s *testBlobBaseFeeService) BlobBaseFee() *hexutil.Big {
+	return (*hexutil.Big)(s.fee)
+}
+
+func TestCalculateBlobFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxFee  string
+		blobFee *big.Int
+		err     string
+	}{
+		{
+			name:    "Default",
+			blobFee: big.NewInt(2000000000),
+		},
+		{
+			name:    "Max",
+			maxFee:  "3 gwei",
+			blobFee: big.NewInt(3000000000),
+		},
+		{
+			name:   "MaxTooLow",
+			maxFee: "0.5 gwei",
+			err:    "blob base fee 1 GWei is higher than specified maximum (0.5 GWei); increase with --max-fee-per-blob-gas if you are sure you want to do this",
+		},
+		{
+			name:   "MaxInvalid",
+			maxFee: "bad",
+			err:    "failed to obtain max fee per blob gas: failed to parse numeric value of  bad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("max-fee-per-blob-gas", test.maxFee)
+			defer viper.Set("max-fee-per-blob-gas", nil)
+			c := newTestConn(t, &testBlobBaseFeeService{fee: big.NewInt(1000000000)})
+
+			blobFee, err := c.CalculateBlobFee(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.blobFee, blobFee)
+		})
+	}
+}
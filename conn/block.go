@@ -0,0 +1,270 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Block is a block.  Fields introduced by later forks are nil if the block predates the fork.
+type Block struct {
+	Number                uint64              `json:"number"`
+	Hash                  common.Hash         `json:"hash"`
+	ParentHash            common.Hash         `json:"parent_hash"`
+	StateRoot             common.Hash         `json:"state_root"`
+	Timestamp             time.Time           `json:"timestamp"`
+	Miner                 common.Address      `json:"miner"`
+	ExtraData             hexutil.Bytes       `json:"extra_data"`
+	Difficulty            *big.Int            `json:"difficulty"`
+	TotalDifficulty       *big.Int            `json:"total_difficulty,omitempty"`
+	GasUsed               uint64              `json:"gas_used"`
+	GasLimit              uint64              `json:"gas_limit"`
+	BaseFeePerGas         *big.Int            `json:"base_fee_per_gas,omitempty"`
+	WithdrawalsRoot       *common.Hash        `json:"withdrawals_root,omitempty"`
+	Withdrawals           []*Withdrawal       `json:"withdrawals,omitempty"`
+	BlobGasUsed           *uint64             `json:"blob_gas_used,omitempty"`
+	ExcessBlobGas         *uint64             `json:"excess_blob_gas,omitempty"`
+	ParentBeaconBlockRoot *common.Hash        `json:"parent_beacon_block_root,omitempty"`
+	Uncles                []common.Hash       `json:"uncles"`
+	TransactionHashes     []common.Hash       `json:"transaction_hashes,omitempty"`
+	Transactions          []*BlockTransaction `json:"transactions,omitempty"`
+}
+
+// BlockTransaction is a summary of a transaction in a block.
+type BlockTransaction struct {
+	Hash  common.Hash     `json:"hash"`
+	Type  uint64          `json:"type"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Nonce uint64          `json:"nonce"`
+	Value *big.Int        `json:"value"`
+	Gas   uint64          `json:"gas"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// Withdrawal is a withdrawal from the consensus layer.
+type Withdrawal struct {
+	Index          uint64         `json:"index"`
+	ValidatorIndex uint64         `json:"validator_index"`
+	Address        common.Address `json:"address"`
+	// Amount is in Wei.
+	Amount *big.Int `json:"amount"`
+}
+
+type blockJSON struct {
+	Number                hexutil.Uint64    `json:"number"`
+	Hash                  common.Hash       `json:"hash"`
+	ParentHash            common.Hash       `json:"parentHash"`
+	StateRoot             common.Hash       `json:"stateRoot"`
+	Timestamp             hexutil.Uint64    `json:"timestamp"`
+	Miner                 common.Address    `json:"miner"`
+	ExtraData             hexutil.Bytes     `json:"extraData"`
+	Difficulty            *hexutil.Big      `json:"difficulty"`
+	TotalDifficulty       *hexutil.Big      `json:"totalDifficulty"`
+	GasUsed               hexutil.Uint64    `json:"gasUsed"`
+	GasLimit              hexutil.Uint64    `json:"gasLimit"`
+	BaseFeePerGas         *hexutil.Big      `json:"baseFeePerGas"`
+	WithdrawalsRoot       *common.Hash      `json:"withdrawalsRoot"`
+	Withdrawals           []*withdrawalJSON `json:"withdrawals"`
+	BlobGasUsed           *hexutil.Uint64   `json:"blobGasUsed"`
+	ExcessBlobGas         *hexutil.Uint64   `json:"excessBlobGas"`
+	ParentBeaconBlockRoot *common.Hash      `json:"parentBeaconBlockRoot"`
+	Uncles                []common.Hash     `json:"uncles"`
+	Transactions          []json.RawMessage `json:"transactions"`
+}
+
+type blockTransactionJSON struct {
+	Hash  common.Hash     `json:"hash"`
+	Type  hexutil.Uint64  `json:"type"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Nonce hexutil.Uint64  `json:"nonce"`
+	Value *hexutil.Big    `json:"value"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+type withdrawalJSON struct {
+	Index          hexutil.Uint64 `json:"index"`
+	ValidatorIndex hexutil.Uint64 `json:"validatorIndex"`
+	Address        common.Address `json:"address"`
+	// Amount is in Gwei.
+	Amount hexutil.Uint64 `json:"amount"`
+}
+
+// Block returns the block with the given ID, which can be a block number in decimal or hex,
+// a block hash, or one of the tags "latest", "earliest", "pending", "safe" or "finalized".
+// If transactions is true summaries of the block's transactions are returned, otherwise just
+// their hashes.
+// Blocks are decoded directly rather than with the client so that fields from forks that it
+// does not know about are available.
+func (c *Conn) Block(ctx context.Context, id string, transactions bool) (*Block, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain block when offline")
+	}
+
+	method := "eth_getBlockByNumber"
+	id = strings.ToLower(strings.TrimSpace(id))
+	switch {
+	case id == "":
+		id = "latest"
+	case id == "latest" || id == "earliest" || id == "pending" || id == "safe" || id == "finalized":
+	case strings.HasPrefix(id, "0x") && len(id) == 66:
+		method = "eth_getBlockByHash"
+	case strings.HasPrefix(id, "0x"):
+		number, err := hexutil.DecodeUint64(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid block %s", id)
+		}
+		id = hexutil.EncodeUint64(number)
+	default:
+		number, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid block %s", id)
+		}
+		id = hexutil.EncodeUint64(number)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res *blockJSON
+	if err := c.rpcClient.CallContext(ctx, &res, method, id, transactions); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block")
+	}
+	if res == nil {
+		return nil, errors.New("block not found")
+	}
+	return res.block(transactions)
+}
+
+// block converts the JSON representation of a block.
+func (b *blockJSON) block(transactions bool) (*Block, error) {
+	block := &Block{
+		Number:                uint64(b.Number),
+		Hash:                  b.Hash,
+		ParentHash:            b.ParentHash,
+		StateRoot:             b.StateRoot,
+		Timestamp:             time.Unix(int64(b.Timestamp), 0),
+		Miner:                 b.Miner,
+		ExtraData:             b.ExtraData,
+		Difficulty:            b.Difficulty.ToInt(),
+		GasUsed:               uint64(b.GasUsed),
+		GasLimit:              uint64(b.GasLimit),
+		WithdrawalsRoot:       b.WithdrawalsRoot,
+		BlobGasUsed:           (*uint64)(b.BlobGasUsed),
+		ExcessBlobGas:         (*uint64)(b.ExcessBlobGas),
+		ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
+		Uncles:                b.Uncles,
+	}
+	if b.TotalDifficulty != nil {
+		block.TotalDifficulty = b.TotalDifficulty.ToInt()
+	}
+	if b.BaseFeePerGas != nil {
+		block.BaseFeePerGas = b.BaseFeePerGas.ToInt()
+	}
+	if b.WithdrawalsRoot != nil {
+		block.Withdrawals = make([]*Withdrawal, len(b.Withdrawals))
+		for i, withdrawal := range b.Withdrawals {
+			block.Withdrawals[i] = &Withdrawal{
+				Index:          uint64(withdrawal.Index),
+				ValidatorIndex: uint64(withdrawal.ValidatorIndex),
+				Address:        withdrawal.Address,
+				Amount:         new(big.Int).Mul(new(big.Int).SetUint64(uint64(withdrawal.Amount)), big.NewInt(1e9)),
+			}
+		}
+	}
+
+	block.TransactionHashes = make([]common.Hash, len(b.Transactions))
+	if transactions {
+		block.Transactions = make([]*BlockTransaction, len(b.Transactions))
+	}
+	for i := range b.Transactions {
+		if !transactions {
+			if err := json.Unmarshal(b.Transactions[i], &block.TransactionHashes[i]); err != nil {
+				return nil, errors.Wrap(err, "invalid transaction hash")
+			}
+			continue
+		}
+		var tx blockTransactionJSON
+		if err := json.Unmarshal(b.Transactions[i], &tx); err != nil {
+			return nil, errors.Wrap(err, "invalid transaction")
+		}
+		block.TransactionHashes[i] = tx.Hash
+		block.Transactions[i] = &BlockTransaction{
+			Hash:  tx.Hash,
+			Type:  uint64(tx.Type),
+			From:  tx.From,
+			To:    tx.To,
+			Nonce: uint64(tx.Nonce),
+			Value: tx.Value.ToInt(),
+			Gas:   uint64(tx.Gas),
+			Input: tx.Input,
+		}
+	}
+	return block, nil
+}
+
+// Uncles returns the headers of the uncles of the given block.
+// Uncles do not contain transactions.
+func (c *Conn) Uncles(ctx context.Context, block *Block) ([]*Block, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain uncles when offline")
+	}
+	if len(block.Uncles) == 0 {
+		return []*Block{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res := make([]*blockJSON, len(block.Uncles))
+	batch := make([]rpc.BatchElem, len(block.Uncles))
+	for i := range block.Uncles {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getUncleByBlockHashAndIndex",
+			Args:   []interface{}{block.Hash, hexutil.EncodeUint64(uint64(i))},
+			Result: &res[i],
+		}
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain uncles")
+	}
+
+	uncles := make([]*Block, len(block.Uncles))
+	for i := range batch {
+		if batch[i].Error != nil {
+			return nil, errors.Wrapf(batch[i].Error, "failed to obtain uncle %d", i)
+		}
+		if res[i] == nil {
+			return nil, errors.Errorf("uncle %d not found", i)
+		}
+		uncle, err := res[i].block(false)
+		if err != nil {
+			return nil, err
+		}
+		uncles[i] = uncle
+	}
+	return uncles, nil
+}
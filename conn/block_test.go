@@ -0,0 +1,191 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+var (
+	testBlockHash = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	testTxHash    = common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+)
+
+type testBlockService struct {
+	// requests are the block IDs requested.
+	requests []string
+}
+
+func (s *testBlockService) GetBlockByNumber(number string, full bool) map[string]interface{} {
+	s.requests = append(s.requests, number)
+	if number == "0x2" {
+		return nil
+	}
+	return testBlock(full)
+}
+
+func (s *testBlockService) GetBlockByHash(hash common.Hash, full bool) map[string]interface{} {
+	s.requests = append(s.requests, hash.Hex())
+	return testBlock(full)
+}
+
+func (s *testBlockService) GetUncleByBlockHashAndIndex(hash common.Hash, index hexutil.Uint64) map[string]interface{} {
+	if hash != testBlockHash || index > 1 {
+		return nil
+	}
+	return map[string]interface{}{
+		"number":       "0x1",
+		"hash":         common.BigToHash(big.NewInt(int64(index) + 1)),
+		"parentHash":   "0x3333333333333333333333333333333333333333333333333333333333333333",
+		"timestamp":    "0x5f5e1000",
+		"miner":        "0x2b5ad5c4795c026514f8317c7a215e218dccd6cf",
+		"extraData":    "0x",
+		"difficulty":   "0x1000",
+		"gasUsed":      "0x0",
+		"gasLimit":     "0x1c9c380",
+		"uncles":       []interface{}{},
+		"transactions": []interface{}{},
+	}
+}
+
+func testBlock(full bool) map[string]interface{} {
+	var tx interface{} = testTxHash
+	if full {
+		tx = map[string]interface{}{
+			"hash":  testTxHash,
+			"type":  "0x3",
+			"from":  "0x5ffc014343cd971b7eb70732021e26c35b744cc4",
+			"to":    "0x2b5ad5c4795c026514f8317c7a215e218dccd6cf",
+			"nonce": "0x5",
+			"value": "0xde0b6b3a7640000",
+			"gas":   "0x5208",
+			"input": "0xa9059cbb",
+		}
+	}
+	return map[string]interface{}{
+		"number":          "0x1",
+		"hash":            testBlockHash,
+		"parentHash":      "0x3333333333333333333333333333333333333333333333333333333333333333",
+		"stateRoot":       "0x6666666666666666666666666666666666666666666666666666666666666666",
+		"timestamp":       "0x5f5e1000",
+		"miner":           "0x5ffc014343cd971b7eb70732021e26c35b744cc4",
+		"extraData":       "0x",
+		"difficulty":      "0x0",
+		"gasUsed":         "0x5208",
+		"gasLimit":        "0x1c9c380",
+		"baseFeePerGas":   "0x3b9aca00",
+		"withdrawalsRoot": "0x4444444444444444444444444444444444444444444444444444444444444444",
+		"withdrawals": []interface{}{
+			map[string]interface{}{
+				"index":          "0x10",
+				"validatorIndex": "0x20",
+				"address":        "0x5ffc014343cd971b7eb70732021e26c35b744cc4",
+				"amount":         "0x1c9c380",
+			},
+			map[string]interface{}{
+				"index":          "0x11",
+				"validatorIndex": "0x21",
+				"address":        "0x2b5ad5c4795c026514f8317c7a215e218dccd6cf",
+				"amount":         "0x773594000",
+			},
+		},
+		"blobGasUsed":           "0x20000",
+		"excessBlobGas":         "0x0",
+		"parentBeaconBlockRoot": "0x5555555555555555555555555555555555555555555555555555555555555555",
+		"uncles":                []interface{}{},
+		"transactions":          []interface{}{tx},
+	}
+}
+
+func TestBlock(t *testing.T) {
+	ctx := context.Background()
+
+	service := &testBlockService{}
+	c := newTestConn(t, service)
+
+	block, err := c.Block(ctx, "", false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), block.Number)
+	require.Equal(t, testBlockHash, block.Hash)
+	require.Equal(t, common.HexToHash("0x6666666666666666666666666666666666666666666666666666666666666666"), block.StateRoot)
+	require.Equal(t, int64(1600000000), block.Timestamp.Unix())
+	require.Equal(t, uint64(21000), block.GasUsed)
+	require.Equal(t, big.NewInt(1000000000), block.BaseFeePerGas)
+	require.Nil(t, block.TotalDifficulty)
+	require.Equal(t, common.HexToHash("0x4444444444444444444444444444444444444444444444444444444444444444"), *block.WithdrawalsRoot)
+	require.Len(t, block.Withdrawals, 2)
+	require.Equal(t, uint64(16), block.Withdrawals[0].Index)
+	require.Equal(t, uint64(32), block.Withdrawals[0].ValidatorIndex)
+	require.Equal(t, common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), block.Withdrawals[0].Address)
+	require.Equal(t, big.NewInt(30000000000000000), block.Withdrawals[0].Amount)
+	require.Equal(t, "32000000000000000000", block.Withdrawals[1].Amount.String())
+	require.Equal(t, uint64(131072), *block.BlobGasUsed)
+	require.Equal(t, []common.Hash{testTxHash}, block.TransactionHashes)
+	require.Nil(t, block.Transactions)
+
+	block, err = c.Block(ctx, testBlockHash.Hex(), true)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions, 1)
+	tx := block.Transactions[0]
+	require.Equal(t, testTxHash, tx.Hash)
+	require.Equal(t, uint64(3), tx.Type)
+	require.Equal(t, common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"), *tx.To)
+	require.Equal(t, big.NewInt(1000000000000000000), tx.Value)
+	require.Equal(t, hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb}, tx.Input)
+
+	_, err = c.Block(ctx, "10", false)
+	require.NoError(t, err)
+	_, err = c.Block(ctx, "finalized", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"latest", testBlockHash.Hex(), "0xa", "finalized"}, service.requests)
+
+	_, err = c.Block(ctx, "2", false)
+	require.EqualError(t, err, "block not found")
+
+	_, err = c.Block(ctx, "bad", false)
+	require.Error(t, err)
+}
+
+func TestUncles(t *testing.T) {
+	ctx := context.Background()
+
+	c := newTestConn(t, &testBlockService{})
+
+	uncles, err := c.Uncles(ctx, &conn.Block{Hash: testBlockHash})
+	require.NoError(t, err)
+	require.Empty(t, uncles)
+
+	uncles, err = c.Uncles(ctx, &conn.Block{
+		Hash:   testBlockHash,
+		Uncles: []common.Hash{common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))},
+	})
+	require.NoError(t, err)
+	require.Len(t, uncles, 2)
+	require.Equal(t, common.BigToHash(big.NewInt(2)), uncles[1].Hash)
+	require.Equal(t, common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"), uncles[1].Miner)
+	require.Equal(t, big.NewInt(4096), uncles[1].Difficulty)
+
+	_, err = c.Uncles(ctx, &conn.Block{
+		Hash:   testBlockHash,
+		Uncles: []common.Hash{{}, {}, {}},
+	})
+	require.EqualError(t, err, "uncle 2 not found")
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// BlockReceipt is a summary of the receipt of a transaction in a block.
+type BlockReceipt struct {
+	TransactionHash common.Hash     `json:"transaction_hash"`
+	Type            uint64          `json:"type"`
+	From            common.Address  `json:"from"`
+	To              *common.Address `json:"to,omitempty"`
+	ContractAddress *common.Address `json:"contract_address,omitempty"`
+	GasUsed         uint64          `json:"gas_used"`
+	Status          uint64          `json:"status"`
+}
+
+type blockReceiptJSON struct {
+	TransactionHash common.Hash     `json:"transactionHash"`
+	Type            hexutil.Uint64  `json:"type"`
+	From            common.Address  `json:"from"`
+	To              *common.Address `json:"to"`
+	ContractAddress *common.Address `json:"contractAddress"`
+	GasUsed         hexutil.Uint64  `json:"gasUsed"`
+	Status          hexutil.Uint64  `json:"status"`
+}
+
+// BlockReceipts returns summaries of the receipts for the transactions in the block.
+// If the node does not support eth_getBlockReceipts the receipts are requested individually
+// in batches.
+func (c *Conn) BlockReceipts(ctx context.Context, block *Block) ([]*BlockReceipt, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain receipts when offline")
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var receipts []*blockReceiptJSON
+	err := c.rpcClient.CallContext(callCtx, &receipts, "eth_getBlockReceipts", hexutil.EncodeUint64(block.Number))
+	if err != nil {
+		var rpcErr rpc.Error
+		if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+			return nil, errors.Wrap(err, "failed to obtain block receipts")
+		}
+		// Method not found, so fall back to obtaining individual receipts.
+		receipts = make([]*blockReceiptJSON, len(block.TransactionHashes))
+		batch := make([]rpc.BatchElem, len(block.TransactionHashes))
+		for i := range block.TransactionHashes {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{block.TransactionHashes[i]},
+				Result: &receipts[i],
+			}
+		}
+		if err := c.BatchCallContext(ctx, batch); err != nil {
+			return nil, errors.Wrap(err, "failed to obtain receipts")
+		}
+		for i := range batch {
+			if batch[i].Error != nil {
+				return nil, errors.Wrapf(batch[i].Error, "failed to obtain receipt for %s", block.TransactionHashes[i].Hex())
+			}
+		}
+	}
+
+	res := make([]*BlockReceipt, len(receipts))
+	for i, receipt := range receipts {
+		if receipt == nil {
+			return nil, errors.New("receipt not found")
+		}
+		res[i] = &BlockReceipt{
+			TransactionHash: receipt.TransactionHash,
+			Type:            uint64(receipt.Type),
+			From:            receipt.From,
+			To:              receipt.To,
+			ContractAddress: receipt.ContractAddress,
+			GasUsed:         uint64(receipt.GasUsed),
+			Status:          uint64(receipt.Status),
+		}
+	}
+	return res, nil
+}
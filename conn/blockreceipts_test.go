@@ -0,0 +1,95 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testReceiptService struct{}
+
+func (s *testReceiptService) GetTransactionReceipt(hash common.Hash) map[string]interface{} {
+	if hash != testTxHash {
+		return nil
+	}
+	return map[string]interface{}{
+		"transactionHash": testTxHash,
+		"type":            "0x2",
+		"from":            "0x5ffc014343cd971b7eb70732021e26c35b744cc4",
+		"to":              "0x2b5ad5c4795c026514f8317c7a215e218dccd6cf",
+		"contractAddress": nil,
+		"gasUsed":         "0xb411",
+		"status":          "0x1",
+	}
+}
+
+type testBlockReceiptService struct {
+	testReceiptService
+}
+
+func (s *testBlockReceiptService) GetBlockReceipts(number string) []map[string]interface{} {
+	return []map[string]interface{}{s.GetTransactionReceipt(testTxHash)}
+}
+
+func TestBlockReceipts(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		service interface{}
+		hashes  []common.Hash
+		err     string
+	}{
+		{
+			name:    "BlockReceipts",
+			service: &testBlockReceiptService{},
+			hashes:  []common.Hash{testTxHash},
+		},
+		{
+			name:    "Fallback",
+			service: &testReceiptService{},
+			hashes:  []common.Hash{testTxHash},
+		},
+		{
+			name:    "FallbackMissing",
+			service: &testReceiptService{},
+			hashes:  []common.Hash{testBlockHash},
+			err:     "receipt not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConn(t, test.service)
+
+			receipts, err := c.BlockReceipts(ctx, &conn.Block{Number: 1, TransactionHashes: test.hashes})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, receipts, 1)
+			require.Equal(t, testTxHash, receipts[0].TransactionHash)
+			require.Equal(t, uint64(2), receipts[0].Type)
+			require.Equal(t, uint64(46097), receipts[0].GasUsed)
+			require.Equal(t, common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"), *receipts[0].To)
+			require.Nil(t, receipts[0].ContractAddress)
+		})
+	}
+}
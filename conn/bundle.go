@@ -0,0 +1,199 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// BundleSimulation is the result of simulating a bundle of transactions.
+type BundleSimulation struct {
+	BundleHash        common.Hash
+	BundleGasPrice    *big.Int
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	GasFees           *big.Int
+	TotalGasUsed      uint64
+	StateBlockNumber  uint64
+	Results           []*BundleTransactionResult
+}
+
+// BundleTransactionResult is the result of simulating a single transaction in a bundle.
+type BundleTransactionResult struct {
+	TxHash            common.Hash
+	From              common.Address
+	To                *common.Address
+	GasUsed           uint64
+	GasPrice          *big.Int
+	GasFees           *big.Int
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	// Error is set if the transaction failed.
+	Error string
+	// Revert is the revert reason of the transaction, if supplied.
+	Revert string
+}
+
+type bundleSimulationJSON struct {
+	BundleHash        common.Hash                    `json:"bundleHash"`
+	BundleGasPrice    string                         `json:"bundleGasPrice"`
+	CoinbaseDiff      string                         `json:"coinbaseDiff"`
+	EthSentToCoinbase string                         `json:"ethSentToCoinbase"`
+	GasFees           string                         `json:"gasFees"`
+	TotalGasUsed      uint64                         `json:"totalGasUsed"`
+	StateBlockNumber  uint64                         `json:"stateBlockNumber"`
+	Results           []*bundleTransactionResultJSON `json:"results"`
+}
+
+type bundleTransactionResultJSON struct {
+	TxHash            common.Hash     `json:"txHash"`
+	FromAddress       common.Address  `json:"fromAddress"`
+	ToAddress         *common.Address `json:"toAddress"`
+	GasUsed           uint64          `json:"gasUsed"`
+	GasPrice          string          `json:"gasPrice"`
+	GasFees           string          `json:"gasFees"`
+	CoinbaseDiff      string          `json:"coinbaseDiff"`
+	EthSentToCoinbase string          `json:"ethSentToCoinbase"`
+	Error             string          `json:"error"`
+	Revert            string          `json:"revert"`
+}
+
+// bundleParams returns the parameters common to bundle requests.
+func bundleParams(txs []*types.Transaction, blockNumber uint64) (map[string]interface{}, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions in bundle")
+	}
+	rawTxs := make([]string, len(txs))
+	for i, tx := range txs {
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode transaction")
+		}
+		rawTxs[i] = hexutil.Encode(data)
+	}
+	return map[string]interface{}{
+		"txs":         rawTxs,
+		"blockNumber": hexutil.EncodeUint64(blockNumber),
+	}, nil
+}
+
+// SimulateBundle simulates a bundle of transactions with the relay at the given URL, as if
+// it were included in the given block on top of the state of the current block.
+func (c *Conn) SimulateBundle(ctx context.Context,
+	relayURL string,
+	txs []*types.Transaction,
+	blockNumber uint64,
+) (
+	*BundleSimulation,
+	error,
+) {
+	params, err := bundleParams(txs, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	params["stateBlockNumber"] = "latest"
+
+	var res bundleSimulationJSON
+	if err := c.callRelay(ctx, relayURL, &res, "eth_callBundle", params); err != nil {
+		return nil, errors.Wrap(err, "failed to simulate bundle")
+	}
+
+	simulation := &BundleSimulation{
+		BundleHash:        res.BundleHash,
+		BundleGasPrice:    decimalToBig(res.BundleGasPrice),
+		CoinbaseDiff:      decimalToBig(res.CoinbaseDiff),
+		EthSentToCoinbase: decimalToBig(res.EthSentToCoinbase),
+		GasFees:           decimalToBig(res.GasFees),
+		TotalGasUsed:      res.TotalGasUsed,
+		StateBlockNumber:  res.StateBlockNumber,
+		Results:           make([]*BundleTransactionResult, len(res.Results)),
+	}
+	for i, result := range res.Results {
+		simulation.Results[i] = &BundleTransactionResult{
+			TxHash:            result.TxHash,
+			From:              result.FromAddress,
+			To:                result.ToAddress,
+			GasUsed:           result.GasUsed,
+			GasPrice:          decimalToBig(result.GasPrice),
+			GasFees:           decimalToBig(result.GasFees),
+			CoinbaseDiff:      decimalToBig(result.CoinbaseDiff),
+			EthSentToCoinbase: decimalToBig(result.EthSentToCoinbase),
+			Error:             result.Error,
+			Revert:            result.Revert,
+		}
+	}
+	return simulation, nil
+}
+
+// SendBundle sends a bundle of transactions to the relay at the given URL for inclusion in
+// the given block, returning the hash of the bundle.
+func (c *Conn) SendBundle(ctx context.Context,
+	relayURL string,
+	txs []*types.Transaction,
+	blockNumber uint64,
+) (
+	common.Hash,
+	error,
+) {
+	if c.dryRun {
+		return common.Hash{}, ErrDryRun
+	}
+	params, err := bundleParams(txs, blockNumber)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	var res struct {
+		BundleHash common.Hash `json:"bundleHash"`
+	}
+	if err := c.callRelay(ctx, relayURL, &res, "eth_sendBundle", params); err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to send bundle")
+	}
+	return res.BundleHash, nil
+}
+
+// callRelay calls a method on the relay at the given URL.
+func (c *Conn) callRelay(ctx context.Context, relayURL string, result interface{}, method string, args ...interface{}) error {
+	if c.client == nil {
+		return errors.New("cannot contact relay when offline")
+	}
+	relay, err := newRelay(relayURL)
+	if err != nil {
+		return err
+	}
+	rpcClient, err := relay.dial()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to relay")
+	}
+	defer rpcClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return rpcClient.CallContext(ctx, result, method, args...)
+}
+
+// decimalToBig converts a decimal string to a big integer, returning 0 if it is not valid.
+func decimalToBig(input string) *big.Int {
+	res, success := new(big.Int).SetString(input, 10)
+	if !success {
+		return big.NewInt(0)
+	}
+	return res
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testBundleService struct {
+	params map[string]interface{}
+}
+
+func (s *testBundleService) CallBundle(params map[string]interface{}) map[string]interface{} {
+	s.params = params
+	return map[string]interface{}{
+		"bundleHash":        common.HexToHash("0x01"),
+		"bundleGasPrice":    "2000000000",
+		"coinbaseDiff":      "142000000000000",
+		"ethSentToCoinbase": "100000000000000",
+		"gasFees":           "42000000000000",
+		"totalGasUsed":      21000,
+		"stateBlockNumber":  100,
+		"results": []map[string]interface{}{
+			{
+				"txHash":            common.HexToHash("0x02"),
+				"fromAddress":       common.HexToAddress("0x03"),
+				"toAddress":         common.HexToAddress("0x04"),
+				"gasUsed":           21000,
+				"gasPrice":          "2000000000",
+				"gasFees":           "42000000000000",
+				"coinbaseDiff":      "142000000000000",
+				"ethSentToCoinbase": "100000000000000",
+				"revert":            "insufficient balance",
+			},
+		},
+	}
+}
+
+func (s *testBundleService) SendBundle(params map[string]interface{}) map[string]interface{} {
+	s.params = params
+	return map[string]interface{}{
+		"bundleHash": common.HexToHash("0x01"),
+	}
+}
+
+func newTestBundleRelay(t *testing.T) (*httptest.Server, *testBundleService) {
+	service := &testBundleService{}
+	server := newTestServer(t, map[string]interface{}{"eth": service})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Flashbots-Signature") == "" {
+			http.Error(w, "missing signature", http.StatusUnauthorized)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer, service
+}
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+
+	relay, service := newTestBundleRelay(t)
+	endpoint := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, endpoint.server.URL)
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	rawTx, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	simulation, err := c.SimulateBundle(ctx, relay.URL, []*types.Transaction{tx}, 101)
+	require.NoError(t, err)
+	require.Equal(t, "0x65", service.params["blockNumber"])
+	require.Equal(t, "latest", service.params["stateBlockNumber"])
+	require.Equal(t, []interface{}{hexutil.Encode(rawTx)}, service.params["txs"])
+	require.Equal(t, common.HexToHash("0x01"), simulation.BundleHash)
+	require.Equal(t, big.NewInt(142000000000000), simulation.CoinbaseDiff)
+	require.Equal(t, big.NewInt(100000000000000), simulation.EthSentToCoinbase)
+	require.Equal(t, uint64(21000), simulation.TotalGasUsed)
+	require.Len(t, simulation.Results, 1)
+	require.Equal(t, common.HexToAddress("0x03"), simulation.Results[0].From)
+	require.Equal(t, common.HexToAddress("0x04"), *simulation.Results[0].To)
+	require.Equal(t, big.NewInt(2000000000), simulation.Results[0].GasPrice)
+	require.Equal(t, "insufficient balance", simulation.Results[0].Revert)
+
+	hash, err := c.SendBundle(ctx, relay.URL, []*types.Transaction{tx}, 102)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x01"), hash)
+	require.Equal(t, "0x66", service.params["blockNumber"])
+
+	_, err = c.SendBundle(ctx, relay.URL, []*types.Transaction{}, 102)
+	require.Error(t, err)
+}
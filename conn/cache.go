@@ -0,0 +1,198 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// callCache caches the results of calls on the filesystem.
+type callCache struct {
+	dir string
+	// ttl is the time for which the results of calls against a block tag such as "latest" are
+	// cached.  Results of calls against a specific block do not expire.
+	ttl time.Duration
+	// chainID is the chain ID of the connection, set once it is known.
+	chainID *big.Int
+}
+
+// cachedCall is the result of a call stored in the cache.
+type cachedCall struct {
+	Result    json.RawMessage `json:"result"`
+	Timestamp time.Time       `json:"timestamp"`
+	// Final is set if the call was made against a specific block, so its result does not expire.
+	Final bool `json:"final"`
+}
+
+// newCallCache creates a call cache if one is configured with cache, otherwise nil.
+func newCallCache() (*callCache, error) {
+	if !viper.GetBool("cache") || viper.GetBool("no-cache") {
+		return nil, nil
+	}
+	dir := viper.GetString("cache-dir")
+	if dir == "" {
+		return nil, errors.New("cache directory not specified")
+	}
+	ttl := viper.GetDuration("cache-ttl")
+	if ttl < 0 {
+		return nil, fmt.Errorf("invalid cache TTL %v", ttl)
+	}
+	return &callCache{
+		dir: dir,
+		ttl: ttl,
+	}, nil
+}
+
+// path returns the path of the cache entry for the call with the given parameters.
+func (c *callCache) path(params json.RawMessage) string {
+	return filepath.Join(c.dir, c.chainID.String(), fmt.Sprintf("%x.json", crypto.Keccak256(params)))
+}
+
+// get returns the cached result of the call with the given parameters, or nil if there is none.
+func (c *callCache) get(params json.RawMessage) json.RawMessage {
+	data, err := ioutil.ReadFile(c.path(params))
+	if err != nil {
+		return nil
+	}
+	cached := &cachedCall{}
+	if err := json.Unmarshal(data, cached); err != nil {
+		// An unreadable entry is treated as missing.
+		return nil
+	}
+	if !cached.Final && time.Since(cached.Timestamp) >= c.ttl {
+		return nil
+	}
+	return cached.Result
+}
+
+// put stores the result of the call with the given parameters.
+func (c *callCache) put(params json.RawMessage, result json.RawMessage) {
+	final := finalCall(params)
+	if !final && c.ttl == 0 {
+		return
+	}
+	data, err := json.Marshal(&cachedCall{
+		Result:    result,
+		Timestamp: time.Now(),
+		Final:     final,
+	})
+	if err != nil {
+		return
+	}
+	// Failure to cache is not fatal.
+	path := c.path(params)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+		_ = ioutil.WriteFile(path, data, 0600)
+	}
+}
+
+// finalCall returns true if the call with the given parameters is made against a specific
+// block, rather than a tag such as "latest" whose state changes over time.
+func finalCall(params json.RawMessage) bool {
+	args := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(params, &args); err != nil || len(args) < 2 {
+		return false
+	}
+	var tag string
+	if err := json.Unmarshal(args[1], &tag); err != nil {
+		// An object selecting the block by hash or number.
+		return true
+	}
+	return strings.HasPrefix(tag, "0x")
+}
+
+// cacheTransport is an HTTP transport that serves calls from the call cache where possible,
+// and stores the results of other calls in the cache.
+type cacheTransport struct {
+	next  http.RoundTripper
+	cache *callCache
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.cache.chainID == nil {
+		return t.next.RoundTrip(req)
+	}
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := &struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}{}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || trimmed[0] != '{' {
+		// Batches are not cached.
+		return t.next.RoundTrip(req)
+	}
+	if err := json.Unmarshal(body, request); err != nil || request.Method != "eth_call" {
+		return t.next.RoundTrip(req)
+	}
+
+	if result := t.cache.get(request.Params); result != nil {
+		data, err := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         req.Proto,
+			ProtoMajor:    req.ProtoMajor,
+			ProtoMinor:    req.ProtoMinor,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          ioutil.NopCloser(bytes.NewReader(data)),
+			ContentLength: int64(len(data)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	response := &struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}{}
+	if err := json.Unmarshal(respBody, response); err == nil && (len(response.Error) == 0 || string(response.Error) == "null") && len(response.Result) > 0 {
+		t.cache.put(request.Params, response.Result)
+	}
+	return resp, nil
+}
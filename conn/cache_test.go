@@ -0,0 +1,134 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type testCacheService struct {
+	calls int
+}
+
+func (s *testCacheService) Call(args map[string]interface{}, block string) hexutil.Bytes {
+	s.calls++
+	return hexutil.Bytes{byte(s.calls)}
+}
+
+func TestCallCache(t *testing.T) {
+	ctx := context.Background()
+	defer viper.Set("cache", nil)
+	defer viper.Set("no-cache", nil)
+	defer viper.Set("cache-dir", nil)
+	defer viper.Set("cache-ttl", nil)
+
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	latest := ethereum.CallMsg{To: &to, Data: []byte{0x01}}
+	other := ethereum.CallMsg{To: &to, Data: []byte{0x02}}
+
+	tests := []struct {
+		name    string
+		cache   bool
+		noCache bool
+		ttl     time.Duration
+		// calls are the calls to make, with the block number (-1 for latest).
+		calls []int64
+		msgs  []ethereum.CallMsg
+		// results are the expected results of the calls.
+		results []byte
+		// requests is the expected number of calls that reach the server.
+		requests int
+	}{
+		{
+			name:     "Disabled",
+			calls:    []int64{-1, -1},
+			msgs:     []ethereum.CallMsg{latest, latest},
+			results:  []byte{1, 2},
+			requests: 2,
+		},
+		{
+			name:     "Latest",
+			cache:    true,
+			ttl:      time.Minute,
+			calls:    []int64{-1, -1, -1},
+			msgs:     []ethereum.CallMsg{latest, latest, other},
+			results:  []byte{1, 1, 2},
+			requests: 2,
+		},
+		{
+			name:     "NoTTL",
+			cache:    true,
+			calls:    []int64{-1, -1, 100, 100},
+			msgs:     []ethereum.CallMsg{latest, latest, latest, latest},
+			results:  []byte{1, 2, 3, 3},
+			requests: 3,
+		},
+		{
+			name:     "Block",
+			cache:    true,
+			ttl:      time.Minute,
+			calls:    []int64{100, 101, 100, -1},
+			msgs:     []ethereum.CallMsg{latest, latest, latest, latest},
+			results:  []byte{1, 2, 1, 3},
+			requests: 3,
+		},
+		{
+			name:     "NoCache",
+			cache:    true,
+			noCache:  true,
+			ttl:      time.Minute,
+			calls:    []int64{100, 100},
+			msgs:     []ethereum.CallMsg{latest, latest},
+			results:  []byte{1, 2},
+			requests: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "cache")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			service := &testCacheService{}
+			viper.Set("cache", test.cache)
+			viper.Set("no-cache", test.noCache)
+			viper.Set("cache-dir", dir)
+			viper.Set("cache-ttl", test.ttl)
+			c := newTestConn(t, service)
+
+			for i := range test.calls {
+				var block *big.Int
+				if test.calls[i] >= 0 {
+					block = big.NewInt(test.calls[i])
+				}
+				res, err := c.CallContract(ctx, test.msgs[i], block, nil)
+				require.NoError(t, err)
+				require.Equal(t, []byte{test.results[i]}, res)
+			}
+			require.Equal(t, test.requests, service.calls)
+		})
+	}
+}
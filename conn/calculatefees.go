@@ -23,46 +23,75 @@ import (
 	"github.com/wealdtech/go-string2eth"
 )
 
-// CalculateFees calculates the base and priority fees.
+// defaultPriorityFeePerGas is the priority fee per gas used if it cannot be otherwise obtained.
+var defaultPriorityFeePerGas = big.NewInt(1500000000)
+
+// CalculateFees calculates the max fee per gas and max priority fee per gas for a transaction.
+// If the priority fee is not supplied it is suggested from recent fee history, and if the max fee
+// is not supplied it is set to twice the current base fee plus the priority fee.
 func (c *Conn) CalculateFees() (*big.Int, *big.Int, error) {
-	// Set max fee per gas.
-	feePerGas, err := c.CurrentBaseFee(context.Background())
+	baseFeePerGas, err := c.CurrentBaseFee(context.Background())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to obtain current base fee")
 	}
 
+	// Set priority fee per gas.
+	var priorityFeePerGas *big.Int
+	if viper.GetString("max-priority-fee-per-gas") != "" {
+		priorityFeePerGas, err = string2eth.StringToWei(viper.GetString("max-priority-fee-per-gas"))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to obtain max priority fee per gas")
+		}
+	} else {
+		priorityFeePerGas, err = c.SuggestPriorityFee(context.Background())
+		if err != nil {
+			priorityFeePerGas = new(big.Int).Set(defaultPriorityFeePerGas)
+		}
+	}
+
+	// Default max fee per gas allows for the base fee to double.
+	feePerGas := new(big.Int).Mul(baseFeePerGas, big.NewInt(2))
+	feePerGas = feePerGas.Add(feePerGas, priorityFeePerGas)
 	if viper.GetString("max-fee-per-gas") == "" {
-		viper.Set("max-fee-per-gas", "200gwei")
+		return feePerGas, priorityFeePerGas, nil
 	}
+
 	maxFeePerGas, err := string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to obtain max fee per gas")
 	}
 
-	// Set priority fee per gas.
-	priorityFeePerGas, err := string2eth.StringToWei(viper.GetString("priority-fee-per-gas"))
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to obtain max priority fee per gas")
-	}
-
 	// Ensure that the total fee per gas does not exceed the max allowed.
-	totalFeePerGas := new(big.Int).Add(feePerGas, priorityFeePerGas)
+	totalFeePerGas := new(big.Int).Add(baseFeePerGas, priorityFeePerGas)
 	if totalFeePerGas.Cmp(maxFeePerGas) > 0 {
-		return nil, nil, fmt.Errorf("base fee %s plus priority fee %s (total %s) is higher than specified maximum (%s); increase with --max-fee-per-gas if you are sure you want to do this", string2eth.WeiToGWeiString(feePerGas), string2eth.WeiToGWeiString(priorityFeePerGas), string2eth.WeiToGWeiString(totalFeePerGas), string2eth.WeiToGWeiString(maxFeePerGas))
+		return nil, nil, fmt.Errorf("base fee %s plus priority fee %s (total %s) is higher than specified maximum (%s); increase with --max-fee-per-gas if you are sure you want to do this", string2eth.WeiToGWeiString(baseFeePerGas), string2eth.WeiToGWeiString(priorityFeePerGas), string2eth.WeiToGWeiString(totalFeePerGas), string2eth.WeiToGWeiString(maxFeePerGas))
+	}
+
+	// Do not exceed the max allowed.
+	if feePerGas.Cmp(maxFeePerGas) > 0 {
+		feePerGas = maxFeePerGas
 	}
 
-	// Try to double the base fee, but not exceed the max allowed.
-	feePerGas = new(big.Int).Mul(feePerGas, big.NewInt(2))
-	totalFeePerGas = totalFeePerGas.Add(feePerGas, priorityFeePerGas)
-	if totalFeePerGas.Cmp(maxFeePerGas) >= 0 {
-		feePerGas = feePerGas.Sub(maxFeePerGas, priorityFeePerGas)
+	return feePerGas, priorityFeePerGas, nil
+}
+
+// CalculateGasPrice calculates the gas price for a legacy transaction.  If the max fee per gas is
+// supplied it is used as an upper limit on the suggested gas price.
+func (c *Conn) CalculateGasPrice(ctx context.Context) (*big.Int, error) {
+	gasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Priority fee per gas cannot be higher than fee per gas.
-	if priorityFeePerGas.Cmp(feePerGas) > 0 {
-		// In this situation add the priority fee to the base fee.
-		feePerGas = feePerGas.Add(feePerGas, priorityFeePerGas)
+	if viper.GetString("max-fee-per-gas") != "" {
+		maxFeePerGas, err := string2eth.StringToWei(viper.GetString("max-fee-per-gas"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain max fee per gas")
+		}
+		if gasPrice.Cmp(maxFeePerGas) > 0 {
+			gasPrice = maxFeePerGas
+		}
 	}
 
-	return feePerGas, priorityFeePerGas, nil
+	return gasPrice, nil
 }
@@ -0,0 +1,102 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// OverrideAccount specifies the state of an account to override for the duration of a call.
+// Only fields that are set are overridden.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      *hexutil.Bytes              `json:"code,omitempty"`
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// CallContract executes a call against the state at the given block (nil for latest), with
+// optional state overrides.
+func (c *Conn) CallContract(ctx context.Context,
+	msg ethereum.CallMsg,
+	blockNumber *big.Int,
+	overrides map[common.Address]*OverrideAccount,
+) (
+	[]byte,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot call contract when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if len(overrides) == 0 {
+		return c.client.CallContract(ctx, msg, blockNumber)
+	}
+
+	var res hexutil.Bytes
+	if err := c.rpcClient.CallContext(ctx, &res, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber), overrides); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// toCallArg converts a call message to its JSON-RPC representation.
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
+}
+
+// toBlockNumArg converts a block number to its JSON-RPC representation.
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	if number.Cmp(big.NewInt(-1)) == 0 {
+		return "pending"
+	}
+	return hexutil.EncodeBig(number)
+}
@@ -0,0 +1,41 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+// TestOverrideAccountJSON ensures that only set fields are overridden.
+func TestOverrideAccountJSON(t *testing.T) {
+	data, err := json.Marshal(&conn.OverrideAccount{})
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(data))
+
+	data, err = json.Marshal(&conn.OverrideAccount{
+		Balance: (*hexutil.Big)(big.NewInt(1000)),
+		StateDiff: map[common.Hash]common.Hash{
+			common.HexToHash("0x01"): common.HexToHash("0x02"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"balance":"0x3e8","stateDiff":{"0x0000000000000000000000000000000000000000000000000000000000000001":"0x0000000000000000000000000000000000000000000000000000000000000002"}}`, string(data))
+}
@@ -0,0 +1,206 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+// ropstenChainConfig is the configuration of the retired Ropsten test network, which is no
+// longer provided by go-ethereum.
+var ropstenChainConfig = &params.ChainConfig{
+	ChainID:             big.NewInt(3),
+	HomesteadBlock:      big.NewInt(0),
+	EIP150Block:         big.NewInt(0),
+	EIP155Block:         big.NewInt(10),
+	EIP158Block:         big.NewInt(10),
+	ByzantiumBlock:      big.NewInt(1700000),
+	ConstantinopleBlock: big.NewInt(4230000),
+	PetersburgBlock:     big.NewInt(4939394),
+	IstanbulBlock:       big.NewInt(6485846),
+	MuirGlacierBlock:    big.NewInt(7117117),
+	BerlinBlock:         big.NewInt(9812189),
+	LondonBlock:         big.NewInt(10499401),
+	Ethash:              new(params.EthashConfig),
+}
+
+// rinkebyChainConfig is the configuration of the retired Rinkeby test network, which is no
+// longer provided by go-ethereum.
+var rinkebyChainConfig = &params.ChainConfig{
+	ChainID:             big.NewInt(4),
+	HomesteadBlock:      big.NewInt(1),
+	EIP150Block:         big.NewInt(2),
+	EIP155Block:         big.NewInt(3),
+	EIP158Block:         big.NewInt(3),
+	ByzantiumBlock:      big.NewInt(1035301),
+	ConstantinopleBlock: big.NewInt(3660663),
+	PetersburgBlock:     big.NewInt(4321234),
+	IstanbulBlock:       big.NewInt(5435345),
+	BerlinBlock:         big.NewInt(8290928),
+	LondonBlock:         big.NewInt(8897988),
+	Clique: &params.CliqueConfig{
+		Period: 15,
+		Epoch:  30000,
+	},
+}
+
+// goerliChainConfig is the configuration of the retired Goerli test network, which is no
+// longer provided by go-ethereum.
+var goerliChainConfig = &params.ChainConfig{
+	ChainID:                 big.NewInt(5),
+	HomesteadBlock:          big.NewInt(0),
+	DAOForkSupport:          true,
+	EIP150Block:             big.NewInt(0),
+	EIP155Block:             big.NewInt(0),
+	EIP158Block:             big.NewInt(0),
+	ByzantiumBlock:          big.NewInt(0),
+	ConstantinopleBlock:     big.NewInt(0),
+	PetersburgBlock:         big.NewInt(0),
+	IstanbulBlock:           big.NewInt(1561651),
+	BerlinBlock:             big.NewInt(4460644),
+	LondonBlock:             big.NewInt(5062605),
+	TerminalTotalDifficulty: big.NewInt(10790000),
+	ShanghaiTime:            timestamp(1678832736),
+	CancunTime:              timestamp(1705473120),
+	Clique: &params.CliqueConfig{
+		Period: 15,
+		Epoch:  30000,
+	},
+}
+
+// timestamp returns a pointer to the given timestamp, as used for time-based forks.
+func timestamp(t uint64) *uint64 {
+	return &t
+}
+
+// knownChains are the chains for which configuration is known.
+var knownChains = []struct {
+	name   string
+	config *params.ChainConfig
+}{
+	{name: "mainnet", config: params.MainnetChainConfig},
+	{name: "ropsten", config: ropstenChainConfig},
+	{name: "rinkeby", config: rinkebyChainConfig},
+	{name: "goerli", config: goerliChainConfig},
+	{name: "sepolia", config: params.SepoliaChainConfig},
+}
+
+// chainConfig returns the configuration for a known chain, or nil if the chain is not known.
+func chainConfig(chainID *big.Int) *params.ChainConfig {
+	if chainID == nil {
+		return nil
+	}
+	for _, chain := range knownChains {
+		if chain.config.ChainID.Cmp(chainID) == 0 {
+			return chain.config
+		}
+	}
+	return nil
+}
+
+// ChainConfig returns the configuration of the chain for the connection, or nil if it is not known.
+func (c *Conn) ChainConfig() *params.ChainConfig {
+	return c.config
+}
+
+// NetworkName returns the name of the network for the connection, or an empty string if it is not known.
+func (c *Conn) NetworkName() string {
+	for _, chain := range knownChains {
+		if chain.config == c.config {
+			return chain.name
+		}
+	}
+	return ""
+}
+
+// ClientVersion returns the version string of the client.
+func (c *Conn) ClientVersion(ctx context.Context) (string, error) {
+	if c.offline {
+		return "", errors.New("cannot obtain client version when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res string
+	if err := c.rpcClient.CallContext(ctx, &res, "web3_clientVersion"); err != nil {
+		return "", errors.Wrap(err, "failed to obtain client version")
+	}
+	return res, nil
+}
+
+// Fork is a fork as configured in the client.
+type Fork struct {
+	ActivationTime time.Time     `json:"activation_time"`
+	ForkID         hexutil.Bytes `json:"fork_id"`
+}
+
+// ForkSchedule contains the current and upcoming forks as configured in the client.
+// Next and Last are nil if there are no upcoming forks.
+type ForkSchedule struct {
+	Current *Fork `json:"current"`
+	Next    *Fork `json:"next,omitempty"`
+	Last    *Fork `json:"last,omitempty"`
+}
+
+type forkJSON struct {
+	ActivationTime hexutil.Uint64 `json:"activationTime"`
+	ForkID         hexutil.Bytes  `json:"forkId"`
+}
+
+type forkScheduleJSON struct {
+	Current *forkJSON `json:"current"`
+	Next    *forkJSON `json:"next"`
+	Last    *forkJSON `json:"last"`
+}
+
+func (f *forkJSON) fork() *Fork {
+	if f == nil {
+		return nil
+	}
+	return &Fork{
+		ActivationTime: time.Unix(int64(f.ActivationTime), 0),
+		ForkID:         f.ForkID,
+	}
+}
+
+// ForkSchedule returns the fork schedule configured in the client.
+// This requires the client to support eth_config.
+func (c *Conn) ForkSchedule(ctx context.Context) (*ForkSchedule, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain fork schedule when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res *forkScheduleJSON
+	if err := c.rpcClient.CallContext(ctx, &res, "eth_config"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	if res == nil || res.Current == nil {
+		return nil, errors.New("fork schedule not available")
+	}
+	return &ForkSchedule{
+		Current: res.Current.fork(),
+		Next:    res.Next.fork(),
+		Last:    res.Last.fork(),
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testChainService struct {
+	chainID int64
+}
+
+func (s *testChainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(s.chainID))
+}
+
+func (s *testChainService) Config() map[string]interface{} {
+	return map[string]interface{}{
+		"current": map[string]interface{}{
+			"activationTime": "0x65f1b057",
+			"forkId":         "0x9f3d2254",
+			"chainId":        hexutil.EncodeBig(big.NewInt(s.chainID)),
+		},
+		"next": nil,
+		"last": nil,
+	}
+}
+
+type testWeb3Service struct{}
+
+func (s *testWeb3Service) ClientVersion() string {
+	return "Geth/v1.14.0-stable/linux-amd64/go1.22.2"
+}
+
+func newTestChainConn(t *testing.T, chainID int64) *conn.Conn {
+	c, err := conn.New(context.Background(), newTestURL(t, map[string]interface{}{
+		"eth":  &testChainService{chainID: chainID},
+		"web3": &testWeb3Service{},
+	}))
+	require.NoError(t, err)
+	return c
+}
+
+func TestChainConfig(t *testing.T) {
+	c := newTestChainConn(t, 1)
+	require.Equal(t, params.MainnetChainConfig, c.ChainConfig())
+	require.Equal(t, "mainnet", c.NetworkName())
+
+	c = newTestChainConn(t, 11155111)
+	require.Equal(t, params.SepoliaChainConfig, c.ChainConfig())
+	require.Equal(t, "sepolia", c.NetworkName())
+
+	c = newTestChainConn(t, 12345)
+	require.Nil(t, c.ChainConfig())
+	require.Equal(t, "", c.NetworkName())
+}
+
+func TestClientVersion(t *testing.T) {
+	c := newTestChainConn(t, 1)
+	version, err := c.ClientVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Geth/v1.14.0-stable/linux-amd64/go1.22.2", version)
+}
+
+func TestForkSchedule(t *testing.T) {
+	c := newTestChainConn(t, 1)
+	schedule, err := c.ForkSchedule(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1710338135), schedule.Current.ActivationTime.Unix())
+	require.Equal(t, hexutil.Bytes{0x9f, 0x3d, 0x22, 0x54}, schedule.Current.ForkID)
+	require.Nil(t, schedule.Next)
+	require.Nil(t, schedule.Last)
+}
@@ -36,12 +36,38 @@ type Conn struct {
 
 	rpcClient *rpc.Client
 	client    *ethclient.Client
-	// config    *params.ChainConfig
+	config    *params.ChainConfig
 
 	// nonces tracks per-address nonces.
 	nonces   map[common.Address]uint64
 	noncesMu sync.Mutex
 
+	// london is set once it is known if the chain supports EIP-1559 transactions.
+	london *bool
+	// rollup is set once the layer 2 rollup type of the chain is known.
+	rollup *string
+
+	// endpoints are the healthy endpoints for the connection.
+	endpoints []string
+	// broadcast is set if transactions should be sent to all endpoints.
+	broadcast bool
+	// private is set if transactions should be sent to the private relay rather than the endpoints.
+	private bool
+	// relay is the private relay for transactions.
+	relay *privateRelay
+	// dryRun is set if transactions should be simulated rather than sent.
+	dryRun bool
+	// labels are resolved in place of addresses.
+	labels Labels
+	// verifier verifies block headers against a trusted block.
+	verifier *headerVerifier
+	// batchUnsupported is set to 1 once it is known that the connection does not support batches.
+	batchUnsupported int32
+	// graphQLURL is the URL of the GraphQL endpoint of the connection, if any.
+	graphQLURL string
+	// graphQLUnavailable is set to 1 once it is known that the GraphQL endpoint cannot be used.
+	graphQLUnavailable int32
+
 	// Information for offline connections.
 	offline       bool
 	chainID       *big.Int
@@ -55,7 +81,43 @@ func New(ctx context.Context, url string) (*Conn, error) {
 		return newOffline(ctx)
 	}
 
-	rpcClient, err := rpc.DialContext(ctx, url)
+	endpoints := splitEndpoints(url)
+	if len(endpoints) == 0 {
+		return nil, errors.New("no connection supplied")
+	}
+	if len(endpoints) > 1 {
+		// Only use the endpoints that are available.
+		timeout := viper.GetDuration("timeout")
+		if timeout == 0 {
+			return nil, errors.New("timeout not specified")
+		}
+		var err error
+		endpoints, _, err = healthyEndpoints(ctx, endpoints, timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var relay *privateRelay
+	if viper.GetBool("private") {
+		var err error
+		relay, err = newPrivateRelay()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verifier, err := newHeaderVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newCallCache()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := dialEndpoints(ctx, endpoints, viper.GetDuration("timeout"), viper.GetInt("retries"), viper.GetFloat64("rate-limit"), relay, verifier, cache)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to RPC client")
 	}
@@ -70,21 +132,10 @@ func New(ctx context.Context, url string) (*Conn, error) {
 	if err != nil {
 		return nil, errors.New("unable to contact client")
 	}
-
-	// var config *params.ChainConfig
-	// switch {
-	// case chainID.Cmp(params.MainnetChainConfig.ChainID) == 0:
-	// 	config = params.MainnetChainConfig
-	// case chainID.Cmp(params.RopstenChainConfig.ChainID) == 0:
-	// 	config = params.RopstenChainConfig
-	// case chainID.Cmp(params.SepoliaChainConfig.ChainID) == 0:
-	// 	config = params.SepoliaChainConfig
-	// case chainID.Cmp(params.RinkebyChainConfig.ChainID) == 0:
-	// 	config = params.RinkebyChainConfig
-	// case chainID.Cmp(params.GoerliChainConfig.ChainID) == 0:
-	// 	config = params.GoerliChainConfig
-	// default:
-	// }
+	if cache != nil {
+		// Calls are cached per chain.
+		cache.chainID = chainID
+	}
 
 	timeout := viper.GetDuration("timeout")
 	if timeout == 0 {
@@ -92,12 +143,19 @@ func New(ctx context.Context, url string) (*Conn, error) {
 	}
 
 	conn := &Conn{
-		timeout:   timeout,
-		rpcClient: rpcClient,
-		client:    client,
-		// config:    config,
-		chainID: chainID,
-		nonces:  make(map[common.Address]uint64),
+		timeout:    timeout,
+		rpcClient:  rpcClient,
+		client:     client,
+		config:     chainConfig(chainID),
+		chainID:    chainID,
+		nonces:     make(map[common.Address]uint64),
+		endpoints:  endpoints,
+		broadcast:  viper.GetBool("broadcast-all"),
+		private:    relay != nil,
+		relay:      relay,
+		dryRun:     viper.GetBool("dry-run"),
+		verifier:   verifier,
+		graphQLURL: graphQLURL(endpoints[0]),
 	}
 
 	return conn, nil
@@ -112,11 +170,11 @@ func newOffline(ctx context.Context) (*Conn, error) {
 	case "mainnet":
 		chainID = params.MainnetChainConfig.ChainID
 	case "ropsten":
-		chainID = params.RopstenChainConfig.ChainID
+		chainID = ropstenChainConfig.ChainID
 	case "rinkeby":
-		chainID = params.RinkebyChainConfig.ChainID
+		chainID = rinkebyChainConfig.ChainID
 	case "goerli", "gorli", "görli":
-		chainID = params.GoerliChainConfig.ChainID
+		chainID = goerliChainConfig.ChainID
 	case "sepolia":
 		chainID = params.SepoliaChainConfig.ChainID
 	default:
@@ -140,6 +198,7 @@ func newOffline(ctx context.Context) (*Conn, error) {
 
 	return &Conn{
 		offline: true,
+		config:  chainConfig(chainID),
 		chainID: chainID,
 		nonces:  make(map[common.Address]uint64),
 	}, nil
@@ -15,13 +15,69 @@ package conn_test
 
 import (
 	"context"
+	"math/big"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethereal/v2/conn"
 )
 
+// testChainIDService provides the chain ID that is requested when a connection is created.
+type testChainIDService struct{}
+
+// ChainId returns the chain ID.
+func (s *testChainIDService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+// newTestServer creates an RPC server that provides the chain ID along with
+// the supplied services, keyed by namespace.  A service in the "eth"
+// namespace only needs the methods its test uses, and can supply its own
+// ChainId to override the default.
+func newTestServer(t *testing.T, services map[string]interface{}) *rpc.Server {
+	t.Helper()
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &testChainIDService{}))
+	for namespace, service := range services {
+		require.NoError(t, server.RegisterName(namespace, service))
+	}
+	t.Cleanup(server.Stop)
+
+	return server
+}
+
+// newTestURL starts an HTTP server for the supplied services and returns its
+// URL, setting a timeout for connections made to it.
+func newTestURL(t *testing.T, services map[string]interface{}) string {
+	t.Helper()
+
+	httpServer := httptest.NewServer(newTestServer(t, services))
+	t.Cleanup(httpServer.Close)
+
+	viper.Set("timeout", time.Minute)
+	t.Cleanup(func() { viper.Set("timeout", nil) })
+
+	return httpServer.URL
+}
+
+// newTestConn creates a connection to a server that provides the supplied
+// service in the "eth" namespace.
+func newTestConn(t *testing.T, service interface{}) *conn.Conn {
+	t.Helper()
+
+	c, err := conn.New(context.Background(), newTestURL(t, map[string]interface{}{"eth": service}))
+	require.NoError(t, err)
+
+	return c
+}
+
 // TestConnBad tests bad connection creation.
 func TestConnBad(t *testing.T) {
 	ctx := context.Background()
@@ -17,8 +17,9 @@ import (
 	"context"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/go-string2eth"
 )
@@ -51,7 +52,11 @@ func (c *Conn) CurrentBaseFee(ctx context.Context) (*big.Int, error) {
 		return c.baseFeePerGas, nil
 	}
 
-	baseFee := misc.CalcBaseFee(&params.ChainConfig{
+	if block.BaseFee() == nil {
+		return nil, errors.New("chain does not support EIP-1559")
+	}
+
+	baseFee := eip1559.CalcBaseFee(&params.ChainConfig{
 		LondonBlock: big.NewInt(0),
 	}, block.Header())
 	return baseFee, nil
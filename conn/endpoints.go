@@ -0,0 +1,295 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// splitEndpoints splits a comma-separated list of endpoints.
+func splitEndpoints(input string) []string {
+	endpoints := make([]string, 0)
+	for _, endpoint := range strings.Split(input, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// isHTTPEndpoint returns true if the endpoint is accessed over HTTP.
+func isHTTPEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://")
+}
+
+// healthyEndpoints returns the endpoints that respond within the timeout, along with their chain ID.
+// All healthy endpoints must be on the same chain.
+func healthyEndpoints(ctx context.Context, endpoints []string, timeout time.Duration) ([]string, *big.Int, error) {
+	healthy := make([]string, 0, len(endpoints))
+	var chainID *big.Int
+	failures := make([]string, 0)
+	for _, endpoint := range endpoints {
+		endpointChainID, err := endpointChainID(ctx, endpoint, timeout)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", endpoint, err))
+			continue
+		}
+		if chainID == nil {
+			chainID = endpointChainID
+		} else if chainID.Cmp(endpointChainID) != 0 {
+			return nil, nil, fmt.Errorf("endpoint %s is on chain %s but %s is on chain %s", endpoint, endpointChainID, healthy[0], chainID)
+		}
+		healthy = append(healthy, endpoint)
+	}
+	if len(healthy) == 0 {
+		return nil, nil, fmt.Errorf("no healthy endpoints (%s)", strings.Join(failures, "; "))
+	}
+	return healthy, chainID, nil
+}
+
+// endpointChainID obtains the chain ID from an endpoint.
+func endpointChainID(ctx context.Context, endpoint string, timeout time.Duration) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rpcClient, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.Close()
+	return ethclient.NewClient(rpcClient).ChainID(ctx)
+}
+
+// dialEndpoints dials the first of the endpoints.  If it is accessed over HTTP then requests
+// are rate limited and retried on transient errors, and fail over to the other endpoints
+// accessed over HTTP.  If a private relay is supplied then transactions are sent to it
+// rather than the endpoints, which requires the endpoint to be accessed over HTTP.
+func dialEndpoints(ctx context.Context,
+	endpoints []string,
+	timeout time.Duration,
+	retries int,
+	rateLimit float64,
+	relay *privateRelay,
+	verifier *headerVerifier,
+	cache *callCache,
+) (*rpc.Client, error) {
+	if !isHTTPEndpoint(endpoints[0]) {
+		if relay != nil {
+			return nil, fmt.Errorf("private transactions require a connection over HTTP, but %s is not", endpoints[0])
+		}
+		if verifier != nil {
+			return nil, fmt.Errorf("header verification requires a connection over HTTP, but %s is not", endpoints[0])
+		}
+		if cache != nil {
+			return nil, fmt.Errorf("caching calls requires a connection over HTTP, but %s is not", endpoints[0])
+		}
+		return rpc.DialContext(ctx, endpoints[0])
+	}
+
+	limiter, err := newRateLimiter(rateLimit)
+	if err != nil {
+		return nil, err
+	}
+	transport := &retryTransport{
+		next:    http.DefaultTransport,
+		retries: retries,
+		limiter: limiter,
+	}
+
+	failover := &failoverTransport{
+		base: http.DefaultTransport,
+	}
+	for _, endpoint := range endpoints {
+		if !isHTTPEndpoint(endpoint) {
+			continue
+		}
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid endpoint %s", endpoint)
+		}
+		failover.endpoints = append(failover.endpoints, endpointURL)
+	}
+	if len(failover.endpoints) > 1 {
+		// Give each endpoint a share of the timeout, so that there is time to fail over.
+		failover.timeout = timeout / time.Duration(len(failover.endpoints))
+		transport.next = failover
+	}
+
+	var next http.RoundTripper = transport
+	if relay != nil {
+		next = &privateTransport{
+			next: transport,
+			relay: &relayTransport{
+				base:  http.DefaultTransport,
+				relay: relay,
+			},
+		}
+	}
+	if verifier != nil {
+		// The verifier fetches the headers it needs without verifying them in turn.
+		verifier.client, err = rpc.DialHTTPWithClient(endpoints[0], &http.Client{Transport: transport})
+		if err != nil {
+			return nil, err
+		}
+		next = &verifyTransport{
+			next:     next,
+			verifier: verifier,
+		}
+	}
+	if cache != nil {
+		next = &cacheTransport{
+			next:  next,
+			cache: cache,
+		}
+	}
+	return rpc.DialHTTPWithClient(endpoints[0], &http.Client{Transport: next})
+}
+
+// failoverTransport is an HTTP transport that sends requests to the current endpoint,
+// failing over to the next endpoint if the request fails or times out.
+type failoverTransport struct {
+	base      http.RoundTripper
+	timeout   time.Duration
+	endpoints []*url.URL
+	current   int
+	mu        sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	start := t.current
+	t.mu.Unlock()
+
+	sends := sendsTransaction(req)
+	var err error
+	for i := range t.endpoints {
+		if req.Context().Err() != nil {
+			break
+		}
+		index := (start + i) % len(t.endpoints)
+		var resp *http.Response
+		var written bool
+		resp, written, err = t.roundTrip(req, t.endpoints[index])
+		if err == nil {
+			t.mu.Lock()
+			t.current = index
+			t.mu.Unlock()
+			return resp, nil
+		}
+		if sends && written {
+			// The transaction might have reached the endpoint, so is not sent to another.
+			break
+		}
+	}
+	if err == nil {
+		err = req.Context().Err()
+	}
+	return nil, err
+}
+
+// roundTrip sends the request to a single endpoint, returning whether any of the request was
+// written to the endpoint along with the response.
+func (t *failoverTransport) roundTrip(req *http.Request, endpoint *url.URL) (*http.Response, bool, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	attempt := req.Clone(ctx)
+	attempt.URL = endpoint
+	attempt.Host = ""
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, false, err
+		}
+		attempt.Body = body
+	}
+
+	attempt, written := traceWrite(attempt)
+	resp, err := t.base.RoundTrip(attempt)
+	if err != nil {
+		cancel()
+		return nil, written.Load(), err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		resp.Body.Close()
+		cancel()
+		return nil, true, fmt.Errorf("%s returned %s", endpoint.Host, resp.Status)
+	}
+	// The context must remain alive until the body has been read.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, true, nil
+}
+
+// cancelOnClose cancels a context when the reader is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// broadcastTransaction sends the transaction to all endpoints, succeeding if any of them accepts it.
+func (c *Conn) broadcastTransaction(ctx context.Context, tx *types.Transaction) error {
+	errs := make([]error, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i := range c.endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.sendTransactionTo(ctx, c.endpoints[i], tx)
+		}(i)
+	}
+	wg.Wait()
+
+	failures := make([]string, 0)
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", c.endpoints[i], err))
+	}
+	return fmt.Errorf("no endpoint accepted the transaction (%s)", strings.Join(failures, "; "))
+}
+
+// sendTransactionTo sends the transaction to a single endpoint.
+func (c *Conn) sendTransactionTo(ctx context.Context, endpoint string, tx *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rpcClient, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer rpcClient.Close()
+	return ethclient.NewClient(rpcClient).SendTransaction(ctx, tx)
+}
@@ -0,0 +1,156 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testEndpointService struct {
+	chainID int64
+	calls   int32
+	sent    int32
+}
+
+func (s *testEndpointService) ChainId() *hexutil.Big {
+	atomic.AddInt32(&s.calls, 1)
+	return (*hexutil.Big)(big.NewInt(s.chainID))
+}
+
+func (s *testEndpointService) SendRawTransaction(data hexutil.Bytes) common.Hash {
+	atomic.AddInt32(&s.sent, 1)
+	return crypto.Keccak256Hash(data)
+}
+
+// testEndpoint is an endpoint that can be made to fail.
+type testEndpoint struct {
+	service *testEndpointService
+	server  *httptest.Server
+	failing int32
+}
+
+func newTestEndpoint(t *testing.T, chainID int64) *testEndpoint {
+	endpoint := &testEndpoint{
+		service: &testEndpointService{chainID: chainID},
+	}
+	server := newTestServer(t, map[string]interface{}{"eth": endpoint.service})
+	endpoint.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&endpoint.failing) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(endpoint.server.Close)
+	return endpoint
+}
+
+func TestEndpoints(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+
+	// First endpoint is down when connecting.
+	down := newTestEndpoint(t, 1)
+	atomic.StoreInt32(&down.failing, 1)
+	up := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, down.server.URL+","+up.server.URL)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), c.ChainID())
+
+	// First endpoint goes down after connecting.
+	first := newTestEndpoint(t, 1)
+	second := newTestEndpoint(t, 1)
+	c, err = conn.New(ctx, first.server.URL+", "+second.server.URL)
+	require.NoError(t, err)
+	atomic.StoreInt32(&first.failing, 1)
+	calls := atomic.LoadInt32(&second.service.calls)
+	_, err = c.Client().ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, calls+1, atomic.LoadInt32(&second.service.calls))
+
+	// Endpoints on different chains.
+	other := newTestEndpoint(t, 5)
+	_, err = conn.New(ctx, up.server.URL+","+other.server.URL)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is on chain 5")
+
+	// No healthy endpoints.
+	_, err = conn.New(ctx, down.server.URL+","+first.server.URL)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no healthy endpoints")
+}
+
+func TestEndpointsSend(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+
+	first := newTestEndpoint(t, 1)
+	second := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, first.server.URL+","+second.server.URL)
+	require.NoError(t, err)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+
+	// Transactions are not sent to another endpoint if they might have reached the first.
+	atomic.StoreInt32(&first.failing, 1)
+	require.Error(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(0), atomic.LoadInt32(&second.service.sent))
+
+	// Transactions are sent to another endpoint if the first cannot be reached.
+	first.server.Close()
+	require.NoError(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(1), atomic.LoadInt32(&second.service.sent))
+}
+
+func TestEndpointsBroadcast(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+	viper.Set("broadcast-all", true)
+	defer viper.Set("broadcast-all", nil)
+
+	first := newTestEndpoint(t, 1)
+	second := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, first.server.URL+","+second.server.URL)
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	require.NoError(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(1), atomic.LoadInt32(&first.service.sent))
+	require.Equal(t, int32(1), atomic.LoadInt32(&second.service.sent))
+
+	// Succeeds if one endpoint is down.
+	atomic.StoreInt32(&first.failing, 1)
+	require.NoError(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(2), atomic.LoadInt32(&second.service.sent))
+
+	// Fails if all endpoints are down.
+	atomic.StoreInt32(&second.failing, 1)
+	require.Error(t, c.SendTransaction(ctx, tx))
+}
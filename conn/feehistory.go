@@ -0,0 +1,203 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// FeeSuggestion is a suggested set of fees for a transaction.
+type FeeSuggestion struct {
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// FeeSuggestions are suggested fees for transactions at different speeds of inclusion.
+type FeeSuggestions struct {
+	// BaseFeePerGas is the base fee per gas of the next block.
+	BaseFeePerGas *big.Int
+	Slow          *FeeSuggestion
+	Standard      *FeeSuggestion
+	Fast          *FeeSuggestion
+}
+
+// DefaultFeeHistoryBlocks is the default number of blocks of fee history used to suggest fees.
+const DefaultFeeHistoryBlocks = 20
+
+// DefaultFeeHistoryPercentiles are the default percentiles of priority fees within each block
+// used to suggest slow, standard and fast fees.
+var DefaultFeeHistoryPercentiles = []float64{10, 50, 90}
+
+// SupportsLondon returns true if the chain supports EIP-1559 (London) transactions.
+func (c *Conn) SupportsLondon(ctx context.Context) (bool, error) {
+	if c.client == nil {
+		// Offline; assume London.
+		return true, nil
+	}
+	if c.london != nil {
+		return *c.london, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain latest header")
+	}
+	london := header.BaseFee != nil
+	c.london = &london
+
+	return london, nil
+}
+
+// SuggestFees suggests slow, standard and fast fees for a transaction based on the fee history
+// of the given number of recent blocks, using the given percentiles of priority fees paid within
+// each block.
+func (c *Conn) SuggestFees(ctx context.Context, blocks uint64, percentiles []float64) (*FeeSuggestions, error) {
+	if c.client == nil {
+		return nil, errors.New("cannot suggest fees when offline")
+	}
+	if blocks == 0 {
+		return nil, errors.New("at least one block is required")
+	}
+	if len(percentiles) != 3 {
+		return nil, errors.New("slow, standard and fast percentiles are required")
+	}
+	for i := range percentiles {
+		if percentiles[i] < 0 || percentiles[i] > 100 {
+			return nil, errors.New("percentiles must be between 0 and 100")
+		}
+		if i > 0 && percentiles[i] < percentiles[i-1] {
+			return nil, errors.New("percentiles must be in increasing order")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var history feeHistory
+	if err := c.rpcClient.CallContext(ctx, &history, "eth_feeHistory", hexutil.Uint64(blocks), "latest", percentiles); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fee history")
+	}
+
+	suggestions, err := history.suggestions()
+	if err != nil {
+		return nil, err
+	}
+
+	// If recent blocks have no priority fees to use then fall back to the node's suggestion.
+	for _, suggestion := range []*FeeSuggestion{suggestions.Slow, suggestions.Standard, suggestions.Fast} {
+		if suggestion.MaxPriorityFeePerGas != nil {
+			continue
+		}
+		priorityFeePerGas, err := c.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain suggested priority fee")
+		}
+		suggestion.MaxPriorityFeePerGas = priorityFeePerGas
+		suggestion.MaxFeePerGas = new(big.Int).Add(suggestion.MaxFeePerGas, priorityFeePerGas)
+	}
+
+	return suggestions, nil
+}
+
+// SuggestPriorityFee suggests a priority fee per gas for a transaction, based on the fee history
+// of recent blocks.
+func (c *Conn) SuggestPriorityFee(ctx context.Context) (*big.Int, error) {
+	suggestions, err := c.SuggestFees(ctx, DefaultFeeHistoryBlocks, DefaultFeeHistoryPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	return suggestions.Standard.MaxPriorityFeePerGas, nil
+}
+
+// SuggestGasPrice suggests a gas price for a legacy transaction.
+func (c *Conn) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if c.client == nil {
+		return nil, errors.New("cannot suggest gas price when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain suggested gas price")
+	}
+	return gasPrice, nil
+}
+
+// feeHistory is the response to eth_feeHistory.
+type feeHistory struct {
+	BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+	Reward        [][]*hexutil.Big `json:"reward"`
+}
+
+// suggestions creates fee suggestions from the fee history.  The max fee per gas allows for the
+// base fee to double.  If there are no priority fees for a percentile the suggestion's max
+// priority fee per gas is nil, and its max fee per gas does not include a priority fee.
+func (h *feeHistory) suggestions() (*FeeSuggestions, error) {
+	if len(h.BaseFeePerGas) == 0 || h.BaseFeePerGas[len(h.BaseFeePerGas)-1] == nil {
+		return nil, errors.New("fee history does not contain base fee; chain does not support EIP-1559")
+	}
+	// The final base fee is that of the next block.
+	baseFeePerGas := h.BaseFeePerGas[len(h.BaseFeePerGas)-1].ToInt()
+
+	suggestions := make([]*FeeSuggestion, 3)
+	for i := range suggestions {
+		suggestions[i] = &FeeSuggestion{
+			MaxFeePerGas: new(big.Int).Mul(baseFeePerGas, big.NewInt(2)),
+		}
+		if priorityFeePerGas := medianReward(h.Reward, i); priorityFeePerGas != nil {
+			suggestions[i].MaxPriorityFeePerGas = priorityFeePerGas
+			suggestions[i].MaxFeePerGas = suggestions[i].MaxFeePerGas.Add(suggestions[i].MaxFeePerGas, priorityFeePerGas)
+		}
+	}
+
+	return &FeeSuggestions{
+		BaseFeePerGas: new(big.Int).Set(baseFeePerGas),
+		Slow:          suggestions[0],
+		Standard:      suggestions[1],
+		Fast:          suggestions[2],
+	}, nil
+}
+
+// medianReward returns the median of the non-zero rewards at the given percentile index in a fee
+// history, or nil if there are none.
+func medianReward(rewards [][]*hexutil.Big, index int) *big.Int {
+	values := make([]*big.Int, 0, len(rewards))
+	for _, blockRewards := range rewards {
+		if len(blockRewards) <= index || blockRewards[index] == nil {
+			continue
+		}
+		reward := blockRewards[index].ToInt()
+		if reward.Sign() == 0 {
+			// Empty blocks return 0; ignore them.
+			continue
+		}
+		values = append(values, reward)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	return new(big.Int).Set(values[len(values)/2])
+}
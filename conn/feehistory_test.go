@@ -0,0 +1,133 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func reward(val int64) []*hexutil.Big {
+	return []*hexutil.Big{(*hexutil.Big)(big.NewInt(val))}
+}
+
+func TestMedianReward(t *testing.T) {
+	tests := []struct {
+		name     string
+		rewards  [][]*hexutil.Big
+		expected *big.Int
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name:    "Zeros",
+			rewards: [][]*hexutil.Big{reward(0), reward(0)},
+		},
+		{
+			name:     "Single",
+			rewards:  [][]*hexutil.Big{reward(5)},
+			expected: big.NewInt(5),
+		},
+		{
+			name:     "Odd",
+			rewards:  [][]*hexutil.Big{reward(3), reward(1), reward(2)},
+			expected: big.NewInt(2),
+		},
+		{
+			name:     "IgnoresZeros",
+			rewards:  [][]*hexutil.Big{reward(0), reward(4), {}, reward(0), reward(6), reward(5)},
+			expected: big.NewInt(5),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := medianReward(test.rewards, 0)
+			if test.expected == nil {
+				require.Nil(t, res)
+			} else {
+				require.Equal(t, test.expected.String(), res.String())
+			}
+		})
+	}
+}
+
+func TestFeeHistorySuggestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  *feeHistory
+		err      string
+		baseFee  int64
+		slow     []int64
+		standard []int64
+		fast     []int64
+	}{
+		{
+			name:    "Empty",
+			history: &feeHistory{},
+			err:     "fee history does not contain base fee; chain does not support EIP-1559",
+		},
+		{
+			name: "Good",
+			history: &feeHistory{
+				BaseFeePerGas: []*hexutil.Big{(*hexutil.Big)(big.NewInt(90)), (*hexutil.Big)(big.NewInt(100))},
+				Reward: [][]*hexutil.Big{
+					{(*hexutil.Big)(big.NewInt(1)), (*hexutil.Big)(big.NewInt(2)), (*hexutil.Big)(big.NewInt(3))},
+				},
+			},
+			baseFee:  100,
+			slow:     []int64{201, 1},
+			standard: []int64{202, 2},
+			fast:     []int64{203, 3},
+		},
+		{
+			name: "NoRewards",
+			history: &feeHistory{
+				BaseFeePerGas: []*hexutil.Big{(*hexutil.Big)(big.NewInt(100))},
+			},
+			baseFee:  100,
+			slow:     []int64{200},
+			standard: []int64{200},
+			fast:     []int64{200},
+		},
+	}
+
+	check := func(t *testing.T, expected []int64, suggestion *FeeSuggestion) {
+		require.Equal(t, big.NewInt(expected[0]).String(), suggestion.MaxFeePerGas.String())
+		if len(expected) == 1 {
+			require.Nil(t, suggestion.MaxPriorityFeePerGas)
+		} else {
+			require.Equal(t, big.NewInt(expected[1]).String(), suggestion.MaxPriorityFeePerGas.String())
+		}
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.history.suggestions()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(test.baseFee).String(), res.BaseFeePerGas.String())
+			check(t, test.slow, res.Slow)
+			check(t, test.standard, res.Standard)
+			check(t, test.fast, res.Fast)
+		})
+	}
+}
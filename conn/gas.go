@@ -37,7 +37,10 @@ func (c *Conn) EstimateGas(ctx context.Context,
 		return uint64(gasLimit), nil
 	}
 
-	msg := ethereum.CallMsg{From: txData.From, To: txData.To, Value: txData.Value, Data: txData.Data}
+	msg := ethereum.CallMsg{From: txData.From, To: txData.To, Value: txData.Value, Data: txData.Data, AccessList: txData.AccessList}
+	if txData.BlobSidecar != nil {
+		msg.BlobHashes = txData.BlobSidecar.BlobHashes()
+	}
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 	gas, err := c.client.EstimateGas(ctx, msg)
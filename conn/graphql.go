@@ -0,0 +1,277 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ErrGraphQLUnavailable is returned if the connection does not provide a usable GraphQL
+// endpoint, in which case JSON-RPC should be used instead.
+var ErrGraphQLUnavailable = errors.New("GraphQL unavailable")
+
+// graphQLURL returns the URL of the GraphQL endpoint for the given endpoint, or an empty
+// string if there is none.  The URL can be configured with graphql-url, otherwise it is
+// the path used by geth on its HTTP server.
+func graphQLURL(endpoint string) string {
+	if url := viper.GetString("graphql-url"); url != "" {
+		return url
+	}
+	if !isHTTPEndpoint(endpoint) {
+		return ""
+	}
+	return fmt.Sprintf("%s/graphql", strings.TrimSuffix(endpoint, "/"))
+}
+
+// graphQLLong is a GraphQL Long, which is returned as a number or as a hex string depending
+// on the version of the server.
+type graphQLLong uint64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *graphQLLong) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		var number uint64
+		if err := json.Unmarshal(input, &number); err != nil {
+			return errors.Wrap(err, "invalid long")
+		}
+		*l = graphQLLong(number)
+		return nil
+	}
+	number, err := strconv.ParseUint(str, 0, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid long")
+	}
+	*l = graphQLLong(number)
+	return nil
+}
+
+// graphQLAccount is a GraphQL Account.
+type graphQLAccount struct {
+	Address common.Address `json:"address"`
+}
+
+// graphQLParent is the parent of a GraphQL Block.
+type graphQLParent struct {
+	Hash common.Hash `json:"hash"`
+}
+
+type graphQLTransaction struct {
+	Hash            common.Hash     `json:"hash"`
+	Type            graphQLLong     `json:"type"`
+	From            graphQLAccount  `json:"from"`
+	To              *graphQLAccount `json:"to"`
+	Nonce           graphQLLong     `json:"nonce"`
+	Value           *hexutil.Big    `json:"value"`
+	Gas             graphQLLong     `json:"gas"`
+	InputData       hexutil.Bytes   `json:"inputData"`
+	Status          *graphQLLong    `json:"status"`
+	GasUsed         *graphQLLong    `json:"gasUsed"`
+	CreatedContract *graphQLAccount `json:"createdContract"`
+}
+
+type graphQLBlock struct {
+	Number        graphQLLong           `json:"number"`
+	Hash          common.Hash           `json:"hash"`
+	Parent        *graphQLParent        `json:"parent"`
+	StateRoot     common.Hash           `json:"stateRoot"`
+	Timestamp     graphQLLong           `json:"timestamp"`
+	Miner         graphQLAccount        `json:"miner"`
+	ExtraData     hexutil.Bytes         `json:"extraData"`
+	Difficulty    *hexutil.Big          `json:"difficulty"`
+	GasUsed       graphQLLong           `json:"gasUsed"`
+	GasLimit      graphQLLong           `json:"gasLimit"`
+	BaseFeePerGas *hexutil.Big          `json:"baseFeePerGas"`
+	BlobGasUsed   *graphQLLong          `json:"blobGasUsed"`
+	Transactions  []*graphQLTransaction `json:"transactions"`
+}
+
+// GraphQLBlocks returns the blocks in the given range, with summaries of their transactions
+// and optionally of their receipts, using a single GraphQL query.  Withdrawals and uncles are
+// not included.
+// ErrGraphQLUnavailable is returned if the GraphQL endpoint cannot be used, including if block
+// headers are being verified as GraphQL responses are not.
+func (c *Conn) GraphQLBlocks(ctx context.Context,
+	from uint64,
+	to uint64,
+	receipts bool,
+) (
+	[]*Block,
+	[][]*BlockReceipt,
+	error,
+) {
+	if c.offline {
+		return nil, nil, errors.New("cannot obtain blocks when offline")
+	}
+	if c.graphQLURL == "" || c.verifier != nil || atomic.LoadInt32(&c.graphQLUnavailable) == 1 {
+		return nil, nil, ErrGraphQLUnavailable
+	}
+
+	receiptFields := ""
+	if receipts {
+		receiptFields = " status gasUsed createdContract { address }"
+	}
+	query := fmt.Sprintf(`{ blocks(from: %d, to: %d) { number hash parent { hash } stateRoot timestamp miner { address } extraData difficulty gasUsed gasLimit baseFeePerGas blobGasUsed transactions { hash type from { address } to { address } nonce value gas inputData%s } } }`, from, to, receiptFields)
+
+	var res struct {
+		Blocks []*graphQLBlock `json:"blocks"`
+	}
+	if err := c.graphQLQuery(ctx, query, &res); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		// Use JSON-RPC from now on.
+		atomic.StoreInt32(&c.graphQLUnavailable, 1)
+		return nil, nil, ErrGraphQLUnavailable
+	}
+	if uint64(len(res.Blocks)) != to-from+1 {
+		// Blocks are missing, so let JSON-RPC report the error.
+		return nil, nil, ErrGraphQLUnavailable
+	}
+
+	blocks := make([]*Block, len(res.Blocks))
+	blockReceipts := make([][]*BlockReceipt, len(res.Blocks))
+	for i, b := range res.Blocks {
+		blocks[i], blockReceipts[i] = b.block(receipts)
+	}
+	return blocks, blockReceipts, nil
+}
+
+// block converts the GraphQL representation of a block.
+func (b *graphQLBlock) block(receipts bool) (*Block, []*BlockReceipt) {
+	block := &Block{
+		Number:            uint64(b.Number),
+		Hash:              b.Hash,
+		StateRoot:         b.StateRoot,
+		Timestamp:         time.Unix(int64(b.Timestamp), 0),
+		Miner:             b.Miner.Address,
+		ExtraData:         b.ExtraData,
+		Difficulty:        b.Difficulty.ToInt(),
+		GasUsed:           uint64(b.GasUsed),
+		GasLimit:          uint64(b.GasLimit),
+		BlobGasUsed:       (*uint64)(b.BlobGasUsed),
+		Uncles:            []common.Hash{},
+		TransactionHashes: make([]common.Hash, len(b.Transactions)),
+		Transactions:      make([]*BlockTransaction, len(b.Transactions)),
+	}
+	if b.Parent != nil {
+		block.ParentHash = b.Parent.Hash
+	}
+	if b.BaseFeePerGas != nil {
+		block.BaseFeePerGas = b.BaseFeePerGas.ToInt()
+	}
+
+	var blockReceipts []*BlockReceipt
+	if receipts {
+		blockReceipts = make([]*BlockReceipt, len(b.Transactions))
+	}
+	for i, tx := range b.Transactions {
+		block.TransactionHashes[i] = tx.Hash
+		value := new(big.Int)
+		if tx.Value != nil {
+			value = tx.Value.ToInt()
+		}
+		block.Transactions[i] = &BlockTransaction{
+			Hash:  tx.Hash,
+			Type:  uint64(tx.Type),
+			From:  tx.From.Address,
+			Nonce: uint64(tx.Nonce),
+			Value: value,
+			Gas:   uint64(tx.Gas),
+			Input: tx.InputData,
+		}
+		if tx.To != nil {
+			to := tx.To.Address
+			block.Transactions[i].To = &to
+		}
+		if receipts {
+			receipt := &BlockReceipt{
+				TransactionHash: tx.Hash,
+				Type:            uint64(tx.Type),
+				From:            tx.From.Address,
+				To:              block.Transactions[i].To,
+			}
+			if tx.CreatedContract != nil {
+				address := tx.CreatedContract.Address
+				receipt.ContractAddress = &address
+			}
+			if tx.GasUsed != nil {
+				receipt.GasUsed = uint64(*tx.GasUsed)
+			}
+			if tx.Status != nil {
+				receipt.Status = uint64(*tx.Status)
+			}
+			blockReceipts[i] = receipt
+		}
+	}
+	return block, blockReceipts
+}
+
+// graphQLQuery sends a query to the GraphQL endpoint, decoding the data in the response
+// in to the result.
+func (c *Conn) graphQLQuery(ctx context.Context, query string, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GraphQL request failed with status %d", resp.StatusCode)
+	}
+
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return errors.Wrap(err, "invalid GraphQL response")
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("GraphQL query failed: %s", res.Errors[0].Message)
+	}
+	return json.Unmarshal(res.Data, result)
+}
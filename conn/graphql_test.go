@@ -0,0 +1,132 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+const testGraphQLResponse = `{"data":{"blocks":[
+{"number":"0x10","hash":"0x1111111111111111111111111111111111111111111111111111111111111111","parent":{"hash":"0x3333333333333333333333333333333333333333333333333333333333333333"},"stateRoot":"0x4444444444444444444444444444444444444444444444444444444444444444","timestamp":"0x5f5e1000","miner":{"address":"0x2b5ad5c4795c026514f8317c7a215e218dccd6cf"},"extraData":"0x","difficulty":"0x0","gasUsed":"0x5208","gasLimit":"0x1c9c380","baseFeePerGas":"0x3b9aca00","blobGasUsed":null,"transactions":[
+{"hash":"0x2222222222222222222222222222222222222222222222222222222222222222","type":"0x2","from":{"address":"0x5ffc014343cd971b7eb70732021e26c35b744cc4"},"to":{"address":"0x2b5ad5c4795c026514f8317c7a215e218dccd6cf"},"nonce":"0x5","value":"0xde0b6b3a7640000","gas":"0x5208","inputData":"0x","status":"0x1","gasUsed":"0x5208","createdContract":null}]},
+{"number":17,"hash":"0x5555555555555555555555555555555555555555555555555555555555555555","parent":{"hash":"0x1111111111111111111111111111111111111111111111111111111111111111"},"stateRoot":"0x4444444444444444444444444444444444444444444444444444444444444444","timestamp":1600000012,"miner":{"address":"0x2b5ad5c4795c026514f8317c7a215e218dccd6cf"},"extraData":"0x","difficulty":"0x0","gasUsed":0,"gasLimit":30000000,"baseFeePerGas":"0x3b9aca00","blobGasUsed":"0x20000","transactions":[]}
+]}}`
+
+func newTestGraphQLServer(t *testing.T, graphQL string, queries *int32) *httptest.Server {
+	server := newTestServer(t, nil)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			server.ServeHTTP(w, r)
+			return
+		}
+		atomic.AddInt32(queries, 1)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.True(t, strings.Contains(string(body), "blocks(from: 16, to: 17)"))
+		if graphQL == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(graphQL))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestGraphQLBlocks(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+
+	queries := int32(0)
+	server := newTestGraphQLServer(t, testGraphQLResponse, &queries)
+	c, err := conn.New(ctx, server.URL)
+	require.NoError(t, err)
+
+	blocks, receipts, err := c.GraphQLBlocks(ctx, 16, 17, true)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(16), blocks[0].Number)
+	require.Equal(t, common.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333"), blocks[0].ParentHash)
+	require.Equal(t, int64(0x5f5e1000), blocks[0].Timestamp.Unix())
+	require.Equal(t, uint64(21000), blocks[0].GasUsed)
+	require.Equal(t, big.NewInt(1000000000), blocks[0].BaseFeePerGas)
+	require.Nil(t, blocks[0].BlobGasUsed)
+	require.Len(t, blocks[0].Transactions, 1)
+	require.Equal(t, uint64(2), blocks[0].Transactions[0].Type)
+	require.Equal(t, uint64(5), blocks[0].Transactions[0].Nonce)
+	require.Equal(t, common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"), *blocks[0].Transactions[0].To)
+	require.Equal(t, []common.Hash{common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")}, blocks[0].TransactionHashes)
+	require.Len(t, receipts[0], 1)
+	require.Equal(t, uint64(21000), receipts[0][0].GasUsed)
+	require.Equal(t, uint64(1), receipts[0][0].Status)
+	require.Nil(t, receipts[0][0].ContractAddress)
+
+	require.Equal(t, uint64(17), blocks[1].Number)
+	require.Equal(t, int64(1600000012), blocks[1].Timestamp.Unix())
+	require.Equal(t, uint64(30000000), blocks[1].GasLimit)
+	require.Equal(t, uint64(0x20000), *blocks[1].BlobGasUsed)
+	require.Len(t, receipts[1], 0)
+	require.Equal(t, int32(1), atomic.LoadInt32(&queries))
+}
+
+func TestGraphQLBlocksUnavailable(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{
+			name: "NotFound",
+		},
+		{
+			name:     "Errors",
+			response: `{"errors":[{"message":"Cannot query field \"blobGasUsed\" on type \"Block\"."}]}`,
+		},
+		{
+			name:     "MissingBlocks",
+			response: `{"data":{"blocks":[]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queries := int32(0)
+			server := newTestGraphQLServer(t, test.response, &queries)
+			c, err := conn.New(ctx, server.URL)
+			require.NoError(t, err)
+
+			_, _, err = c.GraphQLBlocks(ctx, 16, 17, false)
+			require.Equal(t, conn.ErrGraphQLUnavailable, err)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// maxHeadHistory is the number of recent blocks tracked to detect reorgs.
+const maxHeadHistory = 128
+
+// HeadEvent is a new head of the chain.
+type HeadEvent struct {
+	Header *types.Header
+	// Reorg is set if the new head replaced blocks previously seen.
+	Reorg *Reorg
+}
+
+// Reorg contains information about a chain reorganisation.
+type Reorg struct {
+	// Depth is the number of blocks that were replaced.
+	Depth uint64
+	// OldHead is the hash of the head prior to the reorg.
+	OldHead common.Hash
+	// OldHeadNumber is the number of the head prior to the reorg.
+	OldHeadNumber uint64
+	// CommonAncestor is the number of the last block shared by the old and new chains.
+	CommonAncestor uint64
+}
+
+// headTracker tracks the hashes of recent blocks, to detect reorgs.
+type headTracker struct {
+	fetch  func(ctx context.Context, hash common.Hash) (*types.Header, error)
+	hashes map[uint64]common.Hash
+	head   uint64
+}
+
+// track adds a new head to the tracker, returning the resultant event.
+// If the head has already been seen it returns nil.
+func (t *headTracker) track(ctx context.Context, header *types.Header) (*HeadEvent, error) {
+	number := header.Number.Uint64()
+	hash := header.Hash()
+	if len(t.hashes) == 0 || number > t.head+maxHeadHistory {
+		// Nothing useful tracked; start afresh.
+		t.hashes = map[uint64]common.Hash{number: hash}
+		t.head = number
+		return &HeadEvent{Header: header}, nil
+	}
+	if known, exists := t.hashes[number]; exists && known == hash {
+		return nil, nil
+	}
+
+	// Walk back along the new chain until it joins the tracked chain,
+	// filling in any blocks that were not seen along the way.
+	newHashes := map[uint64]common.Hash{number: hash}
+	n := number
+	parent := header.ParentHash
+	for n > 0 {
+		known, exists := t.hashes[n-1]
+		if exists && known == parent {
+			break
+		}
+		if !exists && n-1 <= t.head {
+			// Beyond the tracked history.
+			break
+		}
+		ancestor, err := t.fetch(ctx, parent)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to obtain block %s", parent.Hex())
+		}
+		n--
+		newHashes[n] = parent
+		parent = ancestor.ParentHash
+	}
+
+	event := &HeadEvent{Header: header}
+	if n <= t.head {
+		event.Reorg = &Reorg{
+			Depth:          t.head - n + 1,
+			OldHead:        t.hashes[t.head],
+			OldHeadNumber:  t.head,
+			CommonAncestor: n - 1,
+		}
+	}
+
+	for k := range t.hashes {
+		if k >= n || k+maxHeadHistory <= number {
+			delete(t.hashes, k)
+		}
+	}
+	for k, v := range newHashes {
+		if k+maxHeadHistory > number {
+			t.hashes[k] = v
+		}
+	}
+	t.head = number
+
+	return event, nil
+}
+
+// SubscribeHeads streams new heads of the chain to the supplied channel until the context is done.
+// If the connection supports subscriptions they are used, and re-established if they fail,
+// for example if a websocket or IPC connection drops whilst the node restarts; otherwise the
+// connection is polled at the supplied interval.
+// Heads that replace previously seen blocks are marked as reorgs.
+func (c *Conn) SubscribeHeads(ctx context.Context,
+	interval time.Duration,
+	ch chan<- *HeadEvent,
+) error {
+	if c.client == nil {
+		return errors.New("cannot subscribe to heads when offline")
+	}
+
+	tracker := &headTracker{
+		fetch:  c.headerByHash,
+		hashes: make(map[uint64]common.Hash),
+	}
+
+	retry := &backoff{}
+	for {
+		subCh := make(chan *types.Header)
+		sub, err := c.client.SubscribeNewHead(ctx, subCh)
+		if err != nil {
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				return c.pollHeads(ctx, interval, tracker, ch)
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Try again after backing off.
+			if !retry.wait(ctx) {
+				return nil
+			}
+			continue
+		}
+		retry.reset()
+
+		err = c.receiveHeads(ctx, sub, subCh, tracker, ch)
+		sub.Unsubscribe()
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		// Subscription failed; loop round to resubscribe.
+	}
+}
+
+// receiveHeads receives heads from a subscription until the subscription fails or the context is done.
+func (c *Conn) receiveHeads(ctx context.Context,
+	sub ethereum.Subscription,
+	subCh <-chan *types.Header,
+	tracker *headTracker,
+	ch chan<- *HeadEvent,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case header := <-subCh:
+			if err := c.deliverHead(ctx, header, tracker, ch); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pollHeads polls for heads at the given interval until the context is done.
+func (c *Conn) pollHeads(ctx context.Context,
+	interval time.Duration,
+	tracker *headTracker,
+	ch chan<- *HeadEvent,
+) error {
+	for {
+		opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		header, err := c.client.HeaderByNumber(opCtx, nil)
+		cancel()
+		if err == nil {
+			// Failures are transient; try again next time round.
+			_ = c.deliverHead(ctx, header, tracker, ch)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// deliverHead tracks the head and sends the resultant event to the channel.
+func (c *Conn) deliverHead(ctx context.Context,
+	header *types.Header,
+	tracker *headTracker,
+	ch chan<- *HeadEvent,
+) error {
+	event, err := tracker.track(ctx, header)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return nil
+	}
+	select {
+	case ch <- event:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// headerByHash obtains the header of a block given its hash.
+func (c *Conn) headerByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.HeaderByHash(ctx, hash)
+}
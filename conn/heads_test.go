@@ -0,0 +1,156 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain builds a chain of headers from parent, with the given fork identifier.
+func testChain(headers map[common.Hash]*types.Header, parent *types.Header, length int, fork byte) []*types.Header {
+	chain := make([]*types.Header, 0, length)
+	for i := 0; i < length; i++ {
+		header := &types.Header{
+			Number:     big.NewInt(0),
+			Extra:      []byte{fork},
+			Difficulty: big.NewInt(0),
+		}
+		if parent != nil {
+			header.Number = new(big.Int).Add(parent.Number, big.NewInt(1))
+			header.ParentHash = parent.Hash()
+		}
+		headers[header.Hash()] = header
+		chain = append(chain, header)
+		parent = header
+	}
+	return chain
+}
+
+func TestHeadTracker(t *testing.T) {
+	headers := make(map[common.Hash]*types.Header)
+	// Main chain 0..9.
+	main := testChain(headers, nil, 10, 0)
+	// Fork from block 6 with blocks 7..10.
+	fork := testChain(headers, main[6], 4, 1)
+	// Replacement for block 9 with the same parent.
+	sibling := testChain(headers, main[8], 1, 2)[0]
+
+	fetch := func(ctx context.Context, hash common.Hash) (*types.Header, error) {
+		header, exists := headers[hash]
+		if !exists {
+			return nil, errors.New("not found")
+		}
+		return header, nil
+	}
+
+	tests := []struct {
+		name   string
+		heads  []*types.Header
+		events int
+		reorg  *Reorg
+	}{
+		{
+			name:   "Linear",
+			heads:  main,
+			events: 10,
+		},
+		{
+			name:   "Duplicate",
+			heads:  []*types.Header{main[7], main[8], main[8]},
+			events: 2,
+		},
+		{
+			name:   "Gap",
+			heads:  []*types.Header{main[3], main[7], main[8]},
+			events: 3,
+		},
+		{
+			name:   "Reorg",
+			heads:  []*types.Header{main[5], main[6], main[7], main[8], main[9], fork[3]},
+			events: 6,
+			reorg: &Reorg{
+				Depth:          3,
+				OldHead:        main[9].Hash(),
+				OldHeadNumber:  9,
+				CommonAncestor: 6,
+			},
+		},
+		{
+			name:   "ReorgShorter",
+			heads:  []*types.Header{main[7], main[8], main[9], fork[0]},
+			events: 4,
+			reorg: &Reorg{
+				Depth:          3,
+				OldHead:        main[9].Hash(),
+				OldHeadNumber:  9,
+				CommonAncestor: 6,
+			},
+		},
+		{
+			name:   "Sibling",
+			heads:  []*types.Header{main[8], main[9], sibling},
+			events: 3,
+			reorg: &Reorg{
+				Depth:          1,
+				OldHead:        main[9].Hash(),
+				OldHeadNumber:  9,
+				CommonAncestor: 8,
+			},
+		},
+		{
+			name:   "ReorgThenExtend",
+			heads:  []*types.Header{main[8], main[9], fork[2], fork[3]},
+			events: 4,
+			reorg: &Reorg{
+				Depth:          2,
+				OldHead:        main[9].Hash(),
+				OldHeadNumber:  9,
+				CommonAncestor: 7,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &headTracker{
+				fetch:  fetch,
+				hashes: make(map[uint64]common.Hash),
+			}
+			events := 0
+			var reorg *Reorg
+			for _, head := range test.heads {
+				event, err := tracker.track(context.Background(), head)
+				require.NoError(t, err)
+				if event == nil {
+					continue
+				}
+				events++
+				require.Equal(t, head.Hash(), event.Header.Hash())
+				if event.Reorg != nil {
+					require.Nil(t, reorg, "multiple reorgs")
+					reorg = event.Reorg
+				}
+			}
+			require.Equal(t, test.events, events)
+			require.Equal(t, test.reorg, reorg)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testHeadsService struct {
+	heads         chan *types.Header
+	subscriptions int32
+}
+
+func (s *testHeadsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	atomic.AddInt32(&s.subscriptions, 1)
+	go func() {
+		for {
+			select {
+			case head := <-s.heads:
+				if err := notifier.Notify(sub.ID, head); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func TestSubscribeHeadsReconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := &testHeadsService{heads: make(chan *types.Header)}
+	var mu sync.Mutex
+	var server *rpc.Server
+	startServer := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		server = newTestServer(t, map[string]interface{}{"eth": service})
+	}
+	startServer()
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current := server
+		mu.Unlock()
+		current.WebsocketHandler([]string{"*"}).ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+	c, err := conn.New(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http"))
+	require.NoError(t, err)
+
+	events := make(chan *conn.HeadEvent)
+	go func() {
+		_ = c.SubscribeHeads(ctx, time.Second, events)
+	}()
+
+	var parent *types.Header
+	sendHead := func(number int64) {
+		head := &types.Header{
+			Number:     big.NewInt(number),
+			Difficulty: big.NewInt(0),
+		}
+		if parent != nil {
+			head.ParentHash = parent.Hash()
+		}
+		parent = head
+		select {
+		case service.heads <- head:
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "subscription not established")
+		}
+		select {
+		case event := <-events:
+			require.Equal(t, head.Hash(), event.Header.Hash())
+			require.Nil(t, event.Reorg)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "head not received")
+		}
+	}
+
+	sendHead(1)
+	sendHead(2)
+
+	// Restart the server, dropping the connection.
+	mu.Lock()
+	server.Stop()
+	mu.Unlock()
+	startServer()
+
+	sendHead(3)
+	require.Equal(t, int32(2), atomic.LoadInt32(&service.subscriptions))
+}
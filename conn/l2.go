@@ -0,0 +1,253 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// Rollup types.
+const (
+	// RollupNone is a chain that is not a known layer 2 rollup.
+	RollupNone = ""
+	// RollupOPStack is an OP-stack chain, such as Optimism or Base.
+	RollupOPStack = "op-stack"
+	// RollupArbitrum is an Arbitrum chain.
+	RollupArbitrum = "arbitrum"
+)
+
+var (
+	// gasPriceOracleAddress is the address of the OP-stack gas price oracle predeploy.
+	gasPriceOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+	// arbSysAddress is the address of the Arbitrum system precompile.
+	arbSysAddress = common.HexToAddress("0x0000000000000000000000000000000000000064")
+	// nodeInterfaceAddress is the address of the Arbitrum node interface precompile.
+	nodeInterfaceAddress = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+)
+
+const l2ABIJSON = `[
+{"type":"function","name":"getL1Fee","stateMutability":"view","inputs":[{"name":"_data","type":"bytes"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"arbChainID","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"gasEstimateL1Component","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"contractCreation","type":"bool"},{"name":"data","type":"bytes"}],"outputs":[{"name":"gasEstimateForL1","type":"uint64"},{"name":"baseFee","type":"uint256"},{"name":"l1BaseFeeEstimate","type":"uint256"}]}
+]`
+
+var l2ABI abi.ABI
+
+func init() {
+	var err error
+	l2ABI, err = abi.JSON(strings.NewReader(l2ABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// L1Fee is the layer 1 data component of the cost of a layer 2 transaction.
+type L1Fee struct {
+	// Rollup is the type of rollup that charges the fee.
+	Rollup string
+	// Fee is the layer 1 data fee, in wei.
+	Fee *big.Int
+	// Gas is the layer 2 gas that pays for the layer 1 data.  It is only set on Arbitrum,
+	// where it is already included in the gas estimate for the transaction.
+	Gas uint64
+}
+
+// TransactionCost is the cost of a mined transaction.
+type TransactionCost struct {
+	// BlockNumber is the number of the block in which the transaction was mined.
+	BlockNumber uint64
+	// GasUsed is the layer 2 gas used by the transaction.
+	GasUsed uint64
+	// GasPrice is the effective gas price paid by the transaction.
+	GasPrice *big.Int
+	// ExecutionFee is the cost of the gas used by the transaction.
+	ExecutionFee *big.Int
+	// BlobFee is the cost of the blob gas used by the transaction, if any.
+	BlobFee *big.Int
+	// L1Fee is the layer 1 data fee charged in addition to the execution fee, if any.
+	L1Fee *big.Int
+	// L1Gas is the part of the gas used that paid for layer 1 data, if known.
+	L1Gas uint64
+	// Total is the total cost of the transaction.
+	Total *big.Int
+}
+
+// Rollup returns the type of layer 2 rollup of the chain, or RollupNone if it is not a known rollup.
+func (c *Conn) Rollup(ctx context.Context) (string, error) {
+	if c.rollup != nil {
+		return *c.rollup, nil
+	}
+	if c.offline {
+		return RollupNone, nil
+	}
+
+	rollup := RollupNone
+	data, err := l2ABI.Pack("arbChainID")
+	if err != nil {
+		return "", err
+	}
+	res, err := c.CallContract(ctx, ethereum.CallMsg{To: &arbSysAddress, Data: data}, nil, nil)
+	if err == nil && len(res) == 32 {
+		rollup = RollupArbitrum
+	} else {
+		opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		code, err := c.client.CodeAt(opCtx, gasPriceOracleAddress, nil)
+		cancel()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to check for gas price oracle")
+		}
+		if len(code) > 0 {
+			rollup = RollupOPStack
+		}
+	}
+
+	c.rollup = &rollup
+	return rollup, nil
+}
+
+// L1Fee returns the layer 1 data fee that the chain would charge for the transaction, in addition
+// to its layer 2 gas.  It returns nil if the chain is not a known rollup.
+func (c *Conn) L1Fee(ctx context.Context, tx *types.Transaction) (*L1Fee, error) {
+	rollup, err := c.Rollup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rollup {
+	case RollupOPStack:
+		return c.opStackL1Fee(ctx, tx)
+	case RollupArbitrum:
+		return c.arbitrumL1Fee(ctx, tx)
+	default:
+		return nil, nil
+	}
+}
+
+// opStackL1Fee obtains the layer 1 data fee from the OP-stack gas price oracle.
+func (c *Conn) opStackL1Fee(ctx context.Context, tx *types.Transaction) (*L1Fee, error) {
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode transaction")
+	}
+	data, err := l2ABI.Pack("getL1Fee", txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.CallContract(ctx, ethereum.CallMsg{To: &gasPriceOracleAddress, Data: data}, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain L1 fee from gas price oracle")
+	}
+	outputs, err := l2ABI.Unpack("getL1Fee", res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse L1 fee from gas price oracle")
+	}
+
+	return &L1Fee{
+		Rollup: RollupOPStack,
+		Fee:    outputs[0].(*big.Int),
+	}, nil
+}
+
+// arbitrumL1Fee obtains the layer 1 data fee from the Arbitrum node interface.
+func (c *Conn) arbitrumL1Fee(ctx context.Context, tx *types.Transaction) (*L1Fee, error) {
+	to := common.Address{}
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	data, err := l2ABI.Pack("gasEstimateL1Component", to, tx.To() == nil, tx.Data())
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.CallContract(ctx, ethereum.CallMsg{To: &nodeInterfaceAddress, Data: data}, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain L1 gas from node interface")
+	}
+	outputs, err := l2ABI.Unpack("gasEstimateL1Component", res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse L1 gas from node interface")
+	}
+	gas := outputs[0].(uint64)
+	baseFee := outputs[1].(*big.Int)
+
+	return &L1Fee{
+		Rollup: RollupArbitrum,
+		Fee:    new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gas)),
+		Gas:    gas,
+	}, nil
+}
+
+// rollupReceipt contains the cost-related fields of a transaction receipt, including those
+// added by rollups.
+type rollupReceipt struct {
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	BlobGasUsed       *hexutil.Uint64 `json:"blobGasUsed"`
+	BlobGasPrice      *hexutil.Big    `json:"blobGasPrice"`
+	L1Fee             *hexutil.Big    `json:"l1Fee"`
+	GasUsedForL1      *hexutil.Uint64 `json:"gasUsedForL1"`
+}
+
+// TransactionCost returns the cost of a mined transaction, including any layer 1 data fee.
+func (c *Conn) TransactionCost(ctx context.Context, hash common.Hash) (*TransactionCost, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain transaction cost when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var receipt *rollupReceipt
+	if err := c.rpcClient.CallContext(ctx, &receipt, "eth_getTransactionReceipt", hash); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain transaction receipt")
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	if receipt.EffectiveGasPrice == nil {
+		return nil, errors.New("transaction receipt does not contain effective gas price")
+	}
+
+	cost := &TransactionCost{
+		BlockNumber: uint64(receipt.BlockNumber),
+		GasUsed:     uint64(receipt.GasUsed),
+		GasPrice:    receipt.EffectiveGasPrice.ToInt(),
+	}
+	cost.ExecutionFee = new(big.Int).Mul(cost.GasPrice, new(big.Int).SetUint64(cost.GasUsed))
+	cost.Total = new(big.Int).Set(cost.ExecutionFee)
+	if receipt.BlobGasUsed != nil && receipt.BlobGasPrice != nil {
+		cost.BlobFee = new(big.Int).Mul(receipt.BlobGasPrice.ToInt(), new(big.Int).SetUint64(uint64(*receipt.BlobGasUsed)))
+		cost.Total.Add(cost.Total, cost.BlobFee)
+	}
+	if receipt.L1Fee != nil {
+		cost.L1Fee = receipt.L1Fee.ToInt()
+		cost.Total.Add(cost.Total, cost.L1Fee)
+	}
+	if receipt.GasUsedForL1 != nil {
+		// Arbitrum charges for layer 1 data through layer 2 gas, so it is already in the execution fee.
+		cost.L1Gas = uint64(*receipt.GasUsedForL1)
+		cost.L1Fee = new(big.Int).Mul(cost.GasPrice, new(big.Int).SetUint64(cost.L1Gas))
+	}
+
+	return cost, nil
+}
@@ -0,0 +1,192 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testL2Service struct {
+	rollup  string
+	receipt map[string]interface{}
+}
+
+func (s *testL2Service) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(10))
+}
+
+func (s *testL2Service) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	switch common.HexToAddress(args["to"].(string)) {
+	case common.HexToAddress("0x64"):
+		if s.rollup == conn.RollupArbitrum {
+			return common.LeftPadBytes(big.NewInt(42161).Bytes(), 32), nil
+		}
+	case common.HexToAddress("0x420000000000000000000000000000000000000F"):
+		if s.rollup == conn.RollupOPStack {
+			return common.LeftPadBytes(big.NewInt(1000).Bytes(), 32), nil
+		}
+	case common.HexToAddress("0xC8"):
+		if s.rollup == conn.RollupArbitrum {
+			res := common.LeftPadBytes(big.NewInt(500).Bytes(), 32)
+			res = append(res, common.LeftPadBytes(big.NewInt(10).Bytes(), 32)...)
+			return append(res, common.LeftPadBytes(big.NewInt(20).Bytes(), 32)...), nil
+		}
+	}
+	return hexutil.Bytes{}, nil
+}
+
+func (s *testL2Service) GetCode(address common.Address, block string) hexutil.Bytes {
+	if s.rollup == conn.RollupOPStack && address == common.HexToAddress("0x420000000000000000000000000000000000000F") {
+		return hexutil.Bytes{0x60, 0x80}
+	}
+	return hexutil.Bytes{}
+}
+
+func (s *testL2Service) GetTransactionReceipt(hash common.Hash) map[string]interface{} {
+	return s.receipt
+}
+
+func TestL1Fee(t *testing.T) {
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(10),
+		Nonce:     1,
+		GasFeeCap: big.NewInt(100),
+		GasTipCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+
+	tests := []struct {
+		name   string
+		rollup string
+		fee    *conn.L1Fee
+	}{
+		{
+			name:   "None",
+			rollup: conn.RollupNone,
+		},
+		{
+			name:   "OPStack",
+			rollup: conn.RollupOPStack,
+			fee: &conn.L1Fee{
+				Rollup: conn.RollupOPStack,
+				Fee:    big.NewInt(1000),
+			},
+		},
+		{
+			name:   "Arbitrum",
+			rollup: conn.RollupArbitrum,
+			fee: &conn.L1Fee{
+				Rollup: conn.RollupArbitrum,
+				Fee:    big.NewInt(5000),
+				Gas:    500,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConn(t, &testL2Service{rollup: test.rollup})
+			rollup, err := c.Rollup(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.rollup, rollup)
+			fee, err := c.L1Fee(context.Background(), tx)
+			require.NoError(t, err)
+			require.Equal(t, test.fee, fee)
+		})
+	}
+}
+
+func TestTransactionCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		receipt map[string]interface{}
+		cost    *conn.TransactionCost
+		err     string
+	}{
+		{
+			name: "Missing",
+			err:  "not found",
+		},
+		{
+			name: "L1",
+			receipt: map[string]interface{}{
+				"blockNumber":       "0x10",
+				"gasUsed":           "0x5208",
+				"effectiveGasPrice": "0x64",
+			},
+			cost: &conn.TransactionCost{
+				BlockNumber:  16,
+				GasUsed:      21000,
+				GasPrice:     big.NewInt(100),
+				ExecutionFee: big.NewInt(2100000),
+				Total:        big.NewInt(2100000),
+			},
+		},
+		{
+			name: "OPStack",
+			receipt: map[string]interface{}{
+				"gasUsed":           "0x5208",
+				"effectiveGasPrice": "0x64",
+				"l1Fee":             "0x3e8",
+			},
+			cost: &conn.TransactionCost{
+				GasUsed:      21000,
+				GasPrice:     big.NewInt(100),
+				ExecutionFee: big.NewInt(2100000),
+				L1Fee:        big.NewInt(1000),
+				Total:        big.NewInt(2101000),
+			},
+		},
+		{
+			name: "Arbitrum",
+			receipt: map[string]interface{}{
+				"gasUsed":           "0x5208",
+				"effectiveGasPrice": "0x64",
+				"gasUsedForL1":      "0x1f4",
+			},
+			cost: &conn.TransactionCost{
+				GasUsed:      21000,
+				GasPrice:     big.NewInt(100),
+				ExecutionFee: big.NewInt(2100000),
+				L1Fee:        big.NewInt(50000),
+				L1Gas:        500,
+				Total:        big.NewInt(2100000),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConn(t, &testL2Service{receipt: test.receipt})
+			cost, err := c.TransactionCost(context.Background(), common.Hash{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.cost, cost)
+			}
+		})
+	}
+}
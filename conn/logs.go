@@ -0,0 +1,237 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// logKey uniquely identifies a log.
+type logKey struct {
+	txHash common.Hash
+	index  uint
+}
+
+// logTracker tracks the logs that have been delivered, to avoid duplicates when
+// backfilling after a reconnection.
+type logTracker struct {
+	block uint64
+	seen  map[logKey]bool
+}
+
+// deliver sends the log to the channel if it has not already been sent.
+func (t *logTracker) deliver(ctx context.Context, log types.Log, ch chan<- types.Log) error {
+	if log.Removed {
+		// Pass through removals from reorgs as-is.
+		select {
+		case ch <- log:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if log.BlockNumber < t.block {
+		return nil
+	}
+	if log.BlockNumber > t.block {
+		t.block = log.BlockNumber
+		t.seen = make(map[logKey]bool)
+	}
+	key := logKey{txHash: log.TxHash, index: log.Index}
+	if t.seen[key] {
+		return nil
+	}
+	t.seen[key] = true
+
+	select {
+	case ch <- log:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SubscribeLogs streams logs matching the query to the supplied channel until the context is done.
+// If the connection supports subscriptions they are used, and re-established if they fail,
+// for example if a websocket or IPC connection drops whilst the node restarts; otherwise the
+// connection is polled at the supplied interval.
+// Logs missed whilst re-establishing a subscription are backfilled.
+func (c *Conn) SubscribeLogs(ctx context.Context,
+	query ethereum.FilterQuery,
+	interval time.Duration,
+	ch chan<- types.Log,
+) error {
+	if c.client == nil {
+		return errors.New("cannot subscribe to logs when offline")
+	}
+
+	tracker := &logTracker{seen: make(map[logKey]bool)}
+	if query.FromBlock == nil {
+		// Start from the current block.
+		blockNumber, err := c.blockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		tracker.block = blockNumber
+	} else {
+		tracker.block = query.FromBlock.Uint64()
+	}
+	query.FromBlock = nil
+	query.ToBlock = nil
+
+	retry := &backoff{}
+	for {
+		// Backfill anything since the last block we saw.
+		if err := c.backfillLogs(ctx, query, tracker, ch); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// The node may be restarting; try again after backing off.
+			if !retry.wait(ctx) {
+				return nil
+			}
+			continue
+		}
+
+		subCh := make(chan types.Log)
+		sub, err := c.client.SubscribeFilterLogs(ctx, query, subCh)
+		if err != nil {
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				return c.pollLogs(ctx, query, interval, tracker, ch)
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Try again after backing off.
+			if !retry.wait(ctx) {
+				return nil
+			}
+			continue
+		}
+		retry.reset()
+
+		err = c.receiveLogs(ctx, sub, subCh, tracker, ch)
+		sub.Unsubscribe()
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		// Subscription failed; loop round to resubscribe.
+	}
+}
+
+// receiveLogs receives logs from a subscription until the subscription fails or the context is done.
+func (c *Conn) receiveLogs(ctx context.Context,
+	sub ethereum.Subscription,
+	subCh <-chan types.Log,
+	tracker *logTracker,
+	ch chan<- types.Log,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case log := <-subCh:
+			if err := tracker.deliver(ctx, log, ch); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// pollLogs polls for logs at the given interval until the context is done.
+func (c *Conn) pollLogs(ctx context.Context,
+	query ethereum.FilterQuery,
+	interval time.Duration,
+	tracker *logTracker,
+	ch chan<- types.Log,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		if err := c.backfillLogs(ctx, query, tracker, ch); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Transient failure; try again next time round.
+			continue
+		}
+	}
+}
+
+// backfillLogs fetches logs from the last block seen by the tracker to the current block.
+func (c *Conn) backfillLogs(ctx context.Context,
+	query ethereum.FilterQuery,
+	tracker *logTracker,
+	ch chan<- types.Log,
+) error {
+	blockNumber, err := c.blockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if blockNumber < tracker.block {
+		return nil
+	}
+	query.FromBlock = new(big.Int).SetUint64(tracker.block)
+	query.ToBlock = new(big.Int).SetUint64(blockNumber)
+
+	opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	logs, err := c.client.FilterLogs(opCtx, query)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain logs")
+	}
+	for _, log := range logs {
+		if err := tracker.deliver(ctx, log, ch); err != nil {
+			return err
+		}
+	}
+	if blockNumber > tracker.block {
+		// Move on so that the next fetch does not cover the same blocks again.
+		tracker.block = blockNumber
+		tracker.seen = make(map[logKey]bool)
+		for _, log := range logs {
+			if log.BlockNumber == blockNumber {
+				tracker.seen[logKey{txHash: log.TxHash, index: log.Index}] = true
+			}
+		}
+	}
+	return nil
+}
+
+// blockNumber obtains the current block number.
+func (c *Conn) blockNumber(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	blockNumber, err := c.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain block number")
+	}
+	return blockNumber, nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testLogsService struct {
+	mu            sync.Mutex
+	block         uint64
+	logs          []types.Log
+	notifications chan types.Log
+	subscriptions int32
+}
+
+func (s *testLogsService) BlockNumber() hexutil.Uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return hexutil.Uint64(s.block)
+}
+
+func (s *testLogsService) GetLogs(crit map[string]interface{}) ([]types.Log, error) {
+	from, err := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	to, err := hexutil.DecodeUint64(crit["toBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]types.Log, 0)
+	for _, log := range s.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			res = append(res, log)
+		}
+	}
+	return res, nil
+}
+
+func (s *testLogsService) Logs(ctx context.Context, crit map[string]interface{}) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	atomic.AddInt32(&s.subscriptions, 1)
+	go func() {
+		for {
+			select {
+			case log := <-s.notifications:
+				if err := notifier.Notify(sub.ID, log); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// addLog adds a log to those available to eth_getLogs, and moves the chain on to its block.
+func (s *testLogsService) addLog(log types.Log) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, log)
+	if log.BlockNumber > s.block {
+		s.block = log.BlockNumber
+	}
+}
+
+func testLog(blockNumber uint64, index uint) types.Log {
+	return types.Log{
+		Address:     common.HexToAddress("0x0000000000000000000000000000000000000001"),
+		Topics:      []common.Hash{},
+		Data:        []byte{},
+		BlockNumber: blockNumber,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber + 1000)),
+		Index:       index,
+	}
+}
+
+// receiveLog receives a log from the channel, failing if none arrives in time.
+func receiveLog(t *testing.T, ch <-chan types.Log) types.Log {
+	select {
+	case log := <-ch:
+		return log
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "log not received")
+	}
+	return types.Log{}
+}
+
+// requireNoLog ensures that no further log is received.
+func requireNoLog(t *testing.T, ch <-chan types.Log) {
+	select {
+	case log := <-ch:
+		require.Failf(t, "unexpected log", "block %d index %d", log.BlockNumber, log.Index)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestSubscribeLogsReconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := &testLogsService{
+		block:         10,
+		notifications: make(chan types.Log),
+	}
+	var mu sync.Mutex
+	var server *rpc.Server
+	startServer := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		server = newTestServer(t, map[string]interface{}{"eth": service})
+	}
+	startServer()
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current := server
+		mu.Unlock()
+		current.WebsocketHandler([]string{"*"}).ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+	c, err := conn.New(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http"))
+	require.NoError(t, err)
+
+	logs := make(chan types.Log)
+	go func() {
+		_ = c.SubscribeLogs(ctx, ethereum.FilterQuery{}, time.Second, logs)
+	}()
+
+	notify := func(log types.Log) {
+		select {
+		case service.notifications <- log:
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "subscription not established")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&service.subscriptions) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	log1 := testLog(11, 0)
+	service.addLog(log1)
+	notify(log1)
+	require.Equal(t, log1, receiveLog(t, logs))
+
+	// Logs emitted whilst the connection is down are only available to eth_getLogs.
+	log2 := testLog(11, 1)
+	log3 := testLog(12, 0)
+	service.addLog(log2)
+	service.addLog(log3)
+
+	// Restart the server, dropping the connection.
+	mu.Lock()
+	server.Stop()
+	mu.Unlock()
+	startServer()
+
+	// The missed logs are backfilled, without repeating the log already delivered.
+	require.Equal(t, log2, receiveLog(t, logs))
+	require.Equal(t, log3, receiveLog(t, logs))
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&service.subscriptions) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// A log notified again by the new subscription is not repeated.
+	log4 := testLog(13, 0)
+	service.addLog(log4)
+	notify(log3)
+	notify(log4)
+	require.Equal(t, log4, receiveLog(t, logs))
+	requireNoLog(t, logs)
+}
+
+func TestSubscribeLogsPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := &testLogsService{
+		block:         5,
+		notifications: make(chan types.Log),
+	}
+	log1 := testLog(5, 0)
+	service.addLog(log1)
+	c := newTestConn(t, service)
+
+	logs := make(chan types.Log)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.SubscribeLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(5)}, 10*time.Millisecond, logs)
+	}()
+
+	// Notifications are not available over HTTP, so the logs are polled.
+	require.Equal(t, log1, receiveLog(t, logs))
+	log2 := testLog(6, 0)
+	service.addLog(log2)
+	require.Equal(t, log2, receiveLog(t, logs))
+	log3 := testLog(6, 1)
+	service.addLog(log3)
+	require.Equal(t, log3, receiveLog(t, logs))
+	requireNoLog(t, logs)
+	require.Equal(t, int32(0), atomic.LoadInt32(&service.subscriptions))
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "subscription did not stop")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -44,33 +45,96 @@ func (c *Conn) currentNonce(ctx context.Context,
 	uint64,
 	error,
 ) {
-	_, exists := c.nonces[address]
-	if !exists {
+	if _, exists := c.nonces[address]; exists {
+		return c.nonces[address], nil
+	}
+
+	// Nonce can be supplied as a number, or as the source from which to obtain it.
+	source := strings.ToLower(viper.GetString("nonce"))
+	switch source {
+	case "", "auto", "pending", "latest":
 		if c.client == nil {
-			// Offline, fetch from supplied value.
-			tmp := viper.GetString("nonce")
-			if tmp == "" {
-				return 0, errors.New("nonce not supplied")
-			}
-			nonce, err := strconv.ParseUint(tmp, 10, 64)
-			if err != nil {
-				return 0, errors.Wrap(err, "invalid nonce")
-			}
-			c.nonces[address] = nonce
-		} else {
-			// Fetch from chain.
-			ctx, cancel := context.WithTimeout(ctx, c.timeout)
-			defer cancel()
-			nonce, err := c.client.PendingNonceAt(ctx, address)
-			if err != nil {
-				return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain nonce for %s", address.Hex()))
-			}
-			c.nonces[address] = nonce
+			return 0, errors.New("nonce not supplied")
+		}
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		var nonce uint64
+		var err error
+		switch source {
+		case "latest":
+			nonce, err = c.client.NonceAt(ctx, address, nil)
+		case "pending":
+			nonce, err = c.client.PendingNonceAt(ctx, address)
+		default:
+			nonce, err = c.autoNonce(ctx, address)
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain nonce for %s", address.Hex()))
+		}
+		c.nonces[address] = nonce
+	default:
+		nonce, err := strconv.ParseUint(source, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid nonce")
 		}
+		c.nonces[address] = nonce
 	}
+
 	return c.nonces[address], nil
 }
 
+// autoNonce obtains the higher of the pending and latest nonces.  Nodes behind load balancers
+// can return a pending nonce from a node that has fallen behind, which would be lower than the
+// latest nonce and so already used.
+func (c *Conn) autoNonce(ctx context.Context, address common.Address) (uint64, error) {
+	pending, err := c.client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	latest, err := c.client.NonceAt(ctx, address, nil)
+	if err != nil {
+		return 0, err
+	}
+	if latest > pending {
+		return latest, nil
+	}
+	return pending, nil
+}
+
+// ReserveNonce reserves the current nonce for the given address, returning it.  Subsequent
+// calls for the same address will return incrementing nonces.
+func (c *Conn) ReserveNonce(ctx context.Context,
+	address common.Address,
+) (
+	uint64,
+	error,
+) {
+	c.noncesMu.Lock()
+	defer c.noncesMu.Unlock()
+
+	nonce, err := c.currentNonce(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	c.nonces[address]++
+
+	return nonce, nil
+}
+
+// ReleaseNonce releases a nonce reserved with ReserveNonce, for example if the transaction that
+// would have used it could not be created.  The nonce is only released if it was the most recent
+// to be reserved for the address.
+func (c *Conn) ReleaseNonce(address common.Address,
+	nonce uint64,
+) {
+	c.noncesMu.Lock()
+	defer c.noncesMu.Unlock()
+
+	if current, exists := c.nonces[address]; exists && current == nonce+1 {
+		c.nonces[address]--
+	}
+}
+
 // NextNonce obtains the next nonce for the given address.
 func (c *Conn) NextNonce(ctx context.Context,
 	address common.Address,
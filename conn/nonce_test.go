@@ -0,0 +1,136 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+func TestReserveNonce(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+	viper.Set("chainid", "1")
+	defer viper.Set("chainid", "")
+
+	viper.Set("nonce", "auto")
+	c, err := conn.New(ctx, "offline")
+	require.NoError(t, err)
+	_, err = c.ReserveNonce(ctx, address)
+	require.EqualError(t, err, "nonce not supplied")
+
+	viper.Set("nonce", "bad")
+	_, err = c.ReserveNonce(ctx, address)
+	require.EqualError(t, err, `invalid nonce: strconv.ParseUint: parsing "bad": invalid syntax`)
+
+	viper.Set("nonce", "5")
+	defer viper.Set("nonce", "")
+	nonce, err := c.ReserveNonce(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+	nonce, err = c.ReserveNonce(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), nonce)
+
+	// Releasing an older nonce has no effect.
+	c.ReleaseNonce(address, 5)
+	nonce, err = c.CurrentNonce(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), nonce)
+
+	// Releasing the most recent nonce makes it available again.
+	c.ReleaseNonce(address, 6)
+	nonce, err = c.ReserveNonce(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), nonce)
+}
+
+type testNonceService struct {
+	pending uint64
+	latest  uint64
+}
+
+func (s *testNonceService) GetTransactionCount(address common.Address, block string) hexutil.Uint64 {
+	if block == "pending" {
+		return hexutil.Uint64(s.pending)
+	}
+	return hexutil.Uint64(s.latest)
+}
+
+func TestCurrentNonceSource(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	defer viper.Set("nonce", "")
+
+	tests := []struct {
+		name    string
+		source  string
+		pending uint64
+		latest  uint64
+		nonce   uint64
+	}{
+		{
+			name:    "AutoPending",
+			source:  "auto",
+			pending: 12,
+			latest:  10,
+			nonce:   12,
+		},
+		{
+			name:    "AutoStalePending",
+			source:  "auto",
+			pending: 8,
+			latest:  10,
+			nonce:   10,
+		},
+		{
+			name:    "Default",
+			source:  "",
+			pending: 8,
+			latest:  10,
+			nonce:   10,
+		},
+		{
+			name:    "Pending",
+			source:  "pending",
+			pending: 8,
+			latest:  10,
+			nonce:   8,
+		},
+		{
+			name:    "Latest",
+			source:  "latest",
+			pending: 12,
+			latest:  10,
+			nonce:   10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("nonce", test.source)
+			c := newTestConn(t, &testNonceService{pending: test.pending, latest: test.latest})
+			nonce, err := c.CurrentNonce(ctx, address)
+			require.NoError(t, err)
+			require.Equal(t, test.nonce, nonce)
+		})
+	}
+}
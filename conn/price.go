@@ -0,0 +1,141 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const priceFeedABIJSON = `[
+{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+{"type":"function","name":"description","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"latestRoundData","stateMutability":"view","inputs":[],"outputs":[{"name":"roundId","type":"uint80"},{"name":"answer","type":"int256"},{"name":"startedAt","type":"uint256"},{"name":"updatedAt","type":"uint256"},{"name":"answeredInRound","type":"uint80"}]}
+]`
+
+var priceFeedABI abi.ABI
+
+func init() {
+	var err error
+	priceFeedABI, err = abi.JSON(strings.NewReader(priceFeedABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// FeedRound is the latest round of data from a Chainlink-compatible price feed.
+type FeedRound struct {
+	// Description is the description of the feed, for example "ETH / USD".
+	Description string
+	// Decimals is the number of decimals in the answer.
+	Decimals uint8
+	// RoundID is the ID of the round.
+	RoundID *big.Int
+	// Answer is the answer for the round, scaled by 10^Decimals.
+	Answer *big.Int
+	// StartedAt is the time at which the round started.
+	StartedAt time.Time
+	// UpdatedAt is the time at which the answer was last updated.
+	UpdatedAt time.Time
+	// AnsweredInRound is the ID of the round in which the answer was computed.
+	AnsweredInRound *big.Int
+}
+
+// Price is the price of Ether from a price feed.
+type Price struct {
+	// Price is the price of 1 Ether, scaled by 10^Decimals.
+	Price *big.Int
+	// Decimals is the number of decimals in the price.
+	Decimals uint8
+	// Currency is the currency in which the price is quoted, for example "USD".
+	Currency string
+	// UpdatedAt is the time at which the price was last updated.
+	UpdatedAt time.Time
+}
+
+// Value returns the value of an amount of Wei in the currency of the price.
+func (p *Price) Value(wei *big.Int) *big.Float {
+	value := new(big.Float).SetInt(new(big.Int).Mul(wei, p.Price))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(18+int64(p.Decimals)), nil)
+	return value.Quo(value, new(big.Float).SetInt(scale))
+}
+
+// LatestRoundData returns the latest round of data from a Chainlink-compatible price feed.
+func (c *Conn) LatestRoundData(ctx context.Context, feed common.Address) (*FeedRound, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain price feed data when offline")
+	}
+
+	outputs := make(map[string][]interface{})
+	for _, method := range []string{"decimals", "description", "latestRoundData"} {
+		data, err := priceFeedABI.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.CallContract(ctx, ethereum.CallMsg{To: &feed, Data: data}, nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to call %s on price feed", method)
+		}
+		outputs[method], err = priceFeedABI.Unpack(method, res)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s from price feed", method)
+		}
+	}
+
+	roundData := outputs["latestRoundData"]
+	return &FeedRound{
+		Description:     outputs["description"][0].(string),
+		Decimals:        outputs["decimals"][0].(uint8),
+		RoundID:         roundData[0].(*big.Int),
+		Answer:          roundData[1].(*big.Int),
+		StartedAt:       time.Unix(roundData[2].(*big.Int).Int64(), 0),
+		UpdatedAt:       time.Unix(roundData[3].(*big.Int).Int64(), 0),
+		AnsweredInRound: roundData[4].(*big.Int),
+	}, nil
+}
+
+// EtherPrice returns the price of Ether from a Chainlink-compatible price feed, for example
+// the ETH / USD feed.
+func (c *Conn) EtherPrice(ctx context.Context, feed common.Address) (*Price, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain price when offline")
+	}
+
+	round, err := c.LatestRoundData(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+	if round.Answer.Sign() <= 0 {
+		return nil, errors.New("price feed returned an invalid price")
+	}
+	currency := round.Description
+	if parts := strings.Split(currency, "/"); len(parts) == 2 {
+		// Descriptions are of the form "ETH / USD".
+		currency = parts[1]
+	}
+
+	return &Price{
+		Price:     round.Answer,
+		Decimals:  round.Decimals,
+		Currency:  strings.TrimSpace(currency),
+		UpdatedAt: round.UpdatedAt,
+	}, nil
+}
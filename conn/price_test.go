@@ -0,0 +1,109 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testPriceService struct {
+	price *big.Int
+}
+
+func (s *testPriceService) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input, ok := args["data"]
+	if !ok {
+		input = args["input"]
+	}
+	switch input.(string)[:10] {
+	case "0x313ce567":
+		// decimals()
+		return common.LeftPadBytes([]byte{8}, 32), nil
+	case "0x7284e416":
+		// description()
+		res := common.LeftPadBytes([]byte{0x20}, 32)
+		res = append(res, common.LeftPadBytes([]byte{9}, 32)...)
+		return append(res, common.RightPadBytes([]byte("ETH / USD"), 32)...), nil
+	case "0xfeaf968c":
+		// latestRoundData()
+		res := common.LeftPadBytes([]byte{1}, 32)
+		res = append(res, common.LeftPadBytes(s.price.Bytes(), 32)...)
+		res = append(res, common.LeftPadBytes(big.NewInt(1700000000).Bytes(), 32)...)
+		res = append(res, common.LeftPadBytes(big.NewInt(1700000000).Bytes(), 32)...)
+		return append(res, common.LeftPadBytes([]byte{1}, 32)...), nil
+	}
+	return hexutil.Bytes{}, nil
+}
+
+func TestEtherPrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		price *big.Int
+		err   string
+	}{
+		{
+			name:  "Good",
+			price: big.NewInt(250000000000),
+		},
+		{
+			name:  "Zero",
+			price: big.NewInt(0),
+			err:   "price feed returned an invalid price",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConn(t, &testPriceService{price: test.price})
+
+			price, err := c.EtherPrice(context.Background(), common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.price, price.Price)
+				require.Equal(t, uint8(8), price.Decimals)
+				require.Equal(t, "USD", price.Currency)
+				require.Equal(t, time.Unix(1700000000, 0), price.UpdatedAt)
+				// 0.01 Ether at 2500 USD is 25 USD.
+				value, _ := price.Value(big.NewInt(10000000000000000)).Float64()
+				require.Equal(t, 25.0, value)
+			}
+		})
+	}
+}
+
+func TestLatestRoundData(t *testing.T) {
+	c := newTestConn(t, &testPriceService{price: big.NewInt(250000000000)})
+
+	round, err := c.LatestRoundData(context.Background(), common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"))
+	require.NoError(t, err)
+	require.Equal(t, &conn.FeedRound{
+		Description:     "ETH / USD",
+		Decimals:        8,
+		RoundID:         big.NewInt(1),
+		Answer:          big.NewInt(250000000000),
+		StartedAt:       time.Unix(1700000000, 0),
+		UpdatedAt:       time.Unix(1700000000, 0),
+		AnsweredInRound: big.NewInt(1),
+	}, round)
+}
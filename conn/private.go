@@ -0,0 +1,234 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// DefaultPrivateRelay is the relay used for private transactions if none is configured.
+const DefaultPrivateRelay = "https://rpc.flashbots.net/"
+
+// DefaultBundleRelay is the relay used for bundles if none is supplied.
+const DefaultBundleRelay = "https://relay.flashbots.net/"
+
+// privateRelay is a relay that accepts transactions without passing them to the public mempool,
+// such as Flashbots Protect.
+type privateRelay struct {
+	url string
+	// key signs requests to the relay.  It identifies the sender to the relay, and need not hold
+	// any funds.
+	key *ecdsa.PrivateKey
+}
+
+// newPrivateRelay creates the private relay for transactions from the configuration.
+func newPrivateRelay() (*privateRelay, error) {
+	url := viper.GetString("private-relay")
+	if url == "" {
+		url = DefaultPrivateRelay
+	}
+	return newRelay(url)
+}
+
+// newRelay creates a relay at the given URL.  Requests are signed with the key configured
+// with private-relay-key, or a new key if none is configured.
+func newRelay(url string) (*privateRelay, error) {
+	relay := &privateRelay{
+		url: url,
+	}
+	if !isHTTPEndpoint(relay.url) {
+		return nil, fmt.Errorf("private relay %s does not use HTTP", relay.url)
+	}
+
+	var err error
+	if viper.GetString("private-relay-key") != "" {
+		relay.key, err = crypto.HexToECDSA(strings.TrimPrefix(viper.GetString("private-relay-key"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid private relay key")
+		}
+	} else {
+		relay.key, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate private relay key")
+		}
+	}
+	return relay, nil
+}
+
+// signature returns the value of the X-Flashbots-Signature header for a request body, which
+// is the address of the key followed by its signature of the hash of the body.
+func (r *privateRelay) signature(body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body).Hex()
+	sig, err := crypto.Sign(accounts.TextHash([]byte(hash)), r.key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", crypto.PubkeyToAddress(r.key.PublicKey).Hex(), hexutil.Encode(sig)), nil
+}
+
+// dial returns an RPC client for the relay.
+func (r *privateRelay) dial() (*rpc.Client, error) {
+	return rpc.DialHTTPWithClient(r.url, &http.Client{
+		Transport: &relayTransport{
+			base:  http.DefaultTransport,
+			relay: r,
+		},
+	})
+}
+
+// relayTransport is an HTTP transport that sends requests to the private relay, signing each of them.
+type relayTransport struct {
+	base  http.RoundTripper
+	relay *privateRelay
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *relayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := t.relay.signature(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign request to private relay")
+	}
+
+	relayReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, t.relay.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	relayReq.Header.Set("Content-Type", "application/json")
+	relayReq.Header.Set("X-Flashbots-Signature", signature)
+	return t.base.RoundTrip(relayReq)
+}
+
+// privateTransport is an HTTP transport that sends transactions to the private relay, and
+// all other requests to the next transport.
+type privateTransport struct {
+	next  http.RoundTripper
+	relay *relayTransport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *privateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	sends, others := countTransactionSends(body)
+	if sends == 0 {
+		return t.next.RoundTrip(req)
+	}
+	if others > 0 {
+		return nil, errors.New("cannot send transactions privately in a batch with other requests")
+	}
+	return t.relay.RoundTrip(req)
+}
+
+// readBody reads the body of a request, leaving it in place to be read again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return []byte{}, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// countTransactionSends returns the number of JSON-RPC requests in the body that send
+// transactions, and the number of other requests.
+func countTransactionSends(body []byte) (int, int) {
+	type request struct {
+		Method string `json:"method"`
+	}
+	requests := make([]*request, 0)
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return 0, 1
+		}
+	} else {
+		single := &request{}
+		if err := json.Unmarshal(body, single); err != nil {
+			return 0, 1
+		}
+		requests = append(requests, single)
+	}
+
+	sends := 0
+	for _, request := range requests {
+		if request != nil && request.Method == "eth_sendRawTransaction" {
+			sends++
+		}
+	}
+	return sends, len(requests) - sends
+}
+
+// SendPrivateTransaction sends the supplied transaction to the private relay rather than the
+// network, so that it does not enter the public mempool.
+func (c *Conn) SendPrivateTransaction(ctx context.Context,
+	tx *types.Transaction,
+) error {
+	if c.client == nil {
+		return errors.New("cannot send transaction when offline")
+	}
+	if c.dryRun {
+		return ErrDryRun
+	}
+	if c.relay == nil {
+		relay, err := newPrivateRelay()
+		if err != nil {
+			return err
+		}
+		c.relay = relay
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	rpcClient, err := c.relay.dial()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to private relay")
+	}
+	defer rpcClient.Close()
+	if err := ethclient.NewClient(rpcClient).SendTransaction(ctx, tx); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return nil
+		}
+		return errors.Wrap(err, "failed to send transaction to private relay")
+	}
+	return nil
+}
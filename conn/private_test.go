@@ -0,0 +1,129 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+// newTestRelay creates a relay that records the signers of the requests it receives.
+func newTestRelay(t *testing.T, signers *[]common.Address) (*httptest.Server, *testEndpointService) {
+	service := &testEndpointService{chainID: 1}
+	server := newTestServer(t, map[string]interface{}{"eth": service})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		parts := strings.Split(r.Header.Get("X-Flashbots-Signature"), ":")
+		if len(parts) != 2 {
+			http.Error(w, "missing signature", http.StatusUnauthorized)
+			return
+		}
+		hash := accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex()))
+		pubKey, err := crypto.SigToPub(hash, common.FromHex(parts[1]))
+		if err != nil || crypto.PubkeyToAddress(*pubKey) != common.HexToAddress(parts[0]) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		*signers = append(*signers, common.HexToAddress(parts[0]))
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer, service
+}
+
+func TestPrivate(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signers := make([]common.Address, 0)
+	relay, relayService := newTestRelay(t, &signers)
+	viper.Set("private", true)
+	defer viper.Set("private", nil)
+	viper.Set("private-relay", relay.URL)
+	defer viper.Set("private-relay", nil)
+	viper.Set("private-relay-key", hexutil.Encode(crypto.FromECDSA(key)))
+	defer viper.Set("private-relay-key", nil)
+
+	endpoint := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, endpoint.server.URL)
+	require.NoError(t, err)
+
+	// Transactions sent through the connection and the client both go to the relay.
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	require.NoError(t, c.SendTransaction(ctx, tx))
+	require.NoError(t, c.Client().SendTransaction(ctx, tx))
+	require.Equal(t, int32(2), atomic.LoadInt32(&relayService.sent))
+	require.Equal(t, int32(0), atomic.LoadInt32(&endpoint.service.sent))
+	require.Equal(t, []common.Address{crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(key.PublicKey)}, signers)
+
+	// Other requests go to the endpoint.
+	calls := atomic.LoadInt32(&endpoint.service.calls)
+	_, err = c.Client().ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, calls+1, atomic.LoadInt32(&endpoint.service.calls))
+	require.Equal(t, int32(0), atomic.LoadInt32(&relayService.calls))
+
+	// Private transactions require an HTTP connection.
+	_, err = conn.New(ctx, "/tmp/geth.ipc")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "require a connection over HTTP")
+}
+
+func TestSendPrivateTransaction(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", 5*time.Second)
+	defer viper.Set("timeout", nil)
+
+	signers := make([]common.Address, 0)
+	relay, relayService := newTestRelay(t, &signers)
+	viper.Set("private-relay", relay.URL)
+	defer viper.Set("private-relay", nil)
+
+	endpoint := newTestEndpoint(t, 1)
+	c, err := conn.New(ctx, endpoint.server.URL)
+	require.NoError(t, err)
+
+	// A key is generated if none is configured.
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	require.NoError(t, c.SendPrivateTransaction(ctx, tx))
+	require.Equal(t, int32(1), atomic.LoadInt32(&relayService.sent))
+	require.Len(t, signers, 1)
+
+	// Without private transactions enabled other sends go to the endpoint.
+	require.NoError(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(1), atomic.LoadInt32(&endpoint.service.sent))
+}
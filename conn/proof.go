@@ -0,0 +1,74 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// AccountProof is the Merkle proof of an account and some of its storage.
+type AccountProof struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []*StorageProof `json:"storageProof"`
+}
+
+// StorageProof is the Merkle proof of a storage slot.
+type StorageProof struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// Proof returns the Merkle proof of an account and the given storage slots at the given
+// block (nil for latest).
+func (c *Conn) Proof(ctx context.Context,
+	address common.Address,
+	keys []common.Hash,
+	blockNumber *big.Int,
+) (
+	*AccountProof,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot obtain proof when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if keys == nil {
+		keys = []common.Hash{}
+	}
+	var proof *AccountProof
+	if err := c.rpcClient.CallContext(ctx, &proof, "eth_getProof", address, keys, toBlockNumArg(blockNumber)); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proof")
+	}
+	if proof == nil {
+		return nil, errors.New("no proof returned")
+	}
+	if len(proof.StorageProof) != len(keys) {
+		return nil, errors.Errorf("expected %d storage proofs, received %d", len(keys), len(proof.StorageProof))
+	}
+	return proof, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testProofService struct {
+	block string
+}
+
+func (s *testProofService) GetProof(address common.Address, keys []common.Hash, block string) map[string]interface{} {
+	s.block = block
+	storageProofs := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		storageProofs[i] = map[string]interface{}{
+			"key":   key.Hex(),
+			"value": "0x1",
+			"proof": []string{"0x0102", "0x0304"},
+		}
+	}
+	return map[string]interface{}{
+		"address":      address.Hex(),
+		"accountProof": []string{"0x05"},
+		"balance":      "0x0",
+		"codeHash":     common.Hash{0x01}.Hex(),
+		"nonce":        "0x1",
+		"storageHash":  common.Hash{0x02}.Hex(),
+		"storageProof": storageProofs,
+	}
+}
+
+func TestProof(t *testing.T) {
+	service := &testProofService{}
+	c := newTestConn(t, service)
+
+	address := common.HexToAddress("0x4200000000000000000000000000000000000016")
+	key := common.Hash{0x03}
+	proof, err := c.Proof(context.Background(), address, []common.Hash{key}, big.NewInt(16))
+	require.NoError(t, err)
+	require.Equal(t, "0x10", service.block)
+	require.Equal(t, address, proof.Address)
+	require.Equal(t, common.Hash{0x02}, proof.StorageHash)
+	require.Len(t, proof.StorageProof, 1)
+	require.Equal(t, key.Hex(), proof.StorageProof[0].Key)
+	require.Equal(t, []hexutil.Bytes{{0x01, 0x02}, {0x03, 0x04}}, proof.StorageProof[0].Proof)
+
+	proof, err = c.Proof(context.Background(), address, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "latest", service.block)
+	require.Len(t, proof.StorageProof, 0)
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// RawCall calls the given JSON-RPC method with the given parameters, returning the raw result.
+func (c *Conn) RawCall(ctx context.Context,
+	method string,
+	params []json.RawMessage,
+) (
+	json.RawMessage,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot call method when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	args := make([]interface{}, len(params))
+	for i := range params {
+		args[i] = params[i]
+	}
+	var res json.RawMessage
+	if err := c.rpcClient.CallContext(ctx, &res, method, args...); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		// A null result.
+		res = json.RawMessage("null")
+	}
+	return res, nil
+}
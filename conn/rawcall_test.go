@@ -0,0 +1,95 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testRawCallService struct{}
+
+func (s *testRawCallService) Echo(number hexutil.Uint64, options map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"number":  number,
+		"options": options,
+	}
+}
+
+func (s *testRawCallService) Nothing() *string {
+	return nil
+}
+
+func TestRawCall(t *testing.T) {
+	ctx := context.Background()
+	c, err := conn.New(ctx, newTestURL(t, map[string]interface{}{"test": &testRawCallService{}}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		params string
+		res    string
+		err    string
+	}{
+		{
+			name:   "Echo",
+			method: "test_echo",
+			params: `["0x10",{"tracer":"callTracer"}]`,
+			res:    `{"number":"0x10","options":{"tracer":"callTracer"}}`,
+		},
+		{
+			name:   "NoParams",
+			method: "eth_chainId",
+			res:    `"0x1"`,
+		},
+		{
+			name:   "Null",
+			method: "test_nothing",
+			res:    `null`,
+		},
+		{
+			name:   "UnknownMethod",
+			method: "test_unknown",
+			err:    "the method test_unknown does not exist/is not available",
+		},
+		{
+			name:   "BadParams",
+			method: "test_echo",
+			params: `["bad"]`,
+			err:    "invalid argument 0: json: cannot unmarshal hex string without 0x prefix into Go value of type hexutil.Uint64",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := make([]json.RawMessage, 0)
+			if test.params != "" {
+				require.NoError(t, json.Unmarshal([]byte(test.params), &params))
+			}
+			res, err := c.RawCall(ctx, test.method, params)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.JSONEq(t, test.res, string(res))
+			}
+		})
+	}
+}
@@ -14,13 +14,62 @@
 package conn
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/wealdtech/go-ens/v3"
 )
 
-// Resolve resolves a name to an address.
-func (c *Conn) Resolve(name string) (common.Address, error) {
-	return ens.Resolve(c.client, name)
+// Labels provides the addresses for labels.
+type Labels interface {
+	// Address returns the address for a label.
+	Address(label string) (common.Address, bool)
+}
+
+// SetLabels sets the labels that can be resolved in place of addresses.
+func (c *Conn) SetLabels(labels Labels) {
+	c.labels = labels
+}
+
+// Resolve resolves a label, an ENS name or a hex address to an address.  Labels and hex
+// addresses do not require a connection, so can be resolved when offline.  An error is
+// returned if the input is not a valid address, or is an ENS name that does not resolve
+// to an address.
+func (c *Conn) Resolve(input string) (common.Address, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return common.Address{}, errors.New("no name or address supplied")
+	}
+
+	if !strings.Contains(input, ".") {
+		if c.labels != nil {
+			if address, exists := c.labels.Address(input); exists {
+				return address, nil
+			}
+		}
+		if !common.IsHexAddress(input) {
+			return common.Address{}, fmt.Errorf("invalid address %s", input)
+		}
+		address := common.HexToAddress(input)
+		if address == ens.UnknownAddress {
+			return common.Address{}, fmt.Errorf("invalid address %s", input)
+		}
+		return address, nil
+	}
+
+	if c.offline || c.client == nil {
+		return common.Address{}, fmt.Errorf("cannot resolve ENS name %s when offline", input)
+	}
+	address, err := ens.Resolve(c.client, input)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, fmt.Sprintf("failed to resolve ENS name %s", input))
+	}
+	if address == ens.UnknownAddress {
+		return common.Address{}, fmt.Errorf("ENS name %s does not have an address", input)
+	}
+	return address, nil
 }
 
 // ReverseResolve resolves an address to a name
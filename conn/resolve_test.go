@@ -0,0 +1,117 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+func TestResolveOffline(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("chainid", "1")
+	defer viper.Set("chainid", "")
+	c, err := conn.New(ctx, "offline")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		input   string
+		address common.Address
+		err     string
+	}{
+		{
+			name:    "Checksummed",
+			input:   "0x5FfC014343cd971B7eb70732021E26C35B744cc4",
+			address: common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+		},
+		{
+			name:    "NoPrefix",
+			input:   " 5ffc014343cd971b7eb70732021e26c35b744cc4 ",
+			address: common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+		},
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no name or address supplied",
+		},
+		{
+			name:  "Short",
+			input: "0x1234",
+			err:   "invalid address 0x1234",
+		},
+		{
+			name:  "Long",
+			input: "0x5FfC014343cd971B7eb70732021E26C35B744cc400",
+			err:   "invalid address 0x5FfC014343cd971B7eb70732021E26C35B744cc400",
+		},
+		{
+			name:  "Zero",
+			input: "0x0000000000000000000000000000000000000000",
+			err:   "invalid address 0x0000000000000000000000000000000000000000",
+		},
+		{
+			name:  "Name",
+			input: "enstest.eth",
+			err:   "cannot resolve ENS name enstest.eth when offline",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := c.Resolve(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, address)
+			}
+		})
+	}
+}
+
+// testLabels are labels for testing.
+type testLabels map[string]common.Address
+
+func (l testLabels) Address(label string) (common.Address, bool) {
+	address, exists := l[label]
+	return address, exists
+}
+
+func TestResolveLabels(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("chainid", "1")
+	defer viper.Set("chainid", "")
+	c, err := conn.New(ctx, "offline")
+	require.NoError(t, err)
+	c.SetLabels(testLabels{
+		"wallet": common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"),
+	})
+
+	address, err := c.Resolve(" wallet ")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"), address)
+
+	address, err = c.Resolve("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), address)
+
+	_, err = c.Resolve("unknown")
+	require.EqualError(t, err, "invalid address unknown")
+}
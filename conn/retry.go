@@ -0,0 +1,198 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	minRequestBackoff = 250 * time.Millisecond
+	maxRequestBackoff = 10 * time.Second
+)
+
+// sendMethod matches JSON-RPC requests that send transactions, which must not be repeated if
+// they might have reached the server.
+var sendMethod = regexp.MustCompile(`"method"\s*:\s*"eth_send`)
+
+// retryTransport is an HTTP transport that limits the rate of requests, and retries requests
+// that fail with transient errors such as rate limiting by the provider or a reset connection.
+// Requests that send transactions are only retried if they failed before they were written.
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	limiter *rateLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	sends := sendsTransaction(req)
+	delay := minRequestBackoff
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		attemptReq, written := traceWrite(attemptReq)
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.retries || ctx.Err() != nil || !retryable(resp, err) || (sends && written.Load()) {
+			return resp, err
+		}
+
+		// Back off before retrying, respecting any delay requested by the server.
+		wait := delay
+		if resp != nil {
+			if retryAfter := retryAfter(resp); retryAfter > 0 {
+				wait = retryAfter
+			}
+			resp.Body.Close()
+		}
+		if wait > maxRequestBackoff {
+			wait = maxRequestBackoff
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		}
+		if delay < maxRequestBackoff {
+			delay *= 2
+		}
+	}
+}
+
+// retryable returns true if the request failed with a transient error.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// Network errors, including resets and timeouts, are transient; cancellation is not.
+		return !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendsTransaction returns true if the request sends a transaction.
+func sendsTransaction(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return false
+	}
+	return sendMethod.Match(data)
+}
+
+// traceWrite returns a copy of the request that records if any of it is written to the server.
+func traceWrite(req *http.Request) (*http.Request, *atomic.Bool) {
+	written := &atomic.Bool{}
+	trace := &httptrace.ClientTrace{
+		WroteHeaders: func() {
+			written.Store(true)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), written
+}
+
+// retryAfter returns the delay requested by the server before retrying, or 0 if none.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+// rateLimiter spaces out requests so that no more than the given number are made per second.
+// A nil rate limiter does not limit requests.
+type rateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	mu       sync.Mutex
+}
+
+// newRateLimiter creates a rate limiter for the given number of requests per second.
+// It returns nil if the rate is not positive.
+func newRateLimiter(rate float64) (*rateLimiter, error) {
+	if rate < 0 {
+		return nil, fmt.Errorf("invalid rate limit %v", rate)
+	}
+	if rate == 0 {
+		return nil, nil
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / rate),
+	}, nil
+}
+
+// wait waits until the next request can be made.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(at)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+// newTestRetryServer creates a server that rejects requests with too many requests whilst
+// rejections is positive.
+func newTestRetryServer(t *testing.T, rejections *int32, requests *int32) *httptest.Server {
+	server := newTestServer(t, nil)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if atomic.AddInt32(rejections, -1) >= 0 {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+
+	tests := []struct {
+		name       string
+		retries    int
+		rejections int32
+		requests   int32
+		err        bool
+	}{
+		{
+			name:     "NoRejections",
+			retries:  3,
+			requests: 1,
+		},
+		{
+			name:       "Retried",
+			retries:    3,
+			rejections: 2,
+			requests:   3,
+		},
+		{
+			name:       "TooManyRejections",
+			retries:    1,
+			rejections: 3,
+			requests:   2,
+			err:        true,
+		},
+		{
+			name:       "NoRetries",
+			rejections: 1,
+			requests:   1,
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("retries", test.retries)
+			defer viper.Set("retries", nil)
+
+			rejections := int32(0)
+			requests := int32(0)
+			server := newTestRetryServer(t, &rejections, &requests)
+			c, err := conn.New(ctx, server.URL)
+			require.NoError(t, err)
+
+			atomic.StoreInt32(&rejections, test.rejections)
+			atomic.StoreInt32(&requests, 0)
+			_, err = c.Client().ChainID(ctx)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.requests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestRetrySend(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+	viper.Set("retries", 3)
+	defer viper.Set("retries", nil)
+
+	rejections := int32(0)
+	requests := int32(0)
+	server := newTestRetryServer(t, &rejections, &requests)
+	c, err := conn.New(ctx, server.URL)
+	require.NoError(t, err)
+
+	// Transactions are not sent again once they have reached the server.
+	atomic.StoreInt32(&rejections, 1)
+	atomic.StoreInt32(&requests, 0)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	require.Error(t, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	viper.Set("timeout", time.Minute)
+	defer viper.Set("timeout", nil)
+	viper.Set("rate-limit", 20)
+	defer viper.Set("rate-limit", nil)
+
+	rejections := int32(0)
+	requests := int32(0)
+	server := newTestRetryServer(t, &rejections, &requests)
+	c, err := conn.New(ctx, server.URL)
+	require.NoError(t, err)
+
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err = c.Client().ChainID(ctx)
+		require.NoError(t, err)
+	}
+	// Requests are spaced 50ms apart.
+	require.GreaterOrEqual(t, int64(time.Since(started)), int64(200*time.Millisecond))
+
+	viper.Set("rate-limit", -1)
+	_, err = conn.New(ctx, server.URL)
+	require.Error(t, err)
+}
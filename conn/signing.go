@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethereal/v2/cli"
+	"github.com/wealdtech/ethereal/v2/util"
 )
 
 // SignTransaction signs the given transaction, returning a signed transaction.
@@ -33,8 +34,34 @@ func (c *Conn) SignTransaction(ctx context.Context,
 	*types.Transaction,
 	error,
 ) {
+	if err := cli.LoadCredentials(c.ChainID(), signer); err != nil {
+		return nil, err
+	}
+	hardwareWallet, err := cli.HardwareWalletType()
+	if err != nil {
+		return nil, err
+	}
+
 	var signedTx *types.Transaction
 	switch {
+	case cli.ExternalSigner() != "":
+		wallet, account, err := cli.ObtainExternalSignerAccount(cli.ExternalSigner(), signer)
+		if err != nil {
+			return nil, err
+		}
+		signedTx, err = util.ExternalSigner(c.ChainID(), wallet, account)(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+	case hardwareWallet != "":
+		wallet, account, err := cli.ObtainHardwareWalletAccount(hardwareWallet, viper.GetString("hd-path"))
+		if err != nil {
+			return nil, err
+		}
+		signedTx, err = util.HardwareSigner(c.ChainID(), wallet, account)(signer, tx)
+		if err != nil {
+			return nil, err
+		}
 	case viper.GetString("passphrase") != "":
 		wallet, account, err := cli.ObtainWalletAndAccount(c.ChainID(), signer)
 		if err != nil {
@@ -53,8 +80,25 @@ func (c *Conn) SignTransaction(ctx context.Context,
 		if signer != keyAddr {
 			return nil, errors.New("not authorized to sign this account")
 		}
+		signedTx, err = types.SignTx(tx, types.LatestSignerForChainID(c.ChainID()), key)
+		if err != nil {
+			return nil, err
+		}
+	case viper.GetString("mnemonic") != "":
+		key, err := util.HDPrivateKey(viper.GetString("mnemonic"), "", viper.GetString("hd-path"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain key from mnemonic")
+		}
+		keyAddr := crypto.PubkeyToAddress(key.PublicKey)
+		if signer != keyAddr {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		signedTx, err = types.SignTx(tx, types.LatestSignerForChainID(c.ChainID()), key)
+		if err != nil {
+			return nil, err
+		}
 	default:
-		return nil, errors.New("no passphrase or private key; cannot sign")
+		return nil, errors.New("no passphrase, private key, mnemonic, hardware wallet or external signer; cannot sign")
 	}
 	return signedTx, nil
 }
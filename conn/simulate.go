@@ -0,0 +1,95 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ErrDryRun is returned when a transaction is not sent because the connection is in dry-run mode.
+var ErrDryRun = errors.New("transaction not sent in dry-run mode")
+
+// Simulation is the result of simulating a transaction against the latest state of the chain.
+type Simulation struct {
+	// Err is the error from executing the transaction, if it failed.
+	Err error
+	// ReturnData is the data returned by the transaction, if it succeeded.
+	ReturnData []byte
+	// GasEstimate is the estimated gas used by the transaction, if it succeeded.
+	GasEstimate uint64
+	// Balance is the balance of the sender.
+	Balance *big.Int
+	// MaxCost is the maximum cost of the transaction: its value plus its gas limit at its
+	// maximum fee per gas.
+	MaxCost *big.Int
+}
+
+// DryRun returns true if the connection is in dry-run mode, in which case transactions are
+// simulated rather than sent.
+func (c *Conn) DryRun() bool {
+	return c.dryRun
+}
+
+// SimulateTransaction executes the transaction from the given sender against the latest state
+// of the chain without sending it, and estimates the gas that it would use.  The transaction
+// does not need to be signed.  A transaction that fails is not an error; the failure is
+// returned in the simulation.
+func (c *Conn) SimulateTransaction(ctx context.Context,
+	from common.Address,
+	tx *types.Transaction,
+) (
+	*Simulation,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot simulate transaction when offline")
+	}
+
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+
+	simulation := &Simulation{
+		MaxCost: tx.Cost(),
+	}
+
+	var err error
+	opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	simulation.Balance, err = c.client.BalanceAt(opCtx, from, nil)
+	cancel()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain balance of sender")
+	}
+
+	simulation.ReturnData, simulation.Err = c.CallContract(ctx, msg, nil, nil)
+	if simulation.Err != nil {
+		return simulation, nil
+	}
+
+	opCtx, cancel = context.WithTimeout(ctx, c.timeout)
+	simulation.GasEstimate, simulation.Err = c.client.EstimateGas(opCtx, msg)
+	cancel()
+
+	return simulation, nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// revertError is an error from a call that reverts with data.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+// revertFailed is the revert data for Error("failed").
+var revertFailed = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000006" +
+	"6661696c65640000000000000000000000000000000000000000000000000000"
+
+// testSimulationService provides the methods used to simulate transactions.
+type testSimulationService struct {
+	sent int32
+}
+
+func (s *testSimulationService) GetBalance(address common.Address, block string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1000000))
+}
+
+func (s *testSimulationService) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	// Newer clients send the call data as input rather than data.
+	if args["input"] == "0xdead" || args["data"] == "0xdead" {
+		return nil, &revertError{data: revertFailed}
+	}
+	return hexutil.Bytes{0x01}, nil
+}
+
+func (s *testSimulationService) EstimateGas(args map[string]interface{}) hexutil.Uint64 {
+	return 25000
+}
+
+func (s *testSimulationService) GetTransactionCount(address common.Address, block string) hexutil.Uint64 {
+	return 3
+}
+
+func (s *testSimulationService) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func (s *testSimulationService) GetBlockByNumber(number string, full bool) *types.Header {
+	return &types.Header{
+		Number:     big.NewInt(1),
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     types.EmptyTxsHash,
+		Difficulty: big.NewInt(0),
+		BaseFee:    big.NewInt(1),
+	}
+}
+
+func (s *testSimulationService) SendRawTransaction(data hexutil.Bytes) common.Hash {
+	atomic.AddInt32(&s.sent, 1)
+	return crypto.Keccak256Hash(data)
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	service := &testSimulationService{}
+	url := newTestURL(t, map[string]interface{}{"eth": service})
+	c, err := conn.New(ctx, url)
+	require.NoError(t, err)
+	require.False(t, c.DryRun())
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+
+	tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       30000,
+		To:        &to,
+		Value:     big.NewInt(500),
+		Data:      []byte{0x01},
+	})
+	require.NoError(t, err)
+	simulation, err := c.SimulateTransaction(ctx, from, tx)
+	require.NoError(t, err)
+	require.NoError(t, simulation.Err)
+	require.Equal(t, []byte{0x01}, simulation.ReturnData)
+	require.Equal(t, uint64(25000), simulation.GasEstimate)
+	require.Equal(t, big.NewInt(1000000), simulation.Balance)
+	require.Equal(t, big.NewInt(300500), simulation.MaxCost)
+
+	tx, err = types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     2,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       30000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad},
+	})
+	require.NoError(t, err)
+	simulation, err = c.SimulateTransaction(ctx, from, tx)
+	require.NoError(t, err)
+	require.Error(t, simulation.Err)
+	require.Equal(t, "execution reverted: failed", util.RevertReason(nil, simulation.Err))
+	require.Equal(t, uint64(0), simulation.GasEstimate)
+
+	// Transactions are not sent in dry-run mode.
+	viper.Set("dry-run", true)
+	defer viper.Set("dry-run", nil)
+	c, err = conn.New(ctx, url)
+	require.NoError(t, err)
+	require.True(t, c.DryRun())
+	require.Equal(t, conn.ErrDryRun, c.SendTransaction(ctx, tx))
+	require.Equal(t, int32(0), atomic.LoadInt32(&service.sent))
+
+	// Transactions are created unsigned in dry-run mode, and do not reserve their nonces.
+	gasLimit := uint64(21000)
+	for i := 0; i < 2; i++ {
+		unsignedTx, err := c.CreateSignedTransaction(ctx, &conn.TransactionData{
+			From:                 from,
+			To:                   &to,
+			Value:                big.NewInt(500),
+			GasLimit:             &gasLimit,
+			MaxFeePerGas:         big.NewInt(10),
+			MaxPriorityFeePerGas: big.NewInt(1),
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), unsignedTx.Nonce())
+		_, r, _ := unsignedTx.RawSignatureValues()
+		require.Equal(t, 0, r.Sign())
+
+		simulation, err = c.SimulateTransaction(ctx, from, unsignedTx)
+		require.NoError(t, err)
+		require.NoError(t, simulation.Err)
+		require.Equal(t, big.NewInt(210500), simulation.MaxCost)
+	}
+}
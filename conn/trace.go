@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// CallFrame is a frame of a trace generated by the callTracer.
+type CallFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*CallFrame    `json:"calls,omitempty"`
+}
+
+type traceConfig struct {
+	Tracer         string                              `json:"tracer"`
+	StateOverrides map[common.Address]*OverrideAccount `json:"stateOverrides,omitempty"`
+}
+
+// TraceTransaction traces a mined transaction with the callTracer.
+// This requires the node to support the debug namespace.
+func (c *Conn) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	if c.offline {
+		return nil, errors.New("cannot trace transaction when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res CallFrame
+	if err := c.rpcClient.CallContext(ctx, &res, "debug_traceTransaction", txHash, &traceConfig{Tracer: "callTracer"}); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// TraceCall traces a call with the callTracer, against the state at the given block (nil for
+// latest) with optional state overrides.
+// This requires the node to support the debug namespace.
+func (c *Conn) TraceCall(ctx context.Context,
+	msg ethereum.CallMsg,
+	blockNumber *big.Int,
+	overrides map[common.Address]*OverrideAccount,
+) (
+	*CallFrame,
+	error,
+) {
+	if c.offline {
+		return nil, errors.New("cannot trace call when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res CallFrame
+	config := &traceConfig{
+		Tracer:         "callTracer",
+		StateOverrides: overrides,
+	}
+	if err := c.rpcClient.CallContext(ctx, &res, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), config); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -16,22 +16,48 @@ package conn
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/cli"
 )
 
-// CreateSignedTransaction creates a signed transaction.
+// CreateSignedTransaction creates a signed transaction.  If the connection is in dry-run mode
+// the transaction is returned unsigned, as it will be simulated rather than sent, and its nonce
+// is not reserved.
 func (c *Conn) CreateSignedTransaction(ctx context.Context,
 	txData *TransactionData,
 ) (
 	signedTx *types.Transaction,
 	err error,
 ) {
+	if txData.Nonce == nil {
+		// Reserve the nonce for the transaction, releasing it if the transaction is not created or
+		// is not to be sent.
+		var nonce uint64
+		nonce, err = c.ReserveNonce(ctx, txData.From)
+		if err != nil {
+			return
+		}
+		defer func() {
+			if err != nil || c.dryRun {
+				c.ReleaseNonce(txData.From, nonce)
+			}
+		}()
+		txNonce := int64(nonce)
+		txData.Nonce = &txNonce
+	}
+
 	tx, err := c.CreateTransaction(ctx, txData)
 	if err != nil {
 		return
 	}
+	if c.dryRun {
+		signedTx = tx
+		return
+	}
 
 	// Sign the transaction.
 	signedTx, err = c.SignTransaction(ctx, txData.From, tx)
@@ -40,12 +66,6 @@ func (c *Conn) CreateSignedTransaction(ctx context.Context,
 		return
 	}
 
-	// Increment the nonce for the next transaction.
-	_, err = c.NextNonce(ctx, txData.From)
-	if err != nil {
-		return
-	}
-
 	return
 }
 
@@ -75,6 +95,26 @@ func (c *Conn) CreateTransaction(ctx context.Context,
 		txData.GasLimit = &gasLimit
 	}
 
+	london, err := c.SupportsLondon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hardwareWallet, err := cli.HardwareWalletType()
+	if err != nil {
+		return nil, err
+	}
+	if txData.BlobSidecar != nil {
+		if !london || hardwareWallet != "" {
+			return nil, errors.New("blob transactions require a chain that supports EIP-1559 and cannot be signed by hardware wallets")
+		}
+		if txData.To == nil {
+			return nil, errors.New("blob transactions cannot create contracts")
+		}
+	}
+	if !london {
+		return c.createLegacyTransaction(ctx, txData)
+	}
+
 	// Calculate fees.
 	maxFeePerGas, maxPriorityFeePerGas, err := c.CalculateFees()
 	if err != nil {
@@ -89,26 +129,125 @@ func (c *Conn) CreateTransaction(ctx context.Context,
 		maxPriorityFeePerGas = txData.MaxPriorityFeePerGas
 	}
 
+	if txData.BlobSidecar != nil {
+		return c.createBlobTransaction(ctx, txData, maxFeePerGas, maxPriorityFeePerGas)
+	}
+
 	// Create the transaction
 	return types.NewTx(&types.DynamicFeeTx{
-		ChainID:   c.ChainID(),
-		Nonce:     uint64(*txData.Nonce),
-		GasFeeCap: maxFeePerGas,
-		GasTipCap: maxPriorityFeePerGas,
-		Gas:       *txData.GasLimit,
-		To:        txData.To,
-		Value:     txData.Value,
-		Data:      txData.Data,
+		ChainID:    c.ChainID(),
+		Nonce:      uint64(*txData.Nonce),
+		GasFeeCap:  maxFeePerGas,
+		GasTipCap:  maxPriorityFeePerGas,
+		Gas:        *txData.GasLimit,
+		To:         txData.To,
+		Value:      txData.Value,
+		Data:       txData.Data,
+		AccessList: txData.AccessList,
+	}), nil
+}
+
+// createBlobTransaction creates a transaction that carries blobs, as per EIP-4844.
+func (c *Conn) createBlobTransaction(ctx context.Context,
+	txData *TransactionData,
+	maxFeePerGas *big.Int,
+	maxPriorityFeePerGas *big.Int,
+) (
+	*types.Transaction,
+	error,
+) {
+	maxFeePerBlobGas := txData.MaxFeePerBlobGas
+	if maxFeePerBlobGas == nil {
+		var err error
+		maxFeePerBlobGas, err = c.CalculateBlobFee(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value := txData.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(c.ChainID()),
+		Nonce:      uint64(*txData.Nonce),
+		GasFeeCap:  uint256.MustFromBig(maxFeePerGas),
+		GasTipCap:  uint256.MustFromBig(maxPriorityFeePerGas),
+		Gas:        *txData.GasLimit,
+		To:         *txData.To,
+		Value:      uint256.MustFromBig(value),
+		Data:       txData.Data,
+		AccessList: txData.AccessList,
+		BlobFeeCap: uint256.MustFromBig(maxFeePerBlobGas),
+		BlobHashes: txData.BlobSidecar.BlobHashes(),
+		Sidecar:    txData.BlobSidecar,
 	}), nil
 }
 
-// SendTransaction send the supplied transaction to the network.
+// createLegacyTransaction creates a transaction for a chain that does not support EIP-1559.
+func (c *Conn) createLegacyTransaction(ctx context.Context,
+	txData *TransactionData,
+) (
+	*types.Transaction,
+	error,
+) {
+	gasPrice := txData.MaxFeePerGas
+	if gasPrice == nil {
+		var err error
+		gasPrice, err = c.CalculateGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(txData.AccessList) > 0 {
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    c.ChainID(),
+			Nonce:      uint64(*txData.Nonce),
+			GasPrice:   gasPrice,
+			Gas:        *txData.GasLimit,
+			To:         txData.To,
+			Value:      txData.Value,
+			Data:       txData.Data,
+			AccessList: txData.AccessList,
+		}), nil
+	}
+
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(*txData.Nonce),
+		GasPrice: gasPrice,
+		Gas:      *txData.GasLimit,
+		To:       txData.To,
+		Value:    txData.Value,
+		Data:     txData.Data,
+	}), nil
+}
+
+// SendTransaction send the supplied transaction to the network.  If private transactions are
+// enabled it is sent to the private relay instead.  If the connection is in dry-run mode the
+// transaction is not sent and ErrDryRun is returned.
 func (c *Conn) SendTransaction(ctx context.Context,
 	tx *types.Transaction,
 ) error {
 	if c.client == nil {
 		return errors.New("cannot send transaction when offline")
 	}
+	if c.dryRun {
+		return ErrDryRun
+	}
+
+	if c.private {
+		return c.SendPrivateTransaction(ctx, tx)
+	}
+
+	if c.broadcast && len(c.endpoints) > 1 {
+		if err := c.broadcastTransaction(ctx, tx); err != nil {
+			return errors.Wrap(err, "failed to send transaction")
+		}
+		return nil
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
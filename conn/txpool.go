@@ -0,0 +1,191 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// TxPoolStatus is the number of transactions in the node's transaction pool.
+type TxPoolStatus struct {
+	// Pending is the number of transactions that are ready to be included in a block.
+	Pending uint64 `json:"pending"`
+	// Queued is the number of transactions that cannot yet be included in a block,
+	// for example because of a nonce gap.
+	Queued uint64 `json:"queued"`
+}
+
+// TxPoolContent is the content of the node's transaction pool, by sender.
+// Transactions for each sender are ordered by nonce.
+type TxPoolContent struct {
+	Pending map[common.Address][]*types.Transaction `json:"pending"`
+	Queued  map[common.Address][]*types.Transaction `json:"queued"`
+}
+
+// TxPoolInspection is a summary of the content of the node's transaction pool, by sender
+// and nonce.
+type TxPoolInspection struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
+// TxPoolStatus returns the number of transactions in the node's transaction pool.
+// This requires the node to support the txpool namespace.
+func (c *Conn) TxPoolStatus(ctx context.Context) (*TxPoolStatus, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain transaction pool status when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := c.rpcClient.CallContext(ctx, &res, "txpool_status"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain transaction pool status")
+	}
+	return &TxPoolStatus{
+		Pending: uint64(res.Pending),
+		Queued:  uint64(res.Queued),
+	}, nil
+}
+
+// TxPoolContent returns the content of the node's transaction pool.
+// This requires the node to support the txpool namespace.
+func (c *Conn) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain transaction pool content when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res map[string]map[common.Address]map[string]*types.Transaction
+	if err := c.rpcClient.CallContext(ctx, &res, "txpool_content"); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain transaction pool content")
+	}
+	return &TxPoolContent{
+		Pending: orderTxPoolTransactions(res["pending"]),
+		Queued:  orderTxPoolTransactions(res["queued"]),
+	}, nil
+}
+
+// TxPoolContentFrom returns the content of the node's transaction pool for a single sender.
+// If the node does not support txpool_contentFrom the full content is obtained and filtered.
+// This requires the node to support the txpool namespace.
+func (c *Conn) TxPoolContentFrom(ctx context.Context, address common.Address) (*TxPoolContent, error) {
+	if c.offline {
+		return nil, errors.New("cannot obtain transaction pool content when offline")
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res map[string]map[string]*types.Transaction
+	if err := c.rpcClient.CallContext(callCtx, &res, "txpool_contentFrom", address); err != nil {
+		// Fall back to the full content.
+		content, err := c.TxPoolContent(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res := &TxPoolContent{
+			Pending: make(map[common.Address][]*types.Transaction),
+			Queued:  make(map[common.Address][]*types.Transaction),
+		}
+		if txs, exists := content.Pending[address]; exists {
+			res.Pending[address] = txs
+		}
+		if txs, exists := content.Queued[address]; exists {
+			res.Queued[address] = txs
+		}
+		return res, nil
+	}
+	return &TxPoolContent{
+		Pending: orderTxPoolTransactions(map[common.Address]map[string]*types.Transaction{address: res["pending"]}),
+		Queued:  orderTxPoolTransactions(map[common.Address]map[string]*types.Transaction{address: res["queued"]}),
+	}, nil
+}
+
+// TxPoolInspect returns a summary of the content of the node's transaction pool.
+// This requires the node to support the txpool namespace.
+func (c *Conn) TxPoolInspect(ctx context.Context) (*TxPoolInspection, error) {
+	if c.offline {
+		return nil, errors.New("cannot inspect transaction pool when offline")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var res map[string]map[common.Address]map[string]string
+	if err := c.rpcClient.CallContext(ctx, &res, "txpool_inspect"); err != nil {
+		return nil, errors.Wrap(err, "failed to inspect transaction pool")
+	}
+	pending, err := keyByNonce(res["pending"])
+	if err != nil {
+		return nil, err
+	}
+	queued, err := keyByNonce(res["queued"])
+	if err != nil {
+		return nil, err
+	}
+	return &TxPoolInspection{
+		Pending: pending,
+		Queued:  queued,
+	}, nil
+}
+
+// orderTxPoolTransactions turns transactions keyed by sender and nonce in to lists of
+// transactions ordered by nonce.
+func orderTxPoolTransactions(input map[common.Address]map[string]*types.Transaction) map[common.Address][]*types.Transaction {
+	res := make(map[common.Address][]*types.Transaction, len(input))
+	for address, txs := range input {
+		if len(txs) == 0 {
+			continue
+		}
+		ordered := make([]*types.Transaction, 0, len(txs))
+		for _, tx := range txs {
+			ordered = append(ordered, tx)
+		}
+		sort.Slice(ordered, func(i, j int) bool {
+			return ordered[i].Nonce() < ordered[j].Nonce()
+		})
+		res[address] = ordered
+	}
+	return res
+}
+
+// keyByNonce turns the nonce keys of transaction pool summaries in to numbers.
+func keyByNonce(input map[common.Address]map[string]string) (map[common.Address]map[uint64]string, error) {
+	res := make(map[common.Address]map[uint64]string, len(input))
+	for address, summaries := range input {
+		res[address] = make(map[uint64]string, len(summaries))
+		for nonceStr, summary := range summaries {
+			nonce, err := strconv.ParseUint(nonceStr, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid nonce %s in transaction pool", nonceStr)
+			}
+			res[address][nonce] = summary
+		}
+	}
+	return res, nil
+}
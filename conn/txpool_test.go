@@ -0,0 +1,114 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+type testTxPoolService struct {
+	txs map[common.Address]map[string]*types.Transaction
+}
+
+func (s *testTxPoolService) Status() map[string]hexutil.Uint {
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(3),
+		"queued":  hexutil.Uint(1),
+	}
+}
+
+func (s *testTxPoolService) Content() map[string]map[common.Address]map[string]*types.Transaction {
+	return map[string]map[common.Address]map[string]*types.Transaction{
+		"pending": s.txs,
+		"queued":  {},
+	}
+}
+
+func (s *testTxPoolService) Inspect() map[string]map[common.Address]map[string]string {
+	res := map[string]map[common.Address]map[string]string{
+		"pending": {},
+		"queued":  {},
+	}
+	for address, txs := range s.txs {
+		res["pending"][address] = make(map[string]string)
+		for nonce, tx := range txs {
+			res["pending"][address][nonce] = fmt.Sprintf("%s: %v wei + %d gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+	}
+	return res
+}
+
+func TestTxPool(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	txs := make(map[string]*types.Transaction)
+	for _, nonce := range []uint64{12, 10, 11} {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1000000000),
+			GasFeeCap: big.NewInt(20000000000),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(1),
+		})
+		require.NoError(t, err)
+		txs[fmt.Sprintf("%d", nonce)] = tx
+	}
+
+	c, err := conn.New(ctx, newTestURL(t, map[string]interface{}{"txpool": &testTxPoolService{
+		txs: map[common.Address]map[string]*types.Transaction{sender: txs},
+	}}))
+	require.NoError(t, err)
+
+	status, err := c.TxPoolStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &conn.TxPoolStatus{Pending: 3, Queued: 1}, status)
+
+	content, err := c.TxPoolContent(ctx)
+	require.NoError(t, err)
+	require.Len(t, content.Pending[sender], 3)
+	require.Len(t, content.Queued, 0)
+	for i, tx := range content.Pending[sender] {
+		require.Equal(t, uint64(10+i), tx.Nonce())
+		require.Equal(t, txs[fmt.Sprintf("%d", 10+i)].Hash(), tx.Hash())
+	}
+
+	// The test server does not support txpool_contentFrom, so this exercises the fallback.
+	content, err = c.TxPoolContentFrom(ctx, sender)
+	require.NoError(t, err)
+	require.Len(t, content.Pending[sender], 3)
+	content, err = c.TxPoolContentFrom(ctx, to)
+	require.NoError(t, err)
+	require.Len(t, content.Pending, 0)
+
+	inspection, err := c.TxPoolInspect(ctx)
+	require.NoError(t, err)
+	require.Len(t, inspection.Pending[sender], 3)
+	require.Contains(t, inspection.Pending[sender][10], to.Hex())
+}
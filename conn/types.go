@@ -17,6 +17,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // TransactionData contains data to build a transaction.
@@ -33,4 +34,10 @@ type TransactionData struct {
 
 	MaxFeePerGas         *big.Int
 	MaxPriorityFeePerGas *big.Int
+
+	AccessList types.AccessList
+
+	// BlobSidecar contains the blobs for a blob transaction, along with their commitments and proofs.
+	BlobSidecar      *types.BlobTxSidecar
+	MaxFeePerBlobGas *big.Int
 }
@@ -0,0 +1,400 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	// maxVerifyDistance is the maximum number of blocks between a header and the closest
+	// verified header for the header to be verified.
+	maxVerifyDistance = 10000
+	// verifyBatchSize is the number of headers requested at a time when linking a header
+	// to the verified chain.
+	verifyBatchSize = 100
+)
+
+// headerJSON is the JSON representation of a block header, including the fields of forks that
+// the client does not know about, so that its hash can be calculated.
+type headerJSON struct {
+	ParentHash            common.Hash      `json:"parentHash"`
+	UncleHash             common.Hash      `json:"sha3Uncles"`
+	Coinbase              common.Address   `json:"miner"`
+	Root                  common.Hash      `json:"stateRoot"`
+	TxHash                common.Hash      `json:"transactionsRoot"`
+	ReceiptHash           common.Hash      `json:"receiptsRoot"`
+	Bloom                 hexutil.Bytes    `json:"logsBloom"`
+	Difficulty            *hexutil.Big     `json:"difficulty"`
+	Number                *hexutil.Big     `json:"number"`
+	GasLimit              hexutil.Uint64   `json:"gasLimit"`
+	GasUsed               hexutil.Uint64   `json:"gasUsed"`
+	Time                  hexutil.Uint64   `json:"timestamp"`
+	Extra                 hexutil.Bytes    `json:"extraData"`
+	MixDigest             common.Hash      `json:"mixHash"`
+	Nonce                 types.BlockNonce `json:"nonce"`
+	BaseFee               *hexutil.Big     `json:"baseFeePerGas"`
+	WithdrawalsRoot       *common.Hash     `json:"withdrawalsRoot"`
+	BlobGasUsed           *hexutil.Uint64  `json:"blobGasUsed"`
+	ExcessBlobGas         *hexutil.Uint64  `json:"excessBlobGas"`
+	ParentBeaconBlockRoot *common.Hash     `json:"parentBeaconBlockRoot"`
+	RequestsHash          *common.Hash     `json:"requestsHash"`
+	Hash                  common.Hash      `json:"hash"`
+}
+
+// hash calculates the hash of the header from its contents.
+func (h *headerJSON) hash() common.Hash {
+	difficulty := new(big.Int)
+	if h.Difficulty != nil {
+		difficulty = h.Difficulty.ToInt()
+	}
+	fields := []interface{}{
+		h.ParentHash,
+		h.UncleHash,
+		h.Coinbase,
+		h.Root,
+		h.TxHash,
+		h.ReceiptHash,
+		[]byte(h.Bloom),
+		difficulty,
+		h.Number.ToInt(),
+		uint64(h.GasLimit),
+		uint64(h.GasUsed),
+		uint64(h.Time),
+		[]byte(h.Extra),
+		h.MixDigest,
+		h.Nonce,
+	}
+	// Fields added by later forks are present only if all earlier ones are.
+	optional := []interface{}{}
+	if h.BaseFee != nil {
+		optional = append(optional, h.BaseFee.ToInt())
+	}
+	if h.WithdrawalsRoot != nil {
+		optional = append(optional, *h.WithdrawalsRoot)
+	}
+	if h.BlobGasUsed != nil {
+		optional = append(optional, uint64(*h.BlobGasUsed))
+	}
+	if h.ExcessBlobGas != nil {
+		optional = append(optional, uint64(*h.ExcessBlobGas))
+	}
+	if h.ParentBeaconBlockRoot != nil {
+		optional = append(optional, *h.ParentBeaconBlockRoot)
+	}
+	if h.RequestsHash != nil {
+		optional = append(optional, *h.RequestsHash)
+	}
+	fields = append(fields, optional...)
+
+	data, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		// All fields are encodable, so this should never happen.
+		panic(err)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// headerVerifier checks that block headers link by hash to a trusted block.
+type headerVerifier struct {
+	// client fetches headers without verification.
+	client *rpc.Client
+	// hashes are the hashes of verified blocks.
+	hashes map[uint64]common.Hash
+	mu     sync.Mutex
+	// warn is called with a description of any header that fails verification.
+	warn func(msg string)
+}
+
+// newHeaderVerifier creates a header verifier for the block configured with trusted-block,
+// or nil if none is configured.
+func newHeaderVerifier() (*headerVerifier, error) {
+	trusted := viper.GetString("trusted-block")
+	if trusted == "" {
+		return nil, nil
+	}
+	number, hash, err := parseTrustedBlock(trusted)
+	if err != nil {
+		return nil, err
+	}
+	return &headerVerifier{
+		hashes: map[uint64]common.Hash{number: hash},
+	}, nil
+}
+
+// parseTrustedBlock parses a trusted block in the form number:hash.
+func parseTrustedBlock(input string) (uint64, common.Hash, error) {
+	parts := strings.Split(strings.TrimSpace(input), ":")
+	if len(parts) != 2 {
+		return 0, common.Hash{}, fmt.Errorf("trusted block %s is not in the form number:hash", input)
+	}
+	number, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("invalid trusted block number %s", parts[0])
+	}
+	hash, err := hexutil.Decode(parts[1])
+	if err != nil || len(hash) != common.HashLength {
+		return 0, common.Hash{}, fmt.Errorf("invalid trusted block hash %s", parts[1])
+	}
+	return number, common.BytesToHash(hash), nil
+}
+
+// SetHeaderWarnings sets the function called with a description of any block header returned
+// by the connection that does not link to the trusted block.
+func (c *Conn) SetHeaderWarnings(warn func(msg string)) {
+	if c.verifier != nil {
+		c.verifier.warn = warn
+	}
+}
+
+// verify verifies that the header links to the verified chain.
+func (v *headerVerifier) verify(ctx context.Context, header *headerJSON) error {
+	if header.Number == nil {
+		// Pending blocks cannot be verified.
+		return nil
+	}
+	number := header.Number.ToInt().Uint64()
+	hash := header.hash()
+	if header.Hash != (common.Hash{}) && header.Hash != hash {
+		return fmt.Errorf("block %d was returned with hash %s that does not match its contents", number, header.Hash.Hex())
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if known, exists := v.hashes[number]; exists {
+		if known != hash {
+			return fmt.Errorf("block %d was returned with hash %s but the verified chain has %s", number, hash.Hex(), known.Hex())
+		}
+		return nil
+	}
+
+	anchor := v.closest(number)
+	if number > anchor {
+		if number-anchor > maxVerifyDistance {
+			return fmt.Errorf("block %d is too far from verified block %d to verify", number, anchor)
+		}
+		// Walk forward from the verified block to the header.
+		headers, err := v.fetch(ctx, anchor+1, number-1)
+		if err != nil {
+			return err
+		}
+		headers = append(headers, header)
+		parent := v.hashes[anchor]
+		hashes := make(map[uint64]common.Hash, len(headers))
+		for i, header := range headers {
+			if header.ParentHash != parent {
+				return fmt.Errorf("block %d does not link to verified block %d", number, anchor)
+			}
+			parent = header.hash()
+			hashes[anchor+uint64(i)+1] = parent
+		}
+		v.record(hashes)
+		return nil
+	}
+
+	if anchor-number > maxVerifyDistance {
+		return fmt.Errorf("block %d is too far from verified block %d to verify", number, anchor)
+	}
+	// Walk back from the verified block to the header.
+	headers, err := v.fetch(ctx, number+1, anchor)
+	if err != nil {
+		return err
+	}
+	if headers[len(headers)-1].hash() != v.hashes[anchor] {
+		return fmt.Errorf("block %d does not link to verified block %d", number, anchor)
+	}
+	headers = append([]*headerJSON{header}, headers...)
+	hashes := make(map[uint64]common.Hash, len(headers))
+	for i := len(headers) - 1; i > 0; i-- {
+		childNumber := number + uint64(i)
+		hashes[childNumber] = headers[i].hash()
+		if headers[i].ParentHash != headers[i-1].hash() {
+			return fmt.Errorf("block %d does not link to verified block %d", number, anchor)
+		}
+	}
+	hashes[number] = hash
+	v.record(hashes)
+	return nil
+}
+
+// closest returns the number of the verified block closest to the given number.
+func (v *headerVerifier) closest(number uint64) uint64 {
+	var closest uint64
+	var distance uint64
+	first := true
+	for verified := range v.hashes {
+		d := verified - number
+		if number > verified {
+			d = number - verified
+		}
+		if first || d < distance {
+			closest = verified
+			distance = d
+			first = false
+		}
+	}
+	return closest
+}
+
+// record adds the given hashes to the verified chain.
+func (v *headerVerifier) record(hashes map[uint64]common.Hash) {
+	for number, hash := range hashes {
+		v.hashes[number] = hash
+	}
+}
+
+// fetch fetches the headers of the given range of blocks, without verification.
+func (v *headerVerifier) fetch(ctx context.Context, from uint64, to uint64) ([]*headerJSON, error) {
+	headers := make([]*headerJSON, 0)
+	for start := from; start <= to; start += verifyBatchSize {
+		end := start + verifyBatchSize - 1
+		if end > to {
+			end = to
+		}
+		res := make([]*headerJSON, end-start+1)
+		batch := make([]rpc.BatchElem, len(res))
+		for i := range batch {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []interface{}{hexutil.EncodeUint64(start + uint64(i)), false},
+				Result: &res[i],
+			}
+		}
+		if err := v.client.BatchCallContext(ctx, batch); err != nil {
+			return nil, errors.Wrap(err, "failed to obtain headers to verify")
+		}
+		for i := range batch {
+			if batch[i].Error != nil {
+				return nil, errors.Wrapf(batch[i].Error, "failed to obtain header %d to verify", start+uint64(i))
+			}
+			if res[i] == nil || res[i].Number == nil {
+				return nil, errors.Errorf("header %d to verify not found", start+uint64(i))
+			}
+		}
+		headers = append(headers, res...)
+	}
+	return headers, nil
+}
+
+// verifyTransport is an HTTP transport that verifies the block headers in responses against
+// the verified chain, warning of any that do not link to it.
+type verifyTransport struct {
+	next     http.RoundTripper
+	verifier *headerVerifier
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *verifyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	ids := blockRequestIDs(body)
+	if len(ids) == 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	for _, header := range blockResponseHeaders(respBody, ids) {
+		if err := t.verifier.verify(req.Context(), header); err != nil && t.verifier.warn != nil {
+			t.verifier.warn(fmt.Sprintf("Header verification failed: %v", err))
+		}
+	}
+	return resp, nil
+}
+
+// blockRequestIDs returns the IDs of the JSON-RPC requests in the body that obtain blocks.
+func blockRequestIDs(body []byte) map[string]bool {
+	type request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	requests := make([]*request, 0)
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil
+		}
+	} else {
+		single := &request{}
+		if err := json.Unmarshal(body, single); err != nil {
+			return nil
+		}
+		requests = append(requests, single)
+	}
+
+	ids := make(map[string]bool)
+	for _, request := range requests {
+		if request != nil && (request.Method == "eth_getBlockByNumber" || request.Method == "eth_getBlockByHash") {
+			ids[string(request.ID)] = true
+		}
+	}
+	return ids
+}
+
+// blockResponseHeaders returns the headers in the JSON-RPC responses in the body with the given IDs.
+func blockResponseHeaders(body []byte, ids map[string]bool) []*headerJSON {
+	type response struct {
+		ID     json.RawMessage `json:"id"`
+		Result *headerJSON     `json:"result"`
+	}
+	responses := make([]*response, 0)
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(body, &responses); err != nil {
+			return nil
+		}
+	} else {
+		single := &response{}
+		if err := json.Unmarshal(body, single); err != nil {
+			return nil
+		}
+		responses = append(responses, single)
+	}
+
+	headers := make([]*headerJSON, 0, len(responses))
+	for _, response := range responses {
+		if response != nil && response.Result != nil && ids[string(response.ID)] {
+			headers = append(headers, response.Result)
+		}
+	}
+	return headers
+}
@@ -0,0 +1,235 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+// testVerifyLondonBlock is the first block of the test chain with a base fee.
+const testVerifyLondonBlock = 15
+
+type testVerifyService struct {
+	headers []*types.Header
+	// forged are headers returned in place of those in the chain.
+	forged map[uint64]map[string]interface{}
+}
+
+func newTestVerifyService(length int) *testVerifyService {
+	s := &testVerifyService{
+		forged: make(map[uint64]map[string]interface{}),
+	}
+	parent := common.Hash{}
+	for i := 0; i < length; i++ {
+		header := &types.Header{
+			ParentHash:  parent,
+			UncleHash:   types.EmptyUncleHash,
+			Coinbase:    common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"),
+			Root:        common.BigToHash(big.NewInt(int64(i + 1000))),
+			TxHash:      types.EmptyRootHash,
+			ReceiptHash: types.EmptyRootHash,
+			Difficulty:  big.NewInt(131072),
+			Number:      big.NewInt(int64(i)),
+			GasLimit:    30000000,
+			Time:        uint64(1600000000 + 12*i),
+			Extra:       []byte("test"),
+		}
+		if i >= testVerifyLondonBlock {
+			header.BaseFee = big.NewInt(1000000000)
+		}
+		s.headers = append(s.headers, header)
+		parent = header.Hash()
+	}
+	return s
+}
+
+func (s *testVerifyService) GetBlockByNumber(number string, full bool) (interface{}, error) {
+	var n uint64
+	if number == "latest" {
+		n = uint64(len(s.headers) - 1)
+	} else {
+		var err error
+		n, err = hexutil.DecodeUint64(number)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if forged, exists := s.forged[n]; exists {
+		return forged, nil
+	}
+	if n >= uint64(len(s.headers)) {
+		return nil, nil
+	}
+	return s.headers[n], nil
+}
+
+func (s *testVerifyService) GetBlockByHash(hash common.Hash, full bool) (interface{}, error) {
+	for _, header := range s.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, nil
+}
+
+// forge replaces a header in the chain with an altered version.
+func (s *testVerifyService) forge(number uint64, alter func(header *types.Header), hash *common.Hash) {
+	header := types.CopyHeader(s.headers[number])
+	alter(header)
+	data, err := header.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+	res := make(map[string]interface{})
+	if err := json.Unmarshal(data, &res); err != nil {
+		panic(err)
+	}
+	if hash != nil {
+		res["hash"] = hash.Hex()
+	}
+	s.forged[number] = res
+}
+
+func TestVerifyHeaders(t *testing.T) {
+	ctx := context.Background()
+	defer viper.Set("trusted-block", nil)
+
+	tests := []struct {
+		name     string
+		trusted  func(s *testVerifyService) string
+		forge    func(s *testVerifyService)
+		blocks   []string
+		warnings int
+	}{
+		{
+			name: "Forward",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("10:%s", s.headers[10].Hash().Hex())
+			},
+			blocks: []string{"25", "latest", "20", "28"},
+		},
+		{
+			name: "Backward",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("20:%s", s.headers[20].Hash().Hex())
+			},
+			blocks: []string{"5", "0", "12", "20"},
+		},
+		{
+			name: "WrongTrustedHash",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("20:%s", s.headers[19].Hash().Hex())
+			},
+			blocks:   []string{"5", "25"},
+			warnings: 2,
+		},
+		{
+			name: "ForgedBeforeTrusted",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("20:%s", s.headers[20].Hash().Hex())
+			},
+			forge: func(s *testVerifyService) {
+				s.forge(5, func(header *types.Header) {
+					header.Root = common.HexToHash("0x0102")
+				}, nil)
+			},
+			blocks:   []string{"5", "4", "6"},
+			warnings: 2,
+		},
+		{
+			name: "ForgedHash",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("10:%s", s.headers[10].Hash().Hex())
+			},
+			forge: func(s *testVerifyService) {
+				hash := common.HexToHash("0x0102")
+				s.forge(25, func(header *types.Header) {}, &hash)
+			},
+			blocks:   []string{"25"},
+			warnings: 1,
+		},
+		{
+			name: "ChangedAfterVerification",
+			trusted: func(s *testVerifyService) string {
+				return fmt.Sprintf("10:%s", s.headers[10].Hash().Hex())
+			},
+			blocks:   []string{"25", "changed:25"},
+			warnings: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestVerifyService(30)
+			if test.forge != nil {
+				test.forge(service)
+			}
+			viper.Set("trusted-block", test.trusted(service))
+			c := newTestConn(t, service)
+			warnings := make([]string, 0)
+			var mu sync.Mutex
+			c.SetHeaderWarnings(func(msg string) {
+				mu.Lock()
+				warnings = append(warnings, msg)
+				mu.Unlock()
+			})
+
+			for _, block := range test.blocks {
+				if block == "latest" {
+					_, err := c.Client().HeaderByNumber(ctx, nil)
+					require.NoError(t, err)
+					continue
+				}
+				if len(block) > 8 && block[:8] == "changed:" {
+					number, err := strconv.ParseUint(block[8:], 10, 64)
+					require.NoError(t, err)
+					service.forge(number, func(header *types.Header) {
+						header.Extra = []byte("changed")
+					}, nil)
+					block = block[8:]
+				}
+				_, err := c.Block(ctx, block, false)
+				require.NoError(t, err)
+			}
+			require.Len(t, warnings, test.warnings, warnings)
+		})
+	}
+}
+
+func TestVerifyHeadersInvalidTrustedBlock(t *testing.T) {
+	ctx := context.Background()
+	defer viper.Set("trusted-block", nil)
+
+	service := newTestVerifyService(2)
+	url := newTestURL(t, map[string]interface{}{"eth": service})
+
+	for _, trusted := range []string{"10", "a:0x01", "10:0x0102"} {
+		viper.Set("trusted-block", trusted)
+		_, err := conn.New(ctx, url)
+		require.Error(t, err, trusted)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// waitPollInterval is the interval between checks when waiting for a transaction.
+var waitPollInterval = 5 * time.Second
+
+// ErrNotMined is returned when a transaction is not mined within the time limit.
+var ErrNotMined = errors.New("transaction not mined within time limit")
+
+// MinedTransaction contains information about a mined transaction.
+type MinedTransaction struct {
+	Receipt           *types.Receipt
+	Confirmations     uint64
+	EffectiveGasPrice *big.Int
+}
+
+// WaitForTransaction waits for the transaction with the given hash to be mined and to have
+// the given number of confirmations, where the block containing the transaction is the first
+// confirmation.  If the transaction is removed from its block by a reorganisation then waiting
+// continues until it is mined again.  If limit is 0 it will wait forever, otherwise it returns
+// ErrNotMined if the transaction is not mined with sufficient confirmations within the limit.
+func (c *Conn) WaitForTransaction(ctx context.Context,
+	hash common.Hash,
+	confirmations uint64,
+	limit time.Duration,
+) (
+	*MinedTransaction,
+	error,
+) {
+	if c.client == nil {
+		return nil, errors.New("cannot wait for transaction when offline")
+	}
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	if limit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limit)
+		defer cancel()
+	}
+
+	for {
+		mined, err := c.minedTransaction(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if mined != nil && mined.Confirmations >= confirmations {
+			return mined, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ErrNotMined
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// minedTransaction returns information about the transaction if it is mined in the canonical
+// chain, or nil if not.
+func (c *Conn) minedTransaction(ctx context.Context, hash common.Hash) (*MinedTransaction, error) {
+	opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	receipt, err := c.client.TransactionReceipt(opCtx, hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) || ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to obtain transaction receipt")
+	}
+
+	// Confirm that the block containing the transaction is still canonical.
+	header, err := c.client.HeaderByNumber(opCtx, receipt.BlockNumber)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to obtain block header")
+	}
+	if header.Hash() != receipt.BlockHash {
+		// Reorganised out; wait for it to be mined again.
+		return nil, nil
+	}
+
+	head, err := c.client.BlockNumber(opCtx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to obtain current block number")
+	}
+	mined := &MinedTransaction{
+		Receipt: receipt,
+	}
+	if head >= receipt.BlockNumber.Uint64() {
+		mined.Confirmations = head - receipt.BlockNumber.Uint64() + 1
+	}
+
+	tx, _, err := c.client.TransactionByHash(opCtx, hash)
+	if err == nil {
+		mined.EffectiveGasPrice = EffectiveGasPrice(tx, header.BaseFee)
+	}
+
+	return mined, nil
+}
+
+// EffectiveGasPrice calculates the gas price paid by a transaction in a block with the given
+// base fee.
+func EffectiveGasPrice(tx *types.Transaction, baseFee *big.Int) *big.Int {
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType || baseFee == nil {
+		return new(big.Int).Set(tx.GasPrice())
+	}
+	price := new(big.Int).Add(baseFee, tx.GasTipCap())
+	if price.Cmp(tx.GasFeeCap()) > 0 {
+		price.Set(tx.GasFeeCap())
+	}
+	return price
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/conn"
+)
+
+func TestEffectiveGasPrice(t *testing.T) {
+	legacyTx := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(50)})
+	dynamicTx := types.NewTx(&types.DynamicFeeTx{GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(10)})
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		baseFee  *big.Int
+		expected *big.Int
+	}{
+		{
+			name:     "Legacy",
+			tx:       legacyTx,
+			baseFee:  big.NewInt(40),
+			expected: big.NewInt(50),
+		},
+		{
+			name:     "DynamicNoBaseFee",
+			tx:       dynamicTx,
+			expected: big.NewInt(100),
+		},
+		{
+			name:     "DynamicUnderCap",
+			tx:       dynamicTx,
+			baseFee:  big.NewInt(40),
+			expected: big.NewInt(50),
+		},
+		{
+			name:     "DynamicCapped",
+			tx:       dynamicTx,
+			baseFee:  big.NewInt(95),
+			expected: big.NewInt(100),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected.String(), conn.EffectiveGasPrice(test.tx, test.baseFee).String())
+		})
+	}
+}
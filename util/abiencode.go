@@ -0,0 +1,250 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// ParseTypes parses a comma-separated list of ABI types, optionally in parentheses, for example
+// "(address,uint256)".  Parameter names are ignored.  Tuples are not supported.
+func ParseTypes(input string) ([]abi.Type, error) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "(") && strings.HasSuffix(input, ")") {
+		input = input[1 : len(input)-1]
+	}
+	canonical, err := canonicalParams(input)
+	if err != nil {
+		return nil, err
+	}
+	if canonical == "" {
+		return []abi.Type{}, nil
+	}
+	if strings.Contains(canonical, "(") {
+		return nil, fmt.Errorf("tuple types are not supported")
+	}
+	names := strings.Split(canonical, ",")
+	res := make([]abi.Type, len(names))
+	for i := range names {
+		res[i], err = abi.NewType(names[i], "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type %s", names[i])
+		}
+	}
+	return res, nil
+}
+
+// ParseABIValue parses a value of the given ABI type, returning it in the form required to
+// encode it with abi.Arguments.Pack().  Integers can be decimal or 0x-prefixed hex, bytes are
+// hex, and arrays are comma-separated elements within square brackets, for example "[1,2,3]".
+func ParseABIValue(typ abi.Type, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		value, ok := new(big.Int).SetString(input, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", input)
+		}
+		min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(typ.Size))
+		if typ.T == abi.IntTy {
+			max.Rsh(max, 1)
+			min.Neg(max)
+		}
+		if value.Cmp(min) < 0 || value.Cmp(max) >= 0 {
+			return nil, fmt.Errorf("integer %s out of range for %s", input, typ.String())
+		}
+		if typ.GetType() == reflect.TypeOf(value) {
+			return value, nil
+		}
+		res := reflect.New(typ.GetType()).Elem()
+		if typ.T == abi.IntTy {
+			res.SetInt(value.Int64())
+		} else {
+			res.SetUint(value.Uint64())
+		}
+		return res.Interface(), nil
+	case abi.BoolTy:
+		switch input {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		default:
+			return nil, fmt.Errorf("invalid boolean %s", input)
+		}
+	case abi.StringTy:
+		return input, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(input) {
+			return nil, fmt.Errorf("invalid address %s", input)
+		}
+		return common.HexToAddress(input), nil
+	case abi.BytesTy, abi.FixedBytesTy:
+		data, err := hexutil.Decode("0x" + strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes %s", input)
+		}
+		if typ.T == abi.BytesTy {
+			return data, nil
+		}
+		if len(data) != typ.Size {
+			return nil, fmt.Errorf("%s requires %d bytes but %d supplied", typ.String(), typ.Size, len(data))
+		}
+		res := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(res, reflect.ValueOf(data))
+		return res.Interface(), nil
+	case abi.ArrayTy, abi.SliceTy:
+		if !strings.HasPrefix(input, "[") || !strings.HasSuffix(input, "]") {
+			return nil, fmt.Errorf("array %s must be in square brackets", input)
+		}
+		elements := make([]string, 0)
+		if inner := strings.TrimSpace(input[1 : len(input)-1]); inner != "" {
+			elements = splitElements(inner)
+		}
+		var res reflect.Value
+		if typ.T == abi.ArrayTy {
+			if len(elements) != typ.Size {
+				return nil, fmt.Errorf("%s requires %d elements but %d supplied", typ.String(), typ.Size, len(elements))
+			}
+			res = reflect.New(typ.GetType()).Elem()
+		} else {
+			res = reflect.MakeSlice(typ.GetType(), len(elements), len(elements))
+		}
+		for i := range elements {
+			element, err := ParseABIValue(*typ.Elem, elements[i])
+			if err != nil {
+				return nil, err
+			}
+			res.Index(i).Set(reflect.ValueOf(element))
+		}
+		return res.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ.String())
+	}
+}
+
+// splitElements splits the elements of an array at top-level commas.
+func splitElements(input string) []string {
+	res := make([]string, 0)
+	depth := 0
+	start := 0
+	for i := range input {
+		switch input[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, strings.TrimSpace(input[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(res, strings.TrimSpace(input[start:]))
+}
+
+// EncodePacked encodes values in the non-standard packed mode of Solidity's abi.encodePacked():
+// static types use only as many bytes as they require, strings and bytes are not padded and
+// have no length, and array elements are padded to 32 bytes.  Values are in the form returned
+// by ParseABIValue().
+func EncodePacked(types []abi.Type, values []interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("%d types but %d values", len(types), len(values))
+	}
+	res := make([]byte, 0)
+	for i := range types {
+		data, err := encodePacked(types[i], reflect.ValueOf(values[i]), false)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, data...)
+	}
+	return res, nil
+}
+
+// encodePacked encodes a single value, padding it to 32 bytes if it is an array element.
+func encodePacked(typ abi.Type, value reflect.Value, inArray bool) ([]byte, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		var n *big.Int
+		switch value.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = big.NewInt(value.Int())
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = new(big.Int).SetUint64(value.Uint())
+		default:
+			n = new(big.Int).Set(value.Interface().(*big.Int))
+		}
+		if inArray {
+			return math.U256Bytes(n), nil
+		}
+		// Negative values are encoded in two's complement.
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(typ.Size)), big.NewInt(1))
+		return math.PaddedBigBytes(n.And(n, mask), typ.Size/8), nil
+	case abi.BoolTy:
+		res := []byte{0x00}
+		if value.Bool() {
+			res[0] = 0x01
+		}
+		if inArray {
+			return common.LeftPadBytes(res, 32), nil
+		}
+		return res, nil
+	case abi.AddressTy:
+		address := value.Interface().(common.Address)
+		if inArray {
+			return common.LeftPadBytes(address.Bytes(), 32), nil
+		}
+		return address.Bytes(), nil
+	case abi.FixedBytesTy:
+		res := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(res), value)
+		if inArray {
+			return common.RightPadBytes(res, 32), nil
+		}
+		return res, nil
+	case abi.StringTy, abi.BytesTy:
+		if inArray {
+			return nil, fmt.Errorf("arrays of %s cannot be packed", typ.String())
+		}
+		if typ.T == abi.StringTy {
+			return []byte(value.String()), nil
+		}
+		return value.Bytes(), nil
+	case abi.ArrayTy, abi.SliceTy:
+		if inArray {
+			return nil, fmt.Errorf("nested arrays cannot be packed")
+		}
+		res := make([]byte, 0)
+		for i := 0; i < value.Len(); i++ {
+			data, err := encodePacked(*typ.Elem, value.Index(i), true)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, data...)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("%s cannot be packed", typ.String())
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		types []string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: "()",
+			types: []string{},
+		},
+		{
+			name:  "Parens",
+			input: "(address,uint256)",
+			types: []string{"address", "uint256"},
+		},
+		{
+			name:  "Names",
+			input: "address owner, uint nonce, bytes32[] hashes",
+			types: []string{"address", "uint256", "bytes32[]"},
+		},
+		{
+			name:  "Tuple",
+			input: "(address,(uint256,bool))",
+			err:   "tuple types are not supported",
+		},
+		{
+			name:  "Invalid",
+			input: "adress",
+			err:   "invalid type adress",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			types, err := ParseTypes(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, len(types))
+			for i := range types {
+				names[i] = types[i].String()
+			}
+			require.Equal(t, test.types, names)
+		})
+	}
+}
+
+func TestParseABIValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		input string
+		value string
+		err   string
+	}{
+		{
+			name:  "Uint8",
+			typ:   "uint8",
+			input: "255",
+			value: "255",
+		},
+		{
+			name:  "Uint8Overflow",
+			typ:   "uint8",
+			input: "256",
+			err:   "integer 256 out of range for uint8",
+		},
+		{
+			name:  "Uint24Hex",
+			typ:   "uint24",
+			input: "0xffffff",
+			value: "16777215",
+		},
+		{
+			name:  "UintNegative",
+			typ:   "uint256",
+			input: "-1",
+			err:   "integer -1 out of range for uint256",
+		},
+		{
+			name:  "Int16Negative",
+			typ:   "int16",
+			input: "-32768",
+			value: "-32768",
+		},
+		{
+			name:  "Int16Underflow",
+			typ:   "int16",
+			input: "-32769",
+			err:   "integer -32769 out of range for int16",
+		},
+		{
+			name:  "Bool",
+			typ:   "bool",
+			input: "true",
+			value: "true",
+		},
+		{
+			name:  "BoolInvalid",
+			typ:   "bool",
+			input: "yes",
+			err:   "invalid boolean yes",
+		},
+		{
+			name:  "Address",
+			typ:   "address",
+			input: "0x5FfC014343cd971B7eb70732021E26C35B744cc4",
+			value: "0x5FfC014343cd971B7eb70732021E26C35B744cc4",
+		},
+		{
+			name:  "AddressInvalid",
+			typ:   "address",
+			input: "0x1234",
+			err:   "invalid address 0x1234",
+		},
+		{
+			name:  "Bytes",
+			typ:   "bytes",
+			input: "0x0102",
+			value: "[1 2]",
+		},
+		{
+			name:  "Bytes2",
+			typ:   "bytes2",
+			input: "0102",
+			value: "[1 2]",
+		},
+		{
+			name:  "Bytes2Short",
+			typ:   "bytes2",
+			input: "0x01",
+			err:   "bytes2 requires 2 bytes but 1 supplied",
+		},
+		{
+			name:  "Slice",
+			typ:   "uint16[]",
+			input: "[1, 2, 3]",
+			value: "[1 2 3]",
+		},
+		{
+			name:  "EmptySlice",
+			typ:   "uint256[]",
+			input: "[]",
+			value: "[]",
+		},
+		{
+			name:  "Array",
+			typ:   "uint256[2]",
+			input: "[1,2,3]",
+			err:   "uint256[2] requires 2 elements but 3 supplied",
+		},
+		{
+			name:  "NestedArray",
+			typ:   "uint8[][]",
+			input: "[[1,2],[3]]",
+			value: "[[1 2] [3]]",
+		},
+		{
+			name:  "ArrayNoBrackets",
+			typ:   "uint256[]",
+			input: "1,2",
+			err:   "array 1,2 must be in square brackets",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typ, err := abi.NewType(test.typ, "", nil)
+			require.NoError(t, err)
+			value, err := ParseABIValue(typ, test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.value, fmt.Sprintf("%v", value))
+			// Values must be acceptable to the standard encoder.
+			_, err = abi.Arguments{{Type: typ}}.Pack(value)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEncodePacked(t *testing.T) {
+	tests := []struct {
+		name   string
+		types  string
+		values []string
+		data   string
+		err    string
+	}{
+		{
+			// Example from the Solidity documentation.
+			name:   "Documentation",
+			types:  "int16,bytes1,uint16,string",
+			values: []string{"-1", "0x42", "0x03", "Hello, world!"},
+			data:   "0xffff42000348656c6c6f2c20776f726c6421",
+		},
+		{
+			name:   "AddressUint",
+			types:  "address,uint256",
+			values: []string{"0x5FfC014343cd971B7eb70732021E26C35B744cc4", "1"},
+			data:   "0x5ffc014343cd971b7eb70732021e26c35b744cc40000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{
+			name:   "BoolBytes",
+			types:  "bool,bytes",
+			values: []string{"true", "0x0102"},
+			data:   "0x010102",
+		},
+		{
+			name:   "Arrays",
+			types:  "uint8[],bool[2],int8[]",
+			values: []string{"[1,2]", "[true,false]", "[-1]"},
+			data:   "0x0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		},
+		{
+			name:   "BytesNArray",
+			types:  "bytes2[]",
+			values: []string{"[0x0102]"},
+			data:   "0x0102000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:   "StringArray",
+			types:  "string[]",
+			values: []string{"[a,b]"},
+			err:    "arrays of string cannot be packed",
+		},
+		{
+			name:   "NestedArray",
+			types:  "uint8[][]",
+			values: []string{"[[1]]"},
+			err:    "nested arrays cannot be packed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			types, err := ParseTypes(test.types)
+			require.NoError(t, err)
+			values := make([]interface{}, len(test.values))
+			for i := range test.values {
+				values[i], err = ParseABIValue(types[i], test.values[i])
+				require.NoError(t, err)
+			}
+			data, err := EncodePacked(types, values)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.data, fmt.Sprintf("%#x", data))
+		})
+	}
+
+	_, err := EncodePacked([]abi.Type{}, []interface{}{1})
+	require.EqualError(t, err, "0 types but 1 values")
+}
@@ -0,0 +1,60 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package abisource obtains verified contract ABIs from online sources.
+package abisource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/explorer"
+)
+
+// ErrNotFound is returned when the source does not have an ABI for the contract.
+var ErrNotFound = errors.New("ABI not found")
+
+// Source is a source of contract ABIs.
+type Source interface {
+	// ABI returns the JSON ABI for the contract at the given address on the given chain.
+	ABI(ctx context.Context, chainID *big.Int, address common.Address) (string, error)
+}
+
+// New creates a source given its name.  The key is the API key, used by Etherscan, and the URL
+// overrides the default endpoint of the source; it is required for Blockscout.
+func New(name string, key string, url string) (Source, error) {
+	etherscanClient, sourcifyClient, err := explorer.Clients(name, key, url)
+	if errors.Is(err, explorer.ErrUnknown) {
+		return nil, fmt.Errorf("unknown ABI source %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sourcifyClient != nil {
+		return &Sourcify{client: sourcifyClient}, nil
+	}
+	return &Etherscan{client: etherscanClient}, nil
+}
+
+// validate ensures that the data is a JSON ABI.
+func validate(data []byte) error {
+	var abi []interface{}
+	if err := json.Unmarshal(data, &abi); err != nil {
+		return errors.Wrap(err, "invalid ABI")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abisource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[{"inputs":[],"name":"totalSupply","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+var testAddress = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+func TestEtherscan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("address") {
+		case testAddress.Hex():
+			fmt.Fprintf(w, `{"status":"1","message":"OK","result":%q}`, testABI)
+		default:
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Contract source code not verified"}`)
+		}
+	}))
+	defer server.Close()
+
+	source, err := New("blockscout", "", server.URL)
+	require.NoError(t, err)
+
+	abi, err := source.ABI(context.Background(), big.NewInt(1), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, testABI, abi)
+
+	_, err = source.ABI(context.Background(), big.NewInt(1), common.Address{})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSourcify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == fmt.Sprintf("/v2/contract/5/%s", testAddress.Hex()) {
+			require.Equal(t, "abi", r.URL.Query().Get("fields"))
+			fmt.Fprintf(w, `{"match":"match","chainId":"5","address":%q,"abi":%s}`, testAddress.Hex(), testABI)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	source, err := New("sourcify", "", server.URL)
+	require.NoError(t, err)
+
+	abi, err := source.ABI(context.Background(), big.NewInt(5), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, testABI, abi)
+
+	_, err = source.ABI(context.Background(), big.NewInt(1), testAddress)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":%q}`, testABI)
+	}))
+	defer server.Close()
+
+	source, err := New("etherscan", "key", server.URL)
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "abisource")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cache := NewCache(source, dir)
+
+	for i := 0; i < 2; i++ {
+		abi, err := cache.ABI(context.Background(), big.NewInt(1), testAddress)
+		require.NoError(t, err)
+		assert.Equal(t, testABI, abi)
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("blockscout", "", "")
+	assert.EqualError(t, err, "blockscout requires a URL")
+	_, err = New("unknown", "", "")
+	assert.EqualError(t, err, "unknown ABI source unknown")
+}
@@ -0,0 +1,60 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abisource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Cache caches the ABIs obtained from another source on the filesystem.
+type Cache struct {
+	source Source
+	dir    string
+}
+
+// NewCache creates a cache for the source, storing ABIs under the given directory.
+func NewCache(source Source, dir string) *Cache {
+	return &Cache{
+		source: source,
+		dir:    dir,
+	}
+}
+
+// ABI returns the JSON ABI for the contract at the given address on the given chain.
+func (c *Cache) ABI(ctx context.Context, chainID *big.Int, address common.Address) (string, error) {
+	path := filepath.Join(c.dir, chainID.String(), fmt.Sprintf("%s.abi", strings.ToLower(address.Hex())))
+	if data, err := ioutil.ReadFile(path); err == nil {
+		return string(data), nil
+	}
+
+	abi, err := c.source.ABI(ctx, chainID, address)
+	if err != nil {
+		return "", err
+	}
+
+	// Failure to cache is not fatal.
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+		_ = ioutil.WriteFile(path, []byte(abi), 0600)
+	}
+
+	return abi, nil
+}
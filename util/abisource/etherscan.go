@@ -0,0 +1,43 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abisource
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+)
+
+// Etherscan obtains ABIs from Etherscan, or any service with a compatible API such as Blockscout.
+type Etherscan struct {
+	client *etherscan.Client
+}
+
+// ABI returns the JSON ABI for the contract at the given address on the given chain.
+func (s *Etherscan) ABI(ctx context.Context, chainID *big.Int, address common.Address) (string, error) {
+	abi, err := s.client.ABI(ctx, chainID, address)
+	if errors.Is(err, etherscan.ErrNotVerified) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := validate([]byte(abi)); err != nil {
+		return "", err
+	}
+	return abi, nil
+}
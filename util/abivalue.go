@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ABIValueToString formats a value unpacked from ABI-encoded data according to its type.
+// Addresses are formatted with formatAddress if supplied, otherwise as checksummed hex.
+func ABIValueToString(argType abi.Type, val interface{}, formatAddress func(common.Address) string) (string, error) {
+	switch argType.T {
+	case abi.IntTy:
+		return fmt.Sprintf("%v", val), nil
+	case abi.UintTy:
+		return fmt.Sprintf("%v", val), nil
+	case abi.BoolTy:
+		if val.(bool) {
+			return "true", nil
+		}
+		return "false", nil
+	case abi.StringTy:
+		return val.(string), nil
+	case abi.SliceTy, abi.ArrayTy:
+		res := make([]string, 0)
+		arrayVal := reflect.ValueOf(val)
+		for i := 0; i < arrayVal.Len(); i++ {
+			elemRes, err := ABIValueToString(*argType.Elem, arrayVal.Index(i).Interface(), formatAddress)
+			if err != nil {
+				return "", err
+			}
+			res = append(res, elemRes)
+		}
+		return "[" + strings.Join(res, ",") + "]", nil
+	case abi.AddressTy:
+		addr := val.(common.Address)
+		if formatAddress == nil {
+			return addr.Hex(), nil
+		}
+		return formatAddress(addr), nil
+	case abi.FixedBytesTy:
+		arrayVal := reflect.ValueOf(val)
+		castVal := make([]byte, arrayVal.Len())
+		for i := 0; i < arrayVal.Len(); i++ {
+			castVal[i] = byte(arrayVal.Index(i).Uint())
+		}
+		return fmt.Sprintf("0x%s", hex.EncodeToString(castVal)), nil
+	case abi.BytesTy:
+		return fmt.Sprintf("0x%s", hex.EncodeToString(val.([]byte))), nil
+	case abi.HashTy:
+		return val.(common.Hash).Hex(), nil
+	case abi.TupleTy:
+		res := make([]string, 0)
+		tupleVal := reflect.ValueOf(val)
+		for i, elem := range argType.TupleElems {
+			elemRes, err := ABIValueToString(*elem, tupleVal.Field(i).Interface(), formatAddress)
+			if err != nil {
+				return "", err
+			}
+			if argType.TupleRawNames[i] != "" {
+				elemRes = fmt.Sprintf("%s:%s", argType.TupleRawNames[i], elemRes)
+			}
+			res = append(res, elemRes)
+		}
+		return "{" + strings.Join(res, ",") + "}", nil
+	case abi.FixedPointTy:
+		return "", fmt.Errorf("unhandled type %v", argType)
+	case abi.FunctionTy:
+		return "", fmt.Errorf("unhandled type %v", argType)
+	default:
+		return "", fmt.Errorf("unknown type %v", argType)
+	}
+}
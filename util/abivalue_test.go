@@ -0,0 +1,121 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABIValueToString(t *testing.T) {
+	address := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	tupleType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "holder", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+	})
+	require.NoError(t, err)
+	tuple := struct {
+		Holder common.Address
+		Amount *big.Int
+	}{Holder: address, Amount: big.NewInt(5)}
+
+	tests := []struct {
+		name          string
+		argType       string
+		abiType       *abi.Type
+		val           interface{}
+		formatAddress func(common.Address) string
+		res           string
+		err           string
+	}{
+		{
+			name:    "Int",
+			argType: "int256",
+			val:     big.NewInt(-1),
+			res:     "-1",
+		},
+		{
+			name:    "Bool",
+			argType: "bool",
+			val:     true,
+			res:     "true",
+		},
+		{
+			name:    "String",
+			argType: "string",
+			val:     "hello",
+			res:     "hello",
+		},
+		{
+			name:    "Address",
+			argType: "address",
+			val:     address,
+			res:     "0x5FfC014343cd971B7eb70732021E26C35B744cc4",
+		},
+		{
+			name:          "AddressFormatted",
+			argType:       "address",
+			val:           address,
+			formatAddress: func(common.Address) string { return "test.eth" },
+			res:           "test.eth",
+		},
+		{
+			name:    "FixedBytes",
+			argType: "bytes2",
+			val:     [2]byte{0x01, 0x02},
+			res:     "0x0102",
+		},
+		{
+			name:    "Array",
+			argType: "uint8[2]",
+			val:     [2]uint8{1, 2},
+			res:     "[1,2]",
+		},
+		{
+			name:    "Tuple",
+			abiType: &tupleType,
+			val:     tuple,
+			res:     "{holder:0x5FfC014343cd971B7eb70732021E26C35B744cc4,amount:5}",
+		},
+		{
+			name:    "Function",
+			argType: "function",
+			val:     [24]byte{},
+			err:     "unhandled type function",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			argType := test.abiType
+			if argType == nil {
+				parsedType, err := abi.NewType(test.argType, "", nil)
+				require.NoError(t, err)
+				argType = &parsedType
+			}
+			res, err := ABIValueToString(*argType, test.val, test.formatAddress)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
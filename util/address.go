@@ -0,0 +1,130 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AddressChecksum is the state of the EIP-55 checksum of an address.
+type AddressChecksum int
+
+const (
+	// ChecksumNone is an address that is all lower-case or all upper-case, so has no checksum.
+	ChecksumNone AddressChecksum = iota
+	// ChecksumValid is an address with a correct checksum.
+	ChecksumValid
+	// ChecksumInvalid is an address with mixed case that does not match its checksum, so is
+	// likely to have been mistyped.
+	ChecksumInvalid
+)
+
+// String returns a description of the checksum state.
+func (c AddressChecksum) String() string {
+	switch c {
+	case ChecksumNone:
+		return "none"
+	case ChecksumValid:
+		return "valid"
+	case ChecksumInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// AddressValidation is the result of validating an address.
+type AddressValidation struct {
+	// Address is the address.
+	Address common.Address
+	// Checksum is the state of the address's checksum.
+	Checksum AddressChecksum
+	// Mismatches are the positions of the hex characters, after the 0x prefix, whose case
+	// does not match the checksum.
+	Mismatches []int
+}
+
+// ValidateAddress validates a hex address and its EIP-55 checksum.  An error is returned if
+// the input is not a 20-byte hex string with an optional 0x prefix.
+func ValidateAddress(input string) (*AddressValidation, error) {
+	input = strings.TrimSpace(input)
+	hexStr := strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X")
+	if len(hexStr) != 2*common.AddressLength {
+		return nil, fmt.Errorf("address %s is %d characters long rather than %d", input, len(hexStr), 2*common.AddressLength)
+	}
+	if _, err := hex.DecodeString(hexStr); err != nil {
+		return nil, fmt.Errorf("address %s is not hex", input)
+	}
+
+	res := &AddressValidation{
+		Address:    common.HexToAddress(hexStr),
+		Mismatches: make([]int, 0),
+	}
+	if hexStr == strings.ToLower(hexStr) || hexStr == strings.ToUpper(hexStr) {
+		res.Checksum = ChecksumNone
+		return res, nil
+	}
+	checksummed := strings.TrimPrefix(res.Address.Hex(), "0x")
+	for i := range hexStr {
+		if hexStr[i] != checksummed[i] {
+			res.Mismatches = append(res.Mismatches, i)
+		}
+	}
+	if len(res.Mismatches) == 0 {
+		res.Checksum = ChecksumValid
+	} else {
+		res.Checksum = ChecksumInvalid
+	}
+	return res, nil
+}
+
+// AddressFromKey derives an address from a hex private key or public key, returning the address
+// and the type of key supplied.  Private keys are 32 bytes, compressed public keys 33 bytes and
+// uncompressed public keys 65 bytes, or 64 bytes without their 0x04 prefix.
+func AddressFromKey(input string) (common.Address, string, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(input), "0x"))
+	if err != nil {
+		return common.Address{}, "", fmt.Errorf("key is not hex")
+	}
+	switch len(data) {
+	case 32:
+		key, err := crypto.ToECDSA(data)
+		if err != nil {
+			return common.Address{}, "", fmt.Errorf("invalid private key: %v", err)
+		}
+		return crypto.PubkeyToAddress(key.PublicKey), "private key", nil
+	case 33:
+		pubKey, err := crypto.DecompressPubkey(data)
+		if err != nil {
+			return common.Address{}, "", fmt.Errorf("invalid compressed public key: %v", err)
+		}
+		return crypto.PubkeyToAddress(*pubKey), "compressed public key", nil
+	case 64:
+		data = append([]byte{0x04}, data...)
+		fallthrough
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(data)
+		if err != nil {
+			return common.Address{}, "", fmt.Errorf("invalid public key: %v", err)
+		}
+		return crypto.PubkeyToAddress(*pubKey), "public key", nil
+	default:
+		return common.Address{}, "", fmt.Errorf("key of %d bytes is neither a private key nor a public key", len(data))
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		checksum   AddressChecksum
+		mismatches []int
+		err        string
+	}{
+		{
+			name:     "Checksummed",
+			input:    "0x5FfC014343cd971B7eb70732021E26C35B744cc4",
+			checksum: ChecksumValid,
+		},
+		{
+			name:     "NoPrefix",
+			input:    "5FfC014343cd971B7eb70732021E26C35B744cc4",
+			checksum: ChecksumValid,
+		},
+		{
+			name:     "Lower",
+			input:    "0x5ffc014343cd971b7eb70732021e26c35b744cc4",
+			checksum: ChecksumNone,
+		},
+		{
+			name:     "Upper",
+			input:    "0x5FFC014343CD971B7EB70732021E26C35B744CC4",
+			checksum: ChecksumNone,
+		},
+		{
+			name:       "Mistyped",
+			input:      "0x5FfC014343cd971B7eb70732021E26C35B744Cc4",
+			checksum:   ChecksumInvalid,
+			mismatches: []int{37},
+		},
+		{
+			name:  "Short",
+			input: "0x5FfC014343cd971B7eb70732021E26C35B744cc",
+			err:   "address 0x5FfC014343cd971B7eb70732021E26C35B744cc is 39 characters long rather than 40",
+		},
+		{
+			name:  "NotHex",
+			input: "0x5FfC014343cd971B7eb70732021E26C35B744cg4",
+			err:   "address 0x5FfC014343cd971B7eb70732021E26C35B744cg4 is not hex",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := ValidateAddress(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), res.Address)
+			require.Equal(t, test.checksum, res.Checksum)
+			if test.mismatches == nil {
+				require.Empty(t, res.Mismatches)
+			} else {
+				require.Equal(t, test.mismatches, res.Mismatches)
+			}
+		})
+	}
+}
+
+func TestAddressFromKey(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	uncompressed := crypto.FromECDSAPub(&key.PublicKey)
+
+	tests := []struct {
+		name    string
+		input   string
+		keyType string
+		err     string
+	}{
+		{
+			name:    "PrivateKey",
+			input:   "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			keyType: "private key",
+		},
+		{
+			name:    "CompressedPublicKey",
+			input:   hex.EncodeToString(crypto.CompressPubkey(&key.PublicKey)),
+			keyType: "compressed public key",
+		},
+		{
+			name:    "PublicKey",
+			input:   "0x" + hex.EncodeToString(uncompressed),
+			keyType: "public key",
+		},
+		{
+			name:    "PublicKeyNoPrefix",
+			input:   hex.EncodeToString(uncompressed[1:]),
+			keyType: "public key",
+		},
+		{
+			name:  "NotHex",
+			input: "0xzz",
+			err:   "key is not hex",
+		},
+		{
+			name:  "Length",
+			input: "0x0102",
+			err:   "key of 2 bytes is neither a private key nor a public key",
+		},
+		{
+			name:  "InvalidPrivateKey",
+			input: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			err:   "invalid private key: invalid private key, zero or negative",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, keyType, err := AddressFromKey(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, address, res)
+			require.Equal(t, test.keyType, keyType)
+		})
+	}
+}
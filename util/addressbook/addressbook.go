@@ -0,0 +1,181 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package addressbook provides labels for addresses, stored in a file.
+package addressbook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Entry is a labelled address.
+type Entry struct {
+	Label   string
+	Address common.Address
+}
+
+// Book is an address book.
+type Book struct {
+	path string
+	// entries are keyed by lower-case label, as labels are case-insensitive.
+	entries map[string]*Entry
+}
+
+// New creates an address book stored in the given file, loading any existing entries.
+func New(path string) (*Book, error) {
+	book := &Book{
+		path:    path,
+		entries: make(map[string]*Entry),
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return book, nil
+		}
+		return nil, errors.Wrap(err, "failed to read address book")
+	}
+	stored := make(map[string]string)
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, errors.Wrapf(err, "invalid address book %s", path)
+	}
+	for label, address := range stored {
+		if err := ValidateLabel(label); err != nil {
+			return nil, errors.Wrapf(err, "invalid address book %s", path)
+		}
+		if !common.IsHexAddress(address) {
+			return nil, errors.Errorf("invalid address book %s: invalid address %s for %s", path, address, label)
+		}
+		book.entries[strings.ToLower(label)] = &Entry{
+			Label:   label,
+			Address: common.HexToAddress(address),
+		}
+	}
+	return book, nil
+}
+
+// ValidateLabel returns an error if the label cannot be used.  Labels cannot be confused with
+// addresses or ENS names, so cannot start with 0x, be an address without the 0x prefix, or
+// contain dots or whitespace.
+func ValidateLabel(label string) error {
+	if label == "" {
+		return errors.New("no label supplied")
+	}
+	if strings.HasPrefix(strings.ToLower(label), "0x") {
+		return errors.Errorf("label %s cannot start with 0x", label)
+	}
+	if common.IsHexAddress(label) {
+		return errors.Errorf("label %s cannot be an address", label)
+	}
+	for _, r := range label {
+		if r == '.' || unicode.IsSpace(r) {
+			return errors.Errorf("label %s cannot contain dots or whitespace", label)
+		}
+	}
+	return nil
+}
+
+// Add adds a labelled address to the address book.
+func (b *Book) Add(label string, address common.Address) error {
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+	if entry, exists := b.entries[strings.ToLower(label)]; exists {
+		return errors.Errorf("label %s already exists for %s", entry.Label, entry.Address.Hex())
+	}
+	b.entries[strings.ToLower(label)] = &Entry{
+		Label:   label,
+		Address: address,
+	}
+	return nil
+}
+
+// Remove removes a labelled address from the address book.
+func (b *Book) Remove(label string) error {
+	if _, exists := b.entries[strings.ToLower(label)]; !exists {
+		return errors.Errorf("unknown label %s", label)
+	}
+	delete(b.entries, strings.ToLower(label))
+	return nil
+}
+
+// Address returns the address for a label.
+func (b *Book) Address(label string) (common.Address, bool) {
+	entry, exists := b.entries[strings.ToLower(label)]
+	if !exists {
+		return common.Address{}, false
+	}
+	return entry.Address, true
+}
+
+// Label returns the label for an address.  If the address has more than one label then the
+// first in alphabetical order is returned.
+func (b *Book) Label(address common.Address) (string, bool) {
+	for _, entry := range b.Entries() {
+		if entry.Address == address {
+			return entry.Label, true
+		}
+	}
+	return "", false
+}
+
+// Entries returns the entries in the address book, ordered by label.
+func (b *Book) Entries() []*Entry {
+	res := make([]*Entry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		res = append(res, entry)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return strings.ToLower(res[i].Label) < strings.ToLower(res[j].Label)
+	})
+	return res
+}
+
+// Save writes the address book to its file.  The file is replaced atomically so that an
+// interrupted write does not corrupt it.
+func (b *Book) Save() error {
+	stored := make(map[string]string, len(b.entries))
+	for _, entry := range b.entries {
+		stored[entry.Label] = entry.Address.Hex()
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(b.path), filepath.Base(b.path))
+	if err != nil {
+		return errors.Wrap(err, "failed to create address book")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write address book")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write address book")
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write address book")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addressbook_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/addressbook"
+)
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		err   string
+	}{
+		{
+			name:  "Good",
+			label: "vitalik",
+		},
+		{
+			name: "Empty",
+			err:  "no label supplied",
+		},
+		{
+			name:  "Hex",
+			label: "0xdead",
+			err:   "label 0xdead cannot start with 0x",
+		},
+		{
+			name:  "UnprefixedAddress",
+			label: "d8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			err:   "label d8dA6BF26964aF9D7eEd9e03E53415D37aA96045 cannot be an address",
+		},
+		{
+			name:  "Dot",
+			label: "vitalik.eth",
+			err:   "label vitalik.eth cannot contain dots or whitespace",
+		},
+		{
+			name:  "Space",
+			label: "my wallet",
+			err:   "label my wallet cannot contain dots or whitespace",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := addressbook.ValidateLabel(test.label)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "addressbook.json")
+
+	vitalik := common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	other := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+	book, err := addressbook.New(path)
+	require.NoError(t, err)
+	require.Len(t, book.Entries(), 0)
+
+	require.NoError(t, book.Add("Vitalik", vitalik))
+	require.NoError(t, book.Add("wallet", other))
+	require.NoError(t, book.Add("alias", other))
+	require.EqualError(t, book.Add("vitalik", other), "label Vitalik already exists for 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	require.EqualError(t, book.Add("bad.label", other), "label bad.label cannot contain dots or whitespace")
+
+	address, exists := book.Address("VITALIK")
+	require.True(t, exists)
+	require.Equal(t, vitalik, address)
+	_, exists = book.Address("unknown")
+	require.False(t, exists)
+
+	label, exists := book.Label(other)
+	require.True(t, exists)
+	require.Equal(t, "alias", label)
+	_, exists = book.Label(common.Address{})
+	require.False(t, exists)
+
+	require.NoError(t, book.Save())
+
+	// Reload the book from its file.
+	book, err = addressbook.New(path)
+	require.NoError(t, err)
+	entries := book.Entries()
+	require.Len(t, entries, 3)
+	require.Equal(t, "alias", entries[0].Label)
+	require.Equal(t, "Vitalik", entries[1].Label)
+	require.Equal(t, vitalik, entries[1].Address)
+	require.Equal(t, "wallet", entries[2].Label)
+
+	require.NoError(t, book.Remove("WALLET"))
+	require.EqualError(t, book.Remove("wallet"), "unknown label wallet")
+	require.Len(t, book.Entries(), 2)
+}
+
+func TestBookInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "addressbook.json")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"wallet":"0x1234"}`), 0600))
+	_, err = addressbook.New(path)
+	require.EqualError(t, err, "invalid address book "+path+": invalid address 0x1234 for wallet")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`not json`), 0600))
+	_, err = addressbook.New(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// BatchRow is a single transaction in a batch, as supplied in a batch file.  The recipient and
+// value are as supplied, and are resolved and parsed by the caller.
+type BatchRow struct {
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+// ParseBatch parses a batch of transactions.  The input can be a JSON array of objects with
+// to, value and data fields, or CSV with to, value and data columns in that order.  CSV input
+// can have a header row, and the value and data columns are optional.
+func ParseBatch(input []byte) ([]*BatchRow, error) {
+	input = bytes.TrimSpace(input)
+	if len(input) == 0 {
+		return nil, errors.New("no transactions supplied")
+	}
+
+	var rows []*BatchRow
+	var err error
+	if input[0] == '[' {
+		rows, err = parseBatchJSON(input)
+	} else {
+		rows, err = parseBatchCSV(input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no transactions supplied")
+	}
+	for i, row := range rows {
+		if row.To == "" {
+			return nil, fmt.Errorf("transaction %d has no recipient", i+1)
+		}
+		if row.Data != "" && !strings.HasPrefix(row.Data, "0x") {
+			return nil, fmt.Errorf("transaction %d has data that is not hex", i+1)
+		}
+	}
+	return rows, nil
+}
+
+func parseBatchJSON(input []byte) ([]*BatchRow, error) {
+	rows := make([]*BatchRow, 0)
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	for i, row := range rows {
+		if row == nil {
+			return nil, fmt.Errorf("transaction %d is empty", i+1)
+		}
+		row.To = strings.TrimSpace(row.To)
+		row.Value = strings.TrimSpace(row.Value)
+		row.Data = strings.TrimSpace(row.Data)
+	}
+	return rows, nil
+}
+
+func parseBatchCSV(input []byte) ([]*BatchRow, error) {
+	reader := csv.NewReader(bytes.NewReader(input))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	rows := make([]*BatchRow, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid CSV")
+		}
+		if len(rows) == 0 && line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "to") {
+			// Header row.
+			continue
+		}
+		if len(record) > 3 {
+			return nil, fmt.Errorf("row %d has %d columns; expected at most 3", line, len(record))
+		}
+		row := &BatchRow{
+			To: strings.TrimSpace(record[0]),
+		}
+		if len(record) > 1 {
+			row.Value = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			row.Data = strings.TrimSpace(record[2])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+func TestParseBatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		rows  []*util.BatchRow
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: " \n",
+			err:   "no transactions supplied",
+		},
+		{
+			name:  "CSV",
+			input: "0x5FfC014343cd971B7eb70732021E26C35B744cc4,1 ether\nalice.eth, 0.5ether, 0x01\n",
+			rows: []*util.BatchRow{
+				{To: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", Value: "1 ether"},
+				{To: "alice.eth", Value: "0.5ether", Data: "0x01"},
+			},
+		},
+		{
+			name:  "CSVHeader",
+			input: "to,value,data\n# Comment\n0x5FfC014343cd971B7eb70732021E26C35B744cc4\n",
+			rows: []*util.BatchRow{
+				{To: "0x5FfC014343cd971B7eb70732021E26C35B744cc4"},
+			},
+		},
+		{
+			name:  "CSVHeaderOnly",
+			input: "to,value,data\n",
+			err:   "no transactions supplied",
+		},
+		{
+			name:  "CSVTooManyColumns",
+			input: "alice.eth,1,0x01,extra\n",
+			err:   "row 1 has 4 columns; expected at most 3",
+		},
+		{
+			name:  "CSVMissingRecipient",
+			input: "alice.eth,1\n,2\n",
+			err:   "transaction 2 has no recipient",
+		},
+		{
+			name:  "CSVDataNotHex",
+			input: "alice.eth,1,0102\n",
+			err:   "transaction 1 has data that is not hex",
+		},
+		{
+			name:  "JSON",
+			input: `[{"to":"alice.eth","value":"1 ether"},{"to":"0x5FfC014343cd971B7eb70732021E26C35B744cc4","data":"0x0102"}]`,
+			rows: []*util.BatchRow{
+				{To: "alice.eth", Value: "1 ether"},
+				{To: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", Data: "0x0102"},
+			},
+		},
+		{
+			name:  "JSONUnknownField",
+			input: `[{"to":"alice.eth","amount":"1 ether"}]`,
+			err:   `invalid JSON: json: unknown field "amount"`,
+		},
+		{
+			name:  "JSONEmpty",
+			input: `[]`,
+			err:   "no transactions supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := util.ParseBatch([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.rows, rows)
+			}
+		})
+	}
+}
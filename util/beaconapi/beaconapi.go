@@ -0,0 +1,243 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconapi obtains validator information from the REST API of a beacon node.
+package beaconapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// FarFutureEpoch is the epoch used for events that have not been scheduled.
+const FarFutureEpoch = uint64(math.MaxUint64)
+
+// Client is a client for a beacon node.
+type Client struct {
+	url string
+}
+
+// New creates a new client for the beacon node at the given URL.
+func New(url string) *Client {
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+	}
+}
+
+// Validator is the state of a validator.
+type Validator struct {
+	Index                      uint64
+	Status                     string
+	Balance                    uint64
+	PublicKey                  []byte
+	WithdrawalCredentials      []byte
+	EffectiveBalance           uint64
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+// Spec is the subset of the chain specification required to reason about validators.
+type Spec struct {
+	SecondsPerSlot       uint64
+	SlotsPerEpoch        uint64
+	ShardCommitteePeriod uint64
+}
+
+type validatorResponse struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Status    string `json:"status"`
+	Validator struct {
+		PublicKey                  string `json:"pubkey"`
+		WithdrawalCredentials      string `json:"withdrawal_credentials"`
+		EffectiveBalance           string `json:"effective_balance"`
+		Slashed                    bool   `json:"slashed"`
+		ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+		ActivationEpoch            string `json:"activation_epoch"`
+		ExitEpoch                  string `json:"exit_epoch"`
+		WithdrawableEpoch          string `json:"withdrawable_epoch"`
+	} `json:"validator"`
+}
+
+type headerResponse struct {
+	Header struct {
+		Message struct {
+			Slot string `json:"slot"`
+		} `json:"message"`
+	} `json:"header"`
+}
+
+type genesisResponse struct {
+	GenesisTime string `json:"genesis_time"`
+}
+
+// Validator returns the current state of the validator with the given index or public key.
+func (c *Client) Validator(ctx context.Context, id string) (*Validator, error) {
+	var res validatorResponse
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/states/head/validators/%s", id), &res); err != nil {
+		return nil, err
+	}
+
+	var err error
+	validator := &Validator{
+		Status:  res.Status,
+		Slashed: res.Validator.Slashed,
+	}
+	if validator.PublicKey, err = hexutil.Decode(res.Validator.PublicKey); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if validator.WithdrawalCredentials, err = hexutil.Decode(res.Validator.WithdrawalCredentials); err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal credentials")
+	}
+	for _, field := range []struct {
+		name  string
+		input string
+		val   *uint64
+	}{
+		{"index", res.Index, &validator.Index},
+		{"balance", res.Balance, &validator.Balance},
+		{"effective balance", res.Validator.EffectiveBalance, &validator.EffectiveBalance},
+		{"activation eligibility epoch", res.Validator.ActivationEligibilityEpoch, &validator.ActivationEligibilityEpoch},
+		{"activation epoch", res.Validator.ActivationEpoch, &validator.ActivationEpoch},
+		{"exit epoch", res.Validator.ExitEpoch, &validator.ExitEpoch},
+		{"withdrawable epoch", res.Validator.WithdrawableEpoch, &validator.WithdrawableEpoch},
+	} {
+		if *field.val, err = strconv.ParseUint(field.input, 10, 64); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", field.name))
+		}
+	}
+	return validator, nil
+}
+
+// Spec returns the chain specification.
+func (c *Client) Spec(ctx context.Context) (*Spec, error) {
+	var res map[string]interface{}
+	if err := c.get(ctx, "/eth/v1/config/spec", &res); err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	for _, field := range []struct {
+		name string
+		val  *uint64
+	}{
+		{"SECONDS_PER_SLOT", &spec.SecondsPerSlot},
+		{"SLOTS_PER_EPOCH", &spec.SlotsPerEpoch},
+		{"SHARD_COMMITTEE_PERIOD", &spec.ShardCommitteePeriod},
+	} {
+		input, ok := res[field.name].(string)
+		if !ok {
+			return nil, fmt.Errorf("specification does not contain %s", field.name)
+		}
+		var err error
+		if *field.val, err = strconv.ParseUint(input, 10, 64); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", field.name))
+		}
+	}
+	if spec.SlotsPerEpoch == 0 {
+		return nil, errors.New("specification has zero slots per epoch")
+	}
+	return spec, nil
+}
+
+// HeadSlot returns the slot of the head of the chain.
+func (c *Client) HeadSlot(ctx context.Context) (uint64, error) {
+	var res headerResponse
+	if err := c.get(ctx, "/eth/v1/beacon/headers/head", &res); err != nil {
+		return 0, err
+	}
+	slot, err := strconv.ParseUint(res.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid slot")
+	}
+	return slot, nil
+}
+
+// Genesis returns the genesis time of the chain.
+func (c *Client) Genesis(ctx context.Context) (time.Time, error) {
+	var res genesisResponse
+	if err := c.get(ctx, "/eth/v1/beacon/genesis", &res); err != nil {
+		return time.Time{}, err
+	}
+	genesisTime, err := strconv.ParseInt(res.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid genesis time")
+	}
+	return time.Unix(genesisTime, 0), nil
+}
+
+// EpochTime returns the start time of the given epoch.
+func (s *Spec) EpochTime(genesis time.Time, epoch uint64) time.Time {
+	return genesis.Add(time.Duration(epoch*s.SlotsPerEpoch*s.SecondsPerSlot) * time.Second)
+}
+
+// ExitableEpoch returns the first epoch at which the validator can submit a voluntary exit,
+// or FarFutureEpoch if it has not been activated.
+func (v *Validator) ExitableEpoch(spec *Spec) uint64 {
+	if v.ActivationEpoch == FarFutureEpoch {
+		return FarFutureEpoch
+	}
+	return v.ActivationEpoch + spec.ShardCommitteePeriod
+}
+
+// get calls the API at the given path, decoding the data of the result.
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(data, &res); err == nil && res.Message != "" {
+			return fmt.Errorf("request returned status %d: %s", resp.StatusCode, res.Message)
+		}
+		return fmt.Errorf("request returned status %d", resp.StatusCode)
+	}
+
+	var res struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return errors.Wrap(err, "invalid response")
+	}
+	if err := json.Unmarshal(res.Data, result); err != nil {
+		return errors.Wrap(err, "invalid result")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/head/validators/1234":
+			fmt.Fprint(w, `{"data":{"index":"1234","balance":"32012345678","status":"active_ongoing","validator":{"pubkey":"0xaad67d87ddeb2801860c135a67dc3fecdf77ed9a41da6afe7c8a5232354713bdc6d437cbe0014f3482f2a17e048e30a4","withdrawal_credentials":"0x0100000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4","effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"10","activation_epoch":"100","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}}}`)
+		case "/eth/v1/config/spec":
+			fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32","SHARD_COMMITTEE_PERIOD":"256","CONFIG_NAME":"mainnet"}}`)
+		case "/eth/v1/beacon/headers/head":
+			fmt.Fprint(w, `{"data":{"root":"0x01","canonical":true,"header":{"message":{"slot":"6400","proposer_index":"1"}}}}`)
+		case "/eth/v1/beacon/genesis":
+			fmt.Fprint(w, `{"data":{"genesis_time":"1606824023","genesis_fork_version":"0x00000000"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"Validator not found"}`)
+		}
+	}))
+}
+
+func TestValidator(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	client := New(server.URL + "/")
+
+	validator, err := client.Validator(context.Background(), "1234")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234), validator.Index)
+	assert.Equal(t, "active_ongoing", validator.Status)
+	assert.Equal(t, uint64(32012345678), validator.Balance)
+	assert.Equal(t, uint64(32000000000), validator.EffectiveBalance)
+	assert.Equal(t, common.FromHex("0x0100000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4"), validator.WithdrawalCredentials)
+	assert.Equal(t, uint64(100), validator.ActivationEpoch)
+	assert.Equal(t, FarFutureEpoch, validator.ExitEpoch)
+
+	_, err = client.Validator(context.Background(), "5678")
+	require.EqualError(t, err, "request returned status 404: Validator not found")
+}
+
+func TestSpec(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	client := New(server.URL)
+
+	spec, err := client.Spec(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), spec.SecondsPerSlot)
+	assert.Equal(t, uint64(32), spec.SlotsPerEpoch)
+	assert.Equal(t, uint64(256), spec.ShardCommitteePeriod)
+
+	slot, err := client.HeadSlot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6400), slot)
+
+	genesis, err := client.Genesis(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1606824023), genesis.Unix())
+	assert.Equal(t, genesis.Add(384*time.Second), spec.EpochTime(genesis, 1))
+}
+
+func TestExitableEpoch(t *testing.T) {
+	spec := &Spec{SecondsPerSlot: 12, SlotsPerEpoch: 32, ShardCommitteePeriod: 256}
+	assert.Equal(t, uint64(356), (&Validator{ActivationEpoch: 100}).ExitableEpoch(spec))
+	assert.Equal(t, FarFutureEpoch, (&Validator{ActivationEpoch: FarFutureEpoch}).ExitableEpoch(spec))
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+// blobBytesPerFieldElement is the number of bytes of data stored in each field element of a
+// blob.  The first byte of each field element is left as 0 to ensure that it is a valid element.
+const blobBytesPerFieldElement = 31
+
+// BlobCapacity is the number of bytes of data that can be stored in a single blob.
+const BlobCapacity = params.BlobTxFieldElementsPerBlob * blobBytesPerFieldElement
+
+// MaxBlobs is the maximum number of blobs that can be sent in a single transaction.
+const MaxBlobs = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
+// EncodeBlobs encodes data in to as many blobs as are required to hold it.
+func EncodeBlobs(data []byte) ([]kzg4844.Blob, error) {
+	if len(data) == 0 {
+		return nil, errors.New("no blob data")
+	}
+	numBlobs := (len(data) + BlobCapacity - 1) / BlobCapacity
+	if numBlobs > MaxBlobs {
+		return nil, fmt.Errorf("blob data is %d bytes; maximum is %d", len(data), MaxBlobs*BlobCapacity)
+	}
+
+	blobs := make([]kzg4844.Blob, numBlobs)
+	for i := range blobs {
+		chunk := data[i*BlobCapacity:]
+		if len(chunk) > BlobCapacity {
+			chunk = chunk[:BlobCapacity]
+		}
+		for j := 0; j*blobBytesPerFieldElement < len(chunk); j++ {
+			end := (j + 1) * blobBytesPerFieldElement
+			if end > len(chunk) {
+				end = len(chunk)
+			}
+			copy(blobs[i][j*32+1:], chunk[j*blobBytesPerFieldElement:end])
+		}
+	}
+	return blobs, nil
+}
+
+// BlobSidecar encodes data in to blobs and generates the KZG commitments and proofs for them,
+// returning the sidecar for a blob transaction.
+func BlobSidecar(data []byte) (*types.BlobTxSidecar, error) {
+	blobs, err := EncodeBlobs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, len(blobs)),
+	}
+	for i := range blobs {
+		sidecar.Commitments[i], err = kzg4844.BlobToCommitment(&blobs[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate blob commitment")
+		}
+		sidecar.Proofs[i], err = kzg4844.ComputeBlobProof(&blobs[i], sidecar.Commitments[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate blob proof")
+		}
+	}
+	return sidecar, nil
+}
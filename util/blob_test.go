@@ -0,0 +1,97 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBlobs(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		blobs int
+		err   string
+	}{
+		{
+			name: "Empty",
+			err:  "no blob data",
+		},
+		{
+			name:  "Short",
+			data:  []byte("hello"),
+			blobs: 1,
+		},
+		{
+			name:  "Full",
+			data:  bytes.Repeat([]byte{0xff}, BlobCapacity),
+			blobs: 1,
+		},
+		{
+			name:  "Multiple",
+			data:  bytes.Repeat([]byte{0xff}, BlobCapacity+1),
+			blobs: 2,
+		},
+		{
+			name: "TooLong",
+			data: bytes.Repeat([]byte{0xff}, MaxBlobs*BlobCapacity+1),
+			err:  "blob data is 761857 bytes; maximum is 761856",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blobs, err := EncodeBlobs(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, blobs, test.blobs)
+
+			// Each field element holds 31 bytes of data after a leading 0 byte.
+			res := make([]byte, 0, len(blobs)*BlobCapacity)
+			for i := range blobs {
+				for j := 0; j < len(blobs[i]); j += 32 {
+					assert.Equal(t, byte(0), blobs[i][j])
+					res = append(res, blobs[i][j+1:j+32]...)
+				}
+			}
+			assert.Equal(t, test.data, res[:len(test.data)])
+			assert.Equal(t, make([]byte, len(res)-len(test.data)), res[len(test.data):])
+		})
+	}
+}
+
+func TestBlobSidecar(t *testing.T) {
+	sidecar, err := BlobSidecar(bytes.Repeat([]byte{0xff}, BlobCapacity+1))
+	require.NoError(t, err)
+	require.Len(t, sidecar.Blobs, 2)
+	require.Len(t, sidecar.Commitments, 2)
+	require.Len(t, sidecar.Proofs, 2)
+	for i := range sidecar.Blobs {
+		require.NoError(t, kzg4844.VerifyBlobProof(&sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]))
+	}
+
+	hashes := sidecar.BlobHashes()
+	require.Len(t, hashes, 2)
+	for _, hash := range hashes {
+		assert.True(t, kzg4844.IsValidVersionedHash(hash[:]))
+	}
+}
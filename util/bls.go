@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/pkg/errors"
+)
+
+// BLSSignatureDST is the domain separation tag for BLS signatures on the beacon chain.
+const BLSSignatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// BLSVerify verifies a BLS signature, with compressed public key and signature, over a message.
+func BLSVerify(pubKey []byte, msg []byte, sig []byte) error {
+	pk, err := blsPublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	s, err := blsSignature(sig)
+	if err != nil {
+		return err
+	}
+	hash, err := bls12381.HashToG2(msg, []byte(BLSSignatureDST))
+	if err != nil {
+		return err
+	}
+
+	// e(pk, H(m)) == e(g1, sig)
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	valid, err := bls12381.PairingCheck([]bls12381.G1Affine{*pk, negG1}, []bls12381.G2Affine{hash, *s})
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// blsPublicKey decompresses a public key.
+func blsPublicKey(in []byte) (*bls12381.G1Affine, error) {
+	if len(in) != bls12381.SizeOfG1AffineCompressed {
+		return nil, fmt.Errorf("public key is %d bytes; expected %d", len(in), bls12381.SizeOfG1AffineCompressed)
+	}
+	if in[0]&0x80 == 0 {
+		return nil, errors.New("public key is not compressed")
+	}
+	if in[0]&0x40 != 0 {
+		return nil, errors.New("public key is the point at infinity")
+	}
+	// Decompression also checks that the point is in the correct subgroup.
+	var point bls12381.G1Affine
+	if _, err := point.SetBytes(in); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	return &point, nil
+}
+
+// blsSignature decompresses a signature.
+func blsSignature(in []byte) (*bls12381.G2Affine, error) {
+	if len(in) != bls12381.SizeOfG2AffineCompressed {
+		return nil, fmt.Errorf("signature is %d bytes; expected %d", len(in), bls12381.SizeOfG2AffineCompressed)
+	}
+	if in[0]&0x80 == 0 {
+		return nil, errors.New("signature is not compressed")
+	}
+	if in[0]&0x40 != 0 {
+		return nil, errors.New("signature is the point at infinity")
+	}
+	// Decompression also checks that the point is in the correct subgroup.
+	var point bls12381.G2Affine
+	if _, err := point.SetBytes(in); err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	return &point, nil
+}
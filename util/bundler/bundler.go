@@ -0,0 +1,185 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundler submits and tracks ERC-4337 user operations with a bundler.
+package bundler
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// waitPollInterval is the interval between checks when waiting for a user operation.
+var waitPollInterval = 5 * time.Second
+
+// ErrNotIncluded is returned when a user operation is not included within the time limit.
+var ErrNotIncluded = errors.New("user operation not included within time limit")
+
+// Client is a client for a bundler.
+type Client struct {
+	rpc        *rpc.Client
+	entryPoint common.Address
+}
+
+// New creates a new client for the bundler at the given URL, submitting operations to the given EntryPoint.
+func New(ctx context.Context, url string, entryPoint common.Address) (*Client, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to bundler")
+	}
+	return &Client{
+		rpc:        client,
+		entryPoint: entryPoint,
+	}, nil
+}
+
+// GasEstimate is the gas required by a user operation.
+type GasEstimate struct {
+	PreVerificationGas   *big.Int
+	VerificationGasLimit *big.Int
+	CallGasLimit         *big.Int
+}
+
+// Receipt is the result of a user operation that has been included in a block.
+type Receipt struct {
+	Success         bool
+	Reason          string
+	ActualGasCost   *big.Int
+	ActualGasUsed   *big.Int
+	TransactionHash common.Hash
+	BlockNumber     uint64
+}
+
+type gasEstimateJSON struct {
+	PreVerificationGas   *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit         *hexutil.Big `json:"callGasLimit"`
+}
+
+type receiptJSON struct {
+	Success       bool         `json:"success"`
+	Reason        string       `json:"reason"`
+	ActualGasCost *hexutil.Big `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big `json:"actualGasUsed"`
+	Receipt       struct {
+		TransactionHash common.Hash    `json:"transactionHash"`
+		BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+	} `json:"receipt"`
+}
+
+// SupportedEntryPoints returns the EntryPoint contracts supported by the bundler.
+func (c *Client) SupportedEntryPoints(ctx context.Context) ([]common.Address, error) {
+	var res []common.Address
+	if err := c.rpc.CallContext(ctx, &res, "eth_supportedEntryPoints"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// EstimateUserOperationGas estimates the gas required by a user operation.  The operation
+// must have a signature of the correct form, although it need not be valid.
+func (c *Client) EstimateUserOperationGas(ctx context.Context, op *util.UserOperation) (*GasEstimate, error) {
+	var res gasEstimateJSON
+	if err := c.rpc.CallContext(ctx, &res, "eth_estimateUserOperationGas", op.RPC(c.entryPoint), c.entryPoint); err != nil {
+		return nil, err
+	}
+	if res.PreVerificationGas == nil || res.VerificationGasLimit == nil || res.CallGasLimit == nil {
+		return nil, errors.New("incomplete gas estimate")
+	}
+	return &GasEstimate{
+		PreVerificationGas:   res.PreVerificationGas.ToInt(),
+		VerificationGasLimit: res.VerificationGasLimit.ToInt(),
+		CallGasLimit:         res.CallGasLimit.ToInt(),
+	}, nil
+}
+
+// SendUserOperation sends a signed user operation, returning its hash.
+func (c *Client) SendUserOperation(ctx context.Context, op *util.UserOperation) (common.Hash, error) {
+	var res common.Hash
+	if err := c.rpc.CallContext(ctx, &res, "eth_sendUserOperation", op.RPC(c.entryPoint), c.entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+	return res, nil
+}
+
+// UserOperationReceipt returns the receipt of a user operation, or nil if it has not been included in a block.
+func (c *Client) UserOperationReceipt(ctx context.Context, hash common.Hash) (*Receipt, error) {
+	var res *receiptJSON
+	if err := c.rpc.CallContext(ctx, &res, "eth_getUserOperationReceipt", hash); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	receipt := &Receipt{
+		Success:         res.Success,
+		Reason:          res.Reason,
+		ActualGasCost:   big.NewInt(0),
+		ActualGasUsed:   big.NewInt(0),
+		TransactionHash: res.Receipt.TransactionHash,
+		BlockNumber:     uint64(res.Receipt.BlockNumber),
+	}
+	if res.ActualGasCost != nil {
+		receipt.ActualGasCost = res.ActualGasCost.ToInt()
+	}
+	if res.ActualGasUsed != nil {
+		receipt.ActualGasUsed = res.ActualGasUsed.ToInt()
+	}
+	return receipt, nil
+}
+
+// UserOperationKnown returns true if the bundler knows of the user operation.
+func (c *Client) UserOperationKnown(ctx context.Context, hash common.Hash) (bool, error) {
+	var res map[string]interface{}
+	if err := c.rpc.CallContext(ctx, &res, "eth_getUserOperationByHash", hash); err != nil {
+		return false, err
+	}
+	return res != nil, nil
+}
+
+// WaitForUserOperation waits for the user operation with the given hash to be included in a
+// block.  If limit is 0 it will wait forever, otherwise it returns ErrNotIncluded if the
+// operation is not included within the limit.
+func (c *Client) WaitForUserOperation(ctx context.Context, hash common.Hash, limit time.Duration) (*Receipt, error) {
+	if limit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limit)
+		defer cancel()
+	}
+
+	for {
+		receipt, err := c.UserOperationReceipt(ctx, hash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ErrNotIncluded
+			}
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ErrNotIncluded
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var (
+	testSender = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	testHash   = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	testTxHash = common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+)
+
+// testBundler is a mock bundler.
+type testBundler struct {
+	received map[string]interface{}
+}
+
+func (b *testBundler) SupportedEntryPoints() []common.Address {
+	return []common.Address{util.EntryPointV07}
+}
+
+func (b *testBundler) EstimateUserOperationGas(op map[string]interface{}, entryPoint common.Address) map[string]string {
+	b.received = op
+	return map[string]string{
+		"preVerificationGas":   "0xc350",
+		"verificationGasLimit": "0x61a80",
+		"callGasLimit":         "0x88b8",
+	}
+}
+
+func (b *testBundler) SendUserOperation(op map[string]interface{}, entryPoint common.Address) common.Hash {
+	b.received = op
+	return testHash
+}
+
+func (b *testBundler) GetUserOperationReceipt(hash common.Hash) map[string]interface{} {
+	if hash != testHash {
+		return nil
+	}
+	return map[string]interface{}{
+		"userOpHash":    hash,
+		"success":       false,
+		"reason":        "0x08c379a0",
+		"actualGasCost": "0x3e8",
+		"actualGasUsed": "0x64",
+		"receipt": map[string]interface{}{
+			"transactionHash": testTxHash,
+			"blockNumber":     "0x10",
+		},
+	}
+}
+
+func (b *testBundler) GetUserOperationByHash(hash common.Hash) map[string]interface{} {
+	if hash != testHash {
+		return nil
+	}
+	return map[string]interface{}{"entryPoint": util.EntryPointV07}
+}
+
+func newTestClient(t *testing.T) (*Client, *testBundler) {
+	bundler := &testBundler{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", bundler))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := New(context.Background(), httpServer.URL, util.EntryPointV07)
+	require.NoError(t, err)
+	return client, bundler
+}
+
+func TestBundler(t *testing.T) {
+	client, bundler := newTestClient(t)
+	ctx := context.Background()
+
+	entryPoints, err := client.SupportedEntryPoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{util.EntryPointV07}, entryPoints)
+
+	op := &util.UserOperation{
+		Sender:               testSender,
+		Nonce:                big.NewInt(1),
+		CallData:             []byte{0x01},
+		CallGasLimit:         big.NewInt(0),
+		VerificationGasLimit: big.NewInt(0),
+		PreVerificationGas:   big.NewInt(0),
+		MaxFeePerGas:         big.NewInt(2),
+		MaxPriorityFeePerGas: big.NewInt(1),
+		Signature:            []byte{0x02},
+	}
+	estimate, err := client.EstimateUserOperationGas(ctx, op)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(50000), estimate.PreVerificationGas)
+	assert.Equal(t, big.NewInt(400000), estimate.VerificationGasLimit)
+	assert.Equal(t, big.NewInt(35000), estimate.CallGasLimit)
+	assert.Equal(t, "0x1", bundler.received["nonce"])
+	assert.Equal(t, hexutil.Encode([]byte{0x01}), bundler.received["callData"])
+
+	hash, err := client.SendUserOperation(ctx, op)
+	require.NoError(t, err)
+	assert.Equal(t, testHash, hash)
+
+	receipt, err := client.UserOperationReceipt(ctx, testHash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Success)
+	assert.Equal(t, big.NewInt(1000), receipt.ActualGasCost)
+	assert.Equal(t, testTxHash, receipt.TransactionHash)
+	assert.Equal(t, uint64(16), receipt.BlockNumber)
+
+	receipt, err = client.UserOperationReceipt(ctx, common.Hash{})
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	known, err := client.UserOperationKnown(ctx, testHash)
+	require.NoError(t, err)
+	assert.True(t, known)
+	known, err = client.UserOperationKnown(ctx, common.Hash{})
+	require.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestWaitForUserOperation(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+	waitPollInterval = 10 * time.Millisecond
+
+	receipt, err := client.WaitForUserOperation(ctx, testHash, 0)
+	require.NoError(t, err)
+	assert.Equal(t, testTxHash, receipt.TransactionHash)
+
+	_, err = client.WaitForUserOperation(ctx, common.Hash{}, 50*time.Millisecond)
+	assert.Equal(t, ErrNotIncluded, err)
+}
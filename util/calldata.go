@@ -0,0 +1,85 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// erc20Functions are the standard functions of an ERC-20 token contract.
+const erc20Functions = `[
+{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"allowance","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// erc721Functions are the standard functions of an ERC-721 token contract that are not
+// shared with ERC-20.
+const erc721Functions = `[
+{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]},
+{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"setApprovalForAll","inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"outputs":[]},
+{"type":"function","name":"getApproved","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+{"type":"function","name":"isApprovedForAll","inputs":[{"name":"owner","type":"address"},{"name":"operator","type":"address"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"ownerOf","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]}
+]`
+
+var (
+	standardABIs     []*abi.ABI
+	standardABIsOnce sync.Once
+)
+
+// StandardABIs returns ABIs containing the functions of the ERC-20 and ERC-721 token standards.
+// Functions that share a selector across the standards, such as transferFrom(), are decoded
+// with ERC-20 argument names.
+// The ABIs are parsed once and cached, so must not be modified by callers.
+func StandardABIs() []*abi.ABI {
+	standardABIsOnce.Do(func() {
+		standardABIs = make([]*abi.ABI, 0, 2)
+		for _, input := range []string{erc20Functions, erc721Functions} {
+			parsed, err := abi.JSON(strings.NewReader(input))
+			if err != nil {
+				// Should not happen, as the ABIs are static.
+				panic(err)
+			}
+			standardABIs = append(standardABIs, &parsed)
+		}
+	})
+	return standardABIs
+}
+
+// DecodeCallData decodes call data against the methods in the supplied ABI.
+// It returns the matching method and the method's argument values.
+func DecodeCallData(contractAbi *abi.ABI, data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("data too short")
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unpack arguments for method %s: %v", method.Name, err)
+	}
+	return method, values, nil
+}
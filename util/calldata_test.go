@@ -0,0 +1,84 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCallData(t *testing.T) {
+	abis := StandardABIs()
+	require.Len(t, abis, 2)
+
+	tests := []struct {
+		name   string
+		abi    *abi.ABI
+		data   []byte
+		method string
+		values []interface{}
+		err    string
+	}{
+		{
+			name: "Short",
+			abi:  abis[0],
+			data: MustDecodeHexString("0xa905"),
+			err:  "data too short",
+		},
+		{
+			name: "Unknown",
+			abi:  abis[0],
+			data: MustDecodeHexString("0x01020304"),
+			err:  "no method with id: 0x01020304",
+		},
+		{
+			name:   "ERC20Transfer",
+			abi:    abis[0],
+			data:   MustDecodeHexString("0xa9059cbb0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc400000000000000000000000000000000000000000000000000000000000003e8"),
+			method: "transfer",
+			values: []interface{}{common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), big.NewInt(1000)},
+		},
+		{
+			name: "ERC20TransferTruncated",
+			abi:  abis[0],
+			data: MustDecodeHexString("0xa9059cbb0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4"),
+			err:  "failed to unpack arguments for method transfer: abi: cannot marshal in to go type: length insufficient 32 require 64",
+		},
+		{
+			name:   "ERC721SetApprovalForAll",
+			abi:    abis[1],
+			data:   MustDecodeHexString("0xa22cb4650000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc40000000000000000000000000000000000000000000000000000000000000001"),
+			method: "setApprovalForAll",
+			values: []interface{}{common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method, values, err := DecodeCallData(test.abi, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.method, method.Name)
+				assert.Equal(t, test.values, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chains provides information about public chains, such as their names, native
+// currencies and block explorers.
+package chains
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Chain contains information about a chain.
+type Chain struct {
+	// ID is the chain ID.
+	ID uint64 `json:"chain_id"`
+	// Name is the human-readable name of the chain.
+	Name string `json:"name"`
+	// ShortName is the name of the chain as used with --network.
+	ShortName string `json:"short_name"`
+	// Currency is the symbol of the native currency.
+	Currency string `json:"currency"`
+	// Explorer is the URL of the block explorer, without trailing slash.
+	Explorer string `json:"explorer,omitempty"`
+	// Testnet is true if the chain is a test network.
+	Testnet bool `json:"testnet"`
+	// Deprecated is true if the chain is no longer running.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+var chains = []*Chain{
+	{ID: 1, Name: "Ethereum", ShortName: "mainnet", Currency: "ETH", Explorer: "https://etherscan.io"},
+	{ID: 3, Name: "Ropsten", ShortName: "ropsten", Currency: "ETH", Testnet: true, Deprecated: true},
+	{ID: 4, Name: "Rinkeby", ShortName: "rinkeby", Currency: "ETH", Testnet: true, Deprecated: true},
+	{ID: 5, Name: "Goerli", ShortName: "goerli", Currency: "ETH", Testnet: true, Deprecated: true},
+	{ID: 10, Name: "OP Mainnet", ShortName: "optimism", Currency: "ETH", Explorer: "https://optimistic.etherscan.io"},
+	{ID: 42, Name: "Kovan", ShortName: "kovan", Currency: "ETH", Testnet: true, Deprecated: true},
+	{ID: 56, Name: "BNB Smart Chain", ShortName: "bsc", Currency: "BNB", Explorer: "https://bscscan.com"},
+	{ID: 100, Name: "Gnosis", ShortName: "gnosis", Currency: "xDAI", Explorer: "https://gnosisscan.io"},
+	{ID: 137, Name: "Polygon", ShortName: "polygon", Currency: "POL", Explorer: "https://polygonscan.com"},
+	{ID: 250, Name: "Fantom", ShortName: "fantom", Currency: "FTM", Explorer: "https://ftmscan.com"},
+	{ID: 324, Name: "zkSync Era", ShortName: "zksync", Currency: "ETH", Explorer: "https://explorer.zksync.io"},
+	{ID: 1101, Name: "Polygon zkEVM", ShortName: "polygon-zkevm", Currency: "ETH", Explorer: "https://zkevm.polygonscan.com"},
+	{ID: 5000, Name: "Mantle", ShortName: "mantle", Currency: "MNT", Explorer: "https://mantlescan.xyz"},
+	{ID: 8453, Name: "Base", ShortName: "base", Currency: "ETH", Explorer: "https://basescan.org"},
+	{ID: 17000, Name: "Holesky", ShortName: "holesky", Currency: "ETH", Explorer: "https://holesky.etherscan.io", Testnet: true},
+	{ID: 42161, Name: "Arbitrum One", ShortName: "arbitrum", Currency: "ETH", Explorer: "https://arbiscan.io"},
+	{ID: 42170, Name: "Arbitrum Nova", ShortName: "arbitrum-nova", Currency: "ETH", Explorer: "https://nova.arbiscan.io"},
+	{ID: 42220, Name: "Celo", ShortName: "celo", Currency: "CELO", Explorer: "https://celoscan.io"},
+	{ID: 43114, Name: "Avalanche C-Chain", ShortName: "avalanche", Currency: "AVAX", Explorer: "https://snowtrace.io"},
+	{ID: 59144, Name: "Linea", ShortName: "linea", Currency: "ETH", Explorer: "https://lineascan.build"},
+	{ID: 80002, Name: "Polygon Amoy", ShortName: "amoy", Currency: "POL", Explorer: "https://amoy.polygonscan.com", Testnet: true},
+	{ID: 81457, Name: "Blast", ShortName: "blast", Currency: "ETH", Explorer: "https://blastscan.io"},
+	{ID: 84532, Name: "Base Sepolia", ShortName: "base-sepolia", Currency: "ETH", Explorer: "https://sepolia.basescan.org", Testnet: true},
+	{ID: 421614, Name: "Arbitrum Sepolia", ShortName: "arbitrum-sepolia", Currency: "ETH", Explorer: "https://sepolia.arbiscan.io", Testnet: true},
+	{ID: 534352, Name: "Scroll", ShortName: "scroll", Currency: "ETH", Explorer: "https://scrollscan.com"},
+	{ID: 560048, Name: "Hoodi", ShortName: "hoodi", Currency: "ETH", Explorer: "https://hoodi.etherscan.io", Testnet: true},
+	{ID: 11155111, Name: "Sepolia", ShortName: "sepolia", Currency: "ETH", Explorer: "https://sepolia.etherscan.io", Testnet: true},
+	{ID: 11155420, Name: "OP Sepolia", ShortName: "optimism-sepolia", Currency: "ETH", Explorer: "https://sepolia-optimism.etherscan.io", Testnet: true},
+}
+
+// All returns all known chains, ordered by chain ID.
+func All() []*Chain {
+	res := make([]*Chain, len(chains))
+	copy(res, chains)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+// ByID returns the chain with the given ID, or nil if it is not known.
+func ByID(id *big.Int) *Chain {
+	if id == nil || !id.IsUint64() {
+		return nil
+	}
+	for _, chain := range chains {
+		if chain.ID == id.Uint64() {
+			return chain
+		}
+	}
+	return nil
+}
+
+// ByName returns the chain with the given short name, or nil if it is not known.
+// The match is case-insensitive.
+func ByName(name string) *Chain {
+	name = strings.ToLower(name)
+	for _, chain := range chains {
+		if chain.ShortName == name {
+			return chain
+		}
+	}
+	return nil
+}
+
+// TransactionURL returns the URL of the transaction in the chain's block explorer,
+// or an empty string if the chain has no explorer.
+func (c *Chain) TransactionURL(hash string) string {
+	if c.Explorer == "" {
+		return ""
+	}
+	return c.Explorer + "/tx/" + hash
+}
+
+// AddressURL returns the URL of the address in the chain's block explorer,
+// or an empty string if the chain has no explorer.
+func (c *Chain) AddressURL(address string) string {
+	if c.Explorer == "" {
+		return ""
+	}
+	return c.Explorer + "/address/" + address
+}
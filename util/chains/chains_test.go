@@ -0,0 +1,94 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chains_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/chains"
+)
+
+func TestByID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       *big.Int
+		expected string
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name:     "Mainnet",
+			id:       big.NewInt(1),
+			expected: "Ethereum",
+		},
+		{
+			name:     "Sepolia",
+			id:       big.NewInt(11155111),
+			expected: "Sepolia",
+		},
+		{
+			name: "Unknown",
+			id:   big.NewInt(123456789),
+		},
+		{
+			name: "TooLarge",
+			id:   new(big.Int).Lsh(big.NewInt(1), 64),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := chains.ByID(test.id)
+			if test.expected == "" {
+				require.Nil(t, chain)
+			} else {
+				require.NotNil(t, chain)
+				require.Equal(t, test.expected, chain.Name)
+			}
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	require.Equal(t, uint64(8453), chains.ByName("base").ID)
+	require.Equal(t, uint64(8453), chains.ByName("Base").ID)
+	require.Nil(t, chains.ByName("unknown"))
+}
+
+func TestAll(t *testing.T) {
+	all := chains.All()
+	require.NotEmpty(t, all)
+	ids := make(map[uint64]bool)
+	names := make(map[string]bool)
+	for i, chain := range all {
+		if i > 0 {
+			require.Less(t, all[i-1].ID, chain.ID)
+		}
+		require.False(t, ids[chain.ID], "duplicate chain ID %d", chain.ID)
+		ids[chain.ID] = true
+		require.False(t, names[chain.ShortName], "duplicate short name %s", chain.ShortName)
+		names[chain.ShortName] = true
+		require.NotEmpty(t, chain.Currency)
+	}
+}
+
+func TestURLs(t *testing.T) {
+	chain := chains.ByName("sepolia")
+	require.Equal(t, "https://sepolia.etherscan.io/tx/0x1234", chain.TransactionURL("0x1234"))
+	require.Equal(t, "https://sepolia.etherscan.io/address/0x5678", chain.AddressURL("0x5678"))
+	require.Equal(t, "", chains.ByName("ropsten").TransactionURL("0x1234"))
+}
@@ -0,0 +1,134 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// CodeComparison is the result of comparing deployed code with compiled code.
+type CodeComparison struct {
+	// Match is set if the code matches, ignoring metadata, immutable values and library addresses.
+	Match bool
+	// MetadataMatch is set if the compiler metadata of the code also matches.
+	MetadataMatch bool
+	// Immutables are the offsets of immutable values in the code.
+	Immutables []uint64
+	// Libraries are the offsets of library addresses in the code.
+	Libraries []uint64
+	// Mismatch is the offset of the first difference if the code does not match.
+	Mismatch uint64
+	// Reason describes why the code does not match.
+	Reason string
+}
+
+// CompareCode compares deployed runtime code with hex-encoded runtime code as output by the
+// compiler.  The compiled code can contain library placeholders, which match any address.  The
+// compiler leaves space for immutable values as zeros, so zero-valued 32-byte pushes in the
+// compiled code match any value, as does the address pushed at the start of a library.
+func CompareCode(deployed []byte, compiled string) (*CodeComparison, error) {
+	compiled = strings.TrimPrefix(compiled, "0x")
+	placeholders := placeholderRe.FindAllStringIndex(compiled, -1)
+	// Placeholders are replaced with zeros so that the code can be decoded.
+	for _, placeholder := range placeholders {
+		compiled = compiled[:placeholder[0]] + strings.Repeat("0", 40) + compiled[placeholder[1]:]
+	}
+	local, err := hex.DecodeString(compiled)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compiled code: %v", err)
+	}
+
+	res := &CodeComparison{
+		Immutables: make([]uint64, 0),
+		Libraries:  make([]uint64, 0),
+	}
+	deployedMetadata := ParseCodeMetadata(deployed)
+	localMetadata := ParseCodeMetadata(local)
+	if (deployedMetadata == nil) != (localMetadata == nil) {
+		res.Reason = "only one has compiler metadata"
+		return res, nil
+	}
+	if deployedMetadata != nil {
+		res.MetadataMatch = bytes.Equal(deployed[len(deployed)-deployedMetadata.Length:], local[len(local)-localMetadata.Length:])
+		deployed = deployed[:len(deployed)-deployedMetadata.Length]
+		local = local[:len(local)-localMetadata.Length]
+	} else {
+		res.MetadataMatch = true
+	}
+	if len(deployed) != len(local) {
+		res.Mismatch = uint64(minInt(len(deployed), len(local)))
+		res.Reason = fmt.Sprintf("code is %d bytes but compiled code is %d bytes", len(deployed), len(local))
+		return res, nil
+	}
+
+	// Mark the bytes that can differ.
+	wildcards := make([]bool, len(local))
+	for _, placeholder := range placeholders {
+		offset := placeholder[0] / 2
+		for i := offset; i < offset+20 && i < len(wildcards); i++ {
+			wildcards[i] = true
+		}
+		res.Libraries = append(res.Libraries, uint64(offset))
+	}
+	for _, instruction := range Disassemble(local) {
+		immutable := instruction.Op == vm.PUSH32
+		// Libraries push their own address at the start of their code to guard against calls.
+		library := instruction.Op == vm.PUSH20 && instruction.PC == 0
+		if (!immutable && !library) || instruction.Truncated || !isZero(instruction.Arg) {
+			continue
+		}
+		offset := int(instruction.PC) + 1
+		if bytes.Equal(deployed[offset:offset+len(instruction.Arg)], instruction.Arg) {
+			continue
+		}
+		for i := offset; i < offset+len(instruction.Arg); i++ {
+			wildcards[i] = true
+		}
+		if immutable {
+			res.Immutables = append(res.Immutables, uint64(offset))
+		}
+	}
+
+	for i := range local {
+		if deployed[i] != local[i] && !wildcards[i] {
+			res.Mismatch = uint64(i)
+			res.Reason = fmt.Sprintf("code differs at offset %d", i)
+			return res, nil
+		}
+	}
+	res.Match = true
+	return res, nil
+}
+
+// isZero returns true if all of the bytes are zero.
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
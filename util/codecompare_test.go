@@ -0,0 +1,140 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareCode(t *testing.T) {
+	body := hex.EncodeToString(testDispatcher)
+	metadata := hex.EncodeToString(testCodeMetadata)
+	// PUSH32 immutable, POP, PUSH20 library address, POP.
+	immutable := "7f" + strings.Repeat("00", 32) + "50"
+	library := "73" + strings.Repeat("00", 20) + "50"
+	placeholder := "73" + "__$" + strings.Repeat("a", 34) + "$__" + "50"
+
+	tests := []struct {
+		name          string
+		deployed      string
+		compiled      string
+		match         bool
+		metadataMatch bool
+		immutables    []uint64
+		libraries     []uint64
+		reason        string
+	}{
+		{
+			name:          "Identical",
+			deployed:      body + metadata,
+			compiled:      body + metadata,
+			match:         true,
+			metadataMatch: true,
+		},
+		{
+			name:     "MetadataDiffers",
+			deployed: body + metadata,
+			compiled: body + strings.Replace(metadata, "2d71", "2d72", 1),
+			match:    true,
+		},
+		{
+			name:          "Immutable",
+			deployed:      "7f" + strings.Repeat("11", 32) + "50" + body + metadata,
+			compiled:      immutable + body + metadata,
+			match:         true,
+			metadataMatch: true,
+			immutables:    []uint64{1},
+		},
+		{
+			name:          "Library",
+			deployed:      body + "73" + strings.Repeat("22", 20) + "50" + metadata,
+			compiled:      "0x" + body + placeholder + metadata,
+			match:         true,
+			metadataMatch: true,
+			libraries:     []uint64{uint64(len(testDispatcher) + 1)},
+		},
+		{
+			name:          "LibrarySelfAddress",
+			deployed:      "73" + strings.Repeat("33", 20) + "50" + body + metadata,
+			compiled:      library + body + metadata,
+			match:         true,
+			metadataMatch: true,
+		},
+		{
+			name:     "Different",
+			deployed: body + "00" + metadata,
+			compiled: body + "01" + metadata,
+			reason:   "code differs at offset 39",
+		},
+		{
+			name:     "DifferentLength",
+			deployed: body + metadata,
+			compiled: body + "00" + metadata,
+			reason:   "code is 39 bytes but compiled code is 40 bytes",
+		},
+		{
+			name:     "DifferentNonZeroPush",
+			deployed: "7f" + strings.Repeat("11", 32) + "50" + body,
+			compiled: "7f" + strings.Repeat("01", 32) + "50" + body,
+			reason:   "code differs at offset 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deployed, err := hex.DecodeString(test.deployed)
+			require.NoError(t, err)
+			res, err := CompareCode(deployed, test.compiled)
+			require.NoError(t, err)
+			assert.Equal(t, test.match, res.Match)
+			assert.Equal(t, test.reason, res.Reason)
+			if test.match {
+				assert.Equal(t, test.metadataMatch, res.MetadataMatch)
+				if test.immutables != nil {
+					assert.Equal(t, test.immutables, res.Immutables)
+				}
+				if test.libraries != nil {
+					assert.Equal(t, test.libraries, res.Libraries)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareCodeInvalid(t *testing.T) {
+	_, err := CompareCode(testDispatcher, "0xzz")
+	require.Error(t, err)
+}
+
+func TestCompareCodeSwarm(t *testing.T) {
+	// Runtime code from solc 0.4.23, with Swarm metadata.
+	runtime := "6080604052600080fd00a165627a7a7230582083d530c10e079e85c2f5030dc4ff81c9c24ad62d6af39470de661d4596b5766e0029"
+	deployed, err := hex.DecodeString(runtime)
+	require.NoError(t, err)
+
+	metadata := ParseCodeMetadata(deployed)
+	require.NotNil(t, metadata)
+	assert.Equal(t, "83d530c10e079e85c2f5030dc4ff81c9c24ad62d6af39470de661d4596b5766e", metadata.Swarm)
+	assert.Equal(t, "", metadata.Compiler)
+
+	res, err := CompareCode(deployed, runtime)
+	require.NoError(t, err)
+	assert.True(t, res.Match)
+	assert.True(t, res.MetadataMatch)
+}
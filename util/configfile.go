@@ -0,0 +1,132 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SetConfigValue sets a top-level string value in the configuration file at the given path,
+// leaving the rest of the file, including comments and formatting, untouched.  The format of
+// the file is taken from its extension, and can be YAML (the default), TOML or JSON.  The file is
+// created if it does not exist.
+func SetConfigValue(path string, key string, value string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		data = nil
+	}
+
+	var updated string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		updated, err = setJSONConfigValue(string(data), key, value)
+		if err != nil {
+			return err
+		}
+	case ".toml":
+		updated = setTOMLConfigValue(string(data), key, value)
+	default:
+		updated = setYAMLConfigValue(string(data), key, value)
+	}
+
+	mode := os.FileMode(0600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return ioutil.WriteFile(path, []byte(updated), mode)
+}
+
+// setYAMLConfigValue replaces the top-level key in the YAML, or appends it if not present.
+func setYAMLConfigValue(data string, key string, value string) string {
+	line := fmt.Sprintf("%s: %q", key, value)
+	re := regexp.MustCompile(fmt.Sprintf(`(?m)^%s[ \t]*:.*$`, regexp.QuoteMeta(key)))
+	if re.MatchString(data) {
+		return re.ReplaceAllLiteralString(data, line)
+	}
+	return appendLine(data, line)
+}
+
+// setTOMLConfigValue replaces the top-level key in the TOML, or adds it before the first table
+// if not present.
+func setTOMLConfigValue(data string, key string, value string) string {
+	line := fmt.Sprintf("%s = %q", key, value)
+	lines := strings.Split(data, "\n")
+	for i, existing := range lines {
+		trimmed := strings.TrimSpace(existing)
+		if strings.HasPrefix(trimmed, "[") {
+			// Top-level keys must come before the first table.
+			return strings.Join(append(lines[:i], append([]string{line}, lines[i:]...)...), "\n")
+		}
+		if strings.HasPrefix(trimmed, key) && strings.HasPrefix(strings.TrimSpace(trimmed[len(key):]), "=") {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	return appendLine(data, line)
+}
+
+// setJSONConfigValue replaces the top-level key in the JSON, or adds it at the start of the
+// object if not present.
+func setJSONConfigValue(data string, key string, value string) (string, error) {
+	if strings.TrimSpace(data) == "" {
+		data = "{}"
+	}
+	config := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		return "", errors.Wrap(err, "invalid JSON configuration")
+	}
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	if _, exists := config[key]; exists {
+		re := regexp.MustCompile(fmt.Sprintf(`%s\s*:\s*"(?:[^"\\]|\\.)*"`, regexp.QuoteMeta(string(encodedKey))))
+		if !re.MatchString(data) {
+			return "", errors.Errorf("%s in configuration is not a string", key)
+		}
+		return re.ReplaceAllLiteralString(data, fmt.Sprintf("%s: %s", encodedKey, encodedValue)), nil
+	}
+	start := strings.Index(data, "{")
+	entry := fmt.Sprintf("\n  %s: %s", encodedKey, encodedValue)
+	if len(config) > 0 {
+		entry += ","
+	} else if strings.TrimSpace(data[start+1:]) == "}" {
+		return fmt.Sprintf("{%s\n}\n", entry), nil
+	}
+	return data[:start+1] + entry + data[start+1:], nil
+}
+
+// appendLine appends the line to the data, ensuring that it is on a line of its own.
+func appendLine(data string, line string) string {
+	if data != "" && !strings.HasSuffix(data, "\n") {
+		data += "\n"
+	}
+	return data + line + "\n"
+}
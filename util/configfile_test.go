@@ -0,0 +1,115 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConfigValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		existing string
+		expected string
+		err      string
+	}{
+		{
+			name:     "YAMLMissing",
+			file:     "config.yaml",
+			expected: "default-account: \"0x01\"\n",
+		},
+		{
+			name:     "YAMLAppend",
+			file:     "config.yaml",
+			existing: "# My configuration\nconnection: http://localhost:8545",
+			expected: "# My configuration\nconnection: http://localhost:8545\ndefault-account: \"0x01\"\n",
+		},
+		{
+			name:     "YAMLReplace",
+			file:     ".ethereal",
+			existing: "# My configuration\ndefault-account: 0x02 # old\nconnection: http://localhost:8545\n",
+			expected: "# My configuration\ndefault-account: \"0x01\"\nconnection: http://localhost:8545\n",
+		},
+		{
+			name:     "YAMLNested",
+			file:     "config.yml",
+			existing: "other:\n  default-account: 0x02\n",
+			expected: "other:\n  default-account: 0x02\ndefault-account: \"0x01\"\n",
+		},
+		{
+			name:     "TOMLReplace",
+			file:     "config.toml",
+			existing: "# Comment\ndefault-account = \"0x02\"\n",
+			expected: "# Comment\ndefault-account = \"0x01\"\n",
+		},
+		{
+			name:     "TOMLBeforeTable",
+			file:     "config.toml",
+			existing: "connection = \"http://localhost:8545\"\n[table]\ndefault-account = \"0x02\"\n",
+			expected: "connection = \"http://localhost:8545\"\ndefault-account = \"0x01\"\n[table]\ndefault-account = \"0x02\"\n",
+		},
+		{
+			name:     "JSONEmpty",
+			file:     "config.json",
+			existing: "{}",
+			expected: "{\n  \"default-account\": \"0x01\"\n}\n",
+		},
+		{
+			name:     "JSONAdd",
+			file:     "config.json",
+			existing: "{\n  \"connection\": \"http://localhost:8545\"\n}\n",
+			expected: "{\n  \"default-account\": \"0x01\",\n  \"connection\": \"http://localhost:8545\"\n}\n",
+		},
+		{
+			name:     "JSONReplace",
+			file:     "config.json",
+			existing: "{\n  \"default-account\": \"0x02\",\n  \"connection\": \"http://localhost:8545\"\n}\n",
+			expected: "{\n  \"default-account\": \"0x01\",\n  \"connection\": \"http://localhost:8545\"\n}\n",
+		},
+		{
+			name:     "JSONInvalid",
+			file:     "config.json",
+			existing: "{",
+			err:      "invalid JSON configuration: unexpected end of JSON input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "configfile")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, test.file)
+			if test.existing != "" {
+				require.NoError(t, ioutil.WriteFile(path, []byte(test.existing), 0600))
+			}
+
+			err = SetConfigValue(path, "default-account", "0x01")
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				data, err := ioutil.ReadFile(path)
+				require.NoError(t, err)
+				require.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
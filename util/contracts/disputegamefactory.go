@@ -0,0 +1,261 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// DisputeGameFactoryMetaData contains all meta data concerning the DisputeGameFactory contract.
+var DisputeGameFactoryMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"gameCount\",\"inputs\":[],\"outputs\":[{\"name\":\"gameCount_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"gameAtIndex\",\"inputs\":[{\"name\":\"_index\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"gameType_\",\"type\":\"uint32\"},{\"name\":\"timestamp_\",\"type\":\"uint64\"},{\"name\":\"proxy_\",\"type\":\"address\"}],\"stateMutability\":\"view\"}]",
+}
+
+// DisputeGameFactoryABI is the input ABI used to generate the binding from.
+// Deprecated: Use DisputeGameFactoryMetaData.ABI instead.
+var DisputeGameFactoryABI = DisputeGameFactoryMetaData.ABI
+
+// DisputeGameFactory is an auto generated Go binding around an Ethereum contract.
+type DisputeGameFactory struct {
+	DisputeGameFactoryCaller     // Read-only binding to the contract
+	DisputeGameFactoryTransactor // Write-only binding to the contract
+	DisputeGameFactoryFilterer   // Log filterer for contract events
+}
+
+// DisputeGameFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type DisputeGameFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DisputeGameFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type DisputeGameFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DisputeGameFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type DisputeGameFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DisputeGameFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type DisputeGameFactorySession struct {
+	Contract     *DisputeGameFactory // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts       // Call options to use throughout this session
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// DisputeGameFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type DisputeGameFactoryCallerSession struct {
+	Contract *DisputeGameFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts             // Call options to use throughout this session
+}
+
+// DisputeGameFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type DisputeGameFactoryTransactorSession struct {
+	Contract     *DisputeGameFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts             // Transaction auth options to use throughout this session
+}
+
+// DisputeGameFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type DisputeGameFactoryRaw struct {
+	Contract *DisputeGameFactory // Generic contract binding to access the raw methods on
+}
+
+// DisputeGameFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type DisputeGameFactoryCallerRaw struct {
+	Contract *DisputeGameFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// DisputeGameFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type DisputeGameFactoryTransactorRaw struct {
+	Contract *DisputeGameFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewDisputeGameFactory creates a new instance of DisputeGameFactory, bound to a specific deployed contract.
+func NewDisputeGameFactory(address common.Address, backend bind.ContractBackend) (*DisputeGameFactory, error) {
+	contract, err := bindDisputeGameFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeGameFactory{DisputeGameFactoryCaller: DisputeGameFactoryCaller{contract: contract}, DisputeGameFactoryTransactor: DisputeGameFactoryTransactor{contract: contract}, DisputeGameFactoryFilterer: DisputeGameFactoryFilterer{contract: contract}}, nil
+}
+
+// NewDisputeGameFactoryCaller creates a new read-only instance of DisputeGameFactory, bound to a specific deployed contract.
+func NewDisputeGameFactoryCaller(address common.Address, caller bind.ContractCaller) (*DisputeGameFactoryCaller, error) {
+	contract, err := bindDisputeGameFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeGameFactoryCaller{contract: contract}, nil
+}
+
+// NewDisputeGameFactoryTransactor creates a new write-only instance of DisputeGameFactory, bound to a specific deployed contract.
+func NewDisputeGameFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*DisputeGameFactoryTransactor, error) {
+	contract, err := bindDisputeGameFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeGameFactoryTransactor{contract: contract}, nil
+}
+
+// NewDisputeGameFactoryFilterer creates a new log filterer instance of DisputeGameFactory, bound to a specific deployed contract.
+func NewDisputeGameFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*DisputeGameFactoryFilterer, error) {
+	contract, err := bindDisputeGameFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &DisputeGameFactoryFilterer{contract: contract}, nil
+}
+
+// bindDisputeGameFactory binds a generic wrapper to an already deployed contract.
+func bindDisputeGameFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(DisputeGameFactoryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DisputeGameFactory *DisputeGameFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DisputeGameFactory.Contract.DisputeGameFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DisputeGameFactory *DisputeGameFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DisputeGameFactory.Contract.DisputeGameFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DisputeGameFactory *DisputeGameFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DisputeGameFactory.Contract.DisputeGameFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DisputeGameFactory *DisputeGameFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DisputeGameFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DisputeGameFactory *DisputeGameFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DisputeGameFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DisputeGameFactory *DisputeGameFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DisputeGameFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// GameAtIndex is a free data retrieval call binding the contract method 0xbb8aa1fc.
+//
+// Solidity: function gameAtIndex(uint256 _index) view returns(uint32 gameType_, uint64 timestamp_, address proxy_)
+func (_DisputeGameFactory *DisputeGameFactoryCaller) GameAtIndex(opts *bind.CallOpts, _index *big.Int) (struct {
+	GameType  uint32
+	Timestamp uint64
+	Proxy     common.Address
+}, error) {
+	var out []interface{}
+	err := _DisputeGameFactory.contract.Call(opts, &out, "gameAtIndex", _index)
+
+	outstruct := new(struct {
+		GameType  uint32
+		Timestamp uint64
+		Proxy     common.Address
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.GameType = *abi.ConvertType(out[0], new(uint32)).(*uint32)
+	outstruct.Timestamp = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.Proxy = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+
+	return *outstruct, err
+
+}
+
+// GameAtIndex is a free data retrieval call binding the contract method 0xbb8aa1fc.
+//
+// Solidity: function gameAtIndex(uint256 _index) view returns(uint32 gameType_, uint64 timestamp_, address proxy_)
+func (_DisputeGameFactory *DisputeGameFactorySession) GameAtIndex(_index *big.Int) (struct {
+	GameType  uint32
+	Timestamp uint64
+	Proxy     common.Address
+}, error) {
+	return _DisputeGameFactory.Contract.GameAtIndex(&_DisputeGameFactory.CallOpts, _index)
+}
+
+// GameAtIndex is a free data retrieval call binding the contract method 0xbb8aa1fc.
+//
+// Solidity: function gameAtIndex(uint256 _index) view returns(uint32 gameType_, uint64 timestamp_, address proxy_)
+func (_DisputeGameFactory *DisputeGameFactoryCallerSession) GameAtIndex(_index *big.Int) (struct {
+	GameType  uint32
+	Timestamp uint64
+	Proxy     common.Address
+}, error) {
+	return _DisputeGameFactory.Contract.GameAtIndex(&_DisputeGameFactory.CallOpts, _index)
+}
+
+// GameCount is a free data retrieval call binding the contract method 0x4d1975b4.
+//
+// Solidity: function gameCount() view returns(uint256 gameCount_)
+func (_DisputeGameFactory *DisputeGameFactoryCaller) GameCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _DisputeGameFactory.contract.Call(opts, &out, "gameCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GameCount is a free data retrieval call binding the contract method 0x4d1975b4.
+//
+// Solidity: function gameCount() view returns(uint256 gameCount_)
+func (_DisputeGameFactory *DisputeGameFactorySession) GameCount() (*big.Int, error) {
+	return _DisputeGameFactory.Contract.GameCount(&_DisputeGameFactory.CallOpts)
+}
+
+// GameCount is a free data retrieval call binding the contract method 0x4d1975b4.
+//
+// Solidity: function gameCount() view returns(uint256 gameCount_)
+func (_DisputeGameFactory *DisputeGameFactoryCallerSession) GameCount() (*big.Int, error) {
+	return _DisputeGameFactory.Contract.GameCount(&_DisputeGameFactory.CallOpts)
+}
@@ -0,0 +1,497 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// IPriceOraclePrice is an auto generated low-level Go binding around an user-defined struct.
+type IPriceOraclePrice struct {
+	Base    *big.Int
+	Premium *big.Int
+}
+
+// ETHRegistrarControllerMetaData contains all meta data concerning the ETHRegistrarController contract.
+var ETHRegistrarControllerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"name\":\"available\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"commitment\",\"type\":\"bytes32\"}],\"name\":\"commit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"commitments\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"secret\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"},{\"internalType\":\"bytes[]\",\"name\":\"data\",\"type\":\"bytes[]\"},{\"internalType\":\"bool\",\"name\":\"reverseRecord\",\"type\":\"bool\"},{\"internalType\":\"uint16\",\"name\":\"ownerControlledFuses\",\"type\":\"uint16\"}],\"name\":\"makeCommitment\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"maxCommitmentAge\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minCommitmentAge\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_REGISTRATION_DURATION\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"secret\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"},{\"internalType\":\"bytes[]\",\"name\":\"data\",\"type\":\"bytes[]\"},{\"internalType\":\"bool\",\"name\":\"reverseRecord\",\"type\":\"bool\"},{\"internalType\":\"uint16\",\"name\":\"ownerControlledFuses\",\"type\":\"uint16\"}],\"name\":\"register\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"}],\"name\":\"renew\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"}],\"name\":\"rentPrice\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"base\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"premium\",\"type\":\"uint256\"}],\"internalType\":\"structIPriceOracle.Price\",\"name\":\"price\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"name\":\"valid\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
+}
+
+// ETHRegistrarControllerABI is the input ABI used to generate the binding from.
+// Deprecated: Use ETHRegistrarControllerMetaData.ABI instead.
+var ETHRegistrarControllerABI = ETHRegistrarControllerMetaData.ABI
+
+// ETHRegistrarController is an auto generated Go binding around an Ethereum contract.
+type ETHRegistrarController struct {
+	ETHRegistrarControllerCaller     // Read-only binding to the contract
+	ETHRegistrarControllerTransactor // Write-only binding to the contract
+	ETHRegistrarControllerFilterer   // Log filterer for contract events
+}
+
+// ETHRegistrarControllerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ETHRegistrarControllerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETHRegistrarControllerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ETHRegistrarControllerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETHRegistrarControllerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ETHRegistrarControllerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETHRegistrarControllerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ETHRegistrarControllerSession struct {
+	Contract     *ETHRegistrarController // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// ETHRegistrarControllerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ETHRegistrarControllerCallerSession struct {
+	Contract *ETHRegistrarControllerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// ETHRegistrarControllerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ETHRegistrarControllerTransactorSession struct {
+	Contract     *ETHRegistrarControllerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// ETHRegistrarControllerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ETHRegistrarControllerRaw struct {
+	Contract *ETHRegistrarController // Generic contract binding to access the raw methods on
+}
+
+// ETHRegistrarControllerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ETHRegistrarControllerCallerRaw struct {
+	Contract *ETHRegistrarControllerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ETHRegistrarControllerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ETHRegistrarControllerTransactorRaw struct {
+	Contract *ETHRegistrarControllerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewETHRegistrarController creates a new instance of ETHRegistrarController, bound to a specific deployed contract.
+func NewETHRegistrarController(address common.Address, backend bind.ContractBackend) (*ETHRegistrarController, error) {
+	contract, err := bindETHRegistrarController(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ETHRegistrarController{ETHRegistrarControllerCaller: ETHRegistrarControllerCaller{contract: contract}, ETHRegistrarControllerTransactor: ETHRegistrarControllerTransactor{contract: contract}, ETHRegistrarControllerFilterer: ETHRegistrarControllerFilterer{contract: contract}}, nil
+}
+
+// NewETHRegistrarControllerCaller creates a new read-only instance of ETHRegistrarController, bound to a specific deployed contract.
+func NewETHRegistrarControllerCaller(address common.Address, caller bind.ContractCaller) (*ETHRegistrarControllerCaller, error) {
+	contract, err := bindETHRegistrarController(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETHRegistrarControllerCaller{contract: contract}, nil
+}
+
+// NewETHRegistrarControllerTransactor creates a new write-only instance of ETHRegistrarController, bound to a specific deployed contract.
+func NewETHRegistrarControllerTransactor(address common.Address, transactor bind.ContractTransactor) (*ETHRegistrarControllerTransactor, error) {
+	contract, err := bindETHRegistrarController(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETHRegistrarControllerTransactor{contract: contract}, nil
+}
+
+// NewETHRegistrarControllerFilterer creates a new log filterer instance of ETHRegistrarController, bound to a specific deployed contract.
+func NewETHRegistrarControllerFilterer(address common.Address, filterer bind.ContractFilterer) (*ETHRegistrarControllerFilterer, error) {
+	contract, err := bindETHRegistrarController(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ETHRegistrarControllerFilterer{contract: contract}, nil
+}
+
+// bindETHRegistrarController binds a generic wrapper to an already deployed contract.
+func bindETHRegistrarController(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ETHRegistrarControllerABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETHRegistrarController *ETHRegistrarControllerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETHRegistrarController.Contract.ETHRegistrarControllerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETHRegistrarController *ETHRegistrarControllerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.ETHRegistrarControllerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETHRegistrarController *ETHRegistrarControllerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.ETHRegistrarControllerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETHRegistrarController *ETHRegistrarControllerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETHRegistrarController.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETHRegistrarController *ETHRegistrarControllerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETHRegistrarController *ETHRegistrarControllerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.contract.Transact(opts, method, params...)
+}
+
+// MINREGISTRATIONDURATION is a free data retrieval call binding the contract method 0x8a95b09f.
+//
+// Solidity: function MIN_REGISTRATION_DURATION() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) MINREGISTRATIONDURATION(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "MIN_REGISTRATION_DURATION")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINREGISTRATIONDURATION is a free data retrieval call binding the contract method 0x8a95b09f.
+//
+// Solidity: function MIN_REGISTRATION_DURATION() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) MINREGISTRATIONDURATION() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MINREGISTRATIONDURATION(&_ETHRegistrarController.CallOpts)
+}
+
+// MINREGISTRATIONDURATION is a free data retrieval call binding the contract method 0x8a95b09f.
+//
+// Solidity: function MIN_REGISTRATION_DURATION() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) MINREGISTRATIONDURATION() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MINREGISTRATIONDURATION(&_ETHRegistrarController.CallOpts)
+}
+
+// Available is a free data retrieval call binding the contract method 0xaeb8ce9b.
+//
+// Solidity: function available(string name) view returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) Available(opts *bind.CallOpts, name string) (bool, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "available", name)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Available is a free data retrieval call binding the contract method 0xaeb8ce9b.
+//
+// Solidity: function available(string name) view returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Available(name string) (bool, error) {
+	return _ETHRegistrarController.Contract.Available(&_ETHRegistrarController.CallOpts, name)
+}
+
+// Available is a free data retrieval call binding the contract method 0xaeb8ce9b.
+//
+// Solidity: function available(string name) view returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) Available(name string) (bool, error) {
+	return _ETHRegistrarController.Contract.Available(&_ETHRegistrarController.CallOpts, name)
+}
+
+// Commitments is a free data retrieval call binding the contract method 0x839df945.
+//
+// Solidity: function commitments(bytes32 ) view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) Commitments(opts *bind.CallOpts, arg0 [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "commitments", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Commitments is a free data retrieval call binding the contract method 0x839df945.
+//
+// Solidity: function commitments(bytes32 ) view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Commitments(arg0 [32]byte) (*big.Int, error) {
+	return _ETHRegistrarController.Contract.Commitments(&_ETHRegistrarController.CallOpts, arg0)
+}
+
+// Commitments is a free data retrieval call binding the contract method 0x839df945.
+//
+// Solidity: function commitments(bytes32 ) view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) Commitments(arg0 [32]byte) (*big.Int, error) {
+	return _ETHRegistrarController.Contract.Commitments(&_ETHRegistrarController.CallOpts, arg0)
+}
+
+// MakeCommitment is a free data retrieval call binding the contract method 0x65a69dcf.
+//
+// Solidity: function makeCommitment(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) pure returns(bytes32)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) MakeCommitment(opts *bind.CallOpts, name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) ([32]byte, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "makeCommitment", name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// MakeCommitment is a free data retrieval call binding the contract method 0x65a69dcf.
+//
+// Solidity: function makeCommitment(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) pure returns(bytes32)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) MakeCommitment(name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) ([32]byte, error) {
+	return _ETHRegistrarController.Contract.MakeCommitment(&_ETHRegistrarController.CallOpts, name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+}
+
+// MakeCommitment is a free data retrieval call binding the contract method 0x65a69dcf.
+//
+// Solidity: function makeCommitment(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) pure returns(bytes32)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) MakeCommitment(name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) ([32]byte, error) {
+	return _ETHRegistrarController.Contract.MakeCommitment(&_ETHRegistrarController.CallOpts, name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+}
+
+// MaxCommitmentAge is a free data retrieval call binding the contract method 0xce1e09c0.
+//
+// Solidity: function maxCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) MaxCommitmentAge(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "maxCommitmentAge")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxCommitmentAge is a free data retrieval call binding the contract method 0xce1e09c0.
+//
+// Solidity: function maxCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) MaxCommitmentAge() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MaxCommitmentAge(&_ETHRegistrarController.CallOpts)
+}
+
+// MaxCommitmentAge is a free data retrieval call binding the contract method 0xce1e09c0.
+//
+// Solidity: function maxCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) MaxCommitmentAge() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MaxCommitmentAge(&_ETHRegistrarController.CallOpts)
+}
+
+// MinCommitmentAge is a free data retrieval call binding the contract method 0x8d839ffe.
+//
+// Solidity: function minCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) MinCommitmentAge(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "minCommitmentAge")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinCommitmentAge is a free data retrieval call binding the contract method 0x8d839ffe.
+//
+// Solidity: function minCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) MinCommitmentAge() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MinCommitmentAge(&_ETHRegistrarController.CallOpts)
+}
+
+// MinCommitmentAge is a free data retrieval call binding the contract method 0x8d839ffe.
+//
+// Solidity: function minCommitmentAge() view returns(uint256)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) MinCommitmentAge() (*big.Int, error) {
+	return _ETHRegistrarController.Contract.MinCommitmentAge(&_ETHRegistrarController.CallOpts)
+}
+
+// RentPrice is a free data retrieval call binding the contract method 0x83e7f6ff.
+//
+// Solidity: function rentPrice(string name, uint256 duration) view returns((uint256,uint256) price)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) RentPrice(opts *bind.CallOpts, name string, duration *big.Int) (IPriceOraclePrice, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "rentPrice", name, duration)
+
+	if err != nil {
+		return *new(IPriceOraclePrice), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(IPriceOraclePrice)).(*IPriceOraclePrice)
+
+	return out0, err
+
+}
+
+// RentPrice is a free data retrieval call binding the contract method 0x83e7f6ff.
+//
+// Solidity: function rentPrice(string name, uint256 duration) view returns((uint256,uint256) price)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) RentPrice(name string, duration *big.Int) (IPriceOraclePrice, error) {
+	return _ETHRegistrarController.Contract.RentPrice(&_ETHRegistrarController.CallOpts, name, duration)
+}
+
+// RentPrice is a free data retrieval call binding the contract method 0x83e7f6ff.
+//
+// Solidity: function rentPrice(string name, uint256 duration) view returns((uint256,uint256) price)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) RentPrice(name string, duration *big.Int) (IPriceOraclePrice, error) {
+	return _ETHRegistrarController.Contract.RentPrice(&_ETHRegistrarController.CallOpts, name, duration)
+}
+
+// Valid is a free data retrieval call binding the contract method 0x9791c097.
+//
+// Solidity: function valid(string name) pure returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerCaller) Valid(opts *bind.CallOpts, name string) (bool, error) {
+	var out []interface{}
+	err := _ETHRegistrarController.contract.Call(opts, &out, "valid", name)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Valid is a free data retrieval call binding the contract method 0x9791c097.
+//
+// Solidity: function valid(string name) pure returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Valid(name string) (bool, error) {
+	return _ETHRegistrarController.Contract.Valid(&_ETHRegistrarController.CallOpts, name)
+}
+
+// Valid is a free data retrieval call binding the contract method 0x9791c097.
+//
+// Solidity: function valid(string name) pure returns(bool)
+func (_ETHRegistrarController *ETHRegistrarControllerCallerSession) Valid(name string) (bool, error) {
+	return _ETHRegistrarController.Contract.Valid(&_ETHRegistrarController.CallOpts, name)
+}
+
+// Commit is a paid mutator transaction binding the contract method 0xf14fcbc8.
+//
+// Solidity: function commit(bytes32 commitment) returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactor) Commit(opts *bind.TransactOpts, commitment [32]byte) (*types.Transaction, error) {
+	return _ETHRegistrarController.contract.Transact(opts, "commit", commitment)
+}
+
+// Commit is a paid mutator transaction binding the contract method 0xf14fcbc8.
+//
+// Solidity: function commit(bytes32 commitment) returns()
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Commit(commitment [32]byte) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Commit(&_ETHRegistrarController.TransactOpts, commitment)
+}
+
+// Commit is a paid mutator transaction binding the contract method 0xf14fcbc8.
+//
+// Solidity: function commit(bytes32 commitment) returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactorSession) Commit(commitment [32]byte) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Commit(&_ETHRegistrarController.TransactOpts, commitment)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x74694a2b.
+//
+// Solidity: function register(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactor) Register(opts *bind.TransactOpts, name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _ETHRegistrarController.contract.Transact(opts, "register", name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x74694a2b.
+//
+// Solidity: function register(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Register(name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Register(&_ETHRegistrarController.TransactOpts, name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x74694a2b.
+//
+// Solidity: function register(string name, address owner, uint256 duration, bytes32 secret, address resolver, bytes[] data, bool reverseRecord, uint16 ownerControlledFuses) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactorSession) Register(name string, owner common.Address, duration *big.Int, secret [32]byte, resolver common.Address, data [][]byte, reverseRecord bool, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Register(&_ETHRegistrarController.TransactOpts, name, owner, duration, secret, resolver, data, reverseRecord, ownerControlledFuses)
+}
+
+// Renew is a paid mutator transaction binding the contract method 0xacf1a841.
+//
+// Solidity: function renew(string name, uint256 duration) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactor) Renew(opts *bind.TransactOpts, name string, duration *big.Int) (*types.Transaction, error) {
+	return _ETHRegistrarController.contract.Transact(opts, "renew", name, duration)
+}
+
+// Renew is a paid mutator transaction binding the contract method 0xacf1a841.
+//
+// Solidity: function renew(string name, uint256 duration) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerSession) Renew(name string, duration *big.Int) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Renew(&_ETHRegistrarController.TransactOpts, name, duration)
+}
+
+// Renew is a paid mutator transaction binding the contract method 0xacf1a841.
+//
+// Solidity: function renew(string name, uint256 duration) payable returns()
+func (_ETHRegistrarController *ETHRegistrarControllerTransactorSession) Renew(name string, duration *big.Int) (*types.Transaction, error) {
+	return _ETHRegistrarController.Contract.Renew(&_ETHRegistrarController.TransactOpts, name, duration)
+}
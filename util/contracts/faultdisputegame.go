@@ -0,0 +1,304 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FaultDisputeGameMetaData contains all meta data concerning the FaultDisputeGame contract.
+var FaultDisputeGameMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"l2BlockNumber\",\"inputs\":[],\"outputs\":[{\"name\":\"l2BlockNumber_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"rootClaim\",\"inputs\":[],\"outputs\":[{\"name\":\"rootClaim_\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"status\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"resolvedAt\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\"}]",
+}
+
+// FaultDisputeGameABI is the input ABI used to generate the binding from.
+// Deprecated: Use FaultDisputeGameMetaData.ABI instead.
+var FaultDisputeGameABI = FaultDisputeGameMetaData.ABI
+
+// FaultDisputeGame is an auto generated Go binding around an Ethereum contract.
+type FaultDisputeGame struct {
+	FaultDisputeGameCaller     // Read-only binding to the contract
+	FaultDisputeGameTransactor // Write-only binding to the contract
+	FaultDisputeGameFilterer   // Log filterer for contract events
+}
+
+// FaultDisputeGameCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FaultDisputeGameCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FaultDisputeGameTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FaultDisputeGameTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FaultDisputeGameFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FaultDisputeGameFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FaultDisputeGameSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FaultDisputeGameSession struct {
+	Contract     *FaultDisputeGame // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FaultDisputeGameCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FaultDisputeGameCallerSession struct {
+	Contract *FaultDisputeGameCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// FaultDisputeGameTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FaultDisputeGameTransactorSession struct {
+	Contract     *FaultDisputeGameTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// FaultDisputeGameRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FaultDisputeGameRaw struct {
+	Contract *FaultDisputeGame // Generic contract binding to access the raw methods on
+}
+
+// FaultDisputeGameCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FaultDisputeGameCallerRaw struct {
+	Contract *FaultDisputeGameCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FaultDisputeGameTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FaultDisputeGameTransactorRaw struct {
+	Contract *FaultDisputeGameTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFaultDisputeGame creates a new instance of FaultDisputeGame, bound to a specific deployed contract.
+func NewFaultDisputeGame(address common.Address, backend bind.ContractBackend) (*FaultDisputeGame, error) {
+	contract, err := bindFaultDisputeGame(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FaultDisputeGame{FaultDisputeGameCaller: FaultDisputeGameCaller{contract: contract}, FaultDisputeGameTransactor: FaultDisputeGameTransactor{contract: contract}, FaultDisputeGameFilterer: FaultDisputeGameFilterer{contract: contract}}, nil
+}
+
+// NewFaultDisputeGameCaller creates a new read-only instance of FaultDisputeGame, bound to a specific deployed contract.
+func NewFaultDisputeGameCaller(address common.Address, caller bind.ContractCaller) (*FaultDisputeGameCaller, error) {
+	contract, err := bindFaultDisputeGame(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FaultDisputeGameCaller{contract: contract}, nil
+}
+
+// NewFaultDisputeGameTransactor creates a new write-only instance of FaultDisputeGame, bound to a specific deployed contract.
+func NewFaultDisputeGameTransactor(address common.Address, transactor bind.ContractTransactor) (*FaultDisputeGameTransactor, error) {
+	contract, err := bindFaultDisputeGame(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FaultDisputeGameTransactor{contract: contract}, nil
+}
+
+// NewFaultDisputeGameFilterer creates a new log filterer instance of FaultDisputeGame, bound to a specific deployed contract.
+func NewFaultDisputeGameFilterer(address common.Address, filterer bind.ContractFilterer) (*FaultDisputeGameFilterer, error) {
+	contract, err := bindFaultDisputeGame(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FaultDisputeGameFilterer{contract: contract}, nil
+}
+
+// bindFaultDisputeGame binds a generic wrapper to an already deployed contract.
+func bindFaultDisputeGame(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FaultDisputeGameABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FaultDisputeGame *FaultDisputeGameRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FaultDisputeGame.Contract.FaultDisputeGameCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FaultDisputeGame *FaultDisputeGameRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FaultDisputeGame.Contract.FaultDisputeGameTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FaultDisputeGame *FaultDisputeGameRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FaultDisputeGame.Contract.FaultDisputeGameTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FaultDisputeGame *FaultDisputeGameCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FaultDisputeGame.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FaultDisputeGame *FaultDisputeGameTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FaultDisputeGame.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FaultDisputeGame *FaultDisputeGameTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FaultDisputeGame.Contract.contract.Transact(opts, method, params...)
+}
+
+// L2BlockNumber is a free data retrieval call binding the contract method 0x8b85902b.
+//
+// Solidity: function l2BlockNumber() view returns(uint256 l2BlockNumber_)
+func (_FaultDisputeGame *FaultDisputeGameCaller) L2BlockNumber(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _FaultDisputeGame.contract.Call(opts, &out, "l2BlockNumber")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L2BlockNumber is a free data retrieval call binding the contract method 0x8b85902b.
+//
+// Solidity: function l2BlockNumber() view returns(uint256 l2BlockNumber_)
+func (_FaultDisputeGame *FaultDisputeGameSession) L2BlockNumber() (*big.Int, error) {
+	return _FaultDisputeGame.Contract.L2BlockNumber(&_FaultDisputeGame.CallOpts)
+}
+
+// L2BlockNumber is a free data retrieval call binding the contract method 0x8b85902b.
+//
+// Solidity: function l2BlockNumber() view returns(uint256 l2BlockNumber_)
+func (_FaultDisputeGame *FaultDisputeGameCallerSession) L2BlockNumber() (*big.Int, error) {
+	return _FaultDisputeGame.Contract.L2BlockNumber(&_FaultDisputeGame.CallOpts)
+}
+
+// ResolvedAt is a free data retrieval call binding the contract method 0x19effeb4.
+//
+// Solidity: function resolvedAt() view returns(uint64)
+func (_FaultDisputeGame *FaultDisputeGameCaller) ResolvedAt(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _FaultDisputeGame.contract.Call(opts, &out, "resolvedAt")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ResolvedAt is a free data retrieval call binding the contract method 0x19effeb4.
+//
+// Solidity: function resolvedAt() view returns(uint64)
+func (_FaultDisputeGame *FaultDisputeGameSession) ResolvedAt() (uint64, error) {
+	return _FaultDisputeGame.Contract.ResolvedAt(&_FaultDisputeGame.CallOpts)
+}
+
+// ResolvedAt is a free data retrieval call binding the contract method 0x19effeb4.
+//
+// Solidity: function resolvedAt() view returns(uint64)
+func (_FaultDisputeGame *FaultDisputeGameCallerSession) ResolvedAt() (uint64, error) {
+	return _FaultDisputeGame.Contract.ResolvedAt(&_FaultDisputeGame.CallOpts)
+}
+
+// RootClaim is a free data retrieval call binding the contract method 0xbcef3b55.
+//
+// Solidity: function rootClaim() view returns(bytes32 rootClaim_)
+func (_FaultDisputeGame *FaultDisputeGameCaller) RootClaim(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _FaultDisputeGame.contract.Call(opts, &out, "rootClaim")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// RootClaim is a free data retrieval call binding the contract method 0xbcef3b55.
+//
+// Solidity: function rootClaim() view returns(bytes32 rootClaim_)
+func (_FaultDisputeGame *FaultDisputeGameSession) RootClaim() ([32]byte, error) {
+	return _FaultDisputeGame.Contract.RootClaim(&_FaultDisputeGame.CallOpts)
+}
+
+// RootClaim is a free data retrieval call binding the contract method 0xbcef3b55.
+//
+// Solidity: function rootClaim() view returns(bytes32 rootClaim_)
+func (_FaultDisputeGame *FaultDisputeGameCallerSession) RootClaim() ([32]byte, error) {
+	return _FaultDisputeGame.Contract.RootClaim(&_FaultDisputeGame.CallOpts)
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(uint8)
+func (_FaultDisputeGame *FaultDisputeGameCaller) Status(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _FaultDisputeGame.contract.Call(opts, &out, "status")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(uint8)
+func (_FaultDisputeGame *FaultDisputeGameSession) Status() (uint8, error) {
+	return _FaultDisputeGame.Contract.Status(&_FaultDisputeGame.CallOpts)
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(uint8)
+func (_FaultDisputeGame *FaultDisputeGameCallerSession) Status() (uint8, error) {
+	return _FaultDisputeGame.Contract.Status(&_FaultDisputeGame.CallOpts)
+}
@@ -1,4 +1,15 @@
 package contracts
 
 //go:generate abigen -abi ERC20.abi -out erc20.go -pkg contracts -type ERC20
+//go:generate abigen -abi ERC20Permit.abi -out erc20permit.go -pkg contracts -type ERC20Permit
+//go:generate abigen -abi ERC721.abi -out erc721.go -pkg contracts -type ERC721
+//go:generate abigen -abi ETHRegistrarController.abi -out ethregistrarcontroller.go -pkg contracts -type ETHRegistrarController
+//go:generate abigen -abi NameWrapper.abi -out namewrapper.go -pkg contracts -type NameWrapper
 //go:generate abigen -abi eth2deposit.abi -out eth2deposit.go -pkg contracts -type Eth2Deposit
+//go:generate abigen -abi L1StandardBridge.abi -out l1standardbridge.go -pkg contracts -type L1StandardBridge
+//go:generate abigen -abi L2StandardBridge.abi -out l2standardbridge.go -pkg contracts -type L2StandardBridge
+//go:generate abigen -abi L2ToL1MessagePasser.abi -out l2tol1messagepasser.go -pkg contracts -type L2ToL1MessagePasser
+//go:generate abigen -abi OptimismPortal2.abi -out optimismportal2.go -pkg contracts -type OptimismPortal2
+//go:generate abigen -abi DisputeGameFactory.abi -out disputegamefactory.go -pkg contracts -type DisputeGameFactory
+//go:generate abigen -abi FaultDisputeGame.abi -out faultdisputegame.go -pkg contracts -type FaultDisputeGame
+//go:generate abigen -abi GnosisSafe.abi -out gnosissafe.go -pkg contracts -type GnosisSafe
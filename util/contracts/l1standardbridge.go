@@ -0,0 +1,222 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// L1StandardBridgeMetaData contains all meta data concerning the L1StandardBridge contract.
+var L1StandardBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"depositETHTo\",\"inputs\":[{\"name\":\"_to\",\"type\":\"address\"},{\"name\":\"_minGasLimit\",\"type\":\"uint32\"},{\"name\":\"_extraData\",\"type\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"depositERC20To\",\"inputs\":[{\"name\":\"_l1Token\",\"type\":\"address\"},{\"name\":\"_l2Token\",\"type\":\"address\"},{\"name\":\"_to\",\"type\":\"address\"},{\"name\":\"_amount\",\"type\":\"uint256\"},{\"name\":\"_minGasLimit\",\"type\":\"uint32\"},{\"name\":\"_extraData\",\"type\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+}
+
+// L1StandardBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use L1StandardBridgeMetaData.ABI instead.
+var L1StandardBridgeABI = L1StandardBridgeMetaData.ABI
+
+// L1StandardBridge is an auto generated Go binding around an Ethereum contract.
+type L1StandardBridge struct {
+	L1StandardBridgeCaller     // Read-only binding to the contract
+	L1StandardBridgeTransactor // Write-only binding to the contract
+	L1StandardBridgeFilterer   // Log filterer for contract events
+}
+
+// L1StandardBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type L1StandardBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1StandardBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type L1StandardBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1StandardBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type L1StandardBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1StandardBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type L1StandardBridgeSession struct {
+	Contract     *L1StandardBridge // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// L1StandardBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type L1StandardBridgeCallerSession struct {
+	Contract *L1StandardBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// L1StandardBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type L1StandardBridgeTransactorSession struct {
+	Contract     *L1StandardBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// L1StandardBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type L1StandardBridgeRaw struct {
+	Contract *L1StandardBridge // Generic contract binding to access the raw methods on
+}
+
+// L1StandardBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type L1StandardBridgeCallerRaw struct {
+	Contract *L1StandardBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// L1StandardBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type L1StandardBridgeTransactorRaw struct {
+	Contract *L1StandardBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewL1StandardBridge creates a new instance of L1StandardBridge, bound to a specific deployed contract.
+func NewL1StandardBridge(address common.Address, backend bind.ContractBackend) (*L1StandardBridge, error) {
+	contract, err := bindL1StandardBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &L1StandardBridge{L1StandardBridgeCaller: L1StandardBridgeCaller{contract: contract}, L1StandardBridgeTransactor: L1StandardBridgeTransactor{contract: contract}, L1StandardBridgeFilterer: L1StandardBridgeFilterer{contract: contract}}, nil
+}
+
+// NewL1StandardBridgeCaller creates a new read-only instance of L1StandardBridge, bound to a specific deployed contract.
+func NewL1StandardBridgeCaller(address common.Address, caller bind.ContractCaller) (*L1StandardBridgeCaller, error) {
+	contract, err := bindL1StandardBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1StandardBridgeCaller{contract: contract}, nil
+}
+
+// NewL1StandardBridgeTransactor creates a new write-only instance of L1StandardBridge, bound to a specific deployed contract.
+func NewL1StandardBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*L1StandardBridgeTransactor, error) {
+	contract, err := bindL1StandardBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1StandardBridgeTransactor{contract: contract}, nil
+}
+
+// NewL1StandardBridgeFilterer creates a new log filterer instance of L1StandardBridge, bound to a specific deployed contract.
+func NewL1StandardBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*L1StandardBridgeFilterer, error) {
+	contract, err := bindL1StandardBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &L1StandardBridgeFilterer{contract: contract}, nil
+}
+
+// bindL1StandardBridge binds a generic wrapper to an already deployed contract.
+func bindL1StandardBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(L1StandardBridgeABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1StandardBridge *L1StandardBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1StandardBridge.Contract.L1StandardBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1StandardBridge *L1StandardBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.L1StandardBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1StandardBridge *L1StandardBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.L1StandardBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1StandardBridge *L1StandardBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1StandardBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1StandardBridge *L1StandardBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1StandardBridge *L1StandardBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// DepositERC20To is a paid mutator transaction binding the contract method 0x838b2520.
+//
+// Solidity: function depositERC20To(address _l1Token, address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) returns()
+func (_L1StandardBridge *L1StandardBridgeTransactor) DepositERC20To(opts *bind.TransactOpts, _l1Token common.Address, _l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.contract.Transact(opts, "depositERC20To", _l1Token, _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
+
+// DepositERC20To is a paid mutator transaction binding the contract method 0x838b2520.
+//
+// Solidity: function depositERC20To(address _l1Token, address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) returns()
+func (_L1StandardBridge *L1StandardBridgeSession) DepositERC20To(_l1Token common.Address, _l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.DepositERC20To(&_L1StandardBridge.TransactOpts, _l1Token, _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
+
+// DepositERC20To is a paid mutator transaction binding the contract method 0x838b2520.
+//
+// Solidity: function depositERC20To(address _l1Token, address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) returns()
+func (_L1StandardBridge *L1StandardBridgeTransactorSession) DepositERC20To(_l1Token common.Address, _l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.DepositERC20To(&_L1StandardBridge.TransactOpts, _l1Token, _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
+
+// DepositETHTo is a paid mutator transaction binding the contract method 0x9a2ac6d5.
+//
+// Solidity: function depositETHTo(address _to, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L1StandardBridge *L1StandardBridgeTransactor) DepositETHTo(opts *bind.TransactOpts, _to common.Address, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.contract.Transact(opts, "depositETHTo", _to, _minGasLimit, _extraData)
+}
+
+// DepositETHTo is a paid mutator transaction binding the contract method 0x9a2ac6d5.
+//
+// Solidity: function depositETHTo(address _to, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L1StandardBridge *L1StandardBridgeSession) DepositETHTo(_to common.Address, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.DepositETHTo(&_L1StandardBridge.TransactOpts, _to, _minGasLimit, _extraData)
+}
+
+// DepositETHTo is a paid mutator transaction binding the contract method 0x9a2ac6d5.
+//
+// Solidity: function depositETHTo(address _to, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L1StandardBridge *L1StandardBridgeTransactorSession) DepositETHTo(_to common.Address, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L1StandardBridge.Contract.DepositETHTo(&_L1StandardBridge.TransactOpts, _to, _minGasLimit, _extraData)
+}
@@ -0,0 +1,201 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// L2StandardBridgeMetaData contains all meta data concerning the L2StandardBridge contract.
+var L2StandardBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"withdrawTo\",\"inputs\":[{\"name\":\"_l2Token\",\"type\":\"address\"},{\"name\":\"_to\",\"type\":\"address\"},{\"name\":\"_amount\",\"type\":\"uint256\"},{\"name\":\"_minGasLimit\",\"type\":\"uint32\"},{\"name\":\"_extraData\",\"type\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"}]",
+}
+
+// L2StandardBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use L2StandardBridgeMetaData.ABI instead.
+var L2StandardBridgeABI = L2StandardBridgeMetaData.ABI
+
+// L2StandardBridge is an auto generated Go binding around an Ethereum contract.
+type L2StandardBridge struct {
+	L2StandardBridgeCaller     // Read-only binding to the contract
+	L2StandardBridgeTransactor // Write-only binding to the contract
+	L2StandardBridgeFilterer   // Log filterer for contract events
+}
+
+// L2StandardBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type L2StandardBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2StandardBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type L2StandardBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2StandardBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type L2StandardBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2StandardBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type L2StandardBridgeSession struct {
+	Contract     *L2StandardBridge // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// L2StandardBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type L2StandardBridgeCallerSession struct {
+	Contract *L2StandardBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// L2StandardBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type L2StandardBridgeTransactorSession struct {
+	Contract     *L2StandardBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// L2StandardBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type L2StandardBridgeRaw struct {
+	Contract *L2StandardBridge // Generic contract binding to access the raw methods on
+}
+
+// L2StandardBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type L2StandardBridgeCallerRaw struct {
+	Contract *L2StandardBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// L2StandardBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type L2StandardBridgeTransactorRaw struct {
+	Contract *L2StandardBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewL2StandardBridge creates a new instance of L2StandardBridge, bound to a specific deployed contract.
+func NewL2StandardBridge(address common.Address, backend bind.ContractBackend) (*L2StandardBridge, error) {
+	contract, err := bindL2StandardBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &L2StandardBridge{L2StandardBridgeCaller: L2StandardBridgeCaller{contract: contract}, L2StandardBridgeTransactor: L2StandardBridgeTransactor{contract: contract}, L2StandardBridgeFilterer: L2StandardBridgeFilterer{contract: contract}}, nil
+}
+
+// NewL2StandardBridgeCaller creates a new read-only instance of L2StandardBridge, bound to a specific deployed contract.
+func NewL2StandardBridgeCaller(address common.Address, caller bind.ContractCaller) (*L2StandardBridgeCaller, error) {
+	contract, err := bindL2StandardBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L2StandardBridgeCaller{contract: contract}, nil
+}
+
+// NewL2StandardBridgeTransactor creates a new write-only instance of L2StandardBridge, bound to a specific deployed contract.
+func NewL2StandardBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*L2StandardBridgeTransactor, error) {
+	contract, err := bindL2StandardBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L2StandardBridgeTransactor{contract: contract}, nil
+}
+
+// NewL2StandardBridgeFilterer creates a new log filterer instance of L2StandardBridge, bound to a specific deployed contract.
+func NewL2StandardBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*L2StandardBridgeFilterer, error) {
+	contract, err := bindL2StandardBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &L2StandardBridgeFilterer{contract: contract}, nil
+}
+
+// bindL2StandardBridge binds a generic wrapper to an already deployed contract.
+func bindL2StandardBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(L2StandardBridgeABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L2StandardBridge *L2StandardBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L2StandardBridge.Contract.L2StandardBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L2StandardBridge *L2StandardBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.L2StandardBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L2StandardBridge *L2StandardBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.L2StandardBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L2StandardBridge *L2StandardBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L2StandardBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L2StandardBridge *L2StandardBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L2StandardBridge *L2StandardBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0xa3a79548.
+//
+// Solidity: function withdrawTo(address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L2StandardBridge *L2StandardBridgeTransactor) WithdrawTo(opts *bind.TransactOpts, _l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L2StandardBridge.contract.Transact(opts, "withdrawTo", _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0xa3a79548.
+//
+// Solidity: function withdrawTo(address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L2StandardBridge *L2StandardBridgeSession) WithdrawTo(_l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.WithdrawTo(&_L2StandardBridge.TransactOpts, _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0xa3a79548.
+//
+// Solidity: function withdrawTo(address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes _extraData) payable returns()
+func (_L2StandardBridge *L2StandardBridgeTransactorSession) WithdrawTo(_l2Token common.Address, _to common.Address, _amount *big.Int, _minGasLimit uint32, _extraData []byte) (*types.Transaction, error) {
+	return _L2StandardBridge.Contract.WithdrawTo(&_L2StandardBridge.TransactOpts, _l2Token, _to, _amount, _minGasLimit, _extraData)
+}
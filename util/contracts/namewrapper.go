@@ -0,0 +1,502 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// NameWrapperMetaData contains all meta data concerning the NameWrapper contract.
+var NameWrapperMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"ownerOf\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"function\",\"name\":\"getData\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"fuses\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"}]},{\"type\":\"function\",\"name\":\"isApprovedForAll\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"operator\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}]},{\"type\":\"function\",\"name\":\"setApprovalForAll\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"operator\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"approved\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"safeTransferFrom\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"id\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"wrap\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"name\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"wrappedOwner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"resolver\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"wrapETH2LD\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"label\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"wrappedOwner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"ownerControlledFuses\",\"type\":\"uint16\",\"internalType\":\"uint16\"},{\"name\":\"resolver\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"}]},{\"type\":\"function\",\"name\":\"unwrap\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"parentNode\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"labelhash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"controller\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"unwrapETH2LD\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"labelhash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"registrant\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"controller\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"setFuses\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"node\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"ownerControlledFuses\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\",\"internalType\":\"uint32\"}]},{\"type\":\"function\",\"name\":\"setChildFuses\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"parentNode\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"labelhash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"fuses\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"setSubnodeOwner\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"parentNode\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"label\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"fuses\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"expiry\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[{\"name\":\"node\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"setResolver\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"node\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"resolver\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[]}]",
+}
+
+// NameWrapperABI is the input ABI used to generate the binding from.
+// Deprecated: Use NameWrapperMetaData.ABI instead.
+var NameWrapperABI = NameWrapperMetaData.ABI
+
+// NameWrapper is an auto generated Go binding around an Ethereum contract.
+type NameWrapper struct {
+	NameWrapperCaller     // Read-only binding to the contract
+	NameWrapperTransactor // Write-only binding to the contract
+	NameWrapperFilterer   // Log filterer for contract events
+}
+
+// NameWrapperCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NameWrapperCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NameWrapperTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NameWrapperTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NameWrapperFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NameWrapperFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NameWrapperSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NameWrapperSession struct {
+	Contract     *NameWrapper      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NameWrapperCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NameWrapperCallerSession struct {
+	Contract *NameWrapperCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// NameWrapperTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NameWrapperTransactorSession struct {
+	Contract     *NameWrapperTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// NameWrapperRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NameWrapperRaw struct {
+	Contract *NameWrapper // Generic contract binding to access the raw methods on
+}
+
+// NameWrapperCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NameWrapperCallerRaw struct {
+	Contract *NameWrapperCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NameWrapperTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NameWrapperTransactorRaw struct {
+	Contract *NameWrapperTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNameWrapper creates a new instance of NameWrapper, bound to a specific deployed contract.
+func NewNameWrapper(address common.Address, backend bind.ContractBackend) (*NameWrapper, error) {
+	contract, err := bindNameWrapper(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NameWrapper{NameWrapperCaller: NameWrapperCaller{contract: contract}, NameWrapperTransactor: NameWrapperTransactor{contract: contract}, NameWrapperFilterer: NameWrapperFilterer{contract: contract}}, nil
+}
+
+// NewNameWrapperCaller creates a new read-only instance of NameWrapper, bound to a specific deployed contract.
+func NewNameWrapperCaller(address common.Address, caller bind.ContractCaller) (*NameWrapperCaller, error) {
+	contract, err := bindNameWrapper(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NameWrapperCaller{contract: contract}, nil
+}
+
+// NewNameWrapperTransactor creates a new write-only instance of NameWrapper, bound to a specific deployed contract.
+func NewNameWrapperTransactor(address common.Address, transactor bind.ContractTransactor) (*NameWrapperTransactor, error) {
+	contract, err := bindNameWrapper(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NameWrapperTransactor{contract: contract}, nil
+}
+
+// NewNameWrapperFilterer creates a new log filterer instance of NameWrapper, bound to a specific deployed contract.
+func NewNameWrapperFilterer(address common.Address, filterer bind.ContractFilterer) (*NameWrapperFilterer, error) {
+	contract, err := bindNameWrapper(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NameWrapperFilterer{contract: contract}, nil
+}
+
+// bindNameWrapper binds a generic wrapper to an already deployed contract.
+func bindNameWrapper(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(NameWrapperABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NameWrapper *NameWrapperRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NameWrapper.Contract.NameWrapperCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NameWrapper *NameWrapperRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NameWrapper.Contract.NameWrapperTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NameWrapper *NameWrapperRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NameWrapper.Contract.NameWrapperTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NameWrapper *NameWrapperCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NameWrapper.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NameWrapper *NameWrapperTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NameWrapper.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NameWrapper *NameWrapperTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NameWrapper.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetData is a free data retrieval call binding the contract method 0x0178fe3f.
+//
+// Solidity: function getData(uint256 id) view returns(address owner, uint32 fuses, uint64 expiry)
+func (_NameWrapper *NameWrapperCaller) GetData(opts *bind.CallOpts, id *big.Int) (struct {
+	Owner  common.Address
+	Fuses  uint32
+	Expiry uint64
+}, error) {
+	var out []interface{}
+	err := _NameWrapper.contract.Call(opts, &out, "getData", id)
+
+	outstruct := new(struct {
+		Owner  common.Address
+		Fuses  uint32
+		Expiry uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Owner = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Fuses = *abi.ConvertType(out[1], new(uint32)).(*uint32)
+	outstruct.Expiry = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+
+	return *outstruct, err
+
+}
+
+// GetData is a free data retrieval call binding the contract method 0x0178fe3f.
+//
+// Solidity: function getData(uint256 id) view returns(address owner, uint32 fuses, uint64 expiry)
+func (_NameWrapper *NameWrapperSession) GetData(id *big.Int) (struct {
+	Owner  common.Address
+	Fuses  uint32
+	Expiry uint64
+}, error) {
+	return _NameWrapper.Contract.GetData(&_NameWrapper.CallOpts, id)
+}
+
+// GetData is a free data retrieval call binding the contract method 0x0178fe3f.
+//
+// Solidity: function getData(uint256 id) view returns(address owner, uint32 fuses, uint64 expiry)
+func (_NameWrapper *NameWrapperCallerSession) GetData(id *big.Int) (struct {
+	Owner  common.Address
+	Fuses  uint32
+	Expiry uint64
+}, error) {
+	return _NameWrapper.Contract.GetData(&_NameWrapper.CallOpts, id)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_NameWrapper *NameWrapperCaller) IsApprovedForAll(opts *bind.CallOpts, account common.Address, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _NameWrapper.contract.Call(opts, &out, "isApprovedForAll", account, operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_NameWrapper *NameWrapperSession) IsApprovedForAll(account common.Address, operator common.Address) (bool, error) {
+	return _NameWrapper.Contract.IsApprovedForAll(&_NameWrapper.CallOpts, account, operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_NameWrapper *NameWrapperCallerSession) IsApprovedForAll(account common.Address, operator common.Address) (bool, error) {
+	return _NameWrapper.Contract.IsApprovedForAll(&_NameWrapper.CallOpts, account, operator)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 id) view returns(address owner)
+func (_NameWrapper *NameWrapperCaller) OwnerOf(opts *bind.CallOpts, id *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _NameWrapper.contract.Call(opts, &out, "ownerOf", id)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 id) view returns(address owner)
+func (_NameWrapper *NameWrapperSession) OwnerOf(id *big.Int) (common.Address, error) {
+	return _NameWrapper.Contract.OwnerOf(&_NameWrapper.CallOpts, id)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 id) view returns(address owner)
+func (_NameWrapper *NameWrapperCallerSession) OwnerOf(id *big.Int) (common.Address, error) {
+	return _NameWrapper.Contract.OwnerOf(&_NameWrapper.CallOpts, id)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes data) returns()
+func (_NameWrapper *NameWrapperTransactor) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, id *big.Int, amount *big.Int, data []byte) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "safeTransferFrom", from, to, id, amount, data)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes data) returns()
+func (_NameWrapper *NameWrapperSession) SafeTransferFrom(from common.Address, to common.Address, id *big.Int, amount *big.Int, data []byte) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SafeTransferFrom(&_NameWrapper.TransactOpts, from, to, id, amount, data)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes data) returns()
+func (_NameWrapper *NameWrapperTransactorSession) SafeTransferFrom(from common.Address, to common.Address, id *big.Int, amount *big.Int, data []byte) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SafeTransferFrom(&_NameWrapper.TransactOpts, from, to, id, amount, data)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_NameWrapper *NameWrapperTransactor) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_NameWrapper *NameWrapperSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetApprovalForAll(&_NameWrapper.TransactOpts, operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_NameWrapper *NameWrapperTransactorSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetApprovalForAll(&_NameWrapper.TransactOpts, operator, approved)
+}
+
+// SetChildFuses is a paid mutator transaction binding the contract method 0x33c69ea9.
+//
+// Solidity: function setChildFuses(bytes32 parentNode, bytes32 labelhash, uint32 fuses, uint64 expiry) returns()
+func (_NameWrapper *NameWrapperTransactor) SetChildFuses(opts *bind.TransactOpts, parentNode [32]byte, labelhash [32]byte, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "setChildFuses", parentNode, labelhash, fuses, expiry)
+}
+
+// SetChildFuses is a paid mutator transaction binding the contract method 0x33c69ea9.
+//
+// Solidity: function setChildFuses(bytes32 parentNode, bytes32 labelhash, uint32 fuses, uint64 expiry) returns()
+func (_NameWrapper *NameWrapperSession) SetChildFuses(parentNode [32]byte, labelhash [32]byte, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetChildFuses(&_NameWrapper.TransactOpts, parentNode, labelhash, fuses, expiry)
+}
+
+// SetChildFuses is a paid mutator transaction binding the contract method 0x33c69ea9.
+//
+// Solidity: function setChildFuses(bytes32 parentNode, bytes32 labelhash, uint32 fuses, uint64 expiry) returns()
+func (_NameWrapper *NameWrapperTransactorSession) SetChildFuses(parentNode [32]byte, labelhash [32]byte, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetChildFuses(&_NameWrapper.TransactOpts, parentNode, labelhash, fuses, expiry)
+}
+
+// SetFuses is a paid mutator transaction binding the contract method 0x402906fc.
+//
+// Solidity: function setFuses(bytes32 node, uint16 ownerControlledFuses) returns(uint32)
+func (_NameWrapper *NameWrapperTransactor) SetFuses(opts *bind.TransactOpts, node [32]byte, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "setFuses", node, ownerControlledFuses)
+}
+
+// SetFuses is a paid mutator transaction binding the contract method 0x402906fc.
+//
+// Solidity: function setFuses(bytes32 node, uint16 ownerControlledFuses) returns(uint32)
+func (_NameWrapper *NameWrapperSession) SetFuses(node [32]byte, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetFuses(&_NameWrapper.TransactOpts, node, ownerControlledFuses)
+}
+
+// SetFuses is a paid mutator transaction binding the contract method 0x402906fc.
+//
+// Solidity: function setFuses(bytes32 node, uint16 ownerControlledFuses) returns(uint32)
+func (_NameWrapper *NameWrapperTransactorSession) SetFuses(node [32]byte, ownerControlledFuses uint16) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetFuses(&_NameWrapper.TransactOpts, node, ownerControlledFuses)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_NameWrapper *NameWrapperTransactor) SetResolver(opts *bind.TransactOpts, node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "setResolver", node, resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_NameWrapper *NameWrapperSession) SetResolver(node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetResolver(&_NameWrapper.TransactOpts, node, resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_NameWrapper *NameWrapperTransactorSession) SetResolver(node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetResolver(&_NameWrapper.TransactOpts, node, resolver)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0xc658e086.
+//
+// Solidity: function setSubnodeOwner(bytes32 parentNode, string label, address owner, uint32 fuses, uint64 expiry) returns(bytes32 node)
+func (_NameWrapper *NameWrapperTransactor) SetSubnodeOwner(opts *bind.TransactOpts, parentNode [32]byte, label string, owner common.Address, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "setSubnodeOwner", parentNode, label, owner, fuses, expiry)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0xc658e086.
+//
+// Solidity: function setSubnodeOwner(bytes32 parentNode, string label, address owner, uint32 fuses, uint64 expiry) returns(bytes32 node)
+func (_NameWrapper *NameWrapperSession) SetSubnodeOwner(parentNode [32]byte, label string, owner common.Address, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetSubnodeOwner(&_NameWrapper.TransactOpts, parentNode, label, owner, fuses, expiry)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0xc658e086.
+//
+// Solidity: function setSubnodeOwner(bytes32 parentNode, string label, address owner, uint32 fuses, uint64 expiry) returns(bytes32 node)
+func (_NameWrapper *NameWrapperTransactorSession) SetSubnodeOwner(parentNode [32]byte, label string, owner common.Address, fuses uint32, expiry uint64) (*types.Transaction, error) {
+	return _NameWrapper.Contract.SetSubnodeOwner(&_NameWrapper.TransactOpts, parentNode, label, owner, fuses, expiry)
+}
+
+// Unwrap is a paid mutator transaction binding the contract method 0xd8c9921a.
+//
+// Solidity: function unwrap(bytes32 parentNode, bytes32 labelhash, address controller) returns()
+func (_NameWrapper *NameWrapperTransactor) Unwrap(opts *bind.TransactOpts, parentNode [32]byte, labelhash [32]byte, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "unwrap", parentNode, labelhash, controller)
+}
+
+// Unwrap is a paid mutator transaction binding the contract method 0xd8c9921a.
+//
+// Solidity: function unwrap(bytes32 parentNode, bytes32 labelhash, address controller) returns()
+func (_NameWrapper *NameWrapperSession) Unwrap(parentNode [32]byte, labelhash [32]byte, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.Unwrap(&_NameWrapper.TransactOpts, parentNode, labelhash, controller)
+}
+
+// Unwrap is a paid mutator transaction binding the contract method 0xd8c9921a.
+//
+// Solidity: function unwrap(bytes32 parentNode, bytes32 labelhash, address controller) returns()
+func (_NameWrapper *NameWrapperTransactorSession) Unwrap(parentNode [32]byte, labelhash [32]byte, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.Unwrap(&_NameWrapper.TransactOpts, parentNode, labelhash, controller)
+}
+
+// UnwrapETH2LD is a paid mutator transaction binding the contract method 0x8b4dfa75.
+//
+// Solidity: function unwrapETH2LD(bytes32 labelhash, address registrant, address controller) returns()
+func (_NameWrapper *NameWrapperTransactor) UnwrapETH2LD(opts *bind.TransactOpts, labelhash [32]byte, registrant common.Address, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "unwrapETH2LD", labelhash, registrant, controller)
+}
+
+// UnwrapETH2LD is a paid mutator transaction binding the contract method 0x8b4dfa75.
+//
+// Solidity: function unwrapETH2LD(bytes32 labelhash, address registrant, address controller) returns()
+func (_NameWrapper *NameWrapperSession) UnwrapETH2LD(labelhash [32]byte, registrant common.Address, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.UnwrapETH2LD(&_NameWrapper.TransactOpts, labelhash, registrant, controller)
+}
+
+// UnwrapETH2LD is a paid mutator transaction binding the contract method 0x8b4dfa75.
+//
+// Solidity: function unwrapETH2LD(bytes32 labelhash, address registrant, address controller) returns()
+func (_NameWrapper *NameWrapperTransactorSession) UnwrapETH2LD(labelhash [32]byte, registrant common.Address, controller common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.UnwrapETH2LD(&_NameWrapper.TransactOpts, labelhash, registrant, controller)
+}
+
+// Wrap is a paid mutator transaction binding the contract method 0xeb8ae530.
+//
+// Solidity: function wrap(bytes name, address wrappedOwner, address resolver) returns()
+func (_NameWrapper *NameWrapperTransactor) Wrap(opts *bind.TransactOpts, name []byte, wrappedOwner common.Address, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "wrap", name, wrappedOwner, resolver)
+}
+
+// Wrap is a paid mutator transaction binding the contract method 0xeb8ae530.
+//
+// Solidity: function wrap(bytes name, address wrappedOwner, address resolver) returns()
+func (_NameWrapper *NameWrapperSession) Wrap(name []byte, wrappedOwner common.Address, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.Wrap(&_NameWrapper.TransactOpts, name, wrappedOwner, resolver)
+}
+
+// Wrap is a paid mutator transaction binding the contract method 0xeb8ae530.
+//
+// Solidity: function wrap(bytes name, address wrappedOwner, address resolver) returns()
+func (_NameWrapper *NameWrapperTransactorSession) Wrap(name []byte, wrappedOwner common.Address, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.Wrap(&_NameWrapper.TransactOpts, name, wrappedOwner, resolver)
+}
+
+// WrapETH2LD is a paid mutator transaction binding the contract method 0x8cf8b41e.
+//
+// Solidity: function wrapETH2LD(string label, address wrappedOwner, uint16 ownerControlledFuses, address resolver) returns(uint64 expiry)
+func (_NameWrapper *NameWrapperTransactor) WrapETH2LD(opts *bind.TransactOpts, label string, wrappedOwner common.Address, ownerControlledFuses uint16, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.contract.Transact(opts, "wrapETH2LD", label, wrappedOwner, ownerControlledFuses, resolver)
+}
+
+// WrapETH2LD is a paid mutator transaction binding the contract method 0x8cf8b41e.
+//
+// Solidity: function wrapETH2LD(string label, address wrappedOwner, uint16 ownerControlledFuses, address resolver) returns(uint64 expiry)
+func (_NameWrapper *NameWrapperSession) WrapETH2LD(label string, wrappedOwner common.Address, ownerControlledFuses uint16, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.WrapETH2LD(&_NameWrapper.TransactOpts, label, wrappedOwner, ownerControlledFuses, resolver)
+}
+
+// WrapETH2LD is a paid mutator transaction binding the contract method 0x8cf8b41e.
+//
+// Solidity: function wrapETH2LD(string label, address wrappedOwner, uint16 ownerControlledFuses, address resolver) returns(uint64 expiry)
+func (_NameWrapper *NameWrapperTransactorSession) WrapETH2LD(label string, wrappedOwner common.Address, ownerControlledFuses uint16, resolver common.Address) (*types.Transaction, error) {
+	return _NameWrapper.Contract.WrapETH2LD(&_NameWrapper.TransactOpts, label, wrappedOwner, ownerControlledFuses, resolver)
+}
@@ -0,0 +1,500 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// TypesOutputRootProof is an auto generated low-level Go binding around an user-defined struct.
+type TypesOutputRootProof struct {
+	Version                  [32]byte
+	StateRoot                [32]byte
+	MessagePasserStorageRoot [32]byte
+	LatestBlockhash          [32]byte
+}
+
+// TypesWithdrawalTransaction is an auto generated low-level Go binding around an user-defined struct.
+type TypesWithdrawalTransaction struct {
+	Nonce    *big.Int
+	Sender   common.Address
+	Target   common.Address
+	Value    *big.Int
+	GasLimit *big.Int
+	Data     []byte
+}
+
+// OptimismPortal2MetaData contains all meta data concerning the OptimismPortal2 contract.
+var OptimismPortal2MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"proveWithdrawalTransaction\",\"inputs\":[{\"name\":\"_tx\",\"type\":\"tuple\",\"internalType\":\"structTypes.WithdrawalTransaction\",\"components\":[{\"name\":\"nonce\",\"type\":\"uint256\"},{\"name\":\"sender\",\"type\":\"address\"},{\"name\":\"target\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\"}]},{\"name\":\"_disputeGameIndex\",\"type\":\"uint256\"},{\"name\":\"_outputRootProof\",\"type\":\"tuple\",\"internalType\":\"structTypes.OutputRootProof\",\"components\":[{\"name\":\"version\",\"type\":\"bytes32\"},{\"name\":\"stateRoot\",\"type\":\"bytes32\"},{\"name\":\"messagePasserStorageRoot\",\"type\":\"bytes32\"},{\"name\":\"latestBlockhash\",\"type\":\"bytes32\"}]},{\"name\":\"_withdrawalProof\",\"type\":\"bytes[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"finalizeWithdrawalTransaction\",\"inputs\":[{\"name\":\"_tx\",\"type\":\"tuple\",\"internalType\":\"structTypes.WithdrawalTransaction\",\"components\":[{\"name\":\"nonce\",\"type\":\"uint256\"},{\"name\":\"sender\",\"type\":\"address\"},{\"name\":\"target\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"gasLimit\",\"type\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\"}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"finalizedWithdrawals\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"provenWithdrawals\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\"},{\"name\":\"\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"disputeGameProxy\",\"type\":\"address\"},{\"name\":\"timestamp\",\"type\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"numProofSubmitters\",\"inputs\":[{\"name\":\"_withdrawalHash\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"proofSubmitters\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\"},{\"name\":\"\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"checkWithdrawal\",\"inputs\":[{\"name\":\"_withdrawalHash\",\"type\":\"bytes32\"},{\"name\":\"_proofSubmitter\",\"type\":\"address\"}],\"outputs\":[],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"disputeGameFactory\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"respectedGameType\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"proofMaturityDelaySeconds\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\"}]",
+}
+
+// OptimismPortal2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use OptimismPortal2MetaData.ABI instead.
+var OptimismPortal2ABI = OptimismPortal2MetaData.ABI
+
+// OptimismPortal2 is an auto generated Go binding around an Ethereum contract.
+type OptimismPortal2 struct {
+	OptimismPortal2Caller     // Read-only binding to the contract
+	OptimismPortal2Transactor // Write-only binding to the contract
+	OptimismPortal2Filterer   // Log filterer for contract events
+}
+
+// OptimismPortal2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type OptimismPortal2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OptimismPortal2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type OptimismPortal2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OptimismPortal2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type OptimismPortal2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OptimismPortal2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type OptimismPortal2Session struct {
+	Contract     *OptimismPortal2  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// OptimismPortal2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type OptimismPortal2CallerSession struct {
+	Contract *OptimismPortal2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// OptimismPortal2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type OptimismPortal2TransactorSession struct {
+	Contract     *OptimismPortal2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// OptimismPortal2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type OptimismPortal2Raw struct {
+	Contract *OptimismPortal2 // Generic contract binding to access the raw methods on
+}
+
+// OptimismPortal2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type OptimismPortal2CallerRaw struct {
+	Contract *OptimismPortal2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// OptimismPortal2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type OptimismPortal2TransactorRaw struct {
+	Contract *OptimismPortal2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewOptimismPortal2 creates a new instance of OptimismPortal2, bound to a specific deployed contract.
+func NewOptimismPortal2(address common.Address, backend bind.ContractBackend) (*OptimismPortal2, error) {
+	contract, err := bindOptimismPortal2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &OptimismPortal2{OptimismPortal2Caller: OptimismPortal2Caller{contract: contract}, OptimismPortal2Transactor: OptimismPortal2Transactor{contract: contract}, OptimismPortal2Filterer: OptimismPortal2Filterer{contract: contract}}, nil
+}
+
+// NewOptimismPortal2Caller creates a new read-only instance of OptimismPortal2, bound to a specific deployed contract.
+func NewOptimismPortal2Caller(address common.Address, caller bind.ContractCaller) (*OptimismPortal2Caller, error) {
+	contract, err := bindOptimismPortal2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OptimismPortal2Caller{contract: contract}, nil
+}
+
+// NewOptimismPortal2Transactor creates a new write-only instance of OptimismPortal2, bound to a specific deployed contract.
+func NewOptimismPortal2Transactor(address common.Address, transactor bind.ContractTransactor) (*OptimismPortal2Transactor, error) {
+	contract, err := bindOptimismPortal2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OptimismPortal2Transactor{contract: contract}, nil
+}
+
+// NewOptimismPortal2Filterer creates a new log filterer instance of OptimismPortal2, bound to a specific deployed contract.
+func NewOptimismPortal2Filterer(address common.Address, filterer bind.ContractFilterer) (*OptimismPortal2Filterer, error) {
+	contract, err := bindOptimismPortal2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &OptimismPortal2Filterer{contract: contract}, nil
+}
+
+// bindOptimismPortal2 binds a generic wrapper to an already deployed contract.
+func bindOptimismPortal2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(OptimismPortal2ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_OptimismPortal2 *OptimismPortal2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _OptimismPortal2.Contract.OptimismPortal2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_OptimismPortal2 *OptimismPortal2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.OptimismPortal2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_OptimismPortal2 *OptimismPortal2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.OptimismPortal2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_OptimismPortal2 *OptimismPortal2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _OptimismPortal2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_OptimismPortal2 *OptimismPortal2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_OptimismPortal2 *OptimismPortal2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.contract.Transact(opts, method, params...)
+}
+
+// CheckWithdrawal is a free data retrieval call binding the contract method 0x71c1566e.
+//
+// Solidity: function checkWithdrawal(bytes32 _withdrawalHash, address _proofSubmitter) view returns()
+func (_OptimismPortal2 *OptimismPortal2Caller) CheckWithdrawal(opts *bind.CallOpts, _withdrawalHash [32]byte, _proofSubmitter common.Address) error {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "checkWithdrawal", _withdrawalHash, _proofSubmitter)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// CheckWithdrawal is a free data retrieval call binding the contract method 0x71c1566e.
+//
+// Solidity: function checkWithdrawal(bytes32 _withdrawalHash, address _proofSubmitter) view returns()
+func (_OptimismPortal2 *OptimismPortal2Session) CheckWithdrawal(_withdrawalHash [32]byte, _proofSubmitter common.Address) error {
+	return _OptimismPortal2.Contract.CheckWithdrawal(&_OptimismPortal2.CallOpts, _withdrawalHash, _proofSubmitter)
+}
+
+// CheckWithdrawal is a free data retrieval call binding the contract method 0x71c1566e.
+//
+// Solidity: function checkWithdrawal(bytes32 _withdrawalHash, address _proofSubmitter) view returns()
+func (_OptimismPortal2 *OptimismPortal2CallerSession) CheckWithdrawal(_withdrawalHash [32]byte, _proofSubmitter common.Address) error {
+	return _OptimismPortal2.Contract.CheckWithdrawal(&_OptimismPortal2.CallOpts, _withdrawalHash, _proofSubmitter)
+}
+
+// DisputeGameFactory is a free data retrieval call binding the contract method 0xf2b4e617.
+//
+// Solidity: function disputeGameFactory() view returns(address)
+func (_OptimismPortal2 *OptimismPortal2Caller) DisputeGameFactory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "disputeGameFactory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// DisputeGameFactory is a free data retrieval call binding the contract method 0xf2b4e617.
+//
+// Solidity: function disputeGameFactory() view returns(address)
+func (_OptimismPortal2 *OptimismPortal2Session) DisputeGameFactory() (common.Address, error) {
+	return _OptimismPortal2.Contract.DisputeGameFactory(&_OptimismPortal2.CallOpts)
+}
+
+// DisputeGameFactory is a free data retrieval call binding the contract method 0xf2b4e617.
+//
+// Solidity: function disputeGameFactory() view returns(address)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) DisputeGameFactory() (common.Address, error) {
+	return _OptimismPortal2.Contract.DisputeGameFactory(&_OptimismPortal2.CallOpts)
+}
+
+// FinalizedWithdrawals is a free data retrieval call binding the contract method 0xa14238e7.
+//
+// Solidity: function finalizedWithdrawals(bytes32 ) view returns(bool)
+func (_OptimismPortal2 *OptimismPortal2Caller) FinalizedWithdrawals(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "finalizedWithdrawals", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// FinalizedWithdrawals is a free data retrieval call binding the contract method 0xa14238e7.
+//
+// Solidity: function finalizedWithdrawals(bytes32 ) view returns(bool)
+func (_OptimismPortal2 *OptimismPortal2Session) FinalizedWithdrawals(arg0 [32]byte) (bool, error) {
+	return _OptimismPortal2.Contract.FinalizedWithdrawals(&_OptimismPortal2.CallOpts, arg0)
+}
+
+// FinalizedWithdrawals is a free data retrieval call binding the contract method 0xa14238e7.
+//
+// Solidity: function finalizedWithdrawals(bytes32 ) view returns(bool)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) FinalizedWithdrawals(arg0 [32]byte) (bool, error) {
+	return _OptimismPortal2.Contract.FinalizedWithdrawals(&_OptimismPortal2.CallOpts, arg0)
+}
+
+// NumProofSubmitters is a free data retrieval call binding the contract method 0x513747ab.
+//
+// Solidity: function numProofSubmitters(bytes32 _withdrawalHash) view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2Caller) NumProofSubmitters(opts *bind.CallOpts, _withdrawalHash [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "numProofSubmitters", _withdrawalHash)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NumProofSubmitters is a free data retrieval call binding the contract method 0x513747ab.
+//
+// Solidity: function numProofSubmitters(bytes32 _withdrawalHash) view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2Session) NumProofSubmitters(_withdrawalHash [32]byte) (*big.Int, error) {
+	return _OptimismPortal2.Contract.NumProofSubmitters(&_OptimismPortal2.CallOpts, _withdrawalHash)
+}
+
+// NumProofSubmitters is a free data retrieval call binding the contract method 0x513747ab.
+//
+// Solidity: function numProofSubmitters(bytes32 _withdrawalHash) view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) NumProofSubmitters(_withdrawalHash [32]byte) (*big.Int, error) {
+	return _OptimismPortal2.Contract.NumProofSubmitters(&_OptimismPortal2.CallOpts, _withdrawalHash)
+}
+
+// ProofMaturityDelaySeconds is a free data retrieval call binding the contract method 0xbf653a5c.
+//
+// Solidity: function proofMaturityDelaySeconds() view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2Caller) ProofMaturityDelaySeconds(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "proofMaturityDelaySeconds")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProofMaturityDelaySeconds is a free data retrieval call binding the contract method 0xbf653a5c.
+//
+// Solidity: function proofMaturityDelaySeconds() view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2Session) ProofMaturityDelaySeconds() (*big.Int, error) {
+	return _OptimismPortal2.Contract.ProofMaturityDelaySeconds(&_OptimismPortal2.CallOpts)
+}
+
+// ProofMaturityDelaySeconds is a free data retrieval call binding the contract method 0xbf653a5c.
+//
+// Solidity: function proofMaturityDelaySeconds() view returns(uint256)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) ProofMaturityDelaySeconds() (*big.Int, error) {
+	return _OptimismPortal2.Contract.ProofMaturityDelaySeconds(&_OptimismPortal2.CallOpts)
+}
+
+// ProofSubmitters is a free data retrieval call binding the contract method 0xa3860f48.
+//
+// Solidity: function proofSubmitters(bytes32 , uint256 ) view returns(address)
+func (_OptimismPortal2 *OptimismPortal2Caller) ProofSubmitters(opts *bind.CallOpts, arg0 [32]byte, arg1 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "proofSubmitters", arg0, arg1)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ProofSubmitters is a free data retrieval call binding the contract method 0xa3860f48.
+//
+// Solidity: function proofSubmitters(bytes32 , uint256 ) view returns(address)
+func (_OptimismPortal2 *OptimismPortal2Session) ProofSubmitters(arg0 [32]byte, arg1 *big.Int) (common.Address, error) {
+	return _OptimismPortal2.Contract.ProofSubmitters(&_OptimismPortal2.CallOpts, arg0, arg1)
+}
+
+// ProofSubmitters is a free data retrieval call binding the contract method 0xa3860f48.
+//
+// Solidity: function proofSubmitters(bytes32 , uint256 ) view returns(address)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) ProofSubmitters(arg0 [32]byte, arg1 *big.Int) (common.Address, error) {
+	return _OptimismPortal2.Contract.ProofSubmitters(&_OptimismPortal2.CallOpts, arg0, arg1)
+}
+
+// ProvenWithdrawals is a free data retrieval call binding the contract method 0xbb2c727e.
+//
+// Solidity: function provenWithdrawals(bytes32 , address ) view returns(address disputeGameProxy, uint64 timestamp)
+func (_OptimismPortal2 *OptimismPortal2Caller) ProvenWithdrawals(opts *bind.CallOpts, arg0 [32]byte, arg1 common.Address) (struct {
+	DisputeGameProxy common.Address
+	Timestamp        uint64
+}, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "provenWithdrawals", arg0, arg1)
+
+	outstruct := new(struct {
+		DisputeGameProxy common.Address
+		Timestamp        uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.DisputeGameProxy = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Timestamp = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+
+	return *outstruct, err
+
+}
+
+// ProvenWithdrawals is a free data retrieval call binding the contract method 0xbb2c727e.
+//
+// Solidity: function provenWithdrawals(bytes32 , address ) view returns(address disputeGameProxy, uint64 timestamp)
+func (_OptimismPortal2 *OptimismPortal2Session) ProvenWithdrawals(arg0 [32]byte, arg1 common.Address) (struct {
+	DisputeGameProxy common.Address
+	Timestamp        uint64
+}, error) {
+	return _OptimismPortal2.Contract.ProvenWithdrawals(&_OptimismPortal2.CallOpts, arg0, arg1)
+}
+
+// ProvenWithdrawals is a free data retrieval call binding the contract method 0xbb2c727e.
+//
+// Solidity: function provenWithdrawals(bytes32 , address ) view returns(address disputeGameProxy, uint64 timestamp)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) ProvenWithdrawals(arg0 [32]byte, arg1 common.Address) (struct {
+	DisputeGameProxy common.Address
+	Timestamp        uint64
+}, error) {
+	return _OptimismPortal2.Contract.ProvenWithdrawals(&_OptimismPortal2.CallOpts, arg0, arg1)
+}
+
+// RespectedGameType is a free data retrieval call binding the contract method 0x3c9f397c.
+//
+// Solidity: function respectedGameType() view returns(uint32)
+func (_OptimismPortal2 *OptimismPortal2Caller) RespectedGameType(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _OptimismPortal2.contract.Call(opts, &out, "respectedGameType")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// RespectedGameType is a free data retrieval call binding the contract method 0x3c9f397c.
+//
+// Solidity: function respectedGameType() view returns(uint32)
+func (_OptimismPortal2 *OptimismPortal2Session) RespectedGameType() (uint32, error) {
+	return _OptimismPortal2.Contract.RespectedGameType(&_OptimismPortal2.CallOpts)
+}
+
+// RespectedGameType is a free data retrieval call binding the contract method 0x3c9f397c.
+//
+// Solidity: function respectedGameType() view returns(uint32)
+func (_OptimismPortal2 *OptimismPortal2CallerSession) RespectedGameType() (uint32, error) {
+	return _OptimismPortal2.Contract.RespectedGameType(&_OptimismPortal2.CallOpts)
+}
+
+// FinalizeWithdrawalTransaction is a paid mutator transaction binding the contract method 0x8c3152e9.
+//
+// Solidity: function finalizeWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx) returns()
+func (_OptimismPortal2 *OptimismPortal2Transactor) FinalizeWithdrawalTransaction(opts *bind.TransactOpts, _tx TypesWithdrawalTransaction) (*types.Transaction, error) {
+	return _OptimismPortal2.contract.Transact(opts, "finalizeWithdrawalTransaction", _tx)
+}
+
+// FinalizeWithdrawalTransaction is a paid mutator transaction binding the contract method 0x8c3152e9.
+//
+// Solidity: function finalizeWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx) returns()
+func (_OptimismPortal2 *OptimismPortal2Session) FinalizeWithdrawalTransaction(_tx TypesWithdrawalTransaction) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.FinalizeWithdrawalTransaction(&_OptimismPortal2.TransactOpts, _tx)
+}
+
+// FinalizeWithdrawalTransaction is a paid mutator transaction binding the contract method 0x8c3152e9.
+//
+// Solidity: function finalizeWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx) returns()
+func (_OptimismPortal2 *OptimismPortal2TransactorSession) FinalizeWithdrawalTransaction(_tx TypesWithdrawalTransaction) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.FinalizeWithdrawalTransaction(&_OptimismPortal2.TransactOpts, _tx)
+}
+
+// ProveWithdrawalTransaction is a paid mutator transaction binding the contract method 0x4870496f.
+//
+// Solidity: function proveWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx, uint256 _disputeGameIndex, (bytes32,bytes32,bytes32,bytes32) _outputRootProof, bytes[] _withdrawalProof) returns()
+func (_OptimismPortal2 *OptimismPortal2Transactor) ProveWithdrawalTransaction(opts *bind.TransactOpts, _tx TypesWithdrawalTransaction, _disputeGameIndex *big.Int, _outputRootProof TypesOutputRootProof, _withdrawalProof [][]byte) (*types.Transaction, error) {
+	return _OptimismPortal2.contract.Transact(opts, "proveWithdrawalTransaction", _tx, _disputeGameIndex, _outputRootProof, _withdrawalProof)
+}
+
+// ProveWithdrawalTransaction is a paid mutator transaction binding the contract method 0x4870496f.
+//
+// Solidity: function proveWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx, uint256 _disputeGameIndex, (bytes32,bytes32,bytes32,bytes32) _outputRootProof, bytes[] _withdrawalProof) returns()
+func (_OptimismPortal2 *OptimismPortal2Session) ProveWithdrawalTransaction(_tx TypesWithdrawalTransaction, _disputeGameIndex *big.Int, _outputRootProof TypesOutputRootProof, _withdrawalProof [][]byte) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.ProveWithdrawalTransaction(&_OptimismPortal2.TransactOpts, _tx, _disputeGameIndex, _outputRootProof, _withdrawalProof)
+}
+
+// ProveWithdrawalTransaction is a paid mutator transaction binding the contract method 0x4870496f.
+//
+// Solidity: function proveWithdrawalTransaction((uint256,address,address,uint256,uint256,bytes) _tx, uint256 _disputeGameIndex, (bytes32,bytes32,bytes32,bytes32) _outputRootProof, bytes[] _withdrawalProof) returns()
+func (_OptimismPortal2 *OptimismPortal2TransactorSession) ProveWithdrawalTransaction(_tx TypesWithdrawalTransaction, _disputeGameIndex *big.Int, _outputRootProof TypesOutputRootProof, _withdrawalProof [][]byte) (*types.Transaction, error) {
+	return _OptimismPortal2.Contract.ProveWithdrawalTransaction(&_OptimismPortal2.TransactOpts, _tx, _disputeGameIndex, _outputRootProof, _withdrawalProof)
+}
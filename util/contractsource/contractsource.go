@@ -0,0 +1,109 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package contractsource obtains the verified source of contracts from online services.
+package contractsource
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+	"github.com/wealdtech/ethereal/v2/util/explorer"
+)
+
+// ErrNotFound is returned when the provider does not have verified source for the contract.
+var ErrNotFound = errors.New("contract source not found")
+
+// Contract is the verified source and metadata of a contract.
+type Contract struct {
+	Name            string
+	CompilerVersion string
+	Optimized       bool
+	Runs            uint64
+	EVMVersion      string
+	License         string
+	ABI             string
+	// ConstructorArguments are the ABI-encoded arguments passed to the constructor, if known.
+	ConstructorArguments []byte
+	// Match is the type of match, for providers that distinguish between them.
+	Match string
+	// Implementation is the implementation contract if the contract is a proxy.
+	Implementation *common.Address
+	// Creator is the account that created the contract, if known.
+	Creator *common.Address
+	// CreationTransaction is the transaction that created the contract, if known.
+	CreationTransaction *common.Hash
+	// Files are the source files of the contract, keyed by path.
+	Files map[string]string
+}
+
+// Provider is a provider of verified contract source.
+type Provider interface {
+	// Contract returns the verified source of the contract at the given address on the given chain.
+	Contract(ctx context.Context, chainID *big.Int, address common.Address) (*Contract, error)
+}
+
+// New creates a provider given its name.  The key is the API key, used by Etherscan, and the URL
+// overrides the default endpoint of the provider; it is required for Blockscout.
+func New(name string, key string, url string) (Provider, error) {
+	etherscanClient, sourcifyClient, err := explorer.Clients(name, key, url)
+	if errors.Is(err, explorer.ErrUnknown) {
+		return nil, fmt.Errorf("unknown source provider %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sourcifyClient != nil {
+		return &Sourcify{client: sourcifyClient}, nil
+	}
+	return &Etherscan{client: etherscanClient}, nil
+}
+
+// Etherscan obtains contract source from Etherscan, or any service with a compatible API such as
+// Blockscout.
+type Etherscan struct {
+	client *etherscan.Client
+}
+
+// Contract returns the verified source of the contract at the given address on the given chain.
+func (p *Etherscan) Contract(ctx context.Context, chainID *big.Int, address common.Address) (*Contract, error) {
+	source, err := p.client.Source(ctx, chainID, address)
+	if errors.Is(err, etherscan.ErrNotVerified) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	contract := &Contract{
+		Name:                 source.Name,
+		CompilerVersion:      source.CompilerVersion,
+		Optimized:            source.Optimized,
+		Runs:                 source.Runs,
+		EVMVersion:           source.EVMVersion,
+		License:              source.License,
+		ABI:                  source.ABI,
+		ConstructorArguments: source.ConstructorArguments,
+		Implementation:       source.Implementation,
+		Files:                source.Files,
+	}
+	// Contracts created at genesis have no creation transaction, so errors are ignored.
+	if creation, err := p.client.ContractCreation(ctx, chainID, address); err == nil {
+		contract.Creator = &creation.Creator
+		contract.CreationTransaction = &creation.TransactionHash
+	}
+	return contract, nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contractsource
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+func TestEtherscan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("action") == "getcontractcreation":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"contractAddress":"","contractCreator":"0x0000000000000000000000000000000000000001","txHash":"0x1111111111111111111111111111111111111111111111111111111111111111"}]}`)
+		case query.Get("address") == testAddress.Hex():
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"SourceCode":"contract Test {}","ABI":"[]","ContractName":"Test","CompilerVersion":"v0.8.13+commit.abaa5c0e","OptimizationUsed":"1","Runs":"200","EVMVersion":"Default","LicenseType":"MIT","Proxy":"0","Implementation":""}]}`)
+		default:
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"SourceCode":"","ABI":"Contract source code not verified","ContractName":""}]}`)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := New("blockscout", "", server.URL)
+	require.NoError(t, err)
+
+	contract, err := provider.Contract(context.Background(), big.NewInt(1), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, "Test", contract.Name)
+	assert.Equal(t, "v0.8.13+commit.abaa5c0e", contract.CompilerVersion)
+	assert.True(t, contract.Optimized)
+	assert.Equal(t, uint64(200), contract.Runs)
+	assert.Equal(t, map[string]string{"Test.sol": "contract Test {}"}, contract.Files)
+	require.NotNil(t, contract.Creator)
+	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000001"), *contract.Creator)
+	require.NotNil(t, contract.CreationTransaction)
+	assert.Nil(t, contract.Implementation)
+
+	_, err = provider.Contract(context.Background(), big.NewInt(1), common.Address{})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSourcify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/v2/contract/5/%s", testAddress.Hex()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.Equal(t, "all", r.URL.Query().Get("fields"))
+		fmt.Fprint(w, `{"match":"exact_match","sources":{"contracts/Test.sol":{"content":"contract Test {}"},"contracts/Lib.sol":{"content":"library Lib {}"}},"compilation":{"compilerVersion":"0.8.13+commit.abaa5c0e","name":"Test","compilerSettings":{"optimizer":{"enabled":true,"runs":1000},"evmVersion":"london"}},"abi":[],"deployment":{"transactionHash":"0x1111111111111111111111111111111111111111111111111111111111111111","deployer":"0x0000000000000000000000000000000000000001"},"proxyResolution":{"isProxy":true,"implementations":[{"address":"0x0000000000000000000000000000000000000002"}]}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New("sourcify", "", server.URL)
+	require.NoError(t, err)
+
+	contract, err := provider.Contract(context.Background(), big.NewInt(5), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, "Test", contract.Name)
+	assert.Equal(t, "0.8.13+commit.abaa5c0e", contract.CompilerVersion)
+	assert.True(t, contract.Optimized)
+	assert.Equal(t, uint64(1000), contract.Runs)
+	assert.Equal(t, "london", contract.EVMVersion)
+	assert.Equal(t, "exact_match", contract.Match)
+	assert.Equal(t, "[]", contract.ABI)
+	assert.Len(t, contract.Files, 2)
+	assert.Equal(t, "library Lib {}", contract.Files["contracts/Lib.sol"])
+	require.NotNil(t, contract.Creator)
+	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000001"), *contract.Creator)
+	require.NotNil(t, contract.Implementation)
+	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000002"), *contract.Implementation)
+
+	_, err = provider.Contract(context.Background(), big.NewInt(5), common.Address{})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("blockscout", "", "")
+	assert.EqualError(t, err, "blockscout requires a URL")
+	_, err = New("unknown", "", "")
+	assert.EqualError(t, err, "unknown source provider unknown")
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contractsource
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/sourcify"
+)
+
+// Sourcify obtains contract source from Sourcify.
+type Sourcify struct {
+	client *sourcify.Client
+}
+
+// Contract returns the verified source of the contract at the given address on the given chain.
+func (p *Sourcify) Contract(ctx context.Context, chainID *big.Int, address common.Address) (*Contract, error) {
+	contract, err := p.client.Contract(ctx, chainID, address)
+	if errors.Is(err, sourcify.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Contract{
+		Name:                contract.Name,
+		CompilerVersion:     contract.CompilerVersion,
+		Optimized:           contract.Optimized,
+		Runs:                contract.Runs,
+		EVMVersion:          contract.EVMVersion,
+		ABI:                 contract.ABI,
+		Match:               contract.Match,
+		Implementation:      contract.Implementation,
+		Creator:             contract.Creator,
+		CreationTransaction: contract.CreationTransaction,
+		Files:               contract.Files,
+	}, nil
+}
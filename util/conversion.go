@@ -15,9 +15,12 @@
 package util
 
 import (
+	"fmt"
 	"math/big"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Used in TokenValueToString
@@ -56,19 +59,40 @@ func TokenValueToString(input *big.Int, decimals uint8, usePrefix bool) (output
 	return
 }
 
-// StringToTokenValue converts a string to a number of tokens
+// tokenValueRe matches a token value, optionally followed by a unit such as "tokens" or
+// the token's symbol.
+var tokenValueRe = regexp.MustCompile(`^([0-9]*(?:\.[0-9]*)?)(?:\s+[A-Za-z][A-Za-z0-9]*)?$`)
+
+// StringToTokenValue converts a string to a number of tokens.
+// The string can be followed by a unit, for example "1.5 tokens", which is ignored.
 func StringToTokenValue(input string, decimals uint8) (output *big.Int, err error) {
 	output = big.NewInt(0)
+	input = strings.TrimSpace(input)
 	if input == "" {
 		return
 	}
 
+	matches := tokenValueRe.FindStringSubmatch(input)
+	if matches == nil || matches[1] == "" || matches[1] == "." {
+		return nil, fmt.Errorf("invalid token value %q", input)
+	}
+	input = matches[1]
+
 	// Count the number of items after the decimal point
 	parts := strings.Split(input, ".")
 	var additionalZeros int
 	if len(parts) == 2 {
 		// There is a decimal place
 		additionalZeros = int(decimals) - len(parts[1])
+		if additionalZeros < 0 {
+			// Allow trailing zeros beyond the token's precision.
+			if strings.TrimRight(parts[1][decimals:], "0") != "" {
+				return nil, fmt.Errorf("token value %q has more than %d decimal places", input, decimals)
+			}
+			parts[1] = parts[1][:decimals]
+			input = parts[0] + "." + parts[1]
+			additionalZeros = 0
+		}
 	} else {
 		// There is not a decimal place
 		additionalZeros = int(decimals)
@@ -83,3 +107,32 @@ func StringToTokenValue(input string, decimals uint8) (output *big.Int, err erro
 
 	return
 }
+
+var durationRe = regexp.MustCompile(`^([0-9]+)\s*(d|day|days|w|week|weeks|y|year|years)$`)
+
+// StringToDuration converts a string to a duration.  In addition to the units understood by
+// time.ParseDuration it accepts days, weeks and years, where a year is 365 days.
+func StringToDuration(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	match := durationRe.FindStringSubmatch(strings.ToLower(input))
+	if match == nil {
+		duration, err := time.ParseDuration(input)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", input)
+		}
+		return duration, nil
+	}
+	count, err := strconv.ParseInt(match[1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", input)
+	}
+	day := 24 * time.Hour
+	switch match[2][0] {
+	case 'w':
+		return time.Duration(count) * 7 * day, nil
+	case 'y':
+		return time.Duration(count) * 365 * day, nil
+	default:
+		return time.Duration(count) * day, nil
+	}
+}
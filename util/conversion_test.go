@@ -15,6 +15,7 @@ package util
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -77,6 +78,11 @@ func TestStringToTokenValue(t *testing.T) {
 		{"5.000000000000000005", 18, bigInt("5000000000000000005")},
 		{"6.000000000000000006", 18, bigInt("6000000000000000006")},
 		{"777.777", 3, bigInt("777777")},
+		{"1.5 tokens", 18, bigInt("1500000000000000000")},
+		{" 8.25 DAI ", 2, bigInt("825")},
+		{"9.", 1, bigInt("90")},
+		{".5", 1, bigInt("5")},
+		{"1.2000", 2, bigInt("120")},
 	}
 
 	for _, tt := range tests {
@@ -88,3 +94,49 @@ func TestStringToTokenValue(t *testing.T) {
 		}
 	}
 }
+
+func TestStringToTokenValueErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		decimals uint8
+		err      string
+	}{
+		{"abc", 18, `invalid token value "abc"`},
+		{"1.2.3", 18, `invalid token value "1.2.3"`},
+		{"-1", 18, `invalid token value "-1"`},
+		{".", 18, `invalid token value "."`},
+		{"1.5 many tokens", 18, `invalid token value "1.5 many tokens"`},
+		{"1.234", 2, `token value "1.234" has more than 2 decimal places`},
+	}
+
+	for _, tt := range tests {
+		_, err := StringToTokenValue(tt.input, tt.decimals)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestStringToDuration(t *testing.T) {
+	tests := []struct {
+		input  string
+		output time.Duration
+		err    string
+	}{
+		{input: "90m", output: 90 * time.Minute},
+		{input: "28d", output: 28 * 24 * time.Hour},
+		{input: "2 weeks", output: 14 * 24 * time.Hour},
+		{input: "1y", output: 365 * 24 * time.Hour},
+		{input: " 3 Years ", output: 3 * 365 * 24 * time.Hour},
+		{input: "1 month", err: `invalid duration "1 month"`},
+		{input: "", err: `invalid duration ""`},
+	}
+
+	for _, tt := range tests {
+		result, err := StringToDuration(tt.input)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+		} else {
+			assert.Nil(t, err, "Received error")
+			assert.Equal(t, tt.output, result)
+		}
+	}
+}
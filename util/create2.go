@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultCreate2Factory is the address of the deterministic deployment proxy, which is
+// present at the same address on most chains.  It deploys the contract whose init code
+// follows the 32-byte salt in its calldata.
+var DefaultCreate2Factory = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+// ParseSalt parses a CREATE2 salt supplied in hex.  Salts shorter than 32 bytes are
+// left-padded with zeros.
+func ParseSalt(input string) ([32]byte, error) {
+	var salt [32]byte
+	input = strings.TrimPrefix(input, "0x")
+	if len(input)%2 == 1 {
+		input = "0" + input
+	}
+	data, err := hex.DecodeString(input)
+	if err != nil {
+		return salt, fmt.Errorf("invalid salt: %v", err)
+	}
+	if len(data) > len(salt) {
+		return salt, fmt.Errorf("salt is %d bytes; maximum is %d", len(data), len(salt))
+	}
+	copy(salt[len(salt)-len(data):], data)
+	return salt, nil
+}
+
+// Create2Address returns the address of a contract deployed with CREATE2 by the deployer
+// with the given salt and hash of its init code.
+func Create2Address(deployer common.Address, salt [32]byte, initCodeHash []byte) common.Address {
+	return crypto.CreateAddress2(deployer, salt, initCodeHash)
+}
+
+// Create2FactoryData returns the calldata for the deterministic deployment proxy to
+// deploy the given init code with the given salt.
+func Create2FactoryData(salt [32]byte, initCode []byte) []byte {
+	data := make([]byte, 0, len(salt)+len(initCode))
+	data = append(data, salt[:]...)
+	return append(data, initCode...)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSalt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		salt  [32]byte
+		err   string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:  "Short",
+			input: "0x1",
+			salt:  [32]byte{31: 0x01},
+		},
+		{
+			name:  "Full",
+			input: "0xfeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface",
+			salt:  common.HexToHash("0xfeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface"),
+		},
+		{
+			name:  "Invalid",
+			input: "0xzz",
+			err:   "invalid salt: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "Long",
+			input: "0x00feedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface",
+			err:   "salt is 33 bytes; maximum is 32",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			salt, err := ParseSalt(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.salt, salt)
+		})
+	}
+}
+
+func TestCreate2Address(t *testing.T) {
+	// Examples from EIP-1014.
+	tests := []struct {
+		name     string
+		deployer common.Address
+		salt     [32]byte
+		initCode []byte
+		address  common.Address
+	}{
+		{
+			name:     "Example0",
+			initCode: []byte{0x00},
+			address:  common.HexToAddress("0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"),
+		},
+		{
+			name:     "Example1",
+			deployer: common.HexToAddress("0xdeadbeef00000000000000000000000000000000"),
+			initCode: []byte{0x00},
+			address:  common.HexToAddress("0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"),
+		},
+		{
+			name:     "Example5",
+			initCode: []byte{},
+			address:  common.HexToAddress("0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.address, Create2Address(test.deployer, test.salt, crypto.Keccak256(test.initCode)))
+		})
+	}
+}
+
+func TestCreate2FactoryData(t *testing.T) {
+	data := Create2FactoryData([32]byte{31: 0x01}, []byte{0xfe, 0xed})
+	assert.Equal(t, append(append(make([]byte, 31), 0x01), 0xfe, 0xed), data)
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// depositDomainType is the beacon chain domain type for deposits.
+var depositDomainType = []byte{0x03, 0x00, 0x00, 0x00}
+
+// Withdrawal credential prefixes.
+const (
+	// BLSWithdrawalPrefix is the prefix for withdrawal credentials controlled by a BLS key.
+	BLSWithdrawalPrefix = byte(0x00)
+	// ExecutionWithdrawalPrefix is the prefix for withdrawal credentials controlled by an execution address.
+	ExecutionWithdrawalPrefix = byte(0x01)
+	// CompoundingWithdrawalPrefix is the prefix for compounding withdrawal credentials controlled by an execution address.
+	CompoundingWithdrawalPrefix = byte(0x02)
+)
+
+// ValidateWithdrawalCredentials ensures that withdrawal credentials are well-formed.
+func ValidateWithdrawalCredentials(credentials []byte) error {
+	if len(credentials) != 32 {
+		return fmt.Errorf("withdrawal credentials are %d bytes; expected 32", len(credentials))
+	}
+	switch credentials[0] {
+	case BLSWithdrawalPrefix:
+		return nil
+	case ExecutionWithdrawalPrefix, CompoundingWithdrawalPrefix:
+		if !bytes.Equal(credentials[1:12], make([]byte, 11)) {
+			return errors.New("withdrawal credentials for an execution address must have zero padding")
+		}
+		return nil
+	default:
+		return fmt.Errorf("withdrawal credentials have unknown prefix 0x%02x", credentials[0])
+	}
+}
+
+// DepositMessageRoot calculates the SSZ hash tree root of a deposit message.
+func DepositMessageRoot(pubKey []byte, withdrawalCredentials []byte, amount uint64) []byte {
+	return sszHash(
+		sszHash(sszPublicKeyRoot(pubKey), withdrawalCredentials),
+		sszHash(sszUint64(amount), make([]byte, 32)),
+	)
+}
+
+// DepositDataRoot calculates the SSZ hash tree root of deposit data.
+func DepositDataRoot(pubKey []byte, withdrawalCredentials []byte, amount uint64, signature []byte) []byte {
+	return sszHash(
+		sszHash(sszPublicKeyRoot(pubKey), withdrawalCredentials),
+		sszHash(sszUint64(amount), sszSignatureRoot(signature)),
+	)
+}
+
+// DepositDomain calculates the signing domain for deposits with the given fork version.
+// Deposits are valid across forks, so the genesis validators root is always zero.
+func DepositDomain(forkVersion []byte) []byte {
+	forkData := make([]byte, 32)
+	copy(forkData, forkVersion)
+	forkDataRoot := sszHash(forkData, make([]byte, 32))
+	return append(append([]byte{}, depositDomainType...), forkDataRoot[:28]...)
+}
+
+// VerifyDeposit ensures that a deposit is well-formed, its roots match its contents, and
+// its signature is valid for the given fork version.
+func VerifyDeposit(deposit *DepositInfo, forkVersion []byte) error {
+	if len(deposit.PublicKey) != 48 {
+		return fmt.Errorf("public key is %d bytes; expected 48", len(deposit.PublicKey))
+	}
+	if len(deposit.Signature) != 96 {
+		return fmt.Errorf("signature is %d bytes; expected 96", len(deposit.Signature))
+	}
+	if len(forkVersion) != 4 {
+		return fmt.Errorf("fork version is %d bytes; expected 4", len(forkVersion))
+	}
+	if err := ValidateWithdrawalCredentials(deposit.WithdrawalCredentials); err != nil {
+		return err
+	}
+
+	messageRoot := DepositMessageRoot(deposit.PublicKey, deposit.WithdrawalCredentials, deposit.Amount)
+	if len(deposit.DepositMessageRoot) > 0 && !bytes.Equal(deposit.DepositMessageRoot, messageRoot) {
+		return errors.New("deposit message root does not match deposit contents")
+	}
+	if !bytes.Equal(deposit.DepositDataRoot, DepositDataRoot(deposit.PublicKey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature)) {
+		return errors.New("deposit data root does not match deposit contents")
+	}
+
+	signingRoot := sszHash(messageRoot, DepositDomain(forkVersion))
+	if err := BLSVerify(deposit.PublicKey, signingRoot, deposit.Signature); err != nil {
+		return errors.Wrap(err, "invalid deposit signature")
+	}
+	return nil
+}
+
+// sszHash hashes two 32-byte chunks together.
+func sszHash(a []byte, b []byte) []byte {
+	h := sha256.New()
+	h.Write(a)
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// sszPublicKeyRoot calculates the hash tree root of a 48-byte public key.
+func sszPublicKeyRoot(pubKey []byte) []byte {
+	chunks := make([]byte, 64)
+	copy(chunks, pubKey)
+	return sszHash(chunks[:32], chunks[32:])
+}
+
+// sszSignatureRoot calculates the hash tree root of a 96-byte signature.
+func sszSignatureRoot(signature []byte) []byte {
+	chunks := make([]byte, 128)
+	copy(chunks, signature)
+	return sszHash(sszHash(chunks[:32], chunks[32:64]), sszHash(chunks[64:96], chunks[96:]))
+}
+
+// sszUint64 returns the chunk for a uint64.
+func sszUint64(val uint64) []byte {
+	chunk := make([]byte, 32)
+	binary.LittleEndian.PutUint64(chunk, val)
+	return chunk
+}
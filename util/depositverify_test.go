@@ -0,0 +1,192 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+func TestValidateWithdrawalCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials []byte
+		err         string
+	}{
+		{
+			name:        "Short",
+			credentials: common.FromHex("0070f9cba5c36591736e62d2a4c32bdfdecb92ea586e9cdb89d95788ce7f49"),
+			err:         "withdrawal credentials are 31 bytes; expected 32",
+		},
+		{
+			name:        "UnknownPrefix",
+			credentials: common.FromHex("0370f9cba5c36591736e62d2a4c32bdfdecb92ea586e9cdb89d95788ce7f4975"),
+			err:         "withdrawal credentials have unknown prefix 0x03",
+		},
+		{
+			name:        "ExecutionBadPadding",
+			credentials: common.FromHex("0100000000000000000000010000000000000000000000000000000000000001"),
+			err:         "withdrawal credentials for an execution address must have zero padding",
+		},
+		{
+			name:        "BLS",
+			credentials: common.FromHex("0070f9cba5c36591736e62d2a4c32bdfdecb92ea586e9cdb89d95788ce7f4975"),
+		},
+		{
+			name:        "Execution",
+			credentials: common.FromHex("0100000000000000000000000000000000000000000000000000000000000001"),
+		},
+		{
+			name:        "Compounding",
+			credentials: common.FromHex("0200000000000000000000000000000000000000000000000000000000000001"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.ValidateWithdrawalCredentials(test.credentials)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestVerifyDeposit(t *testing.T) {
+	deposit := func() *util.DepositInfo {
+		return &util.DepositInfo{
+			PublicKey:             common.FromHex("aad67d87ddeb2801860c135a67dc3fecdf77ed9a41da6afe7c8a5232354713bdc6d437cbe0014f3482f2a17e048e30a4"),
+			WithdrawalCredentials: common.FromHex("0070f9cba5c36591736e62d2a4c32bdfdecb92ea586e9cdb89d95788ce7f4975"),
+			Amount:                32000000000,
+			Signature:             common.FromHex("a8e83f7a0c36a4aa45906aa45039e39212b9cbd3916550adaeac488a847e216ab8cf1d9360608dd0a092b4a1ced05f2c05b5d8406c40410933ee6ccecff4e31eac088383a815b6cd8d17fa0d87586a0f9fe9f01a4d7bb9aa591851baff1dae13"),
+			DepositMessageRoot:    common.FromHex("b082661eaebf92daf5f0b08728832305cc309467642354508206cd4f09150a1a"),
+			DepositDataRoot:       common.FromHex("2c880f13079bbae7ad9a15bad96a309730a032c497f427cb271e3435947dc646"),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deposit     func() *util.DepositInfo
+		forkVersion []byte
+		err         string
+	}{
+		{
+			name:        "Good",
+			deposit:     deposit,
+			forkVersion: common.FromHex("00000113"),
+		},
+		{
+			name: "GoodSecond",
+			deposit: func() *util.DepositInfo {
+				return &util.DepositInfo{
+					PublicKey:             common.FromHex("94c270cb9c846a6da5619c100c674a70c986b022f0f653ca35b4c2ad849a2a6d47143a9da38e256b9578f622f8e8b851"),
+					WithdrawalCredentials: common.FromHex("0063f9c5c728f07a4490b3ad125d4939655fb07517602939ba181197bc525a62"),
+					Amount:                32000000000,
+					Signature:             common.FromHex("a981d6979692b6c2d6282de0fbf08e15485d1daa471d0a21682d3284916ee9d9defacc851a49be7e17f0d74a2ae196990536f568effff25d38fe28282b7d25e6ecf562cff5246cd023e0b42168126043a80c47a1f6a7089d406430d868c8c580"),
+					DepositMessageRoot:    common.FromHex("693e0a955b70b405f6f79d95df75f3369aa1d8455c7af93a6dff5bb4399f2967"),
+					DepositDataRoot:       common.FromHex("5830db4b95cbcc8ff77cfb473146462a8f68e182c8ed725ad9087d61ea13f7bc"),
+				}
+			},
+			forkVersion: common.FromHex("00000113"),
+		},
+		{
+			name: "GoodThird",
+			deposit: func() *util.DepositInfo {
+				return &util.DepositInfo{
+					PublicKey:             common.FromHex("8b446d4ea379dfbc4d7af1e9fa79e57c6a1014aea10ad9afbdc6bc9e45792f80b859040b2db3c65e53e50d31d8f9ea1e"),
+					WithdrawalCredentials: common.FromHex("0047babb6b3d99ceaa8c117eef7f6be457872e65daed754db0de257b4795b507"),
+					Amount:                32000000000,
+					Signature:             common.FromHex("871cb2e25dce95dba3f1727f13f6f2aca1c358eac86037fdd9c5abc90986f650ad760e4f46540bea090ce8f5f4a1ba010892f9304f4e5cf2ce232c14a2f4cc083da84ce4f9c794f871e2a25574e9a85f847ab88bf8078f1b770666cea85bc4f0"),
+					DepositMessageRoot:    common.FromHex("e8dc7afcad5258bfd6383afe40de006ec32bb7add6cf70a180bc456935b61943"),
+					DepositDataRoot:       common.FromHex("50f915bb6545e183f18df835b3472b5d35ee438b49fcdedb0c7e34ecde108ddf"),
+				}
+			},
+			forkVersion: common.FromHex("00000113"),
+		},
+		{
+			name:        "WrongForkVersion",
+			deposit:     deposit,
+			forkVersion: common.FromHex("00000000"),
+			err:         "invalid deposit signature: signature verification failed",
+		},
+		{
+			name: "WrongAmount",
+			deposit: func() *util.DepositInfo {
+				d := deposit()
+				d.Amount = 1000000000
+				return d
+			},
+			forkVersion: common.FromHex("00000113"),
+			err:         "deposit message root does not match deposit contents",
+		},
+		{
+			name: "WrongDataRoot",
+			deposit: func() *util.DepositInfo {
+				d := deposit()
+				d.DepositDataRoot = d.DepositMessageRoot
+				return d
+			},
+			forkVersion: common.FromHex("00000113"),
+			err:         "deposit data root does not match deposit contents",
+		},
+		{
+			name: "BadWithdrawalCredentials",
+			deposit: func() *util.DepositInfo {
+				d := deposit()
+				d.WithdrawalCredentials[0] = 0x05
+				return d
+			},
+			forkVersion: common.FromHex("00000113"),
+			err:         "withdrawal credentials have unknown prefix 0x05",
+		},
+		{
+			name: "BadSignature",
+			deposit: func() *util.DepositInfo {
+				d := deposit()
+				d.Signature[95]++
+				return d
+			},
+			forkVersion: common.FromHex("00000113"),
+			err:         "deposit data root does not match deposit contents",
+		},
+		{
+			name: "SignatureForOtherKey",
+			deposit: func() *util.DepositInfo {
+				d := deposit()
+				d.Signature = common.FromHex("a981d6979692b6c2d6282de0fbf08e15485d1daa471d0a21682d3284916ee9d9defacc851a49be7e17f0d74a2ae196990536f568effff25d38fe28282b7d25e6ecf562cff5246cd023e0b42168126043a80c47a1f6a7089d406430d868c8c580")
+				d.DepositDataRoot = util.DepositDataRoot(d.PublicKey, d.WithdrawalCredentials, d.Amount, d.Signature)
+				return d
+			},
+			forkVersion: common.FromHex("00000113"),
+			err:         "invalid deposit signature: signature verification failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.VerifyDeposit(test.deposit(), test.forkVersion)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Instruction is a single instruction in EVM bytecode.
+type Instruction struct {
+	PC  uint64
+	Op  vm.OpCode
+	Arg []byte
+	// Truncated is set if the code ends before the end of the argument.
+	Truncated bool
+}
+
+// String returns a readable version of the instruction.
+func (i *Instruction) String() string {
+	if !i.Op.IsPush() || i.Op == vm.PUSH0 {
+		return i.Op.String()
+	}
+	return fmt.Sprintf("%s 0x%x", i.Op, i.Arg)
+}
+
+// CodeMetadata is the metadata appended to contract code by the Solidity compiler.
+type CodeMetadata struct {
+	// Length is the length of the metadata in bytes, including its 2-byte length suffix.
+	Length int
+	// IPFS is the IPFS hash of the contract's metadata file.
+	IPFS string
+	// Swarm is the Swarm hash of the contract's metadata file, for older compilers.
+	Swarm string
+	// Compiler is the version of the compiler.
+	Compiler string
+	// Experimental is set if experimental compiler features were used.
+	Experimental bool
+}
+
+// Disassemble disassembles EVM bytecode in to instructions.
+func Disassemble(code []byte) []*Instruction {
+	res := make([]*Instruction, 0)
+	for pc := uint64(0); pc < uint64(len(code)); pc++ {
+		instruction := &Instruction{
+			PC: pc,
+			Op: vm.OpCode(code[pc]),
+		}
+		if instruction.Op.IsPush() {
+			size := uint64(instruction.Op - vm.PUSH1 + 1)
+			end := pc + 1 + size
+			if end > uint64(len(code)) {
+				end = uint64(len(code))
+				instruction.Truncated = true
+			}
+			instruction.Arg = code[pc+1 : end]
+			pc = end - 1
+		}
+		res = append(res, instruction)
+	}
+	return res
+}
+
+// JumpDestinations returns the valid jump destinations in the instructions.
+func JumpDestinations(instructions []*Instruction) map[uint64]bool {
+	res := make(map[uint64]bool)
+	for _, instruction := range instructions {
+		if instruction.Op == vm.JUMPDEST {
+			res[instruction.PC] = true
+		}
+	}
+	return res
+}
+
+// FunctionSelectors returns the function selectors that the instructions dispatch on, in the
+// order in which they appear.  Selectors are detected as 4-byte pushes compared for equality
+// shortly after, as generated by compilers for function dispatch.
+func FunctionSelectors(instructions []*Instruction) [][4]byte {
+	res := make([][4]byte, 0)
+	seen := make(map[[4]byte]bool)
+	for i, instruction := range instructions {
+		if instruction.Op != vm.PUSH4 || instruction.Truncated {
+			continue
+		}
+		// The comparison may follow a stack manipulation.
+		for j := i + 1; j < len(instructions) && j <= i+2; j++ {
+			if instructions[j].Op != vm.EQ {
+				continue
+			}
+			var selector [4]byte
+			copy(selector[:], instruction.Arg)
+			if !seen[selector] && binary.BigEndian.Uint32(selector[:]) != 0xffffffff {
+				seen[selector] = true
+				res = append(res, selector)
+			}
+			break
+		}
+	}
+	return res
+}
+
+// ParseCodeMetadata parses the metadata appended to contract code by the Solidity compiler,
+// returning nil if the code does not contain metadata.
+func ParseCodeMetadata(code []byte) *CodeMetadata {
+	if len(code) < 2 {
+		return nil
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if length == 0 || length+2 > len(code) {
+		return nil
+	}
+	values, err := decodeCBORMap(code[len(code)-2-length : len(code)-2])
+	if err != nil {
+		return nil
+	}
+
+	metadata := &CodeMetadata{
+		Length: length + 2,
+	}
+	if ipfs, isBytes := values["ipfs"].([]byte); isBytes {
+		metadata.IPFS = base58Encode(ipfs)
+	}
+	for _, key := range []string{"bzzr1", "bzzr0"} {
+		if swarm, isBytes := values[key].([]byte); isBytes {
+			metadata.Swarm = hex.EncodeToString(swarm)
+			break
+		}
+	}
+	switch solc := values["solc"].(type) {
+	case []byte:
+		if len(solc) == 3 {
+			metadata.Compiler = fmt.Sprintf("%d.%d.%d", solc[0], solc[1], solc[2])
+		}
+	case string:
+		metadata.Compiler = solc
+	}
+	if experimental, isBool := values["experimental"].(bool); isBool {
+		metadata.Experimental = experimental
+	}
+	if metadata.IPFS == "" && metadata.Swarm == "" && metadata.Compiler == "" {
+		return nil
+	}
+	return metadata
+}
+
+// decodeCBORMap decodes a CBOR map with text keys and values that are byte strings, text strings
+// or booleans, which is all that is required for code metadata.  All of the data must be used.
+func decodeCBORMap(data []byte) (map[string]interface{}, error) {
+	if len(data) == 0 || data[0]>>5 != 5 {
+		return nil, fmt.Errorf("not a map")
+	}
+	entries, offset, err := cborLength(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{}, entries)
+	for i := uint64(0); i < entries; i++ {
+		var key interface{}
+		key, offset, err = cborItem(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		keyStr, isString := key.(string)
+		if !isString {
+			return nil, fmt.Errorf("invalid key")
+		}
+		res[keyStr], offset, err = cborItem(data, offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if offset != len(data) {
+		return nil, fmt.Errorf("trailing data")
+	}
+	return res, nil
+}
+
+// cborItem decodes the CBOR item at the offset, returning it and the offset of the next item.
+func cborItem(data []byte, offset int) (interface{}, int, error) {
+	if offset >= len(data) {
+		return nil, 0, fmt.Errorf("data too short")
+	}
+	switch data[offset] >> 5 {
+	case 2, 3:
+		length, start, err := cborLength(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		if length > uint64(len(data)-start) {
+			return nil, 0, fmt.Errorf("data too short")
+		}
+		end := start + int(length)
+		if data[offset]>>5 == 3 {
+			return string(data[start:end]), end, nil
+		}
+		return data[start:end], end, nil
+	case 7:
+		switch data[offset] {
+		case 0xf4:
+			return false, offset + 1, nil
+		case 0xf5:
+			return true, offset + 1, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("unsupported item 0x%02x", data[offset])
+}
+
+// cborLength decodes the length of the CBOR item at the offset, returning it and the offset of
+// the item's content.
+func cborLength(data []byte, offset int) (uint64, int, error) {
+	info := data[offset] & 0x1f
+	switch {
+	case info < 24:
+		return uint64(info), offset + 1, nil
+	case info == 24 && offset+2 <= len(data):
+		return uint64(data[offset+1]), offset + 2, nil
+	case info == 25 && offset+3 <= len(data):
+		return uint64(binary.BigEndian.Uint16(data[offset+1:])), offset + 3, nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported length")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDispatcher dispatches on transfer(address,uint256) and balanceOf(address).
+var testDispatcher = MustDecodeHexString("60003560e01c8063a9059cbb1461002357806370a08231146100255763ffffffff16005b005b00")
+
+// testCodeMetadata is Solidity code metadata with an IPFS hash and compiler version 0.8.13.
+var testCodeMetadata = MustDecodeHexString("a26469706673582212202d711642b726b04401627ca9fbac32f5c8530fb1903cc4db02258717921a488164736f6c634300080d0033")
+
+func TestDisassemble(t *testing.T) {
+	instructions := Disassemble(testDispatcher)
+	require.Len(t, instructions, 21)
+	assert.Equal(t, uint64(0), instructions[0].PC)
+	assert.Equal(t, "PUSH1 0x00", instructions[0].String())
+	assert.Equal(t, vm.CALLDATALOAD, instructions[1].Op)
+	assert.Equal(t, "SHR", instructions[3].String())
+	assert.Equal(t, "PUSH4 0xa9059cbb", instructions[5].String())
+	assert.Equal(t, uint64(0x23), instructions[17].PC)
+	assert.Equal(t, vm.JUMPDEST, instructions[17].Op)
+
+	jumpDests := JumpDestinations(instructions)
+	assert.Equal(t, map[uint64]bool{0x23: true, 0x25: true}, jumpDests)
+
+	// Truncated push.
+	instructions = Disassemble([]byte{byte(vm.PUSH1), 0x01, byte(vm.PUSH4), 0x01, 0x02})
+	require.Len(t, instructions, 2)
+	assert.True(t, instructions[1].Truncated)
+	assert.Equal(t, []byte{0x01, 0x02}, instructions[1].Arg)
+}
+
+func TestDisassembleNewOpCodes(t *testing.T) {
+	// PUSH0 PUSH0 TSTORE PUSH1 0x01 TLOAD BLOBHASH BLOBBASEFEE MCOPY
+	instructions := Disassemble(MustDecodeHexString("5f5f5d60015c494a5e"))
+	names := make([]string, len(instructions))
+	for i, instruction := range instructions {
+		names[i] = instruction.String()
+	}
+	assert.Equal(t, []string{"PUSH0", "PUSH0", "TSTORE", "PUSH1 0x01", "TLOAD", "BLOBHASH", "BLOBBASEFEE", "MCOPY"}, names)
+	assert.Empty(t, instructions[0].Arg)
+	assert.Equal(t, uint64(1), instructions[1].PC)
+}
+
+func TestFunctionSelectors(t *testing.T) {
+	selectors := FunctionSelectors(Disassemble(testDispatcher))
+	assert.Equal(t, [][4]byte{{0xa9, 0x05, 0x9c, 0xbb}, {0x70, 0xa0, 0x82, 0x31}}, selectors)
+}
+
+func TestParseCodeMetadata(t *testing.T) {
+	code := append(append([]byte{}, testDispatcher...), testCodeMetadata...)
+	metadata := ParseCodeMetadata(code)
+	require.NotNil(t, metadata)
+	assert.Equal(t, len(testCodeMetadata), metadata.Length)
+	assert.Equal(t, "QmRQ353oFNqt8zfZ9X1HgRUszwv9RkEEwmMZZkbkYEsybn", metadata.IPFS)
+	assert.Equal(t, "0.8.13", metadata.Compiler)
+	assert.False(t, metadata.Experimental)
+
+	assert.Nil(t, ParseCodeMetadata(testDispatcher))
+	assert.Nil(t, ParseCodeMetadata(nil))
+	assert.Nil(t, ParseCodeMetadata([]byte{0x00, 0x33}))
+}
@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package disperse sends multiple Ether or token payments in a single transaction using
+// the Disperse contract.
+package disperse
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// Address is the address of the Disperse contract, which is the same on most chains.
+var Address = common.HexToAddress("0xD152f549545093347A162Dce210e7293f1452150")
+
+// InitCode is the init code for a minimal contract that implements the disperseEther and
+// disperseToken functions of Disperse, for chains on which Disperse is not present.
+//
+// disperseEther requires the value sent to cover the sum of the payments, sends each payment
+// in turn and refunds any excess to the caller.  disperseToken transfers the sum of the
+// payments from the caller to the contract and then transfers each payment from the contract.
+// Both revert if any payment fails, and the contract holds no funds between calls.
+var InitCode = common.FromHex("61028680600e6000396000f3fefe60003560e01c8063e63d38ed14610021578063c73a2d6014610108575b600080fd5b600460043501803580610240529060200161020052600460243501806020016102205235141561001c576000610280526000610260525b610240516102605110156100965761026051602002610220510135610280510180610280511161001c57610280526102605160010161026052610058565b61028051341061001c576000610260525b610240516102605110156100eb57600080808061026051602002610220510135610260516020026102005101355af11561001c5761026051600101610260526100a7565b610280513403801561010657600080808084335af11561001c575b005b3461001c57600435806102a0523b1561001c57600460243501803580610240529060200161020052600460443501806020016102205235141561001c576000610280526000610260525b610240516102605110156101905761026051602002610220510135610280510180610280511161001c57610280526102605160010161026052610152565b7f23b872dd00000000000000000000000000000000000000000000000000000000600052336004523060245261028051604452602060006064600060006102a0515af11561001c573d156101ef5760203d1061001c576000511561001c575b6000610260525b61024051610260511015610284577fa9059cbb000000000000000000000000000000000000000000000000000000006000526102605160200261020051013560045261026051602002610220510135602452602060006044600060006102a0515af11561001c573d156102745760203d1061001c576000511561001c575b61026051600101610260526101f6565b00")
+
+const disperseABI = `[{"inputs":[{"internalType":"address[]","name":"recipients","type":"address[]"},{"internalType":"uint256[]","name":"values","type":"uint256[]"}],"name":"disperseEther","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"contract IERC20","name":"token","type":"address"},{"internalType":"address[]","name":"recipients","type":"address[]"},{"internalType":"uint256[]","name":"values","type":"uint256[]"}],"name":"disperseToken","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+var parsedABI abi.ABI
+
+func init() {
+	var err error
+	parsedABI, err = abi.JSON(strings.NewReader(disperseABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// DeployedAddress returns the address of the contract created from InitCode by the given
+// CREATE2 factory with a zero salt.
+func DeployedAddress(factory common.Address) common.Address {
+	return util.Create2Address(factory, [32]byte{}, crypto.Keccak256(InitCode))
+}
+
+// DeployData returns the calldata for the given CREATE2 factory to deploy InitCode at
+// DeployedAddress.
+func DeployData() []byte {
+	return util.Create2FactoryData([32]byte{}, InitCode)
+}
+
+// Total returns the sum of the values.
+func Total(values []*big.Int) *big.Int {
+	total := big.NewInt(0)
+	for _, value := range values {
+		total.Add(total, value)
+	}
+	return total
+}
+
+// EtherData returns the calldata to send Ether to the recipients.  The transaction must send
+// at least the total of the values.
+func EtherData(recipients []common.Address, values []*big.Int) ([]byte, error) {
+	if err := checkPayments(recipients, values); err != nil {
+		return nil, err
+	}
+	return parsedABI.Pack("disperseEther", recipients, values)
+}
+
+// TokenData returns the calldata to send tokens to the recipients.  The sender must have
+// approved the contract to spend at least the total of the values.
+func TokenData(token common.Address, recipients []common.Address, values []*big.Int) ([]byte, error) {
+	if err := checkPayments(recipients, values); err != nil {
+		return nil, err
+	}
+	return parsedABI.Pack("disperseToken", token, recipients, values)
+}
+
+func checkPayments(recipients []common.Address, values []*big.Int) error {
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+	if len(recipients) != len(values) {
+		return fmt.Errorf("%d recipients but %d values", len(recipients), len(values))
+	}
+	for i, value := range values {
+		if value == nil || value.Sign() < 0 {
+			return fmt.Errorf("invalid value for recipient %d", i+1)
+		}
+	}
+	return nil
+}
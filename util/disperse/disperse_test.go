@@ -0,0 +1,221 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disperse
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// create2FactoryCode is the runtime code of the deterministic deployment proxy.
+var create2FactoryCode = common.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+// erc20Code is the init code of a simple ERC-20 token, with constructor (name, symbol, decimals, supply).
+var erc20Code = common.FromHex("608060405234801561001057600080fd5b506040516108b43803806108b48339818101604052608081101561003357600080fd5b81019080805164010000000081111561004b57600080fd5b8201602081018481111561005e57600080fd5b815164010000000081118282018710171561007857600080fd5b5050929190602001805164010000000081111561009457600080fd5b820160208101848111156100a757600080fd5b81516401000000008111828201871017156100c157600080fd5b505060208201516040909201519093509091508061014057604080517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601c60248201527f4d7573742068617665206120737570706c79206f6620746f6b656e7300000000604482015290519081900360640190fd5b83516101539060009060208701906101cc565b5082516101679060019060208601906101cc565b506002805460ff191660ff84161790556003819055336000818152600460209081526040808320859055805185815290517fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef929181900390910190a350505050610267565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f1061020d57805160ff191683800117855561023a565b8280016001018555821561023a579182015b8281111561023a57825182559160200191906001019061021f565b5061024692915061024a565b5090565b61026491905b808211156102465760008155600101610250565b90565b61063e806102766000396000f3fe608060405234801561001057600080fd5b50600436106100935760003560e01c8063313ce56711610066578063313ce567146101a557806370a08231146101c357806395d89b41146101e9578063a9059cbb146101f1578063dd62ed3e1461021d57610093565b806306fdde0314610098578063095ea7b31461011557806318160ddd1461015557806323b872dd1461016f575b600080fd5b6100a061024b565b6040805160208082528351818301528351919283929083019185019080838360005b838110156100da5781810151838201526020016100c2565b50505050905090810190601f1680156101075780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b6101416004803603604081101561012b57600080fd5b506001600160a01b0381351690602001356102d9565b604080519115158252519081900360200190f35b61015d61033e565b60408051918252519081900360200190f35b6101416004803603606081101561018557600080fd5b506001600160a01b03813581169160208101359091169060400135610344565b6101ad610499565b6040805160ff9092168252519081900360200190f35b61015d600480360360208110156101d957600080fd5b50356001600160a01b03166104a2565b6100a06104bd565b6101416004803603604081101561020757600080fd5b506001600160a01b038135169060200135610517565b61015d6004803603604081101561023357600080fd5b506001600160a01b03813581169160200135166105dd565b6000805460408051602060026001851615610100026000190190941693909304601f810184900484028201840190925281815292918301828280156102d15780601f106102a6576101008083540402835291602001916102d1565b820191906000526020600020905b8154815290600101906020018083116102b457829003601f168201915b505050505081565b6001600160a01b0382166000818152600560209081526040808320338085529083528184208690558151868152915193949390927f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925928290030190a350600192915050565b60035481565b6001600160a01b0383166000908152600460205260408120548211156103a5576040805162461bcd60e51b81526020600482015260116024820152704e6f7420656e6f75676820746f6b656e7360781b604482015290519081900360640190fd5b3360009081526005602090815260408083206001600160a01b0388168452909152902054821115610414576040805162461bcd60e51b81526020600482015260146024820152734e6f7420656e6f75676820616c6c6f77616e636560601b604482015290519081900360640190fd5b3360009081526005602090815260408083206001600160a01b038881168086529184528285208054889003905560048452828520805488900390558716808552938290208054870190558151868152915190927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef928290030190a35060019392505050565b60025460ff1681565b6001600160a01b031660009081526004602052604090205490565b60018054604080516020600284861615610100026000190190941693909304601f810184900484028201840190925281815292918301828280156102d15780601f106102a6576101008083540402835291602001916102d1565b3360009081526004602052604081205482111561056f576040805162461bcd60e51b81526020600482015260116024820152704e6f7420656e6f75676820746f6b656e7360781b604482015290519081900360640190fd5b336000818152600460209081526040808320805487900390556001600160a01b03871680845292819020805487019055805186815290519293927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef929181900390910190a350600192915050565b6001600160a01b038082166000908152600560209081526040808320938616835292905220549291505056fea265627a7a723058201d2ef62b40755b34ac4fd0b4e9817a06349244480de5a62bea6e1dddd9ee773e64736f6c634300050a0032")
+
+const erc20ABI = `[{"inputs":[{"name":"_operator","type":"address"},{"name":"_amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"_holder","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"_name","type":"string"},{"name":"_symbol","type":"string"},{"name":"_decimals","type":"uint8"},{"name":"_totalSupply","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"}]`
+
+type testChain struct {
+	t       *testing.T
+	backend *backends.SimulatedBackend
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	signer  types.Signer
+}
+
+func newTestChain(t *testing.T) *testChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from:                       {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+		util.DefaultCreate2Factory: {Code: create2FactoryCode, Balance: big.NewInt(0)},
+	}, 30000000)
+	t.Cleanup(func() { backend.Close() })
+	return &testChain{
+		t:       t,
+		backend: backend,
+		key:     key,
+		from:    from,
+		signer:  types.LatestSignerForChainID(big.NewInt(1337)),
+	}
+}
+
+// send sends a transaction and returns its receipt.
+func (c *testChain) send(to *common.Address, value *big.Int, data []byte) *types.Receipt {
+	ctx := context.Background()
+	nonce, err := c.backend.PendingNonceAt(ctx, c.from)
+	require.NoError(c.t, err)
+	gasPrice, err := c.backend.SuggestGasPrice(ctx)
+	require.NoError(c.t, err)
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Value:    value,
+		Gas:      5000000,
+		GasPrice: gasPrice,
+		Data:     data,
+	}), c.signer, c.key)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.backend.SendTransaction(ctx, tx))
+	c.backend.Commit()
+	receipt, err := c.backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(c.t, err)
+	return receipt
+}
+
+// call makes a call, returning an error if it reverts.
+func (c *testChain) call(to common.Address, value *big.Int, data []byte) error {
+	_, err := c.backend.CallContract(context.Background(), ethereum.CallMsg{
+		From:  c.from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	}, nil)
+	return err
+}
+
+func (c *testChain) deploy() common.Address {
+	factory := util.DefaultCreate2Factory
+	receipt := c.send(&factory, big.NewInt(0), DeployData())
+	require.Equal(c.t, types.ReceiptStatusSuccessful, receipt.Status)
+	address := DeployedAddress(factory)
+	code, err := c.backend.CodeAt(context.Background(), address, nil)
+	require.NoError(c.t, err)
+	require.NotEmpty(c.t, code)
+	return address
+}
+
+func TestPaymentChecks(t *testing.T) {
+	recipient := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	_, err := EtherData(nil, nil)
+	require.EqualError(t, err, "no recipients")
+	_, err = EtherData([]common.Address{recipient}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	require.EqualError(t, err, "1 recipients but 2 values")
+	_, err = TokenData(recipient, []common.Address{recipient}, []*big.Int{big.NewInt(-1)})
+	require.EqualError(t, err, "invalid value for recipient 1")
+	require.Equal(t, big.NewInt(6), Total([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}))
+}
+
+func TestDisperseEther(t *testing.T) {
+	c := newTestChain(t)
+	disperse := c.deploy()
+	ctx := context.Background()
+
+	recipients := []common.Address{
+		common.HexToAddress("0x0000000000000000000000000000000000000101"),
+		common.HexToAddress("0x0000000000000000000000000000000000000102"),
+		common.HexToAddress("0x0000000000000000000000000000000000000103"),
+	}
+	values := []*big.Int{big.NewInt(1000), big.NewInt(2000), big.NewInt(3000)}
+	data, err := EtherData(recipients, values)
+	require.NoError(t, err)
+
+	// Insufficient value.
+	require.Error(t, c.call(disperse, big.NewInt(5999), data))
+	// Mismatched arrays.
+	badData, err := parsedABI.Pack("disperseEther", recipients, values[:2])
+	require.NoError(t, err)
+	require.Error(t, c.call(disperse, big.NewInt(6000), badData))
+	// Unknown function.
+	require.Error(t, c.call(disperse, big.NewInt(0), []byte{0x01, 0x02, 0x03, 0x04}))
+
+	// Excess value is refunded.
+	before, err := c.backend.BalanceAt(ctx, c.from, nil)
+	require.NoError(t, err)
+	receipt := c.send(&disperse, big.NewInt(10000), data)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	for i := range recipients {
+		balance, err := c.backend.BalanceAt(ctx, recipients[i], nil)
+		require.NoError(t, err)
+		require.Equal(t, values[i], balance)
+	}
+	balance, err := c.backend.BalanceAt(ctx, disperse, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance.Int64())
+	after, err := c.backend.BalanceAt(ctx, c.from, nil)
+	require.NoError(t, err)
+	tx, _, err := c.backend.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tx.GasPrice())
+	spent := new(big.Int).Sub(before, after)
+	require.Equal(t, big.NewInt(6000), spent.Sub(spent, fee))
+}
+
+func TestDisperseToken(t *testing.T) {
+	c := newTestChain(t)
+	disperse := c.deploy()
+	ctx := context.Background()
+
+	tokenABI, err := abi.JSON(strings.NewReader(erc20ABI))
+	require.NoError(t, err)
+	args, err := tokenABI.Pack("", "Test", "TST", uint8(18), big.NewInt(1000000))
+	require.NoError(t, err)
+	receipt := c.send(nil, big.NewInt(0), append(append([]byte{}, erc20Code...), args...))
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	token := receipt.ContractAddress
+
+	recipients := []common.Address{
+		common.HexToAddress("0x0000000000000000000000000000000000000201"),
+		common.HexToAddress("0x0000000000000000000000000000000000000202"),
+	}
+	values := []*big.Int{big.NewInt(100), big.NewInt(200)}
+	data, err := TokenData(token, recipients, values)
+	require.NoError(t, err)
+
+	// No allowance.
+	require.Error(t, c.call(disperse, big.NewInt(0), data))
+
+	approveData, err := tokenABI.Pack("approve", disperse, big.NewInt(300))
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, c.send(&token, big.NewInt(0), approveData).Status)
+
+	// Value cannot be sent with tokens.
+	require.Error(t, c.call(disperse, big.NewInt(1), data))
+	// Token must be a contract.
+	eoaData, err := TokenData(c.from, recipients, values)
+	require.NoError(t, err)
+	require.Error(t, c.call(disperse, big.NewInt(0), eoaData))
+
+	receipt = c.send(&disperse, big.NewInt(0), data)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	for i, holder := range append(recipients, disperse, c.from) {
+		balanceData, err := tokenABI.Pack("balanceOf", holder)
+		require.NoError(t, err)
+		res, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &token, Data: balanceData}, nil)
+		require.NoError(t, err)
+		balance := new(big.Int).SetBytes(res)
+		switch {
+		case i < len(values):
+			require.Equal(t, values[i], balance)
+		case holder == disperse:
+			require.Equal(t, int64(0), balance.Int64())
+		default:
+			require.Equal(t, int64(999700), balance.Int64())
+		}
+	}
+}
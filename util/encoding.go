@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DecodeData decodes data from the given encoding, which can be "hex", "utf8" or "base64".
+func DecodeData(input string, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		input = strings.TrimPrefix(strings.TrimSpace(input), "0x")
+		if len(input)%2 == 1 {
+			input = "0" + input
+		}
+		data, err := hex.DecodeString(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex")
+		}
+		return data, nil
+	case "utf8", "utf-8", "text":
+		return []byte(input), nil
+	case "base64":
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(input))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64")
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %s", encoding)
+	}
+}
+
+// EncodeData encodes data in the given encoding, which can be "hex", "utf8" or "base64".  Hex
+// is prefixed with 0x.  An error is returned if the data is not valid UTF-8 when UTF-8 is
+// requested.
+func EncodeData(data []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		return "0x" + hex.EncodeToString(data), nil
+	case "utf8", "utf-8", "text":
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("data is not valid UTF-8")
+		}
+		return string(data), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("unknown encoding %s", encoding)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeData(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		encoding string
+		data     []byte
+		err      string
+	}{
+		{
+			name:     "Hex",
+			input:    "0x68656c6c6f",
+			encoding: "hex",
+			data:     []byte("hello"),
+		},
+		{
+			name:     "HexOdd",
+			input:    "0x1",
+			encoding: "hex",
+			data:     []byte{0x01},
+		},
+		{
+			name:     "HexInvalid",
+			input:    "0xzz",
+			encoding: "hex",
+			err:      "invalid hex",
+		},
+		{
+			name:     "UTF8",
+			input:    "hello",
+			encoding: "utf8",
+			data:     []byte("hello"),
+		},
+		{
+			name:     "Base64",
+			input:    "aGVsbG8=",
+			encoding: "base64",
+			data:     []byte("hello"),
+		},
+		{
+			name:     "Base64Invalid",
+			input:    "aGVsbG8",
+			encoding: "base64",
+			err:      "invalid base64",
+		},
+		{
+			name:     "Unknown",
+			input:    "hello",
+			encoding: "rot13",
+			err:      "unknown encoding rot13",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := DecodeData(test.input, test.encoding)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.data, data)
+			}
+		})
+	}
+}
+
+func TestEncodeData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+		output   string
+		err      string
+	}{
+		{
+			name:     "Hex",
+			data:     []byte("hello"),
+			encoding: "hex",
+			output:   "0x68656c6c6f",
+		},
+		{
+			name:     "HexEmpty",
+			data:     []byte{},
+			encoding: "hex",
+			output:   "0x",
+		},
+		{
+			name:     "UTF8",
+			data:     []byte("hello"),
+			encoding: "UTF-8",
+			output:   "hello",
+		},
+		{
+			name:     "UTF8Invalid",
+			data:     []byte{0xff, 0xfe},
+			encoding: "utf8",
+			err:      "data is not valid UTF-8",
+		},
+		{
+			name:     "Base64",
+			data:     []byte("hello"),
+			encoding: "base64",
+			output:   "aGVsbG8=",
+		},
+		{
+			name:     "Unknown",
+			data:     []byte("hello"),
+			encoding: "rot13",
+			err:      "unknown encoding rot13",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := EncodeData(test.data, test.encoding)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.output, output)
+			}
+		})
+	}
+}
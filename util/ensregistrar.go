@@ -0,0 +1,53 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+// ETHRegistrarControllerInterfaceID is the ERC-165 interface ID of the .eth registrar
+// controller, under which the controller is registered with the resolver of the .eth domain.
+var ETHRegistrarControllerInterfaceID = interfaceID(
+	"rentPrice(string,uint256)",
+	"available(string)",
+	"makeCommitment(string,address,uint256,bytes32,address,bytes[],bool,uint16)",
+	"commit(bytes32)",
+	"register(string,address,uint256,bytes32,address,bytes[],bool,uint16)",
+	"renew(string,uint256)",
+)
+
+// interfaceID calculates the ERC-165 interface ID for a set of function signatures.
+func interfaceID(signatures ...string) [4]byte {
+	var res [4]byte
+	for _, signature := range signatures {
+		selector := crypto.Keccak256([]byte(signature))
+		for i := range res {
+			res[i] ^= selector[i]
+		}
+	}
+	return res
+}
+
+// RegistrationValue returns the value to send with a registration or renewal given its
+// quoted price.  The value contains a buffer of 5% to allow for the price changing between
+// quote and transaction, with any excess refunded by the controller.
+func RegistrationValue(price contracts.IPriceOraclePrice) *big.Int {
+	total := new(big.Int).Add(price.Base, price.Premium)
+	buffer := new(big.Int).Div(total, big.NewInt(20))
+	return total.Add(total, buffer)
+}
@@ -0,0 +1,46 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+func TestInterfaceID(t *testing.T) {
+	// ERC-721 has a well-known interface ID.
+	id := interfaceID(
+		"balanceOf(address)",
+		"ownerOf(uint256)",
+		"approve(address,uint256)",
+		"getApproved(uint256)",
+		"setApprovalForAll(address,bool)",
+		"isApprovedForAll(address,address)",
+		"transferFrom(address,address,uint256)",
+		"safeTransferFrom(address,address,uint256)",
+		"safeTransferFrom(address,address,uint256,bytes)",
+	)
+	require.Equal(t, [4]byte{0x80, 0xac, 0x58, 0xcd}, id)
+}
+
+func TestRegistrationValue(t *testing.T) {
+	value := RegistrationValue(contracts.IPriceOraclePrice{
+		Base:    big.NewInt(1000000),
+		Premium: big.NewInt(200000),
+	})
+	require.Equal(t, big.NewInt(1260000), value)
+}
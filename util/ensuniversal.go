@@ -0,0 +1,131 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+// UniversalResolverAddress is the address of the ENS universal resolver on mainnet.
+var UniversalResolverAddress = common.HexToAddress("0xce01f8eee7E479C928F8919abD53E553a36CeF67")
+
+const universalResolverABI = `[{"inputs":[{"name":"name","type":"bytes"},{"name":"data","type":"bytes"}],"name":"resolve","outputs":[{"name":"","type":"bytes"},{"name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"reverseName","type":"bytes"}],"name":"reverse","outputs":[{"name":"","type":"string"},{"name":"","type":"address"},{"name":"","type":"address"},{"name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+
+const ensResolverABI = `[{"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"node","type":"bytes32"},{"name":"coinType","type":"uint256"}],"name":"addr","outputs":[{"name":"","type":"bytes"}],"stateMutability":"view","type":"function"}]`
+
+var universalResolver abi.ABI
+var ensResolver abi.ABI
+
+func init() {
+	var err error
+	universalResolver, err = abi.JSON(strings.NewReader(universalResolverABI))
+	if err != nil {
+		panic(err)
+	}
+	ensResolver, err = abi.JSON(strings.NewReader(ensResolverABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// UniversalResolveAddress obtains the address of an ENS name for the given coin type using
+// the universal resolver at the given address, which follows wildcard and off-chain resolution.
+// The address is returned in the binary form used by ENS, and is empty if it is not set.
+func UniversalResolveAddress(ctx context.Context, caller ethereum.ContractCaller, resolver common.Address, name string, coinType uint64) ([]byte, error) {
+	name, err := ens.Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	node, err := ens.NameHash(name)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	var data []byte
+	if coinType == 60 {
+		method = "addr"
+		data, err = ensResolver.Pack(method, node)
+	} else {
+		// Overloaded functions are given a numeric suffix by the ABI parser.
+		method = "addr0"
+		data, err = ensResolver.Pack(method, node, new(big.Int).SetUint64(coinType))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := universalResolverCall(ctx, caller, resolver, "resolve", ens.DNSWireFormat(name), data)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 || len(res[0].([]byte)) == 0 {
+		return nil, nil
+	}
+	values, err := ensResolver.Unpack(method, res[0].([]byte))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode resolver response")
+	}
+	if coinType == 60 {
+		address := values[0].(common.Address)
+		if address == (common.Address{}) {
+			return nil, nil
+		}
+		return address.Bytes(), nil
+	}
+	return values[0].([]byte), nil
+}
+
+// UniversalReverseResolve obtains the primary ENS name of an address using the universal
+// resolver at the given address.  The name is only returned if it resolves back to the
+// address, otherwise an empty string is returned.
+func UniversalReverseResolve(ctx context.Context, caller ethereum.ContractCaller, resolver common.Address, address common.Address) (string, error) {
+	reverseName := fmt.Sprintf("%x.addr.reverse", address.Bytes())
+	res, err := universalResolverCall(ctx, caller, resolver, "reverse", ens.DNSWireFormat(reverseName))
+	if err != nil {
+		return "", err
+	}
+	name := res[0].(string)
+	if name == "" || res[1].(common.Address) != address {
+		return "", nil
+	}
+	return name, nil
+}
+
+// universalResolverCall calls a method on the universal resolver and unpacks the result.
+func universalResolverCall(ctx context.Context, caller ethereum.ContractCaller, resolver common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := universalResolver.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := caller.CallContract(ctx, ethereum.CallMsg{
+		To:   &resolver,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no universal resolver at address")
+	}
+	return universalResolver.Unpack(method, res)
+}
@@ -0,0 +1,125 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	ens "github.com/wealdtech/go-ens/v3"
+)
+
+// universalResolverCaller is a contract caller that acts as a universal resolver for a single name.
+type universalResolverCaller struct {
+	name    string
+	address common.Address
+	btc     []byte
+}
+
+func (c *universalResolverCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := universalResolver.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "resolve":
+		if string(args[0].([]byte)) != string(ens.DNSWireFormat(c.name)) {
+			return nil, errors.New("execution reverted")
+		}
+		data := args[1].([]byte)
+		resolverMethod, err := ensResolver.MethodById(data[:4])
+		if err != nil {
+			return nil, err
+		}
+		var res []byte
+		if resolverMethod.Name == "addr" {
+			res, err = resolverMethod.Outputs.Pack(c.address)
+		} else {
+			// Only the Bitcoin address is set for multicoin lookups.
+			value := []byte{}
+			if new(big.Int).SetBytes(data[36:68]).Uint64() == 0 {
+				value = c.btc
+			}
+			res, err = resolverMethod.Outputs.Pack(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(res, common.Address{})
+	case "reverse":
+		if string(args[0].([]byte)) != string(ens.DNSWireFormat(c.address.Hex()[2:]+".addr.reverse")) {
+			return method.Outputs.Pack("", common.Address{}, common.Address{}, common.Address{})
+		}
+		return method.Outputs.Pack(c.name, c.address, common.Address{}, common.Address{})
+	default:
+		return nil, errors.New("unknown method")
+	}
+}
+
+func TestUniversalResolverSelectors(t *testing.T) {
+	require.Equal(t, crypto.Keccak256([]byte("resolve(bytes,bytes)"))[:4], universalResolver.Methods["resolve"].ID)
+	require.Equal(t, crypto.Keccak256([]byte("reverse(bytes)"))[:4], universalResolver.Methods["reverse"].ID)
+	require.Equal(t, crypto.Keccak256([]byte("addr(bytes32)"))[:4], ensResolver.Methods["addr"].ID)
+	require.Equal(t, crypto.Keccak256([]byte("addr(bytes32,uint256)"))[:4], ensResolver.Methods["addr0"].ID)
+}
+
+func TestUniversalResolveAddress(t *testing.T) {
+	btc, err := EncodeCoinAddress(0, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	require.NoError(t, err)
+	caller := &universalResolverCaller{
+		name:    "enstest.eth",
+		address: common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+		btc:     btc,
+	}
+
+	res, err := UniversalResolveAddress(context.Background(), caller, UniversalResolverAddress, "EnsTest.eth", 60)
+	require.NoError(t, err)
+	require.Equal(t, caller.address.Bytes(), res)
+
+	res, err = UniversalResolveAddress(context.Background(), caller, UniversalResolverAddress, "enstest.eth", 0)
+	require.NoError(t, err)
+	require.Equal(t, btc, res)
+
+	res, err = UniversalResolveAddress(context.Background(), caller, UniversalResolverAddress, "enstest.eth", 2)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	_, err = UniversalResolveAddress(context.Background(), caller, UniversalResolverAddress, "unknown.eth", 60)
+	require.EqualError(t, err, "execution reverted")
+}
+
+func TestUniversalReverseResolve(t *testing.T) {
+	caller := &universalResolverCaller{
+		name:    "enstest.eth",
+		address: common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+	}
+
+	name, err := UniversalReverseResolve(context.Background(), caller, UniversalResolverAddress, caller.address)
+	require.NoError(t, err)
+	require.Equal(t, "enstest.eth", name)
+
+	name, err = UniversalReverseResolve(context.Background(), caller, UniversalResolverAddress, common.HexToAddress("0x0000000000000000000000000000000000000001"))
+	require.NoError(t, err)
+	require.Equal(t, "", name)
+}
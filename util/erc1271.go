@@ -0,0 +1,62 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC1271MagicValue is the value returned by isValidSignature() for a valid signature,
+// which is the selector for isValidSignature(bytes32,bytes).
+var ERC1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+const erc1271ABI = `[{"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"magicValue","type":"bytes4"}],"stateMutability":"view","type":"function"}]`
+
+var erc1271 abi.ABI
+
+func init() {
+	var err error
+	erc1271, err = abi.JSON(strings.NewReader(erc1271ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// IsValidContractSignature checks a signature of a hash against a contract that
+// implements ERC-1271, such as a smart contract wallet.  A contract that rejects the
+// signature by reverting returns an error.
+func IsValidContractSignature(ctx context.Context, caller ethereum.ContractCaller, address common.Address, hash common.Hash, signature []byte) (bool, error) {
+	data, err := erc1271.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, err
+	}
+	res, err := caller.CallContract(ctx, ethereum.CallMsg{
+		To:   &address,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	// The result is a bytes4 value left-aligned in a 32-byte word.
+	if len(res) < len(ERC1271MagicValue) {
+		return false, nil
+	}
+	return bytes.Equal(res[:len(ERC1271MagicValue)], ERC1271MagicValue), nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// erc1271Caller is a contract caller that accepts a single signature.
+type erc1271Caller struct {
+	validSignature []byte
+}
+
+func (c *erc1271Caller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := erc1271.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	if string(args[1].([]byte)) == string(c.validSignature) {
+		return common.RightPadBytes(ERC1271MagicValue, 32), nil
+	}
+	if len(args[1].([]byte)) == 0 {
+		return nil, errors.New("execution reverted")
+	}
+	return make([]byte, 32), nil
+}
+
+func TestERC1271MagicValue(t *testing.T) {
+	require.Equal(t, crypto.Keccak256([]byte("isValidSignature(bytes32,bytes)"))[:4], ERC1271MagicValue)
+}
+
+func TestIsValidContractSignature(t *testing.T) {
+	caller := &erc1271Caller{validSignature: []byte{0x01, 0x02, 0x03}}
+	address := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	hash := crypto.Keccak256Hash([]byte("Hello, world"))
+
+	valid, err := IsValidContractSignature(context.Background(), caller, address, hash, []byte{0x01, 0x02, 0x03})
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = IsValidContractSignature(context.Background(), caller, address, hash, []byte{0x04})
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	_, err = IsValidContractSignature(context.Background(), caller, address, hash, []byte{})
+	require.EqualError(t, err, "execution reverted")
+}
@@ -0,0 +1,223 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etherscan obtains account information from Etherscan, or any service with a compatible API
+// such as Blockscout.
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// urls are the API endpoints for Etherscan by chain ID.
+var urls = map[uint64]string{
+	1:        "https://api.etherscan.io/api",
+	3:        "https://api-ropsten.etherscan.io/api",
+	4:        "https://api-rinkeby.etherscan.io/api",
+	5:        "https://api-goerli.etherscan.io/api",
+	42:       "https://api-kovan.etherscan.io/api",
+	11155111: "https://api-sepolia.etherscan.io/api",
+}
+
+// DefaultURL returns the Etherscan API endpoint for the given chain.
+func DefaultURL(chainID *big.Int) (string, error) {
+	if !chainID.IsUint64() || urls[chainID.Uint64()] == "" {
+		return "", fmt.Errorf("no Etherscan endpoint for chain %v", chainID)
+	}
+	return urls[chainID.Uint64()], nil
+}
+
+// Client is a client for the Etherscan API.
+type Client struct {
+	key string
+	url string
+}
+
+// New creates a new client.  The key is the API key, and the URL overrides the default endpoint
+// for the chain.
+func New(key string, url string) *Client {
+	return &Client{
+		key: key,
+		url: url,
+	}
+}
+
+// Transaction is a summary of a transaction.
+type Transaction struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	Timestamp   time.Time
+}
+
+// Activity is the first and last transactions of an account.
+type Activity struct {
+	First *Transaction
+	Last  *Transaction
+}
+
+type response struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+type transactionResponse struct {
+	Hash        string `json:"hash"`
+	BlockNumber string `json:"blockNumber"`
+	TimeStamp   string `json:"timeStamp"`
+}
+
+// Activity returns the first and last transactions of the account at the given address.
+// Both are nil if the account has no transactions.
+func (c *Client) Activity(ctx context.Context, chainID *big.Int, address common.Address) (*Activity, error) {
+	first, err := c.transaction(ctx, chainID, address, "asc")
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return &Activity{}, nil
+	}
+	last, err := c.transaction(ctx, chainID, address, "desc")
+	if err != nil {
+		return nil, err
+	}
+	return &Activity{
+		First: first,
+		Last:  last,
+	}, nil
+}
+
+// transaction returns the first transaction of the account in the given sort order.
+func (c *Client) transaction(ctx context.Context, chainID *big.Int, address common.Address, sort string) (*Transaction, error) {
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "txlist")
+	params.Set("address", address.Hex())
+	params.Set("page", "1")
+	params.Set("offset", "1")
+	params.Set("sort", sort)
+	var txs []*transactionResponse
+	if err := c.call(ctx, chainID, params, &txs); err != nil {
+		return nil, err
+	}
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	blockNumber, err := strconv.ParseUint(txs[0].BlockNumber, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid block number")
+	}
+	timestamp, err := strconv.ParseInt(txs[0].TimeStamp, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+	return &Transaction{
+		Hash:        common.HexToHash(txs[0].Hash),
+		BlockNumber: blockNumber,
+		Timestamp:   time.Unix(timestamp, 0),
+	}, nil
+}
+
+// call calls the API with the given parameters, decoding the result.
+func (c *Client) call(ctx context.Context, chainID *big.Int, params url.Values, result interface{}) error {
+	res, err := c.request(ctx, chainID, http.MethodGet, params)
+	if err != nil {
+		return err
+	}
+	if res.Status != "1" {
+		// An empty result is reported as a failure with an empty list of results.
+		if res.Message == "No transactions found" {
+			return json.Unmarshal([]byte("[]"), result)
+		}
+		return fmt.Errorf("request failed: %s", res.message())
+	}
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return errors.Wrap(err, "invalid result")
+	}
+	return nil
+}
+
+// request sends a request to the API with the given parameters, returning the response.
+// Parameters are sent in the body of POST requests, and in the URL otherwise.
+func (c *Client) request(ctx context.Context, chainID *big.Int, method string, params url.Values) (*response, error) {
+	base := c.url
+	if base == "" {
+		var err error
+		base, err = DefaultURL(chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.key != "" {
+		params.Set("apikey", c.key)
+	}
+
+	var req *http.Request
+	var err error
+	if method == http.MethodPost {
+		req, err = http.NewRequestWithContext(ctx, method, base, strings.NewReader(params.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		separator := "?"
+		if strings.Contains(base, "?") {
+			separator = "&"
+		}
+		req, err = http.NewRequestWithContext(ctx, method, base+separator+params.Encode(), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request returned status %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+	return &res, nil
+}
+
+// message returns the result of the response if it is a string, otherwise its message.
+func (r *response) message() string {
+	var msg string
+	if err := json.Unmarshal(r.Result, &msg); err != nil || msg == "" {
+		msg = r.Message
+	}
+	return msg
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+func TestActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "account", query.Get("module"))
+		require.Equal(t, "txlist", query.Get("action"))
+		require.Equal(t, "key", query.Get("apikey"))
+		switch {
+		case query.Get("address") != testAddress.Hex():
+			fmt.Fprint(w, `{"status":"0","message":"No transactions found","result":[]}`)
+		case query.Get("sort") == "asc":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"hash":"0x1111111111111111111111111111111111111111111111111111111111111111","blockNumber":"100","timeStamp":"1600000000"}]}`)
+		default:
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"hash":"0x2222222222222222222222222222222222222222222222222222222222222222","blockNumber":"200","timeStamp":"1650000000"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	client := New("key", server.URL)
+
+	activity, err := client.Activity(context.Background(), big.NewInt(1), testAddress)
+	require.NoError(t, err)
+	require.NotNil(t, activity.First)
+	assert.Equal(t, common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"), activity.First.Hash)
+	assert.Equal(t, uint64(100), activity.First.BlockNumber)
+	assert.Equal(t, int64(1600000000), activity.First.Timestamp.Unix())
+	require.NotNil(t, activity.Last)
+	assert.Equal(t, uint64(200), activity.Last.BlockNumber)
+
+	activity, err = client.Activity(context.Background(), big.NewInt(1), common.Address{})
+	require.NoError(t, err)
+	assert.Nil(t, activity.First)
+	assert.Nil(t, activity.Last)
+}
+
+func TestActivityError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Invalid API Key"}`)
+	}))
+	defer server.Close()
+
+	_, err := New("bad", server.URL).Activity(context.Background(), big.NewInt(1), testAddress)
+	require.EqualError(t, err, "request failed: Invalid API Key")
+}
+
+func TestDefaultURL(t *testing.T) {
+	url, err := DefaultURL(big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.etherscan.io/api", url)
+
+	_, err = DefaultURL(big.NewInt(12345))
+	assert.Error(t, err)
+}
@@ -0,0 +1,266 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// pageSize is the number of results requested at a time when listing transactions.
+var pageSize = 1000
+
+// resultWindow is the maximum number of results that can be paged through for a single query.
+var resultWindow = 10000
+
+// HistoryTransaction is a transaction in the history of an account.
+type HistoryTransaction struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	Timestamp   time.Time
+	From        common.Address
+	// To is nil for contract creations.
+	To              *common.Address
+	ContractAddress *common.Address
+	Value           *big.Int
+	GasUsed         uint64
+	GasPrice        *big.Int
+	// Failed is set if the transaction reverted.
+	Failed bool
+	// Function is the signature of the function called by the transaction, if known.
+	Function string
+}
+
+// InternalTransaction is a transfer of Ether made by a contract.
+type InternalTransaction struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	Timestamp   time.Time
+	From        common.Address
+	// To is nil for contract creations.
+	To              *common.Address
+	ContractAddress *common.Address
+	Value           *big.Int
+	// Type is the type of call, for example "call" or "create".
+	Type string
+	// TraceID identifies the call within the transaction.
+	TraceID string
+	// Failed is set if the call reverted.
+	Failed bool
+}
+
+type historyTransactionResponse struct {
+	Hash            string `json:"hash"`
+	BlockNumber     string `json:"blockNumber"`
+	TimeStamp       string `json:"timeStamp"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ContractAddress string `json:"contractAddress"`
+	Value           string `json:"value"`
+	GasUsed         string `json:"gasUsed"`
+	GasPrice        string `json:"gasPrice"`
+	IsError         string `json:"isError"`
+	FunctionName    string `json:"functionName"`
+	Type            string `json:"type"`
+	TraceID         string `json:"traceId"`
+}
+
+// Transactions returns the transactions sent to or from the account at the given address
+// between the given blocks inclusive, oldest first.  If limit is not 0 at most that many
+// transactions are returned.
+func (c *Client) Transactions(ctx context.Context,
+	chainID *big.Int,
+	address common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+	limit int,
+) (
+	[]*HistoryTransaction,
+	error,
+) {
+	responses, err := c.list(ctx, chainID, "txlist", address, fromBlock, toBlock, limit)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*HistoryTransaction, len(responses))
+	for i, response := range responses {
+		tx := &HistoryTransaction{
+			Hash:     common.HexToHash(response.Hash),
+			From:     common.HexToAddress(response.From),
+			To:       optionalAddress(response.To),
+			Failed:   response.IsError == "1",
+			Function: response.FunctionName,
+		}
+		tx.ContractAddress = optionalAddress(response.ContractAddress)
+		if tx.BlockNumber, tx.Timestamp, err = response.position(); err != nil {
+			return nil, err
+		}
+		if tx.Value, err = parseBig(response.Value, "value"); err != nil {
+			return nil, err
+		}
+		if tx.GasPrice, err = parseBig(response.GasPrice, "gas price"); err != nil {
+			return nil, err
+		}
+		if response.GasUsed != "" {
+			if tx.GasUsed, err = strconv.ParseUint(response.GasUsed, 10, 64); err != nil {
+				return nil, errors.Wrap(err, "invalid gas used")
+			}
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// InternalTransactions returns the internal transactions sent to or from the account at the
+// given address between the given blocks inclusive, oldest first.  If limit is not 0 at most
+// that many transactions are returned.
+func (c *Client) InternalTransactions(ctx context.Context,
+	chainID *big.Int,
+	address common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+	limit int,
+) (
+	[]*InternalTransaction,
+	error,
+) {
+	responses, err := c.list(ctx, chainID, "txlistinternal", address, fromBlock, toBlock, limit)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*InternalTransaction, len(responses))
+	for i, response := range responses {
+		tx := &InternalTransaction{
+			Hash:            common.HexToHash(response.Hash),
+			From:            common.HexToAddress(response.From),
+			To:              optionalAddress(response.To),
+			ContractAddress: optionalAddress(response.ContractAddress),
+			Type:            response.Type,
+			TraceID:         response.TraceID,
+			Failed:          response.IsError == "1",
+		}
+		if tx.BlockNumber, tx.Timestamp, err = response.position(); err != nil {
+			return nil, err
+		}
+		if tx.Value, err = parseBig(response.Value, "value"); err != nil {
+			return nil, err
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// list lists the transactions of the given kind, a page at a time.  Etherscan limits the number
+// of results that can be paged through, so when the limit is reached the query restarts at the
+// block of the last result, with duplicates removed.
+func (c *Client) list(ctx context.Context,
+	chainID *big.Int,
+	action string,
+	address common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+	limit int,
+) (
+	[]*historyTransactionResponse,
+	error,
+) {
+	res := make([]*historyTransactionResponse, 0)
+	seen := make(map[string]bool)
+	start := fromBlock
+	page := 1
+	for {
+		params := url.Values{}
+		params.Set("module", "account")
+		params.Set("action", action)
+		params.Set("address", address.Hex())
+		params.Set("startblock", strconv.FormatUint(start, 10))
+		params.Set("endblock", strconv.FormatUint(toBlock, 10))
+		params.Set("page", strconv.Itoa(page))
+		params.Set("offset", strconv.Itoa(pageSize))
+		params.Set("sort", "asc")
+		var txs []*historyTransactionResponse
+		if err := c.call(ctx, chainID, params, &txs); err != nil {
+			return nil, err
+		}
+
+		for _, tx := range txs {
+			key := fmt.Sprintf("%s/%s/%s", tx.Hash, tx.TraceID, tx.From)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			res = append(res, tx)
+			if limit > 0 && len(res) == limit {
+				return res, nil
+			}
+		}
+		if len(txs) < pageSize {
+			return res, nil
+		}
+		if (page+1)*pageSize <= resultWindow {
+			page++
+			continue
+		}
+		last, err := strconv.ParseUint(txs[len(txs)-1].BlockNumber, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid block number")
+		}
+		if last == start {
+			return nil, fmt.Errorf("too many transactions in block %d", last)
+		}
+		start = last
+		page = 1
+	}
+}
+
+// position returns the block number and timestamp of the transaction.
+func (r *historyTransactionResponse) position() (uint64, time.Time, error) {
+	blockNumber, err := strconv.ParseUint(r.BlockNumber, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "invalid block number")
+	}
+	timestamp, err := strconv.ParseInt(r.TimeStamp, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "invalid timestamp")
+	}
+	return blockNumber, time.Unix(timestamp, 0), nil
+}
+
+// optionalAddress returns the address, or nil if it is empty.
+func optionalAddress(input string) *common.Address {
+	if input == "" {
+		return nil
+	}
+	address := common.HexToAddress(input)
+	return &address
+}
+
+// parseBig parses a decimal value, treating an empty value as 0.
+func parseBig(input string, name string) (*big.Int, error) {
+	if input == "" {
+		return big.NewInt(0), nil
+	}
+	value, success := new(big.Int).SetString(input, 10)
+	if !success {
+		return nil, fmt.Errorf("invalid %s %s", name, input)
+	}
+	return value, nil
+}
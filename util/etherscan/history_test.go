@@ -0,0 +1,108 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactions(t *testing.T) {
+	pageSize = 2
+	resultWindow = 4
+	defer func() {
+		pageSize = 1000
+		resultWindow = 10000
+	}()
+
+	// Two transactions in each of blocks 10, 11 and 12.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "txlist", query.Get("action"))
+		require.Equal(t, "asc", query.Get("sort"))
+		start, err := strconv.ParseUint(query.Get("startblock"), 10, 64)
+		require.NoError(t, err)
+		page, err := strconv.Atoi(query.Get("page"))
+		require.NoError(t, err)
+		require.LessOrEqual(t, page*pageSize, resultWindow)
+		results := make([]string, 0)
+		for block := uint64(10); block <= 12; block++ {
+			for i := 0; i < 2; i++ {
+				if block >= start {
+					results = append(results, fmt.Sprintf(`{"hash":"0x%064x","blockNumber":"%d","timeStamp":"1600000000","from":"%s","to":"","contractAddress":"","value":"%d","gasUsed":"21000","gasPrice":"1000000000","isError":"%d","functionName":""}`, block*10+uint64(i), block, testAddress.Hex(), i, i))
+				}
+			}
+		}
+		if len(results) > page*pageSize {
+			results = results[:page*pageSize]
+		}
+		if len(results) > (page-1)*pageSize {
+			results = results[(page-1)*pageSize:]
+		} else {
+			results = nil
+		}
+		if len(results) == 0 {
+			fmt.Fprint(w, `{"status":"0","message":"No transactions found","result":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[%s]}`, strings.Join(results, ","))
+	}))
+	defer server.Close()
+
+	client := New("key", server.URL)
+
+	txs, err := client.Transactions(context.Background(), big.NewInt(1), testAddress, 0, 99999999, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 6)
+	assert.Equal(t, common.BigToHash(big.NewInt(100)), txs[0].Hash)
+	assert.Equal(t, uint64(10), txs[0].BlockNumber)
+	assert.Nil(t, txs[0].To)
+	assert.Equal(t, uint64(21000), txs[0].GasUsed)
+	assert.False(t, txs[0].Failed)
+	assert.True(t, txs[1].Failed)
+	assert.Equal(t, big.NewInt(1), txs[1].Value)
+	assert.Equal(t, uint64(12), txs[5].BlockNumber)
+
+	txs, err = client.Transactions(context.Background(), big.NewInt(1), testAddress, 0, 99999999, 3)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	assert.Equal(t, uint64(11), txs[2].BlockNumber)
+}
+
+func TestInternalTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "txlistinternal", r.URL.Query().Get("action"))
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[{"hash":"0x1111111111111111111111111111111111111111111111111111111111111111","blockNumber":"100","timeStamp":"1600000000","from":"0x0000000000000000000000000000000000000001","to":"%s","contractAddress":"","value":"5","type":"call","traceId":"0_1","isError":"0"}]}`, testAddress.Hex())
+	}))
+	defer server.Close()
+
+	txs, err := New("key", server.URL).InternalTransactions(context.Background(), big.NewInt(1), testAddress, 0, 99999999, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.NotNil(t, txs[0].To)
+	assert.Equal(t, testAddress, *txs[0].To)
+	assert.Equal(t, big.NewInt(5), txs[0].Value)
+	assert.Equal(t, "call", txs[0].Type)
+	assert.Equal(t, "0_1", txs[0].TraceID)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ErrNotVerified is returned when the source of a contract has not been verified.
+var ErrNotVerified = errors.New("contract source not verified")
+
+// Source is the verified source of a contract.
+type Source struct {
+	Name                 string
+	CompilerVersion      string
+	Optimized            bool
+	Runs                 uint64
+	EVMVersion           string
+	License              string
+	ConstructorArguments []byte
+	ABI                  string
+	// Implementation is the implementation contract if the contract is a proxy.
+	Implementation *common.Address
+	// Files are the source files of the contract, keyed by path.
+	Files map[string]string
+}
+
+// Creation is the creation details of a contract.
+type Creation struct {
+	Creator         common.Address
+	TransactionHash common.Hash
+}
+
+type sourceResponse struct {
+	SourceCode           string `json:"SourceCode"`
+	ABI                  string `json:"ABI"`
+	ContractName         string `json:"ContractName"`
+	CompilerVersion      string `json:"CompilerVersion"`
+	OptimizationUsed     string `json:"OptimizationUsed"`
+	Runs                 string `json:"Runs"`
+	ConstructorArguments string `json:"ConstructorArguments"`
+	EVMVersion           string `json:"EVMVersion"`
+	LicenseType          string `json:"LicenseType"`
+	Proxy                string `json:"Proxy"`
+	Implementation       string `json:"Implementation"`
+}
+
+type creationResponse struct {
+	ContractAddress string `json:"contractAddress"`
+	ContractCreator string `json:"contractCreator"`
+	TxHash          string `json:"txHash"`
+}
+
+type sourceFile struct {
+	Content string `json:"content"`
+}
+
+// Source returns the verified source of the contract at the given address.
+func (c *Client) Source(ctx context.Context, chainID *big.Int, address common.Address) (*Source, error) {
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "getsourcecode")
+	params.Set("address", address.Hex())
+	var responses []*sourceResponse
+	if err := c.call(ctx, chainID, params, &responses); err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 || responses[0].SourceCode == "" {
+		return nil, ErrNotVerified
+	}
+	response := responses[0]
+
+	source := &Source{
+		Name:            response.ContractName,
+		CompilerVersion: response.CompilerVersion,
+		Optimized:       response.OptimizationUsed == "1",
+		EVMVersion:      response.EVMVersion,
+		License:         response.LicenseType,
+		ABI:             response.ABI,
+	}
+	if response.Runs != "" {
+		runs, err := strconv.ParseUint(response.Runs, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid runs %s", response.Runs)
+		}
+		source.Runs = runs
+	}
+	if response.ConstructorArguments != "" {
+		args, err := hexToBytes(response.ConstructorArguments)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constructor arguments %s", response.ConstructorArguments)
+		}
+		source.ConstructorArguments = args
+	}
+	if response.Proxy == "1" && common.IsHexAddress(response.Implementation) {
+		source.Implementation = optionalAddress(response.Implementation)
+	}
+	files, err := sourceFiles(response)
+	if err != nil {
+		return nil, err
+	}
+	source.Files = files
+
+	return source, nil
+}
+
+// ContractCreation returns the creation details of the contract at the given address.
+func (c *Client) ContractCreation(ctx context.Context, chainID *big.Int, address common.Address) (*Creation, error) {
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "getcontractcreation")
+	params.Set("contractaddresses", address.Hex())
+	var responses []*creationResponse
+	if err := c.call(ctx, chainID, params, &responses); err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no creation details for %s", address.Hex())
+	}
+	return &Creation{
+		Creator:         common.HexToAddress(responses[0].ContractCreator),
+		TransactionHash: common.HexToHash(responses[0].TxHash),
+	}, nil
+}
+
+// sourceFiles returns the source files from the response.  The source is either a single file,
+// a map of files, or Solidity standard JSON input wrapped in an additional pair of braces.
+func sourceFiles(response *sourceResponse) (map[string]string, error) {
+	code := strings.TrimSpace(response.SourceCode)
+	if strings.HasPrefix(code, "{{") && strings.HasSuffix(code, "}}") {
+		input := struct {
+			Sources map[string]*sourceFile `json:"sources"`
+		}{}
+		if err := json.Unmarshal([]byte(code[1:len(code)-1]), &input); err != nil {
+			return nil, fmt.Errorf("invalid standard JSON source: %v", err)
+		}
+		return flattenSources(input.Sources), nil
+	}
+	if strings.HasPrefix(code, "{") {
+		sources := make(map[string]*sourceFile)
+		if err := json.Unmarshal([]byte(code), &sources); err == nil {
+			return flattenSources(sources), nil
+		}
+	}
+
+	extension := ".sol"
+	if strings.HasPrefix(response.CompilerVersion, "vyper") {
+		extension = ".vy"
+	}
+	name := response.ContractName
+	if name == "" {
+		name = "Contract"
+	}
+	return map[string]string{name + extension: response.SourceCode}, nil
+}
+
+// flattenSources returns the contents of the source files.
+func flattenSources(sources map[string]*sourceFile) map[string]string {
+	res := make(map[string]string, len(sources))
+	for path, source := range sources {
+		if source != nil {
+			res[path] = source.Content
+		}
+	}
+	return res
+}
+
+// hexToBytes decodes a hex string, with or without a 0x prefix.
+func hexToBytes(input string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(input, "0x"))
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sourceServer(t *testing.T, sourceCode string) *httptest.Server {
+	code, err := json.Marshal(sourceCode)
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "getsourcecode", r.URL.Query().Get("action"))
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[{"SourceCode":%s,"ABI":"[]","ContractName":"Test","CompilerVersion":"v0.8.13+commit.abaa5c0e","OptimizationUsed":"1","Runs":"200","ConstructorArguments":"0102","EVMVersion":"Default","LicenseType":"MIT","Proxy":"1","Implementation":"%s"}]}`, code, testAddress.Hex())
+	}))
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  string
+		files map[string]string
+	}{
+		{
+			name:  "Single",
+			code:  "contract Test {}",
+			files: map[string]string{"Test.sol": "contract Test {}"},
+		},
+		{
+			name:  "Multiple",
+			code:  `{"A.sol":{"content":"contract A {}"},"B.sol":{"content":"contract B {}"}}`,
+			files: map[string]string{"A.sol": "contract A {}", "B.sol": "contract B {}"},
+		},
+		{
+			name:  "StandardJSON",
+			code:  `{{"language":"Solidity","sources":{"contracts/Test.sol":{"content":"contract Test {}"}},"settings":{}}}`,
+			files: map[string]string{"contracts/Test.sol": "contract Test {}"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := sourceServer(t, test.code)
+			defer server.Close()
+
+			source, err := New("key", server.URL).Source(context.Background(), big.NewInt(1), testAddress)
+			require.NoError(t, err)
+			assert.Equal(t, test.files, source.Files)
+			assert.Equal(t, "Test", source.Name)
+			assert.True(t, source.Optimized)
+			assert.Equal(t, uint64(200), source.Runs)
+			assert.Equal(t, []byte{0x01, 0x02}, source.ConstructorArguments)
+			require.NotNil(t, source.Implementation)
+			assert.Equal(t, testAddress, *source.Implementation)
+		})
+	}
+}
+
+func TestSourceNotVerified(t *testing.T) {
+	server := sourceServer(t, "")
+	defer server.Close()
+
+	_, err := New("key", server.URL).Source(context.Background(), big.NewInt(1), testAddress)
+	require.Equal(t, ErrNotVerified, err)
+}
+
+func TestContractCreation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "getcontractcreation", query.Get("action"))
+		require.Equal(t, testAddress.Hex(), query.Get("contractaddresses"))
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[{"contractAddress":"%s","contractCreator":"0x0000000000000000000000000000000000000001","txHash":"0x1111111111111111111111111111111111111111111111111111111111111111"}]}`, testAddress.Hex())
+	}))
+	defer server.Close()
+
+	creation, err := New("key", server.URL).ContractCreation(context.Background(), big.NewInt(1), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000001"), creation.Creator)
+	assert.Equal(t, common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"), creation.TransactionHash)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ErrAlreadyVerified is returned when the source of a contract has already been verified.
+var ErrAlreadyVerified = errors.New("contract source already verified")
+
+// Verification is a request to verify the source of a contract.
+type Verification struct {
+	// Address is the address of the deployed contract.
+	Address common.Address
+	// Name is the fully qualified name of the contract, in the form path:Name.
+	Name string
+	// CompilerVersion is the full version of the compiler, for example v0.8.13+commit.abaa5c0e.
+	CompilerVersion string
+	// Input is the Solidity standard JSON input used to compile the contract.
+	Input json.RawMessage
+	// ConstructorArguments are the ABI-encoded arguments passed to the constructor.
+	ConstructorArguments []byte
+}
+
+// VerificationStatus is the status of a verification.
+type VerificationStatus struct {
+	Pending  bool
+	Verified bool
+	// Message is the status as reported by the service.
+	Message string
+}
+
+// ABI returns the JSON ABI of the verified contract at the given address.
+func (c *Client) ABI(ctx context.Context, chainID *big.Int, address common.Address) (string, error) {
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "getabi")
+	params.Set("address", address.Hex())
+	res, err := c.request(ctx, chainID, http.MethodGet, params)
+	if err != nil {
+		return "", err
+	}
+	if res.Status != "1" {
+		if strings.Contains(strings.ToLower(res.message()), "not verified") {
+			return "", ErrNotVerified
+		}
+		return "", fmt.Errorf("request failed: %s", res.message())
+	}
+	var abi string
+	if err := json.Unmarshal(res.Result, &abi); err != nil {
+		return "", errors.Wrap(err, "invalid result")
+	}
+	return abi, nil
+}
+
+// Verify submits the source of a contract for verification, returning the GUID of the
+// verification.
+func (c *Client) Verify(ctx context.Context, chainID *big.Int, verification *Verification) (string, error) {
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "verifysourcecode")
+	params.Set("contractaddress", verification.Address.Hex())
+	params.Set("sourceCode", string(verification.Input))
+	params.Set("codeformat", "solidity-standard-json-input")
+	params.Set("contractname", verification.Name)
+	params.Set("compilerversion", "v"+strings.TrimPrefix(verification.CompilerVersion, "v"))
+	// The misspelling is part of the API.
+	params.Set("constructorArguements", hex.EncodeToString(verification.ConstructorArguments))
+
+	res, err := c.request(ctx, chainID, http.MethodPost, params)
+	if err != nil {
+		return "", err
+	}
+	msg := res.message()
+	if res.Status != "1" {
+		if strings.Contains(strings.ToLower(msg), "already verified") {
+			return "", ErrAlreadyVerified
+		}
+		return "", fmt.Errorf("submission failed: %s", msg)
+	}
+	return msg, nil
+}
+
+// VerificationStatus returns the status of the verification with the given GUID.
+func (c *Client) VerificationStatus(ctx context.Context, chainID *big.Int, guid string) (*VerificationStatus, error) {
+	params := url.Values{}
+	params.Set("module", "contract")
+	params.Set("action", "checkverifystatus")
+	params.Set("guid", guid)
+
+	res, err := c.request(ctx, chainID, http.MethodGet, params)
+	if err != nil {
+		return nil, err
+	}
+	msg := res.message()
+	lowerMsg := strings.ToLower(msg)
+	return &VerificationStatus{
+		Pending:  res.Status != "1" && strings.Contains(lowerMsg, "pending"),
+		Verified: res.Status == "1" || strings.Contains(lowerMsg, "already verified"),
+		Message:  msg,
+	}, nil
+}
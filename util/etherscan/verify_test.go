@@ -0,0 +1,118 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "getabi", query.Get("action"))
+		switch query.Get("address") {
+		case testAddress.Hex():
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":"[]"}`)
+		case common.Address{}.Hex():
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Contract source code not verified"}`)
+		default:
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Invalid Address format"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := New("", server.URL)
+
+	abi, err := client.ABI(context.Background(), big.NewInt(1), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", abi)
+
+	_, err = client.ABI(context.Background(), big.NewInt(1), common.Address{})
+	assert.Equal(t, ErrNotVerified, err)
+
+	_, err = client.ABI(context.Background(), big.NewInt(1), common.HexToAddress("0x01"))
+	assert.EqualError(t, err, "request failed: Invalid Address format")
+}
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.Form.Get("action") {
+		case "verifysourcecode":
+			require.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "key", r.PostForm.Get("apikey"))
+			assert.Equal(t, "solidity-standard-json-input", r.PostForm.Get("codeformat"))
+			assert.Equal(t, "v0.8.13+commit.abaa5c0e", r.PostForm.Get("compilerversion"))
+			assert.Equal(t, "0102", r.PostForm.Get("constructorArguements"))
+			if r.PostForm.Get("contractaddress") == testAddress.Hex() {
+				fmt.Fprint(w, `{"status":"1","message":"OK","result":"guid"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Contract source code already verified"}`)
+		case "checkverifystatus":
+			switch r.Form.Get("guid") {
+			case "pending":
+				fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Pending in queue"}`)
+			case "failed":
+				fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Fail - Unable to verify"}`)
+			default:
+				fmt.Fprint(w, `{"status":"1","message":"OK","result":"Pass - Verified"}`)
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := New("key", server.URL)
+	verification := &Verification{
+		Address:              testAddress,
+		Name:                 "contracts/Test.sol:Test",
+		CompilerVersion:      "0.8.13+commit.abaa5c0e",
+		Input:                json.RawMessage(`{"language":"Solidity"}`),
+		ConstructorArguments: []byte{0x01, 0x02},
+	}
+
+	guid, err := client.Verify(context.Background(), big.NewInt(1), verification)
+	require.NoError(t, err)
+	assert.Equal(t, "guid", guid)
+
+	verification.Address = common.Address{}
+	_, err = client.Verify(context.Background(), big.NewInt(1), verification)
+	assert.Equal(t, ErrAlreadyVerified, err)
+
+	tests := []struct {
+		guid     string
+		expected *VerificationStatus
+	}{
+		{guid: "pending", expected: &VerificationStatus{Pending: true, Message: "Pending in queue"}},
+		{guid: "failed", expected: &VerificationStatus{Message: "Fail - Unable to verify"}},
+		{guid: "guid", expected: &VerificationStatus{Verified: true, Message: "Pass - Verified"}},
+	}
+	for _, test := range tests {
+		t.Run(test.guid, func(t *testing.T) {
+			status, err := client.VerificationStatus(context.Background(), big.NewInt(1), test.guid)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, status)
+		})
+	}
+}
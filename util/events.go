@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodeEvent decodes a log against the events in the supplied ABI.
+// It returns the matching event and the event's values, in the order of the event's inputs.
+// Indexed values of dynamic types are stored in the log as hashes, and are returned as such.
+func DecodeEvent(contractAbi *abi.ABI, log *types.Log) (*abi.Event, []interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil, errors.New("log has no topics")
+	}
+	event, err := contractAbi.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonIndexed, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unpack data for event %s: %v", event.Name, err)
+	}
+
+	values := make([]interface{}, len(event.Inputs))
+	topic := 1
+	data := 0
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			values[i] = nonIndexed[data]
+			data++
+			continue
+		}
+		if topic >= len(log.Topics) {
+			return nil, nil, fmt.Errorf("missing topic for indexed input %d of event %s", i, event.Name)
+		}
+		if input.Type.T == abi.TupleTy {
+			// Tuples are hashed, but the ABI parser rejects them.
+			values[i] = log.Topics[topic]
+		} else {
+			res := make(map[string]interface{})
+			if err := abi.ParseTopicsIntoMap(res, abi.Arguments{input}, log.Topics[topic:topic+1]); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse topic for indexed input %d of event %s: %v", i, event.Name, err)
+			}
+			values[i] = res[input.Name]
+		}
+		topic++
+	}
+
+	return event, values, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(`[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`))
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	to := common.HexToAddress("0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845")
+	log := &types.Log{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}
+
+	event, values, err := DecodeEvent(&contractAbi, log)
+	require.NoError(t, err)
+	require.Equal(t, "Transfer", event.Name)
+	require.Equal(t, []interface{}{from, to, big.NewInt(1000)}, values)
+
+	// Unknown event.
+	log.Topics[0] = common.Hash{}
+	_, _, err = DecodeEvent(&contractAbi, log)
+	require.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package explorer selects the online service that holds verified contracts.
+package explorer
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+	"github.com/wealdtech/ethereal/v2/util/sourcify"
+)
+
+// ErrUnknown is returned when the name of the service is not recognised.
+var ErrUnknown = errors.New("unknown service")
+
+// Clients returns a client for the service with the given name, which is one of etherscan,
+// sourcify or blockscout.  Exactly one of the returned clients is set.  The key is the API key,
+// used by Etherscan, and the URL overrides the default endpoint of the service; it is required
+// for Blockscout.
+func Clients(name string, key string, url string) (*etherscan.Client, *sourcify.Client, error) {
+	switch strings.ToLower(name) {
+	case "etherscan":
+		return etherscan.New(key, url), nil, nil
+	case "sourcify":
+		return nil, sourcify.New(url), nil
+	case "blockscout":
+		if url == "" {
+			return nil, nil, errors.New("blockscout requires a URL")
+		}
+		return etherscan.New("", url), nil, nil
+	default:
+		return nil, nil, ErrUnknown
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explorer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClients(t *testing.T) {
+	etherscanClient, sourcifyClient, err := Clients("Etherscan", "key", "")
+	require.NoError(t, err)
+	assert.NotNil(t, etherscanClient)
+	assert.Nil(t, sourcifyClient)
+
+	etherscanClient, sourcifyClient, err = Clients("sourcify", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, etherscanClient)
+	assert.NotNil(t, sourcifyClient)
+
+	etherscanClient, sourcifyClient, err = Clients("blockscout", "", "https://blockscout.example.com/api")
+	require.NoError(t, err)
+	assert.NotNil(t, etherscanClient)
+	assert.Nil(t, sourcifyClient)
+
+	_, _, err = Clients("blockscout", "", "")
+	assert.EqualError(t, err, "blockscout requires a URL")
+
+	_, _, err = Clients("unknown", "", "")
+	assert.True(t, errors.Is(err, ErrUnknown))
+}
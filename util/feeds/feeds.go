@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package feeds provides the addresses of well-known Chainlink price feeds on public chains.
+package feeds
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Feed contains information about a price feed.
+type Feed struct {
+	// ChainID is the ID of the chain on which the feed is deployed.
+	ChainID uint64 `json:"chain_id"`
+	// Pair is the pair priced by the feed, for example "ETH/USD".
+	Pair string `json:"pair"`
+	// Address is the address of the feed's proxy contract.
+	Address common.Address `json:"address"`
+}
+
+var feeds = []*Feed{
+	{ChainID: 1, Pair: "BTC/ETH", Address: common.HexToAddress("0xdeb288F737066589598e9214E782fa5A8eD689e8")},
+	{ChainID: 1, Pair: "BTC/USD", Address: common.HexToAddress("0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c")},
+	{ChainID: 1, Pair: "DAI/USD", Address: common.HexToAddress("0xAed0c38402a5d19df6E4c03F4E2DceD6e29c1ee9")},
+	{ChainID: 1, Pair: "ETH/USD", Address: common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")},
+	{ChainID: 1, Pair: "EUR/USD", Address: common.HexToAddress("0xb49f677943BC038e9857d61E7d053CaA2C1734C1")},
+	{ChainID: 1, Pair: "LINK/ETH", Address: common.HexToAddress("0xDC530D9457755926550b59e8ECcdaE7624181557")},
+	{ChainID: 1, Pair: "LINK/USD", Address: common.HexToAddress("0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c")},
+	{ChainID: 1, Pair: "STETH/USD", Address: common.HexToAddress("0xCfE54B5cD566aB89272946F602D76Ea879CAb4a8")},
+	{ChainID: 1, Pair: "USDC/USD", Address: common.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6")},
+	{ChainID: 1, Pair: "USDT/USD", Address: common.HexToAddress("0x3E7d1eAB13ad0104d2750B8863b489D65364e32D")},
+	{ChainID: 10, Pair: "ETH/USD", Address: common.HexToAddress("0x13e3Ee699D1909E989722E753853AE30b17e08c5")},
+	{ChainID: 137, Pair: "ETH/USD", Address: common.HexToAddress("0xF9680D99D6C9589e2a93a78A04A279e509205945")},
+	{ChainID: 8453, Pair: "ETH/USD", Address: common.HexToAddress("0x71041dddad3595F9CEd3DcCFBe3D1F4b0a16Bb70")},
+	{ChainID: 42161, Pair: "BTC/USD", Address: common.HexToAddress("0x6ce185860a4963106506C203335A2910413708e9")},
+	{ChainID: 42161, Pair: "ETH/USD", Address: common.HexToAddress("0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612")},
+	{ChainID: 11155111, Pair: "BTC/USD", Address: common.HexToAddress("0x1b44F3514812d835EB1BDB0acB33d3fA3351Ee43")},
+	{ChainID: 11155111, Pair: "ETH/USD", Address: common.HexToAddress("0x694AA1769357215DE4FAC081bf1f309aDC325306")},
+	{ChainID: 11155111, Pair: "LINK/USD", Address: common.HexToAddress("0xc59E3633BAAC79493d908e63626716e204A45EdF")},
+}
+
+// ForChain returns the known feeds on the chain with the given ID, ordered by pair.
+func ForChain(chainID uint64) []*Feed {
+	res := make([]*Feed, 0)
+	for _, feed := range feeds {
+		if feed.ChainID == chainID {
+			res = append(res, feed)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Pair < res[j].Pair
+	})
+	return res
+}
+
+// ByPair returns the feed for the given pair on the chain with the given ID, or nil if it is not
+// known.  The pair is case-insensitive, and its assets can be separated by "/", "-" or "_", for
+// example "ETH/USD" or "eth-usd".
+func ByPair(chainID uint64, pair string) *Feed {
+	pair = NormalizePair(pair)
+	for _, feed := range feeds {
+		if feed.ChainID == chainID && feed.Pair == pair {
+			return feed
+		}
+	}
+	return nil
+}
+
+// NormalizePair returns the canonical form of a pair, for example "ETH/USD".
+func NormalizePair(pair string) string {
+	pair = strings.ToUpper(strings.Join(strings.Fields(pair), ""))
+	return strings.NewReplacer("-", "/", "_", "/").Replace(pair)
+}
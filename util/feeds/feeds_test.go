@@ -0,0 +1,97 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feeds
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeds(t *testing.T) {
+	seen := make(map[uint64]map[string]bool)
+	for _, feed := range feeds {
+		assert.NotEqual(t, common.Address{}, feed.Address)
+		assert.Equal(t, NormalizePair(feed.Pair), feed.Pair)
+		if seen[feed.ChainID] == nil {
+			seen[feed.ChainID] = make(map[string]bool)
+		}
+		assert.False(t, seen[feed.ChainID][feed.Pair], "duplicate feed %s on chain %d", feed.Pair, feed.ChainID)
+		seen[feed.ChainID][feed.Pair] = true
+	}
+}
+
+func TestForChain(t *testing.T) {
+	mainnet := ForChain(1)
+	require.NotEmpty(t, mainnet)
+	for i := range mainnet {
+		assert.Equal(t, uint64(1), mainnet[i].ChainID)
+		if i > 0 {
+			assert.True(t, mainnet[i-1].Pair < mainnet[i].Pair)
+		}
+	}
+	assert.Empty(t, ForChain(999999))
+}
+
+func TestByPair(t *testing.T) {
+	tests := []struct {
+		name    string
+		chainID uint64
+		pair    string
+		address string
+	}{
+		{
+			name:    "Canonical",
+			chainID: 1,
+			pair:    "ETH/USD",
+			address: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+		},
+		{
+			name:    "LowerCaseDash",
+			chainID: 1,
+			pair:    "eth-usd",
+			address: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+		},
+		{
+			name:    "Spaces",
+			chainID: 11155111,
+			pair:    "ETH / USD",
+			address: "0x694AA1769357215DE4FAC081bf1f309aDC325306",
+		},
+		{
+			name:    "UnknownPair",
+			chainID: 1,
+			pair:    "FOO/USD",
+		},
+		{
+			name:    "UnknownChain",
+			chainID: 999999,
+			pair:    "ETH/USD",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			feed := ByPair(test.chainID, test.pair)
+			if test.address == "" {
+				assert.Nil(t, feed)
+			} else {
+				require.NotNil(t, feed)
+				assert.Equal(t, common.HexToAddress(test.address), feed.Address)
+			}
+		})
+	}
+}
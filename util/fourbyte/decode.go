@@ -0,0 +1,149 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fourbyte
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Decoded is calldata decoded against a signature.
+type Decoded struct {
+	Signature string
+	Name      string
+	Inputs    abi.Arguments
+	Values    []interface{}
+}
+
+// Decode decodes calldata against the first of the signatures that matches the data.  A signature
+// matches if its selector matches and the data re-encodes to exactly the supplied data, which
+// weeds out most selector collisions.
+func Decode(signatures []string, data []byte) (*Decoded, error) {
+	if len(data) < 4 {
+		return nil, errors.New("data too short")
+	}
+	for _, signature := range signatures {
+		if !bytes.Equal(crypto.Keccak256([]byte(signature))[:4], data[:4]) {
+			continue
+		}
+		name, inputs, err := ParseSignature(signature)
+		if err != nil {
+			continue
+		}
+		values, err := inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		packed, err := inputs.Pack(values...)
+		if err != nil || !bytes.Equal(packed, data[4:]) {
+			continue
+		}
+		return &Decoded{
+			Signature: signature,
+			Name:      name,
+			Inputs:    inputs,
+			Values:    values,
+		}, nil
+	}
+	return nil, errors.New("no matching signature")
+}
+
+// ParseSignature parses a text signature such as "transfer(address,uint256)" in to its name and arguments.
+func ParseSignature(signature string) (string, abi.Arguments, error) {
+	start := strings.Index(signature, "(")
+	if start < 1 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature %s", signature)
+	}
+	name := signature[:start]
+	types, err := splitTypes(signature[start+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, err
+	}
+	args := make(abi.Arguments, len(types))
+	for i := range types {
+		t, err := parseType(types[i])
+		if err != nil {
+			return "", nil, err
+		}
+		args[i] = abi.Argument{Type: t}
+	}
+	return name, args, nil
+}
+
+// parseType parses a type, which can be a tuple in the form (type1,type2,...) optionally followed by array dimensions.
+func parseType(input string) (abi.Type, error) {
+	m, err := marshaling("", input)
+	if err != nil {
+		return abi.Type{}, err
+	}
+	return abi.NewType(m.Type, "", m.Components)
+}
+
+// marshaling turns a type in to the form required to define tuple components.
+func marshaling(name string, input string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(input, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: input}, nil
+	}
+	end := strings.LastIndex(input, ")")
+	if end == -1 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("invalid type %s", input)
+	}
+	types, err := splitTypes(input[1:end])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+	res := abi.ArgumentMarshaling{Name: name, Type: "tuple" + input[end+1:], Components: make([]abi.ArgumentMarshaling, len(types))}
+	for i := range types {
+		res.Components[i], err = marshaling(fmt.Sprintf("c%d", i), types[i])
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+	}
+	return res, nil
+}
+
+// splitTypes splits a comma-separated list of types, respecting parentheses.
+func splitTypes(input string) ([]string, error) {
+	res := make([]string, 0)
+	if input == "" {
+		return res, nil
+	}
+	depth := 0
+	start := 0
+	for i, c := range input {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %s", input)
+			}
+		case ',':
+			if depth == 0 {
+				res = append(res, input[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %s", input)
+	}
+	return append(res, input[start:]), nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fourbyte looks up function selectors in the 4byte signature directory.
+package fourbyte
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// DefaultURL is the default URL for the 4byte directory API.
+const DefaultURL = "https://www.4byte.directory/api/v1/signatures/"
+
+// Directory is a 4byte signature directory.
+type Directory struct {
+	url      string
+	cacheDir string
+}
+
+type signaturesResponse struct {
+	Next    *string `json:"next"`
+	Results []struct {
+		ID            int    `json:"id"`
+		TextSignature string `json:"text_signature"`
+	} `json:"results"`
+}
+
+// New creates a new directory.  If cacheDir is not empty results are cached in that directory.
+func New(url string, cacheDir string) *Directory {
+	if url == "" {
+		url = DefaultURL
+	}
+	return &Directory{
+		url:      url,
+		cacheDir: cacheDir,
+	}
+}
+
+// Signatures returns the text signatures that match the given selector, oldest first.
+func (d *Directory) Signatures(ctx context.Context, selector []byte) ([]string, error) {
+	if len(selector) < 4 {
+		return nil, errors.New("selector must be 4 bytes")
+	}
+	selectorStr := fmt.Sprintf("0x%s", hex.EncodeToString(selector[:4]))
+
+	var path string
+	if d.cacheDir != "" {
+		path = filepath.Join(d.cacheDir, fmt.Sprintf("%s.json", selectorStr))
+		if data, err := ioutil.ReadFile(path); err == nil {
+			var signatures []string
+			if err := json.Unmarshal(data, &signatures); err == nil {
+				return signatures, nil
+			}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?hex_signature=%s", d.url, selectorStr), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("4byte directory returned status %d", resp.StatusCode)
+	}
+	var res signaturesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+
+	// The oldest signature is usually the genuine one, with later ones being collisions.
+	sort.Slice(res.Results, func(i, j int) bool {
+		return res.Results[i].ID < res.Results[j].ID
+	})
+	signatures := make([]string, len(res.Results))
+	for i := range res.Results {
+		signatures[i] = res.Results[i].TextSignature
+	}
+
+	if path != "" {
+		// Failure to cache is not fatal.
+		if data, err := json.Marshal(signatures); err == nil {
+			if err := os.MkdirAll(d.cacheDir, 0700); err == nil {
+				_ = ioutil.WriteFile(path, data, 0600)
+			}
+		}
+	}
+
+	return signatures, nil
+}
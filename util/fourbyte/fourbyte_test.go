@@ -0,0 +1,99 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fourbyte
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatures(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "0xa9059cbb", r.URL.Query().Get("hex_signature"))
+		fmt.Fprint(w, `{"count":2,"next":null,"previous":null,"results":[{"id":31780,"text_signature":"many_msg_babbage(bytes1)","hex_signature":"0xa9059cbb"},{"id":145,"text_signature":"transfer(address,uint256)","hex_signature":"0xa9059cbb"}]}`)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "fourbyte")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	directory := New(server.URL, dir)
+	for i := 0; i < 2; i++ {
+		signatures, err := directory.Signatures(context.Background(), []byte{0xa9, 0x05, 0x9c, 0xbb})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"transfer(address,uint256)", "many_msg_babbage(bytes1)"}, signatures)
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestDecode(t *testing.T) {
+	data := common.FromHex("0xa9059cbb0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc400000000000000000000000000000000000000000000000000000000000003e8")
+
+	decoded, err := Decode([]string{"many_msg_babbage(bytes1)", "transfer(address,uint256)"}, data)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", decoded.Signature)
+	assert.Equal(t, "transfer", decoded.Name)
+	require.Len(t, decoded.Values, 2)
+	assert.Equal(t, common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), decoded.Values[0])
+	assert.Equal(t, big.NewInt(1000), decoded.Values[1])
+
+	_, err = Decode([]string{"many_msg_babbage(bytes1)"}, data)
+	assert.EqualError(t, err, "no matching signature")
+}
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		name      string
+		types     []string
+		err       string
+	}{
+		{signature: "totalSupply()", name: "totalSupply", types: []string{}},
+		{signature: "transfer(address,uint256)", name: "transfer", types: []string{"address", "uint256"}},
+		{signature: "swap((address,uint256)[],bytes)", name: "swap", types: []string{"(address,uint256)[]", "bytes"}},
+		{signature: "f((uint8,(bool,string)))", name: "f", types: []string{"(uint8,(bool,string))"}},
+		{signature: "f(uint256", err: "invalid signature f(uint256"},
+		{signature: "f((uint256)", err: "unbalanced parentheses in (uint256"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.signature, func(t *testing.T) {
+			name, args, err := ParseSignature(test.signature)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.name, name)
+			types := make([]string, len(args))
+			for i := range args {
+				types[i] = args[i].Type.String()
+			}
+			assert.Equal(t, test.types, types)
+		})
+	}
+}
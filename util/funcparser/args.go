@@ -15,6 +15,10 @@ package funcparser
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -29,12 +33,75 @@ func ParseCall(client *ethclient.Client, contract *util.Contract, call string) (
 		return nil, nil, errors.New("no contract")
 	}
 
+	method, call, err := selectMethod(contract, call)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	is := antlr.NewInputStream(call)
 	lexer := parser.NewFuncLexer(is)
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	tree := parser.NewFuncParser(stream).Start()
 	methodListener := newMethodListener(client, contract)
+	methodListener.method = method
 	antlr.ParseTreeWalkerDefault.Walk(methodListener, tree)
 
 	return methodListener.method, methodListener.args, methodListener.err
 }
+
+// signatureCallRe matches a call that starts with a full signature, e.g. "foo(uint256,bool)(1,true)".
+var signatureCallRe = regexp.MustCompile(`^\s*([a-zA-Z_$][a-zA-Z0-9_$]*)(\([a-z0-9\[\](),\s]*\))\s*(\(.*\))\s*$`)
+
+// selectMethod selects the method for a call, allowing for overloaded methods.  The method can be
+// specified by its full signature, in which case the call is rewritten to remove it; otherwise an
+// overloaded method is selected by its number of arguments.
+func selectMethod(contract *util.Contract, call string) (*abi.Method, string, error) {
+	if match := signatureCallRe.FindStringSubmatch(call); match != nil {
+		sig := match[1] + strings.Join(strings.Fields(match[2]), "")
+		for _, method := range contract.Abi.Methods {
+			if method.Sig == sig {
+				method := method
+				return &method, match[1] + match[3], nil
+			}
+		}
+		return nil, "", fmt.Errorf("unknown method signature %s", sig)
+	}
+
+	start := strings.Index(call, "(")
+	end := strings.LastIndex(call, ")")
+	if start == -1 || end < start {
+		// Leave it to the parser to complain.
+		return nil, call, nil
+	}
+	name := strings.TrimSpace(call[:start])
+	candidates := make([]abi.Method, 0)
+	for _, method := range contract.Abi.Methods {
+		if method.RawName == name {
+			candidates = append(candidates, method)
+		}
+	}
+	if len(candidates) < 2 {
+		// Not overloaded; leave it to the parser.
+		return nil, call, nil
+	}
+
+	args, err := splitArrayElements(fmt.Sprintf("[%s]", call[start+1:end]))
+	if err != nil {
+		return nil, "", err
+	}
+	matches := make([]abi.Method, 0)
+	for _, candidate := range candidates {
+		if len(candidate.Inputs) == len(args) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 1 {
+		return &matches[0], call, nil
+	}
+	sigs := make([]string, len(candidates))
+	for i := range candidates {
+		sigs[i] = candidates[i].Sig
+	}
+	sort.Strings(sigs)
+	return nil, "", fmt.Errorf("method %s is overloaded; specify the signature before the arguments, e.g. %s(...) (available signatures are %s)", name, sigs[0], strings.Join(sigs, ", "))
+}
@@ -15,10 +15,9 @@ package funcparser
 
 import (
 	"fmt"
-	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/wealdtech/ethereal/v2/util"
 	"github.com/wealdtech/ethereal/v2/util/funcparser/parser"
@@ -30,9 +29,9 @@ type methodListener struct {
 	client   *ethclient.Client
 	contract *util.Contract
 	curArg   int
-	// Arrays are all of the same type but can be nested.
-	curArray      []interface{}
-	maxArrayLevel int
+	// Arrays can be nested; these hold the arrays being built and their types.
+	curArray      []reflect.Value
+	curArrayTypes []*abi.Type
 	// Result of parsing the argument
 	method *abi.Method
 	args   []interface{}
@@ -50,6 +49,10 @@ func newMethodListener(client *ethclient.Client, contract *util.Contract) *metho
 }
 
 func (l *methodListener) EnterFuncName(c *parser.FuncNameContext) {
+	if l.method != nil {
+		// Method already selected.
+		return
+	}
 	// Ensure we have the function in the contract
 	if c.GetText() == "constructor" {
 		l.method = &l.contract.Abi.Constructor
@@ -104,7 +107,14 @@ func (l *methodListener) EnterStringArg(c *parser.StringArgContext) {
 	if l.err == nil {
 		input := l.method.Inputs[l.curArg]
 		baseType := baseType(&input.Type)
-		arg, err := StrToStr(baseType, c.GetText())
+		var arg interface{}
+		var err error
+		switch baseType.T {
+		case abi.TupleTy:
+			arg, err = StrToTuple(baseType, c.GetText())
+		default:
+			arg, err = StrToStr(baseType, c.GetText())
+		}
 		if err != nil {
 			l.err = err
 		} else {
@@ -119,127 +129,47 @@ func (l *methodListener) EnterArrayArg(c *parser.ArrayArgContext) {
 			l.err = fmt.Errorf("too many arguments for method at %s", c.GetText())
 			return
 		}
-		input := l.method.Inputs[l.curArg]
-		baseType := baseType(&input.Type)
-		level := arrayLevel(&input.Type)
-		if len(l.curArray) == 0 {
-			// New array
-			l.curArray = make([]interface{}, 0)
-			l.maxArrayLevel = level
+		// Work out the type of this array from its parent.
+		var arrayType *abi.Type
+		if len(l.curArrayTypes) == 0 {
+			arrayType = &l.method.Inputs[l.curArg].Type
 		} else {
-			// Extend existing array
-			level -= len(l.curArray)
+			arrayType = l.curArrayTypes[len(l.curArrayTypes)-1].Elem
 		}
-		for ; level > 0; level-- {
-			array, err := makeArray(baseType, level)
-			if err != nil {
-				l.err = err
-				return
-			}
-			l.curArray = append(l.curArray, array)
+		if arrayType.T != abi.SliceTy && arrayType.T != abi.ArrayTy {
+			l.err = fmt.Errorf("unexpected array %s for type %v", c.GetText(), arrayType)
+			return
 		}
+		l.curArray = append(l.curArray, reflect.MakeSlice(reflect.SliceOf(arrayType.Elem.GetType()), 0, 0))
+		l.curArrayTypes = append(l.curArrayTypes, arrayType)
 	}
 }
 
-// nolint:gocyclo
 func (l *methodListener) ExitArrayArg(c *parser.ArrayArgContext) {
 	if l.err == nil {
-		level := len(l.curArray)
-		if level == 1 {
-			// Only array; push to args
-			l.args = append(l.args, l.curArray[0])
-		} else {
-			// Nested arrays; push to one above
-			input := l.method.Inputs[l.curArg]
-			baseType := baseType(&input.Type)
-			level = l.maxArrayLevel + 1 - level
-			parent := len(l.curArray) - 2
-			child := len(l.curArray) - 1
-			if level == 1 {
-				switch baseType.T {
-				case abi.IntTy:
-					switch baseType.Size {
-					case 8:
-						l.curArray[parent] = append(l.curArray[parent].([][]int8), l.curArray[child].([]int8))
-					case 16:
-						l.curArray[parent] = append(l.curArray[parent].([][]int16), l.curArray[child].([]int16))
-					case 32:
-						l.curArray[parent] = append(l.curArray[parent].([][]int32), l.curArray[child].([]int32))
-					case 64:
-						l.curArray[parent] = append(l.curArray[parent].([][]int64), l.curArray[child].([]int64))
-					default:
-						l.curArray[parent] = append(l.curArray[parent].([][]*big.Int), l.curArray[child].([]*big.Int))
-					}
-				case abi.UintTy:
-					switch baseType.Size {
-					case 8:
-						l.curArray[parent] = append(l.curArray[parent].([][]uint8), l.curArray[child].([]uint8))
-					case 16:
-						l.curArray[parent] = append(l.curArray[parent].([][]uint16), l.curArray[child].([]uint16))
-					case 32:
-						l.curArray[parent] = append(l.curArray[parent].([][]uint32), l.curArray[child].([]uint32))
-					case 64:
-						l.curArray[parent] = append(l.curArray[parent].([][]uint64), l.curArray[child].([]uint64))
-					default:
-						l.curArray[parent] = append(l.curArray[parent].([][]*big.Int), l.curArray[child].([]*big.Int))
-					}
-				case abi.BoolTy:
-					l.curArray[parent] = append(l.curArray[parent].([][]bool), l.curArray[child].([]bool))
-				case abi.StringTy:
-					l.curArray[parent] = append(l.curArray[parent].([][]string), l.curArray[child].([]string))
-				case abi.AddressTy:
-					l.curArray[parent] = append(l.curArray[parent].([][]common.Address), l.curArray[child].([]common.Address))
-				case abi.HashTy:
-					l.curArray[parent] = append(l.curArray[parent].([][]common.Hash), l.curArray[child].([]common.Hash))
-				case abi.BytesTy, abi.FixedBytesTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][]byte), l.curArray[child].([][]byte))
-				default:
-					l.curArray[parent] = append(l.curArray[parent].([]interface{}), l.curArray[child])
-				}
-			} else if level == 2 {
-				switch baseType.T {
-				case abi.IntTy:
-					switch baseType.Size {
-					case 8:
-						l.curArray[parent] = append(l.curArray[parent].([][][]int8), l.curArray[child].([][]int8))
-					case 16:
-						l.curArray[parent] = append(l.curArray[parent].([][][]int16), l.curArray[child].([][]int16))
-					case 32:
-						l.curArray[parent] = append(l.curArray[parent].([][][]int32), l.curArray[child].([][]int32))
-					case 64:
-						l.curArray[parent] = append(l.curArray[parent].([][][]int64), l.curArray[child].([][]int64))
-					default:
-						l.curArray[parent] = append(l.curArray[parent].([][][]*big.Int), l.curArray[child].([][]*big.Int))
-					}
-				case abi.UintTy:
-					switch baseType.Size {
-					case 8:
-						l.curArray[parent] = append(l.curArray[parent].([][][]uint8), l.curArray[child].([][]uint8))
-					case 16:
-						l.curArray[parent] = append(l.curArray[parent].([][][]uint16), l.curArray[child].([][]uint16))
-					case 32:
-						l.curArray[parent] = append(l.curArray[parent].([][][]uint32), l.curArray[child].([][]uint32))
-					case 64:
-						l.curArray[parent] = append(l.curArray[parent].([][][]uint64), l.curArray[child].([][]uint64))
-					default:
-						l.curArray[parent] = append(l.curArray[parent].([][][]*big.Int), l.curArray[child].([][]*big.Int))
-					}
-				case abi.BoolTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][]bool), l.curArray[child].([][]bool))
-				case abi.StringTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][]string), l.curArray[child].([][]string))
-				case abi.AddressTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][]common.Address), l.curArray[child].([][]common.Address))
-				case abi.HashTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][]common.Hash), l.curArray[child].([][]common.Hash))
-				case abi.BytesTy, abi.FixedBytesTy:
-					l.curArray[parent] = append(l.curArray[parent].([][][][]byte), l.curArray[child].([][][]byte))
-				default:
-					l.curArray[parent] = append(l.curArray[parent].([][]interface{}), l.curArray[child].([]interface{}))
-				}
+		array := l.curArray[len(l.curArray)-1]
+		arrayType := l.curArrayTypes[len(l.curArrayTypes)-1]
+		l.curArray = l.curArray[:len(l.curArray)-1]
+		l.curArrayTypes = l.curArrayTypes[:len(l.curArrayTypes)-1]
+
+		if arrayType.T == abi.ArrayTy {
+			// Fixed-size arrays must have the exact number of elements.
+			if array.Len() != arrayType.Size {
+				l.err = fmt.Errorf("expected %d elements for %v, found %d", arrayType.Size, arrayType, array.Len())
+				return
 			}
+			fixed := reflect.New(arrayType.GetType()).Elem()
+			reflect.Copy(fixed, array)
+			array = fixed
+		}
+
+		if len(l.curArray) == 0 {
+			// Outermost array; push to args
+			l.args = append(l.args, array.Interface())
+		} else {
+			// Nested array; push to parent
+			l.curArray[len(l.curArray)-1] = reflect.Append(l.curArray[len(l.curArray)-1], array)
 		}
-		l.curArray = l.curArray[:len(l.curArray)-1]
 	}
 }
 
@@ -251,7 +181,11 @@ func (l *methodListener) EnterDomainArg(c *parser.DomainArgContext) {
 		baseType := baseType(&input.Type)
 		switch baseType.T {
 		case abi.AddressTy:
-			arg, err = ens.Resolve(l.client, c.GetText()[1:])
+			if l.client == nil {
+				err = fmt.Errorf("cannot resolve %s without a connection", c.GetText()[1:])
+			} else {
+				arg, err = ens.Resolve(l.client, c.GetText()[1:])
+			}
 		default:
 			err = fmt.Errorf("unexpected type %v", baseType)
 		}
@@ -315,159 +249,17 @@ func baseType(inputType *abi.Type) *abi.Type {
 	}
 }
 
-// arrayLevel returns the number of levels of array in the type
-func arrayLevel(inputType *abi.Type) int {
-	switch inputType.T {
-	case abi.SliceTy, abi.ArrayTy:
-		return 1 + arrayLevel(inputType.Elem)
-	default:
-		return 0
-	}
-}
-
 func (l *methodListener) pushArg(arg interface{}) {
 	if len(l.curArray) == 0 {
 		l.args = append(l.args, arg)
-	} else {
-		input := l.method.Inputs[l.curArg]
-		baseType := baseType(&input.Type)
-		switch baseType.T {
-		case abi.IntTy:
-			switch baseType.Size {
-			case 8:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]int8), arg.(int8))
-			case 16:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]int16), arg.(int16))
-			case 32:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]int32), arg.(int32))
-			case 64:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]int64), arg.(int64))
-			default:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]*big.Int), arg.(*big.Int))
-			}
-		case abi.UintTy:
-			switch baseType.Size {
-			case 8:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]uint8), arg.(uint8))
-			case 16:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]uint16), arg.(uint16))
-			case 32:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]uint32), arg.(uint32))
-			case 64:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]uint64), arg.(uint64))
-			default:
-				l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]*big.Int), arg.(*big.Int))
-			}
-		case abi.BoolTy:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]bool), arg.(bool))
-		case abi.StringTy:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]string), arg.(string))
-		case abi.AddressTy:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]common.Address), arg.(common.Address))
-		case abi.HashTy:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]common.Hash), arg.(common.Hash))
-		case abi.BytesTy, abi.FixedBytesTy:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([][]byte), arg.([]byte))
-		default:
-			l.curArray[len(l.curArray)-1] = append(l.curArray[len(l.curArray)-1].([]interface{}), arg)
-		}
+		return
 	}
-}
 
-// nolint:gocyclo
-func makeArray(baseType *abi.Type, level int) (interface{}, error) {
-	switch level {
-	case 1:
-		return makeArray1(baseType)
-	case 2:
-		return makeArray2(baseType)
-	default:
-		return nil, fmt.Errorf("unhandled nesting level %d", level)
-	}
-}
-
-func makeArray2(baseType *abi.Type) (interface{}, error) {
-	switch baseType.T {
-	case abi.IntTy:
-		switch baseType.Size {
-		case 8:
-			return make([][]int8, 0), nil
-		case 16:
-			return make([][]int16, 0), nil
-		case 32:
-			return make([][]int32, 0), nil
-		case 64:
-			return make([][]int64, 0), nil
-		default:
-			return make([][]*big.Int, 0), nil
-		}
-	case abi.UintTy:
-		switch baseType.Size {
-		case 8:
-			return make([][]uint8, 0), nil
-		case 16:
-			return make([][]uint16, 0), nil
-		case 32:
-			return make([][]uint32, 0), nil
-		case 64:
-			return make([][]uint64, 0), nil
-		default:
-			return make([][]*big.Int, 0), nil
-		}
-	case abi.BoolTy:
-		return make([][]bool, 0), nil
-	case abi.StringTy:
-		return make([][]string, 0), nil
-	case abi.AddressTy:
-		return make([][]common.Address, 0), nil
-	case abi.HashTy:
-		return make([][]common.Hash, 0), nil
-	case abi.BytesTy, abi.FixedBytesTy:
-		return make([][][]byte, 0), nil
-	default:
-		return nil, fmt.Errorf("unhandled array type %v", baseType.T)
-	}
-}
-
-func makeArray1(baseType *abi.Type) (interface{}, error) {
-	switch baseType.T {
-	case abi.IntTy:
-		switch baseType.Size {
-		case 8:
-			return make([]int8, 0), nil
-		case 16:
-			return make([]int16, 0), nil
-		case 32:
-			return make([]int32, 0), nil
-		case 64:
-			return make([]int64, 0), nil
-		default:
-			return make([]*big.Int, 0), nil
-		}
-	case abi.UintTy:
-		switch baseType.Size {
-		case 8:
-			return make([]uint8, 0), nil
-		case 16:
-			return make([]uint16, 0), nil
-		case 32:
-			return make([]uint32, 0), nil
-		case 64:
-			return make([]uint64, 0), nil
-		default:
-			return make([]*big.Int, 0), nil
-		}
-	case abi.BoolTy:
-		return make([]bool, 0), nil
-	case abi.StringTy:
-		return make([]string, 0), nil
-	case abi.AddressTy:
-		return make([]common.Address, 0), nil
-	case abi.HashTy:
-		return make([]common.Hash, 0), nil
-	case abi.BytesTy, abi.FixedBytesTy:
-		return make([][]byte, 0), nil
-	default:
-		return nil, fmt.Errorf("unhandled array type %v", baseType.T)
+	elemType := l.curArrayTypes[len(l.curArrayTypes)-1].Elem
+	val := reflect.ValueOf(arg)
+	if !val.Type().ConvertibleTo(elemType.GetType()) {
+		l.err = fmt.Errorf("cannot use %v as array element of type %v", arg, elemType)
+		return
 	}
+	l.curArray[len(l.curArray)-1] = reflect.Append(l.curArray[len(l.curArray)-1], val.Convert(elemType.GetType()))
 }
@@ -154,16 +154,61 @@ func TestParse(t *testing.T) {
 			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"}]"}}}`,
 			input: `constructor(12345)`,
 		},
+		{ // 17 - tuple parameter as object
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"components\":[{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"arg1\",\"type\":\"tuple\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test('{"owner":"0x008b7768c04a0c750C3D6b58d44Ff5041DD90480","amount":5}')`,
+		},
+		{ // 18 - tuple parameter as array with nested array
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"components\":[{\"name\":\"flag\",\"type\":\"bool\"},{\"name\":\"values\",\"type\":\"uint8[]\"}],\"name\":\"arg1\",\"type\":\"tuple\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test('[true,[1,2,3]]')`,
+		},
+		{ // 19 - fixed array of uint parameters
+			json:   `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"uint256[3]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input:  `test([1,2,3])`,
+			output: []interface{}{[3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+		},
+		{ // 20 - array of fixed bytes parameters
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"bytes4[]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test([0x01020304,0x05060708])`,
+			output: []interface{}{[][4]byte{
+				{0x01, 0x02, 0x03, 0x04},
+				{0x05, 0x06, 0x07, 0x08},
+			}},
+		},
+		{ // 21 - triple-nested array of uint8 parameters
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"uint8[][2][]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test([[[1],[2,3]],[[4,5,6],[]]])`,
+			output: []interface{}{[][2][]uint8{
+				{{1}, {2, 3}},
+				{{4, 5, 6}, {}},
+			}},
+		},
+		{ // 22 - array of tuple parameters
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"components\":[{\"name\":\"flag\",\"type\":\"bool\"}],\"name\":\"arg1\",\"type\":\"tuple[]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test(['{"flag":true}','{"flag":false}'])`,
+		},
+		{ // 23 - address and string arrays together
+			json:  `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"address[]\"},{\"name\":\"arg2\",\"type\":\"string[]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`,
+			input: `test([0x008b7768c04a0c750C3D6b58d44Ff5041DD90480],["foo","bar"])`,
+			output: []interface{}{
+				[]common.Address{common.HexToAddress("0x008b7768c04a0c750C3D6b58d44Ff5041DD90480")},
+				[]string{"foo", "bar"},
+			},
+		},
 	}
 
 	for i, test := range tests {
 		contract, err := util.ParseCombinedJSON(test.json, "Test")
 		require.Nil(t, err, fmt.Sprintf("failed to parse contract JSON at test %d", i))
-		_, args, err := ParseCall(nil, contract, test.input)
+		method, args, err := ParseCall(nil, contract, test.input)
 		assert.Nil(t, err, fmt.Sprintf("failed to parse call at test %d", i))
 		if test.output != nil {
 			assert.Equal(t, test.output, args, fmt.Sprintf("incorrect value at test %d", i))
 		}
+		if err == nil {
+			_, err = contract.Abi.Pack(method.Name, args...)
+			assert.Nil(t, err, fmt.Sprintf("failed to pack arguments at test %d", i))
+		}
 	}
 }
 
@@ -171,3 +216,44 @@ func _bytes(input string) []byte {
 	bytes, _ := hex.DecodeString(input)
 	return bytes
 }
+
+func TestParseBadArray(t *testing.T) {
+	json := `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"arg1\",\"type\":\"uint256[3]\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`
+	contract, err := util.ParseCombinedJSON(json, "Test")
+	require.Nil(t, err)
+	_, _, err = ParseCall(nil, contract, `test([1,2])`)
+	require.EqualError(t, err, "expected 3 elements for uint256[3], found 2")
+}
+
+func TestParseOverloaded(t *testing.T) {
+	json := `{"contracts":{"Test.sol:Test":{"abi":"[{\"inputs\":[{\"name\":\"a\",\"type\":\"uint256\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"a\",\"type\":\"address\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"a\",\"type\":\"uint256\"},{\"name\":\"b\",\"type\":\"bytes\"}],\"name\":\"test\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"}}}`
+	contract, err := util.ParseCombinedJSON(json, "Test")
+	require.Nil(t, err)
+
+	tests := []struct {
+		input string
+		sig   string
+		err   string
+	}{
+		{input: `test(uint256)(5)`, sig: "test(uint256)"},
+		{input: `test(address)(0x5FfC014343cd971B7eb70732021E26C35B744cc4)`, sig: "test(address)"},
+		{input: `test(uint256, bytes) (5, 0x01)`, sig: "test(uint256,bytes)"},
+		{input: `test(5, 0x01)`, sig: "test(uint256,bytes)"},
+		{input: `test(5)`, err: "method test is overloaded; specify the signature before the arguments, e.g. test(address)(...) (available signatures are test(address), test(uint256), test(uint256,bytes))"},
+		{input: `test(bool)(true)`, err: "unknown method signature test(bool)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			method, args, err := ParseCall(nil, contract, test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.sig, method.Sig)
+			_, err = contract.Abi.Pack(method.Name, args...)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -15,6 +15,7 @@ package funcparser
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -42,21 +43,10 @@ func StrTo(inputType *abi.Type, input string) (interface{}, error) {
 		return StrToHash(inputType, input)
 	case abi.BytesTy, abi.FixedBytesTy:
 		return StrToBytes(inputType, input)
-		//	case abi.ArrayTy, abi.SliceTy:
-		//		baseType := baseType(inputType)
-		//		level := arrayLevel(inputType)
-		//		res, err := makeArray(baseType, level)
-		//		if err != nil {
-		//			return nil, err
-		//		}
-		//		for i := 0; i < arrayVal.Len(); i++ {
-		//			elemRes, err := StrTo(inputType.Elem, fmt.Sprintf("%v", arrayVal.Index(i).Interface()))
-		//			if err != nil {
-		//				return nil, err
-		//			}
-		//			res = append(res, elemRes)
-		//		}
-		//		return res, nil
+	case abi.TupleTy:
+		return StrToTuple(inputType, input)
+	case abi.ArrayTy, abi.SliceTy:
+		return StrToArray(inputType, input)
 	default:
 		return nil, fmt.Errorf("unhandled type %v", inputType)
 	}
@@ -220,3 +210,183 @@ func StrToBytes(inputType *abi.Type, input string) (interface{}, error) {
 	}
 	return nil, fmt.Errorf("invalid byte size %d", inputType.Size)
 }
+
+// StrToTuple turns a string in to a tuple type as given by the ABI information.
+// The string is a JSON object keyed by component name, or a JSON array with the
+// components in order, optionally surrounded by quotes.
+func StrToTuple(inputType *abi.Type, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if len(input) > 1 && (input[0] == '"' || input[0] == '\'') && input[len(input)-1] == input[0] {
+		input = input[1 : len(input)-1]
+	}
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid tuple %s: %v", input, err)
+	}
+	val, err := jsonToValue(inputType, data)
+	if err != nil {
+		return nil, err
+	}
+	return val.Interface(), nil
+}
+
+// jsonToValue turns a decoded JSON value in to a value of the type given by the ABI information.
+func jsonToValue(inputType *abi.Type, data interface{}) (reflect.Value, error) {
+	switch inputType.T {
+	case abi.TupleTy:
+		res := reflect.New(inputType.TupleType).Elem()
+		switch components := data.(type) {
+		case map[string]interface{}:
+			if len(components) != len(inputType.TupleElems) {
+				return reflect.Value{}, fmt.Errorf("expected %d components for tuple, found %d", len(inputType.TupleElems), len(components))
+			}
+			for i, elem := range inputType.TupleElems {
+				component, exists := components[inputType.TupleRawNames[i]]
+				if !exists {
+					return reflect.Value{}, fmt.Errorf("missing tuple component %q", inputType.TupleRawNames[i])
+				}
+				val, err := jsonToValue(elem, component)
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("invalid tuple component %q: %v", inputType.TupleRawNames[i], err)
+				}
+				res.Field(i).Set(val)
+			}
+		case []interface{}:
+			if len(components) != len(inputType.TupleElems) {
+				return reflect.Value{}, fmt.Errorf("expected %d components for tuple, found %d", len(inputType.TupleElems), len(components))
+			}
+			for i, elem := range inputType.TupleElems {
+				val, err := jsonToValue(elem, components[i])
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("invalid tuple component %d: %v", i, err)
+				}
+				res.Field(i).Set(val)
+			}
+		default:
+			return reflect.Value{}, fmt.Errorf("tuple must be a JSON object or array")
+		}
+		return res, nil
+	case abi.SliceTy, abi.ArrayTy:
+		items, isArray := data.([]interface{})
+		if !isArray {
+			return reflect.Value{}, fmt.Errorf("expected array for %v", inputType)
+		}
+		if inputType.T == abi.ArrayTy && len(items) != inputType.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d elements for %v, found %d", inputType.Size, inputType, len(items))
+		}
+		res := reflect.MakeSlice(reflect.SliceOf(inputType.Elem.GetType()), 0, len(items))
+		for _, item := range items {
+			val, err := jsonToValue(inputType.Elem, item)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			res = reflect.Append(res, val)
+		}
+		if inputType.T == abi.ArrayTy {
+			array := reflect.New(inputType.GetType()).Elem()
+			reflect.Copy(array, res)
+			return array, nil
+		}
+		return res, nil
+	case abi.StringTy:
+		str, isString := data.(string)
+		if !isString {
+			return reflect.Value{}, fmt.Errorf("expected string, found %v", data)
+		}
+		return reflect.ValueOf(str), nil
+	default:
+		var str string
+		switch val := data.(type) {
+		case string:
+			str = val
+		case json.Number:
+			str = val.String()
+		case bool:
+			str = fmt.Sprintf("%t", val)
+		default:
+			return reflect.Value{}, fmt.Errorf("unexpected value %v for %v", data, inputType)
+		}
+		val, err := StrTo(inputType, str)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(val).Convert(inputType.GetType()), nil
+	}
+}
+
+// StrToArray turns a string in to an array or slice type as given by the ABI information.
+// The string is a bracketed, comma-separated list of elements, for example "[1,2,3]"; arrays
+// can be nested, for example "[[1,2],[3,4]]".
+func StrToArray(inputType *abi.Type, input string) (interface{}, error) {
+	elements, err := splitArrayElements(input)
+	if err != nil {
+		return nil, err
+	}
+	if inputType.T == abi.ArrayTy && len(elements) != inputType.Size {
+		return nil, fmt.Errorf("expected %d elements for %v, found %d", inputType.Size, inputType, len(elements))
+	}
+
+	res := reflect.MakeSlice(reflect.SliceOf(inputType.Elem.GetType()), 0, len(elements))
+	for _, element := range elements {
+		if inputType.Elem.T == abi.StringTy && (len(element) < 2 || (element[0] != '"' && element[0] != '\'')) {
+			// Strings within arrays may be unquoted.
+			element = fmt.Sprintf("%q", element)
+		}
+		val, err := StrTo(inputType.Elem, element)
+		if err != nil {
+			return nil, err
+		}
+		res = reflect.Append(res, reflect.ValueOf(val).Convert(inputType.Elem.GetType()))
+	}
+
+	if inputType.T == abi.ArrayTy {
+		array := reflect.New(inputType.GetType()).Elem()
+		reflect.Copy(array, res)
+		return array.Interface(), nil
+	}
+	return res.Interface(), nil
+}
+
+// splitArrayElements splits a bracketed array string in to its top-level elements,
+// respecting nested brackets, braces and quoted strings.
+func splitArrayElements(input string) ([]string, error) {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "[") || !strings.HasSuffix(input, "]") {
+		return nil, fmt.Errorf("array %s must be surrounded by brackets", input)
+	}
+	input = input[1 : len(input)-1]
+
+	elements := make([]string, 0)
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[' || r == '{':
+			depth++
+		case r == ']' || r == '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced brackets in array")
+			}
+		case r == ',' && depth == 0:
+			elements = append(elements, strings.TrimSpace(input[start:i]))
+			start = i + 1
+		}
+	}
+	if depth != 0 || quote != 0 {
+		return nil, fmt.Errorf("unterminated element in array")
+	}
+	if last := strings.TrimSpace(input[start:]); last != "" || len(elements) > 0 {
+		elements = append(elements, last)
+	}
+	return elements, nil
+}
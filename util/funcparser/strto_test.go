@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funcparser
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrToArray(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		input  string
+		output interface{}
+		err    string
+	}{
+		{
+			name:   "Empty",
+			typ:    "uint256[]",
+			input:  "[]",
+			output: []*big.Int{},
+		},
+		{
+			name:   "Uints",
+			typ:    "uint256[]",
+			input:  "[1, 2, 3]",
+			output: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		},
+		{
+			name:   "Fixed",
+			typ:    "uint8[2]",
+			input:  "[1,2]",
+			output: [2]uint8{1, 2},
+		},
+		{
+			name:  "FixedWrongSize",
+			typ:   "uint8[2]",
+			input: "[1,2,3]",
+			err:   "expected 2 elements for uint8[2], found 3",
+		},
+		{
+			name:   "Nested",
+			typ:    "uint16[][]",
+			input:  "[[1,2],[3]]",
+			output: [][]uint16{{1, 2}, {3}},
+		},
+		{
+			name:   "Strings",
+			typ:    "string[]",
+			input:  `["a,b","c"]`,
+			output: []string{"a,b", "c"},
+		},
+		{
+			name:  "Unbracketed",
+			typ:   "uint256[]",
+			input: "1,2",
+			err:   "array 1,2 must be surrounded by brackets",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typ, err := abi.NewType(test.typ, "", nil)
+			require.NoError(t, err)
+			res, err := StrToArray(&typ, test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.output, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"strings"
+
+	bip32 "github.com/FactomProject/go-bip32"
+	bip39 "github.com/FactomProject/go-bip39"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// DefaultHDPath is the default derivation path for Ethereum accounts.
+const DefaultHDPath = "m/44'/60'/0'/0/0"
+
+// HDPrivateKey derives the private key for a BIP-39 mnemonic, optional secret and BIP-32 derivation path.
+func HDPrivateKey(mnemonic string, secret string, path string) (*ecdsa.PrivateKey, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid derivation path")
+	}
+	key, err := hdMasterKey(mnemonic, secret)
+	if err != nil {
+		return nil, err
+	}
+	return hdDerive(key, derivationPath)
+}
+
+// HDPrivateKeys derives a number of consecutive private keys for a BIP-39 mnemonic, optional
+// secret and BIP-32 derivation path, incrementing the final component of the path for each key.
+func HDPrivateKeys(mnemonic string, secret string, path string, count int) ([]*ecdsa.PrivateKey, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid derivation path")
+	}
+	if len(derivationPath) == 0 {
+		return nil, errors.New("derivation path has no components")
+	}
+	masterKey, err := hdMasterKey(mnemonic, secret)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*ecdsa.PrivateKey, count)
+	for i := 0; i < count; i++ {
+		keys[i], err = hdDerive(masterKey, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		derivationPath[len(derivationPath)-1]++
+	}
+	return keys, nil
+}
+
+// hdMasterKey obtains the BIP-32 master key for a BIP-39 mnemonic and optional secret.
+func hdMasterKey(mnemonic string, secret string) (*bip32.Key, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+	masterKey, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain master key from seed")
+	}
+	return masterKey, nil
+}
+
+// hdDerive derives the private key at the given path from a master key.
+func hdDerive(masterKey *bip32.Key, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key := masterKey
+	for _, index := range path {
+		var err error
+		key, err = key.NewChildKey(index)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive child key")
+		}
+	}
+	return crypto.ToECDSA(common.LeftPadBytes(key.Key, 32))
+}
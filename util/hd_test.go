@@ -0,0 +1,82 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHDPrivateKey(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		secret   string
+		path     string
+		address  string
+		err      string
+	}{
+		{
+			mnemonic: "yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow",
+			path:     DefaultHDPath,
+			address:  "0xA27DF20E6579aC472481F0Ea918165d24bFb713b",
+		},
+		{
+			mnemonic: "  yellow yellow yellow yellow yellow yellow\nyellow yellow yellow yellow yellow yellow ",
+			path:     "m/44'/60'/0'/0/0",
+			address:  "0xA27DF20E6579aC472481F0Ea918165d24bFb713b",
+		},
+		{
+			mnemonic: "yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow",
+			path:     DefaultHDPath,
+			err:      "invalid mnemonic",
+		},
+		{
+			mnemonic: "yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow",
+			path:     "m/44'/bad",
+			err:      "invalid derivation path",
+		},
+	}
+
+	for i, tt := range tests {
+		key, err := HDPrivateKey(tt.mnemonic, tt.secret, tt.path)
+		if tt.err != "" {
+			require.NotNil(t, err, fmt.Sprintf("failed at test %d", i))
+			assert.Contains(t, err.Error(), tt.err, fmt.Sprintf("failed at test %d", i))
+			continue
+		}
+		require.Nil(t, err, fmt.Sprintf("failed at test %d", i))
+		assert.Equal(t, tt.address, crypto.PubkeyToAddress(key.PublicKey).Hex(), fmt.Sprintf("failed at test %d", i))
+	}
+}
+
+func TestHDPrivateKeys(t *testing.T) {
+	mnemonic := "yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow yellow"
+	keys, err := HDPrivateKeys(mnemonic, "", DefaultHDPath, 3)
+	require.Nil(t, err)
+	require.Len(t, keys, 3)
+	assert.Equal(t, "0xA27DF20E6579aC472481F0Ea918165d24bFb713b", crypto.PubkeyToAddress(keys[0].PublicKey).Hex())
+	for i := range keys {
+		key, err := HDPrivateKey(mnemonic, "", fmt.Sprintf("m/44'/60'/0'/0/%d", i))
+		require.Nil(t, err)
+		assert.Equal(t, key.D, keys[i].D)
+	}
+	// Secret changes the keys.
+	secretKeys, err := HDPrivateKeys(mnemonic, "secret", DefaultHDPath, 1)
+	require.Nil(t, err)
+	assert.NotEqual(t, keys[0].D, secretKeys[0].D)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keychain
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// runner runs a command with the given input, returning its output.
+type runner func(input string, name string, args ...string) (string, error)
+
+// runCommand runs a command with the given input, returning its output.
+func runCommand(input string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if _, isExitErr := err.(*exec.ExitError); isExitErr {
+			return "", &commandError{exitErr: err, stderr: strings.TrimSpace(stderr.String())}
+		}
+		return "", fmt.Errorf("failed to run %s: %v", name, err)
+	}
+	return stdout.String(), nil
+}
+
+// commandError is returned when a command runs but fails.
+type commandError struct {
+	exitErr error
+	stderr  string
+}
+
+func (e *commandError) Error() string {
+	if e.stderr == "" {
+		return e.exitErr.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.exitErr, e.stderr)
+}
+
+// securityBackend stores secrets in the macOS keychain using the security command.
+type securityBackend struct {
+	run runner
+}
+
+func (b *securityBackend) get(key string) (string, error) {
+	output, err := b.run("", "security", "find-generic-password", "-s", service, "-a", key, "-w")
+	if err != nil {
+		if _, isCmdErr := err.(*commandError); isCmdErr {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
+func (b *securityBackend) set(key string, secret string) error {
+	// The secret is passed on standard input so that it is not visible in the process list.
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", strconv.Quote(service), strconv.Quote(key), strconv.Quote(secret))
+	_, err := b.run(command, "security", "-i")
+	return err
+}
+
+func (b *securityBackend) delete(key string) error {
+	_, err := b.run("", "security", "delete-generic-password", "-s", service, "-a", key)
+	if _, isCmdErr := err.(*commandError); isCmdErr {
+		return ErrNotFound
+	}
+	return err
+}
+
+// secretToolBackend stores secrets with libsecret using the secret-tool command.
+type secretToolBackend struct {
+	run runner
+}
+
+func (b *secretToolBackend) get(key string) (string, error) {
+	output, err := b.run("", "secret-tool", "lookup", "service", service, "account", key)
+	if err != nil {
+		if _, isCmdErr := err.(*commandError); isCmdErr {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	if output == "" {
+		return "", ErrNotFound
+	}
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
+func (b *secretToolBackend) set(key string, secret string) error {
+	// The secret is passed on standard input so that it is not visible in the process list.
+	_, err := b.run(secret, "secret-tool", "store", fmt.Sprintf("--label=%s %s", service, key), "service", service, "account", key)
+	return err
+}
+
+func (b *secretToolBackend) delete(key string) error {
+	if _, err := b.get(key); err != nil {
+		return err
+	}
+	_, err := b.run("", "secret-tool", "clear", "service", service, "account", key)
+	return err
+}
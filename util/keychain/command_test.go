@@ -0,0 +1,122 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keychain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore is a store of secrets that mimics the security and secret-tool commands.
+type fakeStore struct {
+	secrets map[string]string
+	calls   []string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{secrets: make(map[string]string)}
+}
+
+func (s *fakeStore) run(input string, name string, args ...string) (string, error) {
+	s.calls = append(s.calls, strings.Join(append([]string{name}, args...), " "))
+	notFound := &commandError{exitErr: errors.New("exit status 1")}
+	switch {
+	case name == "security" && args[0] == "-i":
+		// Input is of the form add-generic-password -U -s "service" -a "key" -w "secret"
+		fields := strings.Fields(strings.TrimSpace(input))
+		s.secrets[strings.Trim(fields[5], `"`)] = strings.Trim(fields[7], `"`)
+		return "", nil
+	case name == "security" && args[0] == "find-generic-password":
+		secret, exists := s.secrets[args[4]]
+		if !exists {
+			return "", notFound
+		}
+		return secret + "\n", nil
+	case name == "security" && args[0] == "delete-generic-password":
+		if _, exists := s.secrets[args[4]]; !exists {
+			return "", notFound
+		}
+		delete(s.secrets, args[4])
+		return "", nil
+	case name == "secret-tool" && args[0] == "store":
+		s.secrets[args[5]] = input
+		return "", nil
+	case name == "secret-tool" && args[0] == "lookup":
+		secret, exists := s.secrets[args[4]]
+		if !exists {
+			return "", notFound
+		}
+		return secret, nil
+	case name == "secret-tool" && args[0] == "clear":
+		delete(s.secrets, args[4])
+		return "", nil
+	}
+	return "", errors.New("unexpected command")
+}
+
+func TestCommandBackends(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend func(run runner) backend
+	}{
+		{
+			name:    "Security",
+			backend: func(run runner) backend { return &securityBackend{run: run} },
+		},
+		{
+			name:    "SecretTool",
+			backend: func(run runner) backend { return &secretToolBackend{run: run} },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newFakeStore()
+			b := test.backend(store.run)
+
+			_, err := b.get("0x01/passphrase")
+			assert.Equal(t, ErrNotFound, err)
+
+			require.NoError(t, b.set("0x01/passphrase", "secret"))
+			secret, err := b.get("0x01/passphrase")
+			require.NoError(t, err)
+			assert.Equal(t, "secret", secret)
+
+			// Secret must not be visible in the arguments of any command.
+			for _, call := range store.calls {
+				assert.NotContains(t, call, "secret ")
+				assert.False(t, strings.HasSuffix(call, "secret"))
+			}
+
+			require.NoError(t, b.delete("0x01/passphrase"))
+			_, err = b.get("0x01/passphrase")
+			assert.Equal(t, ErrNotFound, err)
+			assert.Equal(t, ErrNotFound, b.delete("0x01/passphrase"))
+		})
+	}
+}
+
+func TestCommandBackendFailure(t *testing.T) {
+	failing := func(input string, name string, args ...string) (string, error) {
+		return "", errors.New("failed to run command: executable file not found")
+	}
+	_, err := (&securityBackend{run: failing}).get("key")
+	assert.EqualError(t, err, "failed to run command: executable file not found")
+	_, err = (&secretToolBackend{run: failing}).get("key")
+	assert.EqualError(t, err, "failed to run command: executable file not found")
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keychain stores secrets in the operating system's keychain.
+package keychain
+
+import (
+	"errors"
+)
+
+// service is the name under which secrets are stored in the keychain.
+const service = "ethereal"
+
+// ErrNotFound is returned when a secret is not present in the keychain.
+var ErrNotFound = errors.New("secret not found in keychain")
+
+// backend is a store for secrets.
+type backend interface {
+	get(key string) (string, error)
+	set(key string, secret string) error
+	delete(key string) error
+}
+
+// Get obtains the secret for a key from the keychain.
+func Get(key string) (string, error) {
+	b, err := defaultBackend()
+	if err != nil {
+		return "", err
+	}
+	return b.get(key)
+}
+
+// Set stores the secret for a key in the keychain, replacing any existing secret.
+func Set(key string, secret string) error {
+	b, err := defaultBackend()
+	if err != nil {
+		return err
+	}
+	return b.set(key, secret)
+}
+
+// Delete removes the secret for a key from the keychain.
+func Delete(key string) error {
+	b, err := defaultBackend()
+	if err != nil {
+		return err
+	}
+	return b.delete(key)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keychain
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// credential is the Windows CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialManagerBackend stores secrets in the Windows Credential Manager.
+type credentialManagerBackend struct{}
+
+// defaultBackend returns the Windows Credential Manager.
+func defaultBackend() (backend, error) {
+	return &credentialManagerBackend{}, nil
+}
+
+func (b *credentialManagerBackend) target(key string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(fmt.Sprintf("%s:%s", service, key))
+}
+
+func (b *credentialManagerBackend) get(key string) (string, error) {
+	target, err := b.target(key)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	res, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if res == 0 {
+		if err == errorNotFound {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) // nolint:errcheck
+
+	if cred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	blob := (*[1 << 20]byte)(unsafe.Pointer(cred.CredentialBlob))[:cred.CredentialBlobSize:cred.CredentialBlobSize]
+	return string(blob), nil
+}
+
+func (b *credentialManagerBackend) set(key string, secret string) error {
+	target, err := b.target(key)
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(key)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	cred := &credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	res, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(cred)), 0)
+	if res == 0 {
+		return err
+	}
+	return nil
+}
+
+func (b *credentialManagerBackend) delete(key string) error {
+	target, err := b.target(key)
+	if err != nil {
+		return err
+	}
+	res, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if res == 0 {
+		if err == errorNotFound {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
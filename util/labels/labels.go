@@ -0,0 +1,193 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package labels provides the names of well-known contracts, so that they can be identified
+// in output.
+package labels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Label is the name of a contract.
+type Label struct {
+	// ChainID is the ID of the chain on which the contract is deployed.
+	ChainID uint64 `json:"chain_id"`
+	// Address is the address of the contract.
+	Address common.Address `json:"address"`
+	// Name is the name of the contract.
+	Name string `json:"name"`
+}
+
+// builtin are the labels of well-known contracts.
+var builtin = []*Label{
+	{ChainID: 1, Address: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), Name: "WETH"},
+	{ChainID: 1, Address: common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), Name: "USDC"},
+	{ChainID: 1, Address: common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"), Name: "USDT"},
+	{ChainID: 1, Address: common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), Name: "DAI"},
+	{ChainID: 1, Address: common.HexToAddress("0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"), Name: "WBTC"},
+	{ChainID: 1, Address: common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA"), Name: "LINK"},
+	{ChainID: 1, Address: common.HexToAddress("0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84"), Name: "Lido stETH"},
+	{ChainID: 1, Address: common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"), Name: "Uniswap V2 factory"},
+	{ChainID: 1, Address: common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"), Name: "Uniswap V2 router"},
+	{ChainID: 1, Address: common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"), Name: "Uniswap V3 factory"},
+	{ChainID: 1, Address: common.HexToAddress("0xE592427A0AEce92De3Edee1F18E0157C05861564"), Name: "Uniswap V3 router"},
+	{ChainID: 1, Address: common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"), Name: "Uniswap V3 router 2"},
+	{ChainID: 1, Address: common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD"), Name: "Uniswap universal router"},
+	{ChainID: 1, Address: common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3"), Name: "Permit2"},
+	{ChainID: 1, Address: common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582"), Name: "1inch router"},
+	{ChainID: 1, Address: common.HexToAddress("0x00000000000000ADc04C56Bf30aC9d3c0aAF14dC"), Name: "Seaport"},
+	{ChainID: 1, Address: common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"), Name: "ENS registry"},
+	{ChainID: 1, Address: common.HexToAddress("0x57f1887a8BF19b14fC0dF6Fd9B2acc9Af147eA85"), Name: "ENS base registrar"},
+	{ChainID: 1, Address: common.HexToAddress("0x253553366Da8546fC250F225fe3d25d0C782303b"), Name: "ENS registrar controller"},
+	{ChainID: 1, Address: common.HexToAddress("0xD4416b13d2b3a9aBae7AcD5D6C2BbDBE25686401"), Name: "ENS name wrapper"},
+	{ChainID: 1, Address: common.HexToAddress("0x231b0Ee14048e9dCcD1d247744d114a4EB5E8E63"), Name: "ENS public resolver"},
+	{ChainID: 1, Address: common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"), Name: "Beacon deposit contract"},
+	{ChainID: 1, Address: common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"), Name: "Multicall3"},
+	{ChainID: 1, Address: common.HexToAddress("0xD152f549545093347A162Dce210e7293f1452150"), Name: "Disperse"},
+}
+
+// Database is a database of labels, made up of the built-in labels and those obtained from a
+// remote source.
+type Database struct {
+	names map[uint64]map[common.Address]string
+}
+
+// New creates a database with the built-in labels, and those from the given file if it exists.
+// Labels from the file replace built-in labels for the same contract.
+func New(path string) (*Database, error) {
+	db := &Database{
+		names: make(map[uint64]map[common.Address]string),
+	}
+	db.add(builtin)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return db, nil
+		}
+		return nil, errors.Wrap(err, "failed to read labels")
+	}
+	labels, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid labels %s", path)
+	}
+	db.add(labels)
+
+	return db, nil
+}
+
+// add adds labels to the database.
+func (d *Database) add(labels []*Label) {
+	for _, label := range labels {
+		if d.names[label.ChainID] == nil {
+			d.names[label.ChainID] = make(map[common.Address]string)
+		}
+		d.names[label.ChainID][label.Address] = label.Name
+	}
+}
+
+// Name returns the name of the contract at the given address on the chain with the given ID.
+func (d *Database) Name(chainID uint64, address common.Address) (string, bool) {
+	name, exists := d.names[chainID][address]
+	return name, exists
+}
+
+// ForChain returns the labels on the chain with the given ID, ordered by name.
+func (d *Database) ForChain(chainID uint64) []*Label {
+	res := make([]*Label, 0, len(d.names[chainID]))
+	for address, name := range d.names[chainID] {
+		res = append(res, &Label{
+			ChainID: chainID,
+			Address: address,
+			Name:    name,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Address.Hex() < res[j].Address.Hex()
+	})
+	return res
+}
+
+// Parse parses labels from a JSON array of objects with chain_id, address and name fields.
+func Parse(data []byte) ([]*Label, error) {
+	labels := make([]*Label, 0)
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, err
+	}
+	for i, label := range labels {
+		if label == nil || label.ChainID == 0 || label.Address == (common.Address{}) || label.Name == "" {
+			return nil, fmt.Errorf("label %d requires chain_id, address and name", i)
+		}
+	}
+	return labels, nil
+}
+
+// Update obtains labels from the given URL and stores them in the given file, returning the
+// number of labels obtained.  The file is replaced atomically so that an interrupted write
+// does not corrupt it.
+func Update(ctx context.Context, url string, path string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain labels")
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read labels")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to obtain labels: %s", resp.Status)
+	}
+	labels, err := Parse(data)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid labels")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create labels")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return 0, errors.Wrap(err, "failed to write labels")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return 0, errors.Wrap(err, "failed to write labels")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return 0, errors.Wrap(err, "failed to write labels")
+	}
+
+	return len(labels), nil
+}
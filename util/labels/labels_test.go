@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltin(t *testing.T) {
+	seen := make(map[uint64]map[common.Address]bool)
+	for _, label := range builtin {
+		assert.NotEqual(t, common.Address{}, label.Address)
+		assert.NotEmpty(t, label.Name)
+		if seen[label.ChainID] == nil {
+			seen[label.ChainID] = make(map[common.Address]bool)
+		}
+		assert.False(t, seen[label.ChainID][label.Address], "duplicate label for %s on chain %d", label.Address.Hex(), label.ChainID)
+		seen[label.ChainID][label.Address] = true
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "labels")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "labels.json")
+
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	other := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+	db, err := New(path)
+	require.NoError(t, err)
+	name, exists := db.Name(1, weth)
+	require.True(t, exists)
+	require.Equal(t, "WETH", name)
+	_, exists = db.Name(5, weth)
+	require.False(t, exists)
+	_, exists = db.Name(1, other)
+	require.False(t, exists)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[
+  {"chain_id":1,"address":"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2","name":"Wrapped Ether"},
+  {"chain_id":5,"address":"0x5FfC014343cd971B7eb70732021E26C35B744cc4","name":"Test"}
+]`), 0600))
+	db, err = New(path)
+	require.NoError(t, err)
+	name, exists = db.Name(1, weth)
+	require.True(t, exists)
+	require.Equal(t, "Wrapped Ether", name)
+	name, exists = db.Name(5, other)
+	require.True(t, exists)
+	require.Equal(t, "Test", name)
+
+	chain := db.ForChain(5)
+	require.Len(t, chain, 1)
+	require.Equal(t, other, chain[0].Address)
+	require.Len(t, db.ForChain(1), len(builtin))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"chain_id":1,"name":"Test"}]`), 0600))
+	_, err = New(path)
+	require.EqualError(t, err, "invalid labels "+path+": label 0 requires chain_id, address and name")
+}
+
+func TestUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/labels.json":
+			fmt.Fprint(w, `[{"chain_id":1,"address":"0x5FfC014343cd971B7eb70732021E26C35B744cc4","name":"Test"}]`)
+		case "/invalid.json":
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "labels")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "labels.json")
+
+	_, err = Update(context.Background(), server.URL+"/missing.json", path)
+	require.EqualError(t, err, "failed to obtain labels: 404 Not Found")
+	_, err = Update(context.Background(), server.URL+"/invalid.json", path)
+	require.Error(t, err)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	count, err := Update(context.Background(), server.URL+"/labels.json", path)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	db, err := New(path)
+	require.NoError(t, err)
+	name, exists := db.Name(1, common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"))
+	require.True(t, exists)
+	require.Equal(t, "Test", name)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// placeholderRe matches library placeholders in unlinked binary.  Placeholders are 40 characters
+// long and start with "__"; older compilers use the (truncated) library name, newer compilers use
+// "$" followed by the first 34 characters of the hex hash of the fully-qualified library name.
+var placeholderRe = regexp.MustCompile(`__[$_a-zA-Z0-9./:-]{38}`)
+
+// IsUnlinked returns true if the hex-encoded binary contains library placeholders.
+func IsUnlinked(bin string) bool {
+	return placeholderRe.MatchString(bin)
+}
+
+// UnlinkedLibraries returns the library placeholders in the hex-encoded binary.
+func UnlinkedLibraries(bin string) []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, placeholder := range placeholderRe.FindAllString(bin, -1) {
+		if !seen[placeholder] {
+			seen[placeholder] = true
+			res = append(res, placeholder)
+		}
+	}
+	return res
+}
+
+// LibraryPlaceholders returns the placeholders that can be used for a library in unlinked binary.
+// The name should be fully-qualified (e.g. "contracts/Lib.sol:Lib") to match placeholders from
+// newer compilers.
+func LibraryPlaceholders(name string) []string {
+	hash := hex.EncodeToString(crypto.Keccak256([]byte(name)))
+	legacy := name
+	if len(legacy) > 36 {
+		legacy = legacy[:36]
+	}
+	return []string{
+		fmt.Sprintf("__$%s$__", hash[:34]),
+		fmt.Sprintf("__%s%s", legacy, strings.Repeat("_", 38-len(legacy))),
+	}
+}
+
+// LinkBinary links the hex-encoded binary with the supplied libraries, returning the linked binary.
+// Libraries are keyed by name; a key can also be the placeholder itself.
+func LinkBinary(bin string, libraries map[string]common.Address) ([]byte, error) {
+	bin = strings.TrimPrefix(bin, "0x")
+	for name, address := range libraries {
+		addressStr := hex.EncodeToString(address.Bytes())
+		placeholders := LibraryPlaceholders(name)
+		if len(name) == 40 && strings.HasPrefix(name, "__") {
+			placeholders = append(placeholders, name)
+		}
+		for _, placeholder := range placeholders {
+			bin = strings.ReplaceAll(bin, placeholder, addressStr)
+		}
+	}
+
+	if unlinked := UnlinkedLibraries(bin); len(unlinked) > 0 {
+		return nil, fmt.Errorf("binary has unlinked libraries %s", strings.Join(unlinked, ", "))
+	}
+
+	return hex.DecodeString(bin)
+}
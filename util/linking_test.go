@@ -0,0 +1,78 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkBinary(t *testing.T) {
+	lib := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	libHex := hex.EncodeToString(lib.Bytes())
+
+	tests := []struct {
+		name      string
+		bin       string
+		libraries map[string]common.Address
+		res       string
+		err       string
+	}{
+		{
+			name: "Unlinked",
+			bin:  "6080" + LibraryPlaceholders("contracts/Lib.sol:Lib")[0] + "00",
+			err:  "binary has unlinked libraries " + LibraryPlaceholders("contracts/Lib.sol:Lib")[0],
+		},
+		{
+			name:      "Hashed",
+			bin:       "6080" + LibraryPlaceholders("contracts/Lib.sol:Lib")[0] + "00",
+			libraries: map[string]common.Address{"contracts/Lib.sol:Lib": lib},
+			res:       "6080" + libHex + "00",
+		},
+		{
+			name:      "Legacy",
+			bin:       "0x6080" + LibraryPlaceholders("Lib.sol:Lib")[1] + "00" + LibraryPlaceholders("Lib.sol:Lib")[1],
+			libraries: map[string]common.Address{"Lib.sol:Lib": lib},
+			res:       "6080" + libHex + "00" + libHex,
+		},
+		{
+			name:      "Placeholder",
+			bin:       "6080__$0123456789abcdef0123456789abcdef01$__00",
+			libraries: map[string]common.Address{"__$0123456789abcdef0123456789abcdef01$__": lib},
+			res:       "6080" + libHex + "00",
+		},
+		{
+			name:      "Linked",
+			bin:       "608060",
+			libraries: map[string]common.Address{"Lib": lib},
+			res:       "608060",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := LinkBinary(test.bin, test.libraries)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, hex.EncodeToString(res))
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageHash(t *testing.T) {
+	require.Equal(t, crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n12Hello, world")), MessageHash([]byte("Hello, world")))
+}
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	signer := common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	hash := MessageHash([]byte("Hello, world"))
+	signature, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+
+	address, err := RecoverSigner(hash, signature)
+	require.NoError(t, err)
+	require.Equal(t, signer, address)
+
+	// personal_sign style recovery ID.
+	legacy := make([]byte, len(signature))
+	copy(legacy, signature)
+	legacy[64] += 27
+	address, err = RecoverSigner(hash, legacy)
+	require.NoError(t, err)
+	require.Equal(t, signer, address)
+	require.Equal(t, signature[64]+27, legacy[64])
+
+	_, err = RecoverSigner(hash, signature[:64])
+	require.EqualError(t, err, "signature must be 65 bytes")
+}
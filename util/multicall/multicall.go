@@ -0,0 +1,98 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multicall aggregates read-only contract calls using the Multicall3 contract.
+package multicall
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// Address is the address of the Multicall3 contract, which is the same on most chains.
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const multicallABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var parsedABI abi.ABI
+
+func init() {
+	var err error
+	parsedABI, err = abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Call is a single call to be aggregated.
+type Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Result is the result of a single aggregated call.
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Encode encodes the calls as call data for aggregate3.
+func Encode(calls []Call) ([]byte, error) {
+	return parsedABI.Pack("aggregate3", calls)
+}
+
+// Decode decodes the return data of aggregate3.
+func Decode(data []byte) ([]Result, error) {
+	values, err := parsedABI.Unpack("aggregate3", data)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, errors.New("unexpected number of return values")
+	}
+	results := abi.ConvertType(values[0], new([]Result)).(*[]Result)
+	return *results, nil
+}
+
+// Aggregate makes the calls in a single request to the Multicall3 contract at the given address,
+// using the state at the given block (nil for latest).  An error is returned if the aggregated
+// call fails, which includes any call that does not allow failure failing.
+func Aggregate(ctx context.Context, client *ethclient.Client, address common.Address, calls []Call, blockNumber *big.Int) ([]Result, error) {
+	data, err := Encode(calls)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode calls")
+	}
+	res, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no data returned; check that the multicall contract exists on this chain")
+	}
+	results, err := Decode(res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode results")
+	}
+	if len(results) != len(calls) {
+		return nil, errors.New("unexpected number of results")
+	}
+	return results, nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicall
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	data, err := Encode([]Call{
+		{
+			Target:       common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+			AllowFailure: true,
+			CallData:     []byte{0x18, 0x16, 0x0d, 0xdd},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "82ad56cb"+
+		"0000000000000000000000000000000000000000000000000000000000000020"+
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000000000020"+
+		"0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4"+
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000000000060"+
+		"0000000000000000000000000000000000000000000000000000000000000004"+
+		"18160ddd00000000000000000000000000000000000000000000000000000000",
+		hex.EncodeToString(data))
+}
+
+func TestDecode(t *testing.T) {
+	data, err := hex.DecodeString(
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000040" +
+			"00000000000000000000000000000000000000000000000000000000000000c0" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000040" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"00000000000000000000000000000000000000000000000000000000000003e8" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000040" +
+			"0000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	results, err := Decode(data)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, common.LeftPadBytes([]byte{0x03, 0xe8}, 32), results[0].ReturnData)
+	assert.False(t, results[1].Success)
+	assert.Empty(t, results[1].ReturnData)
+}
@@ -0,0 +1,354 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// EVMCoinTypeFlag is set in the coin type of addresses for EVM chains other than Ethereum
+// mainnet, as per ENSIP-11.
+const EVMCoinTypeFlag = 0x80000000
+
+// coinTypes are the SLIP-44 coin types of coins by their symbol.
+var coinTypes = map[string]uint64{
+	"btc":  0,
+	"ltc":  2,
+	"doge": 3,
+	"eth":  60,
+	"etc":  61,
+}
+
+// utxoCoin contains the address parameters of a Bitcoin-like coin.
+type utxoCoin struct {
+	p2pkhVersion byte
+	p2shVersion  byte
+	hrp          string
+}
+
+var utxoCoins = map[uint64]*utxoCoin{
+	0: {p2pkhVersion: 0x00, p2shVersion: 0x05, hrp: "bc"},
+	2: {p2pkhVersion: 0x30, p2shVersion: 0x32, hrp: "ltc"},
+	3: {p2pkhVersion: 0x1e, p2shVersion: 0x16},
+}
+
+// CoinType returns the coin type given either its symbol, for example "btc", or its
+// SLIP-44 number.
+func CoinType(input string) (uint64, error) {
+	if coinType, exists := coinTypes[strings.ToLower(input)]; exists {
+		return coinType, nil
+	}
+	coinType, err := strconv.ParseUint(input, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unknown coin %s", input)
+	}
+	return coinType, nil
+}
+
+// EVMCoinType returns the coin type for an EVM chain as per ENSIP-11.
+func EVMCoinType(chainID uint64) uint64 {
+	if chainID == 1 {
+		return 60
+	}
+	return EVMCoinTypeFlag | chainID
+}
+
+// isEVMCoinType returns true if the coin type has addresses in the same format as Ethereum.
+func isEVMCoinType(coinType uint64) bool {
+	return coinType == 60 || coinType == 61 || coinType&EVMCoinTypeFlag != 0
+}
+
+// EncodeCoinAddress turns the text form of an address for a coin in to the binary form
+// used by ENS.  Addresses for coins that are not known must be supplied as hex.
+func EncodeCoinAddress(coinType uint64, address string) ([]byte, error) {
+	switch {
+	case isEVMCoinType(coinType):
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address %s", address)
+		}
+		return common.HexToAddress(address).Bytes(), nil
+	case utxoCoins[coinType] != nil:
+		return encodeUTXOAddress(utxoCoins[coinType], address)
+	default:
+		data, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "address for unknown coin must be hex")
+		}
+		return data, nil
+	}
+}
+
+// DecodeCoinAddress turns the binary form of an address used by ENS in to the text form
+// for the coin.  Addresses for coins that are not known are returned as hex.
+func DecodeCoinAddress(coinType uint64, data []byte) (string, error) {
+	switch {
+	case isEVMCoinType(coinType):
+		if len(data) != common.AddressLength {
+			return "", errors.New("invalid address length")
+		}
+		return common.BytesToAddress(data).Hex(), nil
+	case utxoCoins[coinType] != nil:
+		return decodeUTXOAddress(utxoCoins[coinType], data)
+	default:
+		return fmt.Sprintf("%#x", data), nil
+	}
+}
+
+// encodeUTXOAddress turns a Bitcoin-like address in to its output script.
+func encodeUTXOAddress(coin *utxoCoin, address string) ([]byte, error) {
+	if coin.hrp != "" && strings.HasPrefix(strings.ToLower(address), coin.hrp+"1") {
+		version, program, err := decodeSegwitAddress(coin.hrp, address)
+		if err != nil {
+			return nil, err
+		}
+		opcode := byte(0x00)
+		if version > 0 {
+			opcode = 0x50 + version
+		}
+		return append([]byte{opcode, byte(len(program))}, program...), nil
+	}
+
+	data, err := base58CheckDecode(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 21 {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	switch data[0] {
+	case coin.p2pkhVersion:
+		script := []byte{0x76, 0xa9, 0x14}
+		script = append(script, data[1:]...)
+		return append(script, 0x88, 0xac), nil
+	case coin.p2shVersion:
+		script := []byte{0xa9, 0x14}
+		script = append(script, data[1:]...)
+		return append(script, 0x87), nil
+	default:
+		return nil, fmt.Errorf("invalid address version for %s", address)
+	}
+}
+
+// decodeUTXOAddress turns an output script in to a Bitcoin-like address.
+func decodeUTXOAddress(coin *utxoCoin, script []byte) (string, error) {
+	switch {
+	case len(script) == 25 && script[0] == 0x76 && script[1] == 0xa9 && script[2] == 0x14 && script[23] == 0x88 && script[24] == 0xac:
+		return base58CheckEncode(append([]byte{coin.p2pkhVersion}, script[3:23]...)), nil
+	case len(script) == 23 && script[0] == 0xa9 && script[1] == 0x14 && script[22] == 0x87:
+		return base58CheckEncode(append([]byte{coin.p2shVersion}, script[2:22]...)), nil
+	case coin.hrp != "" && len(script) >= 4 && (script[0] == 0x00 || (script[0] >= 0x51 && script[0] <= 0x60)) && int(script[1]) == len(script)-2:
+		version := byte(0)
+		if script[0] != 0x00 {
+			version = script[0] - 0x50
+		}
+		return encodeSegwitAddress(coin.hrp, version, script[2:])
+	default:
+		return "", errors.New("unrecognised output script")
+	}
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58CheckEncode encodes data with a checksum in base 58.
+func base58CheckEncode(data []byte) string {
+	checksum := doubleSHA256(data)
+	return base58Encode(append(append([]byte{}, data...), checksum[:4]...))
+}
+
+// base58Encode encodes data in base 58.
+func base58Encode(data []byte) string {
+	value := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	res := make([]byte, 0, len(data)*138/100+1)
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		res = append(res, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		res = append(res, base58Alphabet[0])
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return string(res)
+}
+
+// base58CheckDecode decodes base 58 data, confirming and removing its checksum.
+func base58CheckDecode(input string) ([]byte, error) {
+	value := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range input {
+		index := strings.IndexRune(base58Alphabet, r)
+		if index == -1 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(index)))
+	}
+	data := value.Bytes()
+	for _, r := range input {
+		if r != rune(base58Alphabet[0]) {
+			break
+		}
+		data = append([]byte{0x00}, data...)
+	}
+	if len(data) < 5 {
+		return nil, errors.New("base58 data too short")
+	}
+	checksum := doubleSHA256(data[:len(data)-4])
+	if !bytes.Equal(checksum[:4], data[len(data)-4:]) {
+		return nil, errors.New("invalid checksum")
+	}
+	return data[:len(data)-4], nil
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Constant  = 1
+	bech32mConstant = 0x2bc830a3
+)
+
+func bech32Polymod(values []byte) uint32 {
+	generator := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		res = append(res, byte(c>>5))
+	}
+	res = append(res, 0)
+	for _, c := range hrp {
+		res = append(res, byte(c&31))
+	}
+	return res
+}
+
+// convertBits regroups data from one bit width to another.
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxv := uint32(1)<<toBits - 1
+	res := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.New("invalid data range")
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			res = append(res, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return res, nil
+}
+
+// encodeSegwitAddress encodes a segregated witness program as a bech32 or bech32m address.
+func encodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 || len(program) < 2 || len(program) > 40 {
+		return "", errors.New("invalid witness program")
+	}
+	converted, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	data := append([]byte{version}, converted...)
+	constant := uint32(bech32Constant)
+	if version > 0 {
+		constant = bech32mConstant
+	}
+	polymod := bech32Polymod(append(append(bech32HRPExpand(hrp), data...), 0, 0, 0, 0, 0, 0)) ^ constant
+	for i := 0; i < 6; i++ {
+		data = append(data, byte(polymod>>uint(5*(5-i))&31))
+	}
+	var builder strings.Builder
+	builder.WriteString(hrp)
+	builder.WriteString("1")
+	for _, d := range data {
+		builder.WriteByte(bech32Charset[d])
+	}
+	return builder.String(), nil
+}
+
+// decodeSegwitAddress decodes a bech32 or bech32m address in to its witness version and program.
+func decodeSegwitAddress(hrp string, address string) (byte, []byte, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return 0, nil, errors.New("mixed case address")
+	}
+	address = strings.ToLower(address)
+	pos := strings.LastIndex(address, "1")
+	if pos < 1 || pos+7 > len(address) || address[:pos] != hrp {
+		return 0, nil, fmt.Errorf("invalid address %s", address)
+	}
+	data := make([]byte, 0, len(address)-pos-1)
+	for _, c := range address[pos+1:] {
+		index := strings.IndexRune(bech32Charset, c)
+		if index == -1 {
+			return 0, nil, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		data = append(data, byte(index))
+	}
+	polymod := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	version := data[0]
+	if (version == 0 && polymod != bech32Constant) || (version > 0 && polymod != bech32mConstant) {
+		return 0, nil, errors.New("invalid checksum")
+	}
+	program, err := convertBits(data[1:len(data)-6], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if version > 16 || len(program) < 2 || len(program) > 40 || (version == 0 && len(program) != 20 && len(program) != 32) {
+		return 0, nil, errors.New("invalid witness program")
+	}
+	return version, program, nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinType(t *testing.T) {
+	tests := []struct {
+		input    string
+		coinType uint64
+		err      string
+	}{
+		{input: "eth", coinType: 60},
+		{input: "BTC", coinType: 0},
+		{input: "doge", coinType: 3},
+		{input: "2", coinType: 2},
+		{input: "0x80000089", coinType: 0x80000089},
+		{input: "unknown", err: "unknown coin unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			coinType, err := CoinType(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.coinType, coinType)
+			}
+		})
+	}
+}
+
+func TestEVMCoinType(t *testing.T) {
+	require.Equal(t, uint64(60), EVMCoinType(1))
+	require.Equal(t, uint64(0x8000000a), EVMCoinType(10))
+}
+
+func TestCoinAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		coinType uint64
+		address  string
+		data     string
+	}{
+		{name: "ETH", coinType: 60, address: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", data: "5ffc014343cd971b7eb70732021e26c35b744cc4"},
+		{name: "Optimism", coinType: 0x8000000a, address: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", data: "5ffc014343cd971b7eb70732021e26c35b744cc4"},
+		{name: "BTCP2PKH", coinType: 0, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", data: "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"},
+		{name: "BTCP2SH", coinType: 0, address: "3Ai1JZ8pdJb2ksieUV8FsxSNVJCpoPi8W6", data: "a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1887"},
+		{name: "BTCSegwit", coinType: 0, address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", data: "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{name: "BTCTaproot", coinType: 0, address: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", data: "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{name: "LTCP2PKH", coinType: 2, address: "LaMT348PWRnrqeeWArpwQPbuanpXDZGEUz", data: "76a914a5f4d12ce3685781b227c1f39548ddef429e978388ac"},
+		{name: "LTCSegwit", coinType: 2, address: "ltc1qdp7p2rpx4a2f80h7a4crvppczgg4egmv5c78w8", data: "0014687c150c26af5493befeed7036043812115ca36c"},
+		{name: "DOGE", coinType: 3, address: "DBXu2kgc3xtvCUWFcxFE3r9hEYgmuaaCyD", data: "76a9144620b70031f0e9437e374a2100934fba4911046088ac"},
+		{name: "Unknown", coinType: 501, address: "0x0102030405", data: "0102030405"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := EncodeCoinAddress(test.coinType, test.address)
+			require.NoError(t, err)
+			require.Equal(t, test.data, hex.EncodeToString(data))
+			address, err := DecodeCoinAddress(test.coinType, data)
+			require.NoError(t, err)
+			require.Equal(t, test.address, address)
+		})
+	}
+}
+
+func TestCoinAddressUpperCaseSegwit(t *testing.T) {
+	data, err := EncodeCoinAddress(0, strings.ToUpper("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"))
+	require.NoError(t, err)
+	require.Equal(t, "0014751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(data))
+}
+
+func TestCoinAddressErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		coinType uint64
+		address  string
+		err      string
+	}{
+		{name: "ETHInvalid", coinType: 60, address: "0x1234", err: "invalid address 0x1234"},
+		{name: "BTCChecksum", coinType: 0, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", err: "invalid checksum"},
+		{name: "BTCCharacter", coinType: 0, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfN0", err: `invalid base58 character '0'`},
+		{name: "BTCLTCAddress", coinType: 0, address: "LaMT348PWRnrqeeWArpwQPbuanpXDZGEUz", err: "invalid address version for LaMT348PWRnrqeeWArpwQPbuanpXDZGEUz"},
+		{name: "BTCSegwitChecksum", coinType: 0, address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", err: "invalid checksum"},
+		{name: "BTCMixedCase", coinType: 0, address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3T4", err: "mixed case address"},
+		{name: "UnknownNotHex", coinType: 501, address: "xyz", err: "address for unknown coin must be hex: encoding/hex: invalid byte: U+0078 'x'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := EncodeCoinAddress(test.coinType, test.address)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NameWrapperAddresses are the addresses of the ENS name wrapper by chain ID.
+var NameWrapperAddresses = map[uint64]common.Address{
+	1:        common.HexToAddress("0xD4416b13d2b3a9aBae7AcD5D6C2BbDBE25686401"),
+	11155111: common.HexToAddress("0x0635513f179D50A207757E05759CbD106d7dFcE8"),
+}
+
+// Fuses of names in the ENS name wrapper.
+const (
+	FuseCannotUnwrap          uint32 = 1
+	FuseCannotBurnFuses       uint32 = 2
+	FuseCannotTransfer        uint32 = 4
+	FuseCannotSetResolver     uint32 = 8
+	FuseCannotSetTTL          uint32 = 16
+	FuseCannotCreateSubdomain uint32 = 32
+	FuseCannotApprove         uint32 = 64
+	FuseParentCannotControl   uint32 = 1 << 16
+	FuseIsDotEth              uint32 = 1 << 17
+	FuseCanExtendExpiry       uint32 = 1 << 18
+)
+
+// OwnerControlledFuses is the mask of fuses that can be burned by the owner of a name.
+const OwnerControlledFuses uint32 = 0xffff
+
+// fuseNames are the names of the fuses, in order of their values.
+var fuseNames = []struct {
+	name string
+	fuse uint32
+}{
+	{"CANNOT_UNWRAP", FuseCannotUnwrap},
+	{"CANNOT_BURN_FUSES", FuseCannotBurnFuses},
+	{"CANNOT_TRANSFER", FuseCannotTransfer},
+	{"CANNOT_SET_RESOLVER", FuseCannotSetResolver},
+	{"CANNOT_SET_TTL", FuseCannotSetTTL},
+	{"CANNOT_CREATE_SUBDOMAIN", FuseCannotCreateSubdomain},
+	{"CANNOT_APPROVE", FuseCannotApprove},
+	{"PARENT_CANNOT_CONTROL", FuseParentCannotControl},
+	{"IS_DOT_ETH", FuseIsDotEth},
+	{"CAN_EXTEND_EXPIRY", FuseCanExtendExpiry},
+}
+
+// ParseFuses parses a comma-separated list of fuses, given either by name (for example
+// CANNOT_UNWRAP or cannot-unwrap) or as numbers, in to a fuse mask.
+func ParseFuses(input string) (uint32, error) {
+	var fuses uint32
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name := strings.ToUpper(strings.ReplaceAll(item, "-", "_"))
+		found := false
+		for _, fuseName := range fuseNames {
+			if fuseName.name == name {
+				fuses |= fuseName.fuse
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		value, err := strconv.ParseUint(item, 0, 32)
+		if err != nil {
+			return 0, fmt.Errorf("unknown fuse %s", item)
+		}
+		fuses |= uint32(value)
+	}
+	return fuses, nil
+}
+
+// FuseNames returns the names of the fuses burned in a fuse mask.  Fuses without a name are
+// returned as hex.
+func FuseNames(fuses uint32) []string {
+	names := make([]string, 0)
+	for _, fuseName := range fuseNames {
+		if fuses&fuseName.fuse != 0 {
+			names = append(names, fuseName.name)
+			fuses &^= fuseName.fuse
+		}
+	}
+	for i := uint(0); i < 32; i++ {
+		if fuses&(uint32(1)<<i) != 0 {
+			names = append(names, fmt.Sprintf("%#x", uint32(1)<<i))
+		}
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFuses(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		fuses uint32
+		err   string
+	}{
+		{name: "Empty", input: "", fuses: 0},
+		{name: "Single", input: "CANNOT_UNWRAP", fuses: FuseCannotUnwrap},
+		{name: "Hyphenated", input: "cannot-unwrap,cannot-transfer", fuses: FuseCannotUnwrap | FuseCannotTransfer},
+		{name: "Spaces", input: "CANNOT_UNWRAP, PARENT_CANNOT_CONTROL", fuses: FuseCannotUnwrap | FuseParentCannotControl},
+		{name: "Number", input: "0x80", fuses: 0x80},
+		{name: "Unknown", input: "CANNOT_FLY", err: "unknown fuse CANNOT_FLY"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fuses, err := ParseFuses(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.fuses, fuses)
+			}
+		})
+	}
+}
+
+func TestFuseNames(t *testing.T) {
+	require.Equal(t, []string{}, FuseNames(0))
+	require.Equal(t, []string{"CANNOT_UNWRAP", "PARENT_CANNOT_CONTROL", "IS_DOT_ETH"}, FuseNames(FuseCannotUnwrap|FuseParentCannotControl|FuseIsDotEth))
+	require.Equal(t, []string{"CANNOT_TRANSFER", "0x80"}, FuseNames(FuseCannotTransfer|0x80))
+}
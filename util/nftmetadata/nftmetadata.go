@@ -0,0 +1,145 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nftmetadata obtains the metadata of non-fungible tokens given their token URI.
+package nftmetadata
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// DefaultIPFSGateway is the default gateway used to fetch content from IPFS.
+const DefaultIPFSGateway = "https://ipfs.io"
+
+// DefaultArweaveGateway is the default gateway used to fetch content from Arweave.
+const DefaultArweaveGateway = "https://arweave.net"
+
+// Metadata is the metadata of a non-fungible token, as defined by the ERC-721 metadata JSON
+// schema and common extensions to it.
+type Metadata struct {
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Image       string       `json:"image,omitempty"`
+	ExternalURL string       `json:"external_url,omitempty"`
+	Attributes  []*Attribute `json:"attributes,omitempty"`
+	// Raw is the metadata as supplied, including any fields not listed above.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Attribute is a single trait of a non-fungible token.
+type Attribute struct {
+	TraitType   string      `json:"trait_type,omitempty"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+// Fetcher fetches token metadata.
+type Fetcher struct {
+	ipfsGateway string
+}
+
+// New creates a new fetcher.  If ipfsGateway is empty the default gateway is used.
+func New(ipfsGateway string) *Fetcher {
+	if ipfsGateway == "" {
+		ipfsGateway = DefaultIPFSGateway
+	}
+	return &Fetcher{
+		ipfsGateway: strings.TrimSuffix(ipfsGateway, "/"),
+	}
+}
+
+// ResolveURI turns a URI that cannot be fetched directly, such as an ipfs:// URI, in to an
+// HTTP URI via the relevant gateway.  Other URIs are returned unchanged.
+func (f *Fetcher) ResolveURI(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(uri, "ipfs://")
+		// Some tokens include a redundant ipfs/ prefix.
+		path = strings.TrimPrefix(path, "ipfs/")
+		return fmt.Sprintf("%s/ipfs/%s", f.ipfsGateway, path)
+	case strings.HasPrefix(uri, "/ipfs/"):
+		return f.ipfsGateway + uri
+	case strings.HasPrefix(uri, "ar://"):
+		return fmt.Sprintf("%s/%s", DefaultArweaveGateway, strings.TrimPrefix(uri, "ar://"))
+	default:
+		return uri
+	}
+}
+
+// Fetch fetches and parses the metadata at the given token URI.
+func (f *Fetcher) Fetch(ctx context.Context, uri string) (*Metadata, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(uri, "data:") {
+		data, err = decodeDataURI(uri)
+	} else {
+		data, err = fetch(ctx, f.ResolveURI(uri))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, errors.Wrap(err, "invalid metadata")
+	}
+	metadata.Raw = data
+	return metadata, nil
+}
+
+// decodeDataURI decodes the content of a data: URI.
+func decodeDataURI(uri string) ([]byte, error) {
+	parts := strings.SplitN(strings.TrimPrefix(uri, "data:"), ",", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid data URI")
+	}
+	if strings.HasSuffix(parts[0], ";base64") {
+		data, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid base64 in data URI")
+		}
+		return data, nil
+	}
+	data, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid data URI")
+	}
+	return []byte(data), nil
+}
+
+// fetch fetches the body of the given URL.
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
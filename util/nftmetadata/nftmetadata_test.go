@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nftmetadata
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{"name":"Token 1","description":"A test token","image":"ipfs://QmImage","attributes":[{"trait_type":"Colour","value":"Blue"},{"trait_type":"Level","value":5,"display_type":"number"}],"extra":true}`
+
+func TestResolveURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		gateway string
+		uri     string
+		res     string
+	}{
+		{
+			name: "HTTPS",
+			uri:  "https://example.com/1.json",
+			res:  "https://example.com/1.json",
+		},
+		{
+			name: "IPFS",
+			uri:  "ipfs://QmMetadata/1",
+			res:  "https://ipfs.io/ipfs/QmMetadata/1",
+		},
+		{
+			name: "IPFSRedundantPrefix",
+			uri:  "ipfs://ipfs/QmMetadata/1",
+			res:  "https://ipfs.io/ipfs/QmMetadata/1",
+		},
+		{
+			name:    "IPFSPath",
+			gateway: "https://gateway.example.com/",
+			uri:     "/ipfs/QmMetadata/1",
+			res:     "https://gateway.example.com/ipfs/QmMetadata/1",
+		},
+		{
+			name: "Arweave",
+			uri:  "ar://abc123",
+			res:  "https://arweave.net/abc123",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.res, New(test.gateway).ResolveURI(test.uri))
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmMetadata/1":
+			fmt.Fprint(w, testMetadata)
+		case "/invalid":
+			fmt.Fprint(w, "not json")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := New(server.URL)
+
+	tests := []struct {
+		name string
+		uri  string
+		err  string
+	}{
+		{
+			name: "IPFS",
+			uri:  "ipfs://QmMetadata/1",
+		},
+		{
+			name: "HTTP",
+			uri:  server.URL + "/ipfs/QmMetadata/1",
+		},
+		{
+			name: "DataBase64",
+			uri:  "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testMetadata)),
+		},
+		{
+			name: "DataPlain",
+			uri:  "data:application/json," + testMetadata,
+		},
+		{
+			name: "DataInvalid",
+			uri:  "data:application/json",
+			err:  "invalid data URI",
+		},
+		{
+			name: "NotFound",
+			uri:  server.URL + "/missing",
+			err:  fmt.Sprintf("request to %s/missing returned status 404", server.URL),
+		},
+		{
+			name: "Invalid",
+			uri:  server.URL + "/invalid",
+			err:  "invalid metadata: invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := fetcher.Fetch(context.Background(), test.uri)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Token 1", metadata.Name)
+			assert.Equal(t, "A test token", metadata.Description)
+			assert.Equal(t, "ipfs://QmImage", metadata.Image)
+			require.Len(t, metadata.Attributes, 2)
+			assert.Equal(t, "Colour", metadata.Attributes[0].TraitType)
+			assert.Equal(t, "Blue", metadata.Attributes[0].Value)
+			assert.Equal(t, float64(5), metadata.Attributes[1].Value)
+			assert.Equal(t, "number", metadata.Attributes[1].DisplayType)
+			assert.JSONEq(t, testMetadata, string(metadata.Raw))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+var (
+	// OPStackL2StandardBridge is the address of the standard bridge predeploy on OP-stack chains.
+	OPStackL2StandardBridge = common.HexToAddress("0x4200000000000000000000000000000000000010")
+	// OPStackL2ToL1MessagePasser is the address of the message passer predeploy on OP-stack
+	// chains, which records withdrawals.
+	OPStackL2ToL1MessagePasser = common.HexToAddress("0x4200000000000000000000000000000000000016")
+	// OPStackETH is the address used by the standard bridge to represent Ether.
+	OPStackETH = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+)
+
+// OPStackWithdrawal is a withdrawal from an OP-stack chain.
+type OPStackWithdrawal struct {
+	// Transaction is the withdrawal transaction to prove and finalize on layer 1.
+	Transaction contracts.TypesWithdrawalTransaction
+	// Hash is the withdrawal hash.
+	Hash common.Hash
+}
+
+var opStackWithdrawalArguments abi.Arguments
+
+func init() {
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	opStackWithdrawalArguments = abi.Arguments{
+		{Type: uint256Type},
+		{Type: addressType},
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: bytesType},
+	}
+}
+
+// OPStackWithdrawalHash returns the hash that identifies a withdrawal transaction.
+func OPStackWithdrawalHash(tx *contracts.TypesWithdrawalTransaction) (common.Hash, error) {
+	data, err := opStackWithdrawalArguments.Pack(tx.Nonce, tx.Sender, tx.Target, tx.Value, tx.GasLimit, tx.Data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// OPStackWithdrawalSlot returns the storage slot of the message passer that records the
+// withdrawal with the given hash.
+func OPStackWithdrawalSlot(hash common.Hash) common.Hash {
+	// The slot is that of sentMessages[hash], with the mapping at slot 0.
+	return crypto.Keccak256Hash(hash.Bytes(), common.Hash{}.Bytes())
+}
+
+// OPStackOutputRoot returns the output root committed to by the output root proof.
+func OPStackOutputRoot(proof *contracts.TypesOutputRootProof) common.Hash {
+	return crypto.Keccak256Hash(proof.Version[:], proof.StateRoot[:], proof.MessagePasserStorageRoot[:], proof.LatestBlockhash[:])
+}
+
+// OPStackWithdrawals returns the withdrawals initiated by a transaction from its receipt.
+func OPStackWithdrawals(receipt *types.Receipt) ([]*OPStackWithdrawal, error) {
+	filterer, err := contracts.NewL2ToL1MessagePasserFilterer(OPStackL2ToL1MessagePasser, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]*OPStackWithdrawal, 0)
+	for _, log := range receipt.Logs {
+		if log.Address != OPStackL2ToL1MessagePasser {
+			continue
+		}
+		event, err := filterer.ParseMessagePassed(*log)
+		if err != nil {
+			// Not a message passed event.
+			continue
+		}
+		withdrawal := &OPStackWithdrawal{
+			Transaction: contracts.TypesWithdrawalTransaction{
+				Nonce:    event.Nonce,
+				Sender:   event.Sender,
+				Target:   event.Target,
+				Value:    event.Value,
+				GasLimit: event.GasLimit,
+				Data:     event.Data,
+			},
+			Hash: event.WithdrawalHash,
+		}
+		hash, err := OPStackWithdrawalHash(&withdrawal.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		if hash != withdrawal.Hash {
+			return nil, fmt.Errorf("withdrawal hash mismatch: event has %#x, calculated %#x", withdrawal.Hash, hash)
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	return withdrawals, nil
+}
@@ -0,0 +1,146 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/contracts"
+)
+
+func testOPStackWithdrawal() *contracts.TypesWithdrawalTransaction {
+	return &contracts.TypesWithdrawalTransaction{
+		Nonce:    new(big.Int).SetBytes(common.FromHex("0x0001000000000000000000000000000000000000000000000000000000000005")),
+		Sender:   common.HexToAddress("0x4200000000000000000000000000000000000007"),
+		Target:   common.HexToAddress("0x25ace71c97B33Cc4729CF772ae268934F7ab5fA1"),
+		Value:    big.NewInt(1000000000000000000),
+		GasLimit: big.NewInt(491520),
+		Data:     common.FromHex("0xd764ad0b"),
+	}
+}
+
+func TestOPStackWithdrawalHash(t *testing.T) {
+	tx := testOPStackWithdrawal()
+
+	// Manual ABI encoding of the withdrawal.
+	encoded := make([]byte, 0)
+	encoded = append(encoded, common.LeftPadBytes(tx.Nonce.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(tx.Sender.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(tx.Target.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(tx.Value.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(tx.GasLimit.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes([]byte{0xc0}, 32)...)
+	encoded = append(encoded, common.LeftPadBytes([]byte{0x04}, 32)...)
+	encoded = append(encoded, common.RightPadBytes(tx.Data, 32)...)
+
+	hash, err := OPStackWithdrawalHash(tx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(encoded), hash)
+}
+
+func TestOPStackWithdrawalSlot(t *testing.T) {
+	hash := common.HexToHash("0x1fb9f4a8b2e9e1d0a7b3a4f1c0b7e4f2a9d6c3b0e7f4a1d8c5b2e9f6a3d0c7b4")
+	expected := crypto.Keccak256Hash(common.FromHex("0x1fb9f4a8b2e9e1d0a7b3a4f1c0b7e4f2a9d6c3b0e7f4a1d8c5b2e9f6a3d0c7b40000000000000000000000000000000000000000000000000000000000000000"))
+	require.Equal(t, expected, OPStackWithdrawalSlot(hash))
+}
+
+func TestOPStackOutputRoot(t *testing.T) {
+	proof := &contracts.TypesOutputRootProof{
+		StateRoot:                common.Hash{0x01},
+		MessagePasserStorageRoot: common.Hash{0x02},
+		LatestBlockhash:          common.Hash{0x03},
+	}
+	encoded := make([]byte, 128)
+	encoded[32] = 0x01
+	encoded[64] = 0x02
+	encoded[96] = 0x03
+	require.Equal(t, crypto.Keccak256Hash(encoded), OPStackOutputRoot(proof))
+}
+
+func testMessagePassedLog(t *testing.T, tx *contracts.TypesWithdrawalTransaction, hash common.Hash) *types.Log {
+	messagePasserABI, err := contracts.L2ToL1MessagePasserMetaData.GetAbi()
+	require.NoError(t, err)
+	event := messagePasserABI.Events["MessagePassed"]
+	data, err := event.Inputs.NonIndexed().Pack(tx.Value, tx.GasLimit, tx.Data, hash)
+	require.NoError(t, err)
+	return &types.Log{
+		Address: OPStackL2ToL1MessagePasser,
+		Topics: []common.Hash{
+			event.ID,
+			common.BigToHash(tx.Nonce),
+			common.BytesToHash(tx.Sender.Bytes()),
+			common.BytesToHash(tx.Target.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func TestOPStackWithdrawals(t *testing.T) {
+	tx := testOPStackWithdrawal()
+	hash, err := OPStackWithdrawalHash(tx)
+	require.NoError(t, err)
+
+	otherLog := testMessagePassedLog(t, tx, hash)
+	otherLog.Address = common.HexToAddress("0x4200000000000000000000000000000000000010")
+
+	tests := []struct {
+		name        string
+		logs        []*types.Log
+		withdrawals []*OPStackWithdrawal
+		err         string
+	}{
+		{
+			name:        "None",
+			withdrawals: []*OPStackWithdrawal{},
+		},
+		{
+			name:        "OtherContract",
+			logs:        []*types.Log{otherLog},
+			withdrawals: []*OPStackWithdrawal{},
+		},
+		{
+			name: "Good",
+			logs: []*types.Log{otherLog, testMessagePassedLog(t, tx, hash)},
+			withdrawals: []*OPStackWithdrawal{
+				{
+					Transaction: *tx,
+					Hash:        hash,
+				},
+			},
+		},
+		{
+			name: "HashMismatch",
+			logs: []*types.Log{testMessagePassedLog(t, tx, common.Hash{0x01})},
+			err:  "withdrawal hash mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withdrawals, err := OPStackWithdrawals(&types.Receipt{Logs: test.logs})
+			if test.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.withdrawals, withdrawals)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output provides the formats in which commands output their results.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is the format in which results are output.
+type Format int
+
+const (
+	// Text is human-readable text.
+	Text Format = iota
+	// JSON is machine-readable JSON, one value per result.
+	JSON
+)
+
+// String implements the stringer interface.
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case JSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFormat parses the name of a format.  An empty name is the text format.
+func ParseFormat(input string) (Format, error) {
+	switch strings.ToLower(input) {
+	case "", "text":
+		return Text, nil
+	case "json":
+		return JSON, nil
+	default:
+		return Text, fmt.Errorf("unknown output format %s", input)
+	}
+}
+
+// Write writes a result as a single line of JSON.
+// Large integers should be supplied as strings so that they can be parsed without loss
+// of precision.
+func Write(w io.Writer, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(result)
+}
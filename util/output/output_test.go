@@ -0,0 +1,72 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/output"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format output.Format
+		err    string
+	}{
+		{
+			name:   "Empty",
+			format: output.Text,
+		},
+		{
+			name:   "Text",
+			input:  "text",
+			format: output.Text,
+		},
+		{
+			name:   "JSON",
+			input:  "JSON",
+			format: output.JSON,
+		},
+		{
+			name:  "Unknown",
+			input: "yaml",
+			err:   "unknown output format yaml",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, err := output.ParseFormat(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.format, format)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, output.Write(buf, map[string]interface{}{
+		"balance": "1000000000000000000000",
+		"name":    "a<b>",
+	}))
+	require.Equal(t, `{"balance":"1000000000000000000000","name":"a<b>"}`+"\n", buf.String())
+}
@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/wealdtech/ethereal/v2/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// Unit is the unit in which Ether values are output.
+type Unit int
+
+const (
+	// Auto selects the most readable unit for each value.
+	Auto Unit = iota
+	// Wei outputs values in Wei, and token amounts without adjusting for decimals.
+	Wei
+	// GWei outputs values in GWei.
+	GWei
+	// Ether outputs values in Ether.
+	Ether
+)
+
+// String implements the stringer interface.
+func (u Unit) String() string {
+	switch u {
+	case Auto:
+		return "auto"
+	case Wei:
+		return "wei"
+	case GWei:
+		return "gwei"
+	case Ether:
+		return "ether"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseUnit parses the name of a unit.  An empty name is the automatic unit.
+func ParseUnit(input string) (Unit, error) {
+	switch strings.ToLower(input) {
+	case "", "auto":
+		return Auto, nil
+	case "wei":
+		return Wei, nil
+	case "gwei":
+		return GWei, nil
+	case "ether", "eth":
+		return Ether, nil
+	default:
+		return Auto, fmt.Errorf("unknown unit %s", input)
+	}
+}
+
+// FormatWei formats a value in Wei in the given unit.
+func FormatWei(value *big.Int, unit Unit) string {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	switch unit {
+	case Wei:
+		return fmt.Sprintf("%s Wei", value.String())
+	case GWei:
+		return fmt.Sprintf("%s GWei", decimalString(value, 9))
+	case Ether:
+		return fmt.Sprintf("%s Ether", decimalString(value, 18))
+	default:
+		return string2eth.WeiToString(value, true)
+	}
+}
+
+// FormatTokens formats a token amount.  If raw is true the amount is output as an integer
+// number of the token's smallest unit, otherwise it is adjusted for the token's decimals.
+func FormatTokens(value *big.Int, decimals uint8, raw bool) string {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	if raw {
+		return value.String()
+	}
+	return util.TokenValueToString(value, decimals, false)
+}
+
+// decimalString formats a value with the given number of decimal places, without trailing zeros.
+func decimalString(value *big.Int, decimals uint8) string {
+	if value.Sign() < 0 {
+		return "-" + util.TokenValueToString(new(big.Int).Neg(value), decimals, false)
+	}
+	return util.TokenValueToString(value, decimals, false)
+}
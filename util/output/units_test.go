@@ -0,0 +1,143 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util/output"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		unit  output.Unit
+		err   string
+	}{
+		{
+			name: "Empty",
+			unit: output.Auto,
+		},
+		{
+			name:  "Wei",
+			input: "wei",
+			unit:  output.Wei,
+		},
+		{
+			name:  "GWei",
+			input: "GWei",
+			unit:  output.GWei,
+		},
+		{
+			name:  "Ether",
+			input: "ether",
+			unit:  output.Ether,
+		},
+		{
+			name:  "Eth",
+			input: "eth",
+			unit:  output.Ether,
+		},
+		{
+			name:  "Unknown",
+			input: "finney",
+			err:   "unknown unit finney",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unit, err := output.ParseUnit(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.unit, unit)
+			}
+		})
+	}
+}
+
+func TestFormatWei(t *testing.T) {
+	value, _ := new(big.Int).SetString("1500000000000000000", 10)
+	tests := []struct {
+		name   string
+		value  *big.Int
+		unit   output.Unit
+		output string
+	}{
+		{
+			name:   "Nil",
+			unit:   output.Wei,
+			output: "0 Wei",
+		},
+		{
+			name:   "Auto",
+			value:  value,
+			unit:   output.Auto,
+			output: "1.5 Ether",
+		},
+		{
+			name:   "Wei",
+			value:  value,
+			unit:   output.Wei,
+			output: "1500000000000000000 Wei",
+		},
+		{
+			name:   "GWei",
+			value:  value,
+			unit:   output.GWei,
+			output: "1500000000 GWei",
+		},
+		{
+			name:   "GWeiFraction",
+			value:  big.NewInt(1500000001),
+			unit:   output.GWei,
+			output: "1.500000001 GWei",
+		},
+		{
+			name:   "Ether",
+			value:  big.NewInt(1000000000),
+			unit:   output.Ether,
+			output: "0.000000001 Ether",
+		},
+		{
+			name:   "EtherZero",
+			value:  big.NewInt(0),
+			unit:   output.Ether,
+			output: "0 Ether",
+		},
+		{
+			name:   "EtherNegative",
+			value:  big.NewInt(-1000000000),
+			unit:   output.Ether,
+			output: "-0.000000001 Ether",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, output.FormatWei(test.value, test.unit))
+		})
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	require.Equal(t, "12.5", output.FormatTokens(big.NewInt(12500000), 6, false))
+	require.Equal(t, "12500000", output.FormatTokens(big.NewInt(12500000), 6, true))
+	require.Equal(t, "0", output.FormatTokens(nil, 18, false))
+}
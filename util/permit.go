@@ -0,0 +1,69 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	permitTypeHash       = crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+)
+
+// PermitDomain is the EIP-712 domain of a token that supports EIP-2612 permits.
+type PermitDomain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// Permit is an EIP-2612 permit, allowing the spender to transfer up to value tokens on
+// behalf of the owner.
+type Permit struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+}
+
+// Separator returns the EIP-712 domain separator.
+func (d *PermitDomain) Separator() common.Hash {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash,
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		math.U256Bytes(new(big.Int).Set(d.ChainID)),
+		common.LeftPadBytes(d.VerifyingContract.Bytes(), 32),
+	)
+}
+
+// PermitHash returns the EIP-712 hash of the permit, which is the value signed by the owner.
+func PermitHash(domainSeparator common.Hash, permit *Permit) common.Hash {
+	structHash := crypto.Keccak256(
+		permitTypeHash,
+		common.LeftPadBytes(permit.Owner.Bytes(), 32),
+		common.LeftPadBytes(permit.Spender.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(permit.Value)),
+		math.U256Bytes(new(big.Int).Set(permit.Nonce)),
+		math.U256Bytes(new(big.Int).Set(permit.Deadline)),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermitHash(t *testing.T) {
+	domain := &PermitDomain{
+		Name:              "Test Token",
+		Version:           "1",
+		ChainID:           big.NewInt(5),
+		VerifyingContract: common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+	}
+	permit := &Permit{
+		Owner:    common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+		Spender:  common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"),
+		Value:    big.NewInt(1000000000000000000),
+		Nonce:    big.NewInt(3),
+		Deadline: big.NewInt(1700000000),
+	}
+
+	// Calculate the expected values with the generic EIP-712 implementation.
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    permit.Owner.Hex(),
+			"spender":  permit.Spender.Hex(),
+			"value":    permit.Value.String(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": permit.Deadline.String(),
+		},
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	require.NoError(t, err)
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+
+	require.Equal(t, common.BytesToHash(domainSeparator), domain.Separator())
+	require.Equal(t, crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, messageHash), PermitHash(domain.Separator(), permit))
+}
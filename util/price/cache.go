@@ -0,0 +1,80 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache caches the prices obtained from another source in a file, for a limited time.
+type Cache struct {
+	source Source
+	path   string
+	ttl    time.Duration
+}
+
+// cachedPrice is a price stored in the cache.
+type cachedPrice struct {
+	Price     string    `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewCache creates a cache for the source, storing prices in the given file for the given time.
+func NewCache(source Source, path string, ttl time.Duration) *Cache {
+	return &Cache{
+		source: source,
+		path:   path,
+		ttl:    ttl,
+	}
+}
+
+// Price returns the price of 1 Ether in the given currency.
+func (c *Cache) Price(ctx context.Context, currency string) (*big.Float, error) {
+	currency = strings.ToUpper(currency)
+	prices := make(map[string]*cachedPrice)
+	if data, err := ioutil.ReadFile(c.path); err == nil {
+		// An unreadable cache is treated as empty.
+		_ = json.Unmarshal(data, &prices)
+	}
+	if cached, exists := prices[currency]; exists && cached != nil && time.Since(cached.Timestamp) < c.ttl {
+		if price, _, err := big.ParseFloat(cached.Price, 10, 256, big.ToNearestEven); err == nil {
+			return price, nil
+		}
+	}
+
+	price, err := c.source.Price(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failure to cache is not fatal.
+	prices[currency] = &cachedPrice{
+		Price:     price.Text('g', -1),
+		Timestamp: time.Now(),
+	}
+	if data, err := json.Marshal(prices); err == nil {
+		if err := os.MkdirAll(filepath.Dir(c.path), 0700); err == nil {
+			_ = ioutil.WriteFile(c.path, data, 0600)
+		}
+	}
+
+	return price, nil
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CoinGecko obtains prices from the CoinGecko API.
+type CoinGecko struct {
+	key string
+	url string
+}
+
+// Price returns the price of 1 Ether in the given currency.
+func (s *CoinGecko) Price(ctx context.Context, currency string) (*big.Float, error) {
+	currency = strings.ToLower(currency)
+	params := url.Values{}
+	params.Set("ids", "ethereum")
+	params.Set("vs_currencies", currency)
+	params.Set("precision", "full")
+	if s.key != "" {
+		params.Set("x_cg_demo_api_key", s.key)
+	}
+
+	body, err := fetch(ctx, fmt.Sprintf("%s/simple/price?%s", s.url, params.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain price from CoinGecko")
+	}
+	var res map[string]map[string]json.Number
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.Wrap(err, "invalid response from CoinGecko")
+	}
+	value, exists := res["ethereum"][currency]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	price, _, err := big.ParseFloat(value.String(), 10, 256, big.ToNearestEven)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid price from CoinGecko")
+	}
+	return price, nil
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package price obtains the price of Ether in fiat currencies.
+package price
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// ErrNotFound is returned when the source does not have a price for the currency.
+var ErrNotFound = errors.New("price not found")
+
+// Source is a source of prices.
+type Source interface {
+	// Price returns the price of 1 Ether in the given currency.
+	Price(ctx context.Context, currency string) (*big.Float, error)
+}
+
+// New creates a source given its name.  The key is the API key, if any, and the URL overrides
+// the default endpoint of the source.
+func New(name string, key string, url string) (Source, error) {
+	switch strings.ToLower(name) {
+	case "", "coingecko":
+		if url == "" {
+			url = "https://api.coingecko.com/api/v3"
+		}
+		return &CoinGecko{key: key, url: strings.TrimSuffix(url, "/")}, nil
+	default:
+		return nil, fmt.Errorf("unknown price source %s", name)
+	}
+}
+
+// Value returns the value of an amount of Wei at the given price of 1 Ether.
+func Value(price *big.Float, wei *big.Int) *big.Float {
+	value := new(big.Float).SetInt(wei)
+	value.Mul(value, price)
+	return value.Quo(value, new(big.Float).SetInt(big.NewInt(1e18)))
+}
+
+// Format formats a value in a fiat currency to 2 decimal places.
+func Format(value *big.Float, currency string) string {
+	return fmt.Sprintf("%s %s", value.Text('f', 2), strings.ToUpper(currency))
+}
+
+// fetch fetches the body of the given URL.
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
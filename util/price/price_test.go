@@ -0,0 +1,110 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package price
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinGecko(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/simple/price", r.URL.Path)
+		assert.Equal(t, "ethereum", r.URL.Query().Get("ids"))
+		switch r.URL.Query().Get("vs_currencies") {
+		case "usd":
+			fmt.Fprint(w, `{"ethereum":{"usd":2500.125}}`)
+		default:
+			fmt.Fprint(w, `{"ethereum":{}}`)
+		}
+	}))
+	defer server.Close()
+
+	source, err := New("coingecko", "", server.URL)
+	require.NoError(t, err)
+
+	price, err := source.Price(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "2500.125", price.Text('f', 3))
+
+	_, err = source.Price(context.Background(), "XYZ")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = New("unknown", "", "")
+	assert.EqualError(t, err, "unknown price source unknown")
+}
+
+func TestValue(t *testing.T) {
+	price := big.NewFloat(2500)
+	assert.Equal(t, "2500.00 USD", Format(Value(price, big.NewInt(1e18)), "usd"))
+	assert.Equal(t, "0.05 EUR", Format(Value(price, big.NewInt(21000*1e9)), "EUR"))
+	assert.Equal(t, "0.00 USD", Format(Value(price, big.NewInt(0)), "USD"))
+}
+
+type testSource struct {
+	requests int
+}
+
+func (s *testSource) Price(ctx context.Context, currency string) (*big.Float, error) {
+	s.requests++
+	if currency != "USD" {
+		return nil, ErrNotFound
+	}
+	return big.NewFloat(2500), nil
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "price")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "prices.json")
+
+	source := &testSource{}
+	cache := NewCache(source, path, time.Minute)
+
+	// First request goes to the source.
+	price, err := cache.Price(context.Background(), "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "2500", price.Text('f', 0))
+	assert.Equal(t, 1, source.requests)
+
+	// Second request is served from the cache.
+	price, err = cache.Price(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "2500", price.Text('f', 0))
+	assert.Equal(t, 1, source.requests)
+
+	// Errors are not cached.
+	_, err = cache.Price(context.Background(), "XYZ")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, 2, source.requests)
+
+	// Expired prices are obtained again.
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"USD":{"price":"1000","timestamp":"2020-01-01T00:00:00Z"}}`), 0600))
+	price, err = cache.Price(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "2500", price.Text('f', 0))
+	assert.Equal(t, 3, source.requests)
+}
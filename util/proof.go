@@ -0,0 +1,100 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+)
+
+// emptyCodeHash is the code hash of an account without code.
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+// VerifyAccountProof verifies a Merkle-Patricia proof of an account against a state root,
+// returning the account it proves.  An account that is proven not to exist is returned
+// as an empty account.
+func VerifyAccountProof(stateRoot common.Hash, address common.Address, proof [][]byte) (*types.StateAccount, error) {
+	value, err := verifyProof(stateRoot, crypto.Keccak256(address.Bytes()), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return &types.StateAccount{
+			Balance:  uint256.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: emptyCodeHash.Bytes(),
+		}, nil
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return nil, fmt.Errorf("invalid account in proof: %v", err)
+	}
+	return &account, nil
+}
+
+// VerifyStorageProof verifies a Merkle-Patricia proof of a storage slot against the
+// storage root of an account, returning the value it proves.  A slot that is proven not to
+// be set is returned as 0.
+func VerifyStorageProof(storageRoot common.Hash, slot common.Hash, proof [][]byte) (*big.Int, error) {
+	if storageRoot == types.EmptyRootHash && len(proof) == 0 {
+		return big.NewInt(0), nil
+	}
+	value, err := verifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	var content []byte
+	if err := rlp.DecodeBytes(value, &content); err != nil {
+		return nil, fmt.Errorf("invalid storage value in proof: %v", err)
+	}
+	return new(big.Int).SetBytes(content), nil
+}
+
+// AccountMatches returns true if the given account has the given values.
+func AccountMatches(account *types.StateAccount, nonce uint64, balance *big.Int, storageRoot common.Hash, codeHash common.Hash) bool {
+	return account.Nonce == nonce &&
+		account.Balance.ToBig().Cmp(balance) == 0 &&
+		account.Root == storageRoot &&
+		bytes.Equal(account.CodeHash, codeHash.Bytes())
+}
+
+// verifyProof verifies a proof of a key against a root, returning the value at the key
+// or nil if the proof shows the key is not present.
+func verifyProof(root common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	db := memorydb.New()
+	for _, node := range proof {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, key, db)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %v", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// proofList collects the nodes of a proof.
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return nil
+}
+
+// newTestTrie creates a secure trie, keyed by the hash of each key.
+func newTestTrie(t *testing.T, entries map[string][]byte) *trie.Trie {
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for key, value := range entries {
+		require.NoError(t, tr.Update(crypto.Keccak256([]byte(key)), value))
+	}
+	return tr
+}
+
+func prove(t *testing.T, tr *trie.Trie, key []byte) [][]byte {
+	var proof proofList
+	require.NoError(t, tr.Prove(crypto.Keccak256(key), &proof))
+	return proof
+}
+
+func TestVerifyProofs(t *testing.T) {
+	slot1 := common.BigToHash(big.NewInt(1))
+	slot2 := common.BigToHash(big.NewInt(2))
+	unset := common.BigToHash(big.NewInt(3))
+	value1, err := rlp.EncodeToBytes(big.NewInt(0x1234).Bytes())
+	require.NoError(t, err)
+	value2, err := rlp.EncodeToBytes(common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4").Bytes())
+	require.NoError(t, err)
+	storage := newTestTrie(t, map[string][]byte{string(slot1.Bytes()): value1, string(slot2.Bytes()): value2})
+
+	address := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	other := common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF")
+	missing := common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	account := &types.StateAccount{
+		Nonce:    5,
+		Balance:  uint256.NewInt(1000000000000000000),
+		Root:     storage.Hash(),
+		CodeHash: crypto.Keccak256([]byte{0x60, 0x00}),
+	}
+	encodedAccount, err := rlp.EncodeToBytes(account)
+	require.NoError(t, err)
+	encodedOther, err := rlp.EncodeToBytes(&types.StateAccount{Balance: uint256.NewInt(1), Root: types.EmptyRootHash, CodeHash: emptyCodeHash.Bytes()})
+	require.NoError(t, err)
+	state := newTestTrie(t, map[string][]byte{
+		string(address.Bytes()): encodedAccount,
+		string(other.Bytes()):   encodedOther,
+	})
+	stateRoot := state.Hash()
+
+	accountProof := func(address common.Address) [][]byte {
+		return prove(t, state, address.Bytes())
+	}
+
+	proven, err := VerifyAccountProof(stateRoot, address, accountProof(address))
+	require.NoError(t, err)
+	require.True(t, AccountMatches(proven, 5, big.NewInt(1000000000000000000), storage.Hash(), common.BytesToHash(account.CodeHash)))
+	require.False(t, AccountMatches(proven, 5, big.NewInt(1), storage.Hash(), common.BytesToHash(account.CodeHash)))
+
+	proven, err = VerifyAccountProof(stateRoot, missing, accountProof(missing))
+	require.NoError(t, err)
+	require.True(t, AccountMatches(proven, 0, big.NewInt(0), types.EmptyRootHash, emptyCodeHash))
+
+	// Proof for a different account.
+	_, err = VerifyAccountProof(stateRoot, address, accountProof(other))
+	require.Error(t, err)
+
+	// Proof against a different root.
+	_, err = VerifyAccountProof(storage.Hash(), address, accountProof(address))
+	require.Error(t, err)
+
+	value, err := VerifyStorageProof(storage.Hash(), slot1, prove(t, storage, slot1.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0x1234), value)
+
+	value, err = VerifyStorageProof(storage.Hash(), slot2, prove(t, storage, slot2.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).SetBytes(address.Bytes()), value)
+
+	value, err = VerifyStorageProof(storage.Hash(), unset, prove(t, storage, unset.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), value)
+
+	value, err = VerifyStorageProof(types.EmptyRootHash, slot1, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), value)
+
+	_, err = VerifyStorageProof(storage.Hash(), slot1, nil)
+	require.Error(t, err)
+
+	// Tampered proof.
+	tampered := prove(t, storage, slot1.Bytes())
+	tampered[len(tampered)-1] = append([]byte{}, tampered[len(tampered)-1]...)
+	tampered[len(tampered)-1][len(tampered[len(tampered)-1])-1] ^= 0x01
+	_, err = VerifyStorageProof(storage.Hash(), slot1, tampered)
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	// EIP1967ImplementationSlot is keccak256("eip1967.proxy.implementation") - 1.
+	EIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// EIP1967AdminSlot is keccak256("eip1967.proxy.admin") - 1.
+	EIP1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	// EIP1967BeaconSlot is keccak256("eip1967.proxy.beacon") - 1.
+	EIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	// EIP1822ImplementationSlot is keccak256("PROXIABLE").
+	EIP1822ImplementationSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+	// ZeppelinOSImplementationSlot is keccak256("org.zeppelinos.proxy.implementation").
+	ZeppelinOSImplementationSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+	// ZeppelinOSAdminSlot is keccak256("org.zeppelinos.proxy.admin").
+	ZeppelinOSAdminSlot = common.HexToHash("0x10d6a54a4754c8869d6886b5f5d7fbfa5b4522237ea5c60d11bc4e7a1ff9390b")
+
+	// beaconImplementationSelector is the selector for implementation().
+	beaconImplementationSelector = []byte{0x5c, 0x60, 0xda, 0x1b}
+)
+
+// ProxyInfo contains information about a proxy contract.
+type ProxyInfo struct {
+	// Type is the type of proxy, e.g. "EIP-1967".
+	Type string
+	// Implementation is the address of the implementation contract.
+	Implementation common.Address
+	// Admin is the address of the proxy admin, if known.
+	Admin *common.Address
+	// Beacon is the address of the beacon, if the proxy is a beacon proxy.
+	Beacon *common.Address
+}
+
+// ProxyDetails obtains details of the proxy at the given address.  It returns nil if the
+// contract is not a recognised proxy.
+func ProxyDetails(ctx context.Context, client *ethclient.Client, address common.Address, blockNumber *big.Int) (*ProxyInfo, error) {
+	implementation, err := storageAddress(ctx, client, address, EIP1967ImplementationSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != nil {
+		info := &ProxyInfo{
+			Type:           "EIP-1967",
+			Implementation: *implementation,
+		}
+		info.Admin, err = storageAddress(ctx, client, address, EIP1967AdminSlot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return info, nil
+	}
+
+	beacon, err := storageAddress(ctx, client, address, EIP1967BeaconSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if beacon != nil {
+		res, err := client.CallContract(ctx, ethereum.CallMsg{To: beacon, Data: beaconImplementationSelector}, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(res) != 32 {
+			return nil, nil
+		}
+		info := &ProxyInfo{
+			Type:           "EIP-1967 beacon",
+			Implementation: common.BytesToAddress(res),
+			Beacon:         beacon,
+		}
+		info.Admin, err = storageAddress(ctx, client, address, EIP1967AdminSlot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return info, nil
+	}
+
+	implementation, err = storageAddress(ctx, client, address, EIP1822ImplementationSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != nil {
+		return &ProxyInfo{
+			Type:           "EIP-1822",
+			Implementation: *implementation,
+		}, nil
+	}
+
+	implementation, err = storageAddress(ctx, client, address, ZeppelinOSImplementationSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != nil {
+		info := &ProxyInfo{
+			Type:           "ZeppelinOS",
+			Implementation: *implementation,
+		}
+		info.Admin, err = storageAddress(ctx, client, address, ZeppelinOSAdminSlot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return info, nil
+	}
+
+	return nil, nil
+}
+
+// storageAddress returns the address held in the given storage slot, or nil if the slot is empty.
+func storageAddress(ctx context.Context, client *ethclient.Client, address common.Address, slot common.Hash, blockNumber *big.Int) (*common.Address, error) {
+	value, err := client.StorageAt(ctx, address, slot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	res := common.BytesToAddress(value)
+	if res == (common.Address{}) {
+		return nil, nil
+	}
+	return &res, nil
+}
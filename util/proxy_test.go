@@ -0,0 +1,38 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxySlots(t *testing.T) {
+	eip1967Slot := func(name string) common.Hash {
+		slot := new(big.Int).SetBytes(crypto.Keccak256([]byte(name)))
+		return common.BigToHash(slot.Sub(slot, big.NewInt(1)))
+	}
+
+	assert.Equal(t, eip1967Slot("eip1967.proxy.implementation"), EIP1967ImplementationSlot)
+	assert.Equal(t, eip1967Slot("eip1967.proxy.admin"), EIP1967AdminSlot)
+	assert.Equal(t, eip1967Slot("eip1967.proxy.beacon"), EIP1967BeaconSlot)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("PROXIABLE")), EIP1822ImplementationSlot)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("org.zeppelinos.proxy.implementation")), ZeppelinOSImplementationSlot)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("org.zeppelinos.proxy.admin")), ZeppelinOSAdminSlot)
+	assert.Equal(t, crypto.Keccak256([]byte("implementation()"))[:4], beaconImplementationSelector)
+}
@@ -0,0 +1,141 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// errorSelector is the selector for Error(string).
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// panicSelector is the selector for Panic(uint256).
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// ErrUnknownRevert is returned when revert data does not match a known error.
+var ErrUnknownRevert = errors.New("unknown error")
+
+// panicReasons are the descriptions of the panic codes generated by the Solidity compiler.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop from empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized function",
+}
+
+// RevertData obtains the revert data from an error returned by a call or gas estimate, if present.
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	dataStr, isString := dataErr.ErrorData().(string)
+	if !isString {
+		return nil, false
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(dataStr, "0x"))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// DecodeRevert decodes revert data in to a human-readable message.  It understands Error(string),
+// Panic(uint256) and any custom errors defined in the supplied ABI, which can be nil.  If the data
+// does not match any of these the error wraps ErrUnknownRevert.
+func DecodeRevert(contractAbi *abi.ABI, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "execution reverted", nil
+	}
+	if len(data) < 4 {
+		return "", fmt.Errorf("revert data 0x%s too short", hex.EncodeToString(data))
+	}
+
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return "", err
+		}
+		return reason, nil
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) != 36 {
+			return "", errors.New("invalid panic data")
+		}
+		code := new(big.Int).SetBytes(data[4:])
+		if code.IsUint64() {
+			if reason, exists := panicReasons[code.Uint64()]; exists {
+				return fmt.Sprintf("panic: %s (0x%02x)", reason, code.Uint64()), nil
+			}
+		}
+		return fmt.Sprintf("panic: 0x%s", code.Text(16)), nil
+	}
+
+	if contractAbi != nil {
+		for _, abiErr := range contractAbi.Errors {
+			if !bytes.Equal(abiErr.ID[:4], data[:4]) {
+				continue
+			}
+			values, err := abiErr.Inputs.Unpack(data[4:])
+			if err != nil {
+				return "", err
+			}
+			args := make([]string, len(values))
+			for i := range values {
+				args[i], err = ABIValueToString(abiErr.Inputs[i].Type, values[i], nil)
+				if err != nil {
+					return "", err
+				}
+				if abiErr.Inputs[i].Name != "" {
+					args[i] = fmt.Sprintf("%s=%s", abiErr.Inputs[i].Name, args[i])
+				}
+			}
+			return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(args, ",")), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w 0x%s", ErrUnknownRevert, hex.EncodeToString(data))
+}
+
+// RevertReason provides a human-readable reason for a failed call or gas estimate.  If the error
+// does not contain revert data then the error itself is returned.
+func RevertReason(contractAbi *abi.ABI, err error) string {
+	data, exists := RevertData(err)
+	if !exists {
+		return err.Error()
+	}
+	reason, decodeErr := DecodeRevert(contractAbi, data)
+	switch {
+	case errors.Is(decodeErr, ErrUnknownRevert):
+		return fmt.Sprintf("execution reverted: %s", decodeErr.Error())
+	case decodeErr != nil:
+		return err.Error()
+	}
+	return fmt.Sprintf("execution reverted: %s", reason)
+}
@@ -0,0 +1,128 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDataError struct {
+	data interface{}
+}
+
+func (e *testDataError) Error() string          { return "execution reverted" }
+func (e *testDataError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevert(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"uint256","name":"available","type":"uint256"},{"internalType":"uint256","name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"},{"inputs":[],"name":"Unauthorized","type":"error"},{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"bytes4","name":"selector","type":"bytes4"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"uint256[]","name":"ids","type":"uint256[]"}],"name":"NotOwner","type":"error"}]`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		abi     *abi.ABI
+		data    []byte
+		res     string
+		err     string
+		unknown bool
+	}{
+		{
+			name: "Empty",
+			res:  "execution reverted",
+		},
+		{
+			name: "Short",
+			data: MustDecodeHexString("0x08c379"),
+			err:  "revert data 0x08c379 too short",
+		},
+		{
+			name: "Error",
+			data: MustDecodeHexString("0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000e4e6f7420617574686f72697a6564000000000000000000000000000000000000"),
+			res:  "Not authorized",
+		},
+		{
+			name: "Panic",
+			data: MustDecodeHexString("0x4e487b710000000000000000000000000000000000000000000000000000000000000011"),
+			res:  "panic: arithmetic overflow or underflow (0x11)",
+		},
+		{
+			name: "PanicUnknown",
+			data: MustDecodeHexString("0x4e487b7100000000000000000000000000000000000000000000000000000000000000ff"),
+			res:  "panic: 0xff",
+		},
+		{
+			name: "Custom",
+			abi:  &contractAbi,
+			data: append(contractAbi.Errors["InsufficientBalance"].ID.Bytes()[:4], MustDecodeHexString("0x00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002")...),
+			res:  "InsufficientBalance(available=1,required=2)",
+		},
+		{
+			name: "CustomNoArgs",
+			abi:  &contractAbi,
+			data: contractAbi.Errors["Unauthorized"].ID.Bytes()[:4],
+			res:  "Unauthorized()",
+		},
+		{
+			name: "CustomTypedArgs",
+			abi:  &contractAbi,
+			data: append(contractAbi.Errors["NotOwner"].ID.Bytes()[:4], MustDecodeHexString("0x0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4a9059cbb00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000c000000000000000000000000000000000000000000000000000000000000000020102000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002")...),
+			res:  "NotOwner(owner=0x5FfC014343cd971B7eb70732021E26C35B744cc4,selector=0xa9059cbb,data=0x0102,ids=[1,2])",
+		},
+		{
+			name:    "Unknown",
+			data:    MustDecodeHexString("0x01020304"),
+			err:     "unknown error 0x01020304",
+			unknown: true,
+		},
+		{
+			name:    "UnknownWithABI",
+			abi:     &contractAbi,
+			data:    MustDecodeHexString("0x0102030400000000000000000000000000000000000000000000000000000000000000ff"),
+			err:     "unknown error 0x0102030400000000000000000000000000000000000000000000000000000000000000ff",
+			unknown: true,
+		},
+		{
+			name: "CustomBadArgs",
+			abi:  &contractAbi,
+			data: append(contractAbi.Errors["InsufficientBalance"].ID.Bytes()[:4], 0x01),
+			err:  "abi: cannot marshal in to go type: length insufficient 1 require 32",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := DecodeRevert(test.abi, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				require.Equal(t, test.unknown, errors.Is(err, ErrUnknownRevert))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestRevertReason(t *testing.T) {
+	assert.Equal(t, "plain error", RevertReason(nil, errors.New("plain error")))
+	assert.Equal(t, "execution reverted: panic: division or modulo by zero (0x12)",
+		RevertReason(nil, errors.Wrap(&testDataError{data: "0x4e487b710000000000000000000000000000000000000000000000000000000000000012"}, "failed to estimate gas")))
+	assert.Equal(t, "execution reverted", RevertReason(nil, &testDataError{data: 1}))
+	assert.Equal(t, "execution reverted: unknown error 0x01020304", RevertReason(nil, &testDataError{data: "0x01020304"}))
+}
@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rlpdump decodes RLP into a form suitable for inspection, recognising common structures
+// such as transactions and block headers, and encodes RLP from JSON.
+package rlpdump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+)
+
+// Item is a decoded RLP item, either a byte string or a list.
+type Item struct {
+	// Bytes is the content of a byte string.
+	Bytes []byte
+	// List is the content of a list.
+	List []*Item
+	// IsList is true if the item is a list.
+	IsList bool
+}
+
+// Decode decodes RLP data.  An error is returned if the data is not a single valid RLP item.
+func Decode(data []byte) (*Item, error) {
+	item, rest, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%d bytes of trailing data", len(rest))
+	}
+	return item, nil
+}
+
+func decode(data []byte) (*Item, []byte, error) {
+	kind, content, rest, err := rlp.Split(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kind != rlp.List {
+		return &Item{Bytes: content}, rest, nil
+	}
+	item := &Item{
+		List:   make([]*Item, 0),
+		IsList: true,
+	}
+	for len(content) > 0 {
+		var child *Item
+		child, content, err = decode(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		item.List = append(item.List, child)
+	}
+	return item, rest, nil
+}
+
+// JSON returns the item as a structure suitable for encoding as JSON, with byte strings as hex
+// strings and lists as arrays.  The result can be supplied to Encode.
+func (i *Item) JSON() interface{} {
+	if !i.IsList {
+		return hexutil.Encode(i.Bytes)
+	}
+	res := make([]interface{}, len(i.List))
+	for j := range i.List {
+		res[j] = i.List[j].JSON()
+	}
+	return res
+}
+
+// Encode encodes a JSON structure as RLP.  Arrays are encoded as lists, strings starting with
+// 0x as hex bytes and other strings as UTF-8 text, non-negative integers as big-endian integers
+// and booleans as 0x01 and empty.
+func Encode(input []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid JSON: trailing data")
+	}
+	encodable, err := encodable(value)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(encodable)
+}
+
+// encodable converts a JSON value to a value that can be RLP encoded.
+func encodable(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			var err error
+			res[i], err = encodable(v[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			data, err := hexutil.Decode(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex %s", v)
+			}
+			return data, nil
+		}
+		return []byte(v), nil
+	case json.Number:
+		number, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok || number.Sign() < 0 {
+			return nil, fmt.Errorf("invalid number %s; numbers must be non-negative integers", v)
+		}
+		return number, nil
+	case bool:
+		if v {
+			return []byte{0x01}, nil
+		}
+		return []byte{}, nil
+	default:
+		return nil, fmt.Errorf("cannot encode %v", value)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rlpdump
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		json interface{}
+		err  string
+	}{
+		{
+			name: "Byte",
+			data: "0x05",
+			json: "0x05",
+		},
+		{
+			name: "Empty",
+			data: "0x80",
+			json: "0x",
+		},
+		{
+			name: "String",
+			data: "0x83646f67",
+			json: "0x646f67",
+		},
+		{
+			name: "Nested",
+			data: "0xc7c0c1c0c3c0c1c0",
+			json: []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}, []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}},
+		},
+		{
+			name: "Trailing",
+			data: "0x0505",
+			err:  "1 bytes of trailing data",
+		},
+		{
+			name: "Truncated",
+			data: "0x83646f",
+			err:  "rlp: value size exceeds available input length",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			item, err := Decode(hexutil.MustDecode(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.json, item.JSON())
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		data  string
+		err   string
+	}{
+		{
+			name:  "Text",
+			input: `"dog"`,
+			data:  "0x83646f67",
+		},
+		{
+			name:  "List",
+			input: `["cat", "0x646f67", 1024, 0, true, false]`,
+			data:  "0xce8363617483646f67820400800180",
+		},
+		{
+			name:  "Nested",
+			input: `[[], [[]], [[], [[]]]]`,
+			data:  "0xc7c0c1c0c3c0c1c0",
+		},
+		{
+			name:  "Negative",
+			input: `-1`,
+			err:   "invalid number -1; numbers must be non-negative integers",
+		},
+		{
+			name:  "Fraction",
+			input: `1.5`,
+			err:   "invalid number 1.5; numbers must be non-negative integers",
+		},
+		{
+			name:  "BadHex",
+			input: `"0xzz"`,
+			err:   "invalid hex 0xzz",
+		},
+		{
+			name:  "Null",
+			input: `[null]`,
+			err:   "cannot encode <nil>",
+		},
+		{
+			name:  "Object",
+			input: `{"a":1}`,
+			err:   "cannot encode map[a:1]",
+		},
+		{
+			name:  "Trailing",
+			input: `1 2`,
+			err:   "invalid JSON: trailing data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := Encode([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.data, hexutil.Encode(data))
+		})
+	}
+}
+
+func TestRecognise(t *testing.T) {
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	legacyTx, err := types.NewTx(&types.LegacyTx{
+		Nonce:    5,
+		GasPrice: big.NewInt(1000000000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+		V:        big.NewInt(37),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+	}).MarshalBinary()
+	require.NoError(t, err)
+	dynamicFeeTx, err := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     5,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(2000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		V:         big.NewInt(1),
+		R:         big.NewInt(1),
+		S:         big.NewInt(2),
+	}).MarshalBinary()
+	require.NoError(t, err)
+	header, err := rlp.EncodeToBytes(&types.Header{
+		Coinbase:   to,
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(12965000),
+		GasLimit:   30000000,
+		Time:       1628166822,
+		BaseFee:    big.NewInt(1000000000),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		structure string
+		contains  []string
+		err       string
+	}{
+		{
+			name:      "LegacyTx",
+			data:      legacyTx,
+			structure: "Legacy transaction",
+			contains:  []string{"  nonce: 5\n", "  gas: 21000\n", "  to: 0x5ffc014343cd971b7eb70732021e26c35b744cc4\n", "  v: 37\n"},
+		},
+		{
+			name:      "DynamicFeeTx",
+			data:      dynamicFeeTx,
+			structure: "Dynamic fee transaction",
+			contains:  []string{"  chainId: 1\n", "  maxFeePerGas: 2000000000\n", "  accessList: []\n", "  yParity: 1\n"},
+		},
+		{
+			name:      "Header",
+			data:      header,
+			structure: "Block header",
+			contains:  []string{"  number: 12965000\n", "  miner: 0x5ffc014343cd971b7eb70732021e26c35b744cc4\n", "  baseFeePerGas: 1000000000\n"},
+		},
+		{
+			name:     "Generic",
+			data:     hexutil.MustDecode("0xc88363617483646f67"),
+			contains: []string{"[\n  0x636174\n  0x646f67\n]\n"},
+		},
+		{
+			name: "Empty",
+			data: []byte{},
+			err:  "no data",
+		},
+		{
+			name: "InvalidTypedTx",
+			data: hexutil.MustDecode("0x02c0"),
+			err:  "invalid type 2 transaction",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			structure, err := Recognise(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.structure, structure.Name)
+			output := structure.String()
+			for _, contains := range test.contains {
+				require.Contains(t, output, contains)
+			}
+		})
+	}
+}
+
+func TestStructureJSON(t *testing.T) {
+	structure, err := Recognise(hexutil.MustDecode("0xc88363617483646f67"))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"0x636174", "0x646f67"}, structure.JSON())
+
+	tx, err := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(0),
+		GasFeeCap: big.NewInt(0),
+		Value:     big.NewInt(0),
+		V:         big.NewInt(0),
+		R:         big.NewInt(0),
+		S:         big.NewInt(0),
+	}).MarshalBinary()
+	require.NoError(t, err)
+	structure, err = Recognise(tx)
+	require.NoError(t, err)
+	res := structure.JSON().(map[string]interface{})
+	require.Equal(t, "Dynamic fee transaction", res["type"])
+	require.Equal(t, "0x01", res["fields"].(map[string]interface{})["chainId"])
+}
@@ -0,0 +1,207 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rlpdump
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// field is a named field of a structure.
+type field struct {
+	name    string
+	numeric bool
+}
+
+// Structure is a recognised RLP structure.
+type Structure struct {
+	// Name is the name of the structure, for example "Dynamic fee transaction".
+	Name string
+	// Item is the decoded item.
+	Item *Item
+	// fields are the names of the items in the structure.
+	fields []field
+}
+
+var accessListFields = []field{{"accessList", false}}
+
+var legacyTxFields = []field{
+	{"nonce", true}, {"gasPrice", true}, {"gas", true}, {"to", false}, {"value", true}, {"data", false},
+	{"v", true}, {"r", false}, {"s", false},
+}
+
+var accessListTxFields = concat([]field{
+	{"chainId", true}, {"nonce", true}, {"gasPrice", true}, {"gas", true}, {"to", false}, {"value", true}, {"data", false},
+}, accessListFields, signatureFields)
+
+var dynamicFeeTxFields = concat(dynamicFeeFields, accessListFields, signatureFields)
+
+var blobTxFields = concat(dynamicFeeFields, accessListFields, []field{
+	{"maxFeePerBlobGas", true}, {"blobVersionedHashes", false},
+}, signatureFields)
+
+var setCodeTxFields = concat(dynamicFeeFields, accessListFields, []field{
+	{"authorizationList", false},
+}, signatureFields)
+
+var dynamicFeeFields = []field{
+	{"chainId", true}, {"nonce", true}, {"maxPriorityFeePerGas", true}, {"maxFeePerGas", true}, {"gas", true},
+	{"to", false}, {"value", true}, {"data", false},
+}
+
+var signatureFields = []field{{"yParity", true}, {"r", false}, {"s", false}}
+
+var headerFields = []field{
+	{"parentHash", false}, {"sha3Uncles", false}, {"miner", false}, {"stateRoot", false},
+	{"transactionsRoot", false}, {"receiptsRoot", false}, {"logsBloom", false}, {"difficulty", true},
+	{"number", true}, {"gasLimit", true}, {"gasUsed", true}, {"timestamp", true}, {"extraData", false},
+	{"mixHash", false}, {"nonce", false}, {"baseFeePerGas", true}, {"withdrawalsRoot", false},
+	{"blobGasUsed", true}, {"excessBlobGas", true}, {"parentBeaconBlockRoot", false}, {"requestsHash", false},
+}
+
+// typedTxs are the typed transactions, by type.
+var typedTxs = map[byte]struct {
+	name   string
+	fields []field
+}{
+	0x01: {"Access list transaction", accessListTxFields},
+	0x02: {"Dynamic fee transaction", dynamicFeeTxFields},
+	0x03: {"Blob transaction", blobTxFields},
+	0x04: {"Set code transaction", setCodeTxFields},
+}
+
+func concat(fields ...[]field) []field {
+	res := make([]field, 0)
+	for i := range fields {
+		res = append(res, fields[i]...)
+	}
+	return res
+}
+
+// Recognise decodes RLP data, recognising transactions and block headers.  If the data is not
+// a recognised structure then the structure's name is empty and its items are not named.
+func Recognise(data []byte) (*Structure, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data")
+	}
+	if tx, exists := typedTxs[data[0]]; len(data) > 1 && exists {
+		// Typed transactions are the type followed by the RLP of the transaction.
+		item, err := Decode(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		if !item.IsList || len(item.List) != len(tx.fields) {
+			return nil, fmt.Errorf("invalid type %d transaction", data[0])
+		}
+		return &Structure{Name: tx.name, Item: item, fields: tx.fields}, nil
+	}
+
+	item, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	res := &Structure{Item: item}
+	switch {
+	case isLegacyTx(item):
+		res.Name = "Legacy transaction"
+		res.fields = legacyTxFields
+	case isHeader(item):
+		res.Name = "Block header"
+		res.fields = headerFields[:len(item.List)]
+	}
+	return res, nil
+}
+
+// isLegacyTx returns true if the item looks like a legacy transaction.
+func isLegacyTx(item *Item) bool {
+	if !item.IsList || len(item.List) != 9 {
+		return false
+	}
+	for _, child := range item.List {
+		if child.IsList {
+			return false
+		}
+	}
+	to := len(item.List[3].Bytes)
+	return (to == 0 || to == 20) && len(item.List[7].Bytes) <= 32 && len(item.List[8].Bytes) <= 32
+}
+
+// isHeader returns true if the item looks like a block header.
+func isHeader(item *Item) bool {
+	if !item.IsList || len(item.List) < 15 || len(item.List) > len(headerFields) {
+		return false
+	}
+	for _, child := range item.List {
+		if child.IsList {
+			return false
+		}
+	}
+	return len(item.List[0].Bytes) == 32 && len(item.List[2].Bytes) == 20 && len(item.List[6].Bytes) == 256
+}
+
+// JSON returns the structure as a structure suitable for encoding as JSON.  Recognised
+// structures are objects with named fields, otherwise the structure is as for Item.JSON().
+func (s *Structure) JSON() interface{} {
+	if s.Name == "" {
+		return s.Item.JSON()
+	}
+	fields := make(map[string]interface{})
+	for i, child := range s.Item.List {
+		fields[s.fields[i].name] = child.JSON()
+	}
+	return map[string]interface{}{
+		"type":   s.Name,
+		"fields": fields,
+	}
+}
+
+// String returns a multi-line representation of the structure.
+func (s *Structure) String() string {
+	builder := new(strings.Builder)
+	if s.Name == "" {
+		writeItem(builder, s.Item, "", "")
+		return builder.String()
+	}
+	builder.WriteString(s.Name)
+	builder.WriteString("\n")
+	for i, child := range s.Item.List {
+		label := s.fields[i].name + ": "
+		if s.fields[i].numeric && !child.IsList {
+			builder.WriteString(fmt.Sprintf("  %s%s\n", label, new(big.Int).SetBytes(child.Bytes)))
+			continue
+		}
+		writeItem(builder, child, "  ", label)
+	}
+	return builder.String()
+}
+
+// writeItem writes an item and its children, one per line.
+func writeItem(builder *strings.Builder, item *Item, indent string, label string) {
+	if !item.IsList {
+		builder.WriteString(fmt.Sprintf("%s%s%s\n", indent, label, hexutil.Encode(item.Bytes)))
+		return
+	}
+	if len(item.List) == 0 {
+		builder.WriteString(fmt.Sprintf("%s%s[]\n", indent, label))
+		return
+	}
+	builder.WriteString(fmt.Sprintf("%s%s[\n", indent, label))
+	for _, child := range item.List {
+		writeItem(builder, child, indent+"  ", "")
+	}
+	builder.WriteString(fmt.Sprintf("%s]\n", indent))
+}
@@ -0,0 +1,171 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Safe operations.
+const (
+	// SafeCall is a call from the Safe.
+	SafeCall = uint8(0)
+	// SafeDelegateCall is a delegate call from the Safe.
+	SafeDelegateCall = uint8(1)
+)
+
+var (
+	safeDomainTypeHash       = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeLegacyDomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(address verifyingContract)"))
+	safeTxTypeHash           = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// SafeTransaction is a transaction to be executed by a Safe.
+type SafeTransaction struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      uint8
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// SafeSignature is the signature of an owner of a Safe.
+type SafeSignature struct {
+	Owner     common.Address
+	Signature []byte
+}
+
+// SafeDomainSeparator returns the EIP-712 domain separator of a Safe.  Safes before version
+// 1.3.0 do not include the chain ID in their domain.
+func SafeDomainSeparator(chainID *big.Int, safe common.Address, version string) common.Hash {
+	if safeLegacyDomain(version) {
+		return crypto.Keccak256Hash(
+			safeLegacyDomainTypeHash,
+			common.LeftPadBytes(safe.Bytes(), 32),
+		)
+	}
+	return crypto.Keccak256Hash(
+		safeDomainTypeHash,
+		math.U256Bytes(new(big.Int).Set(chainID)),
+		common.LeftPadBytes(safe.Bytes(), 32),
+	)
+}
+
+// safeLegacyDomain returns true if the Safe version predates the chain ID in its domain.
+func safeLegacyDomain(version string) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return major < 1 || (major == 1 && minor < 3)
+}
+
+// SafeTransactionHash returns the EIP-712 hash of the transaction, which is the value signed by the owners.
+func SafeTransactionHash(domainSeparator common.Hash, tx *SafeTransaction) common.Hash {
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		common.LeftPadBytes(tx.To.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(tx.Value)),
+		crypto.Keccak256(tx.Data),
+		math.U256Bytes(big.NewInt(int64(tx.Operation))),
+		math.U256Bytes(new(big.Int).Set(tx.SafeTxGas)),
+		math.U256Bytes(new(big.Int).Set(tx.BaseGas)),
+		math.U256Bytes(new(big.Int).Set(tx.GasPrice)),
+		common.LeftPadBytes(tx.GasToken.Bytes(), 32),
+		common.LeftPadBytes(tx.RefundReceiver.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(tx.Nonce)),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash)
+}
+
+// SafeSigner returns the owner that created a signature over the transaction hash.  Signatures
+// can be made directly over the hash (v of 27 or 28), made over the hash as a message with
+// eth_sign (v of 31 or 32), or be an approval by the owner in r (v of 1).
+func SafeSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature is %d bytes; expected %d", len(signature), crypto.SignatureLength)
+	}
+	v := signature[crypto.RecoveryIDOffset]
+	digest := hash.Bytes()
+	switch {
+	case v == 1:
+		return common.BytesToAddress(signature[:32]), nil
+	case v == 27 || v == 28:
+	case v == 31 || v == 32:
+		digest = crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash.Bytes())
+		v -= 4
+	default:
+		return common.Address{}, fmt.Errorf("unsupported signature type %d", v)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	sig[crypto.RecoveryIDOffset] = v - 27
+	pubKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "invalid signature")
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// SafeApprovalSignature returns the signature that shows the owner has approved the transaction,
+// either by being the sender of the transaction or by calling approveHash.
+func SafeApprovalSignature(owner common.Address) []byte {
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature, common.LeftPadBytes(owner.Bytes(), 32))
+	signature[crypto.RecoveryIDOffset] = 1
+	return signature
+}
+
+// SafeSignatures returns the signatures in the form required by execTransaction, which is
+// ordered by owner with duplicate owners removed.
+func SafeSignatures(signatures []*SafeSignature) []byte {
+	sorted := make([]*SafeSignature, len(signatures))
+	copy(sorted, signatures)
+	sort.SliceStable(sorted, func(i int, j int) bool {
+		return bytes.Compare(sorted[i].Owner.Bytes(), sorted[j].Owner.Bytes()) < 0
+	})
+
+	res := make([]byte, 0, len(sorted)*crypto.SignatureLength)
+	for i, signature := range sorted {
+		if i > 0 && sorted[i-1].Owner == signature.Owner {
+			continue
+		}
+		res = append(res, signature.Signature...)
+	}
+	return res
+}
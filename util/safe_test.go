@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func testSafeTransaction() *SafeTransaction {
+	return &SafeTransaction{
+		To:             common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"),
+		Value:          big.NewInt(1000000000000000000),
+		Data:           common.FromHex("0xa9059cbb0000000000000000000000002b5ad5c4795c026514f8317c7a215e218dccd6cf0000000000000000000000000000000000000000000000000000000000000001"),
+		Operation:      SafeCall,
+		SafeTxGas:      big.NewInt(0),
+		BaseGas:        big.NewInt(0),
+		GasPrice:       big.NewInt(0),
+		GasToken:       common.Address{},
+		RefundReceiver: common.Address{},
+		Nonce:          big.NewInt(7),
+	}
+}
+
+func TestSafeTransactionHash(t *testing.T) {
+	safe := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	tx := testSafeTransaction()
+
+	// Calculate the expected values with the generic EIP-712 implementation.
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(big.NewInt(5)),
+			VerifyingContract: safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.String(),
+			"data":           hexutil.Encode(tx.Data),
+			"operation":      "0",
+			"safeTxGas":      tx.SafeTxGas.String(),
+			"baseGas":        tx.BaseGas.String(),
+			"gasPrice":       tx.GasPrice.String(),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          tx.Nonce.String(),
+		},
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	require.NoError(t, err)
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+
+	require.Equal(t, common.BytesToHash(domainSeparator), SafeDomainSeparator(big.NewInt(5), safe, "1.3.0"))
+	require.Equal(t, crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, messageHash), SafeTransactionHash(SafeDomainSeparator(big.NewInt(5), safe, "1.4.1"), tx))
+
+	// Older Safes do not include the chain ID in their domain.
+	require.Equal(t, crypto.Keccak256Hash(crypto.Keccak256([]byte("EIP712Domain(address verifyingContract)")), common.LeftPadBytes(safe.Bytes(), 32)), SafeDomainSeparator(big.NewInt(5), safe, "1.1.1"))
+}
+
+func TestSafeSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	hash := SafeTransactionHash(SafeDomainSeparator(big.NewInt(1), common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"), "1.3.0"), testSafeTransaction())
+
+	// Signature over the hash.
+	signature, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+	signature[crypto.RecoveryIDOffset] += 27
+	signer, err := SafeSigner(hash, signature)
+	require.NoError(t, err)
+	require.Equal(t, owner, signer)
+
+	// Signature over the hash as a message.
+	signature, err = crypto.Sign(crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash.Bytes()), key)
+	require.NoError(t, err)
+	signature[crypto.RecoveryIDOffset] += 31
+	signer, err = SafeSigner(hash, signature)
+	require.NoError(t, err)
+	require.Equal(t, owner, signer)
+
+	// Approval.
+	signer, err = SafeSigner(hash, SafeApprovalSignature(owner))
+	require.NoError(t, err)
+	require.Equal(t, owner, signer)
+
+	// Contract signatures are not supported.
+	signature[crypto.RecoveryIDOffset] = 0
+	_, err = SafeSigner(hash, signature)
+	require.EqualError(t, err, "unsupported signature type 0")
+
+	_, err = SafeSigner(hash, signature[:64])
+	require.EqualError(t, err, "signature is 64 bytes; expected 65")
+}
+
+func TestSafeSignatures(t *testing.T) {
+	owner1 := common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	owner2 := common.HexToAddress("0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF")
+	signatures := SafeSignatures([]*SafeSignature{
+		{Owner: owner1, Signature: SafeApprovalSignature(owner1)},
+		{Owner: owner2, Signature: SafeApprovalSignature(owner2)},
+		{Owner: owner1, Signature: SafeApprovalSignature(owner1)},
+	})
+	require.Len(t, signatures, 2*crypto.SignatureLength)
+	require.Equal(t, SafeApprovalSignature(owner2), signatures[:crypto.SignatureLength])
+	require.Equal(t, SafeApprovalSignature(owner1), signatures[crypto.SignatureLength:])
+}
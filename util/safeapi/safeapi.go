@@ -0,0 +1,234 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package safeapi proposes and obtains Safe transactions with the Safe Transaction Service.
+package safeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// urls are the Safe Transaction Service endpoints by chain ID.
+var urls = map[uint64]string{
+	1:        "https://safe-transaction-mainnet.safe.global",
+	10:       "https://safe-transaction-optimism.safe.global",
+	100:      "https://safe-transaction-gnosis-chain.safe.global",
+	137:      "https://safe-transaction-polygon.safe.global",
+	8453:     "https://safe-transaction-base.safe.global",
+	42161:    "https://safe-transaction-arbitrum.safe.global",
+	11155111: "https://safe-transaction-sepolia.safe.global",
+}
+
+// DefaultURL returns the Safe Transaction Service endpoint for the given chain.
+func DefaultURL(chainID *big.Int) (string, error) {
+	if !chainID.IsUint64() || urls[chainID.Uint64()] == "" {
+		return "", fmt.Errorf("no Safe Transaction Service endpoint for chain %v", chainID)
+	}
+	return urls[chainID.Uint64()], nil
+}
+
+// Client is a client for the Safe Transaction Service.
+type Client struct {
+	url string
+}
+
+// New creates a new client for the service at the given URL.
+func New(url string) *Client {
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+	}
+}
+
+// transactionJSON is the service's representation of a Safe transaction.
+type transactionJSON struct {
+	Safe                    string              `json:"safe,omitempty"`
+	To                      string              `json:"to"`
+	Value                   string              `json:"value"`
+	Data                    *string             `json:"data"`
+	Operation               uint8               `json:"operation"`
+	SafeTxGas               json.Number         `json:"safeTxGas"`
+	BaseGas                 json.Number         `json:"baseGas"`
+	GasPrice                string              `json:"gasPrice"`
+	GasToken                string              `json:"gasToken"`
+	RefundReceiver          string              `json:"refundReceiver"`
+	Nonce                   json.Number         `json:"nonce"`
+	ContractTransactionHash string              `json:"contractTransactionHash,omitempty"`
+	SafeTxHash              string              `json:"safeTxHash,omitempty"`
+	Sender                  string              `json:"sender,omitempty"`
+	Signature               string              `json:"signature,omitempty"`
+	Origin                  string              `json:"origin,omitempty"`
+	Confirmations           []*confirmationJSON `json:"confirmations,omitempty"`
+}
+
+type confirmationJSON struct {
+	Owner     string `json:"owner"`
+	Signature string `json:"signature"`
+}
+
+// Propose proposes a transaction to the service, along with the signature of the owner proposing it.
+func (c *Client) Propose(ctx context.Context, safe common.Address, tx *util.SafeTransaction, hash common.Hash, signature *util.SafeSignature) error {
+	req := &transactionJSON{
+		Safe:                    safe.Hex(),
+		To:                      tx.To.Hex(),
+		Value:                   tx.Value.String(),
+		Operation:               tx.Operation,
+		SafeTxGas:               json.Number(tx.SafeTxGas.String()),
+		BaseGas:                 json.Number(tx.BaseGas.String()),
+		GasPrice:                tx.GasPrice.String(),
+		GasToken:                tx.GasToken.Hex(),
+		RefundReceiver:          tx.RefundReceiver.Hex(),
+		Nonce:                   json.Number(tx.Nonce.String()),
+		ContractTransactionHash: hash.Hex(),
+		Sender:                  signature.Owner.Hex(),
+		Signature:               hexutil.Encode(signature.Signature),
+		Origin:                  "ethereal",
+	}
+	if len(tx.Data) > 0 {
+		data := hexutil.Encode(tx.Data)
+		req.Data = &data
+	}
+	return c.post(ctx, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", safe.Hex()), req)
+}
+
+// Confirm adds an owner's signature to a transaction already known to the service.
+func (c *Client) Confirm(ctx context.Context, hash common.Hash, signature []byte) error {
+	return c.post(ctx, fmt.Sprintf("/api/v1/multisig-transactions/%s/confirmations/", hash.Hex()), map[string]string{
+		"signature": hexutil.Encode(signature),
+	})
+}
+
+// Transaction returns a transaction known to the service along with the signatures of the owners that
+// have confirmed it, or nil if the transaction is not known.
+func (c *Client) Transaction(ctx context.Context, hash common.Hash) (*util.SafeTransaction, []*util.SafeSignature, error) {
+	var res transactionJSON
+	found, err := c.get(ctx, fmt.Sprintf("/api/v1/multisig-transactions/%s/", hash.Hex()), &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil
+	}
+
+	tx := &util.SafeTransaction{
+		To:             common.HexToAddress(res.To),
+		Operation:      res.Operation,
+		GasToken:       common.HexToAddress(res.GasToken),
+		RefundReceiver: common.HexToAddress(res.RefundReceiver),
+	}
+	if res.Data != nil {
+		if tx.Data, err = hexutil.Decode(*res.Data); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid data")
+		}
+	}
+	for _, field := range []struct {
+		name  string
+		input string
+		val   **big.Int
+	}{
+		{"value", res.Value, &tx.Value},
+		{"safe transaction gas", res.SafeTxGas.String(), &tx.SafeTxGas},
+		{"base gas", res.BaseGas.String(), &tx.BaseGas},
+		{"gas price", res.GasPrice, &tx.GasPrice},
+		{"nonce", res.Nonce.String(), &tx.Nonce},
+	} {
+		val, success := new(big.Int).SetString(field.input, 10)
+		if !success {
+			return nil, nil, fmt.Errorf("invalid %s %q", field.name, field.input)
+		}
+		*field.val = val
+	}
+
+	signatures := make([]*util.SafeSignature, 0, len(res.Confirmations))
+	for _, confirmation := range res.Confirmations {
+		signature, err := hexutil.Decode(confirmation.Signature)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid signature")
+		}
+		signatures = append(signatures, &util.SafeSignature{
+			Owner:     common.HexToAddress(confirmation.Owner),
+			Signature: signature,
+		})
+	}
+	return tx, signatures, nil
+}
+
+// get calls the API at the given path, decoding the result.  It returns false if the
+// item is not found.
+func (c *Client) get(ctx context.Context, path string, result interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return false, err
+	}
+	data, status, err := c.do(req)
+	if err != nil {
+		return false, err
+	}
+	if status == http.StatusNotFound {
+		return false, nil
+	}
+	if status != http.StatusOK {
+		return false, fmt.Errorf("request returned status %d: %s", status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return false, errors.Wrap(err, "invalid response")
+	}
+	return true, nil
+}
+
+// post sends the body to the API at the given path.
+func (c *Client) post(ctx context.Context, path string, body interface{}) error {
+	reqData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(reqData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	data, status, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusCreated && status != http.StatusNoContent {
+		return fmt.Errorf("request returned status %d: %s", status, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// do carries out the request, returning the body and status of the response.
+func (c *Client) do(req *http.Request) ([]byte, int, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, resp.StatusCode, nil
+}
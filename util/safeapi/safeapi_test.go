@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package safeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+var (
+	testSafe  = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	testOwner = common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	testHash  = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+)
+
+func TestPropose(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", testSafe.Hex()), r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	tx := &util.SafeTransaction{
+		To:        testOwner,
+		Value:     big.NewInt(1000),
+		Data:      []byte{0x01, 0x02},
+		SafeTxGas: big.NewInt(0),
+		BaseGas:   big.NewInt(0),
+		GasPrice:  big.NewInt(0),
+		Nonce:     big.NewInt(5),
+	}
+	err := New(server.URL).Propose(context.Background(), testSafe, tx, testHash, &util.SafeSignature{Owner: testOwner, Signature: util.SafeApprovalSignature(testOwner)})
+	require.NoError(t, err)
+	assert.Equal(t, "1000", received["value"])
+	assert.Equal(t, "0x0102", received["data"])
+	assert.Equal(t, float64(5), received["nonce"])
+	assert.Equal(t, testHash.Hex(), received["contractTransactionHash"])
+	assert.Equal(t, testOwner.Hex(), received["sender"])
+}
+
+func TestTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/api/v1/multisig-transactions/%s/", testHash.Hex()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"safe":"%s","to":"%s","value":"1000","data":null,"operation":0,"safeTxGas":0,"baseGas":"0","gasPrice":"0","gasToken":"0x0000000000000000000000000000000000000000","refundReceiver":"0x0000000000000000000000000000000000000000","nonce":5,"safeTxHash":"%s","confirmations":[{"owner":"%s","signature":"0x%s"}]}`,
+			testSafe.Hex(), testOwner.Hex(), testHash.Hex(), testOwner.Hex(), common.Bytes2Hex(util.SafeApprovalSignature(testOwner)))
+	}))
+	defer server.Close()
+	client := New(server.URL + "/")
+
+	tx, signatures, err := client.Transaction(context.Background(), testHash)
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	assert.Equal(t, testOwner, tx.To)
+	assert.Equal(t, big.NewInt(1000), tx.Value)
+	assert.Empty(t, tx.Data)
+	assert.Equal(t, big.NewInt(5), tx.Nonce)
+	require.Len(t, signatures, 1)
+	assert.Equal(t, testOwner, signatures[0].Owner)
+	assert.Equal(t, util.SafeApprovalSignature(testOwner), signatures[0].Signature)
+
+	tx, _, err = client.Transaction(context.Background(), common.Hash{})
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+}
+
+func TestDefaultURL(t *testing.T) {
+	url, err := DefaultURL(big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, "https://safe-transaction-mainnet.safe.global", url)
+
+	_, err = DefaultURL(big.NewInt(12345))
+	require.EqualError(t, err, "no Safe Transaction Service endpoint for chain 12345")
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// checkpoint is the progress of a scan as recorded in a checkpoint file.
+type checkpoint struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	Next uint64 `json:"next"`
+}
+
+// readCheckpoint reads a checkpoint file, returning nil if it does not exist.
+func readCheckpoint(path string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read checkpoint")
+	}
+	res := &checkpoint{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint %s", path)
+	}
+	if res.Next < res.From || res.Next > res.To+1 {
+		return nil, errors.Errorf("invalid checkpoint %s", path)
+	}
+	return res, nil
+}
+
+// writeCheckpoint writes the progress of a scan to a checkpoint file.  The file is replaced
+// atomically so that an interrupted write does not corrupt it.
+func writeCheckpoint(path string, progress *Progress) error {
+	data, err := json.Marshal(&checkpoint{
+		From: progress.From,
+		To:   progress.To,
+		Next: progress.Next,
+	})
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkpoint")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+	return nil
+}
+
+// removeCheckpoint removes a checkpoint file once a scan has completed.
+func removeCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove checkpoint")
+	}
+	return nil
+}
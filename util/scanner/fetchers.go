@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Logs returns a fetch function that obtains the logs matching the query, ignoring the
+// query's block range.  The result is a []types.Log.
+func Logs(filterer ethereum.LogFilterer, query ethereum.FilterQuery) FetchFunc {
+	return func(ctx context.Context, from uint64, to uint64) (interface{}, error) {
+		rangeQuery := query
+		rangeQuery.BlockHash = nil
+		rangeQuery.FromBlock = new(big.Int).SetUint64(from)
+		rangeQuery.ToBlock = new(big.Int).SetUint64(to)
+		return filterer.FilterLogs(ctx, rangeQuery)
+	}
+}
+
+// BatchCaller makes batches of JSON-RPC requests.  It is satisfied by both *rpc.Client and
+// *conn.Conn.
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error
+}
+
+// Receipts returns a fetch function that obtains the receipts of all transactions in the
+// blocks, using eth_getBlockReceipts in a single batch.  The result is a []*types.Receipt.
+func Receipts(client BatchCaller) FetchFunc {
+	return func(ctx context.Context, from uint64, to uint64) (interface{}, error) {
+		blockReceipts := make([][]*types.Receipt, to-from+1)
+		batch := make([]rpc.BatchElem, len(blockReceipts))
+		for i := range batch {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockReceipts",
+				Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i))},
+				Result: &blockReceipts[i],
+			}
+		}
+		if err := client.BatchCallContext(ctx, batch); err != nil {
+			return nil, err
+		}
+		res := make([]*types.Receipt, 0)
+		for i := range batch {
+			if batch[i].Error != nil {
+				return nil, errors.Wrapf(batch[i].Error, "failed to obtain receipts for block %d", from+uint64(i))
+			}
+			res = append(res, blockReceipts[i]...)
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scanner scans large ranges of blocks using parallel workers, splitting ranges that
+// are too large for the provider and recording progress so that interrupted scans can resume.
+package scanner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Default configuration values.
+const (
+	DefaultChunkSize = 1000
+	DefaultWorkers   = 4
+	DefaultRetries   = 3
+)
+
+// FetchFunc fetches the results for the inclusive range of blocks from..to.
+type FetchFunc func(ctx context.Context, from uint64, to uint64) (interface{}, error)
+
+// HandleFunc handles the results for the inclusive range of blocks from..to, as returned by
+// the fetch function.  Ranges are handled in order, one at a time.
+type HandleFunc func(from uint64, to uint64, result interface{}) error
+
+// ProgressFunc is called after each range of blocks has been handled.
+type ProgressFunc func(progress *Progress)
+
+// Progress is the progress of a scan.
+type Progress struct {
+	// From is the first block of the scan.
+	From uint64
+	// To is the last block of the scan.
+	To uint64
+	// Next is the next block to be handled.
+	Next uint64
+}
+
+// Completed returns the number of blocks that have been handled.
+func (p *Progress) Completed() uint64 {
+	return p.Next - p.From
+}
+
+// Total returns the total number of blocks in the scan.
+func (p *Progress) Total() uint64 {
+	return p.To - p.From + 1
+}
+
+// Config is the configuration for a scan.
+type Config struct {
+	// From is the first block to scan.
+	From uint64
+	// To is the last block to scan.
+	To uint64
+	// Fetch fetches the results for a range of blocks.
+	Fetch FetchFunc
+	// Handle handles the results for a range of blocks.
+	Handle HandleFunc
+	// Progress is called after each range of blocks has been handled, if supplied.
+	Progress ProgressFunc
+	// ChunkSize is the number of blocks fetched by each request, before any splitting.
+	// Defaults to DefaultChunkSize.
+	ChunkSize uint64
+	// Workers is the number of requests made in parallel.  Defaults to DefaultWorkers.
+	Workers int
+	// Retries is the number of times a failed request is retried.  Defaults to DefaultRetries.
+	Retries int
+	// Checkpoint is the path of a file in which progress is recorded, if supplied.  If the file
+	// exists when the scan starts then the scan resumes from the recorded progress, and the file
+	// is removed when the scan completes.
+	Checkpoint string
+}
+
+// rangeErrors are parts of the error messages returned by providers when a request covers
+// too many blocks or would return too many results.
+var rangeErrors = []string{
+	"query returned more than",
+	"response size exceeded",
+	"response size should not",
+	"block range",
+	"too many blocks",
+	"too many results",
+	"limit exceeded",
+	"range too large",
+	"range is too large",
+	"query timeout exceeded",
+}
+
+// IsRangeError returns true if the error is one returned by providers when a request covers
+// too many blocks or would return too many results, in which case the request should be
+// retried with a smaller range.
+func IsRangeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, rangeError := range rangeErrors {
+		if strings.Contains(msg, rangeError) {
+			return true
+		}
+	}
+	return false
+}
+
+// part is the result of fetching part of a chunk.
+type part struct {
+	from   uint64
+	to     uint64
+	result interface{}
+}
+
+// chunk is a range of blocks fetched by a single worker.
+type chunk struct {
+	index int
+	from  uint64
+	to    uint64
+	parts []*part
+	err   error
+}
+
+// Scan scans the range of blocks in the configuration.
+func Scan(ctx context.Context, config *Config) error {
+	if config.Fetch == nil {
+		return errors.New("no fetch function supplied")
+	}
+	if config.Handle == nil {
+		return errors.New("no handle function supplied")
+	}
+	if config.From > config.To {
+		return errors.New("from block is after to block")
+	}
+	chunkSize := config.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	workers := config.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	retries := config.Retries
+	if retries <= 0 {
+		retries = DefaultRetries
+	}
+
+	progress := &Progress{
+		From: config.From,
+		To:   config.To,
+		Next: config.From,
+	}
+	if config.Checkpoint != "" {
+		checkpoint, err := readCheckpoint(config.Checkpoint)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			if checkpoint.From != config.From || checkpoint.To != config.To {
+				return errors.Errorf("checkpoint %s is for blocks %d to %d", config.Checkpoint, checkpoint.From, checkpoint.To)
+			}
+			progress.Next = checkpoint.Next
+		}
+	}
+
+	chunks := make([]*chunk, 0)
+	for from := progress.Next; from <= config.To; from += chunkSize {
+		to := from + chunkSize - 1
+		if to > config.To || to < from {
+			to = config.To
+		}
+		chunks = append(chunks, &chunk{index: len(chunks), from: from, to: to})
+		if to == config.To {
+			break
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Limit the number of chunks fetched ahead of those handled, to bound memory use.
+	window := make(chan struct{}, workers*2)
+	jobs := make(chan *chunk)
+	go func() {
+		defer close(jobs)
+		for _, job := range chunks {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan *chunk)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.parts, job.err = fetchRange(ctx, config.Fetch, job.from, job.to, retries)
+				select {
+				case results <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	err := handleChunks(ctx, config, chunks, results, window, progress)
+	cancel()
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+
+	if config.Checkpoint != "" {
+		if err := removeCheckpoint(config.Checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleChunks handles the fetched chunks in order, recording progress as it goes.
+func handleChunks(ctx context.Context,
+	config *Config,
+	chunks []*chunk,
+	results <-chan *chunk,
+	window <-chan struct{},
+	progress *Progress,
+) error {
+	pending := make(map[int]*chunk)
+	next := 0
+	for next < len(chunks) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result := <-results:
+			if result.err != nil {
+				return errors.Wrapf(result.err, "failed to fetch blocks %d to %d", result.from, result.to)
+			}
+			pending[result.index] = result
+		}
+		for pending[next] != nil {
+			for _, part := range pending[next].parts {
+				if err := config.Handle(part.from, part.to, part.result); err != nil {
+					return errors.Wrapf(err, "failed to handle blocks %d to %d", part.from, part.to)
+				}
+			}
+			progress.Next = pending[next].to + 1
+			if config.Checkpoint != "" {
+				if err := writeCheckpoint(config.Checkpoint, progress); err != nil {
+					return err
+				}
+			}
+			if config.Progress != nil {
+				config.Progress(progress)
+			}
+			delete(pending, next)
+			<-window
+			next++
+		}
+	}
+	return nil
+}
+
+// fetchRange fetches a range of blocks, splitting it if it is too large for the provider and
+// retrying on other failures.
+func fetchRange(ctx context.Context, fetch FetchFunc, from uint64, to uint64, retries int) ([]*part, error) {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(1<<uint(attempt-1)) * 500 * time.Millisecond):
+			}
+		}
+
+		var result interface{}
+		result, err = fetch(ctx, from, to)
+		if err == nil {
+			return []*part{{from: from, to: to, result: result}}, nil
+		}
+		if IsRangeError(err) {
+			if from == to {
+				// Cannot split the range any further.
+				return nil, err
+			}
+			mid := from + (to-from)/2
+			first, err := fetchRange(ctx, fetch, from, mid, retries)
+			if err != nil {
+				return nil, err
+			}
+			second, err := fetchRange(ctx, fetch, mid+1, to, retries)
+			if err != nil {
+				return nil, err
+			}
+			return append(first, second...), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,224 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blocksFetch returns the block numbers in the range, failing with a range error if the range
+// is larger than the limit.
+func blocksFetch(limit uint64) FetchFunc {
+	return func(ctx context.Context, from uint64, to uint64) (interface{}, error) {
+		if to-from+1 > limit {
+			return nil, errors.New("query returned more than 10000 results")
+		}
+		res := make([]uint64, 0)
+		for block := from; block <= to; block++ {
+			res = append(res, block)
+		}
+		return res, nil
+	}
+}
+
+func TestScan(t *testing.T) {
+	handled := make([]uint64, 0)
+	var lastProgress Progress
+	err := Scan(context.Background(), &Config{
+		From:      5,
+		To:        104,
+		Fetch:     blocksFetch(7),
+		ChunkSize: 20,
+		Workers:   3,
+		Handle: func(from uint64, to uint64, result interface{}) error {
+			blocks := result.([]uint64)
+			require.Equal(t, int(to-from+1), len(blocks))
+			handled = append(handled, blocks...)
+			return nil
+		},
+		Progress: func(progress *Progress) {
+			lastProgress = *progress
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, handled, 100)
+	for i := range handled {
+		require.Equal(t, uint64(i+5), handled[i])
+	}
+	assert.Equal(t, uint64(105), lastProgress.Next)
+	assert.Equal(t, uint64(100), lastProgress.Completed())
+	assert.Equal(t, uint64(100), lastProgress.Total())
+}
+
+func TestScanInvalid(t *testing.T) {
+	handle := func(from uint64, to uint64, result interface{}) error { return nil }
+	assert.EqualError(t, Scan(context.Background(), &Config{From: 2, To: 1, Fetch: blocksFetch(1), Handle: handle}), "from block is after to block")
+	assert.EqualError(t, Scan(context.Background(), &Config{To: 1, Handle: handle}), "no fetch function supplied")
+	assert.EqualError(t, Scan(context.Background(), &Config{To: 1, Fetch: blocksFetch(1)}), "no handle function supplied")
+}
+
+func TestScanSingleBlockTooLarge(t *testing.T) {
+	err := Scan(context.Background(), &Config{
+		From:    0,
+		To:      3,
+		Fetch:   blocksFetch(0),
+		Handle:  func(from uint64, to uint64, result interface{}) error { return nil },
+		Retries: 1,
+	})
+	assert.EqualError(t, err, "failed to fetch blocks 0 to 3: query returned more than 10000 results")
+}
+
+func TestScanRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	fetch := func(ctx context.Context, from uint64, to uint64) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return blocksFetch(100)(ctx, from, to)
+	}
+
+	handled := 0
+	err := Scan(context.Background(), &Config{
+		From:  0,
+		To:    9,
+		Fetch: fetch,
+		Handle: func(from uint64, to uint64, result interface{}) error {
+			handled += len(result.([]uint64))
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, handled)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestScanResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scanner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	// Fail part way through the scan.
+	handled := make([]uint64, 0)
+	err = Scan(context.Background(), &Config{
+		From:       0,
+		To:         99,
+		Fetch:      blocksFetch(100),
+		ChunkSize:  10,
+		Workers:    2,
+		Checkpoint: path,
+		Handle: func(from uint64, to uint64, result interface{}) error {
+			if from == 50 {
+				return errors.New("interrupted")
+			}
+			handled = append(handled, result.([]uint64)...)
+			return nil
+		},
+	})
+	require.EqualError(t, err, "failed to handle blocks 50 to 59: interrupted")
+	require.Len(t, handled, 50)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	// A scan of a different range cannot use the checkpoint.
+	err = Scan(context.Background(), &Config{
+		From:       0,
+		To:         199,
+		Fetch:      blocksFetch(100),
+		Checkpoint: path,
+		Handle:     func(from uint64, to uint64, result interface{}) error { return nil },
+	})
+	require.EqualError(t, err, "checkpoint "+path+" is for blocks 0 to 99")
+
+	// Resume the scan.
+	err = Scan(context.Background(), &Config{
+		From:       0,
+		To:         99,
+		Fetch:      blocksFetch(100),
+		ChunkSize:  10,
+		Workers:    2,
+		Checkpoint: path,
+		Handle: func(from uint64, to uint64, result interface{}) error {
+			handled = append(handled, result.([]uint64)...)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, handled, 100)
+	for i := range handled {
+		require.Equal(t, uint64(i), handled[i])
+	}
+
+	// The checkpoint is removed once the scan completes.
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestIsRangeError(t *testing.T) {
+	tests := []struct {
+		err error
+		res bool
+	}{
+		{err: nil, res: false},
+		{err: errors.New("connection refused"), res: false},
+		{err: errors.New("query returned more than 10000 results"), res: true},
+		{err: errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"), res: true},
+		{err: errors.New("exceed maximum block range: 5000"), res: true},
+		{err: errors.New("block range is too wide"), res: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.res, IsRangeError(test.err))
+	}
+}
+
+type testFilterer struct {
+	query ethereum.FilterQuery
+}
+
+func (f *testFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	f.query = query
+	return []types.Log{{BlockNumber: query.FromBlock.Uint64()}}, nil
+}
+
+func (f *testFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestLogs(t *testing.T) {
+	filterer := &testFilterer{}
+	fetch := Logs(filterer, ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+	res, err := fetch(context.Background(), 10, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Log{{BlockNumber: 10}}, res)
+	assert.Equal(t, big.NewInt(10), filterer.query.FromBlock)
+	assert.Equal(t, big.NewInt(20), filterer.query.ToBlock)
+}
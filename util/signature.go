@@ -0,0 +1,156 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var identifierRe = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+var arraySuffixRe = regexp.MustCompile(`^(\[[0-9]*\])*`)
+
+// CanonicalSignature returns the canonical form of a function, event or error signature, as
+// used to calculate selectors and topics.  The input can be in the form used in Solidity source,
+// for example "function transfer(address to, uint amount) external returns (bool)" has the
+// canonical form "transfer(address,uint256)".
+func CanonicalSignature(input string) (string, error) {
+	sig := strings.TrimSpace(input)
+	for _, keyword := range []string{"function ", "event ", "error "} {
+		sig = strings.TrimSpace(strings.TrimPrefix(sig, keyword))
+	}
+	start := strings.Index(sig, "(")
+	if start == -1 {
+		return "", fmt.Errorf("signature %s has no parameters", input)
+	}
+	name := strings.TrimSpace(sig[:start])
+	if !identifierRe.MatchString(name) {
+		return "", fmt.Errorf("signature %s has invalid name", input)
+	}
+	end, err := matchingParen(sig, start)
+	if err != nil {
+		return "", fmt.Errorf("signature %s has unbalanced parentheses", input)
+	}
+	params, err := canonicalParams(sig[start+1 : end])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s)", name, params), nil
+}
+
+// Selector returns the 4-byte selector for a function or error signature.
+func Selector(signature string) ([4]byte, error) {
+	var res [4]byte
+	canonical, err := CanonicalSignature(signature)
+	if err != nil {
+		return res, err
+	}
+	copy(res[:], crypto.Keccak256([]byte(canonical))[:4])
+	return res, nil
+}
+
+// matchingParen returns the index of the parenthesis that closes the one at the given index.
+func matchingParen(input string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(input); i++ {
+		switch input[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced parentheses")
+}
+
+// canonicalParams returns the canonical form of a comma-separated list of parameters.
+func canonicalParams(input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+	params := make([]string, 0)
+	depth := 0
+	start := 0
+	for i := 0; i <= len(input); i++ {
+		if i < len(input) {
+			switch input[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		param, err := canonicalParam(input[start:i])
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+		start = i + 1
+	}
+	return strings.Join(params, ","), nil
+}
+
+// canonicalParam returns the canonical type of a parameter, removing its name and modifiers.
+func canonicalParam(input string) (string, error) {
+	param := strings.TrimSpace(input)
+	param = strings.TrimSpace(strings.TrimPrefix(param, "tuple"))
+	if strings.HasPrefix(param, "(") {
+		end, err := matchingParen(param, 0)
+		if err != nil {
+			return "", fmt.Errorf("parameter %s has unbalanced parentheses", strings.TrimSpace(input))
+		}
+		components, err := canonicalParams(param[1:end])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s)%s", components, arraySuffixRe.FindString(param[end+1:])), nil
+	}
+
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty parameter")
+	}
+	typ := fields[0]
+	base := typ
+	if idx := strings.Index(typ, "["); idx != -1 {
+		base = typ[:idx]
+	}
+	suffix := typ[len(base):]
+	switch base {
+	case "uint", "int":
+		base += "256"
+	case "byte":
+		base = "bytes1"
+	}
+	typ = base + suffix
+	if _, err := abi.NewType(typ, "", nil); err != nil {
+		return "", fmt.Errorf("invalid type %s", fields[0])
+	}
+	return typ, nil
+}
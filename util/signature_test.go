@@ -0,0 +1,107 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		canonical string
+		err       string
+	}{
+		{
+			name:      "Canonical",
+			input:     "transfer(address,uint256)",
+			canonical: "transfer(address,uint256)",
+		},
+		{
+			name:      "NoParams",
+			input:     "totalSupply()",
+			canonical: "totalSupply()",
+		},
+		{
+			name:      "Solidity",
+			input:     "function transfer(address to, uint amount) external returns (bool)",
+			canonical: "transfer(address,uint256)",
+		},
+		{
+			name:      "Event",
+			input:     "event Transfer(address indexed from, address indexed to, uint256 value)",
+			canonical: "Transfer(address,address,uint256)",
+		},
+		{
+			name:      "Arrays",
+			input:     "f(uint[] memory a, int8[2][] calldata b, bytes32)",
+			canonical: "f(uint256[],int8[2][],bytes32)",
+		},
+		{
+			name:      "Tuple",
+			input:     "f((address to, uint value)[] calldata payments, tuple(bool,(bytes,string)) nested)",
+			canonical: "f((address,uint256)[],(bool,(bytes,string)))",
+		},
+		{
+			name:  "NoParens",
+			input: "transfer",
+			err:   "signature transfer has no parameters",
+		},
+		{
+			name:  "BadName",
+			input: "1transfer(address)",
+			err:   "signature 1transfer(address) has invalid name",
+		},
+		{
+			name:  "Unbalanced",
+			input: "transfer(address",
+			err:   "signature transfer(address has unbalanced parentheses",
+		},
+		{
+			name:  "BadType",
+			input: "transfer(adress,uint256)",
+			err:   "invalid type adress",
+		},
+		{
+			name:  "EmptyParam",
+			input: "transfer(address,)",
+			err:   "empty parameter",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canonical, err := CanonicalSignature(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.canonical, canonical)
+			}
+		})
+	}
+}
+
+func TestSelector(t *testing.T) {
+	selector, err := Selector("function transfer(address to, uint256 amount)")
+	require.NoError(t, err)
+	require.Equal(t, "0xa9059cbb", fmt.Sprintf("%#x", selector))
+
+	_, err = Selector("transfer")
+	require.EqualError(t, err, "signature transfer has no parameters")
+}
@@ -49,3 +49,39 @@ func AccountSigner(chainID *big.Int, wallet *accounts.Wallet, account *accounts.
 	}
 	return
 }
+
+// HardwareSigner generates a signer using an account on a hardware wallet.  If the wallet
+// reports that it cannot sign an EIP-1559 transaction the transaction is signed as a legacy
+// transaction instead, with its maximum fee per gas as the gas price.
+func HardwareSigner(chainID *big.Int, wallet accounts.Wallet, account *accounts.Account) (signerfn bind.SignerFn) {
+	signerfn = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		signedTx, err := wallet.SignTx(*account, tx, chainID)
+		if err != nil && tx.Type() == types.DynamicFeeTxType && len(tx.AccessList()) == 0 &&
+			(errors.Is(err, types.ErrTxTypeNotSupported) || errors.Is(err, accounts.ErrNotSupported)) {
+			return wallet.SignTx(*account, types.NewTx(&types.LegacyTx{
+				Nonce:    tx.Nonce(),
+				GasPrice: tx.GasFeeCap(),
+				Gas:      tx.Gas(),
+				To:       tx.To(),
+				Value:    tx.Value(),
+				Data:     tx.Data(),
+			}), chainID)
+		}
+		return signedTx, err
+	}
+	return
+}
+
+// ExternalSigner generates a signer using an account on an external signer such as clef
+func ExternalSigner(chainID *big.Int, wallet accounts.Wallet, account *accounts.Account) (signerfn bind.SignerFn) {
+	signerfn = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return wallet.SignTx(*account, tx, chainID)
+	}
+	return
+}
@@ -0,0 +1,136 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testHardwareWallet is a wallet that signs transactions in the way that a hardware
+// wallet would, optionally only signing legacy transactions.
+type testHardwareWallet struct {
+	accounts.Wallet
+	key        *ecdsa.PrivateKey
+	legacyOnly bool
+	err        error
+}
+
+func (w *testHardwareWallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	if w.legacyOnly {
+		signer = types.NewEIP155Signer(chainID)
+	}
+	return types.SignTx(tx, signer, w.key)
+}
+
+func TestHardwareSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account := &accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey)}
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	dynamicFeeTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     5,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(20000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	accessListTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      5,
+		GasTipCap:  big.NewInt(1000000000),
+		GasFeeCap:  big.NewInt(20000000000),
+		Gas:        21000,
+		To:         &to,
+		Value:      big.NewInt(1),
+		AccessList: types.AccessList{{Address: to}},
+	})
+
+	tests := []struct {
+		name    string
+		wallet  *testHardwareWallet
+		address common.Address
+		tx      *types.Transaction
+		txType  uint8
+		err     string
+	}{
+		{
+			name:    "Unauthorized",
+			wallet:  &testHardwareWallet{key: key},
+			address: to,
+			tx:      dynamicFeeTx,
+			err:     "not authorized to sign this account",
+		},
+		{
+			name:    "DynamicFee",
+			wallet:  &testHardwareWallet{key: key},
+			address: account.Address,
+			tx:      dynamicFeeTx,
+			txType:  types.DynamicFeeTxType,
+		},
+		{
+			name:    "LegacyFallback",
+			wallet:  &testHardwareWallet{key: key, legacyOnly: true},
+			address: account.Address,
+			tx:      dynamicFeeTx,
+			txType:  types.LegacyTxType,
+		},
+		{
+			name:    "AccessListNoFallback",
+			wallet:  &testHardwareWallet{key: key, legacyOnly: true},
+			address: account.Address,
+			tx:      accessListTx,
+			err:     types.ErrTxTypeNotSupported.Error(),
+		},
+		{
+			name:    "RejectedNoFallback",
+			wallet:  &testHardwareWallet{key: key, err: errors.New("rejected on device")},
+			address: account.Address,
+			tx:      dynamicFeeTx,
+			err:     "rejected on device",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signedTx, err := HardwareSigner(chainID, test.wallet, account)(test.address, test.tx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.txType, signedTx.Type())
+			require.Equal(t, test.tx.Nonce(), signedTx.Nonce())
+			require.Equal(t, test.tx.GasFeeCap(), signedTx.GasFeeCap())
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+			require.NoError(t, err)
+			require.Equal(t, account.Address, sender)
+		})
+	}
+}
@@ -29,6 +29,11 @@ type Contract struct {
 	Name   string
 	Abi    abi.ABI
 	Binary []byte
+	// UnlinkedBinary is the hex-encoded binary if it contains library placeholders.
+	UnlinkedBinary string
+	// RuntimeBinary is the hex-encoded runtime binary, if present; it can contain library
+	// placeholders.
+	RuntimeBinary string
 }
 
 // ParseCombinedJSON parses a combined JSON output of solc for a specific contract
@@ -76,7 +81,9 @@ func ParseCombinedJSON(input string, name string) (*Contract, error) {
 
 			// Obtain binary
 			binStr, exists := contractJSON["bin"]
-			if exists {
+			if exists && IsUnlinked(binStr.(string)) {
+				contract.UnlinkedBinary = binStr.(string)
+			} else if exists {
 				bin, err := hex.DecodeString(binStr.(string))
 				if err != nil {
 					return nil, err
@@ -84,6 +91,11 @@ func ParseCombinedJSON(input string, name string) (*Contract, error) {
 				contract.Binary = bin
 			}
 
+			// Obtain runtime binary
+			if runtimeStr, exists := contractJSON["bin-runtime"]; exists {
+				contract.RuntimeBinary = runtimeStr.(string)
+			}
+
 			return contract, nil
 		}
 	}
@@ -0,0 +1,271 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sourcify obtains verified contracts from, and submits contracts for verification to,
+// a Sourcify server.
+package sourcify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util"
+)
+
+// DefaultURL is the URL of the public Sourcify server.
+const DefaultURL = "https://sourcify.dev/server"
+
+// ErrNotFound is returned when the contract has not been verified.
+var ErrNotFound = errors.New("contract not verified")
+
+// ErrAlreadyVerified is returned when the contract has already been verified.
+var ErrAlreadyVerified = errors.New("contract already verified")
+
+// Client is a client for the Sourcify server API.
+type Client struct {
+	url string
+}
+
+// New creates a new client.  The URL overrides the default server.
+func New(url string) *Client {
+	if url == "" {
+		url = DefaultURL
+	}
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+	}
+}
+
+// Contract is a verified contract.
+type Contract struct {
+	// Match is the type of match, for example exact_match.
+	Match           string
+	Name            string
+	CompilerVersion string
+	Optimized       bool
+	Runs            uint64
+	EVMVersion      string
+	ABI             string
+	// Implementation is the implementation contract if the contract is a proxy.
+	Implementation *common.Address
+	// Creator is the account that created the contract, if known.
+	Creator *common.Address
+	// CreationTransaction is the transaction that created the contract, if known.
+	CreationTransaction *common.Hash
+	// Files are the source files of the contract, keyed by path.
+	Files map[string]string
+}
+
+// Job is the status of a verification job.
+type Job struct {
+	Completed bool
+	// Match is the type of match if verification succeeded.
+	Match string
+	// Error is the reason verification failed.
+	Error string
+}
+
+type contractResponse struct {
+	Match   string `json:"match"`
+	Sources map[string]struct {
+		Content string `json:"content"`
+	} `json:"sources"`
+	Compilation struct {
+		CompilerVersion  string `json:"compilerVersion"`
+		Name             string `json:"name"`
+		CompilerSettings struct {
+			Optimizer struct {
+				Enabled bool   `json:"enabled"`
+				Runs    uint64 `json:"runs"`
+			} `json:"optimizer"`
+			EVMVersion string `json:"evmVersion"`
+		} `json:"compilerSettings"`
+	} `json:"compilation"`
+	ABI        json.RawMessage `json:"abi"`
+	Deployment struct {
+		TransactionHash string `json:"transactionHash"`
+		Deployer        string `json:"deployer"`
+	} `json:"deployment"`
+	ProxyResolution *struct {
+		IsProxy         bool `json:"isProxy"`
+		Implementations []struct {
+			Address string `json:"address"`
+		} `json:"implementations"`
+	} `json:"proxyResolution"`
+}
+
+type submission struct {
+	StdJSONInput       json.RawMessage `json:"stdJsonInput"`
+	CompilerVersion    string          `json:"compilerVersion"`
+	ContractIdentifier string          `json:"contractIdentifier"`
+}
+
+type errorResponse struct {
+	CustomCode string `json:"customCode"`
+	Message    string `json:"message"`
+}
+
+type jobResponse struct {
+	VerificationID string         `json:"verificationId"`
+	IsJobCompleted bool           `json:"isJobCompleted"`
+	Error          *errorResponse `json:"error"`
+	Contract       struct {
+		Match *string `json:"match"`
+	} `json:"contract"`
+}
+
+// Contract returns the verified contract at the given address on the given chain.
+func (c *Client) Contract(ctx context.Context, chainID *big.Int, address common.Address) (*Contract, error) {
+	var res contractResponse
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("/v2/contract/%v/%s?fields=all", chainID, address.Hex()), nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Match == "" || len(res.Sources) == 0 {
+		return nil, ErrNotFound
+	}
+
+	settings := res.Compilation.CompilerSettings
+	contract := &Contract{
+		Match:           res.Match,
+		Name:            res.Compilation.Name,
+		CompilerVersion: res.Compilation.CompilerVersion,
+		Optimized:       settings.Optimizer.Enabled,
+		Runs:            settings.Optimizer.Runs,
+		EVMVersion:      settings.EVMVersion,
+		Files:           make(map[string]string, len(res.Sources)),
+	}
+	if len(res.ABI) > 0 && string(res.ABI) != "null" {
+		contract.ABI = string(res.ABI)
+	}
+	for path, source := range res.Sources {
+		contract.Files[path] = source.Content
+	}
+	if common.IsHexAddress(res.Deployment.Deployer) {
+		creator := common.HexToAddress(res.Deployment.Deployer)
+		contract.Creator = &creator
+	}
+	if res.Deployment.TransactionHash != "" {
+		hash := common.HexToHash(res.Deployment.TransactionHash)
+		contract.CreationTransaction = &hash
+	}
+	if res.ProxyResolution != nil && res.ProxyResolution.IsProxy && len(res.ProxyResolution.Implementations) > 0 {
+		implementation := common.HexToAddress(res.ProxyResolution.Implementations[0].Address)
+		contract.Implementation = &implementation
+	}
+	return contract, nil
+}
+
+// ABI returns the JSON ABI of the verified contract at the given address on the given chain.
+func (c *Client) ABI(ctx context.Context, chainID *big.Int, address common.Address) (string, error) {
+	var res contractResponse
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("/v2/contract/%v/%s?fields=abi", chainID, address.Hex()), nil, &res); err != nil {
+		return "", err
+	}
+	if res.Match == "" || len(res.ABI) == 0 || string(res.ABI) == "null" {
+		return "", ErrNotFound
+	}
+	return string(res.ABI), nil
+}
+
+// Verify submits the contract at the given address for verification, returning the ID of the
+// verification job.  The input is the Solidity standard JSON input, and the name is the fully
+// qualified name of the contract.  Sourcify obtains the constructor arguments from the creation
+// transaction, so they are not required.
+func (c *Client) Verify(ctx context.Context, chainID *big.Int, address common.Address, input json.RawMessage, compilerVersion string, name string) (string, error) {
+	body, err := json.Marshal(&submission{
+		StdJSONInput:       input,
+		CompilerVersion:    strings.TrimPrefix(compilerVersion, "v"),
+		ContractIdentifier: name,
+	})
+	if err != nil {
+		return "", err
+	}
+	var res jobResponse
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("/v2/verify/%v/%s", chainID, address.Hex()), body, &res); err != nil {
+		return "", err
+	}
+	if res.VerificationID == "" {
+		return "", errors.New("no verification ID returned")
+	}
+	return res.VerificationID, nil
+}
+
+// Job returns the status of the verification job with the given ID.
+func (c *Client) Job(ctx context.Context, id string) (*Job, error) {
+	var res jobResponse
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("/v2/verify/%s", url.PathEscape(id)), nil, &res); err != nil {
+		return nil, err
+	}
+	job := &Job{
+		Completed: res.IsJobCompleted,
+	}
+	if res.Error != nil {
+		job.Error = res.Error.Message
+	}
+	if res.Contract.Match != nil {
+		job.Match = *res.Contract.Match
+	}
+	return job, nil
+}
+
+// call calls the API at the given path, decoding the result.
+func (c *Client) call(ctx context.Context, method string, path string, body []byte, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var res errorResponse
+		if err := json.Unmarshal(data, &res); err != nil || res.Message == "" {
+			return fmt.Errorf("request returned status %d", resp.StatusCode)
+		}
+		if res.CustomCode == "already_verified" {
+			return ErrAlreadyVerified
+		}
+		return fmt.Errorf("request failed: %s", res.Message)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return errors.Wrap(err, "invalid response")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+func TestContract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/v2/contract/5/%s", testAddress.Hex()) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"customCode":"not_found","message":"Contract not found"}`)
+			return
+		}
+		switch r.URL.Query().Get("fields") {
+		case "abi":
+			fmt.Fprint(w, `{"match":"match","abi":[]}`)
+		default:
+			fmt.Fprint(w, `{"match":"exact_match","sources":{"contracts/Test.sol":{"content":"contract Test {}"}},"compilation":{"compilerVersion":"0.8.13+commit.abaa5c0e","name":"Test","compilerSettings":{"optimizer":{"enabled":true,"runs":1000},"evmVersion":"london"}},"abi":[],"deployment":{"transactionHash":"0x1111111111111111111111111111111111111111111111111111111111111111","deployer":"0x0000000000000000000000000000000000000001"},"proxyResolution":{"isProxy":false,"implementations":[]}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL + "/")
+
+	contract, err := client.Contract(context.Background(), big.NewInt(5), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, "exact_match", contract.Match)
+	assert.Equal(t, "Test", contract.Name)
+	assert.Equal(t, uint64(1000), contract.Runs)
+	assert.Equal(t, map[string]string{"contracts/Test.sol": "contract Test {}"}, contract.Files)
+	require.NotNil(t, contract.CreationTransaction)
+	assert.Nil(t, contract.Implementation)
+
+	abi, err := client.ABI(context.Background(), big.NewInt(5), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", abi)
+
+	_, err = client.Contract(context.Background(), big.NewInt(1), testAddress)
+	assert.Equal(t, ErrNotFound, err)
+	_, err = client.ABI(context.Background(), big.NewInt(1), testAddress)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/v2/verify/5/%s", testAddress.Hex()):
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			data, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			var body submission
+			require.NoError(t, json.Unmarshal(data, &body))
+			assert.Equal(t, "0.8.13+commit.abaa5c0e", body.CompilerVersion)
+			assert.Equal(t, "contracts/Test.sol:Test", body.ContractIdentifier)
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"verificationId":"job"}`)
+		case fmt.Sprintf("/v2/verify/5/%s", common.Address{}.Hex()):
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"customCode":"already_verified","message":"The contract is already verified"}`)
+		case fmt.Sprintf("/v2/verify/1/%s", testAddress.Hex()):
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"customCode":"unsupported_chain","message":"Chain 1 is not supported"}`)
+		case "/v2/verify/job":
+			fmt.Fprint(w, `{"isJobCompleted":true,"verificationId":"job","contract":{"match":"exact_match"}}`)
+		case "/v2/verify/failed":
+			fmt.Fprint(w, `{"isJobCompleted":true,"verificationId":"failed","error":{"customCode":"no_match","message":"The compiled bytecode does not match"},"contract":{"match":null}}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	input := json.RawMessage(`{"language":"Solidity"}`)
+
+	id, err := client.Verify(context.Background(), big.NewInt(5), testAddress, input, "v0.8.13+commit.abaa5c0e", "contracts/Test.sol:Test")
+	require.NoError(t, err)
+	assert.Equal(t, "job", id)
+
+	job, err := client.Job(context.Background(), id)
+	require.NoError(t, err)
+	assert.True(t, job.Completed)
+	assert.Equal(t, "exact_match", job.Match)
+	assert.Empty(t, job.Error)
+
+	job, err = client.Job(context.Background(), "failed")
+	require.NoError(t, err)
+	assert.True(t, job.Completed)
+	assert.Empty(t, job.Match)
+	assert.Equal(t, "The compiled bytecode does not match", job.Error)
+
+	_, err = client.Verify(context.Background(), big.NewInt(5), common.Address{}, input, "0.8.13", "Test")
+	assert.Equal(t, ErrAlreadyVerified, err)
+	_, err = client.Verify(context.Background(), big.NewInt(1), testAddress, input, "0.8.13", "Test")
+	assert.EqualError(t, err, "request failed: Chain 1 is not supported")
+	_, err = client.Job(context.Background(), "error")
+	assert.EqualError(t, err, "request returned status 500")
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, DefaultURL, New("").url)
+	assert.Equal(t, "http://localhost:5555", New("http://localhost:5555/").url)
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	addressKeyRe   = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	hashKeyRe      = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	hexNumberKeyRe = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+	numberKeyRe    = regexp.MustCompile(`^-?[0-9]+$`)
+	keyTypeRe      = regexp.MustCompile(`^(address|bool|string|bytes|bytes[0-9]+|u?int[0-9]*)$`)
+	typedKeyRe     = regexp.MustCompile(`^(address|bool|string|bytes|bytes[0-9]+|u?int[0-9]*):(.*)$`)
+)
+
+// StorageSlot parses a storage slot given as either a decimal or a hex number.
+func StorageSlot(input string) (common.Hash, error) {
+	slot, success := math.ParseBig256(input)
+	if !success {
+		return common.Hash{}, fmt.Errorf("invalid storage slot %s", input)
+	}
+	return common.BigToHash(slot), nil
+}
+
+// MappingSlot returns the storage slot for the value with the given encoded key in a mapping at the given slot.
+func MappingSlot(slot common.Hash, key []byte) common.Hash {
+	return crypto.Keccak256Hash(key, slot.Bytes())
+}
+
+// ArraySlot returns the storage slot for the element at the given index in a dynamic array at the given slot,
+// where each element occupies the given number of slots.
+func ArraySlot(slot common.Hash, index *big.Int, size uint64) common.Hash {
+	base := new(big.Int).SetBytes(crypto.Keccak256(slot.Bytes()))
+	offset := new(big.Int).Mul(index, new(big.Int).SetUint64(size))
+	return common.BigToHash(math.U256(base.Add(base, offset)))
+}
+
+// OffsetSlot returns the storage slot the given number of slots after the given slot.
+func OffsetSlot(slot common.Hash, offset uint64) common.Hash {
+	res := new(big.Int).SetBytes(slot.Bytes())
+	return common.BigToHash(math.U256(res.Add(res, new(big.Int).SetUint64(offset))))
+}
+
+// MappingKey encodes a mapping key as used when calculating a storage slot.  If keyType is supplied
+// the whole of the input is the value of that type.  Otherwise the key can be supplied with an
+// explicit type in the form type:value, or the type is inferred from the value: 20-byte hex values
+// are addresses, 32-byte hex values are bytes32, other hex and decimal values are integers and
+// anything else is a string.
+func MappingKey(input string, keyType string) ([]byte, error) {
+	value := input
+	if keyType != "" {
+		if !keyTypeRe.MatchString(keyType) {
+			return nil, fmt.Errorf("invalid type %s", keyType)
+		}
+	} else if match := typedKeyRe.FindStringSubmatch(input); match != nil {
+		keyType = match[1]
+		value = match[2]
+	} else {
+		switch {
+		case addressKeyRe.MatchString(input):
+			keyType = "address"
+		case hashKeyRe.MatchString(input):
+			keyType = "bytes32"
+		case hexNumberKeyRe.MatchString(input):
+			keyType = "uint256"
+		case numberKeyRe.MatchString(input) && strings.HasPrefix(input, "-"):
+			keyType = "int256"
+		case numberKeyRe.MatchString(input):
+			keyType = "uint256"
+		default:
+			keyType = "string"
+		}
+	}
+
+	switch {
+	case keyType == "address":
+		if !addressKeyRe.MatchString(value) {
+			return nil, fmt.Errorf("invalid address %s", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(value).Bytes(), 32), nil
+	case keyType == "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %s", value)
+		}
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case keyType == "string":
+		return []byte(value), nil
+	case keyType == "bytes":
+		return hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	case strings.HasPrefix(keyType, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(keyType, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type %s", keyType)
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > size {
+			return nil, fmt.Errorf("value %s too long for %s", value, keyType)
+		}
+		return common.RightPadBytes(data, 32), nil
+	default:
+		// Integer types.
+		val, success := math.ParseBig256(value)
+		if !success {
+			if strings.HasPrefix(value, "-") {
+				val, success = math.ParseBig256(value[1:])
+				if success {
+					val = val.Neg(val)
+				}
+			}
+			if !success {
+				return nil, fmt.Errorf("invalid number %s", value)
+			}
+		}
+		if val.Sign() < 0 && strings.HasPrefix(keyType, "u") {
+			return nil, fmt.Errorf("negative value %s for %s", value, keyType)
+		}
+		return math.U256Bytes(val), nil
+	}
+}
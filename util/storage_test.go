@@ -0,0 +1,82 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingKey(t *testing.T) {
+	tests := []struct {
+		input   string
+		keyType string
+		res     string
+		err     string
+	}{
+		{input: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", res: "0x0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4"},
+		{input: "5", res: "0x0000000000000000000000000000000000000000000000000000000000000005"},
+		{input: "-1", res: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{input: "uint8:0x10", res: "0x0000000000000000000000000000000000000000000000000000000000000010"},
+		{input: "uint256:-1", err: "negative value -1 for uint256"},
+		{input: "bool:true", res: "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		{input: "bytes4:0x01020304", res: "0x0102030400000000000000000000000000000000000000000000000000000000"},
+		{input: "bytes2:0x010203", err: "value 0x010203 too long for bytes2"},
+		{input: "bytes:0x0102", res: "0x0102"},
+		{input: "hello", res: "0x68656c6c6f"},
+		{input: "string:5", res: "0x35"},
+		{input: "address:0x01", err: "invalid address 0x01"},
+		{input: "0x10", res: "0x0000000000000000000000000000000000000000000000000000000000000010"},
+		{input: "0x10", keyType: "uint", res: "0x0000000000000000000000000000000000000000000000000000000000000010"},
+		{input: "0x10", keyType: "string", res: "0x30783130"},
+		{input: "-2", keyType: "int", res: "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe"},
+		{input: "0x5FfC014343cd971B7eb70732021E26C35B744cc4", keyType: "address", res: "0x0000000000000000000000005ffc014343cd971b7eb70732021e26c35b744cc4"},
+		{input: "0x01", keyType: "bytes32", res: "0x0100000000000000000000000000000000000000000000000000000000000000"},
+		{input: "uint8:5", keyType: "string", res: "0x75696e74383a35"},
+		{input: "5", keyType: "float", err: "invalid type float"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.keyType, test.input), func(t *testing.T) {
+			res, err := MappingKey(test.input, test.keyType)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, fmt.Sprintf("%#x", res))
+			}
+		})
+	}
+}
+
+func TestSlots(t *testing.T) {
+	slot, err := StorageSlot("0x02")
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x02"), slot)
+
+	// Key 0 in a mapping at slot 0.
+	key, err := MappingKey("0", "")
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5"), MappingSlot(common.Hash{}, key))
+
+	// First element of a dynamic array at slot 2.
+	assert.Equal(t, common.HexToHash("0x405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ace"), ArraySlot(slot, big.NewInt(0), 1))
+	assert.Equal(t, common.HexToHash("0x405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ad2"), ArraySlot(slot, big.NewInt(2), 2))
+	assert.Equal(t, common.HexToHash("0x05"), OffsetSlot(slot, 3))
+}
@@ -14,15 +14,23 @@
 package util
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 // WaitForTransaction waits for the transaction to be mined, or for the limit to expire
+//
+// Deprecated: use conn.Conn.WaitForTransaction, which also handles confirmations and reorgs.
 func WaitForTransaction(client *ethclient.Client, txHash common.Hash, limit time.Duration) bool {
 	start := time.Now()
 	first := true
@@ -41,3 +49,40 @@ func WaitForTransaction(client *ethclient.Client, txHash common.Hash, limit time
 	}
 	return false
 }
+
+// DecodeRawTransaction decodes a raw signed transaction.  It accepts both the canonical binary
+// encoding and the RLP-wrapped encoding of typed transactions.
+func DecodeRawTransaction(data []byte) (*types.Transaction, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalBinary(data); err == nil {
+		return tx, nil
+	}
+
+	tx = &types.Transaction{}
+	if err := tx.DecodeRLP(rlp.NewStream(bytes.NewReader(data), 0)); err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction")
+	}
+	return tx, nil
+}
+
+// DecodeRawTransactions decodes raw signed transactions, supplied as hex strings one per line.
+// Blank lines are ignored.
+func DecodeRawTransactions(input string) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "0x")
+		if line == "" {
+			continue
+		}
+		data, err := hex.DecodeString(line)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode hex")
+		}
+		tx, err := DecodeRawTransaction(data)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRawTransactions(t *testing.T) {
+	key, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	to := common.HexToAddress("0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845")
+	chainID := big.NewInt(1)
+	signer := types.LatestSignerForChainID(chainID)
+
+	legacyTx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(1000000000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	})
+	require.NoError(t, err)
+	dynamicTx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     2,
+		GasFeeCap: big.NewInt(2000000000),
+		GasTipCap: big.NewInt(1000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	legacyBinary, err := legacyTx.MarshalBinary()
+	require.NoError(t, err)
+	dynamicBinary, err := dynamicTx.MarshalBinary()
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	require.NoError(t, dynamicTx.EncodeRLP(buf))
+	dynamicRLP := buf.Bytes()
+
+	tests := []struct {
+		name   string
+		input  string
+		hashes []common.Hash
+		err    string
+	}{
+		{
+			name:   "Empty",
+			input:  "",
+			hashes: []common.Hash{},
+		},
+		{
+			name:  "BadHex",
+			input: "0xzz",
+			err:   "failed to decode hex: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "BadTransaction",
+			input: "0x0102",
+			err:   "failed to decode transaction: typed transaction too short",
+		},
+		{
+			name:   "Legacy",
+			input:  "0x" + hex.EncodeToString(legacyBinary),
+			hashes: []common.Hash{legacyTx.Hash()},
+		},
+		{
+			name:   "DynamicBinary",
+			input:  "0x" + hex.EncodeToString(dynamicBinary),
+			hashes: []common.Hash{dynamicTx.Hash()},
+		},
+		{
+			name:   "DynamicRLP",
+			input:  hex.EncodeToString(dynamicRLP),
+			hashes: []common.Hash{dynamicTx.Hash()},
+		},
+		{
+			name:   "Multiple",
+			input:  "0x" + hex.EncodeToString(legacyBinary) + "\r\n\n0x" + hex.EncodeToString(dynamicBinary) + "\n",
+			hashes: []common.Hash{legacyTx.Hash(), dynamicTx.Hash()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			txs, err := DecodeRawTransactions(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			hashes := make([]common.Hash, len(txs))
+			for i := range txs {
+				hashes[i] = txs[i].Hash()
+			}
+			require.Equal(t, test.hashes, hashes)
+		})
+	}
+}
@@ -84,6 +84,21 @@ func (f *function) String() string {
 	return buffer.String()
 }
 
+// FunctionName returns the name of the function called by a transaction's data if its
+// signature is known, otherwise an empty string.
+func FunctionName(input []byte) string {
+	if len(input) < 4 {
+		return ""
+	}
+	var sig [4]byte
+	copy(sig[:], input[:4])
+	function, exists := functions[sig]
+	if !exists {
+		return ""
+	}
+	return function.name
+}
+
 // DataToString takes a transaction's data bytes and converts it in to a useful representation if one exists
 func DataToString(client *ethclient.Client, input []byte) string {
 	if len(input) == 0 {
@@ -0,0 +1,289 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+)
+
+// unsignedTransactionJSON is the JSON representation of an unsigned transaction.
+type unsignedTransactionJSON struct {
+	Type                 *hexutil.Uint64   `json:"type,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+	Nonce                *hexutil.Uint64   `json:"nonce,omitempty"`
+	From                 *common.Address   `json:"from,omitempty"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  *hexutil.Uint64   `json:"gas,omitempty"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value,omitempty"`
+	Input                *hexutil.Bytes    `json:"input,omitempty"`
+	Data                 *hexutil.Bytes    `json:"data,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+// unsignedLegacyTx is the RLP representation of an unsigned legacy transaction.  The chain ID
+// and its accompanying empty values are present for EIP-155 transactions.
+type unsignedLegacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	To       *common.Address `rlp:"nil"`
+	Value    *big.Int
+	Data     []byte
+	ChainID  *big.Int `rlp:"optional"`
+	Zero1    uint     `rlp:"optional"`
+	Zero2    uint     `rlp:"optional"`
+}
+
+// unsignedAccessListTx is the RLP representation of an unsigned access list transaction.
+type unsignedAccessListTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList types.AccessList
+}
+
+// unsignedDynamicFeeTx is the RLP representation of an unsigned dynamic fee transaction.
+type unsignedDynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList types.AccessList
+}
+
+// ParseUnsignedTransaction parses an unsigned transaction.  The transaction can be supplied as
+// JSON, as the RLP-encoded payload that is signed, or as a full transaction encoding in which
+// case any signature is ignored.  The chain ID of the transaction is returned separately, as
+// it is not available from unsigned legacy transactions; it is nil if not present.
+func ParseUnsignedTransaction(input []byte) (*types.Transaction, *big.Int, error) {
+	if len(input) == 0 {
+		return nil, nil, errors.New("no transaction supplied")
+	}
+	if trimmed := bytes.TrimSpace(input); len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseUnsignedTransactionJSON(trimmed)
+	}
+
+	switch input[0] {
+	case types.AccessListTxType:
+		var tx unsignedAccessListTx
+		if err := rlp.DecodeBytes(input[1:], &tx); err == nil {
+			return types.NewTx(&types.AccessListTx{
+				ChainID:    tx.ChainID,
+				Nonce:      tx.Nonce,
+				GasPrice:   tx.GasPrice,
+				Gas:        tx.Gas,
+				To:         tx.To,
+				Value:      tx.Value,
+				Data:       tx.Data,
+				AccessList: tx.AccessList,
+			}), tx.ChainID, nil
+		}
+	case types.DynamicFeeTxType:
+		var tx unsignedDynamicFeeTx
+		if err := rlp.DecodeBytes(input[1:], &tx); err == nil {
+			return types.NewTx(&types.DynamicFeeTx{
+				ChainID:    tx.ChainID,
+				Nonce:      tx.Nonce,
+				GasTipCap:  tx.GasTipCap,
+				GasFeeCap:  tx.GasFeeCap,
+				Gas:        tx.Gas,
+				To:         tx.To,
+				Value:      tx.Value,
+				Data:       tx.Data,
+				AccessList: tx.AccessList,
+			}), tx.ChainID, nil
+		}
+	default:
+		var tx unsignedLegacyTx
+		if err := rlp.DecodeBytes(input, &tx); err == nil {
+			var chainID *big.Int
+			if tx.ChainID != nil && tx.ChainID.Sign() != 0 {
+				chainID = tx.ChainID
+			}
+			return types.NewTx(&types.LegacyTx{
+				Nonce:    tx.Nonce,
+				GasPrice: tx.GasPrice,
+				Gas:      tx.Gas,
+				To:       tx.To,
+				Value:    tx.Value,
+				Data:     tx.Data,
+			}), chainID, nil
+		}
+	}
+
+	// Not an unsigned payload; try a full transaction.
+	tx, err := DecodeRawTransaction(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	var chainID *big.Int
+	if tx.Type() != types.LegacyTxType || tx.Protected() {
+		chainID = tx.ChainId()
+	}
+	return tx, chainID, nil
+}
+
+func parseUnsignedTransactionJSON(input []byte) (*types.Transaction, *big.Int, error) {
+	var data unsignedTransactionJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid JSON")
+	}
+	if data.Nonce == nil {
+		return nil, nil, errors.New("nonce missing")
+	}
+	if data.Gas == nil {
+		return nil, nil, errors.New("gas missing")
+	}
+	value := new(big.Int)
+	if data.Value != nil {
+		value = data.Value.ToInt()
+	}
+	var callData []byte
+	switch {
+	case data.Input != nil:
+		callData = *data.Input
+	case data.Data != nil:
+		callData = *data.Data
+	}
+	var chainID *big.Int
+	if data.ChainID != nil {
+		chainID = data.ChainID.ToInt()
+	}
+	var accessList types.AccessList
+	if data.AccessList != nil {
+		accessList = *data.AccessList
+	}
+
+	txType := uint64(types.LegacyTxType)
+	switch {
+	case data.Type != nil:
+		txType = uint64(*data.Type)
+	case data.MaxFeePerGas != nil:
+		txType = types.DynamicFeeTxType
+	case data.AccessList != nil:
+		txType = types.AccessListTxType
+	}
+
+	switch txType {
+	case types.LegacyTxType:
+		if data.GasPrice == nil {
+			return nil, nil, errors.New("gasPrice missing")
+		}
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(*data.Nonce),
+			GasPrice: data.GasPrice.ToInt(),
+			Gas:      uint64(*data.Gas),
+			To:       data.To,
+			Value:    value,
+			Data:     callData,
+		}), chainID, nil
+	case types.AccessListTxType:
+		if chainID == nil {
+			return nil, nil, errors.New("chainId missing")
+		}
+		if data.GasPrice == nil {
+			return nil, nil, errors.New("gasPrice missing")
+		}
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      uint64(*data.Nonce),
+			GasPrice:   data.GasPrice.ToInt(),
+			Gas:        uint64(*data.Gas),
+			To:         data.To,
+			Value:      value,
+			Data:       callData,
+			AccessList: accessList,
+		}), chainID, nil
+	case types.DynamicFeeTxType:
+		if chainID == nil {
+			return nil, nil, errors.New("chainId missing")
+		}
+		if data.MaxFeePerGas == nil {
+			return nil, nil, errors.New("maxFeePerGas missing")
+		}
+		if data.MaxPriorityFeePerGas == nil {
+			return nil, nil, errors.New("maxPriorityFeePerGas missing")
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      uint64(*data.Nonce),
+			GasTipCap:  data.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap:  data.MaxFeePerGas.ToInt(),
+			Gas:        uint64(*data.Gas),
+			To:         data.To,
+			Value:      value,
+			Data:       callData,
+			AccessList: accessList,
+		}), chainID, nil
+	default:
+		return nil, nil, errors.Errorf("unsupported transaction type %d", txType)
+	}
+}
+
+// MarshalUnsignedTransaction returns the JSON representation of the transaction without its
+// signature, in the form accepted by ParseUnsignedTransaction.  The sender is included if
+// supplied.
+func MarshalUnsignedTransaction(tx *types.Transaction, from *common.Address) ([]byte, error) {
+	txType := hexutil.Uint64(tx.Type())
+	nonce := hexutil.Uint64(tx.Nonce())
+	gas := hexutil.Uint64(tx.Gas())
+	input := hexutil.Bytes(tx.Data())
+	data := &unsignedTransactionJSON{
+		Type:  &txType,
+		Nonce: &nonce,
+		From:  from,
+		To:    tx.To(),
+		Gas:   &gas,
+		Value: (*hexutil.Big)(tx.Value()),
+		Input: &input,
+	}
+	if tx.Type() != types.LegacyTxType || tx.Protected() {
+		data.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		data.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		data.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		accessList := tx.AccessList()
+		data.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		data.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		data.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		data.AccessList = &accessList
+	default:
+		return nil, errors.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	return json.Marshal(data)
+}
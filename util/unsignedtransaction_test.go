@@ -0,0 +1,222 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnsignedTransaction(t *testing.T) {
+	to := common.HexToAddress("0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845")
+	chainID := big.NewInt(5)
+
+	dynamicTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     2,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(2000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Data:      []byte{0x01, 0x02},
+	})
+	dynamicPayload, err := rlp.EncodeToBytes([]interface{}{
+		chainID, uint64(2), big.NewInt(1000000000), big.NewInt(2000000000), uint64(21000), &to, big.NewInt(1), []byte{0x01, 0x02}, types.AccessList{},
+	})
+	require.NoError(t, err)
+	dynamicPayload = append([]byte{types.DynamicFeeTxType}, dynamicPayload...)
+
+	legacyTx := types.NewTx(&types.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(1000000000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	})
+	legacyPayload, err := rlp.EncodeToBytes([]interface{}{
+		uint64(1), big.NewInt(1000000000), uint64(21000), &to, big.NewInt(1), []byte{},
+	})
+	require.NoError(t, err)
+	legacyEIP155Payload, err := rlp.EncodeToBytes([]interface{}{
+		uint64(1), big.NewInt(1000000000), uint64(21000), &to, big.NewInt(1), []byte{}, chainID, uint(0), uint(0),
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	signedTx, err := types.SignTx(dynamicTx, types.LatestSignerForChainID(chainID), key)
+	require.NoError(t, err)
+	signedBinary, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		input   []byte
+		tx      *types.Transaction
+		chainID *big.Int
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no transaction supplied",
+		},
+		{
+			name:  "JSONBad",
+			input: []byte(`{`),
+			err:   "invalid JSON: unexpected end of JSON input",
+		},
+		{
+			name:  "JSONNonceMissing",
+			input: []byte(`{"gas":"0x5208"}`),
+			err:   "nonce missing",
+		},
+		{
+			name:  "JSONChainIDMissing",
+			input: []byte(`{"nonce":"0x2","gas":"0x5208","maxFeePerGas":"0x77359400","maxPriorityFeePerGas":"0x3b9aca00"}`),
+			err:   "chainId missing",
+		},
+		{
+			name:    "JSONDynamic",
+			input:   []byte(` {"chainId":"0x5","nonce":"0x2","to":"0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845","gas":"0x5208","maxFeePerGas":"0x77359400","maxPriorityFeePerGas":"0x3b9aca00","value":"0x1","input":"0x0102"}`),
+			tx:      dynamicTx,
+			chainID: chainID,
+		},
+		{
+			name:  "JSONLegacy",
+			input: []byte(`{"nonce":"0x1","to":"0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845","gas":"0x5208","gasPrice":"0x3b9aca00","value":"0x1"}`),
+			tx:    legacyTx,
+		},
+		{
+			name:    "RLPDynamic",
+			input:   dynamicPayload,
+			tx:      dynamicTx,
+			chainID: chainID,
+		},
+		{
+			name:  "RLPLegacy",
+			input: legacyPayload,
+			tx:    legacyTx,
+		},
+		{
+			name:    "RLPLegacyEIP155",
+			input:   legacyEIP155Payload,
+			tx:      legacyTx,
+			chainID: chainID,
+		},
+		{
+			name:    "Signed",
+			input:   signedBinary,
+			tx:      dynamicTx,
+			chainID: chainID,
+		},
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx, txChainID, err := ParseUnsignedTransaction(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, signer.Hash(test.tx), signer.Hash(tx))
+			if test.chainID == nil {
+				require.Nil(t, txChainID)
+			} else {
+				require.Equal(t, test.chainID.String(), txChainID.String())
+			}
+		})
+	}
+}
+
+func TestMarshalUnsignedTransaction(t *testing.T) {
+	to := common.HexToAddress("0x2ab7150Bba7D5F181b3aF5623e52b15bB1054845")
+	from := common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+	chainID := big.NewInt(5)
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		from     *common.Address
+		expected string
+		chainID  *big.Int
+	}{
+		{
+			name: "Legacy",
+			tx: types.NewTx(&types.LegacyTx{
+				Nonce:    0,
+				GasPrice: big.NewInt(1000000000),
+				Gas:      21000,
+				To:       &to,
+				Value:    big.NewInt(1),
+			}),
+			expected: `{"type":"0x0","nonce":"0x0","to":"0x2ab7150bba7d5f181b3af5623e52b15bb1054845","gas":"0x5208","gasPrice":"0x3b9aca00","value":"0x1","input":"0x"}`,
+		},
+		{
+			name: "DynamicFee",
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     2,
+				GasTipCap: big.NewInt(1000000000),
+				GasFeeCap: big.NewInt(2000000000),
+				Gas:       21000,
+				To:        &to,
+				Value:     big.NewInt(1),
+				Data:      []byte{0x01, 0x02},
+			}),
+			from:     &from,
+			expected: `{"type":"0x2","chainId":"0x5","nonce":"0x2","from":"0x5ffc014343cd971b7eb70732021e26c35b744cc4","to":"0x2ab7150bba7d5f181b3af5623e52b15bb1054845","gas":"0x5208","maxFeePerGas":"0x77359400","maxPriorityFeePerGas":"0x3b9aca00","value":"0x1","input":"0x0102","accessList":[]}`,
+			chainID:  chainID,
+		},
+		{
+			name: "Creation",
+			tx: types.NewTx(&types.AccessListTx{
+				ChainID:  chainID,
+				Nonce:    3,
+				GasPrice: big.NewInt(1000000000),
+				Gas:      100000,
+				Value:    big.NewInt(0),
+				Data:     []byte{0x60, 0x00},
+			}),
+			expected: `{"type":"0x1","chainId":"0x5","nonce":"0x3","gas":"0x186a0","gasPrice":"0x3b9aca00","value":"0x0","input":"0x6000","accessList":[]}`,
+			chainID:  chainID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := MarshalUnsignedTransaction(test.tx, test.from)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(data))
+
+			// Confirm that the output can be parsed.
+			tx, txChainID, err := ParseUnsignedTransaction(data)
+			require.NoError(t, err)
+			require.Equal(t, test.tx.Hash(), tx.Hash())
+			if test.chainID == nil {
+				require.Nil(t, txChainID)
+			} else {
+				require.Equal(t, test.chainID.String(), txChainID.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// EntryPointV06 is the address of the ERC-4337 EntryPoint contract version 0.6.
+	EntryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	// EntryPointV07 is the address of the ERC-4337 EntryPoint contract version 0.7.
+	EntryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+)
+
+// UserOperation is an ERC-4337 user operation.  Fields are those of EntryPoint version 0.7; for
+// version 0.6 the factory and its data are combined as the init code.
+type UserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	Factory              *common.Address
+	FactoryData          []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Signature            []byte
+}
+
+// initCode returns the init code of the operation, which is the factory followed by its data.
+func (op *UserOperation) initCode() []byte {
+	if op.Factory == nil {
+		return []byte{}
+	}
+	return append(op.Factory.Bytes(), op.FactoryData...)
+}
+
+// Hash returns the hash of the operation for the given EntryPoint and chain, which is the value
+// signed by the account.  EntryPoint version 0.6 is used for its well-known address, otherwise
+// version 0.7.
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	var packed []byte
+	if entryPoint == EntryPointV06 {
+		packed = concatWords(
+			common.LeftPadBytes(op.Sender.Bytes(), 32),
+			math.U256Bytes(new(big.Int).Set(op.Nonce)),
+			crypto.Keccak256(op.initCode()),
+			crypto.Keccak256(op.CallData),
+			math.U256Bytes(new(big.Int).Set(op.CallGasLimit)),
+			math.U256Bytes(new(big.Int).Set(op.VerificationGasLimit)),
+			math.U256Bytes(new(big.Int).Set(op.PreVerificationGas)),
+			math.U256Bytes(new(big.Int).Set(op.MaxFeePerGas)),
+			math.U256Bytes(new(big.Int).Set(op.MaxPriorityFeePerGas)),
+			crypto.Keccak256(nil),
+		)
+	} else {
+		packed = concatWords(
+			common.LeftPadBytes(op.Sender.Bytes(), 32),
+			math.U256Bytes(new(big.Int).Set(op.Nonce)),
+			crypto.Keccak256(op.initCode()),
+			crypto.Keccak256(op.CallData),
+			packUint128s(op.VerificationGasLimit, op.CallGasLimit),
+			math.U256Bytes(new(big.Int).Set(op.PreVerificationGas)),
+			packUint128s(op.MaxPriorityFeePerGas, op.MaxFeePerGas),
+			crypto.Keccak256(nil),
+		)
+	}
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(packed),
+		common.LeftPadBytes(entryPoint.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(chainID)),
+	)
+}
+
+// RPC returns the operation in the form used by bundlers for the given EntryPoint.
+func (op *UserOperation) RPC(entryPoint common.Address) map[string]interface{} {
+	res := map[string]interface{}{
+		"sender":               op.Sender,
+		"nonce":                (*hexutil.Big)(op.Nonce),
+		"callData":             hexutil.Bytes(op.CallData),
+		"callGasLimit":         (*hexutil.Big)(op.CallGasLimit),
+		"verificationGasLimit": (*hexutil.Big)(op.VerificationGasLimit),
+		"preVerificationGas":   (*hexutil.Big)(op.PreVerificationGas),
+		"maxFeePerGas":         (*hexutil.Big)(op.MaxFeePerGas),
+		"maxPriorityFeePerGas": (*hexutil.Big)(op.MaxPriorityFeePerGas),
+		"signature":            hexutil.Bytes(op.Signature),
+	}
+	if entryPoint == EntryPointV06 {
+		res["initCode"] = hexutil.Bytes(op.initCode())
+		res["paymasterAndData"] = hexutil.Bytes{}
+	} else if op.Factory != nil {
+		res["factory"] = op.Factory
+		res["factoryData"] = hexutil.Bytes(op.FactoryData)
+	}
+	return res
+}
+
+// packUint128s packs two 128-bit values in to a single word.
+func packUint128s(high *big.Int, low *big.Int) []byte {
+	res := make([]byte, 32)
+	copy(res[:16], common.LeftPadBytes(high.Bytes(), 16))
+	copy(res[16:], common.LeftPadBytes(low.Bytes(), 16))
+	return res
+}
+
+// concatWords concatenates 32-byte words.
+func concatWords(words ...[]byte) []byte {
+	res := make([]byte, 0, len(words)*32)
+	for _, word := range words {
+		res = append(res, word...)
+	}
+	return res
+}
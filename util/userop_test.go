@@ -0,0 +1,98 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func testUserOperation() *UserOperation {
+	factory := common.HexToAddress("0x9406Cc6185a346906296840746125a0E44976454")
+	return &UserOperation{
+		Sender:               common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4"),
+		Nonce:                big.NewInt(3),
+		Factory:              &factory,
+		FactoryData:          common.FromHex("0x5fbfb9cf0000000000000000000000007e5f4552091a69125d5dfcb7b8c2659029395bdf0000000000000000000000000000000000000000000000000000000000000000"),
+		CallData:             common.FromHex("0xb61d27f60000000000000000000000002b5ad5c4795c026514f8317c7a215e218dccd6cf00000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000000600000000000000000000000000000000000000000000000000000000000000000"),
+		CallGasLimit:         big.NewInt(35000),
+		VerificationGasLimit: big.NewInt(400000),
+		PreVerificationGas:   big.NewInt(50000),
+		MaxFeePerGas:         big.NewInt(30000000000),
+		MaxPriorityFeePerGas: big.NewInt(1500000000),
+	}
+}
+
+func userOperationArguments(t *testing.T, types ...string) abi.Arguments {
+	args := make(abi.Arguments, len(types))
+	for i, name := range types {
+		argType, err := abi.NewType(name, "", nil)
+		require.NoError(t, err)
+		args[i] = abi.Argument{Type: argType}
+	}
+	return args
+}
+
+func TestUserOperationHashV06(t *testing.T) {
+	op := testUserOperation()
+	chainID := big.NewInt(11155111)
+
+	// Calculate the expected value with the generic ABI encoder, as per the EntryPoint.
+	inner, err := userOperationArguments(t, "address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256", "uint256", "uint256", "bytes32").Pack(
+		op.Sender, op.Nonce, crypto.Keccak256Hash(append(op.Factory.Bytes(), op.FactoryData...)), crypto.Keccak256Hash(op.CallData),
+		op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas, op.MaxFeePerGas, op.MaxPriorityFeePerGas, crypto.Keccak256Hash(nil))
+	require.NoError(t, err)
+	outer, err := userOperationArguments(t, "bytes32", "address", "uint256").Pack(crypto.Keccak256Hash(inner), EntryPointV06, chainID)
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.Keccak256Hash(outer), op.Hash(EntryPointV06, chainID))
+}
+
+func TestUserOperationHashV07(t *testing.T) {
+	op := testUserOperation()
+	op.Factory = nil
+	chainID := big.NewInt(11155111)
+
+	accountGasLimits := new(big.Int).Or(new(big.Int).Lsh(op.VerificationGasLimit, 128), op.CallGasLimit)
+	gasFees := new(big.Int).Or(new(big.Int).Lsh(op.MaxPriorityFeePerGas, 128), op.MaxFeePerGas)
+	inner, err := userOperationArguments(t, "address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256", "bytes32").Pack(
+		op.Sender, op.Nonce, crypto.Keccak256Hash(nil), crypto.Keccak256Hash(op.CallData),
+		accountGasLimits, op.PreVerificationGas, gasFees, crypto.Keccak256Hash(nil))
+	require.NoError(t, err)
+	outer, err := userOperationArguments(t, "bytes32", "address", "uint256").Pack(crypto.Keccak256Hash(inner), EntryPointV07, chainID)
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.Keccak256Hash(outer), op.Hash(EntryPointV07, chainID))
+}
+
+func TestUserOperationRPC(t *testing.T) {
+	op := testUserOperation()
+
+	v06 := op.RPC(EntryPointV06)
+	require.Contains(t, v06, "initCode")
+	require.Contains(t, v06, "paymasterAndData")
+	require.NotContains(t, v06, "factory")
+
+	v07 := op.RPC(EntryPointV07)
+	require.Equal(t, op.Factory, v07["factory"])
+	require.NotContains(t, v07, "initCode")
+
+	op.Factory = nil
+	require.NotContains(t, op.RPC(EntryPointV07), "factory")
+}
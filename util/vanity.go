@@ -0,0 +1,151 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var hexCharsRe = regexp.MustCompile("^[0-9a-fA-F]*$")
+
+// VanityMatcher matches addresses against a prefix, suffix and regular expression.
+type VanityMatcher struct {
+	prefix   string
+	suffix   string
+	re       *regexp.Regexp
+	checksum bool
+}
+
+// NewVanityMatcher creates a matcher for addresses that start with the prefix, end with
+// the suffix and match the regular expression, any of which may be empty.  Matching is
+// carried out against the hex form of the address without its 0x prefix.  If checksum is
+// true matching is carried out against the EIP-55 checksummed form of the address, so is
+// case-sensitive; otherwise it is carried out against the lower-case form.
+func NewVanityMatcher(prefix string, suffix string, pattern string, checksum bool) (*VanityMatcher, error) {
+	prefix = strings.TrimPrefix(prefix, "0x")
+	if !hexCharsRe.MatchString(prefix) {
+		return nil, fmt.Errorf("invalid prefix %s", prefix)
+	}
+	if !hexCharsRe.MatchString(suffix) {
+		return nil, fmt.Errorf("invalid suffix %s", suffix)
+	}
+	if len(prefix)+len(suffix) > common.AddressLength*2 {
+		return nil, errors.New("prefix and suffix are longer than an address")
+	}
+	if prefix == "" && suffix == "" && pattern == "" {
+		return nil, errors.New("no prefix, suffix or pattern supplied")
+	}
+	matcher := &VanityMatcher{
+		prefix:   prefix,
+		suffix:   suffix,
+		checksum: checksum,
+	}
+	if !checksum {
+		matcher.prefix = strings.ToLower(matcher.prefix)
+		matcher.suffix = strings.ToLower(matcher.suffix)
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pattern")
+		}
+		matcher.re = re
+	}
+	return matcher, nil
+}
+
+// Match returns true if the address matches.
+func (m *VanityMatcher) Match(address common.Address) bool {
+	var hex string
+	if m.checksum {
+		hex = address.Hex()[2:]
+	} else {
+		hex = strings.ToLower(address.Hex()[2:])
+	}
+	if !strings.HasPrefix(hex, m.prefix) || !strings.HasSuffix(hex, m.suffix) {
+		return false
+	}
+	return m.re == nil || m.re.MatchString(hex)
+}
+
+// Difficulty returns the expected number of addresses that must be generated to find a
+// match for the prefix and suffix.  Regular expressions are not taken in to account.
+func (m *VanityMatcher) Difficulty() float64 {
+	difficulty := math.Pow(16, float64(len(m.prefix)+len(m.suffix)))
+	if m.checksum {
+		// Each letter has an even chance of being upper- or lower-case in the checksum.
+		for _, c := range m.prefix + m.suffix {
+			if (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+				difficulty *= 2
+			}
+		}
+	}
+	return difficulty
+}
+
+// VanitySearch generates keys using the given number of workers until one is found whose
+// address matches, or the context is cancelled.  The number of keys generated so far is
+// kept in attempts, which can be read atomically while the search is running.
+func VanitySearch(ctx context.Context, matcher *VanityMatcher, workers int, attempts *uint64) (*ecdsa.PrivateKey, error) {
+	if workers < 1 {
+		return nil, errors.New("at least one worker is required")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan *ecdsa.PrivateKey, workers)
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				key, err := crypto.GenerateKey()
+				if err != nil {
+					errs <- err
+					return
+				}
+				atomic.AddUint64(attempts, 1)
+				if matcher.Match(crypto.PubkeyToAddress(key.PublicKey)) {
+					found <- key
+					return
+				}
+			}
+		}()
+	}
+
+	var key *ecdsa.PrivateKey
+	var err error
+	select {
+	case key = <-found:
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	cancel()
+	wg.Wait()
+	return key, err
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVanityMatcher(t *testing.T) {
+	// Checksummed form is 0x5FfC014343cd971B7eb70732021E26C35B744cc4.
+	address := common.HexToAddress("0x5ffc014343cd971b7eb70732021e26c35b744cc4")
+
+	tests := []struct {
+		name     string
+		prefix   string
+		suffix   string
+		pattern  string
+		checksum bool
+		err      string
+		match    bool
+	}{
+		{
+			name: "Empty",
+			err:  "no prefix, suffix or pattern supplied",
+		},
+		{
+			name:   "InvalidPrefix",
+			prefix: "0xzz",
+			err:    "invalid prefix zz",
+		},
+		{
+			name:   "InvalidSuffix",
+			suffix: "g",
+			err:    "invalid suffix g",
+		},
+		{
+			name:    "InvalidPattern",
+			pattern: "(",
+			err:     "invalid pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "Prefix",
+			prefix: "0x5FFC",
+			match:  true,
+		},
+		{
+			name:   "PrefixMismatch",
+			prefix: "5ffd",
+		},
+		{
+			name:   "Suffix",
+			suffix: "4CC4",
+			match:  true,
+		},
+		{
+			name:   "PrefixAndSuffix",
+			prefix: "5ffc",
+			suffix: "4cc4",
+			match:  true,
+		},
+		{
+			name:    "Pattern",
+			pattern: "^5f+c0",
+			match:   true,
+		},
+		{
+			name:     "Checksum",
+			prefix:   "5FfC",
+			suffix:   "4cc4",
+			checksum: true,
+			match:    true,
+		},
+		{
+			name:     "ChecksumMismatch",
+			prefix:   "5ffc",
+			checksum: true,
+		},
+		{
+			name:     "ChecksumPattern",
+			pattern:  "1B7eb",
+			checksum: true,
+			match:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matcher, err := NewVanityMatcher(test.prefix, test.suffix, test.pattern, test.checksum)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.match, matcher.Match(address))
+		})
+	}
+}
+
+func TestVanityMatcherDifficulty(t *testing.T) {
+	matcher, err := NewVanityMatcher("12", "", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, float64(256), matcher.Difficulty())
+
+	matcher, err = NewVanityMatcher("1a", "B", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, float64(16*16*16*4), matcher.Difficulty())
+}
+
+func TestVanitySearch(t *testing.T) {
+	matcher, err := NewVanityMatcher("a", "", "", false)
+	require.NoError(t, err)
+
+	var attempts uint64
+	key, err := VanitySearch(context.Background(), matcher, 4, &attempts)
+	require.NoError(t, err)
+	assert.True(t, matcher.Match(crypto.PubkeyToAddress(key.PublicKey)))
+	assert.NotZero(t, attempts)
+}
+
+func TestVanitySearchCancelled(t *testing.T) {
+	// Practically impossible to match.
+	matcher, err := NewVanityMatcher("0000000000000000", "", "", false)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var attempts uint64
+	_, err = VanitySearch(ctx, matcher, 2, &attempts)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/etherscan"
+)
+
+// Etherscan verifies contracts with Etherscan, or any service with a compatible API such as
+// Blockscout.
+type Etherscan struct {
+	client *etherscan.Client
+}
+
+// Submit submits the request for verification, returning the GUID of the verification.
+func (s *Etherscan) Submit(ctx context.Context, chainID *big.Int, request *Request) (string, error) {
+	id, err := s.client.Verify(ctx, chainID, &etherscan.Verification{
+		Address:              request.Address,
+		Name:                 request.Name,
+		CompilerVersion:      request.CompilerVersion,
+		Input:                request.Input,
+		ConstructorArguments: request.ConstructorArguments,
+	})
+	if errors.Is(err, etherscan.ErrAlreadyVerified) {
+		return "", ErrAlreadyVerified
+	}
+	return id, err
+}
+
+// Status returns the status of the verification with the given GUID.
+func (s *Etherscan) Status(ctx context.Context, chainID *big.Int, id string) (*Status, error) {
+	status, err := s.client.VerificationStatus(ctx, chainID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{
+		Completed: !status.Pending,
+		Verified:  status.Verified,
+		Message:   status.Message,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/sourcify"
+)
+
+// Sourcify verifies contracts with Sourcify.
+type Sourcify struct {
+	client *sourcify.Client
+}
+
+// Submit submits the request for verification, returning the ID of the verification job.
+func (s *Sourcify) Submit(ctx context.Context, chainID *big.Int, request *Request) (string, error) {
+	id, err := s.client.Verify(ctx, chainID, request.Address, request.Input, request.CompilerVersion, request.Name)
+	if errors.Is(err, sourcify.ErrAlreadyVerified) {
+		return "", ErrAlreadyVerified
+	}
+	return id, err
+}
+
+// Status returns the status of the verification job with the given ID.
+func (s *Sourcify) Status(ctx context.Context, _ *big.Int, id string) (*Status, error) {
+	job, err := s.client.Job(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !job.Completed:
+		return &Status{Message: "pending"}, nil
+	case job.Error != "":
+		return &Status{Completed: true, Message: job.Error}, nil
+	case job.Match != "":
+		return &Status{Completed: true, Verified: true, Message: job.Match}, nil
+	default:
+		return &Status{Completed: true, Message: "no match"}, nil
+	}
+}
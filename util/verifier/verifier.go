@@ -0,0 +1,125 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verifier submits contract source for verification to online services.
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethereal/v2/util/explorer"
+)
+
+// ErrAlreadyVerified is returned when the contract has already been verified.
+var ErrAlreadyVerified = errors.New("contract already verified")
+
+// Request is a request to verify a contract.
+type Request struct {
+	// Address is the address of the deployed contract.
+	Address common.Address
+	// Name is the fully qualified name of the contract, in the form path:Name.
+	Name string
+	// CompilerVersion is the full version of the compiler, for example v0.8.13+commit.abaa5c0e.
+	CompilerVersion string
+	// Input is the Solidity standard JSON input used to compile the contract.
+	Input json.RawMessage
+	// ConstructorArguments are the ABI-encoded arguments passed to the constructor.
+	ConstructorArguments []byte
+}
+
+// Status is the status of a verification.
+type Status struct {
+	// Completed is set once verification has finished, successfully or otherwise.
+	Completed bool
+	// Verified is set if verification succeeded.
+	Verified bool
+	// Message describes the status.
+	Message string
+}
+
+// Verifier verifies contract source.
+type Verifier interface {
+	// Submit submits the request for verification, returning an identifier with which to
+	// obtain the status of the verification.
+	Submit(ctx context.Context, chainID *big.Int, request *Request) (string, error)
+	// Status returns the status of the verification with the given identifier.
+	Status(ctx context.Context, chainID *big.Int, id string) (*Status, error)
+}
+
+// New creates a verifier given its name.  The key is the API key, used by Etherscan, and the URL
+// overrides the default endpoint of the verifier; it is required for Blockscout.
+func New(name string, key string, url string) (Verifier, error) {
+	etherscanClient, sourcifyClient, err := explorer.Clients(name, key, url)
+	if errors.Is(err, explorer.ErrUnknown) {
+		return nil, fmt.Errorf("unknown verifier %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sourcifyClient != nil {
+		return &Sourcify{client: sourcifyClient}, nil
+	}
+	return &Etherscan{client: etherscanClient}, nil
+}
+
+// Wait polls the status of the verification with the given identifier until it completes or
+// the context is done.
+func Wait(ctx context.Context, verifier Verifier, chainID *big.Int, id string, interval time.Duration) (*Status, error) {
+	for {
+		status, err := verifier.Status(ctx, chainID, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Report the timeout rather than the failed request it interrupted.
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if status.Completed {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// QualifiedName returns the fully qualified name of the contract in the standard JSON input.
+// If the name is not qualified with the path of its source it is qualified with the only
+// source in the input, as long as there is only one.
+func QualifiedName(input json.RawMessage, name string) (string, error) {
+	if strings.Contains(name, ":") {
+		return name, nil
+	}
+	parsed := struct {
+		Sources map[string]json.RawMessage `json:"sources"`
+	}{}
+	if err := json.Unmarshal(input, &parsed); err != nil {
+		return "", errors.Wrap(err, "invalid standard JSON input")
+	}
+	if len(parsed.Sources) != 1 {
+		return "", fmt.Errorf("contract name %s must be qualified with its source, in the form path:%s", name, name)
+	}
+	for path := range parsed.Sources {
+		return fmt.Sprintf("%s:%s", path, name), nil
+	}
+	return "", nil
+}
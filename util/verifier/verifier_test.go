@@ -0,0 +1,193 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInput = `{"language":"Solidity","sources":{"contracts/Test.sol":{"content":"contract Test {}"}},"settings":{}}`
+
+var testAddress = common.HexToAddress("0x5FfC014343cd971B7eb70732021E26C35B744cc4")
+
+func testRequest() *Request {
+	return &Request{
+		Address:              testAddress,
+		Name:                 "contracts/Test.sol:Test",
+		CompilerVersion:      "v0.8.13+commit.abaa5c0e",
+		Input:                json.RawMessage(testInput),
+		ConstructorArguments: []byte{0x01, 0x02},
+	}
+}
+
+func TestEtherscan(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.Form.Get("action") {
+		case "verifysourcecode":
+			require.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, testAddress.Hex(), r.PostForm.Get("contractaddress"))
+			assert.Equal(t, testInput, r.PostForm.Get("sourceCode"))
+			assert.Equal(t, "contracts/Test.sol:Test", r.PostForm.Get("contractname"))
+			assert.Equal(t, "v0.8.13+commit.abaa5c0e", r.PostForm.Get("compilerversion"))
+			assert.Equal(t, "0102", r.PostForm.Get("constructorArguements"))
+			assert.Equal(t, "key", r.PostForm.Get("apikey"))
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":"guid"}`)
+		case "checkverifystatus":
+			require.Equal(t, "guid", r.Form.Get("guid"))
+			polls++
+			if polls == 1 {
+				fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Pending in queue"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":"Pass - Verified"}`)
+		}
+	}))
+	defer server.Close()
+
+	verifier, err := New("etherscan", "key", server.URL)
+	require.NoError(t, err)
+	id, err := verifier.Submit(context.Background(), big.NewInt(1), testRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "guid", id)
+
+	status, err := Wait(context.Background(), verifier, big.NewInt(1), id, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, status.Verified)
+	assert.Equal(t, "Pass - Verified", status.Message)
+	assert.Equal(t, 2, polls)
+}
+
+func TestEtherscanFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.Form.Get("action") {
+		case "verifysourcecode":
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Contract source code already verified"}`)
+		case "checkverifystatus":
+			fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Fail - Unable to verify"}`)
+		}
+	}))
+	defer server.Close()
+
+	verifier, err := New("blockscout", "", server.URL)
+	require.NoError(t, err)
+	_, err = verifier.Submit(context.Background(), big.NewInt(1), testRequest())
+	assert.Equal(t, ErrAlreadyVerified, err)
+
+	status, err := verifier.Status(context.Background(), big.NewInt(1), "guid")
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.False(t, status.Verified)
+	assert.Equal(t, "Fail - Unable to verify", status.Message)
+}
+
+func TestSourcify(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/v2/verify/5/%s", testAddress.Hex()):
+			require.Equal(t, http.MethodPost, r.Method)
+			data, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			submission := struct {
+				StdJSONInput       json.RawMessage `json:"stdJsonInput"`
+				CompilerVersion    string          `json:"compilerVersion"`
+				ContractIdentifier string          `json:"contractIdentifier"`
+			}{}
+			require.NoError(t, json.Unmarshal(data, &submission))
+			assert.JSONEq(t, testInput, string(submission.StdJSONInput))
+			assert.Equal(t, "0.8.13+commit.abaa5c0e", submission.CompilerVersion)
+			assert.Equal(t, "contracts/Test.sol:Test", submission.ContractIdentifier)
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"verificationId":"job"}`)
+		case "/v2/verify/job":
+			polls++
+			if polls == 1 {
+				fmt.Fprint(w, `{"isJobCompleted":false,"verificationId":"job","contract":{"match":null}}`)
+				return
+			}
+			fmt.Fprint(w, `{"isJobCompleted":true,"verificationId":"job","contract":{"match":"exact_match"}}`)
+		case fmt.Sprintf("/v2/verify/5/%s", common.Address{}.Hex()):
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"customCode":"already_verified","message":"The contract is already verified"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	verifier, err := New("sourcify", "", server.URL+"/")
+	require.NoError(t, err)
+	id, err := verifier.Submit(context.Background(), big.NewInt(5), testRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "job", id)
+
+	status, err := Wait(context.Background(), verifier, big.NewInt(5), id, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, status.Verified)
+	assert.Equal(t, "exact_match", status.Message)
+
+	request := testRequest()
+	request.Address = common.Address{}
+	_, err = verifier.Submit(context.Background(), big.NewInt(5), request)
+	assert.Equal(t, ErrAlreadyVerified, err)
+}
+
+func TestWaitTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"isJobCompleted":false}`)
+	}))
+	defer server.Close()
+
+	verifier, err := New("sourcify", "", server.URL)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = Wait(ctx, verifier, big.NewInt(5), "job", 5*time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestQualifiedName(t *testing.T) {
+	name, err := QualifiedName(json.RawMessage(testInput), "Test")
+	require.NoError(t, err)
+	assert.Equal(t, "contracts/Test.sol:Test", name)
+
+	name, err = QualifiedName(json.RawMessage(testInput), "other/Test.sol:Test")
+	require.NoError(t, err)
+	assert.Equal(t, "other/Test.sol:Test", name)
+
+	_, err = QualifiedName(json.RawMessage(`{"sources":{"A.sol":{},"B.sol":{}}}`), "Test")
+	assert.EqualError(t, err, "contract name Test must be qualified with its source, in the form path:Test")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("blockscout", "", "")
+	assert.EqualError(t, err, "blockscout requires a URL")
+	_, err = New("unknown", "", "")
+	assert.EqualError(t, err, "unknown verifier unknown")
+}